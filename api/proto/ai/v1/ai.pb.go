@@ -6985,7 +6985,7 @@ var file_ai_v1_ai_proto_rawDesc = []byte{
 	0x5f, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x53, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x4e, 0x41,
 	0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f,
 	0x4e, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x05, 0x32, 0xe9, 0x0f, 0x0a, 0x14,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x05, 0x32, 0xb5, 0x10, 0x0a, 0x14,
 	0x41, 0x49, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72,
 	0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
 	0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x70, 0x65, 0x6e, 0x66,
@@ -7101,29 +7101,34 @@ var file_ai_v1_ai_proto_rawDesc = []byte{
 	0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c,
 	0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
-	0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x12, 0x23, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64,
-	0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x65,
-	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x65,
-	0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d, 0x6d,
-	0x61, 0x72, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x54, 0x0a, 0x0b, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44,
-	0x12, 0x21, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xac, 0x01, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x2e,
-	0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x07, 0x41,
-	0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x6a, 0x61, 0x6d, 0x65, 0x73, 0x62,
-	0x72, 0x6f, 0x77, 0x6e, 0x2f, 0x70, 0x65, 0x6e, 0x66, 0x2d, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x70,
-	0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x69,
-	0x76, 0x31, 0xa2, 0x02, 0x03, 0x50, 0x41, 0x58, 0xaa, 0x02, 0x0d, 0x50, 0x65, 0x6e, 0x66, 0x6f,
-	0x6c, 0x64, 0x2e, 0x41, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0d, 0x50, 0x65, 0x6e, 0x66, 0x6f,
-	0x6c, 0x64, 0x5c, 0x41, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x19, 0x50, 0x65, 0x6e, 0x66, 0x6f,
-	0x6c, 0x64, 0x5c, 0x41, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0f, 0x50, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x3a, 0x3a,
-	0x41, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x1b, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x5a, 0x0a, 0x0d, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x42, 0x79,
+	0x49, 0x44, 0x12, 0x23, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c,
+	0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a,
+	0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a,
+	0x0b, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x12, 0x21, 0x2e, 0x70,
+	0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x70, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0xac, 0x01, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x65, 0x6e, 0x66,
+	0x6f, 0x6c, 0x64, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x07, 0x41, 0x69, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x6a, 0x61, 0x6d, 0x65, 0x73, 0x62, 0x72, 0x6f, 0x77, 0x6e,
+	0x2f, 0x70, 0x65, 0x6e, 0x66, 0x2d, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x69, 0x76, 0x31, 0xa2, 0x02,
+	0x03, 0x50, 0x41, 0x58, 0xaa, 0x02, 0x0d, 0x50, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x2e, 0x41,
+	0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0d, 0x50, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x5c, 0x41,
+	0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x19, 0x50, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x5c, 0x41,
+	0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x0f, 0x50, 0x65, 0x6e, 0x66, 0x6f, 0x6c, 0x64, 0x3a, 0x3a, 0x41, 0x69, 0x3a, 0x3a,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -7276,32 +7281,34 @@ var file_ai_v1_ai_proto_depIdxs = []int32{
 	66, // 60: penfold.ai.v1.AICoordinatorService.ListAvailableModels:input_type -> penfold.ai.v1.ListAvailableModelsRequest
 	68, // 61: penfold.ai.v1.AICoordinatorService.TestStage:input_type -> penfold.ai.v1.TestStageRequest
 	47, // 62: penfold.ai.v1.AICoordinatorService.Query:input_type -> penfold.ai.v1.QueryRequest
-	50, // 63: penfold.ai.v1.AICoordinatorService.SummarizeByID:input_type -> penfold.ai.v1.SummarizeByIDRequest
-	52, // 64: penfold.ai.v1.AICoordinatorService.AnalyzeByID:input_type -> penfold.ai.v1.AnalyzeByIDRequest
-	6,  // 65: penfold.ai.v1.AICoordinatorService.GenerateEmbedding:output_type -> penfold.ai.v1.EmbeddingResponse
-	8,  // 66: penfold.ai.v1.AICoordinatorService.GenerateSummary:output_type -> penfold.ai.v1.SummaryResponse
-	11, // 67: penfold.ai.v1.AICoordinatorService.ExtractAssertions:output_type -> penfold.ai.v1.AssertionResponse
-	14, // 68: penfold.ai.v1.AICoordinatorService.ClassifyContent:output_type -> penfold.ai.v1.ClassifyContentResponse
-	16, // 69: penfold.ai.v1.AICoordinatorService.TriageContent:output_type -> penfold.ai.v1.TriageContentResponse
-	22, // 70: penfold.ai.v1.AICoordinatorService.ExtractEntities:output_type -> penfold.ai.v1.ExtractEntitiesResponse
-	24, // 71: penfold.ai.v1.AICoordinatorService.DeepAnalyze:output_type -> penfold.ai.v1.DeepAnalyzeResponse
-	33, // 72: penfold.ai.v1.AICoordinatorService.GetModelStatus:output_type -> penfold.ai.v1.GetModelStatusResponse
-	35, // 73: penfold.ai.v1.AICoordinatorService.ListModels:output_type -> penfold.ai.v1.ListModelsResponse
-	37, // 74: penfold.ai.v1.AICoordinatorService.RegisterModel:output_type -> penfold.ai.v1.RegisterModelResponse
-	39, // 75: penfold.ai.v1.AICoordinatorService.UpdateModel:output_type -> penfold.ai.v1.UpdateModelResponse
-	41, // 76: penfold.ai.v1.AICoordinatorService.DeleteModel:output_type -> penfold.ai.v1.DeleteModelResponse
-	44, // 77: penfold.ai.v1.AICoordinatorService.GetRoutingRules:output_type -> penfold.ai.v1.GetRoutingRulesResponse
-	46, // 78: penfold.ai.v1.AICoordinatorService.UpdateRoutingRule:output_type -> penfold.ai.v1.UpdateRoutingRuleResponse
-	60, // 79: penfold.ai.v1.AICoordinatorService.GetStageConfig:output_type -> penfold.ai.v1.GetStageConfigResponse
-	62, // 80: penfold.ai.v1.AICoordinatorService.SetStageConfig:output_type -> penfold.ai.v1.SetStageConfigResponse
-	64, // 81: penfold.ai.v1.AICoordinatorService.ResetStageConfig:output_type -> penfold.ai.v1.ResetStageConfigResponse
-	67, // 82: penfold.ai.v1.AICoordinatorService.ListAvailableModels:output_type -> penfold.ai.v1.ListAvailableModelsResponse
-	69, // 83: penfold.ai.v1.AICoordinatorService.TestStage:output_type -> penfold.ai.v1.TestStageResponse
-	49, // 84: penfold.ai.v1.AICoordinatorService.Query:output_type -> penfold.ai.v1.QueryResponse
-	51, // 85: penfold.ai.v1.AICoordinatorService.SummarizeByID:output_type -> penfold.ai.v1.SummarizeByIDResponse
-	57, // 86: penfold.ai.v1.AICoordinatorService.AnalyzeByID:output_type -> penfold.ai.v1.AnalyzeByIDResponse
-	65, // [65:87] is the sub-list for method output_type
-	43, // [43:65] is the sub-list for method input_type
+	47, // 63: penfold.ai.v1.AICoordinatorService.QueryStream:input_type -> penfold.ai.v1.QueryRequest
+	50, // 64: penfold.ai.v1.AICoordinatorService.SummarizeByID:input_type -> penfold.ai.v1.SummarizeByIDRequest
+	52, // 65: penfold.ai.v1.AICoordinatorService.AnalyzeByID:input_type -> penfold.ai.v1.AnalyzeByIDRequest
+	6,  // 66: penfold.ai.v1.AICoordinatorService.GenerateEmbedding:output_type -> penfold.ai.v1.EmbeddingResponse
+	8,  // 67: penfold.ai.v1.AICoordinatorService.GenerateSummary:output_type -> penfold.ai.v1.SummaryResponse
+	11, // 68: penfold.ai.v1.AICoordinatorService.ExtractAssertions:output_type -> penfold.ai.v1.AssertionResponse
+	14, // 69: penfold.ai.v1.AICoordinatorService.ClassifyContent:output_type -> penfold.ai.v1.ClassifyContentResponse
+	16, // 70: penfold.ai.v1.AICoordinatorService.TriageContent:output_type -> penfold.ai.v1.TriageContentResponse
+	22, // 71: penfold.ai.v1.AICoordinatorService.ExtractEntities:output_type -> penfold.ai.v1.ExtractEntitiesResponse
+	24, // 72: penfold.ai.v1.AICoordinatorService.DeepAnalyze:output_type -> penfold.ai.v1.DeepAnalyzeResponse
+	33, // 73: penfold.ai.v1.AICoordinatorService.GetModelStatus:output_type -> penfold.ai.v1.GetModelStatusResponse
+	35, // 74: penfold.ai.v1.AICoordinatorService.ListModels:output_type -> penfold.ai.v1.ListModelsResponse
+	37, // 75: penfold.ai.v1.AICoordinatorService.RegisterModel:output_type -> penfold.ai.v1.RegisterModelResponse
+	39, // 76: penfold.ai.v1.AICoordinatorService.UpdateModel:output_type -> penfold.ai.v1.UpdateModelResponse
+	41, // 77: penfold.ai.v1.AICoordinatorService.DeleteModel:output_type -> penfold.ai.v1.DeleteModelResponse
+	44, // 78: penfold.ai.v1.AICoordinatorService.GetRoutingRules:output_type -> penfold.ai.v1.GetRoutingRulesResponse
+	46, // 79: penfold.ai.v1.AICoordinatorService.UpdateRoutingRule:output_type -> penfold.ai.v1.UpdateRoutingRuleResponse
+	60, // 80: penfold.ai.v1.AICoordinatorService.GetStageConfig:output_type -> penfold.ai.v1.GetStageConfigResponse
+	62, // 81: penfold.ai.v1.AICoordinatorService.SetStageConfig:output_type -> penfold.ai.v1.SetStageConfigResponse
+	64, // 82: penfold.ai.v1.AICoordinatorService.ResetStageConfig:output_type -> penfold.ai.v1.ResetStageConfigResponse
+	67, // 83: penfold.ai.v1.AICoordinatorService.ListAvailableModels:output_type -> penfold.ai.v1.ListAvailableModelsResponse
+	69, // 84: penfold.ai.v1.AICoordinatorService.TestStage:output_type -> penfold.ai.v1.TestStageResponse
+	49, // 85: penfold.ai.v1.AICoordinatorService.Query:output_type -> penfold.ai.v1.QueryResponse
+	49, // 86: penfold.ai.v1.AICoordinatorService.QueryStream:output_type -> penfold.ai.v1.QueryResponse
+	51, // 87: penfold.ai.v1.AICoordinatorService.SummarizeByID:output_type -> penfold.ai.v1.SummarizeByIDResponse
+	57, // 88: penfold.ai.v1.AICoordinatorService.AnalyzeByID:output_type -> penfold.ai.v1.AnalyzeByIDResponse
+	66, // [66:89] is the sub-list for method output_type
+	43, // [43:66] is the sub-list for method input_type
 	43, // [43:43] is the sub-list for extension type_name
 	43, // [43:43] is the sub-list for extension extendee
 	0,  // [0:43] is the sub-list for field type_name
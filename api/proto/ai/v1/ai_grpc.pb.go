@@ -43,6 +43,7 @@ const (
 	AICoordinatorService_ListAvailableModels_FullMethodName = "/penfold.ai.v1.AICoordinatorService/ListAvailableModels"
 	AICoordinatorService_TestStage_FullMethodName           = "/penfold.ai.v1.AICoordinatorService/TestStage"
 	AICoordinatorService_Query_FullMethodName               = "/penfold.ai.v1.AICoordinatorService/Query"
+	AICoordinatorService_QueryStream_FullMethodName         = "/penfold.ai.v1.AICoordinatorService/QueryStream"
 	AICoordinatorService_SummarizeByID_FullMethodName       = "/penfold.ai.v1.AICoordinatorService/SummarizeByID"
 	AICoordinatorService_AnalyzeByID_FullMethodName         = "/penfold.ai.v1.AICoordinatorService/AnalyzeByID"
 )
@@ -114,6 +115,10 @@ type AICoordinatorServiceClient interface {
 	// Query performs RAG-style question answering over the knowledge base.
 	// Searches relevant content and generates an answer using an LLM.
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// QueryStream performs a query like Query, streaming the answer as it is
+	// generated. Each message carries the next fragment of the answer; sources,
+	// model and token counts may arrive on any message, typically the last.
+	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryResponse], error)
 	// SummarizeByID generates a summary of content identified by its ID.
 	// Fetches the content and produces a summary with key points.
 	SummarizeByID(ctx context.Context, in *SummarizeByIDRequest, opts ...grpc.CallOption) (*SummarizeByIDResponse, error)
@@ -330,6 +335,25 @@ func (c *aICoordinatorServiceClient) Query(ctx context.Context, in *QueryRequest
 	return out, nil
 }
 
+func (c *aICoordinatorServiceClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AICoordinatorService_ServiceDesc.Streams[0], AICoordinatorService_QueryStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[QueryRequest, QueryResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AICoordinatorService_QueryStreamClient = grpc.ServerStreamingClient[QueryResponse]
+
 func (c *aICoordinatorServiceClient) SummarizeByID(ctx context.Context, in *SummarizeByIDRequest, opts ...grpc.CallOption) (*SummarizeByIDResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SummarizeByIDResponse)
@@ -417,6 +441,10 @@ type AICoordinatorServiceServer interface {
 	// Query performs RAG-style question answering over the knowledge base.
 	// Searches relevant content and generates an answer using an LLM.
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+	// QueryStream performs a query like Query, streaming the answer as it is
+	// generated. Each message carries the next fragment of the answer; sources,
+	// model and token counts may arrive on any message, typically the last.
+	QueryStream(*QueryRequest, grpc.ServerStreamingServer[QueryResponse]) error
 	// SummarizeByID generates a summary of content identified by its ID.
 	// Fetches the content and produces a summary with key points.
 	SummarizeByID(context.Context, *SummarizeByIDRequest) (*SummarizeByIDResponse, error)
@@ -493,6 +521,9 @@ func (UnimplementedAICoordinatorServiceServer) TestStage(context.Context, *TestS
 func (UnimplementedAICoordinatorServiceServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
 }
+func (UnimplementedAICoordinatorServiceServer) QueryStream(*QueryRequest, grpc.ServerStreamingServer[QueryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
+}
 func (UnimplementedAICoordinatorServiceServer) SummarizeByID(context.Context, *SummarizeByIDRequest) (*SummarizeByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SummarizeByID not implemented")
 }
@@ -880,6 +911,17 @@ func _AICoordinatorService_Query_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AICoordinatorService_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AICoordinatorServiceServer).QueryStream(m, &grpc.GenericServerStream[QueryRequest, QueryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AICoordinatorService_QueryStreamServer = grpc.ServerStreamingServer[QueryResponse]
+
 func _AICoordinatorService_SummarizeByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SummarizeByIDRequest)
 	if err := dec(in); err != nil {
@@ -1012,6 +1054,12 @@ var AICoordinatorService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _AICoordinatorService_AnalyzeByID_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryStream",
+			Handler:       _AICoordinatorService_QueryStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "ai/v1/ai.proto",
 }
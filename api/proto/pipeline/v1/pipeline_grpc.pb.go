@@ -28,6 +28,7 @@ const (
 	PipelineService_KickProcessing_FullMethodName               = "/penfold.pipeline.v1.PipelineService/KickProcessing"
 	PipelineService_RetryFailed_FullMethodName                  = "/penfold.pipeline.v1.PipelineService/RetryFailed"
 	PipelineService_GetQueueStatus_FullMethodName               = "/penfold.pipeline.v1.PipelineService/GetQueueStatus"
+	PipelineService_RequeueStuckItems_FullMethodName            = "/penfold.pipeline.v1.PipelineService/RequeueStuckItems"
 	PipelineService_GetPipelineHealth_FullMethodName            = "/penfold.pipeline.v1.PipelineService/GetPipelineHealth"
 	PipelineService_GetContentTrace_FullMethodName              = "/penfold.pipeline.v1.PipelineService/GetContentTrace"
 	PipelineService_ListDeletedSources_FullMethodName           = "/penfold.pipeline.v1.PipelineService/ListDeletedSources"
@@ -103,6 +104,9 @@ type PipelineServiceClient interface {
 	RetryFailed(ctx context.Context, in *RetryFailedRequest, opts ...grpc.CallOption) (*RetryFailedResponse, error)
 	// GetQueueStatus retrieves processing queue depths and rates.
 	GetQueueStatus(ctx context.Context, in *GetQueueStatusRequest, opts ...grpc.CallOption) (*GetQueueStatusResponse, error)
+	// RequeueStuckItems requeues the pending items of one stage that have
+	// waited longer than a threshold.
+	RequeueStuckItems(ctx context.Context, in *RequeueStuckItemsRequest, opts ...grpc.CallOption) (*RequeueStuckItemsResponse, error)
 	// GetPipelineHealth performs a comprehensive pipeline health check.
 	GetPipelineHealth(ctx context.Context, in *GetPipelineHealthRequest, opts ...grpc.CallOption) (*GetPipelineHealthResponse, error)
 	// GetContentTrace retrieves full processing history for a content item.
@@ -284,6 +288,16 @@ func (c *pipelineServiceClient) GetQueueStatus(ctx context.Context, in *GetQueue
 	return out, nil
 }
 
+func (c *pipelineServiceClient) RequeueStuckItems(ctx context.Context, in *RequeueStuckItemsRequest, opts ...grpc.CallOption) (*RequeueStuckItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueStuckItemsResponse)
+	err := c.cc.Invoke(ctx, PipelineService_RequeueStuckItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pipelineServiceClient) GetPipelineHealth(ctx context.Context, in *GetPipelineHealthRequest, opts ...grpc.CallOption) (*GetPipelineHealthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPipelineHealthResponse)
@@ -852,6 +866,9 @@ type PipelineServiceServer interface {
 	RetryFailed(context.Context, *RetryFailedRequest) (*RetryFailedResponse, error)
 	// GetQueueStatus retrieves processing queue depths and rates.
 	GetQueueStatus(context.Context, *GetQueueStatusRequest) (*GetQueueStatusResponse, error)
+	// RequeueStuckItems requeues the pending items of one stage that have
+	// waited longer than a threshold.
+	RequeueStuckItems(context.Context, *RequeueStuckItemsRequest) (*RequeueStuckItemsResponse, error)
 	// GetPipelineHealth performs a comprehensive pipeline health check.
 	GetPipelineHealth(context.Context, *GetPipelineHealthRequest) (*GetPipelineHealthResponse, error)
 	// GetContentTrace retrieves full processing history for a content item.
@@ -991,6 +1008,9 @@ func (UnimplementedPipelineServiceServer) RetryFailed(context.Context, *RetryFai
 func (UnimplementedPipelineServiceServer) GetQueueStatus(context.Context, *GetQueueStatusRequest) (*GetQueueStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStatus not implemented")
 }
+func (UnimplementedPipelineServiceServer) RequeueStuckItems(context.Context, *RequeueStuckItemsRequest) (*RequeueStuckItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueStuckItems not implemented")
+}
 func (UnimplementedPipelineServiceServer) GetPipelineHealth(context.Context, *GetPipelineHealthRequest) (*GetPipelineHealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineHealth not implemented")
 }
@@ -1285,6 +1305,24 @@ func _PipelineService_GetQueueStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PipelineService_RequeueStuckItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueStuckItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).RequeueStuckItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_RequeueStuckItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).RequeueStuckItems(ctx, req.(*RequeueStuckItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PipelineService_GetPipelineHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPipelineHealthRequest)
 	if err := dec(in); err != nil {
@@ -2306,6 +2344,10 @@ var PipelineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetQueueStatus",
 			Handler:    _PipelineService_GetQueueStatus_Handler,
 		},
+		{
+			MethodName: "RequeueStuckItems",
+			Handler:    _PipelineService_RequeueStuckItems_Handler,
+		},
 		{
 			MethodName: "GetPipelineHealth",
 			Handler:    _PipelineService_GetPipelineHealth_Handler,
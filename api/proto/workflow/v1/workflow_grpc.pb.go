@@ -23,10 +23,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WorkflowService_ListWorkflows_FullMethodName     = "/workflow.v1.WorkflowService/ListWorkflows"
-	WorkflowService_GetWorkflowStatus_FullMethodName = "/workflow.v1.WorkflowService/GetWorkflowStatus"
-	WorkflowService_CancelWorkflow_FullMethodName    = "/workflow.v1.WorkflowService/CancelWorkflow"
-	WorkflowService_TerminateWorkflow_FullMethodName = "/workflow.v1.WorkflowService/TerminateWorkflow"
+	WorkflowService_ListWorkflows_FullMethodName      = "/workflow.v1.WorkflowService/ListWorkflows"
+	WorkflowService_GetWorkflowStatus_FullMethodName  = "/workflow.v1.WorkflowService/GetWorkflowStatus"
+	WorkflowService_CancelWorkflow_FullMethodName     = "/workflow.v1.WorkflowService/CancelWorkflow"
+	WorkflowService_TerminateWorkflow_FullMethodName  = "/workflow.v1.WorkflowService/TerminateWorkflow"
+	WorkflowService_GetWorkflowHistory_FullMethodName = "/workflow.v1.WorkflowService/GetWorkflowHistory"
 )
 
 // WorkflowServiceClient is the client API for WorkflowService service.
@@ -43,6 +44,8 @@ type WorkflowServiceClient interface {
 	CancelWorkflow(ctx context.Context, in *CancelWorkflowRequest, opts ...grpc.CallOption) (*CancelWorkflowResponse, error)
 	// TerminateWorkflow forcefully terminates a workflow.
 	TerminateWorkflow(ctx context.Context, in *TerminateWorkflowRequest, opts ...grpc.CallOption) (*TerminateWorkflowResponse, error)
+	// GetWorkflowHistory retrieves a page of a workflow's event history.
+	GetWorkflowHistory(ctx context.Context, in *GetWorkflowHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowHistoryResponse, error)
 }
 
 type workflowServiceClient struct {
@@ -93,6 +96,16 @@ func (c *workflowServiceClient) TerminateWorkflow(ctx context.Context, in *Termi
 	return out, nil
 }
 
+func (c *workflowServiceClient) GetWorkflowHistory(ctx context.Context, in *GetWorkflowHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkflowHistoryResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_GetWorkflowHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkflowServiceServer is the server API for WorkflowService service.
 // All implementations must embed UnimplementedWorkflowServiceServer
 // for forward compatibility.
@@ -107,6 +120,8 @@ type WorkflowServiceServer interface {
 	CancelWorkflow(context.Context, *CancelWorkflowRequest) (*CancelWorkflowResponse, error)
 	// TerminateWorkflow forcefully terminates a workflow.
 	TerminateWorkflow(context.Context, *TerminateWorkflowRequest) (*TerminateWorkflowResponse, error)
+	// GetWorkflowHistory retrieves a page of a workflow's event history.
+	GetWorkflowHistory(context.Context, *GetWorkflowHistoryRequest) (*GetWorkflowHistoryResponse, error)
 	mustEmbedUnimplementedWorkflowServiceServer()
 }
 
@@ -129,6 +144,9 @@ func (UnimplementedWorkflowServiceServer) CancelWorkflow(context.Context, *Cance
 func (UnimplementedWorkflowServiceServer) TerminateWorkflow(context.Context, *TerminateWorkflowRequest) (*TerminateWorkflowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TerminateWorkflow not implemented")
 }
+func (UnimplementedWorkflowServiceServer) GetWorkflowHistory(context.Context, *GetWorkflowHistoryRequest) (*GetWorkflowHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowHistory not implemented")
+}
 func (UnimplementedWorkflowServiceServer) mustEmbedUnimplementedWorkflowServiceServer() {}
 func (UnimplementedWorkflowServiceServer) testEmbeddedByValue()                         {}
 
@@ -222,6 +240,24 @@ func _WorkflowService_TerminateWorkflow_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowService_GetWorkflowHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServiceServer).GetWorkflowHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowService_GetWorkflowHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServiceServer).GetWorkflowHistory(ctx, req.(*GetWorkflowHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkflowService_ServiceDesc is the grpc.ServiceDesc for WorkflowService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -245,6 +281,10 @@ var WorkflowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TerminateWorkflow",
 			Handler:    _WorkflowService_TerminateWorkflow_Handler,
 		},
+		{
+			MethodName: "GetWorkflowHistory",
+			Handler:    _WorkflowService_GetWorkflowHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "workflow/v1/workflow.proto",
@@ -3,16 +3,21 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // AIClient manages the connection to the Penfold AI service through the Gateway.
@@ -55,9 +60,9 @@ func (c *AIClient) Connect(ctx context.Context) error {
 	dialOpts := c.buildDialOptions()
 
 	// Establish connection.
-	conn, err := grpc.DialContext(connectCtx, c.serverAddr, dialOpts...)
+	conn, err := Dial(connectCtx, "AI service at "+c.serverAddr, c.serverAddr, dialOpts...)
 	if err != nil {
-		return fmt.Errorf("connecting to AI service at %s: %w", c.serverAddr, err)
+		return err
 	}
 
 	c.conn = conn
@@ -70,11 +75,6 @@ func (c *AIClient) Connect(ctx context.Context) error {
 // buildDialOptions constructs the gRPC dial options from client configuration.
 func (c *AIClient) buildDialOptions() []grpc.DialOption {
 	opts := []grpc.DialOption{
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                c.options.KeepaliveTime,
-			Timeout:             c.options.KeepaliveTimeout,
-			PermitWithoutStream: true,
-		}),
 		grpc.WithDefaultCallOptions(
 			grpc.WaitForReady(true),
 		),
@@ -83,6 +83,7 @@ func (c *AIClient) buildDialOptions() []grpc.DialOption {
 		// which can cause confusing hangs when the server is unreachable.
 		grpc.WithBlock(),
 	}
+	opts = append(opts, messageDialOptions(c.options)...)
 
 	// Configure credentials.
 	if c.options.Insecure {
@@ -94,6 +95,15 @@ func (c *AIClient) buildDialOptions() []grpc.DialOption {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	if c.options.RequestTimeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(requestTimeoutUnaryInterceptor(c.options.RequestTimeout)))
+	}
+	opts = append(opts, retryDialOptions(c.options)...)
+
+	if c.options.Debug {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(os.Stderr, c.options.DebugTrace)))
+	}
+
 	return opts
 }
 
@@ -147,8 +157,22 @@ type QueryRequest struct {
 	Model        string
 	MaxTokens    int32
 	Temperature  float32
+
+	// PinnedContext is content the answer should be grounded in, in
+	// addition to whatever the server retrieves.
+	PinnedContext []QueryContext
+}
+
+// QueryContext is a content item pinned as context for a query.
+type QueryContext struct {
+	ContentID string
+	Title     string
+	Text      string
 }
 
+// maxPinnedContextChars caps how much of each pinned item's text is sent.
+const maxPinnedContextChars = 8000
+
 // QueryResponse represents the response from a query operation.
 type QueryResponse struct {
 	ResponseID   string
@@ -180,10 +204,116 @@ func (c *AIClient) Query(ctx context.Context, req *QueryRequest) (*QueryResponse
 	}
 
 	ctx = c.contextWithTenant(ctx, req.TenantID)
+	protoReq := buildQueryProtoRequest(req)
 
-	// Build the proto request.
+	// Apply a default timeout only if the parent context has no deadline.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+	}
+
+	resp, err := client.Query(ctx, protoReq)
+	if err != nil {
+		return nil, fmt.Errorf("query request failed: %w", err)
+	}
+
+	return c.convertQueryResponse(resp), nil
+}
+
+// ErrStreamingUnsupported is returned by QueryStream when the server does not
+// implement the streaming query RPC. Callers should fall back to Query.
+var ErrStreamingUnsupported = errors.New("server does not support streaming queries")
+
+// QueryStream performs a query like Query, calling onChunk with each fragment
+// of the answer as it arrives. It returns the complete response once the
+// stream ends. If the server has no streaming RPC, it returns
+// ErrStreamingUnsupported before onChunk is ever called.
+func (c *AIClient) QueryStream(ctx context.Context, req *QueryRequest, onChunk func(string) error) (*QueryResponse, error) {
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("AI client not connected")
+	}
+
+	ctx = c.contextWithTenant(ctx, req.TenantID)
+
+	// Apply a default timeout only if the parent context has no deadline.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+	}
+
+	stream, err := client.QueryStream(ctx, buildQueryProtoRequest(req))
+	if err != nil {
+		return nil, queryStreamError(err)
+	}
+
+	result := &QueryResponse{}
+	received := false
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			if !received {
+				return nil, queryStreamError(err)
+			}
+			return nil, fmt.Errorf("query stream failed: %w", err)
+		}
+		received = true
+
+		chunk := c.convertQueryResponse(msg)
+		if chunk.Answer != "" {
+			result.Answer += chunk.Answer
+			if err := onChunk(chunk.Answer); err != nil {
+				return nil, err
+			}
+		}
+		mergeQueryResponse(result, chunk)
+	}
+
+	return result, nil
+}
+
+// queryStreamError maps an error from opening a query stream, reporting an
+// unimplemented RPC as ErrStreamingUnsupported.
+func queryStreamError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return ErrStreamingUnsupported
+	}
+	return fmt.Errorf("query stream failed: %w", err)
+}
+
+// mergeQueryResponse copies the non-answer fields set on a streamed chunk
+// into result.
+func mergeQueryResponse(result, chunk *QueryResponse) {
+	if chunk.ResponseID != "" {
+		result.ResponseID = chunk.ResponseID
+	}
+	if chunk.ModelUsed != "" {
+		result.ModelUsed = chunk.ModelUsed
+	}
+	if chunk.InputTokens > 0 {
+		result.InputTokens = chunk.InputTokens
+	}
+	if chunk.OutputTokens > 0 {
+		result.OutputTokens = chunk.OutputTokens
+	}
+	if chunk.LatencyMs > 0 {
+		result.LatencyMs = chunk.LatencyMs
+	}
+	result.Sources = append(result.Sources, chunk.Sources...)
+}
+
+// buildQueryProtoRequest converts a QueryRequest to its proto form.
+func buildQueryProtoRequest(req *QueryRequest) *aiv1.QueryRequest {
 	protoReq := &aiv1.QueryRequest{
-		Question: req.Question,
+		Question: questionWithPinnedContext(req),
 	}
 	if req.TenantID != "" {
 		protoReq.TenantId = &req.TenantID
@@ -200,20 +330,35 @@ func (c *AIClient) Query(ctx context.Context, req *QueryRequest) (*QueryResponse
 	if req.Temperature > 0 {
 		protoReq.Temperature = &req.Temperature
 	}
+	return protoReq
+}
 
-	// Apply a default timeout only if the parent context has no deadline.
-	if _, ok := ctx.Deadline(); !ok {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, 10*time.Minute)
-		defer cancel()
+// questionWithPinnedContext prefixes the question with the text of each
+// pinned content item. The Query RPC has no separate context field, so pinned
+// content travels in the question itself.
+func questionWithPinnedContext(req *QueryRequest) string {
+	if len(req.PinnedContext) == 0 {
+		return req.Question
 	}
 
-	resp, err := client.Query(ctx, protoReq)
-	if err != nil {
-		return nil, fmt.Errorf("query request failed: %w", err)
-	}
+	var sb strings.Builder
+	sb.WriteString("Answer the question using the following content as the primary context.\n\n")
+	for _, c := range req.PinnedContext {
+		sb.WriteString("--- " + c.ContentID)
+		if c.Title != "" {
+			sb.WriteString(" (" + c.Title + ")")
+		}
+		sb.WriteString(" ---\n")
 
-	return c.convertQueryResponse(resp), nil
+		text := c.Text
+		if len(text) > maxPinnedContextChars {
+			text = strings.ToValidUTF8(text[:maxPinnedContextChars], "") + "\n[truncated]"
+		}
+		sb.WriteString(text)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Question: " + req.Question)
+	return sb.String()
 }
 
 // convertQueryResponse converts the proto response to our QueryResponse type.
@@ -531,6 +676,30 @@ func (c *AIClient) convertAnalyzeResponse(resp *aiv1.AnalyzeByIDResponse) *Analy
 	return result
 }
 
+// GenerateSummary summarizes req.Content with the AI service. Unlike
+// Summarize, the content is passed in rather than looked up by ID.
+func (c *AIClient) GenerateSummary(ctx context.Context, tenantID string, req *aiv1.SummaryRequest) (*aiv1.SummaryResponse, error) {
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("AI client not connected")
+	}
+
+	ctx = c.contextWithTenant(ctx, tenantID)
+	if tenantID != "" && req.TenantId == nil {
+		req.TenantId = &tenantID
+	}
+
+	resp, err := client.GenerateSummary(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("generate summary request failed: %w", err)
+	}
+
+	return resp, nil
+}
+
 // GetStageConfig returns the current model configuration for all pipeline stages.
 func (c *AIClient) GetStageConfig(ctx context.Context, tenantID string, stage string) (*aiv1.GetStageConfigResponse, error) {
 	c.mu.RLock()
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// startAITestServer serves the AI coordinator service, streaming chunks from
+// QueryStream when chunks is non-nil, and returns a connected AIClient.
+func startAITestServer(t *testing.T, chunks []*aiv1.QueryResponse) *AIClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	aiv1.RegisterAICoordinatorServiceServer(s, &fakeAIServer{chunks: chunks})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts := DefaultOptions()
+	opts.Insecure = true
+	c := NewAIClient(lis.Addr().String(), opts)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// fakeAIServer answers buffered queries, and streamed ones when chunks is
+// non-nil. With nil chunks it behaves like a server without QueryStream.
+type fakeAIServer struct {
+	aiv1.UnimplementedAICoordinatorServiceServer
+	chunks []*aiv1.QueryResponse
+}
+
+func (f *fakeAIServer) Query(ctx context.Context, req *aiv1.QueryRequest) (*aiv1.QueryResponse, error) {
+	return &aiv1.QueryResponse{Answer: "buffered answer"}, nil
+}
+
+func (f *fakeAIServer) QueryStream(req *aiv1.QueryRequest, stream grpc.ServerStreamingServer[aiv1.QueryResponse]) error {
+	if f.chunks == nil {
+		return f.UnimplementedAICoordinatorServiceServer.QueryStream(req, stream)
+	}
+	for _, c := range f.chunks {
+		if err := stream.Send(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAIClient_QueryStream(t *testing.T) {
+	c := startAITestServer(t, []*aiv1.QueryResponse{
+		{Answer: "Hello"},
+		{Answer: ", world"},
+		{
+			ResponseId:   "resp-1",
+			ModelUsed:    "test-model",
+			OutputTokens: proto.Int32(3),
+			Sources:      []*aiv1.QuerySource{{SourceId: "doc-1", Title: "Doc"}},
+		},
+	})
+
+	var chunks []string
+	resp, err := c.QueryStream(context.Background(), &QueryRequest{Question: "hi"}, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("QueryStream() error = %v", err)
+	}
+
+	if got := strings.Join(chunks, "|"); got != "Hello|, world" {
+		t.Errorf("chunks = %q, want %q", got, "Hello|, world")
+	}
+	if resp.Answer != "Hello, world" {
+		t.Errorf("Answer = %q, want %q", resp.Answer, "Hello, world")
+	}
+	if resp.ResponseID != "resp-1" || resp.ModelUsed != "test-model" || resp.OutputTokens != 3 {
+		t.Errorf("metadata not merged: %+v", resp)
+	}
+	if len(resp.Sources) != 1 || resp.Sources[0].SourceID != "doc-1" {
+		t.Errorf("Sources = %+v, want doc-1", resp.Sources)
+	}
+}
+
+func TestAIClient_QueryStream_Unsupported(t *testing.T) {
+	c := startAITestServer(t, nil)
+
+	called := false
+	_, err := c.QueryStream(context.Background(), &QueryRequest{Question: "hi"}, func(string) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrStreamingUnsupported) {
+		t.Fatalf("QueryStream() error = %v, want ErrStreamingUnsupported", err)
+	}
+	if called {
+		t.Error("onChunk should not be called when streaming is unsupported")
+	}
+
+	// The buffered RPC still works on the same connection.
+	resp, err := c.Query(context.Background(), &QueryRequest{Question: "hi"})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if resp.Answer != "buffered answer" {
+		t.Errorf("Answer = %q, want %q", resp.Answer, "buffered answer")
+	}
+}
+
+func TestAIClient_QueryStream_NotConnected(t *testing.T) {
+	c := NewAIClient("localhost:0", nil)
+	if _, err := c.QueryStream(context.Background(), &QueryRequest{}, func(string) error { return nil }); err == nil {
+		t.Error("expected error when not connected")
+	}
+}
+
+func TestQuestionWithPinnedContext(t *testing.T) {
+	req := &QueryRequest{Question: "What was decided?"}
+	if got := questionWithPinnedContext(req); got != req.Question {
+		t.Errorf("without pinned context, question = %q, want it unchanged", got)
+	}
+
+	req.PinnedContext = []QueryContext{
+		{ContentID: "mt-1", Title: "Weekly sync", Text: "We agreed to ship Friday."},
+		{ContentID: "em-2", Text: strings.Repeat("x", maxPinnedContextChars+10)},
+	}
+	got := questionWithPinnedContext(req)
+
+	for _, want := range []string{"--- mt-1 (Weekly sync) ---", "We agreed to ship Friday.", "--- em-2 ---", "[truncated]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("question missing %q", want)
+		}
+	}
+	if !strings.HasSuffix(got, "Question: What was decided?") {
+		t.Errorf("question should end with the original question, got %q", got[len(got)-40:])
+	}
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Circuit breaker defaults: after circuitFailureThreshold consecutive
+// connection failures within circuitFailureWindow, connections to that server
+// fail fast for circuitCooldown.
+const (
+	circuitFailureThreshold = 3
+	circuitFailureWindow    = 2 * time.Minute
+	circuitCooldown         = 30 * time.Second
+)
+
+// circuitStateFile is the circuit breaker state file name under the config dir.
+const circuitStateFile = "circuit-breaker.json"
+
+// ErrServerUnavailable is returned by Dial without attempting a connection
+// while the circuit breaker is open for the server.
+var ErrServerUnavailable = errors.New("server unavailable")
+
+var (
+	circuitMu      sync.Mutex
+	circuitEnabled = true
+)
+
+// SetCircuitBreakerEnabled turns the connection circuit breaker on or off for
+// subsequent Dial calls.
+func SetCircuitBreakerEnabled(enabled bool) {
+	circuitMu.Lock()
+	defer circuitMu.Unlock()
+	circuitEnabled = enabled
+}
+
+// Dial dials addr like grpc.DialContext, guarded by the circuit breaker.
+// While the breaker is open for addr it fails fast with ErrServerUnavailable;
+// otherwise it records whether the connection succeeded. A dial failure is
+// returned as a ConnectError for target, e.g. "gateway at host:port". Every
+// connection to the gateway or one of its services goes through Dial.
+func Dial(ctx context.Context, target, addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	breaker := defaultCircuitBreaker()
+	if breaker != nil {
+		if err := breaker.allow(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		// An interrupted dial says nothing about the server.
+		if breaker != nil && !errors.Is(ctx.Err(), context.Canceled) {
+			breaker.recordFailure(addr)
+		}
+		return nil, NewConnectError(target, err)
+	}
+	if breaker != nil {
+		breaker.recordSuccess(addr)
+	}
+	return conn, nil
+}
+
+// circuitServerState is the breaker state for one server address.
+type circuitServerState struct {
+	// Failures counts consecutive connection failures since FirstFailure.
+	Failures     int       `json:"failures"`
+	FirstFailure time.Time `json:"first_failure"`
+	// OpenUntil is when the cooldown ends. After it passes the breaker is
+	// half-open: one attempt is let through, and a failure reopens it.
+	OpenUntil time.Time `json:"open_until,omitempty"`
+}
+
+// circuitBreaker tracks connection failures per server in a state file, so
+// that separate penf invocations share it. It is best effort: if the state
+// file cannot be read or written, connections are simply attempted.
+type circuitBreaker struct {
+	path      string
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+// defaultCircuitBreaker returns the breaker backed by ~/.penf/, or nil if it
+// is disabled or the config directory is unknown.
+func defaultCircuitBreaker() *circuitBreaker {
+	circuitMu.Lock()
+	enabled := circuitEnabled
+	circuitMu.Unlock()
+	if !enabled {
+		return nil
+	}
+
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return nil
+	}
+	return &circuitBreaker{
+		path:      filepath.Join(dir, circuitStateFile),
+		threshold: circuitFailureThreshold,
+		window:    circuitFailureWindow,
+		cooldown:  circuitCooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns ErrServerUnavailable if the breaker is open for addr.
+func (b *circuitBreaker) allow(addr string) error {
+	state := b.load()[addr]
+	if state == nil || !b.now().Before(state.OpenUntil) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s failed %d consecutive connection attempts; not retrying until %s (use --no-circuit-breaker to try anyway)",
+		ErrServerUnavailable, addr, state.Failures, state.OpenUntil.Local().Format("15:04:05"))
+}
+
+// recordFailure counts a failed connection to addr, opening the breaker once
+// the threshold is reached.
+func (b *circuitBreaker) recordFailure(addr string) {
+	states := b.load()
+	now := b.now()
+
+	state := states[addr]
+	if state == nil {
+		state = &circuitServerState{}
+		states[addr] = state
+	}
+
+	halfOpen := !state.OpenUntil.IsZero() && !now.Before(state.OpenUntil)
+	if !halfOpen && (state.Failures == 0 || now.Sub(state.FirstFailure) > b.window) {
+		state.Failures = 0
+		state.FirstFailure = now
+	}
+	state.Failures++
+
+	if halfOpen || state.Failures >= b.threshold {
+		state.OpenUntil = now.Add(b.cooldown)
+	}
+	b.save(states)
+}
+
+// recordSuccess clears any failures recorded for addr.
+func (b *circuitBreaker) recordSuccess(addr string) {
+	states := b.load()
+	if _, ok := states[addr]; !ok {
+		return
+	}
+	delete(states, addr)
+	b.save(states)
+}
+
+// load reads the state file; a missing or unreadable file is empty state.
+func (b *circuitBreaker) load() map[string]*circuitServerState {
+	states := make(map[string]*circuitServerState)
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return states
+	}
+	if err := json.Unmarshal(data, &states); err != nil || states == nil {
+		return make(map[string]*circuitServerState)
+	}
+	return states
+}
+
+// save writes the state file atomically, ignoring errors.
+func (b *circuitBreaker) save(states map[string]*circuitServerState) {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), circuitStateFile+".*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), b.path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
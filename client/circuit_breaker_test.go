@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newTestCircuitBreaker returns a breaker with a temp state file and a clock
+// the test advances through *now.
+func newTestCircuitBreaker(t *testing.T, now *time.Time) *circuitBreaker {
+	t.Helper()
+	return &circuitBreaker{
+		path:      filepath.Join(t.TempDir(), circuitStateFile),
+		threshold: 3,
+		window:    time.Minute,
+		cooldown:  30 * time.Second,
+		now:       func() time.Time { return *now },
+	}
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := newTestCircuitBreaker(t, &now)
+	const addr = "gateway:50051"
+
+	for i := 0; i < 2; i++ {
+		b.recordFailure(addr)
+		if err := b.allow(addr); err != nil {
+			t.Fatalf("allow() after %d failures = %v, want nil", i+1, err)
+		}
+	}
+	b.recordFailure(addr)
+
+	err := b.allow(addr)
+	if !errors.Is(err, ErrServerUnavailable) {
+		t.Fatalf("allow() after threshold = %v, want ErrServerUnavailable", err)
+	}
+	if err := b.allow("other:50051"); err != nil {
+		t.Errorf("allow(other) = %v, want nil", err)
+	}
+
+	// After the cooldown one attempt is let through; a failure reopens it.
+	now = now.Add(31 * time.Second)
+	if err := b.allow(addr); err != nil {
+		t.Fatalf("allow() after cooldown = %v, want nil", err)
+	}
+	b.recordFailure(addr)
+	if err := b.allow(addr); !errors.Is(err, ErrServerUnavailable) {
+		t.Errorf("allow() after half-open failure = %v, want ErrServerUnavailable", err)
+	}
+
+	// A success closes the breaker and forgets the failures.
+	now = now.Add(31 * time.Second)
+	b.recordSuccess(addr)
+	b.recordFailure(addr)
+	if err := b.allow(addr); err != nil {
+		t.Errorf("allow() after success = %v, want nil", err)
+	}
+}
+
+func TestCircuitBreaker_FailuresOutsideWindowReset(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := newTestCircuitBreaker(t, &now)
+	const addr = "gateway:50051"
+
+	b.recordFailure(addr)
+	b.recordFailure(addr)
+	now = now.Add(2 * time.Minute)
+	b.recordFailure(addr)
+
+	if err := b.allow(addr); err != nil {
+		t.Errorf("allow() = %v, want nil when earlier failures are outside the window", err)
+	}
+}
+
+func TestDefaultCircuitBreaker_Disabled(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	if defaultCircuitBreaker() == nil {
+		t.Fatal("defaultCircuitBreaker() = nil, want a breaker when enabled")
+	}
+
+	SetCircuitBreakerEnabled(false)
+	t.Cleanup(func() { SetCircuitBreakerEnabled(true) })
+	if b := defaultCircuitBreaker(); b != nil {
+		t.Errorf("defaultCircuitBreaker() = %+v, want nil when disabled", b)
+	}
+}
+
+func TestDial_CircuitBreaker(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	// Reserve a port with nothing listening on it.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	dial := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		conn, err := Dial(ctx, "gateway at "+addr, addr,
+			grpc.WithBlock(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if conn != nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		err := dial()
+		var connErr *ConnectError
+		if !errors.As(err, &connErr) {
+			t.Fatalf("Dial() attempt %d = %v, want a ConnectError", i+1, err)
+		}
+	}
+
+	if err := dial(); !errors.Is(err, ErrServerUnavailable) {
+		t.Errorf("Dial() after %d failures = %v, want ErrServerUnavailable", circuitFailureThreshold, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -15,7 +16,6 @@ import (
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -81,6 +81,7 @@ const (
 	DefaultInitialBackoff    = 100 * time.Millisecond
 	DefaultMaxBackoff        = 5 * time.Second
 	DefaultBackoffMultiplier = 2.0
+	DefaultMaxRecvMsgSize    = 64 << 20 // gRPC's own default of 4MB is too small for large graph and log responses
 )
 
 // GRPCClient manages the connection to the Penfold API Gateway.
@@ -106,13 +107,23 @@ type ClientOptions struct {
 	// ConnectTimeout is the maximum time to wait for connection.
 	ConnectTimeout time.Duration
 
+	// RequestTimeout bounds each unary RPC whose context has no deadline.
+	// Zero leaves RPCs unbounded.
+	RequestTimeout time.Duration
+
 	// KeepaliveTime is the interval for keepalive pings.
 	KeepaliveTime time.Duration
 
 	// KeepaliveTimeout is the timeout for keepalive ping response.
 	KeepaliveTimeout time.Duration
 
-	// MaxRetries is the maximum number of retry attempts.
+	// MaxRecvMsgSize is the largest response message, in bytes, the client
+	// accepts. Zero uses gRPC's default.
+	MaxRecvMsgSize int
+
+	// MaxRetries is the maximum number of retry attempts. Idempotent RPCs
+	// that fail with a transient error are retried up to this many times;
+	// zero disables retries.
 	MaxRetries int
 
 	// InitialBackoff is the initial backoff duration for retries.
@@ -127,9 +138,12 @@ type ClientOptions struct {
 	// Insecure disables TLS (for development only).
 	Insecure bool
 
-	// Debug enables verbose logging.
+	// Debug enables verbose logging, including a trace of each RPC.
 	Debug bool
 
+	// DebugTrace adds request and response message sizes to the RPC trace.
+	DebugTrace bool
+
 	// TenantID is the default tenant ID to include in all requests.
 	TenantID string
 
@@ -144,6 +158,7 @@ func DefaultOptions() *ClientOptions {
 		ConnectTimeout:    DefaultConnectTimeout,
 		KeepaliveTime:     DefaultKeepaliveTime,
 		KeepaliveTimeout:  DefaultKeepaliveTimeout,
+		MaxRecvMsgSize:    DefaultMaxRecvMsgSize,
 		MaxRetries:        DefaultMaxRetries,
 		InitialBackoff:    DefaultInitialBackoff,
 		MaxBackoff:        DefaultMaxBackoff,
@@ -183,9 +198,9 @@ func (c *GRPCClient) Connect(ctx context.Context) error {
 	dialOpts := c.buildDialOptions()
 
 	// Establish connection.
-	conn, err := grpc.DialContext(connectCtx, c.serverAddr, dialOpts...)
+	conn, err := Dial(connectCtx, c.serverAddr, c.serverAddr, dialOpts...)
 	if err != nil {
-		return fmt.Errorf("connecting to %s: %w", c.serverAddr, err)
+		return err
 	}
 
 	c.conn = conn
@@ -197,18 +212,24 @@ func (c *GRPCClient) Connect(ctx context.Context) error {
 // buildDialOptions constructs the gRPC dial options from client configuration.
 func (c *GRPCClient) buildDialOptions() []grpc.DialOption {
 	opts := []grpc.DialOption{
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                c.options.KeepaliveTime,
-			Timeout:             c.options.KeepaliveTimeout,
-			PermitWithoutStream: true,
-		}),
-		grpc.WithDefaultCallOptions(
-			grpc.WaitForReady(true),
-		),
 		// Block on dial to detect connection failures early.
 		// Without this, DialContext returns immediately and connection happens lazily,
 		// which can cause confusing hangs when the server is unreachable.
 		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	}
+	opts = append(opts, messageDialOptions(c.options)...)
+
+	// Bound each RPC, retries included, by the request timeout.
+	if c.options.RequestTimeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(requestTimeoutUnaryInterceptor(c.options.RequestTimeout)))
+	}
+
+	// Retry read-only RPCs on transient errors. In debug mode, log retries
+	// and trace each attempt.
+	opts = append(opts, retryDialOptions(c.options)...)
+	if c.options.Debug {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(os.Stderr, c.options.DebugTrace)))
 	}
 
 	// Configure transport credentials.
@@ -554,9 +575,13 @@ func convertHealthCheckResponse(resp *gatewaypb.HealthCheckResponse) *SystemStat
 // This is the canonical way to create a connected client from CLI commands.
 func ConnectFromConfig(cfg *config.CLIConfig) (*GRPCClient, error) {
 	opts := DefaultOptions()
+	opts.ConnectTimeout = cfg.GetConnectTimeout()
+	opts.RequestTimeout = cfg.Timeout
 	opts.Insecure = cfg.Insecure
 	opts.Debug = cfg.Debug
+	opts.DebugTrace = cfg.DebugTrace
 	opts.TenantID = cfg.TenantID
+	opts.ApplyConnectionConfig(cfg)
 
 	if !cfg.Insecure && cfg.TLS.Enabled {
 		tlsConfig, err := LoadClientTLSConfig(&cfg.TLS)
@@ -575,3 +600,15 @@ func ConnectFromConfig(cfg *config.CLIConfig) (*GRPCClient, error) {
 	}
 	return c, nil
 }
+
+// GatewayDialOptions returns the tracing, request timeout, retry and message
+// dial options for commands that dial the gateway directly. The request
+// timeout comes before the retries so it bounds the whole call.
+func GatewayDialOptions(cfg *config.CLIConfig) []grpc.DialOption {
+	var opts []grpc.DialOption
+	opts = append(opts, DebugDialOptions(cfg)...)
+	opts = append(opts, RequestTimeoutDialOptions(cfg)...)
+	opts = append(opts, RetryDialOptions(cfg)...)
+	opts = append(opts, MessageDialOptions(cfg)...)
+	return opts
+}
@@ -31,6 +31,9 @@ func TestDefaultOptions(t *testing.T) {
 	if opts.KeepaliveTimeout != DefaultKeepaliveTimeout {
 		t.Errorf("KeepaliveTimeout = %v, want %v", opts.KeepaliveTimeout, DefaultKeepaliveTimeout)
 	}
+	if opts.MaxRecvMsgSize != DefaultMaxRecvMsgSize {
+		t.Errorf("MaxRecvMsgSize = %v, want %v", opts.MaxRecvMsgSize, DefaultMaxRecvMsgSize)
+	}
 	if opts.MaxRetries != DefaultMaxRetries {
 		t.Errorf("MaxRetries = %v, want %v", opts.MaxRetries, DefaultMaxRetries)
 	}
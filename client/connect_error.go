@@ -0,0 +1,23 @@
+package client
+
+import "fmt"
+
+// ConnectError is returned when establishing a connection to a server fails,
+// as opposed to an RPC on an established connection. Callers use it to tell
+// an unreachable server from a slow or failing request.
+type ConnectError struct {
+	// Target describes what was being connected to, e.g. "gateway at host:port".
+	Target string
+	Err    error
+}
+
+// NewConnectError wraps a dial error for target.
+func NewConnectError(target string, err error) error {
+	return &ConnectError{Target: target, Err: err}
+}
+
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("connecting to %s: %v", e.Target, e.Err)
+}
+
+func (e *ConnectError) Unwrap() error { return e.Err }
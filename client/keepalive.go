@@ -0,0 +1,60 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// MessageDialOptions returns the dial options for the configured keepalive
+// and maximum response size, for commands that dial the gateway directly.
+func MessageDialOptions(cfg *config.CLIConfig) []grpc.DialOption {
+	opts := DefaultOptions()
+	opts.ApplyConnectionConfig(cfg)
+	return messageDialOptions(opts)
+}
+
+// ApplyConnectionConfig copies the configured keepalive, maximum response
+// size and retry count from cfg into o. Settings cfg leaves unset keep o's
+// values.
+func (o *ClientOptions) ApplyConnectionConfig(cfg *config.CLIConfig) {
+	if cfg == nil {
+		return
+	}
+	if cfg.MaxMessageSize > 0 {
+		o.MaxRecvMsgSize = cfg.MaxMessageSize
+	}
+	if cfg.KeepaliveTime > 0 {
+		o.KeepaliveTime = cfg.KeepaliveTime
+	}
+	if cfg.KeepaliveTimeout > 0 {
+		o.KeepaliveTimeout = cfg.KeepaliveTimeout
+	}
+	if cfg.GRPCRetries != nil {
+		o.MaxRetries = *cfg.GRPCRetries
+	}
+}
+
+// messageDialOptions returns the keepalive and maximum response size dial
+// options for o. Unset keepalive values fall back to the defaults, since gRPC
+// would otherwise ping every 10s and trip the server's ping limit.
+func messageDialOptions(o *ClientOptions) []grpc.DialOption {
+	params := keepalive.ClientParameters{
+		Time:                o.KeepaliveTime,
+		Timeout:             o.KeepaliveTimeout,
+		PermitWithoutStream: true,
+	}
+	if params.Time <= 0 {
+		params.Time = DefaultKeepaliveTime
+	}
+	if params.Timeout <= 0 {
+		params.Timeout = DefaultKeepaliveTimeout
+	}
+
+	opts := []grpc.DialOption{grpc.WithKeepaliveParams(params)}
+	if o.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(o.MaxRecvMsgSize)))
+	}
+	return opts
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestApplyConnectionConfig(t *testing.T) {
+	retries := 0
+	opts := DefaultOptions()
+	opts.ApplyConnectionConfig(&config.CLIConfig{
+		MaxMessageSize: 128 << 20,
+		KeepaliveTime:  time.Minute,
+		GRPCRetries:    &retries,
+	})
+	if opts.MaxRecvMsgSize != 128<<20 {
+		t.Errorf("MaxRecvMsgSize = %d, want %d", opts.MaxRecvMsgSize, 128<<20)
+	}
+	if opts.KeepaliveTime != time.Minute {
+		t.Errorf("KeepaliveTime = %v, want 1m", opts.KeepaliveTime)
+	}
+	if opts.KeepaliveTimeout != DefaultKeepaliveTimeout {
+		t.Errorf("KeepaliveTimeout = %v, want default %v", opts.KeepaliveTimeout, DefaultKeepaliveTimeout)
+	}
+	if opts.MaxRetries != 0 {
+		t.Errorf("MaxRetries = %d, want 0 from grpc_retries", opts.MaxRetries)
+	}
+}
+
+func TestMessageDialOptions(t *testing.T) {
+	// The default options always carry the response size limit, so commands
+	// that dial directly get the same limit as the service clients.
+	if got := len(MessageDialOptions(&config.CLIConfig{})); got != 2 {
+		t.Errorf("MessageDialOptions = %d options, want keepalive and max message size", got)
+	}
+	if got := len(messageDialOptions(&ClientOptions{})); got != 1 {
+		t.Errorf("messageDialOptions without max message size = %d options, want keepalive only", got)
+	}
+}
+
+func TestGatewayDialOptions(t *testing.T) {
+	cfg := &config.CLIConfig{Timeout: 30 * time.Second, Debug: true}
+	want := len(DebugDialOptions(cfg)) + len(RequestTimeoutDialOptions(cfg)) + len(RetryDialOptions(cfg)) + len(MessageDialOptions(cfg))
+	if got := len(GatewayDialOptions(cfg)); got != want {
+		t.Errorf("GatewayDialOptions = %d options, want %d", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	logsv1 "github.com/otherjamesbrown/penf-cli/api/proto/logs/v1"
@@ -25,8 +26,11 @@ type LogEntry struct {
 
 // LogFilter represents the filter criteria for querying logs.
 type LogFilter struct {
-	Service  string
-	Level    string
+	// Services matches entries from any of the given services.
+	Services []string
+	// Levels is a minimum level when it holds one value; with several, it
+	// matches entries at exactly any of the given levels.
+	Levels   []string
 	Since    time.Time
 	Until    time.Time
 	Contains string
@@ -61,46 +65,70 @@ func (c *GRPCClient) ListLogs(ctx context.Context, filter LogFilter, limit, offs
 	}
 
 	client := logsv1.NewLogsServiceClient(conn)
+	protoFilter := filter.toProto()
+
+	// Without local filtering, one page is the answer.
+	if !filter.filtersLocally() || limit <= 0 {
+		resp, err := client.ListLogs(ctx, &logsv1.ListLogsRequest{
+			Filter:   protoFilter,
+			Limit:    int32(limit),
+			Offset:   int32(offset),
+			OrderAsc: orderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ListLogs RPC failed: %w", err)
+		}
 
-	// Build filter
-	protoFilter := &logsv1.LogFilter{
-		Service:  filter.Service,
-		Contains: filter.Contains,
-		TraceId:  filter.TraceID,
-	}
-
-	if filter.Level != "" {
-		protoFilter.Level = levelToProto(filter.Level)
-	}
+		// Convert response
+		entries := make([]LogEntry, len(resp.Entries))
+		for i, e := range resp.Entries {
+			entries[i] = entryFromProto(e)
+		}
 
-	if !filter.Since.IsZero() {
-		protoFilter.Since = timestamppb.New(filter.Since)
+		return &LogsResponse{
+			Entries:    entries,
+			TotalCount: resp.TotalCount,
+			Truncated:  resp.Truncated,
+		}, nil
 	}
 
-	if !filter.Until.IsZero() {
-		protoFilter.Until = timestamppb.New(filter.Until)
-	}
+	// The server filter was widened, so page through until limit entries
+	// match or the server runs out.
+	entries := []LogEntry{}
+	truncated := false
+	for {
+		resp, err := client.ListLogs(ctx, &logsv1.ListLogsRequest{
+			Filter:   protoFilter,
+			Limit:    int32(limit),
+			Offset:   int32(offset),
+			OrderAsc: orderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ListLogs RPC failed: %w", err)
+		}
 
-	resp, err := client.ListLogs(ctx, &logsv1.ListLogsRequest{
-		Filter:   protoFilter,
-		Limit:    int32(limit),
-		Offset:   int32(offset),
-		OrderAsc: orderAsc,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ListLogs RPC failed: %w", err)
-	}
+		for _, e := range resp.Entries {
+			entry := entryFromProto(e)
+			if !filter.matches(entry) {
+				continue
+			}
+			if len(entries) == limit {
+				truncated = true
+				break
+			}
+			entries = append(entries, entry)
+		}
 
-	// Convert response
-	entries := make([]LogEntry, len(resp.Entries))
-	for i, e := range resp.Entries {
-		entries[i] = entryFromProto(e)
+		offset += len(resp.Entries)
+		if truncated || len(resp.Entries) < limit || !resp.Truncated {
+			break
+		}
 	}
 
 	return &LogsResponse{
 		Entries:    entries,
-		TotalCount: resp.TotalCount,
-		Truncated:  resp.Truncated,
+		TotalCount: int64(len(entries)),
+		Truncated:  truncated,
 	}, nil
 }
 
@@ -119,23 +147,8 @@ func (c *GRPCClient) StreamLogs(ctx context.Context, filter LogFilter, pollInter
 
 	client := logsv1.NewLogsServiceClient(conn)
 
-	// Build filter
-	protoFilter := &logsv1.LogFilter{
-		Service:  filter.Service,
-		Contains: filter.Contains,
-		TraceId:  filter.TraceID,
-	}
-
-	if filter.Level != "" {
-		protoFilter.Level = levelToProto(filter.Level)
-	}
-
-	if !filter.Since.IsZero() {
-		protoFilter.Since = timestamppb.New(filter.Since)
-	}
-
 	stream, err := client.StreamLogs(ctx, &logsv1.StreamLogsRequest{
-		Filter:         protoFilter,
+		Filter:         filter.toProto(),
 		PollIntervalMs: int32(pollIntervalMs),
 	})
 	if err != nil {
@@ -151,7 +164,9 @@ func (c *GRPCClient) StreamLogs(ctx context.Context, filter LogFilter, pollInter
 			return fmt.Errorf("receiving log entry: %w", err)
 		}
 
-		callback(entryFromProto(entry))
+		if e := entryFromProto(entry); filter.matches(e) {
+			callback(e)
+		}
 	}
 }
 
@@ -214,6 +229,59 @@ func (c *GRPCClient) ListLogServices(ctx context.Context, tenantID string) ([]st
 	return resp.Services, nil
 }
 
+// toProto builds the server-side filter. The server filters on a single
+// service and a minimum level, so with several services the service filter
+// is dropped, and with several levels the lowest is sent; matches then
+// narrows the results.
+func (f LogFilter) toProto() *logsv1.LogFilter {
+	protoFilter := &logsv1.LogFilter{
+		Contains: f.Contains,
+		TraceId:  f.TraceID,
+	}
+
+	if len(f.Services) == 1 {
+		protoFilter.Service = f.Services[0]
+	}
+
+	if len(f.Levels) > 0 {
+		lowest := f.Levels[0]
+		for _, level := range f.Levels[1:] {
+			if levelToProto(level) < levelToProto(lowest) {
+				lowest = level
+			}
+		}
+		protoFilter.Level = levelToProto(lowest)
+	}
+
+	if !f.Since.IsZero() {
+		protoFilter.Since = timestamppb.New(f.Since)
+	}
+
+	if !f.Until.IsZero() {
+		protoFilter.Until = timestamppb.New(f.Until)
+	}
+
+	return protoFilter
+}
+
+// filtersLocally reports whether entries from the server need narrowing by
+// matches.
+func (f LogFilter) filtersLocally() bool {
+	return len(f.Services) > 1 || len(f.Levels) > 1
+}
+
+// matches reports whether an entry satisfies the parts of the filter the
+// server cannot apply.
+func (f LogFilter) matches(e LogEntry) bool {
+	if len(f.Services) > 1 && !slices.Contains(f.Services, e.Service) {
+		return false
+	}
+	if len(f.Levels) > 1 && !slices.Contains(f.Levels, e.Level) {
+		return false
+	}
+	return true
+}
+
 // levelToProto converts a string level to proto LogLevel.
 func levelToProto(level string) logsv1.LogLevel {
 	switch level {
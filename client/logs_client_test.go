@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	logsv1 "github.com/otherjamesbrown/penf-cli/api/proto/logs/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeLogsServer serves a fixed set of entries, honouring the service filter,
+// the minimum level, limit and offset.
+type fakeLogsServer struct {
+	logsv1.UnimplementedLogsServiceServer
+	entries  []*logsv1.LogEntry
+	requests []*logsv1.ListLogsRequest
+}
+
+func (f *fakeLogsServer) ListLogs(ctx context.Context, req *logsv1.ListLogsRequest) (*logsv1.ListLogsResponse, error) {
+	f.requests = append(f.requests, req)
+
+	var matched []*logsv1.LogEntry
+	for _, e := range f.entries {
+		if req.Filter.Service != "" && e.Service != req.Filter.Service {
+			continue
+		}
+		if e.Level < req.Filter.Level {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	start := min(int(req.Offset), len(matched))
+	end := min(start+int(req.Limit), len(matched))
+	return &logsv1.ListLogsResponse{
+		Entries:    matched[start:end],
+		TotalCount: int64(len(matched)),
+		Truncated:  end < len(matched),
+	}, nil
+}
+
+func startLogsTestServer(t *testing.T, srv *fakeLogsServer) *GRPCClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := grpc.NewServer()
+	logsv1.RegisterLogsServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts := DefaultOptions()
+	opts.Insecure = true
+	c := NewGRPCClient(lis.Addr().String(), opts)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestLogFilter_ToProto(t *testing.T) {
+	single := LogFilter{Services: []string{"worker"}, Levels: []string{"warn"}}.toProto()
+	if single.Service != "worker" || single.Level != logsv1.LogLevel_LOG_LEVEL_WARN {
+		t.Errorf("single-value filter = %v", single)
+	}
+
+	multi := LogFilter{Services: []string{"worker", "gateway"}, Levels: []string{"error", "info"}}.toProto()
+	if multi.Service != "" {
+		t.Errorf("multi-service filter sent service %q, want none", multi.Service)
+	}
+	if multi.Level != logsv1.LogLevel_LOG_LEVEL_INFO {
+		t.Errorf("multi-level filter sent %v, want the lowest level", multi.Level)
+	}
+}
+
+func TestLogFilter_Matches(t *testing.T) {
+	f := LogFilter{Services: []string{"worker", "gateway"}, Levels: []string{"debug", "error"}}
+
+	tests := []struct {
+		entry LogEntry
+		want  bool
+	}{
+		{LogEntry{Service: "worker", Level: "error"}, true},
+		{LogEntry{Service: "gateway", Level: "debug"}, true},
+		{LogEntry{Service: "worker", Level: "warn"}, false},
+		{LogEntry{Service: "ai_service", Level: "error"}, false},
+	}
+	for _, tt := range tests {
+		if got := f.matches(tt.entry); got != tt.want {
+			t.Errorf("matches(%+v) = %v, want %v", tt.entry, got, tt.want)
+		}
+	}
+
+	// A single level is a minimum applied by the server.
+	if !(LogFilter{Levels: []string{"warn"}}).matches(LogEntry{Level: "error"}) {
+		t.Error("single level should not filter locally")
+	}
+}
+
+func TestListLogs_MultiValueFilterPages(t *testing.T) {
+	srv := &fakeLogsServer{}
+	for i := 0; i < 10; i++ {
+		service := "worker"
+		if i%2 == 1 {
+			service = "scheduler"
+		}
+		srv.entries = append(srv.entries, &logsv1.LogEntry{Id: int64(i), Service: service, Level: logsv1.LogLevel_LOG_LEVEL_INFO})
+	}
+	srv.entries = append(srv.entries, &logsv1.LogEntry{Id: 10, Service: "gateway", Level: logsv1.LogLevel_LOG_LEVEL_ERROR})
+	c := startLogsTestServer(t, srv)
+
+	resp, err := c.ListLogs(context.Background(), LogFilter{Services: []string{"worker", "gateway"}}, 3, 0, false)
+	if err != nil {
+		t.Fatalf("ListLogs() error = %v", err)
+	}
+	if len(resp.Entries) != 3 || !resp.Truncated {
+		t.Fatalf("got %d entries (truncated=%v), want 3 truncated", len(resp.Entries), resp.Truncated)
+	}
+	for _, e := range resp.Entries {
+		if e.Service != "worker" && e.Service != "gateway" {
+			t.Errorf("unexpected service %q", e.Service)
+		}
+	}
+
+	resp, err = c.ListLogs(context.Background(), LogFilter{Services: []string{"worker", "gateway"}}, 10, 0, false)
+	if err != nil {
+		t.Fatalf("ListLogs() error = %v", err)
+	}
+	if len(resp.Entries) != 6 || resp.Truncated {
+		t.Errorf("got %d entries (truncated=%v), want all 6", len(resp.Entries), resp.Truncated)
+	}
+}
+
+func TestListLogs_ResponseLargerThanGRPCDefault(t *testing.T) {
+	// gRPC rejects responses over 4MB unless MaxRecvMsgSize raises the limit.
+	srv := &fakeLogsServer{entries: []*logsv1.LogEntry{
+		{Id: 1, Service: "worker", Message: strings.Repeat("x", 5<<20)},
+	}}
+	c := startLogsTestServer(t, srv)
+
+	resp, err := c.ListLogs(context.Background(), LogFilter{}, 10, 0, false)
+	if err != nil {
+		t.Fatalf("ListLogs() error = %v", err)
+	}
+	if len(resp.Entries) != 1 || len(resp.Entries[0].Message) != 5<<20 {
+		t.Errorf("got %d entries, want the single large entry", len(resp.Entries))
+	}
+}
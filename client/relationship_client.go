@@ -4,6 +4,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -11,7 +12,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -55,9 +55,9 @@ func (c *RelationshipClient) Connect(ctx context.Context) error {
 	dialOpts := c.buildDialOptions()
 
 	// Establish connection.
-	conn, err := grpc.DialContext(connectCtx, c.serverAddr, dialOpts...)
+	conn, err := Dial(connectCtx, "relationship service at "+c.serverAddr, c.serverAddr, dialOpts...)
 	if err != nil {
-		return fmt.Errorf("connecting to relationship service at %s: %w", c.serverAddr, err)
+		return err
 	}
 
 	c.conn = conn
@@ -70,14 +70,10 @@ func (c *RelationshipClient) Connect(ctx context.Context) error {
 // buildDialOptions constructs the gRPC dial options from client configuration.
 func (c *RelationshipClient) buildDialOptions() []grpc.DialOption {
 	opts := []grpc.DialOption{
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                10 * time.Second,
-			Timeout:             3 * time.Second,
-			PermitWithoutStream: true,
-		}),
 		// Block on dial to detect connection failures early.
 		grpc.WithBlock(),
 	}
+	opts = append(opts, messageDialOptions(c.options)...)
 
 	if c.options.Insecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -88,9 +84,26 @@ func (c *RelationshipClient) buildDialOptions() []grpc.DialOption {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	if c.options.RequestTimeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(requestTimeoutUnaryInterceptor(c.options.RequestTimeout)))
+	}
+	opts = append(opts, retryDialOptions(c.options)...)
+
+	if c.options.Debug {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(os.Stderr, c.options.DebugTrace)))
+	}
+
 	return opts
 }
 
+// Conn returns the underlying gRPC connection so other service clients can
+// share it. Returns nil if not connected.
+func (c *RelationshipClient) Conn() *grpc.ClientConn {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
+}
+
 // Close closes the connection to the Relationship service.
 func (c *RelationshipClient) Close() error {
 	c.mu.Lock()
@@ -226,23 +239,42 @@ type ListRelationshipsRequest struct {
 	SourceEntityType relationshipv1.EntityType
 	TargetEntityType relationshipv1.EntityType
 	PageSize         int32
+	PageToken        string
+}
+
+// RelationshipPage is one page of a relationship listing.
+type RelationshipPage struct {
+	Relationships []*Relationship
+	NextPageToken string
+	TotalCount    int64
 }
 
 // ListRelationships returns a list of relationships matching the filter.
 func (c *RelationshipClient) ListRelationships(ctx context.Context, req *ListRelationshipsRequest) ([]*Relationship, int64, error) {
+	page, err := c.ListRelationshipsPage(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Relationships, page.TotalCount, nil
+}
+
+// ListRelationshipsPage returns one page of relationships matching the filter,
+// along with the token for the next page (empty on the last page).
+func (c *RelationshipClient) ListRelationshipsPage(ctx context.Context, req *ListRelationshipsRequest) (*RelationshipPage, error) {
 	c.mu.RLock()
 	client := c.client
 	c.mu.RUnlock()
 
 	if client == nil {
-		return nil, 0, fmt.Errorf("relationship client not connected")
+		return nil, fmt.Errorf("relationship client not connected")
 	}
 
 	ctx = c.contextWithTenant(ctx, req.TenantID)
 
 	protoReq := &relationshipv1.ListRelationshipsRequest{
-		TenantId: req.TenantID,
-		PageSize: req.PageSize,
+		TenantId:  req.TenantID,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
 	}
 
 	if req.EntityID != "" {
@@ -271,20 +303,22 @@ func (c *RelationshipClient) ListRelationships(ctx context.Context, req *ListRel
 
 	resp, err := client.ListRelationships(ctx, protoReq)
 	if err != nil {
-		return nil, 0, fmt.Errorf("list relationships request failed: %w", err)
+		return nil, fmt.Errorf("list relationships request failed: %w", err)
 	}
 
-	relationships := make([]*Relationship, len(resp.Relationships))
+	page := &RelationshipPage{
+		Relationships: make([]*Relationship, len(resp.Relationships)),
+		NextPageToken: resp.NextPageToken,
+	}
 	for i, r := range resp.Relationships {
-		relationships[i] = protoToRelationship(r)
+		page.Relationships[i] = protoToRelationship(r)
 	}
 
-	var totalCount int64
 	if resp.TotalCount != nil {
-		totalCount = *resp.TotalCount
+		page.TotalCount = *resp.TotalCount
 	}
 
-	return relationships, totalCount, nil
+	return page, nil
 }
 
 // GetRelationship retrieves a single relationship by ID.
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// idempotentMethodPrefixes are the RPC name prefixes that only read state and
+// are safe to retry. Anything else (create, merge, delete, reprocess, ...) is
+// sent once, since a retry after a lost response could apply it twice.
+var idempotentMethodPrefixes = []string{
+	"Get", "List", "Search", "Query", "Find", "Lookup", "Describe", "Show",
+	"Inspect", "Diff", "Compare", "Health", "Expand",
+}
+
+// isIdempotentMethod reports whether a gRPC full method name
+// ("/pkg.Service/Method") names a read-only RPC.
+func isIdempotentMethod(fullMethod string) bool {
+	method := path.Base(fullMethod)
+	if strings.HasSuffix(method, "Search") {
+		return true
+	}
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransientError reports whether err is a gRPC error worth retrying.
+// DeadlineExceeded only counts when it came from the server; once the
+// caller's own deadline has passed there is no time left to retry.
+func isTransientError(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	default:
+		return false
+	}
+}
+
+// RetryDialOptions returns the dial options that retry read-only RPCs on
+// transient errors, for commands that dial the gateway directly. Append them
+// after RequestTimeoutDialOptions so the request timeout bounds the retries.
+func RetryDialOptions(cfg *config.CLIConfig) []grpc.DialOption {
+	opts := DefaultOptions()
+	if cfg != nil {
+		opts.Debug = cfg.Debug
+	}
+	opts.ApplyConnectionConfig(cfg)
+	return retryDialOptions(opts)
+}
+
+// retryDialOptions returns the retry interceptor for o. In debug mode each
+// retry is logged to stderr.
+func retryDialOptions(o *ClientOptions) []grpc.DialOption {
+	var debugOut io.Writer
+	if o.Debug {
+		debugOut = os.Stderr
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(retryUnaryInterceptor(o, debugOut))}
+}
+
+// retryUnaryInterceptor retries idempotent unary RPCs that fail with a
+// transient error, up to opts.MaxRetries times with exponential backoff.
+// If debugOut is non-nil, each retry is logged to it.
+func retryUnaryInterceptor(opts *ClientOptions, debugOut io.Writer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if err == nil || opts.MaxRetries <= 0 || !isIdempotentMethod(method) {
+			return err
+		}
+
+		backoff := opts.InitialBackoff
+		for attempt := 1; attempt <= opts.MaxRetries && isTransientError(ctx, err); attempt++ {
+			if debugOut != nil {
+				fmt.Fprintf(debugOut, "Retrying %s after %s (retry %d/%d, backoff %s)\n",
+					method, status.Code(err), attempt, opts.MaxRetries, backoff)
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+
+			backoff = time.Duration(float64(backoff) * opts.BackoffMultiplier)
+			if opts.MaxBackoff > 0 && backoff > opts.MaxBackoff {
+				backoff = opts.MaxBackoff
+			}
+
+			err = invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+		return err
+	}
+}
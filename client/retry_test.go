@@ -0,0 +1,92 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingInvoker returns a unary invoker that fails with code for the first
+// failures calls, then succeeds. calls counts every invocation.
+func failingInvoker(code codes.Code, failures int, calls *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(code, "transient")
+		}
+		return nil
+	}
+}
+
+func testRetryOptions(retries int) *ClientOptions {
+	return &ClientOptions{
+		MaxRetries:        retries,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        2 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+}
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		code      codes.Code
+		failures  int
+		retries   int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"idempotent recovers", "/penfold.logs.v1.LogsService/ListLogs", codes.Unavailable, 2, 3, 3, false},
+		{"retries exhausted", "/penfold.core.cli.v1.CLIService/GetStatus", codes.Unavailable, 5, 3, 4, true},
+		{"search suffix", "/penfold.search.v1.SearchService/SemanticSearch", codes.DeadlineExceeded, 1, 3, 2, false},
+		{"mutation not retried", "/penfold.content.v1.ContentProcessorService/ReprocessContent", codes.Unavailable, 1, 3, 1, true},
+		{"permanent error not retried", "/penfold.logs.v1.LogsService/ListLogs", codes.NotFound, 1, 3, 1, true},
+		{"retries disabled", "/penfold.logs.v1.LogsService/ListLogs", codes.Unavailable, 1, 0, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			interceptor := retryUnaryInterceptor(testRetryOptions(tt.retries), nil)
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, failingInvoker(tt.code, tt.failures, &calls))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryUnaryInterceptor_CallerDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	interceptor := retryUnaryInterceptor(testRetryOptions(3), nil)
+	err := interceptor(ctx, "/penfold.logs.v1.LogsService/ListLogs", nil, nil, nil, failingInvoker(codes.DeadlineExceeded, 5, &calls))
+	if status.Code(err) != codes.DeadlineExceeded || calls != 1 {
+		t.Errorf("got %v after %d calls, want DeadlineExceeded without retrying", err, calls)
+	}
+}
+
+func TestRetryUnaryInterceptor_DebugLog(t *testing.T) {
+	var log bytes.Buffer
+	calls := 0
+	interceptor := retryUnaryInterceptor(testRetryOptions(3), &log)
+	if err := interceptor(context.Background(), "/penfold.logs.v1.LogsService/ListLogs", nil, nil, nil, failingInvoker(codes.Unavailable, 2, &calls)); err != nil {
+		t.Fatalf("error = %v", err)
+	}
+
+	got := log.String()
+	if strings.Count(got, "Retrying /penfold.logs.v1.LogsService/ListLogs") != 2 || !strings.Contains(got, "retry 2/3") {
+		t.Errorf("debug log = %q, want one line per retry with the count", got)
+	}
+}
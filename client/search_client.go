@@ -4,6 +4,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -11,7 +12,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -56,9 +56,9 @@ func (c *SearchClient) Connect(ctx context.Context) error {
 	dialOpts := c.buildDialOptions()
 
 	// Establish connection.
-	conn, err := grpc.DialContext(connectCtx, c.serverAddr, dialOpts...)
+	conn, err := Dial(connectCtx, "search service at "+c.serverAddr, c.serverAddr, dialOpts...)
 	if err != nil {
-		return fmt.Errorf("connecting to search service at %s: %w", c.serverAddr, err)
+		return err
 	}
 
 	c.conn = conn
@@ -71,17 +71,13 @@ func (c *SearchClient) Connect(ctx context.Context) error {
 // buildDialOptions constructs the gRPC dial options from client configuration.
 func (c *SearchClient) buildDialOptions() []grpc.DialOption {
 	opts := []grpc.DialOption{
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                c.options.KeepaliveTime,
-			Timeout:             c.options.KeepaliveTimeout,
-			PermitWithoutStream: true,
-		}),
 		grpc.WithDefaultCallOptions(
 			grpc.WaitForReady(true),
 		),
 		// Block on dial to detect connection failures early.
 		grpc.WithBlock(),
 	}
+	opts = append(opts, messageDialOptions(c.options)...)
 
 	// Configure credentials.
 	if c.options.Insecure {
@@ -93,6 +89,15 @@ func (c *SearchClient) buildDialOptions() []grpc.DialOption {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	if c.options.RequestTimeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(requestTimeoutUnaryInterceptor(c.options.RequestTimeout)))
+	}
+	opts = append(opts, retryDialOptions(c.options)...)
+
+	if c.options.Debug {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(os.Stderr, c.options.DebugTrace)))
+	}
+
 	return opts
 }
 
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// RequestTimeoutDialOptions returns the dial options that bound each unary
+// RPC by the configured request timeout, for commands that dial the gateway
+// directly. Calls whose context already has a deadline keep it.
+func RequestTimeoutDialOptions(cfg *config.CLIConfig) []grpc.DialOption {
+	if cfg == nil || cfg.Timeout <= 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(requestTimeoutUnaryInterceptor(cfg.Timeout))}
+}
+
+// requestTimeoutUnaryInterceptor gives each unary RPC without a deadline one
+// of timeout. It bounds the whole call, retries included.
+func requestTimeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
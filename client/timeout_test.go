@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// deadlineInvoker records the deadline of the context it is called with.
+func deadlineInvoker(deadline *time.Time, ok *bool) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*deadline, *ok = ctx.Deadline()
+		return nil
+	}
+}
+
+func TestRequestTimeoutUnaryInterceptor(t *testing.T) {
+	interceptor := requestTimeoutUnaryInterceptor(time.Minute)
+
+	var deadline time.Time
+	var ok bool
+	start := time.Now()
+	if err := interceptor(context.Background(), "/penfold.logs.v1.LogsService/ListLogs", nil, nil, nil, deadlineInvoker(&deadline, &ok)); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	if !ok || deadline.Before(start.Add(time.Minute)) || deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("deadline = %v (set %v), want about 1m from now", deadline, ok)
+	}
+
+	callerDeadline := time.Now().Add(5 * time.Second)
+	ctx, cancel := context.WithDeadline(context.Background(), callerDeadline)
+	defer cancel()
+	if err := interceptor(ctx, "/penfold.logs.v1.LogsService/ListLogs", nil, nil, nil, deadlineInvoker(&deadline, &ok)); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	if !deadline.Equal(callerDeadline) {
+		t.Errorf("deadline = %v, want caller's %v", deadline, callerDeadline)
+	}
+}
+
+func TestRequestTimeoutDialOptions(t *testing.T) {
+	if opts := RequestTimeoutDialOptions(nil); opts != nil {
+		t.Errorf("RequestTimeoutDialOptions(nil) = %v, want nil", opts)
+	}
+	if opts := RequestTimeoutDialOptions(&config.CLIConfig{}); opts != nil {
+		t.Errorf("RequestTimeoutDialOptions(no timeout) = %v, want nil", opts)
+	}
+	if opts := RequestTimeoutDialOptions(&config.CLIConfig{Timeout: time.Minute}); len(opts) != 1 {
+		t.Errorf("RequestTimeoutDialOptions(1m) = %d options, want 1", len(opts))
+	}
+}
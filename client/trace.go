@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// redactedValue replaces credential values in traced metadata.
+const redactedValue = "[REDACTED]"
+
+// sensitiveMetadataKeys are substrings of metadata keys whose values are
+// credentials and must not be printed.
+var sensitiveMetadataKeys = []string{"authorization", "cookie", "token", "secret", "password", "api-key", "apikey"}
+
+// DebugDialOptions returns the dial options that trace each RPC to stderr
+// when cfg.Debug is set, for commands that dial the gateway directly.
+func DebugDialOptions(cfg *config.CLIConfig) []grpc.DialOption {
+	if cfg == nil || !cfg.Debug {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(os.Stderr, cfg.DebugTrace))}
+}
+
+// traceUnaryInterceptor logs each unary RPC to out: the method, outgoing
+// metadata with credentials redacted, the status code and latency. With
+// sizes set it also logs the request and response message sizes.
+func traceUnaryInterceptor(out io.Writer, sizes bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		elapsed := time.Since(start)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "[grpc] %s", method)
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			if s := formatTraceMetadata(md); s != "" {
+				fmt.Fprintf(&b, " %s", s)
+			}
+		}
+		fmt.Fprintf(&b, " -> %s (%s)", status.Code(err), elapsed.Round(time.Microsecond))
+		if sizes {
+			fmt.Fprintf(&b, " req=%dB", messageSize(req))
+			if err == nil {
+				fmt.Fprintf(&b, " resp=%dB", messageSize(reply))
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(&b, ": %s", status.Convert(err).Message())
+		}
+		fmt.Fprintln(out, b.String())
+
+		return err
+	}
+}
+
+// formatTraceMetadata renders metadata as sorted key=value pairs, redacting
+// the values of credential keys.
+func formatTraceMetadata(md metadata.MD) string {
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value := strings.Join(md[k], ",")
+		if isSensitiveMetadataKey(k) {
+			value = redactedValue
+		}
+		pairs = append(pairs, k+"="+value)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// isSensitiveMetadataKey reports whether a metadata key carries credentials.
+func isSensitiveMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveMetadataKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// messageSize returns the encoded size of a protobuf message, or 0 for
+// anything else.
+func messageSize(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
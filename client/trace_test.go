@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestTraceUnaryInterceptor(t *testing.T) {
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(
+		"x-tenant-id", "tenant-acme",
+		"authorization", "Bearer s3cret",
+	))
+	ok := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+
+	var out bytes.Buffer
+	req := wrapperspb.String("hello")
+	if err := traceUnaryInterceptor(&out, true)(ctx, "/penfold.logs.v1.LogsService/ListLogs", req, wrapperspb.String("world!"), nil, ok); err != nil {
+		t.Fatalf("error = %v", err)
+	}
+
+	line := out.String()
+	for _, want := range []string{"/penfold.logs.v1.LogsService/ListLogs", "x-tenant-id=tenant-acme", "authorization=[REDACTED]", "-> OK", "req=7B", "resp=8B"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("trace %q missing %q", line, want)
+		}
+	}
+	if strings.Contains(line, "s3cret") {
+		t.Errorf("trace %q leaks the credential", line)
+	}
+}
+
+func TestTraceUnaryInterceptor_Error(t *testing.T) {
+	denied := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.PermissionDenied, "tenant mismatch")
+	}
+
+	var out bytes.Buffer
+	err := traceUnaryInterceptor(&out, false)(context.Background(), "/penfold.core.cli.v1.CLIService/GetStatus", nil, nil, nil, denied)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("error = %v, want the invoker's error", err)
+	}
+
+	line := out.String()
+	if !strings.Contains(line, "-> PermissionDenied") || !strings.Contains(line, "tenant mismatch") {
+		t.Errorf("trace = %q, want the status code and message", line)
+	}
+	if strings.Contains(line, "req=") {
+		t.Errorf("trace = %q, want no sizes without trace mode", line)
+	}
+}
+
+func TestDebugDialOptions(t *testing.T) {
+	if opts := DebugDialOptions(&config.CLIConfig{}); len(opts) != 0 {
+		t.Errorf("DebugDialOptions() without debug = %d options, want none", len(opts))
+	}
+	if opts := DebugDialOptions(&config.CLIConfig{Debug: true}); len(opts) != 1 {
+		t.Errorf("DebugDialOptions() with debug = %d options, want 1", len(opts))
+	}
+}
@@ -4,6 +4,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -213,6 +214,39 @@ func (c *GRPCClient) TerminateWorkflow(ctx context.Context, workflowID, runID, r
 	}, nil
 }
 
+// WorkflowHistoryPage is one page of a workflow's event history.
+type WorkflowHistoryPage struct {
+	// Events holds each event in Temporal's JSON encoding, oldest first.
+	Events        []json.RawMessage
+	NextPageToken []byte
+}
+
+// GetWorkflowHistory retrieves one page of a workflow's event history.
+func (c *GRPCClient) GetWorkflowHistory(ctx context.Context, workflowID, runID string, pageToken []byte) (*WorkflowHistoryPage, error) {
+	client, err := c.WorkflowServiceClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetWorkflowHistory(ctx, &workflowv1.GetWorkflowHistoryRequest{
+		WorkflowId:    workflowID,
+		RunId:         runID,
+		NextPageToken: pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetWorkflowHistory RPC failed: %w", err)
+	}
+
+	page := &WorkflowHistoryPage{
+		Events:        make([]json.RawMessage, 0, len(resp.GetEvents())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, ev := range resp.GetEvents() {
+		page.Events = append(page.Events, json.RawMessage(ev.GetEventJson()))
+	}
+	return page, nil
+}
+
 // mapStatusStringToProto maps a status string to proto enum.
 func mapStatusStringToProto(status string) workflowv1.WorkflowExecutionStatus {
 	switch status {
@@ -4,13 +4,13 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -46,6 +46,7 @@ type AICommandDeps struct {
 	OutputFormat config.OutputFormat
 	LoadConfig   func() (*config.CLIConfig, error)
 	InitClient   func(*config.CLIConfig) (*client.GRPCClient, error)
+	InitSearch   func(*config.CLIConfig) (*client.SearchClient, error)
 }
 
 // DefaultAIDeps returns the default dependencies for production use.
@@ -53,6 +54,7 @@ func DefaultAIDeps() *AICommandDeps {
 	return &AICommandDeps{
 		LoadConfig: config.LoadConfig,
 		InitClient: client.ConnectFromConfig,
+		InitSearch: DefaultSearchDeps().InitSearch,
 	}
 }
 
@@ -64,6 +66,10 @@ var (
 	aiOutput      string
 	aiVerbose     bool
 	aiContext     int
+	aiStream      bool
+
+	aiContextFrom       []string
+	aiContextFromSearch string
 )
 
 // NewAICommand creates the root AI command with all subcommands.
@@ -133,7 +139,25 @@ Examples:
   penf ai query "What's the status of Project Alpha?" --model=gpt-4
 
   # Get verbose output with token usage
-  penf ai query "Who mentioned budget concerns?" --verbose`,
+  penf ai query "Who mentioned budget concerns?" --verbose
+
+  # Print the answer as it is generated
+  penf ai query "Summarize this week's risks" --stream
+
+  # Ground the answer in specific content
+  penf ai query "What did we agree?" --context-from mt-abc123 --context-from em-456
+
+  # Ground the answer in the top search results for a topic
+  penf ai query "What are the open risks?" --context-from-search "TER migration"
+
+With --stream, text output is printed token by token as the model generates
+it. JSON and YAML output still wait for the complete answer. If the server
+does not support streaming, the answer is shown when it is complete.
+
+--context-from pins content items as context for the answer, in addition to
+what the server retrieves. --context-from-search pins the top --context search
+results for a query. Content that cannot be found is skipped with a warning;
+the query fails if none of it can be found.`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAIQuery(cmd.Context(), deps, strings.Join(args, " "))
@@ -141,12 +165,15 @@ Examples:
 	}
 
 	// Define flags.
-	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: auto-selected)")
+	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: default_model from config, else auto-selected)")
 	cmd.Flags().IntVar(&aiMaxTokens, "max-tokens", 1000, "Maximum tokens in response")
 	cmd.Flags().Float64Var(&aiTemperature, "temperature", 0.7, "Response creativity (0.0-1.0)")
 	cmd.Flags().StringVarP(&aiOutput, "output", "o", "", "Output format: text, json, yaml")
 	cmd.Flags().BoolVarP(&aiVerbose, "verbose", "v", false, "Show detailed information")
 	cmd.Flags().IntVar(&aiContext, "context", 5, "Number of source documents to consider")
+	cmd.Flags().BoolVar(&aiStream, "stream", false, "Print the answer as it is generated")
+	cmd.Flags().StringSliceVar(&aiContextFrom, "context-from", nil, "Content ID to use as context (repeatable)")
+	cmd.Flags().StringVar(&aiContextFromSearch, "context-from-search", "", "Use the top search results for this query as context")
 
 	return cmd
 }
@@ -183,7 +210,7 @@ Examples:
 
 	// Define flags.
 	cmd.Flags().StringVar(&summaryLength, "length", "standard", "Summary length: brief, standard, detailed")
-	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: auto-selected)")
+	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: default_model from config, else auto-selected)")
 	cmd.Flags().StringVarP(&aiOutput, "output", "o", "", "Output format: text, json, yaml")
 	cmd.Flags().BoolVarP(&aiVerbose, "verbose", "v", false, "Show detailed information")
 
@@ -226,7 +253,7 @@ Examples:
 
 	// Define flags.
 	cmd.Flags().StringVarP(&analyzeType, "type", "t", "full", "Analysis type: sentiment, entities, topics, action, full")
-	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: auto-selected)")
+	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use (default: default_model from config, else auto-selected)")
 	cmd.Flags().StringVarP(&aiOutput, "output", "o", "", "Output format: text, json, yaml")
 	cmd.Flags().BoolVarP(&aiVerbose, "verbose", "v", false, "Show detailed information")
 
@@ -250,12 +277,25 @@ func runAIQuery(ctx context.Context, deps *AICommandDeps, question string) error
 		}
 	}
 
+	// Resolve pinned context before connecting to the AI service, so bad
+	// content IDs fail fast.
+	var pinned []client.QueryContext
+	if len(aiContextFrom) > 0 || aiContextFromSearch != "" {
+		pinned, err = loadAIPinnedContext(ctx, deps, cfg, aiContextFrom, aiContextFromSearch, aiContext)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Build client options with defaults for keepalive.
 	clientOpts := client.DefaultOptions()
 	clientOpts.Insecure = cfg.Insecure
 	clientOpts.Debug = cfg.Debug
+	clientOpts.DebugTrace = cfg.DebugTrace
 	clientOpts.TenantID = cfg.EffectiveTenantID()
-	// Keep the default ConnectTimeout (10s) for fast failure detection.
+	clientOpts.ConnectTimeout = cfg.GetConnectTimeout()
+	clientOpts.RequestTimeout = cfg.Timeout
+	clientOpts.ApplyConnectionConfig(cfg)
 
 	// Load TLS config if not in insecure mode.
 	if !cfg.Insecure && cfg.TLS.Enabled {
@@ -276,24 +316,89 @@ func runAIQuery(ctx context.Context, deps *AICommandDeps, question string) error
 	}
 	defer aiClient.Close()
 
-	// Execute query via gRPC.
-	queryResp, err := aiClient.Query(ctx, &client.QueryRequest{
+	queryReq := &client.QueryRequest{
 		Question:     question,
 		TenantID:     cfg.EffectiveTenantID(),
 		ContextLimit: int32(aiContext),
-		Model:        aiModel,
+		Model:        resolveAIModel(cfg),
 		MaxTokens:    int32(aiMaxTokens),
 		Temperature:  float32(aiTemperature),
-	})
+
+		PinnedContext: pinned,
+	}
+
+	if aiStream {
+		return runAIQueryStream(ctx, aiClient, outputFormat, queryReq)
+	}
+
+	// Execute query via gRPC.
+	queryResp, err := aiClient.Query(ctx, queryReq)
 	if err != nil {
 		return fmt.Errorf("AI query failed: %w", err)
 	}
 
-	// Convert to response format.
+	return outputAIResponse(outputFormat, newAIQueryResponse(queryReq, queryResp), aiVerbose)
+}
+
+// aiQueryStreamer is the part of client.AIClient used by a streamed query.
+type aiQueryStreamer interface {
+	Query(ctx context.Context, req *client.QueryRequest) (*client.QueryResponse, error)
+	QueryStream(ctx context.Context, req *client.QueryRequest, onChunk func(string) error) (*client.QueryResponse, error)
+}
+
+// runAIQueryStream runs a query over the streaming RPC. Text output is
+// printed as each fragment arrives; other formats print the complete
+// response. Servers without streaming fall back to a buffered query.
+func runAIQueryStream(ctx context.Context, aiClient aiQueryStreamer, format config.OutputFormat, req *client.QueryRequest) error {
+	text := format != config.OutputFormatJSON && format != config.OutputFormatYAML
+
+	printed := false
+	onChunk := func(string) error { return nil }
+	if text {
+		outputAIResponseHeader("query", req.Question, "")
+		onChunk = func(chunk string) error {
+			printed = true
+			_, err := fmt.Print(chunk)
+			return err
+		}
+	}
+
+	queryResp, err := aiClient.QueryStream(ctx, req, onChunk)
+	streamed := err == nil
+	if errors.Is(err, client.ErrStreamingUnsupported) {
+		queryResp, err = aiClient.Query(ctx, req)
+	}
+	if err != nil {
+		if printed {
+			// End the partial answer's line before the error.
+			fmt.Println()
+		}
+		return fmt.Errorf("AI query failed: %w", err)
+	}
+
+	response := newAIQueryResponse(req, queryResp)
+	if !text {
+		return outputAIResponse(format, response, aiVerbose)
+	}
+
+	if streamed {
+		// The answer has already been printed; finish its line.
+		fmt.Println()
+		fmt.Println()
+	} else {
+		fmt.Println(response.Response)
+		fmt.Println()
+	}
+	outputAIResponseFooter(response, aiVerbose)
+	return nil
+}
+
+// newAIQueryResponse converts a query response to an AIResponse.
+func newAIQueryResponse(req *client.QueryRequest, queryResp *client.QueryResponse) *AIResponse {
 	response := &AIResponse{
 		ID:          queryResp.ResponseID,
 		Operation:   "query",
-		Query:       question,
+		Query:       req.Question,
 		Response:    queryResp.Answer,
 		Model:       queryResp.ModelUsed,
 		TokensUsed:  int(queryResp.InputTokens + queryResp.OutputTokens),
@@ -305,6 +410,14 @@ func runAIQuery(ctx context.Context, deps *AICommandDeps, question string) error
 		},
 	}
 
+	if len(req.PinnedContext) > 0 {
+		ids := make([]string, len(req.PinnedContext))
+		for i, c := range req.PinnedContext {
+			ids[i] = c.ContentID
+		}
+		response.Metadata["pinned_context"] = strings.Join(ids, ",")
+	}
+
 	// Convert sources.
 	for _, src := range queryResp.Sources {
 		response.Sources = append(response.Sources, AISource{
@@ -315,7 +428,7 @@ func runAIQuery(ctx context.Context, deps *AICommandDeps, question string) error
 		})
 	}
 
-	return outputAIResponse(outputFormat, response, aiVerbose)
+	return response
 }
 
 // runAISummarize executes the AI summarize command.
@@ -345,8 +458,11 @@ func runAISummarize(ctx context.Context, deps *AICommandDeps, contentID, length
 	clientOpts := client.DefaultOptions()
 	clientOpts.Insecure = cfg.Insecure
 	clientOpts.Debug = cfg.Debug
+	clientOpts.DebugTrace = cfg.DebugTrace
 	clientOpts.TenantID = cfg.EffectiveTenantID()
-	// Keep the default ConnectTimeout (10s) for fast failure detection.
+	clientOpts.ConnectTimeout = cfg.GetConnectTimeout()
+	clientOpts.RequestTimeout = cfg.Timeout
+	clientOpts.ApplyConnectionConfig(cfg)
 
 	// Load TLS config if not in insecure mode.
 	if !cfg.Insecure && cfg.TLS.Enabled {
@@ -372,7 +488,7 @@ func runAISummarize(ctx context.Context, deps *AICommandDeps, contentID, length
 		ContentID: contentID,
 		TenantID:  cfg.EffectiveTenantID(),
 		Length:    length,
-		Model:     aiModel,
+		Model:     resolveAIModel(cfg),
 	})
 	if err != nil {
 		return fmt.Errorf("AI summarize failed: %w", err)
@@ -439,8 +555,11 @@ func runAIAnalyze(ctx context.Context, deps *AICommandDeps, contentID, analysisT
 	clientOpts := client.DefaultOptions()
 	clientOpts.Insecure = cfg.Insecure
 	clientOpts.Debug = cfg.Debug
+	clientOpts.DebugTrace = cfg.DebugTrace
 	clientOpts.TenantID = cfg.EffectiveTenantID()
-	// Keep the default ConnectTimeout (10s) for fast failure detection.
+	clientOpts.ConnectTimeout = cfg.GetConnectTimeout()
+	clientOpts.RequestTimeout = cfg.Timeout
+	clientOpts.ApplyConnectionConfig(cfg)
 
 	// Load TLS config if not in insecure mode.
 	if !cfg.Insecure && cfg.TLS.Enabled {
@@ -466,7 +585,7 @@ func runAIAnalyze(ctx context.Context, deps *AICommandDeps, contentID, analysisT
 		ContentID:    contentID,
 		TenantID:     cfg.EffectiveTenantID(),
 		AnalysisType: analysisType,
-		Model:        aiModel,
+		Model:        resolveAIModel(cfg),
 	})
 	if err != nil {
 		return fmt.Errorf("AI analyze failed: %w", err)
@@ -494,6 +613,15 @@ func runAIAnalyze(ctx context.Context, deps *AICommandDeps, contentID, analysisT
 	return outputAIResponse(outputFormat, response, aiVerbose)
 }
 
+// resolveAIModel returns the --model flag, or the configured default model
+// when the flag isn't given.
+func resolveAIModel(cfg *config.CLIConfig) string {
+	if aiModel != "" {
+		return aiModel
+	}
+	return cfg.DefaultModel
+}
+
 // formatAnalysisResponse formats the analysis response for display.
 func formatAnalysisResponse(resp *client.AnalyzeResponse, analysisType string) string {
 	var sb strings.Builder
@@ -587,7 +715,7 @@ func outputAIResponse(format config.OutputFormat, response *AIResponse, verbose
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputAIResponseText(response, verbose)
@@ -596,21 +724,32 @@ func outputAIResponse(format config.OutputFormat, response *AIResponse, verbose
 
 // outputAIResponseText formats AI response for terminal display.
 func outputAIResponseText(response *AIResponse, verbose bool) error {
-	// Header with operation info.
-	fmt.Printf("\033[1mAI %s\033[0m", strings.Title(response.Operation))
-	if response.Query != "" {
-		fmt.Printf(": %s", response.Query)
-	} else if response.ContentID != "" {
-		fmt.Printf(" [%s]", response.ContentID)
-	}
-	fmt.Println()
-	fmt.Println(strings.Repeat("-", 60))
-	fmt.Println()
+	outputAIResponseHeader(response.Operation, response.Query, response.ContentID)
 
 	// Main response.
 	fmt.Println(response.Response)
 	fmt.Println()
 
+	outputAIResponseFooter(response, verbose)
+	return nil
+}
+
+// outputAIResponseHeader prints the operation header that precedes an AI response.
+func outputAIResponseHeader(operation, query, contentID string) {
+	fmt.Printf("\033[1mAI %s\033[0m", strings.Title(operation))
+	if query != "" {
+		fmt.Printf(": %s", query)
+	} else if contentID != "" {
+		fmt.Printf(" [%s]", contentID)
+	}
+	fmt.Println()
+	fmt.Println(strings.Repeat("-", 60))
+	fmt.Println()
+}
+
+// outputAIResponseFooter prints the sources and, if verbose, the model details
+// that follow an AI response.
+func outputAIResponseFooter(response *AIResponse, verbose bool) {
 	// Sources (if any).
 	if len(response.Sources) > 0 {
 		fmt.Println(strings.Repeat("-", 60))
@@ -641,6 +780,4 @@ func outputAIResponseText(response *AIResponse, verbose bool) error {
 			fmt.Println()
 		}
 	}
-
-	return nil
 }
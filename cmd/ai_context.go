@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// aiContextSearcher returns the content IDs of the top limit search results
+// for query.
+type aiContextSearcher func(ctx context.Context, query string, limit int) ([]string, error)
+
+// aiContentFetcher fetches a content item to pin as query context.
+type aiContentFetcher func(ctx context.Context, contentID string) (client.QueryContext, error)
+
+// loadAIPinnedContext resolves --context-from and --context-from-search into
+// pinned context for a query, connecting to the search and content services
+// as needed.
+func loadAIPinnedContext(ctx context.Context, deps *AICommandDeps, cfg *config.CLIConfig, contentIDs []string, searchQuery string, limit int) ([]client.QueryContext, error) {
+	var search aiContextSearcher
+	if searchQuery != "" {
+		searchClient, err := deps.InitSearch(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to search service: %w", err)
+		}
+		defer searchClient.Close()
+
+		search = func(ctx context.Context, query string, limit int) ([]string, error) {
+			resp, err := searchClient.Search(ctx, &client.SearchRequest{
+				Query:    query,
+				TenantID: cfg.EffectiveTenantID(),
+				Limit:    int32(limit),
+			})
+			if err != nil {
+				return nil, err
+			}
+			ids := make([]string, 0, len(resp.Results))
+			for _, r := range resp.Results {
+				ids = append(ids, r.DocumentID)
+			}
+			return ids, nil
+		}
+	}
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to gateway: %w", err)
+	}
+	defer conn.Close()
+	contentClient := contentv1.NewContentProcessorServiceClient(conn)
+
+	fetch := func(ctx context.Context, contentID string) (client.QueryContext, error) {
+		resp, err := contentClient.GetContentText(ctx, &contentv1.GetContentTextRequest{
+			ContentId: contentID,
+		})
+		if err != nil {
+			return client.QueryContext{}, err
+		}
+		title := resp.Metadata["title"]
+		if title == "" {
+			title = resp.Metadata["subject"]
+		}
+		return client.QueryContext{ContentID: contentID, Title: title, Text: resp.Text}, nil
+	}
+
+	return resolveAIPinnedContext(ctx, os.Stderr, contentIDs, searchQuery, limit, search, fetch)
+}
+
+// resolveAIPinnedContext collects the given content IDs and the top search
+// results for searchQuery, and fetches each one. IDs that cannot be fetched
+// are reported to w and skipped; it is an error if none can be.
+func resolveAIPinnedContext(ctx context.Context, w io.Writer, contentIDs []string, searchQuery string, limit int, search aiContextSearcher, fetch aiContentFetcher) ([]client.QueryContext, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range contentIDs {
+		add(id)
+	}
+	if searchQuery != "" {
+		found, err := search(ctx, searchQuery, limit)
+		if err != nil {
+			return nil, fmt.Errorf("searching for context: %w", err)
+		}
+		if len(found) == 0 {
+			fmt.Fprintf(w, "Warning: no content found for --context-from-search %q\n", searchQuery)
+		}
+		for _, id := range found {
+			add(id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no content to use as context")
+	}
+
+	var pinned []client.QueryContext
+	for _, id := range ids {
+		item, err := fetch(ctx, id)
+		if err != nil {
+			fmt.Fprintf(w, "Warning: skipping context %s: %v\n", id, err)
+			continue
+		}
+		pinned = append(pinned, item)
+	}
+
+	if len(pinned) == 0 {
+		return nil, fmt.Errorf("none of the %d context content item(s) could be found", len(ids))
+	}
+	return pinned, nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+// fakeAIContentFetcher returns content for the IDs in texts and an error for
+// any other ID.
+func fakeAIContentFetcher(texts map[string]string) aiContentFetcher {
+	return func(ctx context.Context, contentID string) (client.QueryContext, error) {
+		text, ok := texts[contentID]
+		if !ok {
+			return client.QueryContext{}, errors.New("not found")
+		}
+		return client.QueryContext{ContentID: contentID, Text: text}, nil
+	}
+}
+
+func TestResolveAIPinnedContext_ContentIDs(t *testing.T) {
+	fetch := fakeAIContentFetcher(map[string]string{"mt-1": "meeting", "em-2": "email"})
+	var warnings bytes.Buffer
+
+	pinned, err := resolveAIPinnedContext(context.Background(), &warnings,
+		[]string{"mt-1", " em-2 ", "mt-1", "missing"}, "", 5, nil, fetch)
+	require.NoError(t, err)
+
+	require.Len(t, pinned, 2, "duplicates should be dropped and missing IDs skipped")
+	assert.Equal(t, "mt-1", pinned[0].ContentID)
+	assert.Equal(t, "em-2", pinned[1].ContentID)
+	assert.Contains(t, warnings.String(), "skipping context missing")
+}
+
+func TestResolveAIPinnedContext_Search(t *testing.T) {
+	fetch := fakeAIContentFetcher(map[string]string{"mt-1": "meeting", "doc-3": "doc"})
+	var gotQuery string
+	var gotLimit int
+	search := func(ctx context.Context, query string, limit int) ([]string, error) {
+		gotQuery, gotLimit = query, limit
+		return []string{"doc-3", "mt-1"}, nil
+	}
+
+	pinned, err := resolveAIPinnedContext(context.Background(), &bytes.Buffer{},
+		[]string{"mt-1"}, "migration", 3, search, fetch)
+	require.NoError(t, err)
+
+	assert.Equal(t, "migration", gotQuery)
+	assert.Equal(t, 3, gotLimit)
+	require.Len(t, pinned, 2)
+	assert.Equal(t, "mt-1", pinned[0].ContentID, "explicit IDs come first")
+	assert.Equal(t, "doc-3", pinned[1].ContentID)
+}
+
+func TestResolveAIPinnedContext_NoneResolve(t *testing.T) {
+	fetch := fakeAIContentFetcher(nil)
+
+	_, err := resolveAIPinnedContext(context.Background(), &bytes.Buffer{},
+		[]string{"missing-1", "missing-2"}, "", 5, nil, fetch)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the 2 context content item(s) could be found")
+}
+
+func TestResolveAIPinnedContext_EmptySearch(t *testing.T) {
+	search := func(ctx context.Context, query string, limit int) ([]string, error) {
+		return nil, nil
+	}
+	var warnings bytes.Buffer
+
+	_, err := resolveAIPinnedContext(context.Background(), &warnings, nil, "nothing", 5, search, fakeAIContentFetcher(nil))
+	require.Error(t, err)
+	assert.Contains(t, warnings.String(), "no content found")
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -354,8 +353,10 @@ func connectAIModel(deps *AICommandDeps) (*client.AIClient, error) {
 	}
 
 	opts := client.DefaultOptions()
-	opts.ConnectTimeout = cfg.Timeout
+	opts.ConnectTimeout = cfg.GetConnectTimeout()
+	opts.RequestTimeout = cfg.Timeout
 	opts.Insecure = cfg.Insecure
+	opts.ApplyConnectionConfig(cfg)
 
 	aiClient := client.NewAIClient(cfg.ServerAddress, opts)
 
@@ -399,7 +400,7 @@ func outputYAMLDoc(v interface{}) error {
 	if err := json.Unmarshal(jdata, &obj); err != nil {
 		return err
 	}
-	data, err := yaml.Marshal(obj)
+	data, err := marshalYAML(obj)
 	if err != nil {
 		return err
 	}
@@ -455,3 +455,75 @@ func TestDefaultAIDeps(t *testing.T) {
 	assert.NotNil(t, deps.LoadConfig)
 	assert.NotNil(t, deps.InitClient)
 }
+
+// fakeAIQueryStreamer streams chunks, or reports streaming as unsupported
+// when chunks is nil.
+type fakeAIQueryStreamer struct {
+	chunks       []string
+	bufferedUsed bool
+}
+
+func (f *fakeAIQueryStreamer) Query(ctx context.Context, req *client.QueryRequest) (*client.QueryResponse, error) {
+	f.bufferedUsed = true
+	return &client.QueryResponse{Answer: "buffered answer", ModelUsed: "test-model"}, nil
+}
+
+func (f *fakeAIQueryStreamer) QueryStream(ctx context.Context, req *client.QueryRequest, onChunk func(string) error) (*client.QueryResponse, error) {
+	if f.chunks == nil {
+		return nil, client.ErrStreamingUnsupported
+	}
+	for _, c := range f.chunks {
+		if err := onChunk(c); err != nil {
+			return nil, err
+		}
+	}
+	return &client.QueryResponse{
+		Answer:    strings.Join(f.chunks, ""),
+		ModelUsed: "test-model",
+		Sources:   []client.QuerySource{{SourceID: "doc-1", Title: "Q4 Plan", ContentType: "document", Relevance: 0.9}},
+	}, nil
+}
+
+func TestRunAIQueryStream_TextPrintsChunks(t *testing.T) {
+	streamer := &fakeAIQueryStreamer{chunks: []string{"The answer", " is 42."}}
+
+	var err error
+	output := captureStdout(func() {
+		err = runAIQueryStream(context.Background(), streamer, config.OutputFormatText, &client.QueryRequest{Question: "What?"})
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "AI Query")
+	assert.Contains(t, output, "The answer is 42.\n")
+	assert.Contains(t, output, "Q4 Plan")
+	assert.False(t, streamer.bufferedUsed)
+}
+
+func TestRunAIQueryStream_JSONEmitsFinalObject(t *testing.T) {
+	streamer := &fakeAIQueryStreamer{chunks: []string{"The answer", " is 42."}}
+
+	var err error
+	output := captureStdout(func() {
+		err = runAIQueryStream(context.Background(), streamer, config.OutputFormatJSON, &client.QueryRequest{Question: "What?"})
+	})
+	require.NoError(t, err)
+
+	var response AIResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &response), "output should be a single JSON object")
+	assert.Equal(t, "The answer is 42.", response.Response)
+	assert.Equal(t, "What?", response.Query)
+	assert.Len(t, response.Sources, 1)
+}
+
+func TestRunAIQueryStream_FallsBackToBuffered(t *testing.T) {
+	streamer := &fakeAIQueryStreamer{}
+
+	var err error
+	output := captureStdout(func() {
+		err = runAIQueryStream(context.Background(), streamer, config.OutputFormatText, &client.QueryRequest{Question: "What?"})
+	})
+	require.NoError(t, err)
+
+	assert.True(t, streamer.bufferedUsed)
+	assert.Contains(t, output, "buffered answer")
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	alertv1 "github.com/otherjamesbrown/penf-cli/api/proto/alert/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -136,7 +135,7 @@ func runAlertList(ctx context.Context, deps *AlertCommandDeps) error {
 				"created_at":   a.CreatedAt,
 			}
 		}
-		data, _ := yaml.Marshal(items)
+		data, _ := marshalYAML(items)
 		fmt.Print(string(data))
 	default:
 		if len(resp.Alerts) == 0 {
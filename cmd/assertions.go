@@ -14,7 +14,6 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	assertionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/assertions/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -65,17 +64,20 @@ This command provides three main operations:
   list      List assertions with filters (type, date, person, project)
   search    Search assertions by keyword
   summary   Get aggregate statistics
+  export    Export assertions with provenance (JSON or CSV)
 
 Examples:
   penf assertions list --type action_item
   penf assertions list --since 7d --attributed-to "James Brown"
   penf assertions search "CLIC" --type decision
-  penf assertions summary --since 30d --group-by type`,
+  penf assertions summary --since 30d --group-by type
+  penf assertions export --entity ent-person-42 -o csv`,
 	}
 
 	cmd.AddCommand(newAssertionsListCommand(deps))
 	cmd.AddCommand(newAssertionsSearchCommand(deps))
 	cmd.AddCommand(newAssertionsSummaryCommand(deps))
+	cmd.AddCommand(newAssertionsExportCommand(deps))
 
 	return cmd
 }
@@ -187,7 +189,7 @@ Examples:
 
 // connectAssertionsToGateway creates a gRPC connection to the gateway service.
 func connectAssertionsToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -210,9 +212,11 @@ func connectAssertionsToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error)
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -617,7 +621,7 @@ func outputJSON(data any) error {
 
 // outputYAML outputs data as YAML.
 func outputYAML(data any) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(data)
 }
 
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assertionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/assertions/v1"
+)
+
+// assertionExportPageSize is the page size used to fetch assertions for
+// export; it is the server's maximum.
+const assertionExportPageSize = 500
+
+// Assertion export flags.
+var (
+	assertionExportEntity        string
+	assertionExportMinConfidence float64
+)
+
+// ExportedAssertion is one assertion with its provenance, as written by
+// 'assertions export'.
+type ExportedAssertion struct {
+	ID         int64                    `json:"id"`
+	Type       string                   `json:"type"`
+	Text       string                   `json:"text"`
+	Confidence float64                  `json:"confidence"`
+	CreatedAt  time.Time                `json:"created_at"`
+	Entities   []ExportedAssertionParty `json:"entities"`
+	Source     *ExportedAssertionSource `json:"source,omitempty"`
+	Excerpt    string                   `json:"excerpt,omitempty"`
+}
+
+// ExportedAssertionParty is an entity an assertion is attributed to.
+type ExportedAssertionParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// ExportedAssertionSource is the content an assertion was extracted from.
+type ExportedAssertionSource struct {
+	ContentID string     `json:"content_id"`
+	Type      string     `json:"type"`
+	Subject   string     `json:"subject,omitempty"`
+	From      string     `json:"from,omitempty"`
+	Date      *time.Time `json:"date,omitempty"`
+}
+
+// newAssertionsExportCommand creates the 'assertions export' subcommand.
+func newAssertionsExportCommand(deps *AssertionsCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export assertions with provenance for fact-checking",
+		Long: `Export assertions with the evidence behind them, for review outside the CLI.
+
+Each exported assertion includes its text, confidence, the entities it is
+attributed to, and the source content ID and excerpt it was extracted from.
+Every matching assertion is exported; there is no limit.
+
+Output is JSON (the default) or CSV. In CSV, entities are joined into one
+cell as "Name (role) [entity-id]".
+
+Examples:
+  # Everything, as JSON
+  penf assertions export > assertions.json
+
+  # High-confidence decisions about one person, as CSV
+  penf assertions export --entity ent-person-42 --type decision --min-confidence 0.8 -o csv
+
+  # Last month's risks
+  penf assertions export --type risk --since 30d -o csv > risks.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAssertionsExport(cmd.Context(), deps, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&assertionExportEntity, "entity", "", "Only assertions attributed to this entity ID")
+	cmd.Flags().Float64Var(&assertionExportMinConfidence, "min-confidence", 0, "Only assertions with at least this confidence (0.0-1.0)")
+	cmd.Flags().StringVar(&assertionType, "type", "", "Filter by assertion type")
+	cmd.Flags().StringVar(&assertionSince, "since", "", "Filter by date >= since (e.g., 7d, 2024-01-01)")
+	cmd.Flags().StringVar(&assertionUntil, "until", "", "Filter by date <= until")
+	cmd.Flags().Int64Var(&assertionProjectID, "project-id", 0, "Filter by project ID")
+	cmd.Flags().StringVarP(&assertionOutput, "output", "o", "", "Output format: json (default), csv")
+
+	return cmd
+}
+
+// runAssertionsExport executes the assertions export command.
+func runAssertionsExport(ctx context.Context, deps *AssertionsCommandDeps, w io.Writer) error {
+	format := assertionOutput
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid output format for export: %s (use json or csv)", format)
+	}
+	if assertionExportMinConfidence < 0 || assertionExportMinConfidence > 1 {
+		return fmt.Errorf("--min-confidence must be between 0.0 and 1.0")
+	}
+
+	req := &assertionsv1.ListAssertionsRequest{
+		Limit:      assertionExportPageSize,
+		ShowSource: true,
+	}
+	if assertionType != "" {
+		req.AssertionType = &assertionType
+	}
+	if assertionSince != "" {
+		since, err := parseDateOrDuration(assertionSince)
+		if err != nil {
+			return fmt.Errorf("parsing --since: %w", err)
+		}
+		req.Since = timestamppb.New(*since)
+	}
+	if assertionUntil != "" {
+		until, err := parseDateOrDuration(assertionUntil)
+		if err != nil {
+			return fmt.Errorf("parsing --until: %w", err)
+		}
+		req.Until = timestamppb.New(*until)
+	}
+	if assertionProjectID > 0 {
+		req.ProjectId = &assertionProjectID
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectAssertionsToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := assertionsv1.NewAssertionsServiceClient(conn)
+	req.TenantId = getTenantIDForAssertions(deps)
+
+	assertions, err := fetchAllAssertions(ctx, client.ListAssertions, req)
+	if err != nil {
+		return err
+	}
+
+	exported := exportAssertions(assertions, assertionExportEntity, assertionExportMinConfidence)
+
+	if format == "csv" {
+		return outputAssertionExportCSV(w, exported)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(exported)
+}
+
+// fetchAllAssertions pages through ListAssertions until every matching
+// assertion has been fetched.
+func fetchAllAssertions(ctx context.Context, list func(context.Context, *assertionsv1.ListAssertionsRequest, ...grpc.CallOption) (*assertionsv1.ListAssertionsResponse, error), req *assertionsv1.ListAssertionsRequest) ([]*assertionsv1.AssertionDetail, error) {
+	var all []*assertionsv1.AssertionDetail
+	for {
+		resp, err := list(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("listing assertions: %w", err)
+		}
+		all = append(all, resp.Assertions...)
+
+		if len(resp.Assertions) < int(req.Limit) || int64(len(all)) >= resp.TotalCount {
+			return all, nil
+		}
+		req.Offset += int32(len(resp.Assertions))
+	}
+}
+
+// exportAssertions converts assertions to their export form, keeping those
+// attributed to entityID (if set) with at least minConfidence.
+func exportAssertions(assertions []*assertionsv1.AssertionDetail, entityID string, minConfidence float64) []ExportedAssertion {
+	exported := []ExportedAssertion{}
+	for _, a := range assertions {
+		if float64(a.Confidence) < minConfidence {
+			continue
+		}
+		if entityID != "" && !assertionAttributedTo(a, entityID) {
+			continue
+		}
+
+		e := ExportedAssertion{
+			ID:         a.Id,
+			Type:       a.AssertionType,
+			Text:       a.Description,
+			Confidence: exportConfidence(a.Confidence),
+			Entities:   []ExportedAssertionParty{},
+		}
+		if a.CreatedAt != nil {
+			e.CreatedAt = a.CreatedAt.AsTime()
+		}
+		if a.SourceQuote != nil {
+			e.Excerpt = *a.SourceQuote
+		}
+		for _, attr := range a.AttributedTo {
+			e.Entities = append(e.Entities, ExportedAssertionParty{ID: attr.EntityId, Name: attr.Name, Role: attr.Role})
+		}
+		if src := a.Source; src != nil {
+			e.Source = &ExportedAssertionSource{
+				ContentID: src.ContentId,
+				Type:      src.SourceType,
+				Subject:   src.GetSubject(),
+				From:      src.GetFrom(),
+			}
+			if src.Date != nil {
+				date := src.Date.AsTime()
+				e.Source.Date = &date
+			}
+		}
+		exported = append(exported, e)
+	}
+	return exported
+}
+
+// assertionAttributedTo reports whether entityID is among an assertion's
+// attributions. Numeric and prefixed forms of the same ID match each other.
+func assertionAttributedTo(a *assertionsv1.AssertionDetail, entityID string) bool {
+	wantNum, wantErr := ParseEntityID(entityID)
+	for _, attr := range a.AttributedTo {
+		if attr.EntityId == entityID {
+			return true
+		}
+		if gotNum, err := ParseEntityID(attr.EntityId); wantErr == nil && err == nil && gotNum == wantNum {
+			return true
+		}
+	}
+	return false
+}
+
+// exportConfidence widens a float32 confidence to the float64 with the same
+// shortest decimal form, so 0.9 is exported as 0.9 rather than
+// 0.8999999761581421.
+func exportConfidence(c float32) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(c), 'f', -1, 32), 64)
+	return f
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assertionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/assertions/v1"
+)
+
+func testExportAssertions() []*assertionsv1.AssertionDetail {
+	created := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	return []*assertionsv1.AssertionDetail{
+		{
+			Id:            1,
+			AssertionType: "decision",
+			Description:   "Ship the migration on Friday",
+			SourceQuote:   proto.String("Let's ship it Friday."),
+			Confidence:    0.9,
+			AttributedTo:  []*assertionsv1.Attribution{{EntityId: "ent-person-42", Name: "Alice", Role: "decision_maker"}},
+			Source: &assertionsv1.SourceContext{
+				ContentId:  "mt-abc",
+				SourceType: "meeting",
+				Subject:    proto.String("Weekly sync"),
+				Date:       timestamppb.New(created),
+			},
+			CreatedAt: timestamppb.New(created),
+		},
+		{
+			Id:            2,
+			AssertionType: "risk",
+			Description:   "Budget may overrun",
+			Confidence:    0.4,
+			AttributedTo:  []*assertionsv1.Attribution{{EntityId: "ent-person-7", Name: "Bob", Role: "owner"}},
+		},
+	}
+}
+
+func TestExportAssertions_Filters(t *testing.T) {
+	all := exportAssertions(testExportAssertions(), "", 0)
+	if len(all) != 2 {
+		t.Fatalf("got %d assertions, want 2", len(all))
+	}
+
+	confident := exportAssertions(testExportAssertions(), "", 0.5)
+	if len(confident) != 1 || confident[0].ID != 1 {
+		t.Errorf("--min-confidence 0.5 kept %+v, want only assertion 1", confident)
+	}
+
+	// A bare numeric ID matches the prefixed attribution.
+	byEntity := exportAssertions(testExportAssertions(), "7", 0)
+	if len(byEntity) != 1 || byEntity[0].ID != 2 {
+		t.Errorf("--entity 7 kept %+v, want only assertion 2", byEntity)
+	}
+
+	none := exportAssertions(testExportAssertions(), "ent-person-99", 0)
+	if len(none) != 0 {
+		t.Errorf("--entity ent-person-99 kept %d assertions, want 0", len(none))
+	}
+}
+
+func TestExportAssertions_Provenance(t *testing.T) {
+	e := exportAssertions(testExportAssertions(), "", 0)[0]
+
+	if e.Excerpt != "Let's ship it Friday." {
+		t.Errorf("Excerpt = %q", e.Excerpt)
+	}
+	if e.Source == nil || e.Source.ContentID != "mt-abc" || e.Source.Subject != "Weekly sync" {
+		t.Errorf("Source = %+v, want mt-abc / Weekly sync", e.Source)
+	}
+	if len(e.Entities) != 1 || e.Entities[0].Name != "Alice" || e.Entities[0].Role != "decision_maker" {
+		t.Errorf("Entities = %+v", e.Entities)
+	}
+}
+
+func TestOutputAssertionExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := outputAssertionExportCSV(&buf, exportAssertions(testExportAssertions(), "", 0)); err != nil {
+		t.Fatalf("outputAssertionExportCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want header + 2", len(records))
+	}
+
+	row := map[string]string{}
+	for i, col := range records[0] {
+		row[col] = records[1][i]
+	}
+	if row["entities"] != "Alice (decision_maker) [ent-person-42]" {
+		t.Errorf("entities = %q", row["entities"])
+	}
+	if row["source_content_id"] != "mt-abc" || row["excerpt"] != "Let's ship it Friday." {
+		t.Errorf("provenance columns = %q, %q", row["source_content_id"], row["excerpt"])
+	}
+	if row["confidence"] != "0.9" {
+		t.Errorf("confidence = %q", row["confidence"])
+	}
+}
+
+func TestFetchAllAssertions_Pages(t *testing.T) {
+	var offsets []int32
+	list := func(ctx context.Context, req *assertionsv1.ListAssertionsRequest, _ ...grpc.CallOption) (*assertionsv1.ListAssertionsResponse, error) {
+		offsets = append(offsets, req.Offset)
+		var page []*assertionsv1.AssertionDetail
+		for i := req.Offset; i < req.Offset+req.Limit && i < 5; i++ {
+			page = append(page, &assertionsv1.AssertionDetail{Id: int64(i)})
+		}
+		return &assertionsv1.ListAssertionsResponse{Assertions: page, TotalCount: 5}, nil
+	}
+
+	got, err := fetchAllAssertions(context.Background(), list, &assertionsv1.ListAssertionsRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("fetchAllAssertions() error = %v", err)
+	}
+	if len(got) != 5 {
+		t.Errorf("got %d assertions, want 5", len(got))
+	}
+	if len(offsets) != 3 || offsets[2] != 4 {
+		t.Errorf("offsets = %v, want [0 2 4]", offsets)
+	}
+}
+
+func TestRunAssertionsExport_RejectsTextFormat(t *testing.T) {
+	orig := assertionOutput
+	t.Cleanup(func() { assertionOutput = orig })
+	assertionOutput = "text"
+
+	if err := runAssertionsExport(context.Background(), DefaultAssertionsDeps(), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for -o text")
+	}
+}
+
+func TestNewAssertionsCommand_ExportDoesNotChangeListDefault(t *testing.T) {
+	NewAssertionsCommand(nil)
+	if assertionOutput != "" {
+		t.Errorf("assertionOutput = %q after building commands, want empty", assertionOutput)
+	}
+}
@@ -68,6 +68,9 @@ mention resolution, including:
 Each trace includes timing, decisions, reasoning, and optionally
 full LLM prompts/responses for debugging.
 
+Use 'penf audit log' to see who ran which commands (merges, deletes,
+reprocessing) and when.
+
 Entity resolution details are in Context Palace knowledge shards.`,
 	}
 
@@ -81,6 +84,7 @@ Entity resolution details are in Context Palace knowledge shards.`,
 	cmd.AddCommand(newAuditCorrectionsCommand(deps))
 	cmd.AddCommand(newAuditComparisonsCommand(deps))
 	cmd.AddCommand(newAuditModelsCommand(deps))
+	cmd.AddCommand(newAuditLogCommand(deps))
 
 	return cmd
 }
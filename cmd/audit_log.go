@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/otherjamesbrown/penf-cli/contextpalace"
+)
+
+// Audit log flags.
+var (
+	auditActor     string
+	auditAction    string
+	auditFrom      string
+	auditTo        string
+	auditPageToken string
+	auditAllPages  bool
+)
+
+// AuditEvent is one logged command in 'audit log' output.
+type AuditEvent struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Command string    `json:"command"`
+	Target  string    `json:"target,omitempty"`
+	Details string    `json:"details,omitempty"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// newAuditLogCommand creates the 'audit log' subcommand.
+func newAuditLogCommand(deps *AuditCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show who ran which commands",
+		Long: `Show the command log recorded in Context-Palace: who ran which penf
+command, against what, and when.
+
+Every command is logged when Context-Palace is configured. The actor is the
+Context-Palace agent that ran it, the action is the subcommand (merge,
+delete, reprocess, ...) and the target is its first argument.
+
+--from and --to accept durations ("2h", "7d"), timestamps ("2026-01-15",
+RFC 3339) and "today" or "yesterday". Results are paged back in time from
+the most recent match; each page is shown oldest first.
+
+Examples:
+  # Who merged entity 123?
+  penf audit log --action merge | grep 123
+
+  # What did one agent change last week?
+  penf audit log --actor agent-mycroft --from 7d
+
+  # Deletes between two dates
+  penf audit log --action delete --from 2026-01-01 --to 2026-02-01
+
+  # Page through a long history
+  penf audit log --limit 50 --page-token <token>
+  penf audit log --action reprocess --all -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuditLog(cmd.Context(), deps, cmd.Root())
+		},
+	}
+
+	cmd.Flags().StringVar(&auditActor, "actor", "", "Filter by actor (Context-Palace agent)")
+	cmd.Flags().StringVar(&auditAction, "action", "", "Filter by action (e.g., merge, delete, reprocess)")
+	cmd.Flags().StringVar(&auditFrom, "from", "", "Only commands at or after this time (e.g., 2h, 7d, yesterday, 2026-01-15)")
+	cmd.Flags().StringVar(&auditTo, "to", "", "Only commands before this time")
+	cmd.Flags().StringVar(&auditPageToken, "page-token", "", "Page token from a previous response")
+	cmd.Flags().BoolVar(&auditAllPages, "all", false, "Fetch all pages")
+
+	return cmd
+}
+
+// runAuditLog queries the Context-Palace command log.
+func runAuditLog(ctx context.Context, deps *AuditCommandDeps, root *cobra.Command) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if !cfg.ContextPalace.IsConfigured() {
+		return fmt.Errorf("the audit log is read from Context-Palace, which is not configured (see 'penf doctor')")
+	}
+
+	query := contextpalace.CommandQuery{
+		Agent: auditActor,
+		Word:  strings.ToLower(strings.TrimSpace(auditAction)),
+		Limit: auditLimit,
+	}
+	if query.Since, err = parseAuditTime(auditFrom); err != nil {
+		return fmt.Errorf("parsing --from value: %w", err)
+	}
+	if query.Until, err = parseAuditTime(auditTo); err != nil {
+		return fmt.Errorf("parsing --to value: %w", err)
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return fmt.Errorf("--from must be before --to")
+	}
+	if query.Offset, err = parseAuditPageToken(auditPageToken); err != nil {
+		return err
+	}
+
+	cp, err := contextpalace.NewClient(cfg.ContextPalace)
+	if err != nil {
+		return fmt.Errorf("connecting to Context-Palace: %w", err)
+	}
+	defer cp.Close()
+
+	events, nextPageToken, err := fetchAuditEvents(ctx, cp.QueryCommands, query, auditAllPages, root)
+	if err != nil {
+		return err
+	}
+
+	switch auditOutput {
+	case "json":
+		err = outputAuditJSON(events)
+		printNextPageHint(os.Stderr, nextPageToken)
+		return err
+	case "yaml":
+		err = outputAuditYAML(events)
+		printNextPageHint(os.Stderr, nextPageToken)
+		return err
+	default:
+		if len(events) == 0 {
+			fmt.Println("No commands found.")
+			return nil
+		}
+		if err := outputAuditEventsTable(os.Stdout, events); err != nil {
+			return err
+		}
+		printNextPageHint(os.Stdout, nextPageToken)
+		return nil
+	}
+}
+
+// fetchAuditEvents runs query, one page or every page, and returns the events
+// in chronological order with the token for the next (older) page. One extra
+// row is requested per page to learn whether another page exists.
+func fetchAuditEvents(ctx context.Context, queryCommands func(context.Context, contextpalace.CommandQuery) ([]contextpalace.CommandEntry, error), query contextpalace.CommandQuery, all bool, root *cobra.Command) ([]AuditEvent, string, error) {
+	pageSize := query.Limit
+	query.Limit = pageSize + 1
+
+	var entries []contextpalace.CommandEntry
+	nextPageToken := ""
+	for {
+		page, err := queryCommands(ctx, query)
+		if err != nil {
+			return nil, "", fmt.Errorf("querying audit log: %w", err)
+		}
+
+		more := len(page) > pageSize
+		if more {
+			page = page[:pageSize]
+		}
+		entries = append(entries, page...)
+		query.Offset += len(page)
+
+		if !more {
+			break
+		}
+		if !all {
+			nextPageToken = strconv.Itoa(query.Offset)
+			break
+		}
+	}
+
+	// Entries arrive newest first; show them oldest first.
+	events := make([]AuditEvent, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		events = append(events, auditEventFromEntry(root, entries[i]))
+	}
+	return events, nextPageToken, nil
+}
+
+// auditEventFromEntry resolves a logged command line against the command tree
+// to find its action (the subcommand run) and target (its first argument).
+func auditEventFromEntry(root *cobra.Command, entry contextpalace.CommandEntry) AuditEvent {
+	event := AuditEvent{
+		Time:    entry.CreatedAt,
+		Actor:   entry.Agent,
+		Action:  entry.Command,
+		Command: entry.FullCommand,
+		Success: entry.Success,
+		Error:   entry.ErrorMessage,
+	}
+
+	// The first word is the binary as invoked, e.g. "penf" or a full path.
+	words := strings.Fields(entry.FullCommand)
+	if len(words) < 2 {
+		return event
+	}
+	found, rest, err := root.Find(words[1:])
+	if err != nil || found == root {
+		return event
+	}
+
+	event.Action = strings.TrimPrefix(found.CommandPath(), root.Name()+" ")
+	positional, flags := splitAuditArgs(found, rest)
+	if len(positional) > 0 {
+		event.Target = positional[0]
+		positional = positional[1:]
+	}
+	event.Details = strings.Join(append(positional, flags...), " ")
+	return event
+}
+
+// splitAuditArgs separates positional arguments from flags (with their
+// values) using cmd's flag definitions, without parsing into the flag
+// variables themselves.
+func splitAuditArgs(cmd *cobra.Command, args []string) (positional, flags []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		flags = append(flags, arg)
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		flag := lookupAuditFlag(cmd, name)
+		if flag != nil && flag.NoOptDefVal == "" && i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	return positional, flags
+}
+
+// lookupAuditFlag finds a local or inherited flag by name or shorthand.
+func lookupAuditFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.InheritedFlags()} {
+		if flag := fs.Lookup(name); flag != nil {
+			return flag
+		}
+		if len(name) == 1 {
+			if flag := fs.ShorthandLookup(name); flag != nil {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+// parseAuditTime parses a --from or --to value. Empty means unbounded.
+func parseAuditTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return parseTimeFilter(s)
+}
+
+// parseAuditPageToken parses an audit log page token, which is the offset of
+// the next page.
+func parseAuditPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page token: %q", token)
+	}
+	return offset, nil
+}
+
+// outputAuditEventsTable writes audit events as a chronological table.
+func outputAuditEventsTable(out io.Writer, events []AuditEvent) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTOR\tACTION\tTARGET\tDETAILS")
+	fmt.Fprintln(w, "----\t-----\t------\t------\t-------")
+
+	for _, e := range events {
+		details := e.Details
+		if !e.Success {
+			details = strings.TrimSpace("✗ " + truncate(e.Error, 40) + " " + details)
+		}
+		target := e.Target
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Actor, e.Action, target, truncate(details, 60))
+	}
+
+	return w.Flush()
+}
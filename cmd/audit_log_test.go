@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/contextpalace"
+)
+
+// auditTestRoot builds a small command tree shaped like penf's.
+func auditTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "penf"}
+	root.PersistentFlags().String("server", "", "")
+
+	rel := &cobra.Command{Use: "relationship"}
+	rel.PersistentFlags().StringP("tenant", "t", "", "")
+	entity := &cobra.Command{Use: "entity"}
+	merge := &cobra.Command{Use: "merge", Run: func(*cobra.Command, []string) {}}
+	merge.Flags().Bool("force", false, "")
+	merge.Flags().String("reason", "", "")
+
+	entity.AddCommand(merge)
+	rel.AddCommand(entity)
+	root.AddCommand(rel)
+	return root
+}
+
+func TestAuditEventFromEntry(t *testing.T) {
+	now := time.Now()
+	event := auditEventFromEntry(auditTestRoot(), contextpalace.CommandEntry{
+		Agent:       "agent-mycroft",
+		Command:     "relationship",
+		FullCommand: "/usr/local/bin/penf relationship entity merge --reason dupe -t acme 123 456 --force",
+		Success:     true,
+		CreatedAt:   now,
+	})
+
+	if event.Action != "relationship entity merge" {
+		t.Errorf("Action = %q", event.Action)
+	}
+	if event.Target != "123" {
+		t.Errorf("Target = %q, want 123", event.Target)
+	}
+	if event.Details != "456 --reason dupe -t acme --force" {
+		t.Errorf("Details = %q", event.Details)
+	}
+	if event.Actor != "agent-mycroft" || !event.Time.Equal(now) {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestAuditEventFromEntry_UnknownCommand(t *testing.T) {
+	event := auditEventFromEntry(auditTestRoot(), contextpalace.CommandEntry{
+		Command:     "removed",
+		FullCommand: "penf removed 42",
+	})
+	if event.Action != "removed" || event.Target != "" {
+		t.Errorf("event = %+v, want the logged command name and no target", event)
+	}
+}
+
+func TestFetchAuditEvents(t *testing.T) {
+	// Five entries, newest first, as the command log returns them.
+	var log []contextpalace.CommandEntry
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 4; i >= 0; i-- {
+		log = append(log, contextpalace.CommandEntry{
+			ID:          int64(i),
+			FullCommand: fmt.Sprintf("penf relationship entity merge ent-%d", i),
+			CreatedAt:   base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	query := func(ctx context.Context, q contextpalace.CommandQuery) ([]contextpalace.CommandEntry, error) {
+		start := min(q.Offset, len(log))
+		return log[start:min(start+q.Limit, len(log))], nil
+	}
+
+	events, token, err := fetchAuditEvents(context.Background(), query, contextpalace.CommandQuery{Limit: 2}, false, auditTestRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "2" || len(events) != 2 {
+		t.Fatalf("first page = %d events, token %q", len(events), token)
+	}
+	if events[0].Target != "ent-3" || events[1].Target != "ent-4" {
+		t.Errorf("first page = %s, %s; want ent-3, ent-4 (oldest first)", events[0].Target, events[1].Target)
+	}
+
+	events, token, err = fetchAuditEvents(context.Background(), query, contextpalace.CommandQuery{Limit: 2, Offset: 4}, false, auditTestRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" || len(events) != 1 || events[0].Target != "ent-0" {
+		t.Errorf("last page = %+v, token %q", events, token)
+	}
+
+	events, token, err = fetchAuditEvents(context.Background(), query, contextpalace.CommandQuery{Limit: 2}, true, auditTestRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" || len(events) != 5 || events[0].Target != "ent-0" {
+		t.Errorf("--all = %d events starting %q, token %q", len(events), events[0].Target, token)
+	}
+}
+
+func TestParseAuditTime(t *testing.T) {
+	if got, err := parseAuditTime(""); err != nil || !got.IsZero() {
+		t.Errorf("empty = %v, %v", got, err)
+	}
+	got, err := parseAuditTime("7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(got); d < 7*24*time.Hour || d > 7*24*time.Hour+time.Minute {
+		t.Errorf("7d parsed to %v ago", d)
+	}
+	if _, err := parseAuditTime("yesterday"); err != nil {
+		t.Errorf("yesterday: %v", err)
+	}
+	if _, err := parseAuditTime("last tuesday"); err == nil {
+		t.Error("expected error for unparseable time")
+	}
+}
+
+func TestParseAuditPageToken(t *testing.T) {
+	if offset, err := parseAuditPageToken("40"); err != nil || offset != 40 {
+		t.Errorf("40 = %d, %v", offset, err)
+	}
+	for _, bad := range []string{"abc", "-1"} {
+		if _, err := parseAuditPageToken(bad); err == nil {
+			t.Errorf("expected error for token %q", bad)
+		}
+	}
+}
+
+func TestOutputAuditEventsTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := outputAuditEventsTable(&buf, []AuditEvent{
+		{Actor: "agent-a", Action: "relationship entity delete", Target: "42", Success: true},
+		{Actor: "agent-b", Action: "content reprocess", Success: false, Error: "timeout"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"TIME", "ACTOR", "TARGET", "relationship entity delete  42", "✗ timeout"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"syscall"
@@ -22,8 +23,13 @@ var (
 	authToken          string
 	authServer         string
 	authNonInteractive bool
+	authWarnBefore     time.Duration
 )
 
+// defaultAuthWarnBefore is how long before a token expires that commands
+// start warning about it.
+const defaultAuthWarnBefore = 24 * time.Hour
+
 // AuthCmd represents the auth command group.
 var AuthCmd = &cobra.Command{
 	Use:   "auth",
@@ -89,11 +95,17 @@ var statusCmd = &cobra.Command{
 Shows:
   - Authentication type (API key or token)
   - Credential source (stored, environment, or none)
+  - Principal (the token's subject, if known)
   - Token expiration status (if applicable)
   - Masked credential values
 
+A warning is printed when the active token has expired or expires within
+--warn-before. Other commands print a one-line warning to stderr when the
+token expires within 24 hours.
+
 Examples:
-  penf auth status`,
+  penf auth status
+  penf auth status --warn-before 72h`,
 	RunE: runStatus,
 }
 
@@ -118,6 +130,9 @@ func init() {
 	loginCmd.Flags().StringVar(&authServer, "server", "", "Server address to associate with credentials")
 	loginCmd.Flags().BoolVar(&authNonInteractive, "non-interactive", false, "Fail instead of prompting for input")
 
+	// Status flags
+	statusCmd.Flags().DurationVar(&authWarnBefore, "warn-before", defaultAuthWarnBefore, "Warn when the token expires within this duration")
+
 	// Add subcommands
 	AuthCmd.AddCommand(loginCmd)
 	AuthCmd.AddCommand(logoutCmd)
@@ -188,6 +203,15 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid credentials: %w", err)
 	}
 
+	// Record the token's subject and expiry so they can be shown and checked
+	// without decrypting it.
+	if creds.AuthType == credentials.AuthTypeToken {
+		if claims, err := credentials.ParseTokenClaims(creds.Token); err == nil {
+			creds.Subject = claims.Subject
+			creds.ExpiresAt = claims.ExpiresAt
+		}
+	}
+
 	// Save credentials
 	if err := store.Save(creds); err != nil {
 		return fmt.Errorf("saving credentials: %w", err)
@@ -359,6 +383,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		}
 		if envToken != "" {
 			fmt.Printf("  PENF_TOKEN: %s (active)\n", credentials.MaskToken(envToken))
+			if claims, err := credentials.ParseTokenClaims(envToken); err == nil {
+				printTokenClaims(claims)
+			}
 		} else {
 			fmt.Println("  PENF_TOKEN: (not set)")
 		}
@@ -373,6 +400,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			if !hasEnvCreds {
 				fmt.Println("\nNot authenticated. Run 'penf auth login' to authenticate.")
 			}
+			printActiveCredentialWarning(authWarnBefore)
 			return nil
 		}
 		return fmt.Errorf("loading credentials: %w", err)
@@ -387,6 +415,15 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Key ID: %s\n", credentials.GenerateAPIKeyID(creds.APIKey))
 	case credentials.AuthTypeToken:
 		fmt.Printf("  Token: %s\n", credentials.MaskToken(creds.Token))
+		// Tokens stored before their claims were recorded.
+		if claims, err := credentials.ParseTokenClaims(creds.Token); err == nil {
+			if creds.ExpiresAt.IsZero() {
+				creds.ExpiresAt = claims.ExpiresAt
+			}
+			if creds.Subject == "" {
+				creds.Subject = claims.Subject
+			}
+		}
 		if !creds.ExpiresAt.IsZero() {
 			fmt.Printf("  Expires: %s (%s)\n",
 				creds.ExpiresAt.Format(time.RFC3339),
@@ -418,18 +455,81 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		fmt.Println("Active Credential Source: Stored credentials")
 	}
 
-	// Check for expiration
-	if creds.AuthType == credentials.AuthTypeToken && !creds.ExpiresAt.IsZero() {
-		if time.Now().After(creds.ExpiresAt) {
-			fmt.Println("\nWarning: Stored token has expired. Run 'penf auth refresh' or 'penf auth login'.")
-		} else if time.Until(creds.ExpiresAt) < time.Hour {
-			fmt.Println("\nWarning: Token expires soon. Consider running 'penf auth refresh'.")
-		}
+	// Check for expiration of the credential in use.
+	if hasEnvCreds {
+		printActiveCredentialWarning(authWarnBefore)
+	} else if warning := credentialExpiryWarning(creds.AuthType, creds.ExpiresAt, time.Now(), authWarnBefore); warning != "" {
+		fmt.Printf("\nWarning: %s\n", warning)
 	}
 
 	return nil
 }
 
+// printTokenClaims prints the principal and expiry read from a token.
+func printTokenClaims(claims credentials.TokenClaims) {
+	if claims.Subject != "" {
+		fmt.Printf("    Subject: %s\n", claims.Subject)
+	}
+	if !claims.ExpiresAt.IsZero() {
+		fmt.Printf("    Expires: %s (%s)\n", claims.ExpiresAt.Format(time.RFC3339), credentials.FormatExpiry(claims.ExpiresAt))
+	}
+}
+
+// printActiveCredentialWarning prints a warning if the active credential is a
+// token that has expired or expires within warnBefore.
+func printActiveCredentialWarning(warnBefore time.Duration) {
+	authType, expiresAt, err := activeCredentialExpiry()
+	if err != nil {
+		return
+	}
+	if warning := credentialExpiryWarning(authType, expiresAt, time.Now(), warnBefore); warning != "" {
+		fmt.Printf("\nWarning: %s\n", warning)
+	}
+}
+
+// activeCredentialExpiry returns the auth type and expiry of the credential
+// commands will use: PENF_API_KEY, then PENF_TOKEN, then the stored
+// credentials. Nothing is decrypted.
+func activeCredentialExpiry() (authType string, expiresAt time.Time, err error) {
+	if os.Getenv("PENF_API_KEY") != "" {
+		return credentials.AuthTypeAPIKey, time.Time{}, nil
+	}
+	if token := os.Getenv("PENF_TOKEN"); token != "" {
+		claims, _ := credentials.ParseTokenClaims(token)
+		return credentials.AuthTypeToken, claims.ExpiresAt, nil
+	}
+	return credentials.StoredExpiry()
+}
+
+// credentialExpiryWarning returns a warning if a token has expired or expires
+// within warnBefore of now, or "" if not. API keys do not expire.
+func credentialExpiryWarning(authType string, expiresAt, now time.Time, warnBefore time.Duration) string {
+	if authType != credentials.AuthTypeToken || expiresAt.IsZero() {
+		return ""
+	}
+	if !now.Before(expiresAt) {
+		return fmt.Sprintf("token expired at %s. Run 'penf auth refresh' or 'penf auth login'.", expiresAt.Format(time.RFC3339))
+	}
+	if expiresAt.Sub(now) <= warnBefore {
+		return fmt.Sprintf("token expires in %s (%s). Run 'penf auth refresh' or 'penf auth login'.",
+			credentials.FormatExpiry(expiresAt), expiresAt.Format(time.RFC3339))
+	}
+	return ""
+}
+
+// WarnIfCredentialExpiring prints a one-line warning to w when the active
+// token has expired or expires within 24 hours. It reads only the unencrypted
+// expiry, so it is cheap enough to run before every command.
+func WarnIfCredentialExpiring(w io.Writer) {
+	authType, expiresAt, err := activeCredentialExpiry()
+	if err != nil {
+		return
+	}
+	if warning := credentialExpiryWarning(authType, expiresAt, time.Now(), defaultAuthWarnBefore); warning != "" {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+}
+
 // runRefresh handles the refresh command.
 func runRefresh(cmd *cobra.Command, args []string) error {
 	store, err := credentials.NewStore()
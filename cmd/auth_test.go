@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/base64"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -270,6 +273,9 @@ func TestRunLogin_WithTokenFlag(t *testing.T) {
 	if creds.AuthType != credentials.AuthTypeToken {
 		t.Errorf("AuthType = %v, want %v", creds.AuthType, credentials.AuthTypeToken)
 	}
+	if creds.Subject != "test" {
+		t.Errorf("Subject = %q, want the token's sub claim", creds.Subject)
+	}
 }
 
 func TestRunLogin_WithEnvVar(t *testing.T) {
@@ -714,3 +720,56 @@ func TestRunRefresh_WithTokenNoRefreshToken(t *testing.T) {
 		t.Errorf("runRefresh() error = %v, expected 'no refresh token'", err)
 	}
 }
+
+func TestCredentialExpiryWarning(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		authType  string
+		expiresAt time.Time
+		want      string
+	}{
+		{"api key", credentials.AuthTypeAPIKey, now.Add(time.Hour), ""},
+		{"no expiry", credentials.AuthTypeToken, time.Time{}, ""},
+		{"far off", credentials.AuthTypeToken, now.Add(48 * time.Hour), ""},
+		{"within threshold", credentials.AuthTypeToken, now.Add(3*time.Hour + time.Minute), "token expires in 3 hours"},
+		{"expired", credentials.AuthTypeToken, now.Add(-time.Minute), "token expired at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := credentialExpiryWarning(tt.authType, tt.expiresAt, now, defaultAuthWarnBefore)
+			if tt.want == "" && got != "" || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("credentialExpiryWarning() = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnIfCredentialExpiring_EnvToken(t *testing.T) {
+	originalAPIKey := os.Getenv("PENF_API_KEY")
+	originalToken := os.Getenv("PENF_TOKEN")
+	defer func() {
+		os.Setenv("PENF_API_KEY", originalAPIKey)
+		os.Setenv("PENF_TOKEN", originalToken)
+	}()
+	os.Unsetenv("PENF_API_KEY")
+
+	exp := time.Now().Add(2 * time.Hour).Unix()
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"alice","exp":%d}`, exp)))
+	os.Setenv("PENF_TOKEN", "eyJhbGciOiJIUzI1NiJ9."+payload+".sig")
+
+	var buf bytes.Buffer
+	WarnIfCredentialExpiring(&buf)
+	if !strings.HasPrefix(buf.String(), "Warning: token expires in") {
+		t.Errorf("warning = %q", buf.String())
+	}
+
+	// An API key takes precedence and does not expire.
+	os.Setenv("PENF_API_KEY", "pf-test-key-12345")
+	buf.Reset()
+	WarnIfCredentialExpiring(&buf)
+	if buf.Len() != 0 {
+		t.Errorf("expected no warning for an API key, got %q", buf.String())
+	}
+}
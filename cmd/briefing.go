@@ -7,12 +7,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	projectv1 "github.com/otherjamesbrown/penf-cli/api/proto/project/v1"
 	watchlistv1 "github.com/otherjamesbrown/penf-cli/api/proto/watchlist/v1"
@@ -25,9 +25,42 @@ var (
 	briefingTier     int32
 	briefingLimit    int32
 	briefingOutput   string
+	briefingSince    string
+	briefingCompare  string
 	escalationSource int64
 )
 
+// briefingWindowFetchLimit is how many assertions are fetched when the
+// briefing is scoped with --since. The window is applied client-side, so
+// more than --limit are fetched to avoid losing recent, lower-priority items.
+const briefingWindowFetchLimit = 500
+
+// BriefingReport is the structured output of a briefing scoped with --since.
+type BriefingReport struct {
+	Project    string                           `json:"project" yaml:"project"`
+	Since      time.Time                        `json:"since" yaml:"since"`
+	Until      time.Time                        `json:"until" yaml:"until"`
+	Assertions []*watchlistv1.BriefingAssertion `json:"assertions" yaml:"assertions"`
+	Comparison *BriefingComparison              `json:"comparison,omitempty" yaml:"comparison,omitempty"`
+}
+
+// BriefingComparison compares a briefing window against the period before it.
+type BriefingComparison struct {
+	Since      time.Time       `json:"since" yaml:"since"`
+	Until      time.Time       `json:"until" yaml:"until"`
+	Total      BriefingDelta   `json:"total" yaml:"total"`
+	Tiers      []BriefingDelta `json:"tiers" yaml:"tiers"`
+	Severities []BriefingDelta `json:"severities" yaml:"severities"`
+}
+
+// BriefingDelta is an assertion count in the current and prior periods.
+type BriefingDelta struct {
+	Label   string `json:"label" yaml:"label"`
+	Current int    `json:"current" yaml:"current"`
+	Prior   int    `json:"prior" yaml:"prior"`
+	Change  int    `json:"change" yaml:"change"`
+}
+
 // BriefingCommandDeps holds the dependencies for briefing commands.
 type BriefingCommandDeps struct {
 	Config     *config.CLIConfig
@@ -61,11 +94,20 @@ Displays assertions grouped by priority tiers:
 Within each tier, assertions are ordered by severity (critical > high > medium > low),
 then by recency.
 
+Use --since to only include assertions updated in a recent window, e.g. for a
+weekly check-in. --compare-to sets the start of a prior period, running up to
+--since, and adds a comparison of assertion counts by tier and severity. Both
+accept durations ("7d", "24h"), dates ("2026-01-15"), RFC 3339 timestamps and
+"today" or "yesterday".
+
 Examples:
   penf briefing "MTC 2026"
   penf briefing "MTC 2026" --tier 1
   penf briefing "MTC 2026" --limit 20
-  penf briefing "MTC 2026" -o json`,
+  penf briefing "MTC 2026" -o json
+
+  # What changed this week, compared to the week before
+  penf briefing "MTC 2026" --since 7d --compare-to 14d`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runBriefing(cmd.Context(), deps, args[0])
@@ -75,6 +117,8 @@ Examples:
 	cmd.Flags().Int32Var(&briefingTier, "tier", 0, "Filter to specific tier (1-4)")
 	cmd.Flags().Int32Var(&briefingLimit, "limit", 50, "Maximum number of assertions")
 	cmd.Flags().StringVarP(&briefingOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().StringVar(&briefingSince, "since", "", "Only include assertions updated since (e.g., 7d, yesterday, 2026-01-15)")
+	cmd.Flags().StringVar(&briefingCompare, "compare-to", "", "Compare against the period from this time up to --since")
 
 	return cmd
 }
@@ -125,7 +169,7 @@ Related Commands:
 
 // connectBriefingToGateway creates a gRPC connection to the gateway service.
 func connectBriefingToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -148,9 +192,11 @@ func connectBriefingToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -180,6 +226,25 @@ func getUserIDForBriefing() string {
 
 // runBriefing executes the briefing command.
 func runBriefing(ctx context.Context, deps *BriefingCommandDeps, projectName string) error {
+	var since, compareTo time.Time
+	var err error
+	if briefingSince != "" {
+		if since, err = parseTimeFilter(briefingSince); err != nil {
+			return fmt.Errorf("parsing --since value: %w", err)
+		}
+	}
+	if briefingCompare != "" {
+		if since.IsZero() {
+			return fmt.Errorf("--compare-to requires --since")
+		}
+		if compareTo, err = parseTimeFilter(briefingCompare); err != nil {
+			return fmt.Errorf("parsing --compare-to value: %w", err)
+		}
+		if !compareTo.Before(since) {
+			return fmt.Errorf("--compare-to must be before --since")
+		}
+	}
+
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -210,11 +275,16 @@ func runBriefing(ctx context.Context, deps *BriefingCommandDeps, projectName str
 	watchlistClient := watchlistv1.NewWatchListServiceClient(conn)
 	userID := getUserIDForBriefing()
 
+	limit := briefingLimit
+	if !since.IsZero() {
+		limit = max(limit, briefingWindowFetchLimit)
+	}
+
 	resp, err := watchlistClient.GetBriefingAssertions(ctx, &watchlistv1.GetBriefingAssertionsRequest{
 		TenantId:  tenantID,
 		UserId:    userID,
 		ProjectId: projectID,
-		Limit:     briefingLimit,
+		Limit:     limit,
 	})
 	if err != nil {
 		return fmt.Errorf("getting briefing assertions: %w", err)
@@ -232,7 +302,83 @@ func runBriefing(ctx context.Context, deps *BriefingCommandDeps, projectName str
 		assertions = filtered
 	}
 
-	return outputBriefing(cfg, projectResp.Project.Name, assertions)
+	if since.IsZero() {
+		return outputBriefing(cfg, projectResp.Project.Name, assertions)
+	}
+
+	report := buildBriefingReport(projectResp.Project.Name, assertions, since, compareTo, time.Now())
+	if len(report.Assertions) > int(briefingLimit) {
+		report.Assertions = report.Assertions[:briefingLimit]
+	}
+	return outputBriefingReport(cfg, report)
+}
+
+// buildBriefingReport keeps the assertions updated in [since, until) and, if
+// compareTo is set, compares their counts with those updated in
+// [compareTo, since).
+func buildBriefingReport(projectName string, assertions []*watchlistv1.BriefingAssertion, since, compareTo, until time.Time) BriefingReport {
+	report := BriefingReport{
+		Project:    projectName,
+		Since:      since,
+		Until:      until,
+		Assertions: briefingAssertionsBetween(assertions, since, until),
+	}
+	if compareTo.IsZero() {
+		return report
+	}
+
+	prior := briefingAssertionsBetween(assertions, compareTo, since)
+	comparison := &BriefingComparison{
+		Since: compareTo,
+		Until: since,
+		Total: newBriefingDelta("Total", len(report.Assertions), len(prior)),
+	}
+
+	countBy := func(items []*watchlistv1.BriefingAssertion, key func(*watchlistv1.BriefingAssertion) string) map[string]int {
+		counts := make(map[string]int)
+		for _, a := range items {
+			counts[key(a)]++
+		}
+		return counts
+	}
+
+	tierKey := func(a *watchlistv1.BriefingAssertion) string { return formatTierName(a.PriorityTier) }
+	currentTiers, priorTiers := countBy(report.Assertions, tierKey), countBy(prior, tierKey)
+	for tier := int32(1); tier <= 4; tier++ {
+		name := formatTierName(tier)
+		comparison.Tiers = append(comparison.Tiers, newBriefingDelta(name, currentTiers[name], priorTiers[name]))
+	}
+
+	severityKey := func(a *watchlistv1.BriefingAssertion) string { return strings.ToLower(a.Severity) }
+	currentSeverities, priorSeverities := countBy(report.Assertions, severityKey), countBy(prior, severityKey)
+	for _, severity := range []string{"critical", "high", "medium", "low"} {
+		comparison.Severities = append(comparison.Severities,
+			newBriefingDelta(severity, currentSeverities[severity], priorSeverities[severity]))
+	}
+
+	report.Comparison = comparison
+	return report
+}
+
+// briefingAssertionsBetween returns the assertions updated in [from, to),
+// preserving their priority order.
+func briefingAssertionsBetween(assertions []*watchlistv1.BriefingAssertion, from, to time.Time) []*watchlistv1.BriefingAssertion {
+	result := []*watchlistv1.BriefingAssertion{}
+	for _, a := range assertions {
+		if a.UpdatedAt == nil {
+			continue
+		}
+		updated := a.UpdatedAt.AsTime()
+		if !updated.Before(from) && updated.Before(to) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// newBriefingDelta returns a BriefingDelta for the given counts.
+func newBriefingDelta(label string, current, prior int) BriefingDelta {
+	return BriefingDelta{Label: label, Current: current, Prior: prior, Change: current - prior}
 }
 
 // runEscalations executes the escalations command.
@@ -290,6 +436,54 @@ func outputBriefing(cfg *config.CLIConfig, projectName string, assertions []*wat
 	}
 }
 
+// outputBriefingReport outputs a briefing scoped to a time window.
+func outputBriefingReport(cfg *config.CLIConfig, report BriefingReport) error {
+	switch getBriefingOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(report)
+	default:
+		fmt.Printf("Project: %s — Priority Briefing\n", report.Project)
+		fmt.Printf("Covering: %s → %s (%d assertions)\n\n",
+			report.Since.Local().Format("2006-01-02 15:04"), report.Until.Local().Format("2006-01-02 15:04"), len(report.Assertions))
+		if len(report.Assertions) == 0 {
+			fmt.Println("No assertions updated in this period.")
+			fmt.Println()
+		} else {
+			outputBriefingTiers(report.Assertions)
+		}
+		if report.Comparison != nil {
+			outputBriefingComparisonText(report.Comparison)
+		}
+		return nil
+	}
+}
+
+// outputBriefingComparisonText outputs a period comparison in human-readable format.
+func outputBriefingComparisonText(c *BriefingComparison) {
+	fmt.Printf("--- Compared to %s → %s ---\n",
+		c.Since.Local().Format("2006-01-02 15:04"), c.Until.Local().Format("2006-01-02 15:04"))
+
+	printDelta := func(d BriefingDelta) {
+		fmt.Printf("  %-24s %4d  (was %d, %+d)\n", d.Label, d.Current, d.Prior, d.Change)
+	}
+	printDelta(c.Total)
+	for _, d := range c.Tiers {
+		printDelta(d)
+	}
+	for _, d := range c.Severities {
+		if d.Current == 0 && d.Prior == 0 {
+			continue
+		}
+		printDelta(d)
+	}
+	fmt.Println()
+}
+
 // outputBriefingText outputs briefing in human-readable format.
 func outputBriefingText(projectName string, assertions []*watchlistv1.BriefingAssertion) error {
 	if len(assertions) == 0 {
@@ -299,6 +493,12 @@ func outputBriefingText(projectName string, assertions []*watchlistv1.BriefingAs
 	}
 
 	fmt.Printf("Project: %s — Priority Briefing\n\n", projectName)
+	outputBriefingTiers(assertions)
+	return nil
+}
+
+// outputBriefingTiers outputs assertions grouped by priority tier.
+func outputBriefingTiers(assertions []*watchlistv1.BriefingAssertion) {
 
 	// Group assertions by tier.
 	tierGroups := make(map[int32][]*watchlistv1.BriefingAssertion)
@@ -338,8 +538,6 @@ func outputBriefingText(projectName string, assertions []*watchlistv1.BriefingAs
 		}
 		fmt.Println()
 	}
-
-	return nil
 }
 
 // outputBriefingJSON outputs briefing as JSON.
@@ -351,7 +549,7 @@ func outputBriefingJSON(assertions []*watchlistv1.BriefingAssertion) error {
 
 // outputBriefingYAML outputs briefing as YAML.
 func outputBriefingYAML(assertions []*watchlistv1.BriefingAssertion) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(assertions)
 }
 
@@ -398,7 +596,7 @@ func outputEscalationsJSON(escalations []*watchlistv1.SeniorityEscalation) error
 
 // outputEscalationsYAML outputs escalations as YAML.
 func outputEscalationsYAML(escalations []*watchlistv1.SeniorityEscalation) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(escalations)
 }
 
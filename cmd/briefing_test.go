@@ -3,9 +3,13 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	watchlistv1 "github.com/otherjamesbrown/penf-cli/api/proto/watchlist/v1"
 )
 
 // TestNewBriefingCommand verifies the briefing command structure.
@@ -206,3 +210,72 @@ func TestEscalationsCommandFlags(t *testing.T) {
 	outputFlag := cmd.Flags().Lookup("output")
 	assert.Equal(t, "yaml", outputFlag.Value.String())
 }
+
+// TestBriefingCommand_SinceFlags verifies the time window flags exist.
+func TestBriefingCommand_SinceFlags(t *testing.T) {
+	cmd := NewBriefingCommand(DefaultBriefingDeps())
+
+	err := cmd.ParseFlags([]string{"TestProject", "--since", "7d", "--compare-to", "14d"})
+	require.NoError(t, err, "flag parsing should succeed")
+
+	assert.Equal(t, "7d", cmd.Flags().Lookup("since").Value.String())
+	assert.Equal(t, "14d", cmd.Flags().Lookup("compare-to").Value.String())
+}
+
+// TestRunBriefing_CompareToValidation verifies --compare-to is checked before connecting.
+func TestRunBriefing_CompareToValidation(t *testing.T) {
+	origSince, origCompare := briefingSince, briefingCompare
+	t.Cleanup(func() { briefingSince, briefingCompare = origSince, origCompare })
+
+	briefingSince, briefingCompare = "", "14d"
+	err := runBriefing(t.Context(), DefaultBriefingDeps(), "TestProject")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--compare-to requires --since")
+
+	briefingSince, briefingCompare = "14d", "7d"
+	err = runBriefing(t.Context(), DefaultBriefingDeps(), "TestProject")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--compare-to must be before --since")
+}
+
+// TestBuildBriefingReport verifies windowing and the period comparison.
+func TestBuildBriefingReport(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	since := now.AddDate(0, 0, -7)
+	compareTo := now.AddDate(0, 0, -14)
+
+	at := func(daysAgo int) *timestamppb.Timestamp {
+		return timestamppb.New(now.AddDate(0, 0, -daysAgo))
+	}
+	assertions := []*watchlistv1.BriefingAssertion{
+		{Id: 1, PriorityTier: 1, Severity: "critical", UpdatedAt: at(1)},
+		{Id: 2, PriorityTier: 4, Severity: "low", UpdatedAt: at(3)},
+		{Id: 3, PriorityTier: 1, Severity: "high", UpdatedAt: at(10)},
+		{Id: 4, PriorityTier: 2, Severity: "high", UpdatedAt: at(12)},
+		{Id: 5, PriorityTier: 2, Severity: "high", UpdatedAt: at(30)},
+		{Id: 6, PriorityTier: 3, Severity: "medium"},
+	}
+
+	report := buildBriefingReport("MTC 2026", assertions, since, compareTo, now)
+
+	require.Len(t, report.Assertions, 2, "only assertions updated since --since should be kept")
+	assert.Equal(t, int64(1), report.Assertions[0].Id, "priority order should be preserved")
+	assert.Equal(t, int64(2), report.Assertions[1].Id)
+	assert.Equal(t, since, report.Since)
+	assert.Equal(t, now, report.Until)
+
+	require.NotNil(t, report.Comparison)
+	assert.Equal(t, BriefingDelta{Label: "Total", Current: 2, Prior: 2, Change: 0}, report.Comparison.Total)
+	require.Len(t, report.Comparison.Tiers, 4)
+	assert.Equal(t, BriefingDelta{Label: formatTierName(2), Current: 0, Prior: 1, Change: -1}, report.Comparison.Tiers[1])
+	assert.Equal(t, BriefingDelta{Label: "high", Current: 0, Prior: 2, Change: -2}, report.Comparison.Severities[1])
+}
+
+// TestBuildBriefingReport_NoComparison verifies the comparison is omitted without --compare-to.
+func TestBuildBriefingReport_NoComparison(t *testing.T) {
+	now := time.Now()
+	report := buildBriefingReport("MTC 2026", nil, now.Add(-time.Hour), time.Time{}, now)
+
+	assert.Nil(t, report.Comparison)
+	assert.NotNil(t, report.Assertions, "assertions should encode as an empty list, not null")
+}
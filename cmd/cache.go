@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// cacheDirName is the directory under ~/.penf/ that holds local caches.
+const cacheDirName = "cache"
+
+// cacheDir returns the directory for the named local cache, e.g.
+// ~/.penf/cache/search. The directory is not created.
+func cacheDir(name string) (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cacheDirName, name), nil
+}
@@ -24,8 +24,8 @@ Certificates are stored in ~/.config/penf/certs/ by default.
 
 Commands:
   init   - Initialize client certificates (generate or copy)
-  show   - Display certificate information and validity
-  verify - Test TLS connection to gateway
+  show   - Display certificate information and validity (alias: info)
+  verify - Test TLS connection to gateway and check the server certificate
 
 Examples:
   # Initialize certs by generating from CA
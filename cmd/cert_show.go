@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/config"
 )
@@ -22,6 +21,7 @@ type CertInfo struct {
 	Path        string    `json:"path" yaml:"path"`
 	Subject     string    `json:"subject" yaml:"subject"`
 	Issuer      string    `json:"issuer" yaml:"issuer"`
+	SANs        []string  `json:"sans,omitempty" yaml:"sans,omitempty"`
 	ValidFrom   time.Time `json:"valid_from" yaml:"valid_from"`
 	ValidTo     time.Time `json:"valid_to" yaml:"valid_to"`
 	ExpiresIn   string    `json:"expires_in" yaml:"expires_in"`
@@ -56,12 +56,14 @@ const expiryWarningDays = 30
 // NewCertShowCommand creates the 'cert show' subcommand.
 func NewCertShowCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show",
-		Short: "Display certificate information",
+		Use:     "show",
+		Aliases: []string{"info"},
+		Short:   "Display certificate information",
 		Long: `Display information about configured mTLS certificates.
 
-Shows the subject, issuer, validity period, and days until expiration for both
-the client certificate and the CA certificate. Also verifies the certificate chain.
+Shows the subject, issuer, subject alternative names, validity period, and days
+until expiration for both the client certificate and the CA certificate. Also
+verifies the certificate chain. Certificates expiring within 30 days are flagged.
 
 Examples:
   # Show certificate information
@@ -243,6 +245,7 @@ func certToInfo(path string, cert *x509.Certificate) *CertInfo {
 		Path:       shortenPath(path),
 		Subject:    formatDN(cert.Subject.String()),
 		Issuer:     formatDN(cert.Issuer.String()),
+		SANs:       certSANs(cert),
 		ValidFrom:  cert.NotBefore,
 		ValidTo:    cert.NotAfter,
 		ExpiresIn:  formatCertExpiry(cert.NotAfter.Sub(now)),
@@ -260,6 +263,21 @@ func certToInfo(path string, cert *x509.Certificate) *CertInfo {
 	return info
 }
 
+// certSANs returns the subject alternative names of a certificate: DNS names,
+// IP addresses, email addresses and URIs.
+func certSANs(cert *x509.Certificate) []string {
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
 // formatDN formats a distinguished name for display.
 func formatDN(dn string) string {
 	// The default format from x509 is comma-separated.
@@ -391,7 +409,7 @@ func outputCertShow(output CertShowOutput) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(output)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(output)
 	default:
 		return outputCertShowText(output)
@@ -449,6 +467,9 @@ func displayCertInfo(info *CertInfo) {
 	fmt.Printf("  Path:       %s\n", info.Path)
 	fmt.Printf("  Subject:    %s\n", info.Subject)
 	fmt.Printf("  Issuer:     %s\n", info.Issuer)
+	if len(info.SANs) > 0 {
+		fmt.Printf("  SANs:       %s\n", strings.Join(info.SANs, ", "))
+	}
 	fmt.Printf("  Valid:      %s to %s\n",
 		info.ValidFrom.Format("2006-01-02"),
 		info.ValidTo.Format("2006-01-02"))
@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -14,7 +15,6 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -26,6 +26,7 @@ type CertVerifyResult struct {
 	Connection    *ConnectionResult `json:"connection,omitempty" yaml:"connection,omitempty"`
 	OverallStatus string            `json:"overall_status" yaml:"overall_status"`
 	Errors        []string          `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Warnings      []string          `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 // LocalCertsResult represents the local certificate verification results.
@@ -37,6 +38,7 @@ type LocalCertsResult struct {
 	CACertPath      string `json:"ca_cert_path" yaml:"ca_cert_path"`
 	ClientKeyPath   string `json:"client_key_path" yaml:"client_key_path"`
 	ExpiresAt       string `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	CAExpiresAt     string `json:"ca_expires_at,omitempty" yaml:"ca_expires_at,omitempty"`
 	Subject         string `json:"subject,omitempty" yaml:"subject,omitempty"`
 	Issuer          string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
 	Error           string `json:"error,omitempty" yaml:"error,omitempty"`
@@ -44,12 +46,29 @@ type LocalCertsResult struct {
 
 // ConnectionResult represents the connection test results.
 type ConnectionResult struct {
-	ServerAddress      string `json:"server_address" yaml:"server_address"`
-	TLSHandshake       bool   `json:"tls_handshake" yaml:"tls_handshake"`
-	ServerCertVerified bool   `json:"server_cert_verified" yaml:"server_cert_verified"`
-	ClientCertAccepted bool   `json:"client_cert_accepted" yaml:"client_cert_accepted"`
-	GatewayResponding  bool   `json:"gateway_responding" yaml:"gateway_responding"`
-	Error              string `json:"error,omitempty" yaml:"error,omitempty"`
+	ServerAddress      string            `json:"server_address" yaml:"server_address"`
+	TLSHandshake       bool              `json:"tls_handshake" yaml:"tls_handshake"`
+	ServerCertVerified bool              `json:"server_cert_verified" yaml:"server_cert_verified"`
+	ClientCertAccepted bool              `json:"client_cert_accepted" yaml:"client_cert_accepted"`
+	GatewayResponding  bool              `json:"gateway_responding" yaml:"gateway_responding"`
+	ServerCert         *ServerCertResult `json:"server_cert,omitempty" yaml:"server_cert,omitempty"`
+	ServerCertError    string            `json:"server_cert_error,omitempty" yaml:"server_cert_error,omitempty"`
+	Error              string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ServerCertResult describes the certificate the server presented in the TLS
+// handshake and whether it validates against the configured CA.
+type ServerCertResult struct {
+	Subject         string   `json:"subject" yaml:"subject"`
+	Issuer          string   `json:"issuer" yaml:"issuer"`
+	SANs            []string `json:"sans,omitempty" yaml:"sans,omitempty"`
+	ExpiresAt       string   `json:"expires_at" yaml:"expires_at"`
+	DaysUntilExpiry int      `json:"days_until_expiry" yaml:"days_until_expiry"`
+	ChainValid      bool     `json:"chain_valid" yaml:"chain_valid"`
+	ChainError      string   `json:"chain_error,omitempty" yaml:"chain_error,omitempty"`
+	Hostname        string   `json:"hostname" yaml:"hostname"`
+	HostnameMatch   bool     `json:"hostname_match" yaml:"hostname_match"`
+	HostnameError   string   `json:"hostname_error,omitempty" yaml:"hostname_error,omitempty"`
 }
 
 var (
@@ -70,7 +89,10 @@ This command performs the following checks:
   2. Validates certificate formats and expiration
   3. Verifies the certificate chain (client cert signed by CA)
   4. Tests TLS handshake with the gateway (unless --local)
-  5. Verifies the gateway accepts the client certificate
+  5. Checks the server certificate: chain, hostname match, and expiry
+  6. Verifies the gateway accepts the client certificate
+
+Certificates that expire within 30 days are reported as warnings.
 
 Use --local to only verify local certificates without testing the connection.
 Use -v/--verbose for detailed certificate information.
@@ -151,9 +173,14 @@ func runCertVerify(cmd *cobra.Command, args []string) error {
 			} else if certVerifyOutput == "text" {
 				printCertConnectionSuccess(connResult)
 			}
+			if certVerifyOutput == "text" {
+				printServerCertResult(connResult.ServerCert, connResult.ServerCertError)
+			}
 		}
 	}
 
+	result.Warnings = certExpiryWarnings(result, time.Now())
+
 	// Output results
 	return outputCertVerifyResult(result)
 }
@@ -169,11 +196,17 @@ func outputCertVerifyResult(result CertVerifyResult) error {
 			return err
 		}
 	case "yaml":
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		if err := enc.Encode(result); err != nil {
 			return err
 		}
 	default:
+		if len(result.Warnings) > 0 {
+			fmt.Println()
+			for _, w := range result.Warnings {
+				fmt.Printf("\033[33m⚠\033[0m %s\n", w)
+			}
+		}
 		if result.OverallStatus == "passed" {
 			fmt.Println("\nAll checks passed")
 		}
@@ -233,6 +266,9 @@ func verifyLocalCertsWithInfo(tlsCfg *config.TLSConfig) (LocalCertsResult, *cert
 		return result, nil, fmt.Errorf("invalid CA certificate PEM format")
 	}
 	result.CACertValid = true
+	if caCert, err := loadCertificate(tlsCfg.CACert); err == nil {
+		result.CAExpiresAt = caCert.NotAfter.Format(time.RFC3339)
+	}
 
 	// Load and validate client certificate and key
 	cert, err := tls.LoadX509KeyPair(tlsCfg.ClientCert, tlsCfg.ClientKey)
@@ -309,6 +345,14 @@ func testTLSConnection(ctx context.Context, cfg *config.CLIConfig) (*ConnectionR
 		return result, fmt.Errorf("TLS is not configured")
 	}
 
+	// Inspect the server certificate on its own connection, so chain and
+	// hostname problems are reported even when the handshake below fails.
+	serverCert, err := inspectServerCert(ctx, cfg.ServerAddress, tlsConfig)
+	if err != nil {
+		result.ServerCertError = err.Error()
+	}
+	result.ServerCert = serverCert
+
 	// Create gRPC connection with TLS
 	creds := credentials.NewTLS(tlsConfig)
 
@@ -333,7 +377,8 @@ func testTLSConnection(ctx context.Context, cfg *config.CLIConfig) (*ConnectionR
 	// Try a health check to verify the gateway is responding
 	grpcClient := client.NewGRPCClient(cfg.ServerAddress, &client.ClientOptions{
 		TLSConfig:      tlsConfig,
-		ConnectTimeout: cfg.Timeout,
+		ConnectTimeout: cfg.GetConnectTimeout(),
+		RequestTimeout: cfg.Timeout,
 	})
 
 	if err := grpcClient.Connect(ctx); err != nil {
@@ -357,6 +402,87 @@ func testTLSConnection(ctx context.Context, cfg *config.CLIConfig) (*ConnectionR
 	return result, nil
 }
 
+// inspectServerCert completes a TLS handshake with addr without verifying the
+// server, then checks the certificate it presented against tlsConfig's roots
+// (the system roots if none) and the server's hostname.
+func inspectServerCert(ctx context.Context, addr string, tlsConfig *tls.Config) (*ServerCertResult, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if tlsConfig.ServerName != "" {
+		host = tlsConfig.ServerName
+	}
+
+	probe := tlsConfig.Clone()
+	probe.InsecureSkipVerify = true // verified below, to report why it fails
+	probe.NextProtos = []string{"h2", "http/1.1"}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	conn, err := (&tls.Dialer{Config: probe}).DialContext(dialCtx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	peers := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("server presented no certificate")
+	}
+	leaf := peers[0]
+
+	result := &ServerCertResult{
+		Subject:         leaf.Subject.String(),
+		Issuer:          leaf.Issuer.String(),
+		SANs:            certSANs(leaf),
+		ExpiresAt:       leaf.NotAfter.Format(time.RFC3339),
+		DaysUntilExpiry: int(time.Until(leaf.NotAfter).Hours() / 24),
+		Hostname:        host,
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range peers[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs, Intermediates: intermediates}); err != nil {
+		result.ChainError = err.Error()
+	} else {
+		result.ChainValid = true
+	}
+	if err := leaf.VerifyHostname(host); err != nil {
+		result.HostnameError = err.Error()
+	} else {
+		result.HostnameMatch = true
+	}
+
+	return result, nil
+}
+
+// certExpiryWarnings returns a warning for each checked certificate that
+// expires within expiryWarningDays of now.
+func certExpiryWarnings(result CertVerifyResult, now time.Time) []string {
+	certs := []struct{ name, expiresAt string }{
+		{"Client certificate", result.LocalCerts.ExpiresAt},
+		{"CA certificate", result.LocalCerts.CAExpiresAt},
+	}
+	if result.Connection != nil && result.Connection.ServerCert != nil {
+		certs = append(certs, struct{ name, expiresAt string }{"Server certificate", result.Connection.ServerCert.ExpiresAt})
+	}
+
+	var warnings []string
+	for _, c := range certs {
+		expiresAt, err := time.Parse(time.RFC3339, c.expiresAt)
+		if err != nil || expiresAt.Before(now) {
+			continue
+		}
+		if days := int(expiresAt.Sub(now).Hours() / 24); days < expiryWarningDays {
+			warnings = append(warnings, fmt.Sprintf("%s expires in %d days (%s)", c.name, days, expiresAt.Format("2006-01-02")))
+		}
+	}
+	return warnings
+}
+
 // categorizeConnectionError provides a user-friendly error message based on the error type.
 func categorizeConnectionError(err error) error {
 	errStr := err.Error()
@@ -435,6 +561,35 @@ func printCertConnectionSuccess(result *ConnectionResult) {
 	}
 }
 
+// printServerCertResult prints the server certificate checks.
+func printServerCertResult(sc *ServerCertResult, inspectErr string) {
+	if inspectErr != "" {
+		fmt.Printf("  \033[31m✗\033[0m Could not inspect the server certificate: %s\n\n", inspectErr)
+	}
+	if sc == nil {
+		return
+	}
+	if sc.ChainValid {
+		fmt.Println("  \033[32m✓\033[0m Server certificate chain validates against the CA")
+	} else {
+		fmt.Printf("  \033[31m✗\033[0m Server certificate chain does not validate: %s\n", sc.ChainError)
+	}
+	if sc.HostnameMatch {
+		fmt.Printf("  \033[32m✓\033[0m Server certificate matches %s\n", sc.Hostname)
+	} else {
+		fmt.Printf("  \033[31m✗\033[0m Server certificate does not match %s: %s\n", sc.Hostname, sc.HostnameError)
+	}
+
+	fmt.Println()
+	fmt.Println("  Server certificate:")
+	fmt.Printf("    Subject:  %s\n", sc.Subject)
+	fmt.Printf("    Issuer:   %s\n", sc.Issuer)
+	if len(sc.SANs) > 0 {
+		fmt.Printf("    SANs:     %s\n", strings.Join(sc.SANs, ", "))
+	}
+	fmt.Printf("    Expires:  %s (%d days)\n", sc.ExpiresAt, sc.DaysUntilExpiry)
+}
+
 // printCertConnectionError prints error messages for connection test.
 func printCertConnectionError(result *ConnectionResult, err error) {
 	if result.TLSHandshake {
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInspectServerCert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	sc, err := inspectServerCert(context.Background(), addr, &tls.Config{RootCAs: roots})
+	if err != nil {
+		t.Fatalf("inspectServerCert() error = %v", err)
+	}
+	if !sc.ChainValid || !sc.HostnameMatch {
+		t.Errorf("expected a valid chain and hostname match: %+v", sc)
+	}
+	if sc.Hostname != "127.0.0.1" || len(sc.SANs) == 0 {
+		t.Errorf("hostname %q, SANs %v", sc.Hostname, sc.SANs)
+	}
+
+	// Against an unrelated CA the chain fails but the certificate is still
+	// reported.
+	sc, err = inspectServerCert(context.Background(), addr, &tls.Config{RootCAs: x509.NewCertPool(), ServerName: "gateway.internal"})
+	if err != nil {
+		t.Fatalf("inspectServerCert() error = %v", err)
+	}
+	if sc.ChainValid || sc.ChainError == "" {
+		t.Errorf("expected the chain to fail: %+v", sc)
+	}
+	if sc.HostnameMatch || sc.Hostname != "gateway.internal" {
+		t.Errorf("expected gateway.internal not to match: %+v", sc)
+	}
+}
+
+func TestCertExpiryWarnings(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	result := CertVerifyResult{
+		LocalCerts: LocalCertsResult{
+			ExpiresAt:   now.Add(10 * 24 * time.Hour).Format(time.RFC3339),
+			CAExpiresAt: now.Add(400 * 24 * time.Hour).Format(time.RFC3339),
+		},
+		Connection: &ConnectionResult{
+			ServerCert: &ServerCertResult{ExpiresAt: now.Add(29*24*time.Hour + time.Hour).Format(time.RFC3339)},
+		},
+	}
+
+	warnings := certExpiryWarnings(result, now)
+	if len(warnings) != 2 {
+		t.Fatalf("warnings = %v, want client and server", warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "Client certificate expires in 10 days") {
+		t.Errorf("warnings[0] = %q", warnings[0])
+	}
+	if !strings.HasPrefix(warnings[1], "Server certificate expires in 29 days") {
+		t.Errorf("warnings[1] = %q", warnings[1])
+	}
+}
+
+func TestPrintServerCertResult_InspectError(t *testing.T) {
+	out := captureStdout(func() {
+		printServerCertResult(nil, "dial tcp 127.0.0.1:1: connect: connection refused")
+	})
+	if !strings.Contains(out, "Could not inspect the server certificate: dial tcp") {
+		t.Errorf("output = %q, want the inspection error", out)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
@@ -20,10 +19,13 @@ import (
 
 // Classify command flags
 var (
-	classifyOutput  string
-	classifyAll     bool
-	classifyDryRun  bool
-	classifyTenant  string
+	classifyOutput      string
+	classifyAll         bool
+	classifyDryRun      bool
+	classifyTenant      string
+	classifyFromFile    string
+	classifyConcurrency int
+	classifyExactOnly   bool
 )
 
 // ClassifyCommandDeps holds the dependencies for classify commands.
@@ -150,7 +152,13 @@ By default, classifies all items with source_system = 'unknown'.
 Use --all to reclassify everything, ignoring current source_system values.
 Use --dry-run to preview changes without persisting to the database.
 
-Provide a content ID to classify a single item.
+Provide a content ID to classify a single item, or --from-file with a file of
+content IDs (one per line, # comments allowed) to classify a list of items
+concurrently, e.g. to re-categorize a backlog after a rule change. File mode
+prints one row per item with its category and the rule that matched. Items no
+rule matched fall through to the default classification and are flagged for
+review; use --exact-only to leave them unchanged and only persist items a
+rule matched.
 
 Examples:
   # Classify all unknown items
@@ -166,7 +174,13 @@ Examples:
   penf classify run em-abc123
 
   # Dry run for a single item
-  penf classify run em-abc123 --dry-run`,
+  penf classify run em-abc123 --dry-run
+
+  # Classify a list of items, 8 at a time, as CSV
+  penf classify run --from-file ids.txt --concurrency 8 -o csv
+
+  # Reclassify a backlog, leaving items no rule matched for review
+  penf classify run --from-file ids.txt --exact-only`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var contentID string
@@ -180,7 +194,10 @@ Examples:
 	cmd.Flags().BoolVar(&classifyAll, "all", false, "Reclassify all items (ignore current classification)")
 	cmd.Flags().BoolVar(&classifyDryRun, "dry-run", false, "Show what would change without persisting")
 	cmd.Flags().StringVar(&classifyTenant, "tenant", "", "Tenant ID (defaults to config tenant)")
-	cmd.Flags().StringVarP(&classifyOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().StringVarP(&classifyOutput, "output", "o", "", "Output format: text, json, yaml (csv with --from-file)")
+	cmd.Flags().StringVar(&classifyFromFile, "from-file", "", "Classify the content IDs listed in a file, one per line ('-' for stdin)")
+	cmd.Flags().IntVar(&classifyConcurrency, "concurrency", defaultReprocessConcurrency, fmt.Sprintf("Concurrent workers for --from-file (max %d)", maxReprocessConcurrency))
+	cmd.Flags().BoolVar(&classifyExactOnly, "exact-only", false, "With --from-file, only persist items a rule matched; flag the rest for review")
 
 	return cmd
 }
@@ -271,6 +288,17 @@ func runClassify(ctx context.Context, deps *ClassifyCommandDeps, contentID strin
 		deps.GRPCClient = grpcClient
 	}
 
+	// File mode
+	if classifyFromFile != "" {
+		if contentID != "" || classifyAll {
+			return fmt.Errorf("--from-file cannot be combined with a content ID or --all")
+		}
+		return runClassifyFromFile(ctx, deps, tenantID, format)
+	}
+	if classifyExactOnly {
+		return fmt.Errorf("--exact-only requires --from-file")
+	}
+
 	// Single item mode
 	if contentID != "" {
 		if classifyDryRun {
@@ -644,7 +672,7 @@ func outputClassifyRulesJSON(rules []ClassificationRule) error {
 }
 
 func outputClassifyRulesYAML(rules []ClassificationRule) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(map[string]interface{}{
 		"rules": rules,
 	})
@@ -709,7 +737,7 @@ func outputClassifyResult(format config.OutputFormat, result *ReprocessContentRe
 		}
 		return enc.Encode(output)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		output := map[string]interface{}{
 			"content_id":    result.ContentID,
 			"source_system": result.SourceSystem,
@@ -745,7 +773,7 @@ func outputClassifyBatchResult(format config.OutputFormat, results []*ReprocessC
 		}
 		return enc.Encode(output)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		output := map[string]interface{}{
 			"processed": len(results),
 			"results":   results,
@@ -767,14 +795,14 @@ func outputClassifyStats(format config.OutputFormat, result *ContentStatsResult)
 		enc.SetIndent("", "  ")
 		return enc.Encode(result)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(result)
 	default:
 		fmt.Printf("Classification Statistics:\n\n")
 		fmt.Printf("Total items: %d\n\n", result.Total)
 		fmt.Println("Breakdown by source system:")
-		for sourceSystem, count := range result.Breakdown {
-			fmt.Printf("  %-20s: %d\n", sourceSystem, count)
+		for _, sourceSystem := range orderedKeys(result.Breakdown, nil) {
+			fmt.Printf("  %-20s: %d\n", sourceSystem, result.Breakdown[sourceSystem])
 		}
 		return nil
 	}
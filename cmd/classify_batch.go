@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// ClassifyBatchResult is the classification of one content item from a
+// --from-file run.
+type ClassifyBatchResult struct {
+	ContentID string `json:"content_id" yaml:"content_id"`
+	Category  string `json:"category,omitempty" yaml:"category,omitempty"`
+	Rule      string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// NeedsReview is set when no rule matched and the item fell through to
+	// the default classification.
+	NeedsReview bool   `json:"needs_review" yaml:"needs_review"`
+	Skipped     bool   `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	JobID       string `json:"job_id,omitempty" yaml:"job_id,omitempty"`
+	Error       string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ClassifyBatchOutput is the output of 'classify run --from-file'.
+type ClassifyBatchOutput struct {
+	Total       int                   `json:"total" yaml:"total"`
+	Classified  int                   `json:"classified" yaml:"classified"`
+	Failed      int                   `json:"failed" yaml:"failed"`
+	NeedsReview int                   `json:"needs_review" yaml:"needs_review"`
+	Skipped     int                   `json:"skipped" yaml:"skipped"`
+	DryRun      bool                  `json:"dry_run" yaml:"dry_run"`
+	Results     []ClassifyBatchResult `json:"results" yaml:"results"`
+}
+
+// readContentIDsFile reads content IDs one per line from path, or from stdin
+// when path is "-". Blank lines and # comments are skipped and duplicates
+// dropped, preserving order.
+func readContentIDsFile(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading ID file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" || strings.HasPrefix(id, "#") || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ID file: %w", err)
+	}
+	return ids, nil
+}
+
+// runClassifyFromFile classifies every content ID listed in classifyFromFile
+// through a bounded worker pool. Each item is evaluated against the current
+// rules and, unless --dry-run, reprocessed so the classification is persisted.
+// With --exact-only, items no rule matched are flagged but not reprocessed.
+func runClassifyFromFile(ctx context.Context, deps *ClassifyCommandDeps, tenantID string, format config.OutputFormat) error {
+	ids, err := readContentIDsFile(classifyFromFile)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no content IDs in %s", classifyFromFile)
+	}
+
+	testRule := deps.TestClassificationRuleFn
+	if testRule == nil {
+		conn, err := connectPipelineToGateway(deps.Config)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		pipelineClient := pipelinev1.NewPipelineServiceClient(conn)
+		testRule = func(ctx context.Context, tenantID, contentID string) (*pipelinev1.TestClassificationRuleResponse, error) {
+			return pipelineClient.TestClassificationRule(ctx, &pipelinev1.TestClassificationRuleRequest{
+				TenantId:  tenantID,
+				ContentId: contentID,
+			})
+		}
+	}
+
+	reprocess := deps.ReprocessContentFn
+	if reprocess == nil {
+		reprocess = func(ctx context.Context, contentID, reason string) (*contentv1.ReprocessContentResponse, error) {
+			return deps.GRPCClient.ReprocessContent(ctx, &contentv1.ReprocessContentRequest{
+				ContentId: contentID,
+				Reason:    reason,
+			})
+		}
+	}
+
+	classifyOne := func(contentID string) ClassifyBatchResult {
+		result := ClassifyBatchResult{ContentID: contentID}
+
+		resp, err := testRule(ctx, tenantID, contentID)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Category = classificationCategory(resp)
+		if resp.MatchedRule != nil {
+			result.Rule = resp.MatchedRule.Name
+		} else {
+			result.NeedsReview = true
+			result.Skipped = classifyExactOnly
+		}
+
+		if !classifyDryRun && !result.Skipped {
+			job, err := reprocess(ctx, contentID, "classify run --from-file")
+			if err != nil {
+				result.Error = err.Error()
+				return result
+			}
+			result.JobID = job.JobId
+		}
+		return result
+	}
+
+	var progress io.Writer = io.Discard
+	if format == config.OutputFormatText || format == "" {
+		progress = os.Stderr
+	}
+
+	output := ClassifyBatchOutput{
+		Total:   len(ids),
+		DryRun:  classifyDryRun,
+		Results: classifyPool(ctx, ids, classifyConcurrency, progress, classifyOne),
+	}
+	for _, r := range output.Results {
+		switch {
+		case r.Error != "":
+			output.Failed++
+		case r.Skipped:
+			output.Skipped++
+			output.NeedsReview++
+		case r.NeedsReview:
+			output.Classified++
+			output.NeedsReview++
+		default:
+			output.Classified++
+		}
+	}
+
+	if err := outputClassifyBatchFile(os.Stdout, format, output); err != nil {
+		return err
+	}
+	if output.Failed > 0 {
+		return fmt.Errorf("%d of %d items failed to classify", output.Failed, output.Total)
+	}
+	return nil
+}
+
+// classifyPool runs classify over ids with at most concurrency calls in
+// flight and returns the results in input order. Items not started before
+// ctx is cancelled are reported as failed.
+func classifyPool(ctx context.Context, ids []string, concurrency int, progress io.Writer, classify func(contentID string) ClassifyBatchResult) []ClassifyBatchResult {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done int
+	)
+	results := make([]ClassifyBatchResult, len(ids))
+	sem := make(chan struct{}, clampReprocessConcurrency(concurrency))
+	bar := newProgressBar(progress, len(ids), "classified")
+	defer bar.Finish()
+
+dispatch:
+	for i, contentID := range ids {
+		select {
+		case <-ctx.Done():
+			for j := i; j < len(ids); j++ {
+				results[j] = ClassifyBatchResult{ContentID: ids[j], Error: ctx.Err().Error()}
+			}
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, contentID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = classify(contentID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			bar.Set(done, "")
+		}(i, contentID)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// classificationCategory formats the type and subtype of a rule test result
+// as a single category, e.g. "notification/jira".
+func classificationCategory(resp *pipelinev1.TestClassificationRuleResponse) string {
+	category := strings.ToLower(resp.ContentType)
+	if resp.ContentSubtype != "" {
+		category += "/" + strings.ToLower(resp.ContentSubtype)
+	}
+	return category
+}
+
+// classifyBatchCSVHeader matches the columns of the --from-file text table.
+var classifyBatchCSVHeader = []string{"content_id", "category", "rule", "needs_review", "skipped", "job_id", "error"}
+
+// outputClassifyBatchFile writes the results of a --from-file run.
+func outputClassifyBatchFile(w io.Writer, format config.OutputFormat, output ClassifyBatchOutput) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(w).Encode(output)
+	case config.OutputFormatCSV:
+		rows := make([][]string, 0, len(output.Results))
+		for _, r := range output.Results {
+			rows = append(rows, []string{
+				r.ContentID, r.Category, r.Rule, strconv.FormatBool(r.NeedsReview),
+				strconv.FormatBool(r.Skipped), r.JobID, r.Error,
+			})
+		}
+		return outputCSV(w, classifyBatchCSVHeader, rows)
+	default:
+		outputClassifyBatchFileText(w, output)
+		return nil
+	}
+}
+
+// outputClassifyBatchFileText prints the results as a table followed by
+// per-category counts.
+func outputClassifyBatchFileText(w io.Writer, output ClassifyBatchOutput) {
+	fmt.Fprintf(w, "  %-24s  %-28s  %-20s  %s\n", "CONTENT ID", "CATEGORY", "RULE", "STATUS")
+	counts := make(map[string]int)
+	for _, r := range output.Results {
+		if r.Error != "" {
+			fmt.Fprintf(w, "  %-24s  %-28s  %-20s  failed: %s\n", r.ContentID, "-", "-", r.Error)
+			continue
+		}
+		counts[r.Category]++
+
+		status := "ok"
+		switch {
+		case r.JobID != "":
+			status = "job " + r.JobID
+		case r.Skipped:
+			status = "skipped"
+		case output.DryRun:
+			status = "dry-run"
+		}
+		if r.NeedsReview {
+			status += "  REVIEW"
+		}
+		rule := r.Rule
+		if rule == "" {
+			rule = "(default)"
+		}
+		fmt.Fprintf(w, "  %-24s  %-28s  %-20s  %s\n", r.ContentID, r.Category, rule, status)
+	}
+
+	fmt.Fprintf(w, "\nClassified %d of %d items", output.Classified, output.Total)
+	if output.Failed > 0 {
+		fmt.Fprintf(w, ", %d failed", output.Failed)
+	}
+	if output.NeedsReview > 0 {
+		fmt.Fprintf(w, ", %d flagged for review", output.NeedsReview)
+	}
+	if output.Skipped > 0 {
+		fmt.Fprintf(w, " (%d skipped by --exact-only)", output.Skipped)
+	}
+	fmt.Fprintln(w)
+	if output.DryRun {
+		fmt.Fprintln(w, "Dry-run mode - no changes persisted")
+	}
+
+	categories := make([]string, 0, len(counts))
+	for c := range counts {
+		categories = append(categories, c)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if counts[categories[i]] != counts[categories[j]] {
+			return counts[categories[i]] > counts[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+	for _, c := range categories {
+		fmt.Fprintf(w, "  %-28s  %d\n", c, counts[c])
+	}
+}
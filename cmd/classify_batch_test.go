@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestReadContentIDsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("em-001\n\n# backlog\n  em-002  \nem-001\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := readContentIDsFile(path)
+	if err != nil {
+		t.Fatalf("readContentIDsFile() error = %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "em-001,em-002" {
+		t.Errorf("ids = %s, want em-001,em-002", got)
+	}
+}
+
+func TestRunClassifyFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("em-001\nem-002\nem-003\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.CLIConfig{ServerAddress: "localhost:50051", Timeout: 30 * time.Second, TenantID: "tenant-test-001"}
+	var (
+		mu          sync.Mutex
+		reprocessed []string
+	)
+	deps := &ClassifyCommandDeps{
+		Config:     cfg,
+		LoadConfig: func() (*config.CLIConfig, error) { return cfg, nil },
+		TestClassificationRuleFn: func(ctx context.Context, tenantID, contentID string) (*pipelinev1.TestClassificationRuleResponse, error) {
+			switch contentID {
+			case "em-001":
+				return &pipelinev1.TestClassificationRuleResponse{
+					ContentType:    "NOTIFICATION",
+					ContentSubtype: "JIRA",
+					MatchedRule:    &pipelinev1.ClassificationRule{Name: "jira"},
+				}, nil
+			case "em-002":
+				return &pipelinev1.TestClassificationRuleResponse{ContentType: "EMAIL", ContentSubtype: "STANDALONE"}, nil
+			default:
+				return nil, fmt.Errorf("content not found")
+			}
+		},
+		ReprocessContentFn: func(ctx context.Context, contentID, reason string) (*contentv1.ReprocessContentResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reprocessed = append(reprocessed, contentID)
+			return &contentv1.ReprocessContentResponse{ContentId: contentID, JobId: "job-" + contentID}, nil
+		},
+	}
+
+	oldFile, oldConcurrency, oldExact, oldOutput, oldDryRun := classifyFromFile, classifyConcurrency, classifyExactOnly, classifyOutput, classifyDryRun
+	classifyFromFile, classifyConcurrency, classifyExactOnly, classifyOutput, classifyDryRun = path, 2, false, "csv", false
+	defer func() {
+		classifyFromFile, classifyConcurrency, classifyExactOnly, classifyOutput, classifyDryRun = oldFile, oldConcurrency, oldExact, oldOutput, oldDryRun
+	}()
+
+	out := captureStdout(func() {
+		err := runClassify(context.Background(), deps, "")
+		if err == nil || !strings.Contains(err.Error(), "1 of 3 items failed") {
+			t.Errorf("runClassify() error = %v, want the failure count", err)
+		}
+	})
+
+	want := "content_id,category,rule,needs_review,skipped,job_id,error\n" +
+		"em-001,notification/jira,jira,false,false,job-em-001,\n" +
+		"em-002,email/standalone,,true,false,job-em-002,\n" +
+		"em-003,,,false,false,,content not found\n"
+	if out != want {
+		t.Errorf("CSV output:\n%s\nwant:\n%s", out, want)
+	}
+	if len(reprocessed) != 2 {
+		t.Errorf("reprocessed %v, want the two classified items", reprocessed)
+	}
+
+	// --exact-only leaves the item no rule matched unchanged.
+	reprocessed = nil
+	classifyExactOnly = true
+	out = captureStdout(func() {
+		_ = runClassify(context.Background(), deps, "")
+	})
+	if !strings.Contains(out, "em-002,email/standalone,,true,true,,\n") {
+		t.Errorf("--exact-only CSV output:\n%s\nwant em-002 skipped", out)
+	}
+	if len(reprocessed) != 1 || reprocessed[0] != "em-001" {
+		t.Errorf("--exact-only reprocessed %v, want only em-001", reprocessed)
+	}
+}
+
+func TestOutputClassifyBatchFileText(t *testing.T) {
+	output := ClassifyBatchOutput{
+		Total: 2, Classified: 2, NeedsReview: 1, DryRun: true,
+		Results: []ClassifyBatchResult{
+			{ContentID: "em-001", Category: "notification/jira", Rule: "jira"},
+			{ContentID: "em-002", Category: "email/standalone", NeedsReview: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := outputClassifyBatchFile(&buf, config.OutputFormatText, output); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{"dry-run  REVIEW", "Classified 2 of 2 items, 1 flagged for review", "Dry-run mode"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -104,7 +104,7 @@ func TestClassifyRunFlags(t *testing.T) {
 		t.Fatal("run subcommand not found")
 	}
 
-	expectedFlags := []string{"all", "dry-run", "output"}
+	expectedFlags := []string{"all", "dry-run", "output", "from-file", "concurrency", "exact-only"}
 
 	for _, flagName := range expectedFlags {
 		flag := runCmd.Flags().Lookup(flagName)
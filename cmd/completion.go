@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	reviewv1 "github.com/otherjamesbrown/penf-cli/api/proto/review/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Dynamic completion settings. Each Tab press runs a fresh penf process, so
+// candidates are cached on disk rather than in memory.
+const (
+	completionTimeout  = 2 * time.Second
+	completionCacheTTL = 30 * time.Second
+	completionPageSize = 100
+)
+
+// completionFetcher lists completion candidates from the server. Candidates
+// use cobra's "value\tdescription" form.
+type completionFetcher func(ctx context.Context, cfg *config.CLIConfig) ([]string, error)
+
+// completionCacheEntry is the on-disk form of cached completion candidates.
+type completionCacheEntry struct {
+	FetchedAt  time.Time `json:"fetched_at"`
+	Candidates []string  `json:"candidates"`
+}
+
+// completeFromServer returns a ValidArgsFunction that completes the first
+// maxArgs positional arguments with candidates from fetch. Errors are never
+// surfaced: a failed lookup simply offers no candidates.
+func completeFromServer(kind string, maxArgs int, loadConfig func() (*config.CLIConfig, error), fetch completionFetcher) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if insecure, _ := cmd.Flags().GetBool("insecure"); insecure {
+			cfg.Insecure = true
+		}
+		cfg.Timeout = completionTimeout
+
+		candidates := cachedCompletions(completionCacheKey(kind, cfg), func(ctx context.Context) ([]string, error) {
+			return fetch(ctx, cfg)
+		})
+		return filterCompletions(candidates, toComplete, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// cachedCompletions returns cached candidates for key if they are fresh, and
+// otherwise fetches and caches them. A stale cache is used if the fetch fails.
+func cachedCompletions(key string, fetch func(ctx context.Context) ([]string, error)) []string {
+	path, pathErr := completionCachePath(key)
+
+	var cached *completionCacheEntry
+	if pathErr == nil {
+		cached = readCompletionCache(path)
+		if cached != nil && time.Since(cached.FetchedAt) < completionCacheTTL {
+			return cached.Candidates
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	candidates, err := fetch(ctx)
+	if err != nil {
+		if cached != nil {
+			return cached.Candidates
+		}
+		return nil
+	}
+
+	if pathErr == nil {
+		writeCompletionCache(path, &completionCacheEntry{FetchedAt: time.Now(), Candidates: candidates})
+	}
+	return candidates
+}
+
+// completionCacheKey scopes cached candidates to the server and tenant so
+// switching either never offers stale IDs from elsewhere.
+func completionCacheKey(kind string, cfg *config.CLIConfig) string {
+	sum := sha256.Sum256([]byte(cfg.ServerAddress + "|" + cfg.EffectiveTenantID()))
+	return fmt.Sprintf("%s-%x", kind, sum[:6])
+}
+
+// completionCachePath returns the cache file for key under ~/.penf/cache/.
+func completionCachePath(key string) (string, error) {
+	dir, err := cacheDir("completion")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, key+".json"), nil
+}
+
+// readCompletionCache reads a cache file, returning nil if it is missing or
+// unreadable.
+func readCompletionCache(path string) *completionCacheEntry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry completionCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// writeCompletionCache writes a cache file. Failures are ignored; the next
+// completion simply fetches again.
+func writeCompletionCache(path string, entry *completionCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+// filterCompletions keeps candidates whose value starts with toComplete and
+// drops values already given as earlier arguments.
+func filterCompletions(candidates []string, toComplete string, args []string) []string {
+	used := make(map[string]bool, len(args))
+	for _, a := range args {
+		used[a] = true
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		value, _, _ := strings.Cut(c, "\t")
+		if used[value] || !strings.HasPrefix(value, toComplete) {
+			continue
+		}
+		matches = append(matches, c)
+	}
+	return matches
+}
+
+// completionDescription flattens a description onto one line for the shell.
+func completionDescription(s string) string {
+	return truncate(strings.Join(strings.Fields(s), " "), 57)
+}
+
+// completeRelationshipIDs completes relationship IDs for 'relationship show'.
+func completeRelationshipIDs(deps *RelationshipCommandDeps) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return completeFromServer("relationships", 1, relationshipCompletionConfig(deps), func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		relClient, err := deps.relationshipClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer deps.Close()
+
+		rels, _, err := relClient.ListRelationships(ctx, &client.ListRelationshipsRequest{
+			TenantID: cfg.EffectiveTenantID(),
+			PageSize: completionPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]string, 0, len(rels))
+		for _, r := range rels {
+			desc := r.RelationshipType
+			if r.SourceEntity != nil && r.TargetEntity != nil {
+				desc = fmt.Sprintf("%s %s %s", r.SourceEntity.Name, r.RelationshipType, r.TargetEntity.Name)
+			}
+			candidates = append(candidates, r.ID+"\t"+completionDescription(desc))
+		}
+		return candidates, nil
+	})
+}
+
+// completeEntityIDs completes entity IDs for the 'relationship entity'
+// subcommands that take maxArgs entity IDs.
+func completeEntityIDs(deps *RelationshipCommandDeps, maxArgs int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return completeFromServer("entities", maxArgs, relationshipCompletionConfig(deps), func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		relClient, err := deps.relationshipClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer deps.Close()
+
+		ents, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+			TenantID: cfg.EffectiveTenantID(),
+			PageSize: completionPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]string, 0, len(ents))
+		for _, e := range ents {
+			candidates = append(candidates, e.ID+"\t"+completionDescription(fmt.Sprintf("%s (%s)", e.Name, e.Type)))
+		}
+		return candidates, nil
+	})
+}
+
+// relationshipCompletionConfig loads config for relationship completions,
+// honoring the --tenant flag as the commands themselves do.
+func relationshipCompletionConfig(deps *RelationshipCommandDeps) func() (*config.CLIConfig, error) {
+	return func() (*config.CLIConfig, error) {
+		cfg, err := deps.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		if relationshipTenant != "" {
+			cfg.TenantID = relationshipTenant
+			cfg.TenantUUID = ""
+		}
+		return cfg, nil
+	}
+}
+
+// completeReviewItemIDs completes pending review item IDs for the review
+// subcommands that act on a single item.
+func completeReviewItemIDs(deps *ReviewCommandDeps) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return completeFromServer("review-items", 1, deps.LoadConfig, func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		grpcClient, err := deps.InitClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer grpcClient.Close()
+
+		tenantID := getTenantID()
+		resp, err := reviewv1.NewReviewServiceClient(grpcClient.GetConnection()).ListReviewItems(ctx, &reviewv1.ListReviewItemsRequest{
+			TenantId: &tenantID,
+			Statuses: []reviewv1.ReviewStatus{reviewv1.ReviewStatus_REVIEW_STATUS_PENDING},
+			PageSize: completionPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]string, 0, len(resp.Items))
+		for _, item := range resp.Items {
+			candidates = append(candidates, item.Id+"\t"+completionDescription(fmt.Sprintf("[%s] %s", item.ContentType, item.ContentSummary)))
+		}
+		return candidates, nil
+	})
+}
+
+// completeJobIDs completes ingest job IDs for 'pipeline job'.
+func completeJobIDs(deps *PipelineCommandDeps) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return completeFromServer("jobs", 1, deps.LoadConfig, func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		conn, err := connectPipelineToGateway(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		resp, err := pipelinev1.NewPipelineServiceClient(conn).ListJobs(ctx, &pipelinev1.ListJobsRequest{Limit: completionPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]string, 0, len(resp.Jobs))
+		for _, job := range resp.Jobs {
+			candidates = append(candidates, job.Id+"\t"+completionDescription(fmt.Sprintf("%s %s", job.Status, job.SourceTag)))
+		}
+		return candidates, nil
+	})
+}
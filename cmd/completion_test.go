@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"ent-1\tJane (person)", "ent-2\tAcme (organization)", "rel-1\tJane works_at Acme"}
+
+	got := filterCompletions(candidates, "ent-", nil)
+	want := []string{"ent-1\tJane (person)", "ent-2\tAcme (organization)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterCompletions prefix = %v, want %v", got, want)
+	}
+
+	got = filterCompletions(candidates, "ent-", []string{"ent-1"})
+	want = []string{"ent-2\tAcme (organization)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterCompletions should drop used args, got %v", got)
+	}
+}
+
+func TestCompletionDescription(t *testing.T) {
+	got := completionDescription("multi\nline\t summary")
+	if got != "multi line summary" {
+		t.Errorf("completionDescription = %q", got)
+	}
+	long := completionDescription(string(make([]byte, 100)) + "x")
+	if len(long) > 60 {
+		t.Errorf("expected truncation to 60 chars, got %d", len(long))
+	}
+}
+
+func TestCachedCompletions_UsesFreshCache(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	calls := 0
+	fetch := func(ctx context.Context) ([]string, error) {
+		calls++
+		return []string{"job-1"}, nil
+	}
+
+	first := cachedCompletions("jobs-test", fetch)
+	second := cachedCompletions("jobs-test", fetch)
+
+	if calls != 1 {
+		t.Errorf("expected one fetch, got %d", calls)
+	}
+	if !reflect.DeepEqual(first, second) || len(second) != 1 {
+		t.Errorf("cached candidates = %v, want %v", second, first)
+	}
+}
+
+func TestCachedCompletions_StaleCacheOnError(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	path, err := completionCachePath("jobs-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeCompletionCache(path, &completionCacheEntry{
+		FetchedAt:  time.Now().Add(-time.Hour),
+		Candidates: []string{"job-old"},
+	})
+
+	calls := 0
+	got := cachedCompletions("jobs-test", func(ctx context.Context) ([]string, error) {
+		calls++
+		return nil, errors.New("unavailable")
+	})
+
+	if calls != 1 {
+		t.Errorf("expected a refetch for a stale cache, got %d calls", calls)
+	}
+	if !reflect.DeepEqual(got, []string{"job-old"}) {
+		t.Errorf("expected stale candidates on error, got %v", got)
+	}
+}
+
+func TestCompletionCacheKey_ScopedByTenant(t *testing.T) {
+	a := completionCacheKey("entities", &config.CLIConfig{ServerAddress: "gw:50051", TenantID: "acme"})
+	b := completionCacheKey("entities", &config.CLIConfig{ServerAddress: "gw:50051", TenantID: "globex"})
+	if a == b {
+		t.Errorf("expected different keys per tenant, both %q", a)
+	}
+}
+
+func TestCompleteFromServer(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	loadConfig := func() (*config.CLIConfig, error) {
+		return &config.CLIConfig{ServerAddress: "gw:50051", TenantID: "acme"}, nil
+	}
+	calls := 0
+	complete := completeFromServer("entities-test", 2, loadConfig, func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		calls++
+		if cfg.Timeout != completionTimeout {
+			t.Errorf("expected completion timeout, got %s", cfg.Timeout)
+		}
+		return []string{"ent-1\tJane", "ent-2\tAcme"}, nil
+	})
+
+	cmd := &cobra.Command{Use: "merge"}
+	got, directive := complete(cmd, []string{"ent-1"}, "")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want NoFileComp", directive)
+	}
+	if !reflect.DeepEqual(got, []string{"ent-2\tAcme"}) {
+		t.Errorf("candidates = %v", got)
+	}
+
+	got, _ = complete(cmd, []string{"ent-1", "ent-2"}, "")
+	if len(got) != 0 {
+		t.Errorf("expected no candidates once all args are given, got %v", got)
+	}
+	if calls != 1 {
+		t.Errorf("expected one fetch, got %d", calls)
+	}
+}
+
+func TestCompleteFromServer_ConfigError(t *testing.T) {
+	complete := completeFromServer("jobs-test", 1, func() (*config.CLIConfig, error) {
+		return nil, os.ErrNotExist
+	}, func(ctx context.Context, cfg *config.CLIConfig) ([]string, error) {
+		t.Fatal("fetch should not run without config")
+		return nil, nil
+	})
+
+	got, _ := complete(&cobra.Command{Use: "job"}, nil, "")
+	if len(got) != 0 {
+		t.Errorf("expected no candidates, got %v", got)
+	}
+}
@@ -68,7 +68,7 @@ Use 'list' to show both lists, 'add' to add an address, 'remove' to remove one.`
 }
 
 func newConfigEmailWhitelistListCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "list",
 		Short: "Show both inbound and outbound whitelists",
 		Long: `Display all addresses on the inbound and outbound email whitelists.
@@ -77,17 +77,17 @@ The inbound whitelist controls which sender addresses can submit content via
 email ingestion. The outbound whitelist controls which addresses receive digest
 deliveries.
 
-Use --format json to get machine-readable output when processing with scripts
+Use --output json to get machine-readable output when processing with scripts
 or AI agents.`,
 		Example: `  # Show both whitelists
   penf config email whitelist list
 
   # Machine-readable output
-  penf config email whitelist list --format json`,
+  penf config email whitelist list --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runConfigEmailWhitelistList(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 func newConfigEmailWhitelistAddCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -128,7 +128,7 @@ idempotent — no error is returned and no duplicate is stored.`,
 	cmd.Flags().StringVar(&inbound, "inbound", "", "Sender address to add to the inbound whitelist")
 	cmd.Flags().StringVar(&outbound, "outbound", "", "Recipient address to add to the outbound whitelist")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func newConfigEmailWhitelistRemoveCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -167,7 +167,7 @@ that is not present succeeds silently with an informational message.`,
 	cmd.Flags().StringVar(&inbound, "inbound", "", "Sender address to remove from the inbound whitelist")
 	cmd.Flags().StringVar(&outbound, "outbound", "", "Recipient address to remove from the outbound whitelist")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // validateEmailAddress returns an error if addr is not a plausible email address.
@@ -284,6 +284,7 @@ func storeWhitelist(ctx context.Context, client pipelinev1.PipelineServiceClient
 }
 
 func runConfigEmailWhitelistList(ctx context.Context, deps *PipelineCommandDeps) error {
+	w := resultOut()
 	client, close, err := connectAndGetClient(deps)
 	if err != nil {
 		return err
@@ -300,33 +301,34 @@ func runConfigEmailWhitelistList(ctx context.Context, deps *PipelineCommandDeps)
 		return err
 	}
 
-	fmt.Println("Email Whitelists")
-	fmt.Println(strings.Repeat("=", 40))
-	fmt.Println()
+	fmt.Fprintln(w, "Email Whitelists")
+	fmt.Fprintln(w, strings.Repeat("=", 40))
+	fmt.Fprintln(w)
 
-	fmt.Printf("Inbound (senders allowed for ingestion): %d address(es)\n", len(inbound))
+	fmt.Fprintf(w, "Inbound (senders allowed for ingestion): %d address(es)\n", len(inbound))
 	if len(inbound) == 0 {
-		fmt.Println("  (none — all senders are blocked)")
+		fmt.Fprintln(w, "  (none — all senders are blocked)")
 	} else {
 		for _, a := range inbound {
-			fmt.Printf("  %s\n", a)
+			fmt.Fprintf(w, "  %s\n", a)
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Printf("Outbound (recipients allowed for delivery): %d address(es)\n", len(outbound))
+	fmt.Fprintf(w, "Outbound (recipients allowed for delivery): %d address(es)\n", len(outbound))
 	if len(outbound) == 0 {
-		fmt.Println("  (none — all delivery is blocked)")
+		fmt.Fprintln(w, "  (none — all delivery is blocked)")
 	} else {
 		for _, a := range outbound {
-			fmt.Printf("  %s\n", a)
+			fmt.Fprintf(w, "  %s\n", a)
 		}
 	}
 
-	return nil
+	return writeResult(EmailWhitelists{Inbound: inbound, Outbound: outbound}, "")
 }
 
 func runConfigEmailWhitelistAdd(ctx context.Context, deps *PipelineCommandDeps, key, addr string) error {
+	w := resultOut()
 	addr = normaliseEmail(addr)
 	if err := validateEmailAddress(addr); err != nil {
 		return err
@@ -345,19 +347,22 @@ func runConfigEmailWhitelistAdd(ctx context.Context, deps *PipelineCommandDeps,
 
 	updated, added := addToWhitelist(addrs, addr)
 	if !added {
-		fmt.Printf("%s is already on the %s whitelist — no change made.\n", addr, friendlyKeyName(key))
-		return nil
+		fmt.Fprintf(w, "%s is already on the %s whitelist — no change made.\n", addr, friendlyKeyName(key))
+		return writeResult(EmailWhitelistChange{Whitelist: friendlyKeyName(key), Address: addr, Addresses: addrs},
+			"already on the whitelist; no change made")
 	}
 
 	if err := storeWhitelist(ctx, client, key, updated); err != nil {
 		return err
 	}
 
-	fmt.Printf("Added %s to the %s whitelist (%d address(es) total).\n", addr, friendlyKeyName(key), len(updated))
-	return nil
+	fmt.Fprintf(w, "Added %s to the %s whitelist (%d address(es) total).\n", addr, friendlyKeyName(key), len(updated))
+	return writeResult(EmailWhitelistChange{Whitelist: friendlyKeyName(key), Address: addr, Changed: true, Addresses: updated},
+		"added to the whitelist")
 }
 
 func runConfigEmailWhitelistRemove(ctx context.Context, deps *PipelineCommandDeps, key, addr string) error {
+	w := resultOut()
 	addr = normaliseEmail(addr)
 	if err := validateEmailAddress(addr); err != nil {
 		return err
@@ -376,16 +381,33 @@ func runConfigEmailWhitelistRemove(ctx context.Context, deps *PipelineCommandDep
 
 	updated, removed := removeFromWhitelist(addrs, addr)
 	if !removed {
-		fmt.Printf("%s was not found on the %s whitelist — no change made.\n", addr, friendlyKeyName(key))
-		return nil
+		fmt.Fprintf(w, "%s was not found on the %s whitelist — no change made.\n", addr, friendlyKeyName(key))
+		return writeResult(EmailWhitelistChange{Whitelist: friendlyKeyName(key), Address: addr, Addresses: addrs},
+			"not on the whitelist; no change made")
 	}
 
 	if err := storeWhitelist(ctx, client, key, updated); err != nil {
 		return err
 	}
 
-	fmt.Printf("Removed %s from the %s whitelist (%d address(es) remaining).\n", addr, friendlyKeyName(key), len(updated))
-	return nil
+	fmt.Fprintf(w, "Removed %s from the %s whitelist (%d address(es) remaining).\n", addr, friendlyKeyName(key), len(updated))
+	return writeResult(EmailWhitelistChange{Whitelist: friendlyKeyName(key), Address: addr, Changed: true, Addresses: updated},
+		"removed from the whitelist")
+}
+
+// EmailWhitelists is the --output json result of 'config email whitelist list'.
+type EmailWhitelists struct {
+	Inbound  []string `json:"inbound"`
+	Outbound []string `json:"outbound"`
+}
+
+// EmailWhitelistChange is the --output json result of 'config email whitelist
+// add' and 'remove'. Addresses is the whitelist after the change.
+type EmailWhitelistChange struct {
+	Whitelist string   `json:"whitelist"`
+	Address   string   `json:"address"`
+	Changed   bool     `json:"changed"`
+	Addresses []string `json:"addresses"`
 }
 
 // friendlyKeyName returns a human-readable name for a whitelist config key.
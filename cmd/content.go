@@ -11,7 +11,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
@@ -31,6 +30,9 @@ var (
 	contentBefore     string
 	contentReason     string
 	contentFull       bool
+	contentSince      string
+	contentPageToken  string
+	contentAll        bool
 )
 
 // ContentCommandDeps holds the dependencies for content commands.
@@ -86,12 +88,16 @@ JSON Output (for AI processing):
     "items": [
       {
         "id": "content-123",
+        "content_type": "EMAIL",
         "source_type": "email",
         "state": "COMPLETED",
-        "metadata": {"subject": "Project update", "from": "user@example.com"}
+        "subject": "Project update",
+        "summary": "Weekly status from the platform team",
+        "created_at": "2026-01-15T10:30:00Z"
       }
     ],
-    "total_count": 42
+    "total_count": 42,
+    "next_page_token": "..."
   }
 
 Related Commands:
@@ -101,7 +107,7 @@ Related Commands:
 	}
 
 	// Add persistent flags
-	cmd.PersistentFlags().StringVarP(&contentOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.PersistentFlags().StringVarP(&contentOutput, "output", "o", "", "Output format: text, json, yaml (list and show also accept template; list also accepts jsonl)")
 	cmd.PersistentFlags().Int32VarP(&contentLimit, "limit", "l", 50, "Maximum number of results")
 
 	// Add subcommands
@@ -116,6 +122,8 @@ Related Commands:
 	cmd.AddCommand(newContentInsightsCommand(deps))
 	cmd.AddCommand(newContentAssertionsCommand(deps))
 	cmd.AddCommand(newContentClearErrorCommand(deps))
+	cmd.AddCommand(newContentDiffCommand(deps))
+	cmd.AddCommand(newContentExportCommand(deps))
 
 	return cmd
 }
@@ -127,7 +135,8 @@ func newContentListCommand(deps *ContentCommandDeps) *cobra.Command {
 		Short: "List content items",
 		Long: `List content items with optional filtering.
 
-Filter by processing state, source type, or tenant. Results are paginated.
+Filter by processing state, source type, creation time, or tenant. Results are
+paginated: use --page-token to fetch the next page, or --all to fetch every page.
 
 Examples:
   # List all content items
@@ -141,11 +150,25 @@ Examples:
   penf content list --source email
   penf content list --source document
 
+  # Filter by creation time
+  penf content list --since 24h
+  penf content list --since 2026-01-01
+
   # Combine filters
   penf content list --status complete --source email --limit 100
 
+  # Paging
+  penf content list --page-token <token>
+  penf content list --status failed --all
+
   # Output as JSON
-  penf content list -o json`,
+  penf content list -o json
+
+  # Stream one JSON object per line while paging
+  penf content list --all -o jsonl
+
+  # One line per item from a Go template (fields as in ContentListItem)
+  penf content list -o template --template '{{.ID}} {{.State}} {{date "2006-01-02" .CreatedAt}} {{.Subject}}'`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runContentList(cmd.Context(), deps)
@@ -154,8 +177,13 @@ Examples:
 
 	cmd.Flags().StringVar(&contentStatus, "status", "", "Filter by processing state: pending, processing, complete, failed, cancelled, rejected, skipped")
 	cmd.Flags().StringVar(&contentSource, "source", "", "Filter by source type: email, document, meeting, slack")
+	cmd.Flags().StringVar(&contentSource, "source-type", "", "Filter by source type (alias for --source)")
+	_ = cmd.Flags().MarkHidden("source-type")
 	cmd.Flags().StringVar(&contentType, "type", "", "Filter by content type: email, meeting, calendar, document, attachment")
 	cmd.Flags().StringVar(&contentTenant, "tenant", "", "Filter by tenant ID (defaults to config tenant)")
+	cmd.Flags().StringVar(&contentSince, "since", "", "Only items created since this time (e.g., '24h', 'yesterday', '2026-01-01')")
+	cmd.Flags().StringVar(&contentPageToken, "page-token", "", "Page token from a previous response")
+	cmd.Flags().BoolVar(&contentAll, "all", false, "Fetch all pages")
 
 	return cmd
 }
@@ -178,7 +206,10 @@ Examples:
   penf content show content-123 --processing
 
   # Output as JSON
-  penf content show content-123 -o json`,
+  penf content show content-123 -o json
+
+  # Render with a Go template (same fields as content list -o template)
+  penf content show content-123 -o template --template '{{.ID}} {{.State}} {{.Subject}}'`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runContentShow(cmd.Context(), deps, args[0])
@@ -234,6 +265,14 @@ func runContentList(ctx context.Context, deps *ContentCommandDeps) error {
 		req.State = &state
 	}
 
+	if contentSince != "" {
+		sinceTime, err := parseTimeFilter(contentSince)
+		if err != nil {
+			return fmt.Errorf("parsing --since value: %w", err)
+		}
+		req.CreatedAfter = timestamppb.New(sinceTime)
+	}
+
 	// Get tenant ID
 	tenantID := contentTenant
 	if tenantID == "" {
@@ -243,19 +282,52 @@ func runContentList(ctx context.Context, deps *ContentCommandDeps) error {
 		return fmt.Errorf("tenant ID required: set via --tenant flag or 'penf config set tenant_id <id>'")
 	}
 	req.TenantId = tenantID
+	req.PageToken = contentPageToken
 
-	// Make request
-	resp, err := client.ListContentItems(ctx, req)
-	if err != nil {
-		return fmt.Errorf("listing content items: %w", err)
-	}
-
-	// Output results
 	format := cfg.OutputFormat
 	if contentOutput != "" {
 		format = config.OutputFormat(contentOutput)
 	}
 
+	// JSON lines and templates are written as each page arrives rather than
+	// collected.
+	stream, err := newRecordStream(os.Stdout, format)
+	if err != nil {
+		return err
+	}
+
+	// Fetch one page, or every page when --all is set
+	resp := &contentv1.ListContentItemsResponse{}
+	for {
+		page, err := client.ListContentItems(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing content items: %w", err)
+		}
+
+		if stream != nil {
+			for _, item := range page.Items {
+				stream.Encode(contentItemToListItem(item))
+			}
+			if stream.Err() != nil {
+				return fmt.Errorf("writing output: %w", stream.Err())
+			}
+		} else {
+			resp.Items = append(resp.Items, page.Items...)
+		}
+		resp.TotalCount = page.TotalCount
+		resp.NextPageToken = page.NextPageToken
+
+		if !contentAll || page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
+	}
+
+	if stream != nil {
+		printNextPageHint(os.Stderr, resp.NextPageToken)
+		return nil
+	}
+
 	return outputContentList(format, resp)
 }
 
@@ -274,6 +346,14 @@ func runContentShow(ctx context.Context, deps *ContentCommandDeps, contentID str
 
 	client := contentv1.NewContentProcessorServiceClient(conn)
 
+	format := cfg.OutputFormat
+	if contentOutput != "" {
+		format = config.OutputFormat(contentOutput)
+	}
+	if err := checkOutputTemplate(format); err != nil {
+		return err
+	}
+
 	// Get content item
 	getReq := &contentv1.GetContentItemRequest{
 		ContentId:        contentID,
@@ -301,27 +381,110 @@ func runContentShow(ctx context.Context, deps *ContentCommandDeps, contentID str
 	logActivity(cfg, fmt.Sprintf("content show: %s", contentID))
 
 	// Output results
-	format := cfg.OutputFormat
-	if contentOutput != "" {
-		format = config.OutputFormat(contentOutput)
-	}
-
 	return outputContentItem(format, item, status)
 }
 
 // Output functions
 
+// ContentListItem is the structured view of a content item used by 'content list'.
+type ContentListItem struct {
+	ID             string     `json:"id" yaml:"id"`
+	ContentType    string     `json:"content_type" yaml:"content_type"`
+	ContentSubtype string     `json:"content_subtype,omitempty" yaml:"content_subtype,omitempty"`
+	SourceType     string     `json:"source_type,omitempty" yaml:"source_type,omitempty"`
+	SourceID       string     `json:"source_id,omitempty" yaml:"source_id,omitempty"`
+	SourceSystem   string     `json:"source_system,omitempty" yaml:"source_system,omitempty"`
+	State          string     `json:"state" yaml:"state"`
+	Subject        string     `json:"subject,omitempty" yaml:"subject,omitempty"`
+	Summary        string     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	FailureReason  string     `json:"failure_reason,omitempty" yaml:"failure_reason,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+	ProcessedAt    *time.Time `json:"processed_at,omitempty" yaml:"processed_at,omitempty"`
+}
+
+// ContentListResult is the structured output of 'content list'.
+type ContentListResult struct {
+	Items         []ContentListItem `json:"items" yaml:"items"`
+	Count         int               `json:"count" yaml:"count"`
+	TotalCount    int64             `json:"total_count" yaml:"total_count"`
+	NextPageToken string            `json:"next_page_token,omitempty" yaml:"next_page_token,omitempty"`
+}
+
 func outputContentList(format config.OutputFormat, resp *contentv1.ListContentItemsResponse) error {
 	switch format {
 	case config.OutputFormatJSON:
-		return outputContentJSON(resp)
+		return outputContentJSON(buildContentListResult(resp))
 	case config.OutputFormatYAML:
-		return outputContentYAML(resp)
+		return outputContentYAML(buildContentListResult(resp))
 	default:
 		return outputContentListText(resp)
 	}
 }
 
+// buildContentListResult converts a list response into its structured output form.
+func buildContentListResult(resp *contentv1.ListContentItemsResponse) ContentListResult {
+	result := ContentListResult{
+		Items:         make([]ContentListItem, 0, len(resp.Items)),
+		Count:         len(resp.Items),
+		TotalCount:    int64(len(resp.Items)),
+		NextPageToken: resp.NextPageToken,
+	}
+	if resp.TotalCount != nil {
+		result.TotalCount = *resp.TotalCount
+	}
+
+	for _, item := range resp.Items {
+		result.Items = append(result.Items, contentItemToListItem(item))
+	}
+
+	return result
+}
+
+// contentItemToListItem converts a proto content item to its list view.
+func contentItemToListItem(item *contentv1.ContentItem) ContentListItem {
+	listItem := ContentListItem{
+		ID:            item.Id,
+		ContentType:   formatContentType(item),
+		SourceType:    item.SourceType,
+		SourceID:      item.SourceId,
+		SourceSystem:  item.Metadata["source_system"],
+		State:         stripEnumPrefix(item.State.String(), "PROCESSING_STATE_"),
+		Subject:       getSubjectFromMetadata(item.Metadata),
+		Summary:       item.GetSummary(),
+		FailureReason: item.GetFailureReason(),
+	}
+
+	if subtype := formatContentSubtype(item); subtype != "-" {
+		listItem.ContentSubtype = subtype
+	}
+	if item.CreatedAt != nil {
+		t := item.CreatedAt.AsTime()
+		listItem.CreatedAt = &t
+	}
+	if item.UpdatedAt != nil {
+		t := item.UpdatedAt.AsTime()
+		listItem.UpdatedAt = &t
+	}
+	if item.ProcessedAt != nil {
+		t := item.ProcessedAt.AsTime()
+		listItem.ProcessedAt = &t
+	}
+
+	return listItem
+}
+
+// contentItemSource returns a display string for where a content item came from.
+func contentItemSource(item *contentv1.ContentItem) string {
+	if source := item.Metadata["source_system"]; source != "" {
+		return source
+	}
+	if item.SourceType != "" {
+		return item.SourceType
+	}
+	return "-"
+}
+
 func outputContentListText(resp *contentv1.ListContentItemsResponse) error {
 	if len(resp.Items) == 0 {
 		fmt.Println("No content items found.")
@@ -334,8 +497,8 @@ func outputContentListText(resp *contentv1.ListContentItemsResponse) error {
 	}
 
 	fmt.Printf("Content Items (%d total):\n\n", totalCount)
-	fmt.Println("  ID                    TYPE        SUBTYPE       SUBJECT/TITLE                      STATE       CREATED")
-	fmt.Println("  --                    ----        -------       -------------                      -----       -------")
+	fmt.Println("  ID                    TYPE        SUBTYPE       SOURCE      SUBJECT/TITLE                      STATE       CREATED     SUMMARY")
+	fmt.Println("  --                    ----        -------       ------      -------------                      -----       -------     -------")
 
 	for _, item := range resp.Items {
 		// Get subject/title from metadata
@@ -353,17 +516,24 @@ func outputContentListText(resp *contentv1.ListContentItemsResponse) error {
 		// Format state
 		stateStr := formatProcessingState(item.State)
 
+		summary := strings.Join(strings.Fields(item.GetSummary()), " ")
+		if summary == "" {
+			summary = "-"
+		}
+
 		// Format type from enum, falling back to source_type
 		typeStr := formatContentType(item)
 		subtypeStr := formatContentSubtype(item)
 
-		fmt.Printf("  %-21s %-11s %-13s %-34s %-11s %s\n",
+		fmt.Printf("  %-21s %-11s %-13s %-11s %-34s %-11s %-11s %s\n",
 			truncate(item.Id, 21),
 			truncate(typeStr, 11),
 			truncate(subtypeStr, 13),
+			truncate(contentItemSource(item), 11),
 			truncate(subject, 34),
 			stateStr,
-			createdStr)
+			createdStr,
+			truncate(summary, 40))
 	}
 
 	fmt.Println()
@@ -393,6 +563,9 @@ func outputContentItem(format config.OutputFormat, item *contentv1.ContentItem,
 			data["processing_status"] = status
 		}
 		return outputContentYAML(data)
+	case config.OutputFormatTemplate:
+		// Same fields as 'content list -o template', so one template works for both.
+		return outputWithTemplate(os.Stdout, contentItemToListItem(item))
 	default:
 		return outputContentItemText(item, status, contentFull)
 	}
@@ -616,7 +789,7 @@ func outputContentJSON(v interface{}) error {
 }
 
 func outputContentYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -884,6 +1057,10 @@ func runContentDeleteSingle(ctx context.Context, deps *ContentCommandDeps, conte
 	}
 	deps.Config = cfg
 
+	if err := guardDestructiveTenant(cfg, cfg.EffectiveTenantID()); err != nil {
+		return err
+	}
+
 	conn, err := connectToGateway(cfg)
 	if err != nil {
 		return err
@@ -929,6 +1106,9 @@ func runContentDeleteBulk(ctx context.Context, deps *ContentCommandDeps) error {
 	if tenantID == "" {
 		return fmt.Errorf("tenant ID required: set via --tenant flag or 'penf config set tenant_id <id>'")
 	}
+	if err := guardDestructiveTenant(cfg, tenantID); err != nil {
+		return err
+	}
 
 	conn, err := connectToGateway(cfg)
 	if err != nil {
@@ -1039,6 +1219,9 @@ func runContentPurgeSingle(ctx context.Context, deps *ContentCommandDeps, conten
 	if !contentConfirm {
 		return fmt.Errorf("--confirm flag is required")
 	}
+	if err := guardDestructiveTenant(cfg, cfg.EffectiveTenantID()); err != nil {
+		return err
+	}
 
 	conn, err := connectToGateway(cfg)
 	if err != nil {
@@ -1096,6 +1279,9 @@ func runContentPurgeBulk(ctx context.Context, deps *ContentCommandDeps) error {
 	if tenantID == "" {
 		return fmt.Errorf("tenant ID required: set via --tenant flag or 'penf config set tenant_id <id>'")
 	}
+	if err := guardDestructiveTenant(cfg, tenantID); err != nil {
+		return err
+	}
 
 	conn, err := connectToGateway(cfg)
 	if err != nil {
@@ -1203,8 +1389,8 @@ func outputContentStatsText(stats *contentv1.ContentStats) error {
 	// Count by source type
 	if len(stats.CountByType) > 0 {
 		fmt.Println("  \033[1mBy Source Type:\033[0m")
-		for sourceType, count := range stats.CountByType {
-			fmt.Printf("    %-12s %d\n", sourceType+":", count)
+		for _, sourceType := range orderedKeys(stats.CountByType, nil) {
+			fmt.Printf("    %-12s %d\n", sourceType+":", stats.CountByType[sourceType])
 		}
 		fmt.Println()
 	}
@@ -1212,8 +1398,8 @@ func outputContentStatsText(stats *contentv1.ContentStats) error {
 	// Count by processing state
 	if len(stats.CountByState) > 0 {
 		fmt.Println("  \033[1mBy Processing State:\033[0m")
-		for state, count := range stats.CountByState {
-			fmt.Printf("    %-12s %d\n", state+":", count)
+		for _, state := range SortedStatuses(stats.CountByState) {
+			fmt.Printf("    %-12s %d\n", state+":", stats.CountByState[state])
 		}
 		fmt.Println()
 	}
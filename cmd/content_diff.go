@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// contentDiffSide is everything compared for one content item.
+type contentDiffSide struct {
+	Item     *contentv1.ContentItem
+	Text     string
+	Insights []*contentv1.Insight
+}
+
+// ContentFieldDiff is one difference between two content items. A is the
+// value from the first item and B from the second.
+type ContentFieldDiff struct {
+	Section string `json:"section" yaml:"section"`
+	Field   string `json:"field" yaml:"field"`
+	A       string `json:"a,omitempty" yaml:"a,omitempty"`
+	B       string `json:"b,omitempty" yaml:"b,omitempty"`
+	Change  string `json:"change" yaml:"change"`
+}
+
+// ContentDiffOutput is the output of 'content diff'.
+type ContentDiffOutput struct {
+	ContentA  string             `json:"content_a" yaml:"content_a"`
+	ContentB  string             `json:"content_b" yaml:"content_b"`
+	Identical bool               `json:"identical" yaml:"identical"`
+	Counts    PipelineDiffCounts `json:"counts" yaml:"counts"`
+	Diffs     []ContentFieldDiff `json:"diffs" yaml:"diffs"`
+}
+
+// newContentDiffCommand creates the 'content diff' subcommand.
+func newContentDiffCommand(deps *ContentCommandDeps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <content-id-a> <content-id-b>",
+		Short: "Compare two content items",
+		Long: `Compare two content items field by field.
+
+Fetches both items and shows where they differ in item fields, metadata,
+extracted text, summary, and extracted insights such as entities and
+keywords. Values only in the second item are shown as ADDED, values only in
+the first as REMOVED.
+
+Use it to decide whether two suspected duplicates really are the same before
+deleting or merging one of them. To compare two pipeline runs of one source,
+use 'penf pipeline diff'.
+
+Examples:
+  # Compare two emails
+  penf content diff em-abc123 em-def456
+
+  # Output as JSON
+  penf content diff em-abc123 em-def456 -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runContentDiff(cmd.Context(), deps, args[0], args[1])
+		},
+	}
+}
+
+// runContentDiff executes the content diff command.
+func runContentDiff(ctx context.Context, deps *ContentCommandDeps, idA, idB string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := contentv1.NewContentProcessorServiceClient(conn)
+
+	a, err := fetchContentDiffSide(ctx, client, idA)
+	if err != nil {
+		return err
+	}
+	b, err := fetchContentDiffSide(ctx, client, idB)
+	if err != nil {
+		return err
+	}
+
+	diffs := diffContentItems(a, b)
+
+	format := cfg.OutputFormat
+	if contentOutput != "" {
+		format = config.OutputFormat(contentOutput)
+	}
+
+	switch format {
+	case config.OutputFormatJSON:
+		return outputContentJSON(buildContentDiffOutput(idA, idB, diffs))
+	case config.OutputFormatYAML:
+		return outputContentYAML(buildContentDiffOutput(idA, idB, diffs))
+	default:
+		outputContentDiffText(os.Stdout, idA, idB, diffs)
+		return nil
+	}
+}
+
+// fetchContentDiffSide fetches the item, text and insights of a content item.
+func fetchContentDiffSide(ctx context.Context, client contentv1.ContentProcessorServiceClient, contentID string) (contentDiffSide, error) {
+	item, err := client.GetContentItem(ctx, &contentv1.GetContentItemRequest{ContentId: contentID})
+	if err != nil {
+		return contentDiffSide{}, fmt.Errorf("getting content item %s: %w", contentID, err)
+	}
+	text, err := client.GetContentText(ctx, &contentv1.GetContentTextRequest{ContentId: contentID})
+	if err != nil {
+		return contentDiffSide{}, fmt.Errorf("getting text of %s: %w", contentID, err)
+	}
+	insights, err := client.GetInsights(ctx, &contentv1.GetInsightsRequest{ContentId: contentID})
+	if err != nil {
+		return contentDiffSide{}, fmt.Errorf("getting insights of %s: %w", contentID, err)
+	}
+	return contentDiffSide{Item: item, Text: text.Text, Insights: insights.Insights}, nil
+}
+
+// diffContentItems compares two content items. Differences use the pipeline
+// diff representation: Stage holds the section ("item", "metadata", "text",
+// "summary", or an insight type), OldValue is from a and NewValue from b.
+func diffContentItems(a, b contentDiffSide) []*pipelinev1.StageDiff {
+	var diffs []*pipelinev1.StageDiff
+	add := func(section, field, valueA, valueB string) {
+		if d := diffContentValue(section, field, valueA, valueB); d != nil {
+			diffs = append(diffs, d)
+		}
+	}
+
+	add("item", "content_type", a.Item.GetContentTypeEnum().String(), b.Item.GetContentTypeEnum().String())
+	add("item", "source_type", a.Item.GetSourceType(), b.Item.GetSourceType())
+	add("item", "content_hash", a.Item.GetContentHash(), b.Item.GetContentHash())
+
+	metaA, metaB := a.Item.GetMetadata(), b.Item.GetMetadata()
+	for _, key := range unionKeys(metaA, metaB) {
+		add("metadata", key, metaA[key], metaB[key])
+	}
+
+	if line, textA, textB, ok := firstDifferingLine(a.Text, b.Text); ok {
+		diffs = append(diffs, &pipelinev1.StageDiff{
+			Stage:      "text",
+			Field:      fmt.Sprintf("line %d", line),
+			OldValue:   textA,
+			NewValue:   textB,
+			ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED,
+		})
+	}
+
+	add("summary", "summary", a.Item.GetSummary(), b.Item.GetSummary())
+
+	insightsA, insightsB := flattenInsights(a.Insights), flattenInsights(b.Insights)
+	for _, insightType := range unionKeys(insightsA, insightsB) {
+		fieldsA, fieldsB := insightsA[insightType], insightsB[insightType]
+		for _, field := range unionKeys(fieldsA, fieldsB) {
+			diffs = append(diffs, diffContentValues(insightType, field, fieldsA[field], fieldsB[field])...)
+		}
+	}
+
+	return diffs
+}
+
+// diffContentValue compares one value, returning nil when they are equal.
+func diffContentValue(section, field, valueA, valueB string) *pipelinev1.StageDiff {
+	d := &pipelinev1.StageDiff{Stage: section, Field: field, OldValue: valueA, NewValue: valueB}
+	switch {
+	case valueA == valueB:
+		return nil
+	case valueA == "":
+		d.ChangeType = pipelinev1.ChangeType_CHANGE_TYPE_ADDED
+	case valueB == "":
+		d.ChangeType = pipelinev1.ChangeType_CHANGE_TYPE_REMOVED
+	default:
+		d.ChangeType = pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED
+	}
+	return d
+}
+
+// diffContentValues compares the values of one insight field. A single value
+// on both sides is compared as a scalar; lists are compared as sets, with one
+// difference per value present on only one side.
+func diffContentValues(section, field string, valuesA, valuesB []string) []*pipelinev1.StageDiff {
+	if len(valuesA) <= 1 && len(valuesB) <= 1 {
+		var valueA, valueB string
+		if len(valuesA) == 1 {
+			valueA = valuesA[0]
+		}
+		if len(valuesB) == 1 {
+			valueB = valuesB[0]
+		}
+		if d := diffContentValue(section, field, valueA, valueB); d != nil {
+			return []*pipelinev1.StageDiff{d}
+		}
+		return nil
+	}
+
+	inA := make(map[string]bool, len(valuesA))
+	for _, v := range valuesA {
+		inA[v] = true
+	}
+	inB := make(map[string]bool, len(valuesB))
+	for _, v := range valuesB {
+		inB[v] = true
+	}
+
+	var diffs []*pipelinev1.StageDiff
+	for _, v := range valuesA {
+		if !inB[v] {
+			diffs = append(diffs, &pipelinev1.StageDiff{Stage: section, Field: field, OldValue: v, ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_REMOVED})
+		}
+	}
+	for _, v := range valuesB {
+		if !inA[v] {
+			diffs = append(diffs, &pipelinev1.StageDiff{Stage: section, Field: field, NewValue: v, ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_ADDED})
+		}
+	}
+	return diffs
+}
+
+// flattenInsights maps each insight type to its fields, with nested objects
+// flattened to dotted field names. Each field holds its value, or the values
+// of a list.
+func flattenInsights(insights []*contentv1.Insight) map[string]map[string][]string {
+	flat := make(map[string]map[string][]string, len(insights))
+	for _, insight := range insights {
+		fields := make(map[string][]string)
+		if insight.Data != nil {
+			flattenInsightData(fields, "", insight.Data.AsMap())
+		}
+		flat[insight.Type] = fields
+	}
+	return flat
+}
+
+func flattenInsightData(fields map[string][]string, prefix string, data map[string]interface{}) {
+	for key, value := range data {
+		field := key
+		if prefix != "" {
+			field = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenInsightData(fields, field, v)
+		case []interface{}:
+			values := make([]string, 0, len(v))
+			for _, item := range v {
+				values = append(values, insightValueString(item))
+			}
+			fields[field] = values
+		default:
+			fields[field] = []string{insightValueString(v)}
+		}
+	}
+}
+
+// insightValueString formats an insight value for comparison. Objects are
+// named by their name, text or value field when they have one.
+func insightValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		for _, key := range []string{"name", "text", "value"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s
+			}
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// firstDifferingLine returns the first line, counting from 1, at which two
+// texts differ, and that line of each. Line endings are normalized first.
+func firstDifferingLine(a, b string) (int, string, string, bool) {
+	a = strings.ReplaceAll(a, "\r\n", "\n")
+	b = strings.ReplaceAll(b, "\r\n", "\n")
+	if a == b {
+		return 0, "", "", false
+	}
+	linesA, linesB := strings.Split(a, "\n"), strings.Split(b, "\n")
+	for i := 0; ; i++ {
+		var lineA, lineB string
+		if i < len(linesA) {
+			lineA = linesA[i]
+		}
+		if i < len(linesB) {
+			lineB = linesB[i]
+		}
+		if lineA != lineB || i >= len(linesA) || i >= len(linesB) {
+			return i + 1, strings.TrimSpace(lineA), strings.TrimSpace(lineB), true
+		}
+	}
+}
+
+// unionKeys returns the keys of both maps, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildContentDiffOutput converts differences to the JSON/YAML output.
+func buildContentDiffOutput(idA, idB string, diffs []*pipelinev1.StageDiff) ContentDiffOutput {
+	out := ContentDiffOutput{
+		ContentA:  idA,
+		ContentB:  idB,
+		Identical: len(diffs) == 0,
+		Counts:    summarizePipelineDiffs(diffs).PipelineDiffCounts,
+		Diffs:     make([]ContentFieldDiff, 0, len(diffs)),
+	}
+	for _, d := range diffs {
+		out.Diffs = append(out.Diffs, ContentFieldDiff{
+			Section: d.Stage,
+			Field:   d.Field,
+			A:       d.OldValue,
+			B:       d.NewValue,
+			Change:  strings.ToLower(strings.TrimPrefix(d.ChangeType.String(), "CHANGE_TYPE_")),
+		})
+	}
+	return out
+}
+
+// outputContentDiffText prints the differences as a table, in the style of
+// 'pipeline diff'.
+func outputContentDiffText(w io.Writer, idA, idB string, diffs []*pipelinev1.StageDiff) {
+	fmt.Fprintf(w, "Content Diff: %s (A) vs %s (B)\n", idA, idB)
+	fmt.Fprintln(w, "====================================")
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No differences found between items.")
+		return
+	}
+
+	counts := summarizePipelineDiffs(diffs)
+	fmt.Fprintf(w, "Found %d differences: %d added, %d removed, %d modified\n\n",
+		counts.Total, counts.Added, counts.Removed, counts.Modified)
+	printStageDiffTable(w, diffs, "SECTION", "A", "B")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+func contentDiffTestSide(t *testing.T, id, summary, text string, metadata map[string]string, entities []interface{}, keywords []interface{}) contentDiffSide {
+	t.Helper()
+	data, err := structpb.NewStruct(map[string]interface{}{"entities": entities})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kw, err := structpb.NewStruct(map[string]interface{}{"keywords": keywords, "language": "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contentDiffSide{
+		Item: &contentv1.ContentItem{
+			Id:              id,
+			SourceType:      "email",
+			ContentHash:     "hash-" + id,
+			Summary:         &summary,
+			Metadata:        metadata,
+			ContentTypeEnum: contentv1.ContentType_CONTENT_TYPE_EMAIL,
+		},
+		Text: text,
+		Insights: []*contentv1.Insight{
+			{Type: "entities", Data: data},
+			{Type: "keywords", Data: kw},
+		},
+	}
+}
+
+func TestDiffContentItems(t *testing.T) {
+	a := contentDiffTestSide(t, "em-a", "Q3 planning", "Hi all,\r\nThe launch is Monday.\r\nThanks",
+		map[string]string{"subject": "Q3 planning", "message_id": "<a@x>"},
+		[]interface{}{map[string]interface{}{"name": "Alice", "type": "person"}, "Acme"},
+		[]interface{}{"launch", "budget"})
+	b := contentDiffTestSide(t, "em-b", "Q3 planning", "Hi all,\nThe launch is Tuesday.\nThanks",
+		map[string]string{"subject": "Q3 planning", "in_reply_to": "<a@x>"},
+		[]interface{}{"Acme", map[string]interface{}{"name": "Bob"}},
+		[]interface{}{"launch", "budget"})
+
+	got := make(map[string]*pipelinev1.StageDiff)
+	for _, d := range diffContentItems(a, b) {
+		got[d.Stage+"/"+d.Field+"/"+d.OldValue+d.NewValue] = d
+	}
+
+	for key, change := range map[string]pipelinev1.ChangeType{
+		"item/content_hash/hash-em-ahash-em-b":                    pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED,
+		"metadata/message_id/<a@x>":                               pipelinev1.ChangeType_CHANGE_TYPE_REMOVED,
+		"metadata/in_reply_to/<a@x>":                              pipelinev1.ChangeType_CHANGE_TYPE_ADDED,
+		"text/line 2/The launch is Monday.The launch is Tuesday.": pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED,
+		"entities/entities/Alice":                                 pipelinev1.ChangeType_CHANGE_TYPE_REMOVED,
+		"entities/entities/Bob":                                   pipelinev1.ChangeType_CHANGE_TYPE_ADDED,
+	} {
+		d, ok := got[key]
+		if !ok {
+			t.Errorf("missing difference %s", key)
+			continue
+		}
+		if d.ChangeType != change {
+			t.Errorf("%s change = %s, want %s", key, d.ChangeType, change)
+		}
+	}
+	if len(got) != 6 {
+		t.Errorf("got %d differences, want 6 (equal summary, subject, keywords and shared entity are not differences): %v", len(got), got)
+	}
+}
+
+func TestDiffContentItems_Identical(t *testing.T) {
+	a := contentDiffTestSide(t, "em-a", "s", "text", nil, []interface{}{"Acme"}, []interface{}{"x"})
+	b := contentDiffTestSide(t, "em-a", "s", "text", nil, []interface{}{"Acme"}, []interface{}{"x"})
+	if diffs := diffContentItems(a, b); len(diffs) != 0 {
+		t.Errorf("identical items have %d differences: %v", len(diffs), diffs)
+	}
+}
+
+func TestOutputContentDiffText(t *testing.T) {
+	diffs := []*pipelinev1.StageDiff{
+		{Stage: "metadata", Field: "subject", OldValue: "Q3 planning", NewValue: "Re: Q3 planning", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED},
+		{Stage: "entities", Field: "entities", NewValue: "Bob", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_ADDED},
+	}
+
+	var buf bytes.Buffer
+	outputContentDiffText(&buf, "em-a", "em-b", diffs)
+	got := buf.String()
+	for _, want := range []string{
+		"Content Diff: em-a (A) vs em-b (B)",
+		"Found 2 differences: 1 added, 0 removed, 1 modified",
+		"SECTION          FIELD                      A                          B                          CHANGE",
+		"metadata         subject                    Q3 planning                Re: Q3 planning",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	out := buildContentDiffOutput("em-a", "em-b", diffs)
+	if out.Identical || out.Counts.Total != 2 || out.Diffs[1].Change != "added" || out.Diffs[1].B != "Bob" {
+		t.Errorf("output = %+v", out)
+	}
+}
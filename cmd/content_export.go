@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+// Content export bundle formats.
+const (
+	contentExportFormatFiles = "files"
+	contentExportFormatJSON  = "json"
+)
+
+// Content export flags.
+var (
+	contentExportDir    string
+	contentExportFormat string
+)
+
+// ContentExportMetadata describes an exported content item.
+type ContentExportMetadata struct {
+	ID              string            `json:"id"`
+	SourceType      string            `json:"source_type"`
+	SourceID        string            `json:"source_id,omitempty"`
+	ContentType     string            `json:"content_type"`
+	State           string            `json:"state"`
+	ContentHash     string            `json:"content_hash,omitempty"`
+	CreatedAt       *time.Time        `json:"created_at,omitempty"`
+	ProcessedAt     *time.Time        `json:"processed_at,omitempty"`
+	FailureCategory string            `json:"failure_category,omitempty"`
+	FailureReason   string            `json:"failure_reason,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	ExportedAt      time.Time         `json:"exported_at"`
+	Skipped         []string          `json:"skipped,omitempty"`
+}
+
+// ContentExportInsight is one extracted insight of an exported content item.
+type ContentExportInsight struct {
+	Type         string                 `json:"type"`
+	Data         map[string]interface{} `json:"data,omitempty"`
+	ExtractedAt  *time.Time             `json:"extracted_at,omitempty"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+}
+
+// ContentExport is everything exported for a content item. Empty parts were
+// not available and are listed in Metadata.Skipped.
+type ContentExport struct {
+	Metadata  ContentExportMetadata  `json:"metadata"`
+	Original  string                 `json:"original,omitempty"`
+	Processed string                 `json:"processed,omitempty"`
+	Text      string                 `json:"text,omitempty"`
+	Summary   string                 `json:"summary,omitempty"`
+	Insights  []ContentExportInsight `json:"insights,omitempty"`
+}
+
+// newContentExportCommand creates the 'content export' subcommand.
+func newContentExportCommand(deps *ContentCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <content-id>",
+		Short: "Export a content item's original and processed content to disk",
+		Long: `Export the original and processed content of a content item to a directory,
+for offline review or to share a specific item.
+
+With --format files (the default), the directory gets one file per part:
+  original.txt     The content as ingested
+  processed.txt    The normalized content produced by the pipeline
+  text.txt         The extracted text
+  summary.txt      The generated summary
+  insights.json    Extracted insights (entities, action items, ...)
+  metadata.json    Item fields, source metadata, and which parts were skipped
+
+With --format json, everything is written to a single <content-id>.json.
+
+Parts the item does not have yet, such as the summary of an item still being
+processed, are skipped with a note rather than failing the export.
+
+Examples:
+  # Export into ./em-abc123
+  penf content export em-abc123
+
+  # Export into ./out
+  penf content export em-abc123 --dir ./out
+
+  # One JSON file, ./out/em-abc123.json
+  penf content export em-abc123 --dir ./out --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runContentExport(cmd.Context(), deps, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&contentExportDir, "dir", "", "Directory to write to (default: ./<content-id>)")
+	cmd.Flags().StringVar(&contentExportFormat, "format", contentExportFormatFiles, "Bundle format: files, json")
+
+	return cmd
+}
+
+// runContentExport executes the content export command.
+func runContentExport(ctx context.Context, deps *ContentCommandDeps, contentID string) error {
+	if contentExportFormat != contentExportFormatFiles && contentExportFormat != contentExportFormatJSON {
+		return fmt.Errorf("invalid --format: %s (must be files or json)", contentExportFormat)
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	export, err := fetchContentExport(ctx, contentv1.NewContentProcessorServiceClient(conn), contentID)
+	if err != nil {
+		return err
+	}
+
+	dir := contentExportDir
+	if dir == "" {
+		dir = contentID
+	}
+
+	var written []string
+	if contentExportFormat == contentExportFormatJSON {
+		written, err = writeContentExportJSON(dir, export)
+	} else {
+		written, err = writeContentExportFiles(dir, export)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %s to %s\n", contentID, dir)
+	for _, path := range written {
+		fmt.Printf("  %s\n", path)
+	}
+	for _, note := range export.Metadata.Skipped {
+		fmt.Printf("  Skipped %s\n", note)
+	}
+	return nil
+}
+
+// fetchContentExport fetches the parts of a content item. Only a failure to
+// get the item itself is an error; parts that are missing or fail to load are
+// recorded as skipped.
+func fetchContentExport(ctx context.Context, client contentv1.ContentProcessorServiceClient, contentID string) (*ContentExport, error) {
+	item, err := client.GetContentItem(ctx, &contentv1.GetContentItemRequest{ContentId: contentID})
+	if err != nil {
+		return nil, fmt.Errorf("getting content item: %w", err)
+	}
+
+	export := &ContentExport{
+		Metadata: ContentExportMetadata{
+			ID:              item.Id,
+			SourceType:      item.SourceType,
+			SourceID:        item.SourceId,
+			ContentType:     formatContentType(item),
+			State:           stripEnumPrefix(item.State.String(), "PROCESSING_STATE_"),
+			ContentHash:     item.ContentHash,
+			FailureCategory: item.GetFailureCategory(),
+			FailureReason:   item.GetFailureReason(),
+			Metadata:        item.Metadata,
+			ExportedAt:      time.Now().UTC(),
+		},
+		Original:  item.RawContent,
+		Processed: item.GetProcessedContent(),
+		Summary:   item.GetSummary(),
+	}
+	if item.CreatedAt != nil {
+		t := item.CreatedAt.AsTime()
+		export.Metadata.CreatedAt = &t
+	}
+	if item.ProcessedAt != nil {
+		t := item.ProcessedAt.AsTime()
+		export.Metadata.ProcessedAt = &t
+	}
+	skip := func(note string) {
+		export.Metadata.Skipped = append(export.Metadata.Skipped, note)
+	}
+
+	if export.Original == "" {
+		skip("original: not stored")
+	}
+	if export.Processed == "" {
+		skip("processed: not processed yet")
+	}
+
+	if text, err := client.GetContentText(ctx, &contentv1.GetContentTextRequest{ContentId: contentID}); err != nil {
+		skip(fmt.Sprintf("text: %v", err))
+	} else if text.Text == "" {
+		skip("text: none extracted")
+	} else {
+		export.Text = text.Text
+	}
+
+	if export.Summary == "" {
+		skip("summary: not generated yet")
+	}
+
+	if resp, err := client.GetInsights(ctx, &contentv1.GetInsightsRequest{ContentId: contentID}); err != nil {
+		skip(fmt.Sprintf("insights: %v", err))
+	} else if len(resp.Insights) == 0 {
+		skip("insights: none extracted")
+	} else {
+		for _, insight := range resp.Insights {
+			exported := ContentExportInsight{Type: insight.Type, ModelVersion: insight.ModelVersion}
+			if insight.Data != nil {
+				exported.Data = insight.Data.AsMap()
+			}
+			if insight.ExtractedAt != nil {
+				t := insight.ExtractedAt.AsTime()
+				exported.ExtractedAt = &t
+			}
+			export.Insights = append(export.Insights, exported)
+		}
+	}
+
+	return export, nil
+}
+
+// writeContentExportFiles writes each available part of export to its own
+// file in dir and returns the paths written.
+func writeContentExportFiles(dir string, export *ContentExport) ([]string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating export directory: %w", err)
+	}
+
+	var written []string
+	write := func(name string, data []byte) error {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	for _, part := range []struct{ name, content string }{
+		{"original.txt", export.Original},
+		{"processed.txt", export.Processed},
+		{"text.txt", export.Text},
+		{"summary.txt", export.Summary},
+	} {
+		if part.content == "" {
+			continue
+		}
+		if err := write(part.name, []byte(part.content)); err != nil {
+			return written, err
+		}
+	}
+
+	if len(export.Insights) > 0 {
+		data, err := json.MarshalIndent(export.Insights, "", "  ")
+		if err != nil {
+			return written, err
+		}
+		if err := write("insights.json", append(data, '\n')); err != nil {
+			return written, err
+		}
+	}
+
+	data, err := json.MarshalIndent(export.Metadata, "", "  ")
+	if err != nil {
+		return written, err
+	}
+	return written, write("metadata.json", append(data, '\n'))
+}
+
+// writeContentExportJSON writes export to <dir>/<content-id>.json and returns
+// the path written.
+func writeContentExportJSON(dir string, export *ContentExport) ([]string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating export directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, export.Metadata.ID+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return []string{path}, nil
+}
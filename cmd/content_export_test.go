@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+// fakeExportClient serves one content item that has not been summarized and
+// whose insights cannot be loaded.
+type fakeExportClient struct {
+	contentv1.ContentProcessorServiceClient
+}
+
+func (fakeExportClient) GetContentItem(ctx context.Context, in *contentv1.GetContentItemRequest, opts ...grpc.CallOption) (*contentv1.ContentItem, error) {
+	processed := "normalized body"
+	return &contentv1.ContentItem{
+		Id:               in.ContentId,
+		SourceType:       "email",
+		RawContent:       "Subject: Hi\r\n\r\nraw body",
+		ProcessedContent: &processed,
+		State:            contentv1.ProcessingState_PROCESSING_STATE_IN_PROGRESS,
+		ContentTypeEnum:  contentv1.ContentType_CONTENT_TYPE_EMAIL,
+		Metadata:         map[string]string{"subject": "Hi"},
+	}, nil
+}
+
+func (fakeExportClient) GetContentText(ctx context.Context, in *contentv1.GetContentTextRequest, opts ...grpc.CallOption) (*contentv1.GetContentTextResponse, error) {
+	return &contentv1.GetContentTextResponse{ContentId: in.ContentId, Text: "raw body"}, nil
+}
+
+func (fakeExportClient) GetInsights(ctx context.Context, in *contentv1.GetInsightsRequest, opts ...grpc.CallOption) (*contentv1.GetInsightsResponse, error) {
+	return nil, status.Error(codes.Unavailable, "extractor down")
+}
+
+func TestFetchContentExport_SkipsMissingParts(t *testing.T) {
+	export, err := fetchContentExport(context.Background(), fakeExportClient{}, "em-1")
+	if err != nil {
+		t.Fatalf("fetchContentExport() error = %v", err)
+	}
+	if export.Original == "" || export.Processed != "normalized body" || export.Text != "raw body" {
+		t.Errorf("export = %+v", export)
+	}
+	if export.Metadata.State != "IN_PROGRESS" || export.Metadata.ContentType != "EMAIL" {
+		t.Errorf("metadata = %+v", export.Metadata)
+	}
+	if len(export.Metadata.Skipped) != 2 || export.Metadata.Skipped[0] != "summary: not generated yet" {
+		t.Errorf("skipped = %q, want summary and insights", export.Metadata.Skipped)
+	}
+}
+
+func TestWriteContentExportFiles(t *testing.T) {
+	data, err := structpb.NewStruct(map[string]interface{}{"items": []interface{}{"send deck"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	export := &ContentExport{
+		Metadata: ContentExportMetadata{ID: "em-1", Skipped: []string{"processed: not processed yet"}},
+		Original: "raw",
+		Text:     "text",
+		Summary:  "summary",
+		Insights: []ContentExportInsight{{Type: "action_items", Data: data.AsMap()}},
+	}
+	dir := filepath.Join(t.TempDir(), "out")
+
+	written, err := writeContentExportFiles(dir, export)
+	if err != nil {
+		t.Fatalf("writeContentExportFiles() error = %v", err)
+	}
+	var names []string
+	for _, path := range written {
+		names = append(names, filepath.Base(path))
+	}
+	want := []string{"original.txt", "text.txt", "summary.txt", "insights.json", "metadata.json"}
+	if len(names) != len(want) {
+		t.Fatalf("wrote %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("wrote %v, want %v", names, want)
+			break
+		}
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta ContentExportMetadata
+	if err := json.Unmarshal(raw, &meta); err != nil || meta.ID != "em-1" || len(meta.Skipped) != 1 {
+		t.Errorf("metadata.json = %s (err %v)", raw, err)
+	}
+}
+
+func TestWriteContentExportJSON(t *testing.T) {
+	dir := t.TempDir()
+	written, err := writeContentExportJSON(dir, &ContentExport{Metadata: ContentExportMetadata{ID: "em-1"}, Summary: "s"})
+	if err != nil {
+		t.Fatalf("writeContentExportJSON() error = %v", err)
+	}
+	if len(written) != 1 || written[0] != filepath.Join(dir, "em-1.json") {
+		t.Fatalf("written = %v", written)
+	}
+
+	raw, err := os.ReadFile(written[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ContentExport
+	if err := json.Unmarshal(raw, &got); err != nil || got.Summary != "s" || got.Metadata.ID != "em-1" {
+		t.Errorf("%s = %s (err %v)", written[0], raw, err)
+	}
+}
@@ -830,3 +830,94 @@ func TestNewContentShowCommand_FullFlag(t *testing.T) {
 		t.Errorf("--full flag type = %v, want 'bool'", fullFlag.Value.Type())
 	}
 }
+
+// TestNewContentListCommand_PagingFlags tests that filter and paging flags are registered.
+func TestNewContentListCommand_PagingFlags(t *testing.T) {
+	cmd := newContentListCommand(DefaultContentDeps())
+
+	for _, name := range []string{"status", "source", "source-type", "since", "page-token", "all"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("--%s flag should be registered", name)
+		}
+	}
+	if !cmd.Flags().Lookup("source-type").Hidden {
+		t.Error("--source-type should be a hidden alias for --source")
+	}
+}
+
+// TestOutputContentListText_Summary tests that the text table shows a truncated summary.
+func TestOutputContentListText_Summary(t *testing.T) {
+	summary := "Weekly status update from the platform team covering\nrelease dates and hiring"
+	resp := &contentv1.ListContentItemsResponse{
+		Items: []*contentv1.ContentItem{
+			{Id: "em-abc123", SourceType: "email", Summary: &summary},
+			{Id: "em-def456", SourceType: "email"},
+		},
+	}
+
+	out := captureStdout(func() {
+		if err := outputContentListText(resp); err != nil {
+			t.Fatalf("outputContentListText: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "SUMMARY") {
+		t.Errorf("expected SUMMARY column, got:\n%s", out)
+	}
+	if !strings.Contains(out, truncate("Weekly status update from the platform team covering release dates and hiring", 40)) {
+		t.Errorf("expected truncated single-line summary, got:\n%s", out)
+	}
+	if strings.Contains(out, "hiring") {
+		t.Errorf("expected summary to be truncated, got:\n%s", out)
+	}
+}
+
+// TestOutputContentListJSON tests that JSON output exposes full item fields and paging.
+func TestOutputContentListJSON(t *testing.T) {
+	summary := "Weekly status update"
+	total := int64(7)
+	resp := &contentv1.ListContentItemsResponse{
+		Items: []*contentv1.ContentItem{
+			{
+				Id:              "em-abc123",
+				SourceType:      "email",
+				State:           contentv1.ProcessingState_PROCESSING_STATE_COMPLETED,
+				ContentTypeEnum: contentv1.ContentType_CONTENT_TYPE_EMAIL,
+				Summary:         &summary,
+				Metadata:        map[string]string{"subject": "Status", "source_system": "gmail"},
+				CreatedAt:       timestamppb.New(time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)),
+			},
+		},
+		NextPageToken: "next-token",
+		TotalCount:    &total,
+	}
+
+	output := captureStdout(func() {
+		if err := outputContentList(config.OutputFormatJSON, resp); err != nil {
+			t.Fatalf("outputContentList failed: %v", err)
+		}
+	})
+
+	var result ContentListResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if result.TotalCount != 7 || result.Count != 1 {
+		t.Errorf("counts = %d/%d, want 1/7", result.Count, result.TotalCount)
+	}
+	if result.NextPageToken != "next-token" {
+		t.Errorf("next_page_token = %q, want %q", result.NextPageToken, "next-token")
+	}
+
+	item := result.Items[0]
+	if item.ID != "em-abc123" || item.ContentType != "EMAIL" || item.State != "COMPLETED" {
+		t.Errorf("unexpected item identity fields: %+v", item)
+	}
+	if item.Subject != "Status" || item.Summary != summary || item.SourceSystem != "gmail" {
+		t.Errorf("unexpected item detail fields: %+v", item)
+	}
+	if item.CreatedAt == nil || !item.CreatedAt.Equal(time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("created_at = %v, want 2026-01-15T10:30:00Z", item.CreatedAt)
+	}
+}
@@ -151,7 +151,7 @@ func newContextShowCmd(deps *PipelineCommandDeps) *cobra.Command {
 }
 
 func newContextRemoveCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:     "remove <id>",
 		Short:   "Delete a context entry",
 		Aliases: []string{"rm", "delete"},
@@ -163,7 +163,7 @@ func newContextRemoveCmd(deps *PipelineCommandDeps) *cobra.Command {
 			}
 			return runContextRemove(cmd.Context(), deps, int32(id))
 		},
-	}
+	})
 }
 
 func newContextTriggerCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -206,11 +206,11 @@ Examples:
 	cmd.Flags().StringVar(&condition, "condition", "", "Condition in format field:match_type:value (required)")
 	_ = cmd.MarkFlagRequired("condition")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func newContextTriggerRemoveCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove <entry-id> <condition-id>",
 		Short: "Remove a trigger condition from a context entry",
 		Long: `Remove a trigger condition from a context entry.
@@ -229,7 +229,7 @@ Examples:
 			}
 			return runContextTriggerRemove(cmd.Context(), deps, int32(entryID), int32(condID))
 		},
-	}
+	})
 }
 
 // ===========================================================================
@@ -438,6 +438,7 @@ func runContextRemove(ctx context.Context, deps *PipelineCommandDeps, id int32)
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -445,12 +446,12 @@ func runContextRemove(ctx context.Context, deps *PipelineCommandDeps, id int32)
 	}
 
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Delete context entry %d? This will also remove all its trigger conditions. [y/N] ", id)
+	fmt.Fprintf(os.Stderr, "Delete context entry %d? This will also remove all its trigger conditions. [y/N] ", id)
 	line, _ := reader.ReadString('\n')
 	line = strings.TrimSpace(strings.ToLower(line))
 	if line != "y" && line != "yes" {
-		fmt.Println("Cancelled.")
-		return nil
+		fmt.Fprintln(w, "Cancelled.")
+		return writeOutcome(false, ContextActionResult{EntryID: id}, "Cancelled.")
 	}
 
 	conn, err := connectPipelineToGateway(cfg)
@@ -469,8 +470,8 @@ func runContextRemove(ctx context.Context, deps *PipelineCommandDeps, id int32)
 		return fmt.Errorf("removing context entry %d: %w", id, err)
 	}
 
-	fmt.Printf("Removed context entry %d\n", id)
-	return nil
+	fmt.Fprintf(w, "Removed context entry %d\n", id)
+	return writeResult(ContextActionResult{EntryID: id}, fmt.Sprintf("Removed context entry %d", id))
 }
 
 func runContextTriggerAdd(ctx context.Context, deps *PipelineCommandDeps, entryID int32, condition string) error {
@@ -484,6 +485,7 @@ func runContextTriggerAdd(ctx context.Context, deps *PipelineCommandDeps, entryI
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -512,11 +514,12 @@ func runContextTriggerAdd(ctx context.Context, deps *PipelineCommandDeps, entryI
 	entry := resp.Entry
 	if entry != nil && len(entry.Conditions) > 0 {
 		added := entry.Conditions[len(entry.Conditions)-1]
-		fmt.Printf("Added condition %d to entry %d: %s:%s:%s\n", added.Id, entryID, added.Field, added.MatchType, added.Value)
+		cond = added
+		fmt.Fprintf(w, "Added condition %d to entry %d: %s:%s:%s\n", added.Id, entryID, added.Field, added.MatchType, added.Value)
 	} else {
-		fmt.Printf("Added condition to entry %d: %s:%s:%s\n", entryID, cond.Field, cond.MatchType, cond.Value)
+		fmt.Fprintf(w, "Added condition to entry %d: %s:%s:%s\n", entryID, cond.Field, cond.MatchType, cond.Value)
 	}
-	return nil
+	return writeResult(ContextActionResult{EntryID: entryID, Condition: cond}, fmt.Sprintf("Added condition to entry %d", entryID))
 }
 
 func runContextTriggerRemove(_ context.Context, _ *PipelineCommandDeps, entryID, condID int32) error {
@@ -527,6 +530,13 @@ func runContextTriggerRemove(_ context.Context, _ *PipelineCommandDeps, entryID,
 		entryID, condID, entryID)
 }
 
+// ContextActionResult is the --output json result of the context commands
+// that change an entry.
+type ContextActionResult struct {
+	EntryID   int32                              `json:"entry_id"`
+	Condition *pipelinev1.TenantContextCondition `json:"condition,omitempty"`
+}
+
 // parseTenantContextCondition parses a "field:match_type:value" string.
 func parseTenantContextCondition(raw string) (*pipelinev1.TenantContextCondition, error) {
 	parts := strings.SplitN(raw, ":", 3)
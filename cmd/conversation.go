@@ -28,14 +28,16 @@ var (
 
 // ConversationCommandDeps holds the dependencies for conversation commands.
 type ConversationCommandDeps struct {
-	Config     *config.CLIConfig
-	LoadConfig func() (*config.CLIConfig, error)
+	Config        *config.CLIConfig
+	LoadConfig    func() (*config.CLIConfig, error)
+	InitRelClient func(*config.CLIConfig) (*client.RelationshipClient, error)
 }
 
 // DefaultConversationDeps returns the default dependencies for production use.
 func DefaultConversationDeps() *ConversationCommandDeps {
 	return &ConversationCommandDeps{
-		LoadConfig: config.LoadConfig,
+		LoadConfig:    config.LoadConfig,
+		InitRelClient: DefaultRelationshipDeps().InitRelClient,
 	}
 }
 
@@ -52,19 +54,22 @@ func NewConversationCommand(deps *ConversationCommandDeps) *cobra.Command {
 
 Conversations group related content by topic, tracking participants and temporal sequence.
 
-This command provides two main operations:
+This command provides three main operations:
 
-  list      List conversations with pagination
-  show      Show detailed conversation view with items and participants
+  list          List conversations with pagination
+  show          Show detailed conversation view with items and participants
+  participants  List participants with message counts and inferred roles
 
 Examples:
   penf conversation list --limit 10
   penf conversation show <conversation-id>
+  penf conversation participants <conversation-id> --sort messages
   penf conversation list -o json`,
 	}
 
 	cmd.AddCommand(newConversationListCommand(deps))
 	cmd.AddCommand(newConversationShowCommand(deps))
+	cmd.AddCommand(newConversationParticipantsCommand(deps))
 	cmd.AddCommand(newConversationStatusCommand(deps))
 	cmd.AddCommand(newConversationMergeCommand(deps))
 	cmd.AddCommand(newConversationSplitCommand(deps))
@@ -136,7 +141,7 @@ Examples:
 
 // connectConversationToGateway creates a gRPC connection to the gateway service.
 func connectConversationToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -159,9 +164,11 @@ func connectConversationToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, erro
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
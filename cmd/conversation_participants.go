@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	conversationv1 "github.com/otherjamesbrown/penf-cli/api/proto/conversation/v1"
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Conversation participants flags.
+var conversationSort string
+
+// Inferred participant roles, from most to least involved.
+const (
+	participantRoleInitiator   = "initiator"
+	participantRoleDriver      = "driver"
+	participantRoleContributor = "contributor"
+	participantRoleObserver    = "observer"
+)
+
+// ConversationParticipantStats is one participant of a conversation with
+// their activity in it and, when their person entity is found in the
+// relationship graph, their overall message counts.
+type ConversationParticipantStats struct {
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	Address       string `json:"address,omitempty" yaml:"address,omitempty"`
+	Role          string `json:"role" yaml:"role"`
+	MessagesSent  int    `json:"messages_sent" yaml:"messages_sent"`
+	EntityID      string `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	TotalSent     *int32 `json:"total_sent,omitempty" yaml:"total_sent,omitempty"`
+	TotalReceived *int32 `json:"total_received,omitempty" yaml:"total_received,omitempty"`
+}
+
+// ConversationParticipants is the output of 'conversation participants'.
+type ConversationParticipants struct {
+	ConversationID string                         `json:"conversation_id" yaml:"conversation_id"`
+	Topic          string                         `json:"topic" yaml:"topic"`
+	ItemCount      int                            `json:"item_count" yaml:"item_count"`
+	Participants   []ConversationParticipantStats `json:"participants" yaml:"participants"`
+	Warnings       []string                       `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// relationshipClient connects to the relationship service, which supplies
+// participants' overall message counts.
+func (d *ConversationCommandDeps) relationshipClient(cfg *config.CLIConfig) (*client.RelationshipClient, error) {
+	if d.InitRelClient == nil {
+		return nil, errors.New("relationship service not configured")
+	}
+	return d.InitRelClient(cfg)
+}
+
+// newConversationParticipantsCommand creates the 'conversation participants'
+// subcommand.
+func newConversationParticipantsCommand(deps *ConversationCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "participants <conversation-id>",
+		Short: "List conversation participants with message counts and roles",
+		Long: `List everyone involved in a conversation, how many of its messages each
+sent, and their inferred role in it.
+
+Roles:
+  initiator     Sent the first message
+  driver        Sent the most messages (at least 30% of them) after the initiator
+  contributor   Sent at least one message
+  observer      Received messages but sent none
+
+Overall sent/received counts come from the participant's person entity in the
+relationship graph and are omitted when no entity matches.
+
+Flags:
+  --sort              Sort by: messages, name (default: conversation order)
+  -o, --output        Output format: text, json, yaml
+
+Examples:
+  penf conversation participants conv-thread-63
+  penf conversation participants conv-thread-63 --sort messages
+  penf conversation participants conv-thread-63 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConversationParticipants(cmd.Context(), deps, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&conversationSort, "sort", "", "Sort by: messages, name")
+	cmd.Flags().StringVarP(&conversationOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runConversationParticipants executes the conversation participants command.
+func runConversationParticipants(ctx context.Context, deps *ConversationCommandDeps, conversationID string) error {
+	switch conversationSort {
+	case "", "messages", "name":
+	default:
+		return fmt.Errorf("invalid --sort %q: must be messages or name", conversationSort)
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectConversationToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tenantID := getTenantIDForConversations(deps)
+	resp, err := conversationv1.NewConversationServiceClient(conn).ShowConversation(ctx, &conversationv1.ShowConversationRequest{
+		TenantId:       tenantID,
+		ConversationId: conversationID,
+	})
+	if err != nil {
+		return fmt.Errorf("showing conversation: %w", err)
+	}
+
+	result := &ConversationParticipants{
+		ConversationID: resp.Id,
+		Topic:          resp.Topic,
+		ItemCount:      len(resp.Items),
+		Participants:   buildConversationParticipants(resp.Participants, resp.Items),
+	}
+
+	if relClient, err := deps.relationshipClient(cfg); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("overall message counts unavailable: %v", err))
+	} else {
+		defer relClient.Close()
+		if err := addParticipantEntityCounts(ctx, relClient, tenantID, result.Participants); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("overall message counts unavailable: %v", err))
+		}
+	}
+
+	sortConversationParticipants(result.Participants, conversationSort)
+	return outputConversationParticipants(result)
+}
+
+// buildConversationParticipants counts each participant's messages among
+// items, matching an item's sender to a participant by name or address, and
+// infers their roles. Senders missing from participants are added.
+func buildConversationParticipants(participants []*conversationv1.ConversationParticipant, items []*conversationv1.ConversationItem) []ConversationParticipantStats {
+	stats := []ConversationParticipantStats{}
+	index := map[string]int{}
+	add := func(name, address string) int {
+		for _, key := range []string{strings.ToLower(address), strings.ToLower(name)} {
+			if i, ok := index[key]; ok && key != "" {
+				return i
+			}
+		}
+		stats = append(stats, ConversationParticipantStats{Name: name, Address: address})
+		i := len(stats) - 1
+		for _, key := range []string{strings.ToLower(address), strings.ToLower(name)} {
+			if key != "" {
+				index[key] = i
+			}
+		}
+		return i
+	}
+
+	for _, p := range participants {
+		add(p.GetName(), p.GetAddress())
+	}
+
+	// Items are counted oldest first so the first sender is the initiator.
+	ordered := make([]*conversationv1.ConversationItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].GetContentDate().AsTime().Before(ordered[j].GetContentDate().AsTime())
+	})
+
+	initiator := -1
+	for _, item := range ordered {
+		from := strings.TrimSpace(item.GetFromName())
+		if from == "" {
+			continue
+		}
+		var i int
+		if strings.Contains(from, "@") {
+			i = add("", from)
+		} else {
+			i = add(from, "")
+		}
+		stats[i].MessagesSent++
+		if initiator < 0 {
+			initiator = i
+		}
+	}
+
+	assignParticipantRoles(stats, initiator)
+	return stats
+}
+
+// assignParticipantRoles sets each participant's role from their share of
+// sent messages. initiator is the index of the first sender, or -1.
+func assignParticipantRoles(stats []ConversationParticipantStats, initiator int) {
+	total, top := 0, -1
+	for i, s := range stats {
+		total += s.MessagesSent
+		if i != initiator && s.MessagesSent > 0 && (top < 0 || s.MessagesSent > stats[top].MessagesSent) {
+			top = i
+		}
+	}
+
+	for i := range stats {
+		switch {
+		case i == initiator:
+			stats[i].Role = participantRoleInitiator
+		case i == top && stats[i].MessagesSent > 1 && stats[i].MessagesSent*10 >= total*3:
+			stats[i].Role = participantRoleDriver
+		case stats[i].MessagesSent > 0:
+			stats[i].Role = participantRoleContributor
+		default:
+			stats[i].Role = participantRoleObserver
+		}
+	}
+}
+
+// addParticipantEntityCounts fills in overall sent/received counts from
+// each participant's person entity, matched by address and then by name.
+func addParticipantEntityCounts(ctx context.Context, relClient *client.RelationshipClient, tenantID string, stats []ConversationParticipantStats) error {
+	for i := range stats {
+		for _, ref := range []string{stats[i].Address, stats[i].Name} {
+			if ref == "" {
+				continue
+			}
+			candidates, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+				TenantID:   tenantID,
+				EntityType: relationshipv1.EntityType_ENTITY_TYPE_PERSON,
+				Search:     ref,
+				PageSize:   20,
+			})
+			if err != nil {
+				return err
+			}
+			if entity := matchEntityByName(candidates, ref); entity != nil {
+				sent, received := entity.SentCount, entity.ReceivedCount
+				stats[i].EntityID = entity.ID
+				stats[i].TotalSent = &sent
+				stats[i].TotalReceived = &received
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// sortConversationParticipants orders participants by messages sent
+// (descending) or by name; any other key keeps conversation order.
+func sortConversationParticipants(stats []ConversationParticipantStats, by string) {
+	switch by {
+	case "messages":
+		sort.SliceStable(stats, func(i, j int) bool { return stats[i].MessagesSent > stats[j].MessagesSent })
+	case "name":
+		sort.SliceStable(stats, func(i, j int) bool {
+			return strings.ToLower(participantLabel(stats[i])) < strings.ToLower(participantLabel(stats[j]))
+		})
+	}
+}
+
+// participantLabel returns the participant's name, or their address when
+// the name is unknown.
+func participantLabel(s ConversationParticipantStats) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Address
+}
+
+// outputConversationParticipants formats and displays conversation
+// participants.
+func outputConversationParticipants(result *ConversationParticipants) error {
+	switch conversationOutput {
+	case "json":
+		return outputJSON(result)
+	case "yaml":
+		return outputYAML(result)
+	default:
+		return outputConversationParticipantsText(result)
+	}
+}
+
+// outputConversationParticipantsText displays participants as a table.
+func outputConversationParticipantsText(result *ConversationParticipants) error {
+	fmt.Printf("Conversation: %s\n", result.ConversationID)
+	fmt.Printf("Topic:        %s\n", result.Topic)
+	fmt.Printf("Items:        %d\n\n", result.ItemCount)
+
+	if len(result.Participants) == 0 {
+		fmt.Println("No participants found.")
+		return nil
+	}
+
+	fmt.Printf("%-30s %-35s %-12s %-6s %-12s %-12s\n",
+		"NAME", "ADDRESS", "ROLE", "SENT", "TOTAL SENT", "TOTAL RECV")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────────────────────────────────────────")
+
+	for _, p := range result.Participants {
+		fmt.Printf("%-30s %-35s %-12s %-6d %-12s %-12s\n",
+			truncateThreadString(p.Name, 30),
+			truncateThreadString(p.Address, 35),
+			p.Role,
+			p.MessagesSent,
+			formatOptionalCount(p.TotalSent),
+			formatOptionalCount(p.TotalReceived))
+	}
+
+	for _, w := range result.Warnings {
+		fmt.Printf("\nWarning: %s", w)
+	}
+	fmt.Println()
+	return nil
+}
+
+// formatOptionalCount renders n, or "-" when it is unknown.
+func formatOptionalCount(n *int32) string {
+	if n == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *n)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	conversationv1 "github.com/otherjamesbrown/penf-cli/api/proto/conversation/v1"
+)
+
+// TestBuildConversationParticipants tests message counting and role inference.
+func TestBuildConversationParticipants(t *testing.T) {
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	item := func(from string, minutes int) *conversationv1.ConversationItem {
+		return &conversationv1.ConversationItem{
+			FromName:    proto.String(from),
+			ContentDate: timestamppb.New(base.Add(time.Duration(minutes) * time.Minute)),
+		}
+	}
+
+	participants := []*conversationv1.ConversationParticipant{
+		{Name: proto.String("Alice"), Address: proto.String("alice@example.com")},
+		{Name: proto.String("Bob"), Address: proto.String("bob@example.com")},
+		{Name: proto.String("Carol"), Address: proto.String("carol@example.com")},
+		{Name: proto.String("Dan"), Address: proto.String("dan@example.com")},
+	}
+	// Items arrive out of order; Carol wrote first.
+	items := []*conversationv1.ConversationItem{
+		item("Bob", 10),
+		item("carol", 0),
+		item("Bob", 20),
+		item("Alice", 30),
+		item("Bob", 40),
+		item("eve@example.com", 50),
+	}
+
+	stats := buildConversationParticipants(participants, items)
+	if len(stats) != 5 {
+		t.Fatalf("expected 5 participants (4 listed + 1 extra sender), got %d", len(stats))
+	}
+
+	want := map[string]struct {
+		sent int
+		role string
+	}{
+		"Alice":           {1, participantRoleContributor},
+		"Bob":             {3, participantRoleDriver},
+		"Carol":           {1, participantRoleInitiator},
+		"Dan":             {0, participantRoleObserver},
+		"eve@example.com": {1, participantRoleContributor},
+	}
+	for _, s := range stats {
+		w, ok := want[participantLabel(s)]
+		if !ok {
+			t.Errorf("unexpected participant %+v", s)
+			continue
+		}
+		if s.MessagesSent != w.sent || s.Role != w.role {
+			t.Errorf("%s: sent=%d role=%s, want sent=%d role=%s", participantLabel(s), s.MessagesSent, s.Role, w.sent, w.role)
+		}
+	}
+}
+
+// TestAssignParticipantRolesNoDriver tests that an even split has no driver.
+func TestAssignParticipantRolesNoDriver(t *testing.T) {
+	stats := []ConversationParticipantStats{
+		{Name: "A", MessagesSent: 1},
+		{Name: "B", MessagesSent: 1},
+		{Name: "C", MessagesSent: 1},
+	}
+	assignParticipantRoles(stats, 0)
+	if stats[1].Role != participantRoleContributor || stats[2].Role != participantRoleContributor {
+		t.Errorf("single-message senders should be contributors, got %s and %s", stats[1].Role, stats[2].Role)
+	}
+}
+
+// TestSortConversationParticipants tests the --sort options.
+func TestSortConversationParticipants(t *testing.T) {
+	stats := []ConversationParticipantStats{
+		{Name: "bob", MessagesSent: 1},
+		{Address: "alice@example.com", MessagesSent: 4},
+		{Name: "Carol", MessagesSent: 2},
+	}
+
+	sortConversationParticipants(stats, "messages")
+	if stats[0].MessagesSent != 4 || stats[2].MessagesSent != 1 {
+		t.Errorf("messages sort: %+v", stats)
+	}
+
+	sortConversationParticipants(stats, "name")
+	if participantLabel(stats[0]) != "alice@example.com" || stats[1].Name != "bob" || stats[2].Name != "Carol" {
+		t.Errorf("name sort: %+v", stats)
+	}
+}
@@ -10,9 +10,10 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
+	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
 	"github.com/otherjamesbrown/penf-cli/pkg/db"
 )
 
@@ -27,16 +28,20 @@ var (
 
 // DbCommandDeps holds the dependencies for database commands.
 type DbCommandDeps struct {
-	Config       *config.CLIConfig
-	LoadConfig   func() (*config.CLIConfig, error)
-	ConnectToDB  func(context.Context, *config.CLIConfig) (*pgxpool.Pool, error)
+	Config           *config.CLIConfig
+	LoadConfig       func() (*config.CLIConfig, error)
+	ConnectToDB      func(context.Context, *config.CLIConfig) (*pgxpool.Pool, error)
+	InitClient       func(*config.CLIConfig) (*client.GRPCClient, error)
+	GatewayBuildInfo func(context.Context) (buildinfo.Info, error)
 }
 
 // DefaultDbDeps returns the default dependencies for production use.
 func DefaultDbDeps() *DbCommandDeps {
 	return &DbCommandDeps{
-		LoadConfig:  config.LoadConfig,
-		ConnectToDB: connectToDatabase,
+		LoadConfig:       config.LoadConfig,
+		ConnectToDB:      connectToDatabase,
+		InitClient:       client.ConnectFromConfig,
+		GatewayBuildInfo: fetchGatewayBuildInfo,
 	}
 }
 
@@ -49,7 +54,8 @@ func NewDbCommand() *cobra.Command {
 		Short: "Database management commands",
 		Long: `Database management commands for Penfold.
 
-Manage database schema migrations and view migration status.
+Manage database schema migrations, view migration status, and report table
+sizes and row counts.
 
 The db command connects directly to the PostgreSQL database to run migrations
 and check status. It requires DATABASE_URL or DB_* environment variables to be set.
@@ -69,7 +75,10 @@ Examples:
   penf db migrate --dry-run
 
   # Apply migrations up to a specific version
-  penf db migrate --target 040`,
+  penf db migrate --target 040
+
+  # Show row counts, table sizes and index bloat
+  penf db stats`,
 		Aliases: []string{"database", "migrations"},
 	}
 
@@ -79,6 +88,7 @@ Examples:
 	// Add subcommands
 	cmd.AddCommand(newDbMigrateCommand(deps))
 	cmd.AddCommand(newDbStatusCommand(deps))
+	cmd.AddCommand(newDbStatsCommand(deps))
 
 	return cmd
 }
@@ -115,6 +125,9 @@ Examples:
   # Check for pending migrations (exit 1 if any pending)
   penf db migrate --check
 
+  # Check the schema against what the gateway expects
+  penf db migrate status
+
   # Use a custom migrations directory
   penf db migrate --migrations ./db/migrations`,
 		Example: `  penf db migrate
@@ -130,6 +143,8 @@ Examples:
 	cmd.Flags().BoolVar(&dbCheck, "check", false, "Check for pending migrations (exit 1 if any pending)")
 	cmd.Flags().StringVarP(&dbTarget, "target", "t", "", "Target version to migrate to (e.g., 040)")
 
+	cmd.AddCommand(newDbMigrateStatusCommand(deps))
+
 	return cmd
 }
 
@@ -327,7 +342,7 @@ func outputMigrationStatus(format config.OutputFormat, status *db.MigrationStatu
 		enc.SetIndent("", "  ")
 		return enc.Encode(status)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(status)
 	default:
 		return outputMigrationStatusText(status)
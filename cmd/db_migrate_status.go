@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+	"github.com/otherjamesbrown/penf-cli/pkg/db"
+)
+
+// Schema states reported by 'db migrate status'.
+const (
+	schemaStateUpToDate = "up_to_date"
+	schemaStateBehind   = "behind"
+	schemaStateAhead    = "ahead"
+)
+
+// Sources of the expected schema version.
+const (
+	schemaExpectFlag       = "flag"
+	schemaExpectGateway    = "gateway"
+	schemaExpectMigrations = "migrations"
+)
+
+// dbExpectVersion overrides the schema version the database must be at.
+var dbExpectVersion string
+
+// DbMigrationEntry is a migration listed by 'db migrate status'.
+type DbMigrationEntry struct {
+	Version   string     `json:"version" yaml:"version"`
+	Name      string     `json:"name" yaml:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty" yaml:"applied_at,omitempty"`
+}
+
+// DbSchemaReport is the output of 'db migrate status'.
+type DbSchemaReport struct {
+	State           string             `json:"state" yaml:"state"`
+	CurrentVersion  string             `json:"current_version" yaml:"current_version"`
+	ExpectedVersion string             `json:"expected_version" yaml:"expected_version"`
+	ExpectedSource  string             `json:"expected_source" yaml:"expected_source"`
+	LatestFile      string             `json:"latest_file,omitempty" yaml:"latest_file,omitempty"`
+	GatewayVersion  string             `json:"gateway_version,omitempty" yaml:"gateway_version,omitempty"`
+	Pending         []DbMigrationEntry `json:"pending" yaml:"pending"`
+	Drift           []DbMigrationEntry `json:"drift,omitempty" yaml:"drift,omitempty"`
+	Warnings        []string           `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// newDbMigrateStatusCommand creates the 'db migrate status' subcommand.
+func newDbMigrateStatusCommand(deps *DbCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Check whether the schema is current",
+		Long: `Check whether the database schema is at the version the gateway expects.
+
+Reports the current schema version (the newest applied migration), the
+expected version, and any pending migrations. The result is one of:
+
+  up_to_date  The database is at the expected version with nothing pending
+  behind      Migrations up to the expected version have not been applied
+  ahead       The database has migrations newer than the expected version,
+              i.e. the CLI or gateway is older than the schema
+
+The expected version is taken from --expect, else from the schema_version
+the gateway reports on /version, else from the newest file in the
+migrations directory.
+
+Exits non-zero when the database is behind, so it can gate a deploy
+alongside 'penf deploy verify'. Ahead is reported as a warning.
+
+Flags:
+  --expect       Schema version the database must be at (e.g., 054)
+  --output       Output format: text, json, yaml (default: text)
+  --migrations   Path to migrations directory (default: migrations)
+
+Examples:
+  penf db migrate status
+  penf db migrate status --expect 054
+  penf db migrate status --output json`,
+		Example: `  penf db migrate status
+  penf db migrate status --expect 054
+  penf db migrate status --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDbMigrateStatus(cmd.Context(), deps)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbExpectVersion, "expect", "", "Schema version the database must be at (default: reported by the gateway)")
+	cmd.Flags().StringVarP(&dbOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runDbMigrateStatus executes the db migrate status command.
+func runDbMigrateStatus(ctx context.Context, deps *DbCommandDeps) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	pool, err := deps.ConnectToDB(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer pool.Close()
+
+	status, err := db.GetMigrationStatus(ctx, pool, resolveMigrationsDir(cfg))
+	if err != nil {
+		return fmt.Errorf("getting migration status: %w", err)
+	}
+
+	var gateway *buildinfo.Info
+	var warnings []string
+	if dbExpectVersion == "" && deps.GatewayBuildInfo != nil {
+		info, err := deps.GatewayBuildInfo(ctx)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("gateway version unavailable: %v", err))
+		} else {
+			gateway = &info
+		}
+	}
+
+	report := buildSchemaReport(status, dbExpectVersion, gateway)
+	report.Warnings = append(warnings, report.Warnings...)
+
+	format := cfg.OutputFormat
+	if dbOutput != "" {
+		format = config.OutputFormat(dbOutput)
+	}
+	if err := outputSchemaReport(format, report); err != nil {
+		return err
+	}
+
+	if report.State == schemaStateBehind {
+		return fmt.Errorf("database schema is behind: at %s, expected %s — run 'penf db migrate' to apply",
+			displaySchemaVersion(report.CurrentVersion), report.ExpectedVersion)
+	}
+	return nil
+}
+
+// fetchGatewayBuildInfo queries the gateway's /version endpoint.
+func fetchGatewayBuildInfo(ctx context.Context) (buildinfo.Info, error) {
+	for _, svc := range DeployedServices {
+		if svc.Name == "penfold-gateway" {
+			return buildinfo.Fetch(ctx, &http.Client{Timeout: 5 * time.Second}, svc.URL)
+		}
+	}
+	return buildinfo.Info{}, fmt.Errorf("no gateway in deployed services")
+}
+
+// buildSchemaReport compares the migration status with the expected schema
+// version. expect takes precedence over the gateway's schema version; with
+// neither, the newest migration file is expected.
+func buildSchemaReport(status *db.MigrationStatus, expect string, gateway *buildinfo.Info) *DbSchemaReport {
+	report := &DbSchemaReport{Pending: []DbMigrationEntry{}}
+
+	for _, m := range status.Applied {
+		if compareMigrationVersions(m.Version, report.CurrentVersion) > 0 {
+			report.CurrentVersion = m.Version
+		}
+	}
+	for _, m := range status.Drift {
+		if compareMigrationVersions(m.Version, report.CurrentVersion) > 0 {
+			report.CurrentVersion = m.Version
+		}
+		report.Drift = append(report.Drift, DbMigrationEntry{Version: m.Version, Name: m.Name, AppliedAt: m.AppliedAt})
+	}
+	for _, m := range status.Applied {
+		if compareMigrationVersions(m.Version, report.LatestFile) > 0 {
+			report.LatestFile = m.Version
+		}
+	}
+	for _, m := range status.Pending {
+		if compareMigrationVersions(m.Version, report.LatestFile) > 0 {
+			report.LatestFile = m.Version
+		}
+	}
+
+	if gateway != nil {
+		report.GatewayVersion = gateway.Version
+	}
+	switch {
+	case expect != "":
+		report.ExpectedVersion, report.ExpectedSource = expect, schemaExpectFlag
+	case gateway != nil && gateway.SchemaVersion != "":
+		report.ExpectedVersion, report.ExpectedSource = gateway.SchemaVersion, schemaExpectGateway
+	default:
+		if gateway != nil {
+			report.Warnings = append(report.Warnings, "gateway does not report a schema version; expecting the newest migration file")
+		}
+		report.ExpectedVersion, report.ExpectedSource = report.LatestFile, schemaExpectMigrations
+	}
+
+	// Only migrations up to the expected version count as pending; newer
+	// files are for a release the gateway is not running yet.
+	for _, m := range status.Pending {
+		if report.ExpectedVersion == "" || compareMigrationVersions(m.Version, report.ExpectedVersion) <= 0 {
+			report.Pending = append(report.Pending, DbMigrationEntry{Version: m.Version, Name: m.Name})
+		}
+	}
+
+	switch {
+	case len(report.Pending) > 0 || compareMigrationVersions(report.CurrentVersion, report.ExpectedVersion) < 0:
+		report.State = schemaStateBehind
+	case compareMigrationVersions(report.CurrentVersion, report.ExpectedVersion) > 0:
+		report.State = schemaStateAhead
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"database is at %s but the %s expects %s; the CLI or gateway may be out of date",
+			report.CurrentVersion, schemaExpectDescription(report.ExpectedSource), report.ExpectedVersion))
+	default:
+		report.State = schemaStateUpToDate
+	}
+	return report
+}
+
+// compareMigrationVersions orders migration versions by their numeric
+// prefix, so "054" and "054_add_index" are equal and "100" sorts after
+// "99". Versions without a numeric prefix compare as strings. The empty
+// version sorts first.
+func compareMigrationVersions(a, b string) int {
+	if a == "" || b == "" {
+		return strings.Compare(a, b)
+	}
+	na, okA := migrationSequence(a)
+	nb, okB := migrationSequence(b)
+	if okA && okB {
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// migrationSequence parses the leading digits of a migration version.
+func migrationSequence(version string) (int, bool) {
+	end := 0
+	for end < len(version) && version[end] >= '0' && version[end] <= '9' {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(version[:end])
+	return n, err == nil
+}
+
+// schemaExpectDescription names the source of the expected version for messages.
+func schemaExpectDescription(source string) string {
+	switch source {
+	case schemaExpectGateway:
+		return "gateway"
+	case schemaExpectFlag:
+		return "--expect flag"
+	default:
+		return "migrations directory"
+	}
+}
+
+// displaySchemaVersion shows an empty version as "none".
+func displaySchemaVersion(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+// outputSchemaReport formats and outputs a schema report.
+func outputSchemaReport(format config.OutputFormat, report *DbSchemaReport) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(report)
+	default:
+		outputSchemaReportText(report)
+		return nil
+	}
+}
+
+// outputSchemaReportText formats a schema report for terminal display.
+func outputSchemaReportText(report *DbSchemaReport) {
+	switch report.State {
+	case schemaStateUpToDate:
+		fmt.Println("\033[32m✓ Schema up to date\033[0m")
+	case schemaStateBehind:
+		fmt.Println("\033[31m✗ Schema behind\033[0m")
+	case schemaStateAhead:
+		fmt.Println("\033[33m! Schema ahead (CLI/gateway mismatch)\033[0m")
+	}
+	fmt.Println()
+
+	fmt.Printf("  Current version:  %s\n", displaySchemaVersion(report.CurrentVersion))
+	fmt.Printf("  Expected version: %s (%s)\n", displaySchemaVersion(report.ExpectedVersion), schemaExpectDescription(report.ExpectedSource))
+	if report.LatestFile != "" && report.LatestFile != report.ExpectedVersion {
+		fmt.Printf("  Newest file:      %s\n", report.LatestFile)
+	}
+	if report.GatewayVersion != "" {
+		fmt.Printf("  Gateway build:    %s\n", report.GatewayVersion)
+	}
+
+	if len(report.Pending) > 0 {
+		fmt.Printf("\n\033[33mPending Migrations (%d):\033[0m\n", len(report.Pending))
+		for _, m := range report.Pending {
+			fmt.Printf("  %-26s %s\n", truncateDbString(m.Version, 26), m.Name)
+		}
+	}
+	if len(report.Drift) > 0 {
+		fmt.Printf("\n\033[31mDrift (%d) - applied but file missing:\033[0m\n", len(report.Drift))
+		for _, m := range report.Drift {
+			fmt.Printf("  %-26s %s\n", truncateDbString(m.Version, 26), m.Name)
+		}
+	}
+
+	if len(report.Warnings) > 0 {
+		fmt.Println()
+		for _, w := range report.Warnings {
+			fmt.Printf("\033[33mWarning:\033[0m %s\n", w)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+	"github.com/otherjamesbrown/penf-cli/pkg/db"
+)
+
+// TestDbMigrateStatusCommand_Help verifies 'db migrate status' is registered.
+func TestDbMigrateStatusCommand_Help(t *testing.T) {
+	cmd := NewDbCommand()
+
+	statusCmd, _, err := cmd.Find([]string{"migrate", "status"})
+	require.NoError(t, err)
+	require.NotNil(t, statusCmd)
+	assert.Equal(t, "status", statusCmd.Name())
+	assert.NotEmpty(t, statusCmd.Example, "migrate status command should have example usage")
+	assert.NotNil(t, statusCmd.Flags().Lookup("expect"))
+	assert.NotNil(t, statusCmd.Flags().Lookup("output"))
+}
+
+func TestCompareMigrationVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"054", "054_add_index", 0},
+		{"053_x", "054", -1},
+		{"100_x", "99_y", 1},
+		{"", "001", -1},
+		{"", "", 0},
+		{"abc", "abd", -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, compareMigrationVersions(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestBuildSchemaReport(t *testing.T) {
+	appliedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(version string, applied bool) db.MigrationStatusEntry {
+		e := db.MigrationStatusEntry{Version: version, Name: version + ".sql"}
+		if applied {
+			e.AppliedAt = &appliedAt
+		}
+		return e
+	}
+	status := &db.MigrationStatus{
+		Applied: []db.MigrationStatusEntry{entry("052_a", true), entry("053_b", true)},
+		Pending: []db.MigrationStatusEntry{entry("054_c", false), entry("055_d", false)},
+	}
+
+	tests := []struct {
+		name        string
+		status      *db.MigrationStatus
+		expect      string
+		gateway     *buildinfo.Info
+		wantState   string
+		wantSource  string
+		wantPending int
+	}{
+		{
+			name:        "behind the newest file",
+			status:      status,
+			wantState:   schemaStateBehind,
+			wantSource:  schemaExpectMigrations,
+			wantPending: 2,
+		},
+		{
+			name:        "gateway expects an applied version",
+			status:      status,
+			gateway:     &buildinfo.Info{Version: "v0.9.0", SchemaVersion: "053"},
+			wantState:   schemaStateUpToDate,
+			wantSource:  schemaExpectGateway,
+			wantPending: 0,
+		},
+		{
+			name:        "gateway expects a pending version",
+			status:      status,
+			gateway:     &buildinfo.Info{Version: "v0.9.0", SchemaVersion: "054"},
+			wantState:   schemaStateBehind,
+			wantSource:  schemaExpectGateway,
+			wantPending: 1,
+		},
+		{
+			name:       "flag overrides gateway",
+			status:     status,
+			expect:     "052",
+			gateway:    &buildinfo.Info{SchemaVersion: "055"},
+			wantState:  schemaStateAhead,
+			wantSource: schemaExpectFlag,
+		},
+		{
+			name: "all applied",
+			status: &db.MigrationStatus{
+				Applied: []db.MigrationStatusEntry{entry("052_a", true), entry("053_b", true)},
+			},
+			wantState:  schemaStateUpToDate,
+			wantSource: schemaExpectMigrations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := buildSchemaReport(tt.status, tt.expect, tt.gateway)
+			assert.Equal(t, tt.wantState, report.State)
+			assert.Equal(t, tt.wantSource, report.ExpectedSource)
+			assert.Len(t, report.Pending, tt.wantPending)
+			assert.Equal(t, "053_b", report.CurrentVersion)
+		})
+	}
+}
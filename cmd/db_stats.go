@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/db"
+)
+
+// Database stats flags.
+var (
+	dbStatsExact   bool
+	dbStatsBloat   bool
+	dbStatsTables  int
+	dbStatsIndexes int
+)
+
+// dbKeyTableCandidates maps each key table category to the table names it
+// may have, in order of preference.
+var dbKeyTableCandidates = []struct {
+	Category string
+	Tables   []string
+}{
+	{"sources", []string{"sources"}},
+	{"content", []string{"content_items", "content"}},
+	{"entities", []string{"entities", "people"}},
+	{"relationships", []string{"relationships", "entity_relationships"}},
+	{"embeddings", []string{"embeddings", "content_embeddings", "chunk_embeddings"}},
+}
+
+// DbKeyTable is the row count and size of one of the key tables.
+type DbKeyTable struct {
+	Category   string `json:"category" yaml:"category"`
+	Table      string `json:"table" yaml:"table"`
+	Rows       int64  `json:"rows" yaml:"rows"`
+	Exact      bool   `json:"exact" yaml:"exact"`
+	TotalBytes int64  `json:"total_bytes" yaml:"total_bytes"`
+}
+
+// DbStatsReport is the output of 'db stats'.
+type DbStatsReport struct {
+	*db.Stats `yaml:",inline"`
+	KeyTables []DbKeyTable `json:"key_tables" yaml:"key_tables"`
+	// Gateway is the database status the gateway reports in its system
+	// status, when the gateway is reachable.
+	Gateway  *client.DatabaseStatus `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	Warnings []string               `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// newDbStatsCommand creates the 'db stats' subcommand.
+func newDbStatsCommand(deps *DbCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show row counts, table sizes and index bloat",
+		Long: `Show how much data the database holds and where the space goes.
+
+Reports:
+  - Row counts for the key tables (sources, content, entities, relationships,
+    embeddings). Counts are planner estimates unless --exact is given.
+  - The largest tables with their on-disk size, split into table and index
+    size, and dead rows awaiting vacuum.
+  - The largest indexes with their size, scan count and, with --bloat and
+    the pgstattuple extension installed, estimated bloat.
+  - The gateway's own view of the database (connections, vector extension,
+    content and entity counts) when the gateway is reachable.
+
+Like the other db commands this connects directly to PostgreSQL and needs
+DATABASE_URL or DB_* environment variables.
+
+Flags:
+  --exact        Count key table rows exactly (slow on large tables)
+  --bloat        Estimate B-tree index bloat with pgstatindex (reads each
+                 listed index in full; slow and I/O-heavy on large indexes)
+  --tables       Number of largest tables to list (default 15)
+  --indexes      Number of largest indexes to list (default 10)
+  --output       Output format: text, json, yaml (default: text)
+
+Examples:
+  penf db stats
+  penf db stats --exact
+  penf db stats --bloat --indexes 5
+  penf db stats --tables 50 --output json`,
+		Example: `  penf db stats
+  penf db stats --exact
+  penf db stats --bloat
+  penf db stats --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDbStats(cmd.Context(), deps)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dbStatsExact, "exact", false, "Count key table rows exactly (slow on large tables)")
+	cmd.Flags().BoolVar(&dbStatsBloat, "bloat", false, "Estimate B-tree index bloat (reads each listed index; slow on large indexes)")
+	cmd.Flags().IntVar(&dbStatsTables, "tables", 15, "Number of largest tables to list")
+	cmd.Flags().IntVar(&dbStatsIndexes, "indexes", 10, "Number of largest indexes to list")
+	cmd.Flags().StringVarP(&dbOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runDbStats executes the db stats command.
+func runDbStats(ctx context.Context, deps *DbCommandDeps) error {
+	if dbStatsTables < 0 || dbStatsIndexes < 0 {
+		return fmt.Errorf("--tables and --indexes must not be negative")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	pool, err := deps.ConnectToDB(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer pool.Close()
+
+	stats, err := db.GetStats(ctx, pool, dbStatsIndexes, dbStatsBloat)
+	if err != nil {
+		return fmt.Errorf("getting database stats: %w", err)
+	}
+
+	report := &DbStatsReport{Stats: stats, KeyTables: dbKeyTables(stats.Tables)}
+	if dbStatsExact {
+		for i := range report.KeyTables {
+			kt := &report.KeyTables[i]
+			schema := "public"
+			for _, t := range stats.Tables {
+				if t.Name == kt.Table {
+					schema = t.Schema
+					break
+				}
+			}
+			n, err := db.CountRows(ctx, pool, schema, kt.Table)
+			if err != nil {
+				return fmt.Errorf("counting rows: %w", err)
+			}
+			kt.Rows, kt.Exact = n, true
+		}
+	}
+	if len(stats.Tables) > dbStatsTables {
+		stats.Tables = stats.Tables[:dbStatsTables]
+	}
+
+	if gateway, err := fetchGatewayDatabaseStatus(ctx, deps, cfg); err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("gateway database status unavailable: %v", err))
+	} else {
+		report.Gateway = gateway
+	}
+
+	format := cfg.OutputFormat
+	if dbOutput != "" {
+		format = config.OutputFormat(dbOutput)
+	}
+	return outputDbStats(format, report)
+}
+
+// dbKeyTables picks the key tables out of tables, using the first
+// candidate name of each category that exists.
+func dbKeyTables(tables []db.TableStats) []DbKeyTable {
+	byName := map[string]db.TableStats{}
+	for _, t := range tables {
+		if _, ok := byName[t.Name]; !ok {
+			byName[t.Name] = t
+		}
+	}
+
+	keyTables := []DbKeyTable{}
+	for _, c := range dbKeyTableCandidates {
+		for _, name := range c.Tables {
+			if t, ok := byName[name]; ok {
+				keyTables = append(keyTables, DbKeyTable{
+					Category:   c.Category,
+					Table:      t.Name,
+					Rows:       t.EstimatedRows,
+					TotalBytes: t.TotalBytes,
+				})
+				break
+			}
+		}
+	}
+	return keyTables
+}
+
+// fetchGatewayDatabaseStatus asks the gateway for its view of the database.
+func fetchGatewayDatabaseStatus(ctx context.Context, deps *DbCommandDeps, cfg *config.CLIConfig) (*client.DatabaseStatus, error) {
+	if deps.InitClient == nil {
+		return nil, fmt.Errorf("gateway client not configured")
+	}
+	grpcClient, err := deps.InitClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer grpcClient.Close()
+
+	status, err := grpcClient.GetStatus(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if status.Database == nil {
+		return nil, fmt.Errorf("gateway did not report database status")
+	}
+	return status.Database, nil
+}
+
+// outputDbStats formats and outputs database stats.
+func outputDbStats(format config.OutputFormat, report *DbStatsReport) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(report)
+	default:
+		outputDbStatsText(report)
+		return nil
+	}
+}
+
+// outputDbStatsText formats database stats for terminal display.
+func outputDbStatsText(report *DbStatsReport) {
+	fmt.Printf("\033[1mDatabase: %s (%s)\033[0m\n\n", report.Database, formatBytes(report.DatabaseBytes))
+
+	fmt.Println("\033[1mKey Tables:\033[0m")
+	if len(report.KeyTables) == 0 {
+		fmt.Println("  (none of sources, content, entities, relationships, embeddings found)")
+	} else {
+		fmt.Println("  CATEGORY        TABLE                        ROWS              SIZE")
+		fmt.Println("  --------        -----                        ----              ----")
+		for _, kt := range report.KeyTables {
+			rows := formatNumber(kt.Rows)
+			if !kt.Exact {
+				rows = "~" + rows
+			}
+			fmt.Printf("  %-15s %-28s %-17s %s\n", kt.Category, truncateDbString(kt.Table, 28), rows, formatBytes(kt.TotalBytes))
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("\033[1mLargest Tables (%d):\033[0m\n", len(report.Tables))
+	fmt.Println("  TABLE                          ROWS (EST)     DEAD          TABLE       INDEXES     TOTAL")
+	fmt.Println("  -----                          ----------     ----          -----       -------     -----")
+	for _, t := range report.Tables {
+		name := t.Name
+		if t.Schema != "public" {
+			name = t.Schema + "." + t.Name
+		}
+		fmt.Printf("  %-30s %-14s %-13s %-11s %-11s %s\n",
+			truncateDbString(name, 30),
+			formatNumber(t.EstimatedRows),
+			formatNumber(t.DeadRows),
+			formatBytes(t.TableBytes),
+			formatBytes(t.IndexBytes),
+			formatBytes(t.TotalBytes))
+	}
+	fmt.Println()
+
+	fmt.Printf("\033[1mLargest Indexes (%d):\033[0m\n", len(report.Indexes))
+	fmt.Println("  INDEX                                    TABLE                    METHOD   SIZE        SCANS         BLOAT")
+	fmt.Println("  -----                                    -----                    ------   ----        -----         -----")
+	for _, idx := range report.Indexes {
+		bloat := "-"
+		if idx.BloatPct != nil {
+			bloat = fmt.Sprintf("%.0f%%", *idx.BloatPct)
+		}
+		fmt.Printf("  %-40s %-24s %-8s %-11s %-13s %s\n",
+			truncateDbString(idx.Name, 40),
+			truncateDbString(idx.Table, 24),
+			idx.Method,
+			formatBytes(idx.Bytes),
+			formatNumber(idx.Scans),
+			bloat)
+	}
+	switch {
+	case !report.BloatChecked:
+		fmt.Println("  Run with --bloat to estimate index bloat (reads each listed index in full).")
+	case !report.BloatAvailable:
+		fmt.Println("  Bloat estimates need the pgstattuple extension (CREATE EXTENSION pgstattuple).")
+	}
+	fmt.Println()
+
+	if g := report.Gateway; g != nil {
+		vector := "disabled"
+		if g.VectorExtensionEnabled {
+			vector = "enabled"
+		}
+		fmt.Println("\033[1mGateway View:\033[0m")
+		fmt.Printf("  Connections:      %d / %d\n", g.ActiveConnections, g.MaxConnections)
+		fmt.Printf("  Vector extension: %s\n", vector)
+		fmt.Printf("  Content items:    %s\n", formatNumber(g.ContentCount))
+		fmt.Printf("  Entities:         %s\n", formatNumber(g.EntityCount))
+		fmt.Printf("  Latency:          %.1fms\n", g.LatencyMs)
+	}
+
+	for _, w := range report.Warnings {
+		fmt.Printf("\033[33mWarning:\033[0m %s\n", w)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/pkg/db"
+)
+
+// TestDbStatsCommand_Help verifies the stats command is registered with its flags.
+func TestDbStatsCommand_Help(t *testing.T) {
+	cmd := NewDbCommand()
+
+	statsCmd, _, err := cmd.Find([]string{"stats"})
+	require.NoError(t, err)
+	require.NotNil(t, statsCmd)
+
+	assert.NotEmpty(t, statsCmd.Example, "stats command should have example usage")
+	for _, name := range []string{"exact", "bloat", "tables", "indexes", "output"} {
+		assert.NotNil(t, statsCmd.Flags().Lookup(name), "stats command should have --%s flag", name)
+	}
+}
+
+func TestDbKeyTables(t *testing.T) {
+	tables := []db.TableStats{
+		{Schema: "public", Name: "content_embeddings", EstimatedRows: 5000, TotalBytes: 9000},
+		{Schema: "public", Name: "content_items", EstimatedRows: 1200, TotalBytes: 4000},
+		{Schema: "public", Name: "sources", EstimatedRows: 40, TotalBytes: 100},
+		{Schema: "public", Name: "entities", EstimatedRows: 300, TotalBytes: 800},
+		{Schema: "public", Name: "people", EstimatedRows: 250, TotalBytes: 700},
+		{Schema: "public", Name: "audit_log", EstimatedRows: 90000, TotalBytes: 20000},
+	}
+
+	got := dbKeyTables(tables)
+	want := []DbKeyTable{
+		{Category: "sources", Table: "sources", Rows: 40, TotalBytes: 100},
+		{Category: "content", Table: "content_items", Rows: 1200, TotalBytes: 4000},
+		{Category: "entities", Table: "entities", Rows: 300, TotalBytes: 800},
+		{Category: "embeddings", Table: "content_embeddings", Rows: 5000, TotalBytes: 9000},
+	}
+	assert.Equal(t, want, got)
+
+	assert.Empty(t, dbKeyTables(nil))
+}
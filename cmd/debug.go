@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -42,6 +41,7 @@ type ConfigInfo struct {
 	TenantSource   string `json:"tenant_source,omitempty" yaml:"tenant_source,omitempty"`
 	OutputFormat   string `json:"output_format" yaml:"output_format"`
 	Timeout        string `json:"timeout" yaml:"timeout"`
+	ConnectTimeout string `json:"connect_timeout" yaml:"connect_timeout"`
 	Debug          bool   `json:"debug" yaml:"debug"`
 	Insecure       bool   `json:"insecure" yaml:"insecure"`
 	CredentialPath string `json:"credential_path" yaml:"credential_path"`
@@ -68,6 +68,104 @@ type SystemInfo struct {
 	NumGoroutine int               `json:"num_goroutine" yaml:"num_goroutine"`
 }
 
+// DebugEnvInfo contains the environment variables read by the CLI and the
+// configuration they resolve to.
+type DebugEnvInfo struct {
+	Variables      []EnvVarInfo `json:"variables" yaml:"variables"`
+	ResolvedConfig ConfigInfo   `json:"resolved_config" yaml:"resolved_config"`
+	ConfigError    string       `json:"config_error,omitempty" yaml:"config_error,omitempty"`
+}
+
+// EnvVarInfo describes a single environment variable and its current value.
+type EnvVarInfo struct {
+	Name      string `json:"name" yaml:"name"`
+	Group     string `json:"group" yaml:"group"`
+	Set       bool   `json:"set" yaml:"set"`
+	Value     string `json:"value,omitempty" yaml:"value,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+}
+
+// envVarSpec declares an environment variable the CLI reads.
+type envVarSpec struct {
+	name      string
+	group     string
+	sensitive bool
+}
+
+// debugEnvVars lists every environment variable read by the CLI, in display order.
+var debugEnvVars = []envVarSpec{
+	{name: "PENF_SERVER_ADDRESS", group: "config"},
+	{name: "PENF_SEARCH_SERVICE_ADDRESS", group: "config"},
+	{name: "PENF_TIMEOUT", group: "config"},
+	{name: "PENF_CONNECT_TIMEOUT", group: "config"},
+	{name: "PENF_REQUEST_TIMEOUT", group: "config"},
+	{name: "PENF_MAX_MESSAGE_SIZE", group: "config"},
+	{name: "PENF_KEEPALIVE_TIME", group: "config"},
+	{name: "PENF_KEEPALIVE_TIMEOUT", group: "config"},
+	{name: "PENF_GRPC_RETRIES", group: "config"},
+	{name: "PENF_STUCK_THRESHOLD_SECONDS", group: "config"},
+	{name: "PENF_OUTPUT_FORMAT", group: "config"},
+	{name: "PENF_TENANT_ID", group: "config"},
+	{name: "PENF_USER_ID", group: "config"},
+	{name: "PENF_DEBUG", group: "config"},
+	{name: "PENF_INSECURE", group: "config"},
+	{name: "PENF_CONFIG_DIR", group: "config"},
+	{name: "PENF_PROFILE", group: "config"},
+	{name: "PENF_INSTALL_PATH", group: "config"},
+	{name: "PENF_UPDATE_PUBLIC_KEY", group: "config"},
+	{name: "PENF_WATCH_WEBHOOK", group: "config", sensitive: true},
+	{name: "PENF_DEFAULT_MODEL", group: "config"},
+	{name: "PENF_CONFIRM_TENANT", group: "config"},
+	{name: "PENF_API_KEY", group: "auth", sensitive: true},
+	{name: "PENF_TOKEN", group: "auth", sensitive: true},
+	{name: "PENF_ENCRYPTION_KEY", group: "auth", sensitive: true},
+	{name: "PENF_TLS_ENABLED", group: "tls"},
+	{name: "PENF_TLS_CA_CERT", group: "tls"},
+	{name: "PENF_TLS_CLIENT_CERT", group: "tls"},
+	{name: "PENF_TLS_CLIENT_KEY", group: "tls"},
+	{name: "PENF_TLS_CERT_DIR", group: "tls"},
+	{name: "PENF_TLS_SKIP_VERIFY", group: "tls"},
+	{name: "GATEWAY_HEALTH_URL", group: "services"},
+	{name: "GATEWAY_EMBEDDINGS_URL", group: "services"},
+	{name: "GATEWAY_LLM_URL", group: "services"},
+	{name: "AI_SERVICE_URL", group: "services"},
+	{name: "AI_COORDINATOR_HEALTH_URL", group: "services"},
+	{name: "WORKER_HEALTH_URL", group: "services"},
+	{name: "DATABASE_URL", group: "database", sensitive: true},
+	{name: "PENFOLD_DB_URL", group: "database", sensitive: true},
+	{name: "PENF_DB_HOST", group: "database"},
+	{name: "PENF_DB_PORT", group: "database"},
+	{name: "PENF_DB_USER", group: "database"},
+	{name: "PENF_DB_NAME", group: "database"},
+	{name: "PENF_DB_PASSWORD", group: "database", sensitive: true},
+	{name: "PENF_DB_SSLMODE", group: "database"},
+	{name: "DB_HOST", group: "database"},
+	{name: "DB_PORT", group: "database"},
+	{name: "DB_USER", group: "database"},
+	{name: "DB_NAME", group: "database"},
+	{name: "DB_PASSWORD", group: "database", sensitive: true},
+	{name: "DB_SSLMODE", group: "database"},
+	{name: "DB_SSLCERT", group: "database"},
+	{name: "DB_SSLKEY", group: "database"},
+	{name: "DB_SSLROOTCERT", group: "database"},
+	{name: "DB_MIN_CONNS", group: "database"},
+	{name: "DB_MAX_CONNS", group: "database"},
+	{name: "REDIS_HOST", group: "redis"},
+	{name: "REDIS_PORT", group: "redis"},
+	{name: "REDIS_PASSWORD", group: "redis", sensitive: true},
+	{name: "PENF_CONTEXT_PALACE_HOST", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_PORT", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_DATABASE", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_USER", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_SSLMODE", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_PROJECT", group: "context-palace"},
+	{name: "PENF_CONTEXT_PALACE_AGENT", group: "context-palace"},
+	{name: "PENFOLD_REPO", group: "session"},
+	{name: "CLAUDE_SESSION_ID", group: "session"},
+	{name: "NO_COLOR", group: "terminal"},
+	{name: "EDITOR", group: "terminal"},
+}
+
 // DebugCommandDeps holds the dependencies for debug commands.
 type DebugCommandDeps struct {
 	Config     *config.CLIConfig
@@ -117,6 +215,7 @@ Commands:
   config - Show configuration details
   env    - Show environment variables
   ping   - Test connection to the server
+  bundle - Collect diagnostics into one archive for a bug report
 
 Examples:
   # Show all debug information
@@ -129,7 +228,10 @@ Examples:
   penf debug ping
 
   # Show relevant environment variables
-  penf debug env`,
+  penf debug env
+
+  # Collect everything for a bug report
+  penf debug bundle --output report.tar.gz`,
 	}
 
 	// Add subcommands.
@@ -137,6 +239,7 @@ Examples:
 	cmd.AddCommand(newDebugConfigCommand(deps))
 	cmd.AddCommand(newDebugEnvCommand(deps))
 	cmd.AddCommand(newDebugPingCommand(deps))
+	cmd.AddCommand(newDebugBundleCommand(deps))
 
 	return cmd
 }
@@ -150,17 +253,22 @@ func newDebugInfoCommand(deps *DebugCommandDeps) *cobra.Command {
 connection status, and system environment.
 
 This is useful for troubleshooting issues or providing diagnostic information
-when reporting bugs.
+when reporting bugs. Use --redact before sharing the output to scrub tokens
+and keys, and --redact-hosts to also hide server hostnames. To collect
+everything at once, use 'penf debug bundle'.
 
 Examples:
   penf debug info
-  penf debug info --output=json`,
+  penf debug info --output=json
+  penf debug info --redact --redact-hosts`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDebugInfo(cmd.Context(), deps)
 		},
 	}
 
 	cmd.Flags().StringVarP(&debugOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().BoolVar(&debugRedact, "redact", false, "Scrub tokens, keys and certificate contents")
+	cmd.Flags().BoolVar(&debugRedactHosts, "redact-hosts", false, "Also replace server hostnames and IP addresses (implies --redact)")
 
 	return cmd
 }
@@ -188,20 +296,27 @@ Examples:
 
 // newDebugEnvCommand creates the 'debug env' subcommand.
 func newDebugEnvCommand(deps *DebugCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "env",
 		Short: "Show relevant environment variables",
-		Long: `Show environment variables relevant to penf configuration.
+		Long: `Show every environment variable the penf CLI reads, and the resolved
+configuration after config file, environment and defaults are applied.
 
-Displays PENF_* environment variables and their values, useful for
-debugging configuration issues.
+Each variable is shown with its current value, or "(not set)". Secrets such as
+API keys, tokens, passwords and database URLs are masked. Use this to explain
+surprising behavior caused by stray environment variables.
 
 Examples:
-  penf debug env`,
+  penf debug env
+  penf debug env --output=json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDebugEnv()
+			return runDebugEnv(deps)
 		},
 	}
+
+	cmd.Flags().StringVarP(&debugOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
 }
 
 // newDebugPingCommand creates the 'debug ping' subcommand.
@@ -233,6 +348,9 @@ func runDebugInfo(ctx context.Context, deps *DebugCommandDeps) error {
 		System:     getSystemInfo(),
 		Timestamp:  time.Now(),
 	}
+	if debugRedact || debugRedactHosts {
+		redactDebugInfo(&info, newDebugRedactor(cfg, true, debugRedactHosts))
+	}
 
 	// Output based on format.
 	format := config.OutputFormatText
@@ -246,7 +364,7 @@ func runDebugInfo(ctx context.Context, deps *DebugCommandDeps) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(info)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(info)
 	default:
 		return outputDebugInfoText(info)
@@ -274,50 +392,91 @@ func runDebugConfig(deps *DebugCommandDeps) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(info)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(info)
 	default:
 		return outputConfigInfoText(info)
 	}
 }
 
-// runDebugEnv shows environment variables.
-func runDebugEnv() error {
-	fmt.Println("Penfold Environment Variables:")
-	fmt.Println()
+// runDebugEnv shows environment variables and the resolved configuration.
+func runDebugEnv(deps *DebugCommandDeps) error {
+	info := DebugEnvInfo{
+		Variables: getEnvVarInfo(),
+	}
 
-	envVars := []string{
-		"PENF_SERVER_ADDRESS",
-		"PENF_TIMEOUT",
-		"PENF_OUTPUT_FORMAT",
-		"PENF_TENANT_ID",
-		"PENF_DEBUG",
-		"PENF_INSECURE",
-		"PENF_CONFIG_DIR",
-		"PENF_API_KEY",
-		"PENF_TOKEN",
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		info.ConfigError = err.Error()
 	}
+	info.ResolvedConfig = getConfigInfo(cfg)
+
+	format := config.OutputFormatText
+	if debugOutput != "" {
+		format = config.OutputFormat(debugOutput)
+	}
+
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(info)
+	default:
+		return outputDebugEnvText(info)
+	}
+}
+
+// getEnvVarInfo returns the current value of every environment variable the CLI reads.
+func getEnvVarInfo() []EnvVarInfo {
+	vars := make([]EnvVarInfo, 0, len(debugEnvVars))
+	for _, spec := range debugEnvVars {
+		value, set := os.LookupEnv(spec.name)
+		if set && spec.sensitive {
+			value = maskValue(value)
+		}
+		vars = append(vars, EnvVarInfo{
+			Name:      spec.name,
+			Group:     spec.group,
+			Set:       set,
+			Value:     value,
+			Sensitive: spec.sensitive,
+		})
+	}
+	return vars
+}
+
+// outputDebugEnvText outputs environment info in text format.
+func outputDebugEnvText(info DebugEnvInfo) error {
+	fmt.Println("Penfold Environment Variables:")
 
 	found := false
-	for _, key := range envVars {
-		value := os.Getenv(key)
-		if value != "" {
+	group := ""
+	for _, v := range info.Variables {
+		if v.Group != group {
+			group = v.Group
+			fmt.Printf("\n  [%s]\n", group)
+		}
+		if v.Set {
 			found = true
-			// Mask sensitive values.
-			if key == "PENF_API_KEY" || key == "PENF_TOKEN" {
-				value = maskValue(value)
-			}
-			fmt.Printf("  %s=%s\n", key, value)
+			fmt.Printf("  %s=%s\n", v.Name, v.Value)
 		} else {
-			fmt.Printf("  %s=(not set)\n", key)
+			fmt.Printf("  \033[90m%s=(not set)\033[0m\n", v.Name)
 		}
 	}
 
 	if !found {
-		fmt.Println("  (no PENF_* environment variables are set)")
+		fmt.Println()
+		fmt.Println("  (none of these environment variables are set)")
 	}
+	fmt.Println()
 
-	return nil
+	if info.ConfigError != "" {
+		fmt.Printf("Error loading config: %s\n\n", info.ConfigError)
+	}
+	return outputConfigInfoText(info.ResolvedConfig)
 }
 
 // runDebugPing tests the connection.
@@ -398,6 +557,7 @@ func getConfigInfo(cfg *config.CLIConfig) ConfigInfo {
 		info.TenantID = cfg.TenantID
 		info.OutputFormat = string(cfg.OutputFormat)
 		info.Timeout = cfg.Timeout.String()
+		info.ConnectTimeout = cfg.GetConnectTimeout().String()
 		info.Debug = cfg.Debug
 		info.Insecure = cfg.Insecure
 
@@ -499,6 +659,7 @@ func outputDebugInfoText(info DebugInfo) error {
 	}
 	fmt.Printf("  Server:        %s\n", info.Config.ServerAddress)
 	fmt.Printf("  Timeout:       %s\n", info.Config.Timeout)
+	fmt.Printf("  Connect Wait:  %s\n", info.Config.ConnectTimeout)
 	fmt.Printf("  Output Format: %s\n", info.Config.OutputFormat)
 	if info.Config.TenantID != "" {
 		fmt.Printf("  Tenant ID:     %s (from %s)\n", info.Config.TenantID, info.Config.TenantSource)
@@ -570,6 +731,7 @@ func outputConfigInfoText(info ConfigInfo) error {
 	fmt.Println("  Active Settings:")
 	fmt.Printf("    server_address: %s\n", info.ServerAddress)
 	fmt.Printf("    timeout:        %s\n", info.Timeout)
+	fmt.Printf("    connect_timeout: %s\n", info.ConnectTimeout)
 	fmt.Printf("    output_format:  %s\n", info.OutputFormat)
 	if info.TenantID != "" {
 		fmt.Printf("    tenant_id:      %s (source: %s)\n", info.TenantID, info.TenantSource)
@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+const (
+	// debugBundleCheckTimeout bounds each server check made for a bundle.
+	debugBundleCheckTimeout = 10 * time.Second
+
+	// debugBundleLogLimit is the most log entries included in a bundle.
+	debugBundleLogLimit = 500
+
+	// redactedValue replaces secrets removed from debug output.
+	redactedValue = "[REDACTED]"
+)
+
+// Debug redaction and bundle flags.
+var (
+	debugRedact          bool
+	debugBundleRedact    bool
+	debugRedactHosts     bool
+	debugBundleOutput    string
+	debugBundleLogsSince time.Duration
+)
+
+// DebugBundleManifest describes the contents of a debug bundle.
+type DebugBundleManifest struct {
+	CreatedAt   time.Time         `json:"created_at"`
+	CLIVersion  string            `json:"cli_version"`
+	Redacted    bool              `json:"redacted"`
+	RedactHosts bool              `json:"redact_hosts"`
+	Files       []string          `json:"files"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// debugBundleFile is one file of a debug bundle.
+type debugBundleFile struct {
+	Name string
+	Data []byte
+}
+
+// newDebugBundleCommand creates the 'debug bundle' subcommand.
+func newDebugBundleCommand(deps *DebugCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Collect diagnostics into one archive for a bug report",
+		Long: `Collect everything useful for a bug report into a single .tar.gz:
+
+  manifest.json      What was collected, and what could not be
+  info.json          CLI version, configuration summary, connection, system
+  env.json           Environment variables read by the CLI (secrets masked)
+  config.yaml        The config file, with secret values removed
+  versions.json      CLI and deployed service versions
+  health.json        System status and pipeline statistics
+  connectivity.json  DNS, TCP, TLS and gRPC checks against the server
+  preflight.json     Gateway and AI coordinator preflight checks
+  logs.json          Recent server logs
+
+A check that fails is recorded in manifest.json rather than failing the
+bundle. Secret values in config.yaml are always removed. With --redact
+(the default), tokens, keys and certificate contents are also scrubbed
+from every file; add --redact-hosts to replace server hostnames and IP
+addresses with placeholders too.
+
+Examples:
+  penf debug bundle
+  penf debug bundle --output report.tar.gz
+  penf debug bundle --redact-hosts --logs-since 4h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDebugBundle(cmd.Context(), deps)
+		},
+	}
+
+	cmd.Flags().StringVarP(&debugBundleOutput, "output", "o", "", "Archive to write (default: penf-debug-<timestamp>.tar.gz)")
+	cmd.Flags().BoolVar(&debugBundleRedact, "redact", true, "Scrub tokens, keys and certificate contents")
+	cmd.Flags().BoolVar(&debugRedactHosts, "redact-hosts", false, "Also replace server hostnames and IP addresses (implies --redact)")
+	cmd.Flags().DurationVar(&debugBundleLogsSince, "logs-since", time.Hour, "How far back to collect server logs")
+
+	return cmd
+}
+
+// runDebugBundle collects diagnostics and writes the bundle archive.
+func runDebugBundle(ctx context.Context, deps *DebugCommandDeps) error {
+	path := debugBundleOutput
+	if path == "" {
+		path = fmt.Sprintf("penf-debug-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	cfg, cfgErr := deps.LoadConfig()
+	redactor := newDebugRedactor(cfg, debugBundleRedact || debugRedactHosts, debugRedactHosts)
+
+	files, manifest := collectDebugBundle(ctx, deps, cfg)
+	if cfgErr != nil {
+		manifest.Errors["config"] = cfgErr.Error()
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("creating bundle: %w", err)
+	}
+	if err := writeDebugBundle(f, files, manifest, redactor); err != nil {
+		f.Close()
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	fmt.Printf("Wrote %s (%d files)\n", path, len(manifest.Files))
+	for _, section := range slices.Sorted(maps.Keys(manifest.Errors)) {
+		fmt.Printf("  Skipped %s: %s\n", section, redactor.String(manifest.Errors[section]))
+	}
+	if !redactor.enabled {
+		fmt.Fprintln(os.Stderr, "Warning: the bundle is not redacted; review it before sharing.")
+	}
+	return nil
+}
+
+// collectDebugBundle gathers the bundle files. Each section that cannot be
+// collected is recorded in the manifest errors instead.
+func collectDebugBundle(ctx context.Context, deps *DebugCommandDeps, cfg *config.CLIConfig) ([]debugBundleFile, DebugBundleManifest) {
+	manifest := DebugBundleManifest{
+		CreatedAt:   time.Now().UTC(),
+		CLIVersion:  deps.Version,
+		Redacted:    debugBundleRedact || debugRedactHosts,
+		RedactHosts: debugRedactHosts,
+		Errors:      make(map[string]string),
+	}
+	var files []debugBundleFile
+	addJSON := func(name string, v interface{}) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			manifest.Errors[name] = err.Error()
+			return
+		}
+		files = append(files, debugBundleFile{Name: name, Data: append(data, '\n')})
+	}
+	check := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, debugBundleCheckTimeout)
+	}
+
+	addJSON("info.json", DebugInfo{
+		CLI:        getCLIInfo(deps),
+		Config:     getConfigInfo(cfg),
+		Connection: getConnectionInfo(ctx, deps, cfg),
+		System:     getSystemInfo(),
+		Timestamp:  time.Now(),
+	})
+
+	env := DebugEnvInfo{Variables: getEnvVarInfo(), ResolvedConfig: getConfigInfo(cfg)}
+	addJSON("env.json", env)
+
+	if configPath, err := config.ConfigPath(); err != nil {
+		manifest.Errors["config.yaml"] = err.Error()
+	} else if data, err := os.ReadFile(configPath); err != nil {
+		manifest.Errors["config.yaml"] = err.Error()
+	} else if data, err = redactConfigYAML(data); err != nil {
+		manifest.Errors["config.yaml"] = err.Error()
+	} else {
+		files = append(files, debugBundleFile{Name: "config.yaml", Data: data})
+	}
+
+	versionsCtx, cancel := check()
+	versions := map[string]interface{}{"cli": getCLIInfo(deps)}
+	var services []map[string]string
+	for _, v := range FetchServiceVersions(versionsCtx, &http.Client{Timeout: debugBundleCheckTimeout}, DeployedServices) {
+		svc := map[string]string{"service": v.Info.ServiceName, "version": v.Info.Version, "commit": v.Info.Commit}
+		if v.Err != nil {
+			svc["error"] = v.Err.Error()
+		}
+		services = append(services, svc)
+	}
+	cancel()
+	versions["services"] = services
+	addJSON("versions.json", versions)
+
+	if cfg == nil {
+		for _, name := range []string{"health.json", "connectivity.json", "logs.json"} {
+			manifest.Errors[name] = "configuration not loaded"
+		}
+	} else {
+		connCtx, cancel := check()
+		addJSON("connectivity.json", runConnectivityChecks(connCtx, cfg, debugBundleCheckTimeout))
+		cancel()
+
+		if grpcClient, err := deps.InitClient(cfg); err != nil {
+			manifest.Errors["health.json"] = err.Error()
+			manifest.Errors["logs.json"] = err.Error()
+		} else {
+			health := map[string]interface{}{}
+			statusCtx, cancel := check()
+			if status, err := grpcClient.GetStatus(statusCtx, true); err != nil {
+				health["status_error"] = err.Error()
+			} else {
+				health["status"] = status
+			}
+			if stats, err := grpcClient.GetStats(statusCtx, cfg.TenantID); err != nil {
+				health["pipeline_error"] = err.Error()
+			} else {
+				health["pipeline"] = stats.GetStats()
+			}
+			cancel()
+			addJSON("health.json", health)
+
+			logsCtx, cancel := check()
+			filter := client.LogFilter{Since: time.Now().Add(-debugBundleLogsSince)}
+			if logs, err := grpcClient.ListLogs(logsCtx, filter, debugBundleLogLimit, 0, false); err != nil {
+				manifest.Errors["logs.json"] = err.Error()
+			} else {
+				addJSON("logs.json", logs)
+			}
+			cancel()
+			grpcClient.Close()
+		}
+	}
+
+	gatewayURL := os.Getenv("GATEWAY_HEALTH_URL")
+	coordinatorURL := os.Getenv("AI_COORDINATOR_HEALTH_URL")
+	if (gatewayURL == "" || coordinatorURL == "") && cfg != nil {
+		derivedGW, derivedAI := deriveHealthURLs(cfg.ServerAddress)
+		if gatewayURL == "" {
+			gatewayURL = derivedGW
+		}
+		if coordinatorURL == "" {
+			coordinatorURL = derivedAI
+		}
+	}
+	if gatewayURL != "" && coordinatorURL != "" {
+		addJSON("preflight.json", runPreflightCheck(gatewayURL, coordinatorURL, debugBundleCheckTimeout))
+	} else {
+		manifest.Errors["preflight.json"] = "no health URLs configured"
+	}
+
+	return files, manifest
+}
+
+// writeDebugBundle writes the manifest and files as a gzipped tar archive,
+// applying the redactor to every file.
+func writeDebugBundle(w io.Writer, files []debugBundleFile, manifest DebugBundleManifest, redactor *debugRedactor) error {
+	manifest.Files = []string{"manifest.json"}
+	for _, f := range files {
+		manifest.Files = append(manifest.Files, f.Name)
+	}
+	for section, msg := range manifest.Errors {
+		manifest.Errors[section] = redactor.String(msg)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	all := append([]debugBundleFile{{Name: "manifest.json", Data: append(data, '\n')}}, files...)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range all {
+		content := redactor.Bytes(f.Data)
+		hdr := &tar.Header{
+			Name:    f.Name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// secretKeyPattern matches configuration keys whose values are secrets.
+var secretKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api_?key|webhook|private_?key|credential)`)
+
+// redactConfigYAML removes the values of secret keys from a YAML document,
+// keeping its structure and comments.
+func redactConfigYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	redactYAMLSecrets(&doc)
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// redactYAMLSecrets replaces the scalar values of secret keys under node.
+func redactYAMLSecrets(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Value != "" && secretKeyPattern.MatchString(key.Value) {
+				value.Value = redactedValue
+				value.Tag = "!!str"
+				value.Style = 0
+				continue
+			}
+			redactYAMLSecrets(value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		redactYAMLSecrets(child)
+	}
+}
+
+// Patterns scrubbed by --redact.
+var (
+	pemBlockPattern     = regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]+)-----[\s\S]*?-----END [A-Z0-9 ]+-----`)
+	jwtPattern          = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]*`)
+	bearerPattern       = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	secretAssignPattern = regexp.MustCompile(`(?i)("?[a-z_]*(?:token|secret|password|passwd|api_?key)"?\s*[:=]\s*"?)([^\s",}]+)`)
+	ipv4Pattern         = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// debugRedactor scrubs secrets, and optionally hostnames, from debug output.
+// Hosts are replaced with stable placeholders (host-1, host-2, ...) so the
+// output still shows which addresses are the same.
+type debugRedactor struct {
+	enabled bool
+	hosts   *strings.Replacer
+}
+
+// newDebugRedactor returns a redactor. With redactHosts, the hosts of the
+// configured server, database and deployed services are replaced.
+func newDebugRedactor(cfg *config.CLIConfig, enabled, redactHosts bool) *debugRedactor {
+	r := &debugRedactor{enabled: enabled}
+	if !enabled || !redactHosts {
+		return r
+	}
+
+	var hosts []string
+	addHost := func(addr string) {
+		host := addr
+		if u, err := url.Parse(addr); err == nil && u.Host != "" {
+			host = u.Hostname()
+		} else if h, _, err := net.SplitHostPort(addr); err == nil {
+			host = h
+		}
+		if host != "" && host != "localhost" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	if cfg != nil {
+		addHost(cfg.ServerAddress)
+		addHost(cfg.SearchServiceAddress)
+		if cfg.Database != nil {
+			addHost(cfg.Database.Host)
+		}
+	}
+	for _, svc := range DeployedServices {
+		addHost(svc.URL)
+	}
+	for _, name := range []string{"PENF_SERVER_ADDRESS", "GATEWAY_HEALTH_URL", "GATEWAY_EMBEDDINGS_URL", "GATEWAY_LLM_URL", "AI_SERVICE_URL", "AI_COORDINATOR_HEALTH_URL", "WORKER_HEALTH_URL"} {
+		if v := os.Getenv(name); v != "" {
+			addHost(v)
+		}
+	}
+
+	// Number hosts in first-seen order, but replace longer names first so
+	// a host is never partly replaced by a shorter one it contains.
+	placeholders := make(map[string]string, len(hosts))
+	for i, host := range hosts {
+		placeholders[host] = fmt.Sprintf("host-%d", i+1)
+	}
+	sort.SliceStable(hosts, func(i, j int) bool { return len(hosts[i]) > len(hosts[j]) })
+	var pairs []string
+	for _, host := range hosts {
+		pairs = append(pairs, host, placeholders[host])
+	}
+	r.hosts = strings.NewReplacer(pairs...)
+	return r
+}
+
+// String returns s with secrets removed.
+func (r *debugRedactor) String(s string) string {
+	if !r.enabled {
+		return s
+	}
+	s = pemBlockPattern.ReplaceAllString(s, "[REDACTED $1]")
+	s = jwtPattern.ReplaceAllString(s, redactedValue)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redactedValue)
+	s = secretAssignPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := secretAssignPattern.FindStringSubmatch(m)
+		if parts[2] == redactedValue || strings.HasPrefix(parts[2], "[REDACTED") {
+			return m
+		}
+		return parts[1] + redactedValue
+	})
+	if r.hosts != nil {
+		s = r.hosts.Replace(s)
+		s = ipv4Pattern.ReplaceAllString(s, "x.x.x.x")
+	}
+	return s
+}
+
+// Bytes returns data with secrets removed.
+func (r *debugRedactor) Bytes(data []byte) []byte {
+	if !r.enabled {
+		return data
+	}
+	return []byte(r.String(string(data)))
+}
+
+// redactDebugInfo scrubs the free-form fields of debug info.
+func redactDebugInfo(info *DebugInfo, r *debugRedactor) {
+	info.Config.ServerAddress = r.String(info.Config.ServerAddress)
+	info.Connection.ServerAddress = r.String(info.Connection.ServerAddress)
+	info.Connection.Error = r.String(info.Connection.Error)
+	for k, v := range info.System.EnvVars {
+		info.System.EnvVars[k] = r.String(v)
+	}
+}
@@ -60,7 +60,7 @@ func TestNewDebugCommand(t *testing.T) {
 
 	// Check subcommands exist.
 	subcommands := cmd.Commands()
-	expectedSubcmds := []string{"info", "config", "env", "ping"}
+	expectedSubcmds := []string{"info", "config", "env", "ping", "bundle"}
 
 	for _, expected := range expectedSubcmds {
 		found := false
@@ -299,7 +299,7 @@ func TestRunDebugEnv(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDebugEnv()
+	err := runDebugEnv(createDebugTestDeps(mockDebugConfig()))
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -324,7 +324,7 @@ func TestRunDebugEnv_WithEnvVarSet(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDebugEnv()
+	err := runDebugEnv(createDebugTestDeps(mockDebugConfig()))
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -337,6 +337,39 @@ func TestRunDebugEnv_WithEnvVarSet(t *testing.T) {
 	assert.Contains(t, output, "PENF_DEBUG=true")
 }
 
+func TestRunDebugEnv_JSONOutput(t *testing.T) {
+	t.Setenv("PENF_API_KEY", "sk-1234567890abcdef")
+	t.Setenv("GATEWAY_LLM_URL", "http://llm.local:8080")
+	t.Setenv("REDIS_PASSWORD", "redis-secret-value")
+
+	debugOutput = "json"
+	defer func() { debugOutput = "" }()
+
+	output := captureStdout(func() {
+		err := runDebugEnv(createDebugTestDeps(mockDebugConfig()))
+		require.NoError(t, err)
+	})
+
+	var info DebugEnvInfo
+	require.NoError(t, json.Unmarshal([]byte(output), &info))
+	assert.Equal(t, "localhost:50051", info.ResolvedConfig.ServerAddress)
+
+	vars := make(map[string]EnvVarInfo)
+	for _, v := range info.Variables {
+		vars[v.Name] = v
+	}
+	assert.Equal(t, "sk-1****cdef", vars["PENF_API_KEY"].Value)
+	assert.True(t, vars["PENF_API_KEY"].Sensitive)
+	assert.Equal(t, "http://llm.local:8080", vars["GATEWAY_LLM_URL"].Value)
+	assert.True(t, vars["GATEWAY_LLM_URL"].Set)
+	assert.True(t, vars["REDIS_PASSWORD"].Sensitive)
+	assert.NotContains(t, output, "redis-secret-value")
+	for _, name := range []string{"DB_SSLROOTCERT", "DB_MAX_CONNS", "NO_COLOR", "EDITOR"} {
+		assert.Contains(t, vars, name)
+	}
+	assert.NotContains(t, output, "sk-1234567890abcdef")
+}
+
 func TestGetCLIInfo(t *testing.T) {
 	deps := createDebugTestDeps(mockDebugConfig())
 
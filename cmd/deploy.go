@@ -62,6 +62,8 @@ Examples:
   penf deploy bridge       Deploy penfold-bridge (TypeScript/Node.js) to dev01
 
 Subcommands:
+  penf deploy status       Show deployed versions and health (--output json for CI)
+  penf deploy verify       Exit non-zero unless every service runs --expect-version
   penf deploy history      Show deployment history
   penf deploy record       Record a deployment in deploy_history
 
@@ -135,6 +137,9 @@ Environment:
 	_ = recordCmd.MarkFlagRequired("commit")
 	deployCmd.AddCommand(recordCmd)
 
+	deployCmd.AddCommand(newDeployStatusCommand())
+	deployCmd.AddCommand(newDeployVerifyCommand())
+
 	return deployCmd
 }
 
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+)
+
+// Service health as reported by 'deploy status'.
+const (
+	deployHealthHealthy     = "healthy"
+	deployHealthUnhealthy   = "unhealthy"
+	deployHealthUnreachable = "unreachable"
+	deployHealthDegraded    = "degraded"
+	deployHealthDown        = "down"
+)
+
+// Deploy status and verify flags.
+var (
+	deployCheckTimeout    time.Duration
+	deployExpectedVersion string
+)
+
+// DeployedService is a deployed service that serves /version and /health.
+type DeployedService struct {
+	Name string
+	URL  string
+}
+
+// DeployedServices are the services queried by 'version --all', 'deploy
+// status' and 'deploy verify'.
+var DeployedServices = []DeployedService{
+	{"penfold-gateway", "http://dev02.brown.chat:8080"},
+	{"penfold-worker", "http://dev01.brown.chat:8085"},
+	{"penfold-ai-coordinator", "http://dev02.brown.chat:8090"},
+}
+
+// ServiceVersion is the build info of a deployed service, or the error
+// fetching it.
+type ServiceVersion struct {
+	Info buildinfo.Info
+	Err  error
+}
+
+// FetchServiceVersions queries the /version endpoint of each service.
+// Services that cannot be queried are reported with version "unreachable".
+func FetchServiceVersions(ctx context.Context, httpClient *http.Client, services []DeployedService) []ServiceVersion {
+	versions := make([]ServiceVersion, 0, len(services))
+	for _, svc := range services {
+		info, err := buildinfo.Fetch(ctx, httpClient, svc.URL)
+		if err != nil {
+			versions = append(versions, ServiceVersion{
+				Info: buildinfo.Info{ServiceName: svc.Name, Version: "unreachable"},
+				Err:  err,
+			})
+			continue
+		}
+		if info.ServiceName == "" {
+			info.ServiceName = svc.Name
+		}
+		versions = append(versions, ServiceVersion{Info: info})
+	}
+	return versions
+}
+
+// DeployServiceStatus is the deployed build and health of one service.
+type DeployServiceStatus struct {
+	Service   string `json:"service" yaml:"service"`
+	URL       string `json:"url" yaml:"url"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
+	Commit    string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty" yaml:"build_time,omitempty"`
+	Health    string `json:"health" yaml:"health"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// DeployStatusReport is the output of 'deploy status'. Health is healthy when
+// every service is, down when none is, and degraded otherwise.
+type DeployStatusReport struct {
+	Health    string                `json:"health" yaml:"health"`
+	Services  []DeployServiceStatus `json:"services" yaml:"services"`
+	CheckedAt time.Time             `json:"checked_at" yaml:"checked_at"`
+}
+
+// DeployVerifyService is the version check of one service.
+type DeployVerifyService struct {
+	Service string `json:"service" yaml:"service"`
+	Version string `json:"version" yaml:"version"`
+	Commit  string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Match   bool   `json:"match" yaml:"match"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// DeployVerifyResult is the output of 'deploy verify'.
+type DeployVerifyResult struct {
+	ExpectedVersion string                `json:"expected_version" yaml:"expected_version"`
+	Passed          bool                  `json:"passed" yaml:"passed"`
+	Services        []DeployVerifyService `json:"services" yaml:"services"`
+	CheckedAt       time.Time             `json:"checked_at" yaml:"checked_at"`
+}
+
+// newDeployStatusCommand creates the 'deploy status' subcommand.
+func newDeployStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the deployed version and health of each service",
+		Long: `Show the version and commit deployed for each service, with a health rollup.
+
+Each service is queried over HTTP for /version and /health. The rollup is
+healthy when every service is healthy, down when none is, and degraded
+otherwise. Use --output json for a manifest CI can record.
+
+Unlike --status, which runs the deploy script's status check on the hosts,
+this only queries the services themselves.
+
+Examples:
+  penf deploy status
+  penf deploy status --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			httpClient := &http.Client{Timeout: deployCheckTimeout}
+			report := buildDeployStatus(cmd.Context(), httpClient, DeployedServices)
+			return outputDeployStatus(os.Stdout, config.OutputFormat(getOutputFormat(cmd)), report)
+		},
+	}
+	cmd.Flags().DurationVar(&deployCheckTimeout, "timeout", 5*time.Second, "Timeout for each service request")
+	return cmd
+}
+
+// newDeployVerifyCommand creates the 'deploy verify' subcommand.
+func newDeployVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check that every service runs the expected version",
+		Long: `Check that every deployed service is running the expected version.
+
+Exits non-zero if any service reports a different version or cannot be
+reached, so deployment pipelines can gate on a single command. A leading
+"v" is ignored when comparing versions.
+
+Examples:
+  penf deploy verify --expect-version v0.9.0
+  penf deploy verify --expect-version v0.9.0 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			httpClient := &http.Client{Timeout: deployCheckTimeout}
+			result := buildDeployVerify(cmd.Context(), httpClient, DeployedServices, deployExpectedVersion)
+			if err := outputDeployVerify(os.Stdout, config.OutputFormat(getOutputFormat(cmd)), result); err != nil {
+				return err
+			}
+			if !result.Passed {
+				var failed int
+				for _, svc := range result.Services {
+					if !svc.Match {
+						failed++
+					}
+				}
+				return fmt.Errorf("%d of %d services not running %s", failed, len(result.Services), result.ExpectedVersion)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&deployExpectedVersion, "expect-version", "", "Version every service must be running (required)")
+	cmd.Flags().DurationVar(&deployCheckTimeout, "timeout", 5*time.Second, "Timeout for each service request")
+	_ = cmd.MarkFlagRequired("expect-version")
+	return cmd
+}
+
+// buildDeployStatus fetches the version and health of each service.
+func buildDeployStatus(ctx context.Context, httpClient *http.Client, services []DeployedService) DeployStatusReport {
+	report := DeployStatusReport{CheckedAt: time.Now().UTC()}
+	versions := FetchServiceVersions(ctx, httpClient, services)
+
+	var healthy int
+	for i, svc := range services {
+		status := DeployServiceStatus{Service: svc.Name, URL: svc.URL}
+		if v := versions[i]; v.Err == nil {
+			status.Version = v.Info.Version
+			status.Commit = v.Info.Commit
+			status.BuildTime = v.Info.BuildTime
+		}
+		status.Health, status.Error = fetchServiceHealth(ctx, httpClient, svc.URL)
+		if status.Health == deployHealthHealthy {
+			healthy++
+		}
+		report.Services = append(report.Services, status)
+	}
+
+	switch healthy {
+	case len(services):
+		report.Health = deployHealthHealthy
+	case 0:
+		report.Health = deployHealthDown
+	default:
+		report.Health = deployHealthDegraded
+	}
+	return report
+}
+
+// fetchServiceHealth queries a service's /health endpoint. A 200 response is
+// healthy unless its body reports another status.
+func fetchServiceHealth(ctx context.Context, httpClient *http.Client, baseURL string) (health, errMsg string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/health", nil)
+	if err != nil {
+		return deployHealthUnreachable, err.Error()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return deployHealthUnreachable, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return deployHealthUnhealthy, fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&body) == nil {
+		switch strings.ToLower(body.Status) {
+		case "", "ok", "up", "healthy", "serving":
+		default:
+			return deployHealthUnhealthy, "status " + body.Status
+		}
+	}
+	return deployHealthHealthy, ""
+}
+
+// buildDeployVerify compares the version of each service with expected.
+func buildDeployVerify(ctx context.Context, httpClient *http.Client, services []DeployedService, expected string) DeployVerifyResult {
+	result := DeployVerifyResult{ExpectedVersion: expected, Passed: true, CheckedAt: time.Now().UTC()}
+	for _, v := range FetchServiceVersions(ctx, httpClient, services) {
+		svc := DeployVerifyService{Service: v.Info.ServiceName, Version: v.Info.Version, Commit: v.Info.Commit}
+		if v.Err != nil {
+			svc.Error = v.Err.Error()
+		} else {
+			svc.Match = sameVersion(v.Info.Version, expected)
+		}
+		if !svc.Match {
+			result.Passed = false
+		}
+		result.Services = append(result.Services, svc)
+	}
+	return result
+}
+
+// sameVersion reports whether two versions are equal, ignoring a leading "v".
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
+
+// outputDeployStatus writes a deploy status report in the given format.
+func outputDeployStatus(w io.Writer, format config.OutputFormat, report DeployStatusReport) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(w).Encode(report)
+	}
+
+	fmt.Fprintf(w, "%-25s %-12s %-10s %s\n", "SERVICE", "VERSION", "COMMIT", "HEALTH")
+	for _, svc := range report.Services {
+		health := svc.Health
+		if svc.Error != "" {
+			health += " (" + svc.Error + ")"
+		}
+		version, commit := svc.Version, truncateCommit(svc.Commit)
+		if version == "" {
+			version, commit = "-", "-"
+		}
+		fmt.Fprintf(w, "%-25s %-12s %-10s %s\n", svc.Service, version, commit, health)
+	}
+	fmt.Fprintf(w, "\nOverall: %s\n", report.Health)
+	return nil
+}
+
+// outputDeployVerify writes a deploy verify result in the given format.
+func outputDeployVerify(w io.Writer, format config.OutputFormat, result DeployVerifyResult) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(w).Encode(result)
+	}
+
+	for _, svc := range result.Services {
+		switch {
+		case svc.Match:
+			fmt.Fprintf(w, "\033[32m✓\033[0m %s: %s\n", svc.Service, svc.Version)
+		case svc.Error != "":
+			fmt.Fprintf(w, "\033[31m✗\033[0m %s: unreachable (%s)\n", svc.Service, svc.Error)
+		default:
+			fmt.Fprintf(w, "\033[31m✗\033[0m %s: %s (expected %s)\n", svc.Service, svc.Version, result.ExpectedVersion)
+		}
+	}
+	return nil
+}
+
+// truncateCommit shortens a commit hash for table display.
+func truncateCommit(commit string) string {
+	if len(commit) > 10 {
+		return commit[:10]
+	}
+	return commit
+}
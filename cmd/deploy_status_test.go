@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+)
+
+// newFakeService serves /version with the given version and /health with the
+// given status code.
+func newFakeService(t *testing.T, name, version string, healthCode int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(buildinfo.Info{ServiceName: name, Version: version, Commit: "abc1234def5678"})
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(healthCode)
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBuildDeployStatus(t *testing.T) {
+	gateway := newFakeService(t, "penfold-gateway", "v0.9.0", http.StatusOK)
+	worker := newFakeService(t, "penfold-worker", "v0.9.0", http.StatusServiceUnavailable)
+	services := []DeployedService{
+		{Name: "penfold-gateway", URL: gateway.URL},
+		{Name: "penfold-worker", URL: worker.URL},
+	}
+	httpClient := &http.Client{Timeout: time.Second}
+
+	report := buildDeployStatus(context.Background(), httpClient, services)
+	if report.Health != deployHealthDegraded {
+		t.Errorf("Health = %q, want %q", report.Health, deployHealthDegraded)
+	}
+	if got := report.Services[0]; got.Version != "v0.9.0" || got.Commit != "abc1234def5678" || got.Health != deployHealthHealthy {
+		t.Errorf("gateway = %+v", got)
+	}
+	if got := report.Services[1]; got.Health != deployHealthUnhealthy || got.Error != "HTTP 503" {
+		t.Errorf("worker = %+v", got)
+	}
+
+	worker.Close()
+	report = buildDeployStatus(context.Background(), httpClient, services[1:])
+	if report.Health != deployHealthDown || report.Services[0].Health != deployHealthUnreachable {
+		t.Errorf("unreachable worker: health %q, service %+v", report.Health, report.Services[0])
+	}
+
+	var buf bytes.Buffer
+	if err := outputDeployStatus(&buf, config.OutputFormatJSON, report); err != nil {
+		t.Fatalf("outputDeployStatus() error = %v", err)
+	}
+	var decoded DeployStatusReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.Health != deployHealthDown || len(decoded.Services) != 1 {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
+
+func TestBuildDeployVerify(t *testing.T) {
+	gateway := newFakeService(t, "penfold-gateway", "v0.9.0", http.StatusOK)
+	worker := newFakeService(t, "penfold-worker", "v0.8.2", http.StatusOK)
+	httpClient := &http.Client{Timeout: time.Second}
+
+	result := buildDeployVerify(context.Background(), httpClient, []DeployedService{{Name: "penfold-gateway", URL: gateway.URL}}, "0.9.0")
+	if !result.Passed || !result.Services[0].Match {
+		t.Errorf("expected v0.9.0 to match 0.9.0: %+v", result)
+	}
+
+	result = buildDeployVerify(context.Background(), httpClient, []DeployedService{
+		{Name: "penfold-gateway", URL: gateway.URL},
+		{Name: "penfold-worker", URL: worker.URL},
+	}, "v0.9.0")
+	if result.Passed || result.Services[1].Match {
+		t.Errorf("expected the v0.8.2 worker to fail verification: %+v", result)
+	}
+
+	var buf bytes.Buffer
+	if err := outputDeployVerify(&buf, config.OutputFormatText, result); err != nil {
+		t.Fatalf("outputDeployVerify() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "penfold-worker: v0.8.2 (expected v0.9.0)") {
+		t.Errorf("text output = %q", buf.String())
+	}
+}
+
+func TestDeployStatusAndVerifySubcommands(t *testing.T) {
+	deployCmd := NewDeployCommand()
+
+	for _, name := range []string{"status", "verify"} {
+		sub, _, err := deployCmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Fatalf("%s subcommand not found", name)
+		}
+	}
+	verifyCmd, _, _ := deployCmd.Find([]string{"verify"})
+	if verifyCmd.Flags().Lookup("expect-version") == nil {
+		t.Error("--expect-version flag not found")
+	}
+}
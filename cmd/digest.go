@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	digestv1 "github.com/otherjamesbrown/penf-cli/api/proto/digest/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -136,7 +135,7 @@ func runDigestGenerate(ctx context.Context, deps *DigestCommandDeps, project str
 				"already_exists": true,
 				"digest_id":     resp.DigestId,
 			}
-			data, _ := yaml.Marshal(out)
+			data, _ := marshalYAML(out)
 			fmt.Print(string(data))
 		default:
 			fmt.Printf("Digest already exists for %s on %s (id: %s)\n", project, date, resp.DigestId)
@@ -159,7 +158,7 @@ func runDigestGenerate(ctx context.Context, deps *DigestCommandDeps, project str
 			"project":     project,
 			"date":        date,
 		}
-		data, _ := yaml.Marshal(out)
+		data, _ := marshalYAML(out)
 		fmt.Print(string(data))
 	default:
 		fmt.Printf("Digest generation started for %s on %s (workflow: %s)\n", project, date, resp.WorkflowId)
@@ -344,7 +343,7 @@ func runDigestList(ctx context.Context, deps *DigestCommandDeps) error {
 				"created_at":   d.CreatedAt,
 			}
 		}
-		data, _ := yaml.Marshal(items)
+		data, _ := marshalYAML(items)
 		fmt.Print(string(data))
 	default:
 		if len(resp.Digests) == 0 {
@@ -402,7 +401,7 @@ func outputDigestDetail(d *digestv1.DigestDetail, format config.OutputFormat) {
 			"model_used":   d.ModelUsed,
 			"created_at":   d.CreatedAt,
 		}
-		data, _ := yaml.Marshal(out)
+		data, _ := marshalYAML(out)
 		fmt.Print(string(data))
 	default:
 		fmt.Printf("Digest: %s\n", d.Id)
@@ -0,0 +1,344 @@
+// Package cmd provides CLI commands for the penf tool.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/contextpalace"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+)
+
+// Doctor check statuses.
+const (
+	doctorStatusOK   = "ok"
+	doctorStatusWarn = "warn"
+	doctorStatusFail = "fail"
+)
+
+// DoctorResult holds the outcome of all doctor checks.
+type DoctorResult struct {
+	Passed bool          `json:"passed"`
+	Checks []DoctorCheck `json:"checks"`
+}
+
+// DoctorCheck is the result of a single doctor check.
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, warn, fail
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+var (
+	doctorTimeout time.Duration
+	doctorJSON    bool
+)
+
+// NewDoctorCommand creates the doctor command.
+func NewDoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common setup problems",
+		Long: `Run a battery of checks against the local setup and report each problem
+with a one-line fix.
+
+Checks:
+  config            - config file exists and parses
+  tls certs         - client certificates load (or insecure mode is set)
+  tenant            - a tenant ID is configured
+  server            - server_address is set
+  dns/tcp/tls/gRPC  - the gateway resolves, accepts connections and is healthy
+  version           - the CLI version matches the gateway version
+  context-palace    - Context-Palace is reachable (only if configured)
+
+Warnings (⚠) do not affect the exit code.
+
+Exit codes:
+  0 - No check failed
+  1 - At least one check failed
+
+Examples:
+  penf doctor
+  penf doctor --json
+  penf doctor --server gateway.example.com:50051`,
+		RunE: runDoctor,
+	}
+
+	cmd.Flags().DurationVar(&doctorTimeout, "timeout", 5*time.Second, "Timeout for each network check")
+	cmd.Flags().BoolVar(&doctorJSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	configPath, _ := config.ConfigPath()
+	cfg, loadErr := config.LoadConfig()
+
+	// Honor the root --server and --insecure flags.
+	if cfg != nil {
+		root := cmd.Root()
+		if server, _ := root.PersistentFlags().GetString("server"); server != "" {
+			cfg.ServerAddress = server
+		}
+		if insecure, _ := root.PersistentFlags().GetBool("insecure"); insecure {
+			cfg.Insecure = true
+		}
+	}
+
+	result := runDoctorChecks(cmd.Context(), cfg, configPath, loadErr, doctorTimeout)
+
+	if doctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+	} else {
+		outputDoctorHuman(result)
+	}
+
+	if !result.Passed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runDoctorChecks runs every doctor check. Checks that depend on an earlier
+// failure (for example the gateway version when the gateway is unreachable)
+// are left out rather than reported as failures of their own.
+func runDoctorChecks(ctx context.Context, cfg *config.CLIConfig, configPath string, loadErr error, timeout time.Duration) DoctorResult {
+	result := DoctorResult{Passed: true}
+	add := func(check DoctorCheck) {
+		result.Checks = append(result.Checks, check)
+		if check.Status == doctorStatusFail {
+			result.Passed = false
+		}
+	}
+
+	add(doctorConfigCheck(configPath, loadErr))
+	if loadErr != nil || cfg == nil {
+		return result
+	}
+	for _, warning := range cfg.Warnings {
+		add(DoctorCheck{
+			Name:    "config",
+			Status:  doctorStatusWarn,
+			Message: warning,
+			Fix:     "export the variable or replace the reference with 'penf config set'",
+		})
+	}
+
+	add(doctorTLSCheck(cfg))
+	add(doctorTenantCheck(cfg))
+
+	if cfg.ServerAddress == "" {
+		add(DoctorCheck{
+			Name:    "server",
+			Status:  doctorStatusFail,
+			Message: "server_address is not set",
+			Fix:     "run 'penf config set server_address <host:port>' or 'penf init'",
+		})
+	} else {
+		add(DoctorCheck{Name: "server", Status: doctorStatusOK, Message: cfg.ServerAddress})
+
+		connectivity := runConnectivityChecks(ctx, cfg, timeout)
+		for _, check := range doctorConnectivityChecks(connectivity) {
+			add(check)
+		}
+
+		if connectivity.Passed {
+			httpClient := &http.Client{Timeout: timeout}
+			gateway, err := buildinfo.Fetch(ctx, httpClient, gatewayBaseURL(cfg.ServerAddress))
+			add(doctorVersionCheck(buildinfo.Version, gateway, err))
+		}
+	}
+
+	if cfg.ContextPalace.IsConfigured() {
+		add(doctorContextPalaceCheck(ctx, cfg.ContextPalace, timeout))
+	}
+
+	return result
+}
+
+// doctorConfigCheck reports whether the config file exists and parses.
+func doctorConfigCheck(configPath string, loadErr error) DoctorCheck {
+	check := DoctorCheck{Name: "config"}
+	switch {
+	case loadErr != nil:
+		check.Status = doctorStatusFail
+		check.Message = loadErr.Error()
+		check.Fix = fmt.Sprintf("fix the YAML in %s or recreate it with 'penf config init'", configPath)
+	case configPath == "":
+		check.Status = doctorStatusWarn
+		check.Message = "could not determine config file location"
+		check.Fix = "make sure $HOME is set"
+	default:
+		if _, err := os.Stat(configPath); err != nil {
+			check.Status = doctorStatusWarn
+			check.Message = fmt.Sprintf("%s not found, using defaults", configPath)
+			check.Fix = "run 'penf init' to create it"
+		} else {
+			check.Status = doctorStatusOK
+			check.Message = configPath
+		}
+	}
+	return check
+}
+
+// doctorTLSCheck reports whether the client certificates load.
+func doctorTLSCheck(cfg *config.CLIConfig) DoctorCheck {
+	check := DoctorCheck{Name: "tls certs"}
+	switch {
+	case cfg.Insecure:
+		check.Status = doctorStatusOK
+		check.Message = "insecure mode set, TLS disabled"
+	case !cfg.TLS.Enabled:
+		check.Status = doctorStatusWarn
+		check.Message = "TLS not enabled, connections are unencrypted"
+		check.Fix = "run 'penf cert init' or set 'insecure: true' if plaintext is intended"
+	default:
+		tlsCfg := cfg.TLS
+		if err := client.CheckCertsExist(&tlsCfg); err != nil {
+			check.Status = doctorStatusFail
+			check.Message = err.Error()
+			check.Fix = "install certificates with 'penf cert init' or fix the tls.* paths in your config"
+			return check
+		}
+		if _, err := client.LoadClientTLSConfig(&tlsCfg); err != nil {
+			check.Status = doctorStatusFail
+			check.Message = err.Error()
+			check.Fix = "check the certificates with 'penf cert verify'"
+			return check
+		}
+		check.Status = doctorStatusOK
+		check.Message = "loaded " + tlsCfg.ClientCert
+	}
+	return check
+}
+
+// doctorTenantCheck reports whether a tenant ID is configured.
+func doctorTenantCheck(cfg *config.CLIConfig) DoctorCheck {
+	tenant, err := resolveTenantID(cfg)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "tenant",
+			Status:  doctorStatusFail,
+			Message: "no tenant ID configured",
+			Fix:     "run 'penf tenant switch <tenant>' or set PENF_TENANT_ID",
+		}
+	}
+	return DoctorCheck{Name: "tenant", Status: doctorStatusOK, Message: tenant}
+}
+
+// doctorConnectivityChecks converts the connectivity stages into doctor checks.
+// Skipped stages are dropped: the TLS stage is covered by doctorTLSCheck and
+// the others are only skipped after an earlier stage has failed.
+func doctorConnectivityChecks(result ConnectivityResult) []DoctorCheck {
+	var checks []DoctorCheck
+	for _, stage := range result.Stages {
+		name := stage.Name
+		if name == connectivityStageGRPC {
+			name = "gRPC"
+		}
+		switch stage.Status {
+		case "passed":
+			checks = append(checks, DoctorCheck{Name: name, Status: doctorStatusOK, Message: stage.Detail})
+		case "failed":
+			checks = append(checks, DoctorCheck{Name: name, Status: doctorStatusFail, Message: stage.Error, Fix: stage.Hint})
+		}
+	}
+	return checks
+}
+
+// doctorContextPalaceCheck reports whether Context-Palace is reachable. It is
+// a warning rather than a failure because command logging is best-effort.
+func doctorContextPalaceCheck(ctx context.Context, cpCfg *config.ContextPalaceConfig, timeout time.Duration) DoctorCheck {
+	check := DoctorCheck{Name: "context-palace"}
+
+	cp, err := contextpalace.NewClient(cpCfg)
+	if err == nil {
+		defer cp.Close()
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = cp.Ping(pingCtx)
+		cancel()
+	}
+	if err != nil {
+		check.Status = doctorStatusWarn
+		check.Message = err.Error()
+		check.Fix = "check the context_palace settings in your config, or remove them to disable command logging"
+		return check
+	}
+
+	check.Status = doctorStatusOK
+	check.Message = fmt.Sprintf("connected to %s/%s", cpCfg.Host, cpCfg.Database)
+	return check
+}
+
+// doctorVersionCheck compares the CLI version with the gateway's /version.
+func doctorVersionCheck(cliVersion string, gateway buildinfo.Info, fetchErr error) DoctorCheck {
+	check := DoctorCheck{Name: "version"}
+	switch {
+	case fetchErr != nil:
+		check.Status = doctorStatusWarn
+		check.Message = "could not read gateway version: " + fetchErr.Error()
+		check.Fix = "run 'penf version --all' to compare versions manually"
+	case cliVersion == "dev":
+		check.Status = doctorStatusWarn
+		check.Message = fmt.Sprintf("development build, gateway is %s", gateway.Version)
+		check.Fix = "install a release build with 'penf update' to compare versions"
+	case strings.TrimPrefix(cliVersion, "v") != strings.TrimPrefix(gateway.Version, "v"):
+		check.Status = doctorStatusWarn
+		check.Message = fmt.Sprintf("CLI %s does not match gateway %s", cliVersion, gateway.Version)
+		check.Fix = "run 'penf update' to install the matching CLI"
+	default:
+		check.Status = doctorStatusOK
+		check.Message = fmt.Sprintf("CLI and gateway both %s", cliVersion)
+	}
+	return check
+}
+
+// gatewayBaseURL returns the gateway's HTTP endpoint for a gRPC server address.
+func gatewayBaseURL(serverAddr string) string {
+	gatewayURL, _ := deriveHealthURLs(serverAddr)
+	return strings.TrimSuffix(gatewayURL, "/health")
+}
+
+// outputDoctorHuman outputs doctor results in human-readable format.
+func outputDoctorHuman(result DoctorResult) {
+	var warnings, failures int
+	for _, check := range result.Checks {
+		switch check.Status {
+		case doctorStatusOK:
+			fmt.Printf("\033[32m✓\033[0m %s: %s\n", check.Name, check.Message)
+		case doctorStatusWarn:
+			warnings++
+			fmt.Printf("\033[33m⚠\033[0m %s: %s\n", check.Name, check.Message)
+		default:
+			failures++
+			fmt.Printf("\033[31m✗\033[0m %s: %s\n", check.Name, check.Message)
+		}
+		if check.Fix != "" && check.Status != doctorStatusOK {
+			fmt.Printf("  Fix: %s\n", check.Fix)
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case failures > 0:
+		fmt.Printf("\033[31m%d check(s) failed\033[0m, %d warning(s)\n", failures, warnings)
+	case warnings > 0:
+		fmt.Printf("\033[33mNo failures\033[0m, %d warning(s)\n", warnings)
+	default:
+		fmt.Println("\033[32mAll checks passed\033[0m")
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/buildinfo"
+)
+
+func TestDoctorConfigCheck(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(existing, []byte("server_address: localhost:50051\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		loadErr error
+		want    string
+	}{
+		{"exists", existing, nil, doctorStatusOK},
+		{"missing", filepath.Join(dir, "missing.yaml"), nil, doctorStatusWarn},
+		{"parse error", existing, errors.New("yaml: line 1: did not find expected key"), doctorStatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := doctorConfigCheck(tt.path, tt.loadErr)
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", check.Status, tt.want, check.Message)
+			}
+			if check.Status != doctorStatusOK && check.Fix == "" {
+				t.Error("expected a fix suggestion")
+			}
+		})
+	}
+}
+
+func TestDoctorTLSCheck(t *testing.T) {
+	cfg := &config.CLIConfig{Insecure: true}
+	if check := doctorTLSCheck(cfg); check.Status != doctorStatusOK {
+		t.Errorf("insecure: status = %q, want ok", check.Status)
+	}
+
+	cfg = &config.CLIConfig{}
+	if check := doctorTLSCheck(cfg); check.Status != doctorStatusWarn {
+		t.Errorf("TLS disabled: status = %q, want warn", check.Status)
+	}
+
+	dir := t.TempDir()
+	cfg = &config.CLIConfig{TLS: config.TLSConfig{Enabled: true, CertDir: dir}}
+	check := doctorTLSCheck(cfg)
+	if check.Status != doctorStatusFail {
+		t.Errorf("missing certs: status = %q, want fail", check.Status)
+	}
+	if cfg.TLS.CACert != "" {
+		t.Error("doctorTLSCheck should not modify the config")
+	}
+}
+
+func TestDoctorTenantCheck(t *testing.T) {
+	t.Setenv("PENF_TENANT_ID", "")
+
+	if check := doctorTenantCheck(&config.CLIConfig{}); check.Status != doctorStatusFail {
+		t.Errorf("no tenant: status = %q, want fail", check.Status)
+	}
+	check := doctorTenantCheck(&config.CLIConfig{TenantID: "acme"})
+	if check.Status != doctorStatusOK || check.Message != "acme" {
+		t.Errorf("tenant set: got %+v", check)
+	}
+}
+
+func TestDoctorVersionCheck(t *testing.T) {
+	gateway := buildinfo.Info{ServiceName: "penfold-gateway", Version: "v0.9.0"}
+
+	tests := []struct {
+		name string
+		cli  string
+		err  error
+		want string
+	}{
+		{"match", "v0.9.0", nil, doctorStatusOK},
+		{"match without prefix", "0.9.0", nil, doctorStatusOK},
+		{"mismatch", "v0.8.2", nil, doctorStatusWarn},
+		{"dev build", "dev", nil, doctorStatusWarn},
+		{"unreachable", "v0.9.0", errors.New("connection refused"), doctorStatusWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := doctorVersionCheck(tt.cli, gateway, tt.err)
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", check.Status, tt.want, check.Message)
+			}
+		})
+	}
+}
+
+func TestDoctorConnectivityChecks_DropsSkippedStages(t *testing.T) {
+	checks := doctorConnectivityChecks(ConnectivityResult{
+		Stages: []ConnectivityStage{
+			{Name: connectivityStageDNS, Status: "passed", Detail: "resolved"},
+			{Name: connectivityStageTCP, Status: "failed", Error: "connection refused", Hint: "check the port"},
+			{Name: connectivityStageTLS, Status: "skipped"},
+			{Name: connectivityStageGRPC, Status: "skipped"},
+		},
+	})
+
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d: %+v", len(checks), checks)
+	}
+	if checks[1].Status != doctorStatusFail || checks[1].Fix != "check the port" {
+		t.Errorf("tcp check = %+v", checks[1])
+	}
+}
+
+func TestRunDoctorChecks_MissingServerAddress(t *testing.T) {
+	t.Setenv("PENF_TENANT_ID", "")
+	cfg := &config.CLIConfig{Insecure: true, TenantID: "acme"}
+
+	result := runDoctorChecks(context.Background(), cfg, filepath.Join(t.TempDir(), "config.yaml"), nil, time.Second)
+
+	if result.Passed {
+		t.Error("expected doctor to fail without a server address")
+	}
+	var server *DoctorCheck
+	for i := range result.Checks {
+		if result.Checks[i].Name == "server" {
+			server = &result.Checks[i]
+		}
+		if result.Checks[i].Name == "version" {
+			t.Error("version check should not run without a server address")
+		}
+	}
+	if server == nil || server.Status != doctorStatusFail {
+		t.Errorf("server check = %+v, want fail", server)
+	}
+}
+
+func TestRunDoctorChecks_ConfigLoadErrorStopsEarly(t *testing.T) {
+	result := runDoctorChecks(context.Background(), nil, "/tmp/config.yaml", errors.New("bad yaml"), time.Second)
+
+	if result.Passed {
+		t.Error("expected failure")
+	}
+	if len(result.Checks) != 1 || result.Checks[0].Name != "config" {
+		t.Errorf("expected only the config check, got %+v", result.Checks)
+	}
+}
+
+func TestOutputDoctorHuman(t *testing.T) {
+	out := captureStdout(func() {
+		outputDoctorHuman(DoctorResult{Checks: []DoctorCheck{
+			{Name: "config", Status: doctorStatusOK, Message: "/home/u/.penf/config.yaml"},
+			{Name: "version", Status: doctorStatusWarn, Message: "CLI v0.8.2 does not match gateway v0.9.0", Fix: "run 'penf update'"},
+			{Name: "tenant", Status: doctorStatusFail, Message: "no tenant ID configured", Fix: "run 'penf tenant switch <tenant>'"},
+		}})
+	})
+
+	for _, want := range []string{"✓\033[0m config", "⚠\033[0m version", "✗\033[0m tenant", "Fix: run 'penf update'", "1 check(s) failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -165,12 +165,12 @@ Examples:
 	cmd.Flags().StringVar(&entityNamePattern, "name-pattern", "", "Name pattern for bulk rejection (SQL LIKE format)")
 	cmd.MarkFlagRequired("reason")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newEntityRestoreCommand creates the 'entity restore' subcommand.
 func newEntityRestoreCommand(deps *EntityCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "restore <entity-id>",
 		Short: "Restore a rejected entity",
 		Long: `Restore a previously rejected entity.
@@ -188,7 +188,7 @@ Examples:
 			}
 			return runEntityRestore(cmd.Context(), deps, id)
 		},
-	}
+	})
 }
 
 // newEntityManagementDeleteCommand creates the 'entity delete' subcommand.
@@ -223,7 +223,7 @@ The --force flag skips the confirmation prompt for scripting purposes.`,
 
 	cmd.Flags().BoolVar(&entityForce, "force", false, "Skip confirmation prompt")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newEntityFilterCommand creates the 'entity filter' subcommand group.
@@ -327,7 +327,7 @@ Examples:
 	cmd.MarkFlagRequired("pattern")
 	cmd.MarkFlagRequired("type")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newEntityPatternListCommand creates the 'entity pattern list' subcommand.
@@ -353,7 +353,7 @@ Examples:
 
 // newEntityPatternRemoveCommand creates the 'entity pattern remove' subcommand.
 func newEntityPatternRemoveCommand(deps *EntityCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove <pattern-id>",
 		Short: "Remove an email pattern",
 		Long: `Remove a tenant email pattern by ID.
@@ -371,7 +371,7 @@ Examples:
 			}
 			return runEntityPatternRemove(cmd.Context(), deps, patternID)
 		},
-	}
+	})
 }
 
 // newEntityFilterAddCommand creates the 'entity filter add' subcommand.
@@ -405,7 +405,7 @@ Examples:
 	cmd.Flags().StringVar(&entityCreatedBy, "created-by", "", "User or service creating the rule")
 	cmd.MarkFlagRequired("reason")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newEntityFilterListCommand creates the 'entity filter list' subcommand.
@@ -456,7 +456,7 @@ Examples:
 
 // newEntityFilterRemoveCommand creates the 'entity filter remove' subcommand.
 func newEntityFilterRemoveCommand(deps *EntityCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove <rule-id>",
 		Short: "Remove a filter rule",
 		Long: `Remove an entity filter rule by ID.
@@ -474,7 +474,7 @@ Examples:
 			}
 			return runEntityFilterRemove(cmd.Context(), deps, ruleID)
 		},
-	}
+	})
 }
 
 // newEntityStatsCommand creates the 'entity stats' subcommand.
@@ -532,7 +532,7 @@ Examples:
 // ==================== gRPC Connection ====================
 
 func connectEntityToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -557,9 +557,11 @@ func connectEntityToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -609,6 +611,10 @@ func runEntityReject(ctx context.Context, deps *EntityCommandDeps, entityID int6
 		return fmt.Errorf("rejecting entity: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{EntityID: entityID, Action: "reject", Reason: entityReason}, fmt.Sprintf("Rejected entity ID %d", entityID))
+	}
+
 	fmt.Printf("Rejected entity ID %d: %s\n", entityID, entityReason)
 	return nil
 }
@@ -640,6 +646,10 @@ func runEntityRestore(ctx context.Context, deps *EntityCommandDeps, entityID int
 		return fmt.Errorf("restoring entity: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{EntityID: entityID, Action: "restore"}, fmt.Sprintf("Restored entity ID %d", entityID))
+	}
+
 	fmt.Printf("Restored entity ID %d\n", entityID)
 	return nil
 }
@@ -651,6 +661,14 @@ func runEntityManagementDelete(ctx context.Context, deps *EntityCommandDeps, ent
 	}
 	deps.Config = cfg
 
+	tenantID, err := getTenantIDForEntity(deps)
+	if err != nil {
+		return err
+	}
+	if err := guardDestructiveTenant(cfg, tenantID); err != nil {
+		return err
+	}
+
 	// Prompt for confirmation unless --force is set
 	if !entityForce {
 		fmt.Printf("WARNING: This will permanently delete entity ID %d and all related records.\n", entityID)
@@ -672,10 +690,6 @@ func runEntityManagementDelete(ctx context.Context, deps *EntityCommandDeps, ent
 	defer conn.Close()
 
 	client := entityv1.NewEntityManagementServiceClient(conn)
-	tenantID, err := getTenantIDForEntity(deps)
-	if err != nil {
-		return err
-	}
 
 	resp, err := client.DeleteEntity(ctx, &entityv1.DeleteEntityRequest{
 		TenantId: tenantID,
@@ -685,6 +699,10 @@ func runEntityManagementDelete(ctx context.Context, deps *EntityCommandDeps, ent
 		return fmt.Errorf("deleting entity: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{EntityID: resp.EntityId, Action: "delete"}, resp.Message)
+	}
+
 	fmt.Printf("Deleted entity ID %d: %s\n", resp.EntityId, resp.Message)
 	return nil
 }
@@ -719,6 +737,10 @@ func runEntityBulkReject(ctx context.Context, deps *EntityCommandDeps) error {
 		return fmt.Errorf("bulk rejecting entities: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{Action: "reject", Reason: entityReason, Count: resp.Count}, fmt.Sprintf("Rejected %d entities", resp.Count))
+	}
+
 	fmt.Printf("Rejected %d entities: %s\n", resp.Count, entityReason)
 	return nil
 }
@@ -758,6 +780,10 @@ func runEntityFilterAdd(ctx context.Context, deps *EntityCommandDeps) error {
 		return fmt.Errorf("creating filter rule: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Rule, fmt.Sprintf("Created filter rule ID %d", resp.Rule.Id))
+	}
+
 	fmt.Printf("Created filter rule ID %d\n", resp.Rule.Id)
 	if entityEmailPattern != "" {
 		fmt.Printf("  Email pattern: %s\n", entityEmailPattern)
@@ -863,6 +889,10 @@ func runEntityFilterRemove(ctx context.Context, deps *EntityCommandDeps, ruleID
 		return fmt.Errorf("deleting filter rule: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{RuleID: ruleID, Action: "delete-filter-rule"}, fmt.Sprintf("Deleted filter rule ID %d", ruleID))
+	}
+
 	fmt.Printf("Deleted filter rule ID %d\n", ruleID)
 	return nil
 }
@@ -914,6 +944,10 @@ func runEntityPatternAdd(ctx context.Context, deps *EntityCommandDeps) error {
 		return fmt.Errorf("creating email pattern: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Pattern, fmt.Sprintf("Created email pattern ID %d", resp.Pattern.Id))
+	}
+
 	fmt.Printf("Created email pattern ID %d\n", resp.Pattern.Id)
 	fmt.Printf("  Pattern: %s\n", entityPatternValue)
 	fmt.Printf("  Type: %s\n", entityPatternType)
@@ -992,6 +1026,10 @@ func runEntityPatternRemove(ctx context.Context, deps *EntityCommandDeps, patter
 		return fmt.Errorf("deleting email pattern: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{PatternID: patternID, Action: "delete-pattern"}, fmt.Sprintf("Deleted email pattern ID %d", patternID))
+	}
+
 	fmt.Printf("Deleted email pattern ID %d\n", patternID)
 	return nil
 }
@@ -1093,7 +1131,7 @@ You can update name, account type, title, company, and metadata.`,
 	cmd.Flags().StringVar(&entityCompany, "company", "", "New company name")
 	cmd.Flags().StringToStringVar(&entityMetadata, "metadata", nil, "Metadata key=value pairs")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newEntityManagementBulkEnrichCommand creates the 'entity bulk-enrich' subcommand.
@@ -1122,7 +1160,7 @@ This command updates all entities with email addresses in the specified domain.`
 	cmd.Flags().BoolVar(&entityIsInternal, "internal", false, "Mark entities as internal")
 	cmd.MarkFlagRequired("domain")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runEntityManagementUpdate(ctx context.Context, deps *EntityCommandDeps, entityID int64) error {
@@ -1174,6 +1212,10 @@ func runEntityManagementUpdate(ctx context.Context, deps *EntityCommandDeps, ent
 		return fmt.Errorf("updating entity: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{EntityID: resp.EntityId, Action: "update"}, resp.Message)
+	}
+
 	fmt.Printf("Updated entity ID %d: %s\n", resp.EntityId, resp.Message)
 	return nil
 }
@@ -1207,6 +1249,10 @@ func runEntityManagementBulkEnrich(ctx context.Context, deps *EntityCommandDeps)
 		return fmt.Errorf("bulk enriching entities: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, EntityActionResult{Action: "bulk-enrich", Domain: entityDomain, Count: resp.Count}, fmt.Sprintf("Enriched %d entities in domain %s", resp.Count, entityDomain))
+	}
+
 	fmt.Printf("Enriched %d entities in domain %s\n", resp.Count, entityDomain)
 	if entityCompany != "" {
 		fmt.Printf("  Set company: %s\n", entityCompany)
@@ -1219,6 +1265,18 @@ func runEntityManagementBulkEnrich(ctx context.Context, deps *EntityCommandDeps)
 
 // ==================== Output Functions ====================
 
+// EntityActionResult is the JSON result of an entity action that returns no
+// record of its own.
+type EntityActionResult struct {
+	EntityID  int64  `json:"entity_id,omitempty"`
+	RuleID    int64  `json:"rule_id,omitempty"`
+	PatternID int64  `json:"pattern_id,omitempty"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+	Count     int32  `json:"count,omitempty"`
+}
+
 func getEntityOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if entityOutput != "" {
 		return config.OutputFormat(entityOutput)
@@ -1297,8 +1355,8 @@ func outputEntityStatsText(stats *entityv1.GetEntityStatsResponse) error {
 	fmt.Println()
 
 	fmt.Println("By Account Type:")
-	for accountType, count := range stats.ByAccountType {
-		fmt.Printf("  %-20s %d\n", accountType, count)
+	for _, accountType := range orderedKeys(stats.ByAccountType, nil) {
+		fmt.Printf("  %-20s %d\n", accountType, stats.ByAccountType[accountType])
 	}
 	fmt.Println()
 
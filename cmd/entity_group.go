@@ -72,7 +72,7 @@ Examples:
 
 	cmd.Flags().StringVar(&groupSource, "source", "manual", "Source of the membership (e.g., manual, pipeline)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newGroupListCommand creates 'entity group list'.
@@ -101,7 +101,7 @@ Examples:
 
 // newGroupRemoveCommand creates 'entity group remove'.
 func newGroupRemoveCommand(deps *EntityCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove <group> <member>",
 		Short: "Remove a member from a group",
 		Long: `Remove a person entity from a group (distribution list) entity.
@@ -117,7 +117,7 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGroupRemove(cmd.Context(), deps, args[0], args[1])
 		},
-	}
+	})
 }
 
 // newGroupListAllCommand creates 'entity group ls'.
@@ -178,6 +178,11 @@ func runGroupAdd(ctx context.Context, deps *EntityCommandDeps, groupRef, memberR
 		return fmt.Errorf("adding group member: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, GroupMemberResult{GroupID: groupID, MemberID: memberID, MembershipID: resp.Id, Action: "add"},
+			fmt.Sprintf("Added member %d to group %d", memberID, groupID))
+	}
+
 	fmt.Printf("Added member %d to group %d (membership ID: %d)\n", memberID, groupID, resp.Id)
 	return nil
 }
@@ -256,6 +261,14 @@ func runGroupRemove(ctx context.Context, deps *EntityCommandDeps, groupRef, memb
 		return fmt.Errorf("removing group member: %w", err)
 	}
 
+	if getEntityOutputFormat(cfg) == config.OutputFormatJSON {
+		msg := fmt.Sprintf("Removed member %d from group %d", memberID, groupID)
+		if !resp.Removed {
+			msg = fmt.Sprintf("Member %d was not found in group %d", memberID, groupID)
+		}
+		return writeOutcomeJSON(resp.Removed, GroupMemberResult{GroupID: groupID, MemberID: memberID, Action: "remove", Removed: resp.Removed}, msg)
+	}
+
 	if resp.Removed {
 		fmt.Printf("Removed member %d from group %d\n", memberID, groupID)
 	} else {
@@ -264,6 +277,15 @@ func runGroupRemove(ctx context.Context, deps *EntityCommandDeps, groupRef, memb
 	return nil
 }
 
+// GroupMemberResult is the JSON result of an entity group add or remove.
+type GroupMemberResult struct {
+	GroupID      int64  `json:"group_id"`
+	MemberID     int64  `json:"member_id"`
+	MembershipID int64  `json:"membership_id,omitempty"`
+	Action       string `json:"action"`
+	Removed      bool   `json:"removed,omitempty"`
+}
+
 func runGroupListAll(ctx context.Context, deps *EntityCommandDeps) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -50,17 +51,30 @@ The bug report will include:
 - CLI version and platform information
 - Last command output (if available)
 
+With --attach-logs, the report also includes the server's version and
+health and its most recent log lines. With --attach-command, the given
+command is run and its output and exit status are included. Secrets and
+hostnames are redacted from attachments, and you are shown them and asked
+to confirm before anything is submitted (use --yes to skip the prompt).
+
 Examples:
   penf feedback bug "Search crashes when query contains special characters"
-  penf feedback bug --title "Search crash" "Crashes with special chars"`,
+  penf feedback bug --title "Search crash" "Crashes with special chars"
+  penf feedback bug --attach-logs "Ingest stalls after upload"
+  penf feedback bug --attach-command "penf search 'a&b'" "Search fails on ampersands"
+  penf feedback bug --attach-logs --dry-run "Preview what would be sent"`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFeedback("bug", currentVersion, args)
+			return runFeedback(cmd.Context(), "bug", currentVersion, args)
 		},
 	}
 	bugCmd.Flags().StringVarP(&feedbackTitle, "title", "t", "", "Custom issue title")
 	bugCmd.Flags().BoolVar(&feedbackDryRun, "dry-run", false, "Show what would be submitted without creating issue")
 	bugCmd.Flags().StringVar(&feedbackContext, "context", "", "Additional context or error output")
+	bugCmd.Flags().BoolVar(&feedbackAttachLogs, "attach-logs", false, "Attach recent log lines and version/health info")
+	bugCmd.Flags().IntVar(&feedbackLogLines, "log-lines", 50, "Number of log lines to attach with --attach-logs")
+	bugCmd.Flags().StringVar(&feedbackAttachCommand, "attach-command", "", "Run this command and attach its output")
+	bugCmd.Flags().BoolVarP(&feedbackYes, "yes", "y", false, "Submit attachments without asking for confirmation")
 
 	// Feature subcommand.
 	featureCmd := &cobra.Command{
@@ -73,7 +87,7 @@ Examples:
   penf feedback feature --title "Notion import" "Import pages from Notion"`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFeedback("feature", currentVersion, args)
+			return runFeedback(cmd.Context(), "feature", currentVersion, args)
 		},
 	}
 	featureCmd.Flags().StringVarP(&feedbackTitle, "title", "t", "", "Custom issue title")
@@ -85,7 +99,7 @@ Examples:
 	return feedbackCmd
 }
 
-func runFeedback(feedbackType, currentVersion string, args []string) error {
+func runFeedback(ctx context.Context, feedbackType, currentVersion string, args []string) error {
 	description := strings.Join(args, " ")
 
 	// Determine title.
@@ -100,8 +114,21 @@ func runFeedback(feedbackType, currentVersion string, args []string) error {
 		}
 	}
 
+	// Collect diagnostics.
+	var attachments []feedbackAttachment
+	if feedbackAttachLogs || feedbackAttachCommand != "" {
+		if feedbackLogLines <= 0 {
+			return fmt.Errorf("--log-lines must be positive")
+		}
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		fmt.Println("Collecting diagnostics...")
+		attachments = collectFeedbackAttachments(ctx, currentVersion)
+	}
+
 	// Build issue body.
-	body := buildIssueBody(feedbackType, description, currentVersion)
+	body := buildIssueBody(feedbackType, description, currentVersion, attachments)
 
 	// Determine labels.
 	var labels []string
@@ -123,6 +150,16 @@ func runFeedback(feedbackType, currentVersion string, args []string) error {
 		return nil
 	}
 
+	// Attachments may hold sensitive data the redactor missed.
+	ok, err := confirmFeedbackAttachments(attachments, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Cancelled; nothing was submitted.")
+		return nil
+	}
+
 	// Check if gh CLI is available.
 	if !isGhCliAvailable() {
 		return fmt.Errorf("the 'gh' CLI is required but not found or not authenticated\n\n" +
@@ -144,7 +181,7 @@ func runFeedback(feedbackType, currentVersion string, args []string) error {
 }
 
 // buildIssueBody constructs the GitHub issue body.
-func buildIssueBody(feedbackType, description, version string) string {
+func buildIssueBody(feedbackType, description, version string, attachments []feedbackAttachment) string {
 	var sb strings.Builder
 
 	if feedbackType == "bug" {
@@ -169,6 +206,11 @@ func buildIssueBody(feedbackType, description, version string) string {
 	sb.WriteString(fmt.Sprintf("- **OS/Platform:** %s/%s\n", runtime.GOOS, runtime.GOARCH))
 	sb.WriteString(fmt.Sprintf("- **Go version:** %s\n", runtime.Version()))
 
+	if len(attachments) > 0 {
+		sb.WriteString("\n### Diagnostics\n\n")
+		writeFeedbackAttachments(&sb, attachments)
+	}
+
 	sb.WriteString("\n---\n")
 	sb.WriteString("*Submitted via `penf feedback`*\n")
 
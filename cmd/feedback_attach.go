@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Feedback attachment flags.
+var (
+	feedbackAttachLogs    bool
+	feedbackLogLines      int
+	feedbackAttachCommand string
+	feedbackYes           bool
+)
+
+const (
+	// feedbackAttachTimeout bounds each piece of diagnostic collection.
+	feedbackAttachTimeout = 10 * time.Second
+	// feedbackCommandTimeout bounds an --attach-command run.
+	feedbackCommandTimeout = 2 * time.Minute
+	// feedbackAttachmentMaxLen keeps attachments well inside GitHub's
+	// 65536-character issue body limit. Longer output keeps its tail, where
+	// errors usually are.
+	feedbackAttachmentMaxLen = 20000
+)
+
+// feedbackAttachment is a block of diagnostic output added to an issue.
+type feedbackAttachment struct {
+	Title   string
+	Content string
+}
+
+// collectFeedbackAttachments gathers the diagnostics requested by
+// --attach-logs and --attach-command, redacted with the debug redactor.
+func collectFeedbackAttachments(ctx context.Context, version string) []feedbackAttachment {
+	cfg, _ := config.LoadConfig()
+	redactor := newDebugRedactor(cfg, true, true)
+
+	var attachments []feedbackAttachment
+	if feedbackAttachLogs {
+		attachments = append(attachments, feedbackHealthAttachment(ctx, cfg, version))
+		attachments = append(attachments, feedbackLogsAttachment(ctx, cfg, feedbackLogLines))
+	}
+	if feedbackAttachCommand != "" {
+		attachments = append(attachments, feedbackCommandAttachment(ctx, feedbackAttachCommand))
+	}
+
+	for i := range attachments {
+		attachments[i].Content = truncateAttachment(redactor.String(attachments[i].Content), feedbackAttachmentMaxLen)
+	}
+	return attachments
+}
+
+// feedbackHealthAttachment summarizes client and server versions and health.
+func feedbackHealthAttachment(ctx context.Context, cfg *config.CLIConfig, version string) feedbackAttachment {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "penf %s (%s/%s)\n", version, runtime.GOOS, runtime.GOARCH)
+	if cfg == nil {
+		sb.WriteString("health: configuration not loaded\n")
+		return feedbackAttachment{Title: "Version and health", Content: sb.String()}
+	}
+	fmt.Fprintf(&sb, "server: %s\n", cfg.ServerAddress)
+
+	grpcClient, err := client.ConnectFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(&sb, "health: %v\n", err)
+		return feedbackAttachment{Title: "Version and health", Content: sb.String()}
+	}
+	defer grpcClient.Close()
+
+	statusCtx, cancel := context.WithTimeout(ctx, feedbackAttachTimeout)
+	defer cancel()
+	status, err := grpcClient.GetStatus(statusCtx, true)
+	if err != nil {
+		fmt.Fprintf(&sb, "health: %v\n", err)
+		return feedbackAttachment{Title: "Version and health", Content: sb.String()}
+	}
+	writeFeedbackHealth(&sb, status)
+	return feedbackAttachment{Title: "Version and health", Content: sb.String()}
+}
+
+// writeFeedbackHealth writes a compact health summary for an issue.
+func writeFeedbackHealth(w io.Writer, status *client.SystemStatus) {
+	if status.Version != nil {
+		fmt.Fprintf(w, "server version: %s (%s)\n", status.Version.Version, status.Version.Commit)
+	}
+	fmt.Fprintf(w, "healthy: %t", status.Healthy)
+	if status.Message != "" {
+		fmt.Fprintf(w, " (%s)", status.Message)
+	}
+	fmt.Fprintln(w)
+	for _, svc := range status.Services {
+		fmt.Fprintf(w, "  %-20s %-10s %s", svc.Name, svc.Status, svc.Version)
+		if svc.Message != "" {
+			fmt.Fprintf(w, " %s", svc.Message)
+		}
+		fmt.Fprintln(w)
+	}
+	if status.Database != nil {
+		fmt.Fprintf(w, "database: healthy=%t connection=%s\n", status.Database.Healthy, status.Database.ConnectionStatus)
+	}
+}
+
+// feedbackLogsAttachment fetches the most recent log lines from the gateway.
+func feedbackLogsAttachment(ctx context.Context, cfg *config.CLIConfig, lines int) feedbackAttachment {
+	title := fmt.Sprintf("Last %d log lines", lines)
+	if cfg == nil {
+		return feedbackAttachment{Title: title, Content: "configuration not loaded"}
+	}
+
+	grpcClient, err := client.ConnectFromConfig(cfg)
+	if err != nil {
+		return feedbackAttachment{Title: title, Content: err.Error()}
+	}
+	defer grpcClient.Close()
+
+	logsCtx, cancel := context.WithTimeout(ctx, feedbackAttachTimeout)
+	defer cancel()
+	resp, err := grpcClient.ListLogs(logsCtx, client.LogFilter{}, lines, 0, false)
+	if err != nil {
+		return feedbackAttachment{Title: title, Content: err.Error()}
+	}
+	return feedbackAttachment{Title: title, Content: formatFeedbackLogs(resp.Entries)}
+}
+
+// formatFeedbackLogs renders newest-first log entries oldest-first, one per
+// line.
+func formatFeedbackLogs(entries []client.LogEntry) string {
+	var sb strings.Builder
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		fmt.Fprintf(&sb, "%s %-5s %s: %s\n", e.Timestamp.UTC().Format(time.RFC3339), strings.ToUpper(e.Level), e.Service, e.Message)
+	}
+	if sb.Len() == 0 {
+		return "(no log entries)"
+	}
+	return sb.String()
+}
+
+// feedbackCommandAttachment runs command through the shell and captures its
+// combined output and exit status.
+func feedbackCommandAttachment(ctx context.Context, command string) feedbackAttachment {
+	runCtx, cancel := context.WithTimeout(ctx, feedbackCommandTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(runCtx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(runCtx, "sh", "-c", command)
+	}
+	out, err := c.CombinedOutput()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "$ %s\n", command)
+	sb.Write(out)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		sb.WriteByte('\n')
+	}
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		fmt.Fprintf(&sb, "[timed out after %s]\n", feedbackCommandTimeout)
+	case err != nil:
+		fmt.Fprintf(&sb, "[%v]\n", err)
+	default:
+		sb.WriteString("[exit status 0]\n")
+	}
+	return feedbackAttachment{Title: "Command output", Content: sb.String()}
+}
+
+// truncateAttachment keeps the last maxLen bytes of s, starting at a line
+// boundary.
+func truncateAttachment(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	tail := s[len(s)-maxLen:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return fmt.Sprintf("[... %d bytes truncated ...]\n%s", len(s)-len(tail), tail)
+}
+
+// confirmFeedbackAttachments shows the attachments and asks before they are
+// posted to a public issue. --yes skips the prompt; without it a
+// non-interactive run is refused rather than sending unreviewed output.
+func confirmFeedbackAttachments(attachments []feedbackAttachment, in io.Reader, out io.Writer) (bool, error) {
+	if len(attachments) == 0 || feedbackYes {
+		return true, nil
+	}
+	if f, ok := in.(*os.File); ok && !isTerminalWriter(f) {
+		return false, fmt.Errorf("attachments need confirmation; review with --dry-run and rerun with --yes")
+	}
+
+	fmt.Fprintln(out, "The following diagnostics will be attached (secrets and hostnames redacted):")
+	for _, a := range attachments {
+		fmt.Fprintf(out, "\n--- %s ---\n%s\n", a.Title, strings.TrimRight(a.Content, "\n"))
+	}
+	fmt.Fprintf(out, "\nThe issue is public. Review the output above for sensitive data.\n")
+	fmt.Fprint(out, "Submit with these attachments? [y/N] ")
+
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.TrimSpace(strings.ToLower(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// writeFeedbackAttachments appends attachments to an issue body as
+// collapsible sections.
+func writeFeedbackAttachments(sb *strings.Builder, attachments []feedbackAttachment) {
+	for _, a := range attachments {
+		fmt.Fprintf(sb, "<details>\n<summary>%s</summary>\n\n```\n%s\n```\n\n</details>\n\n", a.Title, strings.TrimRight(a.Content, "\n"))
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestFormatFeedbackLogs(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []client.LogEntry{
+		{Timestamp: ts.Add(time.Second), Level: "error", Service: "gateway", Message: "second"},
+		{Timestamp: ts, Level: "info", Service: "worker", Message: "first"},
+	}
+	got := formatFeedbackLogs(entries)
+	assert.Equal(t, "2026-01-02T03:04:05Z INFO  worker: first\n2026-01-02T03:04:06Z ERROR gateway: second\n", got)
+	assert.Equal(t, "(no log entries)", formatFeedbackLogs(nil))
+}
+
+func TestTruncateAttachment(t *testing.T) {
+	assert.Equal(t, "short", truncateAttachment("short", 100))
+
+	s := strings.Repeat("line\n", 10) + "boom\n"
+	got := truncateAttachment(s, 12)
+	assert.True(t, strings.HasPrefix(got, "[... "), got)
+	assert.True(t, strings.HasSuffix(got, "line\nboom\n"), got)
+}
+
+func TestFeedbackCommandAttachment(t *testing.T) {
+	a := feedbackCommandAttachment(context.Background(), "echo out; echo err >&2; exit 3")
+	assert.Contains(t, a.Content, "$ echo out")
+	assert.Contains(t, a.Content, "out\n")
+	assert.Contains(t, a.Content, "err\n")
+	assert.Contains(t, a.Content, "exit status 3")
+}
+
+func TestConfirmFeedbackAttachments(t *testing.T) {
+	attachments := []feedbackAttachment{{Title: "Command output", Content: "token=[REDACTED]"}}
+
+	var out bytes.Buffer
+	ok, err := confirmFeedbackAttachments(attachments, strings.NewReader("y\n"), &out)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), "--- Command output ---\ntoken=[REDACTED]")
+
+	ok, err = confirmFeedbackAttachments(attachments, strings.NewReader("\n"), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.False(t, ok, "the default answer is no")
+
+	ok, err = confirmFeedbackAttachments(nil, strings.NewReader(""), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.True(t, ok, "nothing to confirm without attachments")
+
+	feedbackYes = true
+	t.Cleanup(func() { feedbackYes = false })
+	ok, err = confirmFeedbackAttachments(attachments, strings.NewReader(""), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestBuildIssueBody_Attachments(t *testing.T) {
+	body := buildIssueBody("bug", "it broke", "v1.0.0", []feedbackAttachment{{Title: "Last 50 log lines", Content: "a\nb\n"}})
+	assert.Contains(t, body, "### Diagnostics")
+	assert.Contains(t, body, "<summary>Last 50 log lines</summary>\n\n```\na\nb\n```")
+
+	assert.NotContains(t, buildIssueBody("bug", "it broke", "v1.0.0", nil), "### Diagnostics")
+}
@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	glossaryv1 "github.com/otherjamesbrown/penf-cli/api/proto/glossary/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -50,6 +49,25 @@ func getTenantIDForGlossary(deps *GlossaryCommandDeps) string {
 	return "00000001-0000-0000-0000-000000000001"
 }
 
+// getGlossaryOutputFormat returns the output format from flag or config.
+func getGlossaryOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
+	if glossaryOutput != "" {
+		return config.OutputFormat(glossaryOutput)
+	}
+	if cfg != nil {
+		return cfg.OutputFormat
+	}
+	return config.OutputFormatText
+}
+
+// GlossaryActionResult is the JSON result of a glossary remove.
+type GlossaryActionResult struct {
+	ID        int64  `json:"id"`
+	Term      string `json:"term"`
+	Expansion string `json:"expansion"`
+	Action    string `json:"action"`
+}
+
 // NewGlossaryCommand creates the root glossary command with all subcommands.
 func NewGlossaryCommand(deps *GlossaryCommandDeps) *cobra.Command {
 	if deps == nil {
@@ -73,6 +91,9 @@ Terms can have:
 
 Query expansion automatically expands known acronyms in search queries.
 
+Use 'glossary export' and 'glossary import' to copy terminology between
+tenants or deployments.
+
 JSON Output (for AI processing):
   penf glossary list -o json
 
@@ -103,6 +124,8 @@ Glossary and acronym workflow details are in Context Palace knowledge shards.`,
 	cmd.AddCommand(newGlossaryLinkCommand(deps))
 	cmd.AddCommand(newGlossaryUnlinkCommand(deps))
 	cmd.AddCommand(newGlossaryLinkedCommand(deps))
+	cmd.AddCommand(newGlossaryExportCommand(deps))
+	cmd.AddCommand(newGlossaryImportCommand(deps))
 
 	return cmd
 }
@@ -142,7 +165,7 @@ Examples:
 	cmd.Flags().StringSliceVarP(&glossaryAliases, "aliases", "a", nil, "Aliases (comma-separated)")
 	cmd.Flags().BoolVar(&glossaryNoExpand, "no-expand", false, "Don't use this term for query expansion")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newGlossaryListCommand creates the 'glossary list' subcommand.
@@ -249,7 +272,7 @@ Examples:
 
 	cmd.Flags().Int64Var(&removeID, "id", 0, "Remove term by ID instead of name")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newGlossaryExpandCommand creates the 'glossary expand' subcommand.
@@ -274,7 +297,7 @@ Examples:
 
 // newGlossaryAliasCommand creates the 'glossary alias' subcommand.
 func newGlossaryAliasCommand(deps *GlossaryCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "alias <term> <alias>",
 		Short: "Add an alias to an existing term",
 		Long: `Add an alias to an existing glossary term.
@@ -298,7 +321,7 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGlossaryAlias(cmd.Context(), deps, args[0], args[1])
 		},
-	}
+	})
 }
 
 // Glossary link command flags
@@ -342,12 +365,12 @@ Examples:
 
 	cmd.Flags().StringVarP(&glossaryLinkType, "type", "t", "company", "Entity type: product, project, company")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newGlossaryUnlinkCommand creates the 'glossary unlink' subcommand.
 func newGlossaryUnlinkCommand(deps *GlossaryCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "unlink <term>",
 		Short: "Remove entity link from a glossary term",
 		Long: `Remove the entity link from a glossary term.
@@ -361,7 +384,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGlossaryUnlink(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 // newGlossaryLinkedCommand creates the 'glossary linked' subcommand.
@@ -437,6 +460,10 @@ func runGlossaryAdd(ctx context.Context, deps *GlossaryCommandDeps, term, expans
 	}
 
 	created := resp.Term
+	if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, created, "Added term: "+created.Term)
+	}
+
 	fmt.Printf("\033[32mAdded term:\033[0m %s\n", created.Term)
 	fmt.Printf("  Expansion:  %s\n", created.Expansion)
 	if created.Definition != "" {
@@ -513,7 +540,7 @@ func runGlossaryShow(ctx context.Context, deps *GlossaryCommandDeps, termStr str
 		return fmt.Errorf("looking up term: %w", err)
 	}
 	if !resp.Found {
-		return fmt.Errorf("term not found: %s", termStr)
+		return notFoundError("term not found: %s", termStr)
 	}
 
 	// Use canonical term name from LookupTerm (handles alias resolution)
@@ -617,7 +644,7 @@ func runGlossaryRemove(ctx context.Context, deps *GlossaryCommandDeps, termStr s
 			return fmt.Errorf("looking up term: %w", err)
 		}
 		if termResp.Term == nil {
-			return fmt.Errorf("term not found: %s", termStr)
+			return notFoundError("term not found: %s", termStr)
 		}
 		term = termResp.Term
 		termID = term.Id
@@ -632,6 +659,10 @@ func runGlossaryRemove(ctx context.Context, deps *GlossaryCommandDeps, termStr s
 		return fmt.Errorf("deleting term: %w", err)
 	}
 
+	if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, GlossaryActionResult{ID: term.Id, Term: term.Term, Expansion: term.Expansion, Action: "remove"}, "Removed term: "+term.Term)
+	}
+
 	fmt.Printf("\033[32mRemoved term:\033[0m %s (%s)\n", term.Term, term.Expansion)
 	return nil
 }
@@ -701,6 +732,9 @@ func runGlossaryAlias(ctx context.Context, deps *GlossaryCommandDeps, termStr, n
 	// Check if alias already exists
 	for _, existing := range existingTerm.Aliases {
 		if strings.EqualFold(existing, newAlias) {
+			if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+				return writeOutcomeJSON(true, existingTerm, fmt.Sprintf("Alias '%s' already exists for term '%s'", newAlias, termStr))
+			}
 			fmt.Printf("Alias '%s' already exists for term '%s'\n", newAlias, termStr)
 			return nil
 		}
@@ -719,6 +753,10 @@ func runGlossaryAlias(ctx context.Context, deps *GlossaryCommandDeps, termStr, n
 		return fmt.Errorf("updating term: %w", err)
 	}
 
+	if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, updateResp.Term, "Added alias: "+newAlias)
+	}
+
 	fmt.Printf("\033[32mAdded alias:\033[0m %s → %s\n", newAlias, termStr)
 	fmt.Printf("  Expansion: %s\n", updateResp.Term.Expansion)
 	fmt.Printf("  All aliases: %s\n", strings.Join(updateResp.Term.Aliases, ", "))
@@ -752,6 +790,10 @@ func runGlossaryLink(ctx context.Context, deps *GlossaryCommandDeps, termStr, en
 		return fmt.Errorf("linking term: %w", err)
 	}
 
+	if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Term, "Linked term: "+resp.Term.Term)
+	}
+
 	fmt.Printf("\033[32mLinked term:\033[0m %s\n", resp.Term.Term)
 	fmt.Printf("  Expansion: %s\n", resp.Term.Expansion)
 	if resp.Term.LinkedEntity != nil {
@@ -785,6 +827,10 @@ func runGlossaryUnlink(ctx context.Context, deps *GlossaryCommandDeps, termStr s
 		return fmt.Errorf("unlinking term: %w", err)
 	}
 
+	if getGlossaryOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Term, "Unlinked term: "+resp.Term.Term)
+	}
+
 	fmt.Printf("\033[32mUnlinked term:\033[0m %s\n", resp.Term.Term)
 	fmt.Printf("  Expansion: %s\n", resp.Term.Expansion)
 
@@ -990,7 +1036,7 @@ func outputGlossaryJSON(v interface{}) error {
 }
 
 func outputGlossaryYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -0,0 +1,523 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	glossaryv1 "github.com/otherjamesbrown/penf-cli/api/proto/glossary/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Glossary import flags.
+var (
+	glossaryImportFile   string
+	glossaryImportDryRun bool
+)
+
+// glossaryExportPageSize is the page size used to read every term.
+const glossaryExportPageSize = 500
+
+// glossaryCSVHeader is the column layout of glossary CSV export and import.
+var glossaryCSVHeader = []string{"term", "expansion", "definition", "context", "aliases", "expand_in_search"}
+
+// GlossaryTermRecord is a glossary term as exported and imported. Term is
+// the key: importing updates an existing term with the same name
+// (case-insensitively) or creates a new one.
+type GlossaryTermRecord struct {
+	Term           string   `json:"term" yaml:"term"`
+	Expansion      string   `json:"expansion" yaml:"expansion"`
+	Definition     string   `json:"definition,omitempty" yaml:"definition,omitempty"`
+	Context        []string `json:"context,omitempty" yaml:"context,omitempty"`
+	Aliases        []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	ExpandInSearch *bool    `json:"expand_in_search,omitempty" yaml:"expand_in_search,omitempty"`
+}
+
+// GlossaryExport is the JSON and YAML document written by glossary export.
+type GlossaryExport struct {
+	Terms []GlossaryTermRecord `json:"terms" yaml:"terms"`
+	Total int                  `json:"total" yaml:"total"`
+}
+
+// Glossary import actions.
+const (
+	glossaryImportAdd       = "add"
+	glossaryImportUpdate    = "update"
+	glossaryImportUnchanged = "unchanged"
+	glossaryImportSkip      = "skip"
+)
+
+// glossaryImportChange is the planned outcome for one imported term.
+type glossaryImportChange struct {
+	Action   string
+	Record   GlossaryTermRecord
+	Existing *glossaryv1.Term
+	// Fields lists the fields an update changes.
+	Fields []string
+}
+
+// newGlossaryExportCommand creates the 'glossary export' subcommand.
+func newGlossaryExportCommand(deps *GlossaryCommandDeps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Export all glossary terms",
+		Long: `Export every glossary term with its expansion, definition, context tags
+and aliases, for backup or for seeding another tenant with 'glossary import'.
+
+Output is JSON by default; use -o csv for a spreadsheet-friendly file (list
+columns are separated by "; ") or -o yaml.
+
+Examples:
+  penf glossary export > glossary.json
+  penf glossary export -o csv > glossary.csv
+  PENF_TENANT_ID=<other> penf glossary import --from-file glossary.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGlossaryExport(cmd.Context(), deps)
+		},
+	}
+}
+
+// newGlossaryImportCommand creates the 'glossary import' subcommand.
+func newGlossaryImportCommand(deps *GlossaryCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import glossary terms from a file",
+		Long: `Import glossary terms from a JSON, YAML or CSV file written by
+'glossary export'. Terms are matched by name (case-insensitively): new terms
+are created and existing terms are updated where they differ.
+
+Fields left empty in the file keep the existing term's value. New terms need
+an expansion; those without one are skipped and reported. Terms not in the
+file are left alone.
+
+The file format is taken from its extension (.csv, .yaml/.yml, otherwise
+JSON). JSON may be an export document or a plain array of terms.
+
+Examples:
+  penf glossary import --from-file glossary.json --dry-run
+  penf glossary import --from-file glossary.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGlossaryImport(cmd.Context(), deps)
+		},
+	}
+
+	cmd.Flags().StringVar(&glossaryImportFile, "from-file", "", "File of terms to import (JSON, YAML or CSV)")
+	cmd.Flags().BoolVar(&glossaryImportDryRun, "dry-run", false, "Show what would change without applying it")
+	_ = cmd.MarkFlagRequired("from-file")
+
+	return cmd
+}
+
+func runGlossaryExport(ctx context.Context, deps *GlossaryCommandDeps) error {
+	format := config.OutputFormatJSON
+	if glossaryOutput != "" && glossaryOutput != string(config.OutputFormatText) {
+		format = config.OutputFormat(glossaryOutput)
+	}
+	switch format {
+	case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatCSV:
+	default:
+		return fmt.Errorf("unsupported export format %q: use json, yaml or csv", format)
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := glossaryv1.NewGlossaryServiceClient(conn)
+	terms, err := listAllGlossaryTerms(ctx, client, getTenantIDForGlossary(deps))
+	if err != nil {
+		return err
+	}
+
+	records := make([]GlossaryTermRecord, 0, len(terms))
+	for _, t := range terms {
+		records = append(records, glossaryTermToRecord(t))
+	}
+	slices.SortFunc(records, func(a, b GlossaryTermRecord) int {
+		return strings.Compare(strings.ToLower(a.Term), strings.ToLower(b.Term))
+	})
+
+	switch format {
+	case config.OutputFormatCSV:
+		return writeGlossaryCSV(os.Stdout, records)
+	case config.OutputFormatYAML:
+		return outputGlossaryYAML(GlossaryExport{Terms: records, Total: len(records)})
+	default:
+		return outputGlossaryJSON(GlossaryExport{Terms: records, Total: len(records)})
+	}
+}
+
+func runGlossaryImport(ctx context.Context, deps *GlossaryCommandDeps) error {
+	records, err := readGlossaryFile(glossaryImportFile)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := glossaryv1.NewGlossaryServiceClient(conn)
+	tenantID := getTenantIDForGlossary(deps)
+
+	existing, err := listAllGlossaryTerms(ctx, client, tenantID)
+	if err != nil {
+		return err
+	}
+	changes := planGlossaryImport(existing, records)
+
+	counts := map[string]int{}
+	failed := 0
+	for _, c := range changes {
+		if c.Action == glossaryImportUnchanged {
+			counts[c.Action]++
+			continue
+		}
+		if c.Action == glossaryImportSkip {
+			counts[c.Action]++
+			printGlossaryImportChange(os.Stdout, c, glossaryImportDryRun)
+			continue
+		}
+
+		if glossaryImportDryRun {
+			counts[c.Action]++
+			printGlossaryImportChange(os.Stdout, c, true)
+			continue
+		}
+
+		var err error
+		if c.Action == glossaryImportAdd {
+			_, err = client.AddTerm(ctx, glossaryAddRequest(tenantID, c.Record))
+		} else {
+			_, err = client.UpdateTerm(ctx, glossaryUpdateRequest(tenantID, c))
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("\033[31mfailed\033[0m %s: %v\n", c.Record.Term, err)
+			continue
+		}
+		counts[c.Action]++
+		printGlossaryImportChange(os.Stdout, c, false)
+	}
+
+	fmt.Println()
+	if glossaryImportDryRun {
+		fmt.Printf("Dry run: %d would be added, %d updated, %d unchanged, %d skipped (omit --dry-run to apply)\n",
+			counts[glossaryImportAdd], counts[glossaryImportUpdate], counts[glossaryImportUnchanged], counts[glossaryImportSkip])
+		return nil
+	}
+	fmt.Printf("Glossary import complete: %d added, %d updated, %d unchanged, %d skipped, %d failed\n",
+		counts[glossaryImportAdd], counts[glossaryImportUpdate], counts[glossaryImportUnchanged], counts[glossaryImportSkip], failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d terms failed", failed, len(changes))
+	}
+	return nil
+}
+
+// listAllGlossaryTerms pages through ListTerms to read every term.
+func listAllGlossaryTerms(ctx context.Context, client glossaryv1.GlossaryServiceClient, tenantID string) ([]*glossaryv1.Term, error) {
+	var terms []*glossaryv1.Term
+	for {
+		resp, err := client.ListTerms(ctx, &glossaryv1.ListTermsRequest{
+			TenantId: tenantID,
+			Limit:    glossaryExportPageSize,
+			Offset:   int32(len(terms)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing terms: %w", err)
+		}
+		terms = append(terms, resp.Terms...)
+		if len(resp.Terms) < glossaryExportPageSize || int64(len(terms)) >= resp.TotalCount {
+			return terms, nil
+		}
+	}
+}
+
+// glossaryTermToRecord converts a stored term to its export form.
+func glossaryTermToRecord(t *glossaryv1.Term) GlossaryTermRecord {
+	expand := t.ExpandInSearch
+	return GlossaryTermRecord{
+		Term:           t.Term,
+		Expansion:      t.Expansion,
+		Definition:     t.Definition,
+		Context:        t.Context,
+		Aliases:        t.Aliases,
+		ExpandInSearch: &expand,
+	}
+}
+
+// planGlossaryImport matches imported records to existing terms by name and
+// decides whether each is added, updated or unchanged.
+func planGlossaryImport(existing []*glossaryv1.Term, records []GlossaryTermRecord) []glossaryImportChange {
+	byTerm := make(map[string]*glossaryv1.Term, len(existing))
+	for _, t := range existing {
+		byTerm[strings.ToLower(t.Term)] = t
+	}
+
+	changes := make([]glossaryImportChange, 0, len(records))
+	for _, r := range records {
+		t, ok := byTerm[strings.ToLower(r.Term)]
+		if !ok {
+			// The server requires an expansion, so a new term without one
+			// would only fail.
+			action := glossaryImportAdd
+			if strings.TrimSpace(r.Expansion) == "" {
+				action = glossaryImportSkip
+			}
+			changes = append(changes, glossaryImportChange{Action: action, Record: r})
+			continue
+		}
+
+		var fields []string
+		if r.Expansion != "" && r.Expansion != t.Expansion {
+			fields = append(fields, "expansion")
+		}
+		if r.Definition != "" && r.Definition != t.Definition {
+			fields = append(fields, "definition")
+		}
+		if len(r.Context) > 0 && !slices.Equal(r.Context, t.Context) {
+			fields = append(fields, "context")
+		}
+		if len(r.Aliases) > 0 && !slices.Equal(r.Aliases, t.Aliases) {
+			fields = append(fields, "aliases")
+		}
+		if r.ExpandInSearch != nil && *r.ExpandInSearch != t.ExpandInSearch {
+			fields = append(fields, "expand_in_search")
+		}
+
+		action := glossaryImportUnchanged
+		if len(fields) > 0 {
+			action = glossaryImportUpdate
+		}
+		changes = append(changes, glossaryImportChange{Action: action, Record: r, Existing: t, Fields: fields})
+	}
+	return changes
+}
+
+// glossaryAddRequest builds the request creating r. Terms are used for query
+// expansion unless the record says otherwise.
+func glossaryAddRequest(tenantID string, r GlossaryTermRecord) *glossaryv1.AddTermRequest {
+	expand := true
+	if r.ExpandInSearch != nil {
+		expand = *r.ExpandInSearch
+	}
+	return &glossaryv1.AddTermRequest{
+		TenantId:       tenantID,
+		Term:           r.Term,
+		Expansion:      r.Expansion,
+		Definition:     r.Definition,
+		Context:        r.Context,
+		Aliases:        r.Aliases,
+		ExpandInSearch: expand,
+	}
+}
+
+// glossaryUpdateRequest builds the request applying only the changed fields.
+func glossaryUpdateRequest(tenantID string, c glossaryImportChange) *glossaryv1.UpdateTermRequest {
+	req := &glossaryv1.UpdateTermRequest{TenantId: tenantID, Id: c.Existing.Id}
+	for _, f := range c.Fields {
+		switch f {
+		case "expansion":
+			req.Expansion = &c.Record.Expansion
+		case "definition":
+			req.Definition = &c.Record.Definition
+		case "context":
+			req.Context = c.Record.Context
+		case "aliases":
+			req.Aliases = c.Record.Aliases
+		case "expand_in_search":
+			req.ExpandInSearch = c.Record.ExpandInSearch
+		}
+	}
+	return req
+}
+
+// printGlossaryImportChange reports an added or updated term.
+func printGlossaryImportChange(w io.Writer, c glossaryImportChange, dryRun bool) {
+	if c.Action == glossaryImportSkip {
+		fmt.Fprintf(w, "\033[31m!\033[0m %s skipped: new term has no expansion\n", c.Record.Term)
+		return
+	}
+	verb := map[string]string{glossaryImportAdd: "added", glossaryImportUpdate: "updated"}[c.Action]
+	if dryRun {
+		verb = "would be " + verb
+	}
+	if c.Action == glossaryImportAdd {
+		fmt.Fprintf(w, "\033[32m+\033[0m %s %s: %s\n", c.Record.Term, verb, c.Record.Expansion)
+		return
+	}
+	fmt.Fprintf(w, "\033[33m~\033[0m %s %s (%s)\n", c.Record.Term, verb, strings.Join(c.Fields, ", "))
+}
+
+// readGlossaryFile reads glossary terms from a JSON, YAML or CSV file. Every
+// term must be named, and names must be unique.
+func readGlossaryFile(path string) ([]GlossaryTermRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening glossary file: %w", err)
+	}
+
+	var records []GlossaryTermRecord
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = parseGlossaryCSV(data)
+	case ".yaml", ".yml":
+		records, err = parseGlossaryYAML(data)
+	default:
+		records, err = parseGlossaryJSON(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading glossary file: %w", err)
+	}
+
+	seen := make(map[string]bool, len(records))
+	for i, r := range records {
+		if r.Term == "" {
+			return nil, fmt.Errorf("glossary file term %d: missing term", i+1)
+		}
+		key := strings.ToLower(r.Term)
+		if seen[key] {
+			return nil, fmt.Errorf("glossary file: duplicate term %q", r.Term)
+		}
+		seen[key] = true
+	}
+	if len(records) == 0 {
+		return nil, errors.New("glossary file has no terms")
+	}
+	return records, nil
+}
+
+// parseGlossaryJSON accepts an export document or a plain array of terms.
+func parseGlossaryJSON(data []byte) ([]GlossaryTermRecord, error) {
+	var records []GlossaryTermRecord
+	if err := json.Unmarshal(data, &records); err == nil {
+		return records, nil
+	}
+	var doc GlossaryExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Terms, nil
+}
+
+// parseGlossaryYAML accepts an export document or a plain list of terms.
+func parseGlossaryYAML(data []byte) ([]GlossaryTermRecord, error) {
+	var records []GlossaryTermRecord
+	if err := yaml.Unmarshal(data, &records); err == nil {
+		return records, nil
+	}
+	var doc GlossaryExport
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Terms, nil
+}
+
+// writeGlossaryCSV writes terms in the glossaryCSVHeader layout.
+func writeGlossaryCSV(w io.Writer, records []GlossaryTermRecord) error {
+	rows := make([][]string, 0, len(records))
+	for _, r := range records {
+		expand := ""
+		if r.ExpandInSearch != nil {
+			expand = strconv.FormatBool(*r.ExpandInSearch)
+		}
+		rows = append(rows, []string{r.Term, r.Expansion, r.Definition, csvList(r.Context), csvList(r.Aliases), expand})
+	}
+	return outputCSV(w, glossaryCSVHeader, rows)
+}
+
+// parseGlossaryCSV reads terms written by writeGlossaryCSV. Columns are
+// matched by header name, so they may be reordered or omitted; "term" is
+// required.
+func parseGlossaryCSV(data []byte) ([]GlossaryTermRecord, error) {
+	r := csv.NewReader(strings.NewReader(string(data)))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["term"]; !ok {
+		return nil, errors.New(`CSV header has no "term" column`)
+	}
+
+	var records []GlossaryTermRecord
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		rec := GlossaryTermRecord{
+			Term:       get("term"),
+			Expansion:  get("expansion"),
+			Definition: get("definition"),
+			Context:    splitCSVList(get("context")),
+			Aliases:    splitCSVList(get("aliases")),
+		}
+		if s := get("expand_in_search"); s != "" {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid expand_in_search %q", line, s)
+			}
+			rec.ExpandInSearch = &b
+		}
+		records = append(records, rec)
+	}
+}
+
+// splitCSVList splits a cell written by csvList.
+func splitCSVList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
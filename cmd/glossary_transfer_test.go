@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	glossaryv1 "github.com/otherjamesbrown/penf-cli/api/proto/glossary/v1"
+)
+
+func TestPlanGlossaryImport(t *testing.T) {
+	existing := []*glossaryv1.Term{
+		{Id: 1, Term: "TER", Expansion: "Technical Execution Review", Aliases: []string{"ter"}, ExpandInSearch: true},
+		{Id: 2, Term: "LKE", Expansion: "Linode Kubernetes Engine", ExpandInSearch: true},
+	}
+	noExpand := false
+	records := []GlossaryTermRecord{
+		{Term: "ter", Expansion: "Technical Execution Review"},
+		{Term: "LKE", Expansion: "Linode Kubernetes Engine", Aliases: []string{"lke"}, ExpandInSearch: &noExpand},
+		{Term: "SLO", Expansion: "Service Level Objective"},
+		{Term: "TBD", Definition: "No expansion given"},
+	}
+
+	changes := planGlossaryImport(existing, records)
+	require.Len(t, changes, 4)
+
+	assert.Equal(t, glossaryImportUnchanged, changes[0].Action, "terms match case-insensitively and empty fields are kept")
+	assert.Equal(t, glossaryImportUpdate, changes[1].Action)
+	assert.Equal(t, []string{"aliases", "expand_in_search"}, changes[1].Fields)
+	assert.Equal(t, glossaryImportAdd, changes[2].Action)
+	assert.Equal(t, glossaryImportSkip, changes[3].Action, "new terms without an expansion are skipped")
+
+	req := glossaryUpdateRequest("t1", changes[1])
+	assert.Equal(t, int64(2), req.Id)
+	assert.Nil(t, req.Expansion)
+	assert.Equal(t, []string{"lke"}, req.Aliases)
+	require.NotNil(t, req.ExpandInSearch)
+	assert.False(t, *req.ExpandInSearch)
+
+	assert.True(t, glossaryAddRequest("t1", changes[2].Record).ExpandInSearch, "new terms expand by default")
+}
+
+func TestGlossaryCSVRoundTrip(t *testing.T) {
+	expand := true
+	records := []GlossaryTermRecord{
+		{Term: "TER", Expansion: "Technical Execution Review", Definition: "Weekly review, with notes", Context: []string{"MTC", "meetings"}, Aliases: []string{"T.E.R.", "ter"}, ExpandInSearch: &expand},
+		{Term: "SLO", Expansion: "Service Level Objective"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeGlossaryCSV(&buf, records))
+	assert.Contains(t, buf.String(), "term,expansion,definition,context,aliases,expand_in_search\n")
+
+	got, err := parseGlossaryCSV(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+}
+
+func TestReadGlossaryFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	records, err := readGlossaryFile(write("export.json", `{"terms": [{"term": "TER", "expansion": "Technical Execution Review"}], "total": 1}`))
+	require.NoError(t, err)
+	assert.Equal(t, "TER", records[0].Term)
+
+	records, err = readGlossaryFile(write("array.json", `[{"term": "SLO", "expansion": "Service Level Objective"}]`))
+	require.NoError(t, err)
+	assert.Equal(t, "SLO", records[0].Term)
+
+	records, err = readGlossaryFile(write("terms.yaml", "terms:\n  - term: LKE\n    expansion: Linode Kubernetes Engine\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "LKE", records[0].Term)
+
+	_, err = readGlossaryFile(write("dup.json", `[{"term": "TER"}, {"term": "ter"}]`))
+	assert.ErrorContains(t, err, "duplicate term")
+
+	_, err = readGlossaryFile(write("nohdr.csv", "TER,Technical Execution Review\n"))
+	assert.ErrorContains(t, err, `no "term" column`)
+}
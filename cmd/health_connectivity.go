@@ -0,0 +1,338 @@
+// Package cmd provides CLI commands for the penf tool.
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Connectivity check stages, in the order they run.
+const (
+	connectivityStageDNS  = "dns"
+	connectivityStageTCP  = "tcp"
+	connectivityStageTLS  = "tls"
+	connectivityStageGRPC = "grpc"
+)
+
+// ConnectivityResult holds the outcome of a staged connectivity check.
+type ConnectivityResult struct {
+	Server      string              `json:"server"`
+	Passed      bool                `json:"passed"`
+	FailedStage string              `json:"failed_stage,omitempty"`
+	Stages      []ConnectivityStage `json:"stages"`
+}
+
+// ConnectivityStage is the result of a single connectivity stage.
+type ConnectivityStage struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // passed, failed, skipped
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Hint      string `json:"hint,omitempty"`
+}
+
+var (
+	connectivityTimeout time.Duration
+	connectivityJSON    bool
+)
+
+// NewHealthConnectivityCommand creates the health connectivity command.
+func NewHealthConnectivityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connectivity",
+		Short: "Diagnose why the CLI cannot connect to the server",
+		Long: `Run staged connectivity checks against the configured server address and
+report which stage failed, with a targeted hint.
+
+Stages:
+  1. DNS   - resolve the server hostname
+  2. TCP   - open a TCP connection to the port
+  3. TLS   - perform the TLS handshake (skipped with --insecure or TLS disabled)
+  4. gRPC  - dial the gateway and call its HealthCheck RPC
+
+Later stages are skipped once a stage fails.
+
+Exit codes:
+  0 - All stages passed
+  1 - A stage failed
+
+Examples:
+  penf health connectivity
+  penf health connectivity --server gateway.example.com:50051
+  penf health connectivity --insecure --json`,
+		RunE: runHealthConnectivity,
+	}
+
+	cmd.Flags().DurationVar(&connectivityTimeout, "timeout", 5*time.Second, "Timeout for each stage")
+	cmd.Flags().BoolVar(&connectivityJSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runHealthConnectivity(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+
+	// Honor the root --server and --insecure flags.
+	root := cmd.Root()
+	if server, _ := root.PersistentFlags().GetString("server"); server != "" {
+		cfg.ServerAddress = server
+	}
+	if insecure, _ := root.PersistentFlags().GetBool("insecure"); insecure {
+		cfg.Insecure = true
+	}
+
+	result := runConnectivityChecks(cmd.Context(), cfg, connectivityTimeout)
+
+	if connectivityJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+	} else {
+		outputConnectivityHuman(result)
+	}
+
+	if !result.Passed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runConnectivityChecks runs the DNS, TCP, TLS and gRPC stages in order,
+// stopping at the first failure.
+func runConnectivityChecks(ctx context.Context, cfg *config.CLIConfig, timeout time.Duration) ConnectivityResult {
+	result := ConnectivityResult{Server: cfg.ServerAddress, Passed: true}
+
+	fail := func(stage ConnectivityStage) {
+		stage.Status = "failed"
+		result.Stages = append(result.Stages, stage)
+		result.Passed = false
+		result.FailedStage = stage.Name
+	}
+	pass := func(stage ConnectivityStage, start time.Time) {
+		stage.Status = "passed"
+		stage.LatencyMs = time.Since(start).Milliseconds()
+		result.Stages = append(result.Stages, stage)
+	}
+	skipRemaining := func(after string) {
+		stages := []string{connectivityStageDNS, connectivityStageTCP, connectivityStageTLS, connectivityStageGRPC}
+		for i, name := range stages {
+			if name == after {
+				for _, rest := range stages[i+1:] {
+					result.Stages = append(result.Stages, ConnectivityStage{Name: rest, Status: "skipped"})
+				}
+				return
+			}
+		}
+	}
+
+	// Stage 1: DNS.
+	host, port, err := net.SplitHostPort(cfg.ServerAddress)
+	if err != nil {
+		fail(ConnectivityStage{
+			Name:  connectivityStageDNS,
+			Error: err.Error(),
+			Hint:  "server_address must be host:port, e.g. 'penf config set server_address gateway.example.com:50051'",
+		})
+		skipRemaining(connectivityStageDNS)
+		return result
+	}
+
+	start := time.Now()
+	dnsCtx, cancel := context.WithTimeout(ctx, timeout)
+	addrs, err := net.DefaultResolver.LookupHost(dnsCtx, host)
+	cancel()
+	if err != nil {
+		fail(ConnectivityStage{Name: connectivityStageDNS, Error: err.Error(), Hint: dnsHint(host, err)})
+		skipRemaining(connectivityStageDNS)
+		return result
+	}
+	pass(ConnectivityStage{Name: connectivityStageDNS, Detail: fmt.Sprintf("%s resolved to %s", host, strings.Join(addrs, ", "))}, start)
+
+	// Stage 2: TCP.
+	start = time.Now()
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.ServerAddress)
+	if err != nil {
+		fail(ConnectivityStage{Name: connectivityStageTCP, Error: err.Error(), Hint: tcpHint(port, err)})
+		skipRemaining(connectivityStageTCP)
+		return result
+	}
+	pass(ConnectivityStage{Name: connectivityStageTCP, Detail: "connected to " + conn.RemoteAddr().String()}, start)
+
+	// Stage 3: TLS.
+	var tlsConfig *tls.Config
+	switch {
+	case cfg.Insecure:
+		conn.Close()
+		result.Stages = append(result.Stages, ConnectivityStage{Name: connectivityStageTLS, Status: "skipped", Detail: "insecure mode"})
+	case !cfg.TLS.Enabled:
+		conn.Close()
+		result.Stages = append(result.Stages, ConnectivityStage{Name: connectivityStageTLS, Status: "skipped", Detail: "TLS not enabled in config"})
+	default:
+		tlsConfig, err = client.LoadClientTLSConfig(&cfg.TLS)
+		if err != nil {
+			conn.Close()
+			fail(ConnectivityStage{
+				Name:  connectivityStageTLS,
+				Error: err.Error(),
+				Hint:  "check tls.client_cert, tls.client_key and tls.ca_cert in your config ('penf cert show')",
+			})
+			skipRemaining(connectivityStageTLS)
+			return result
+		}
+
+		start = time.Now()
+		handshakeConfig := tlsConfig.Clone()
+		if handshakeConfig.ServerName == "" {
+			handshakeConfig.ServerName = host
+		}
+		tlsConn := tls.Client(conn, handshakeConfig)
+		tlsCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = tlsConn.HandshakeContext(tlsCtx)
+		cancel()
+		tlsConn.Close()
+		if err != nil {
+			fail(ConnectivityStage{Name: connectivityStageTLS, Error: err.Error(), Hint: tlsHint(err)})
+			skipRemaining(connectivityStageTLS)
+			return result
+		}
+		state := tlsConn.ConnectionState()
+		pass(ConnectivityStage{Name: connectivityStageTLS, Detail: "handshake completed (" + tls.VersionName(state.Version) + ")"}, start)
+	}
+
+	// Stage 4: gRPC.
+	opts := client.DefaultOptions()
+	opts.ConnectTimeout = timeout
+	opts.Insecure = cfg.Insecure || tlsConfig == nil
+	opts.TLSConfig = tlsConfig
+	opts.TenantID = cfg.TenantID
+	opts.ApplyConnectionConfig(cfg)
+
+	start = time.Now()
+	grpcClient := client.NewGRPCClient(cfg.ServerAddress, opts)
+	grpcCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := grpcClient.Connect(grpcCtx); err != nil {
+		fail(ConnectivityStage{Name: connectivityStageGRPC, Error: err.Error(), Hint: grpcHint(cfg)})
+		return result
+	}
+	defer grpcClient.Close()
+
+	status, err := grpcClient.GetStatus(grpcCtx, false)
+	if err != nil {
+		fail(ConnectivityStage{Name: connectivityStageGRPC, Error: err.Error(), Hint: grpcHint(cfg)})
+		return result
+	}
+	detail := "gateway healthy"
+	if !status.Healthy {
+		detail = "gateway reachable but reports unhealthy: " + status.Message
+	}
+	pass(ConnectivityStage{Name: connectivityStageGRPC, Detail: detail}, start)
+
+	return result
+}
+
+// dnsHint suggests a fix for a hostname resolution failure.
+func dnsHint(host string, err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return fmt.Sprintf("hostname %q does not exist; check server_address for typos or connect to the VPN that serves it", host)
+	}
+	return "DNS lookup failed; check your network connection and resolver settings"
+}
+
+// tcpHint suggests a fix for a TCP dial failure.
+func tcpHint(port string, err error) string {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Sprintf("nothing is listening on port %s; check the port in server_address and that the gateway is running", port)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "connection timed out; a firewall may be dropping traffic or the host is down"
+	}
+	return "TCP connection failed; check that the host is reachable from this network"
+}
+
+// tlsHint suggests a fix for a TLS handshake failure.
+func tlsHint(err error) string {
+	var unknownAuth x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	var certErr x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &unknownAuth):
+		return "server certificate is not signed by the configured CA; check tls.ca_cert"
+	case errors.As(err, &hostErr):
+		return "server certificate does not match the hostname; connect using a name listed in the certificate"
+	case errors.As(err, &certErr) && certErr.Reason == x509.Expired:
+		return "server certificate has expired or is not yet valid; check the server's certificate and your clock"
+	case strings.Contains(err.Error(), "bad certificate"), strings.Contains(err.Error(), "certificate required"):
+		return "server rejected the client certificate; check tls.client_cert and tls.client_key ('penf cert verify')"
+	case strings.Contains(err.Error(), "first record does not look like a TLS handshake"):
+		return "server is not speaking TLS on this port; disable TLS in config or use --insecure for a plaintext server"
+	}
+	return "TLS handshake failed; check that the server expects TLS and that your certificates are current"
+}
+
+// grpcHint suggests a fix for a gRPC dial or HealthCheck failure after the
+// network stages passed.
+func grpcHint(cfg *config.CLIConfig) string {
+	if cfg.Insecure || !cfg.TLS.Enabled {
+		return "port accepts connections but gRPC failed; check server_address points at the gateway gRPC port (not HTTP) and whether the server requires TLS"
+	}
+	return "port accepts connections but gRPC failed; check server_address points at the gateway gRPC port (not HTTP)"
+}
+
+// outputConnectivityHuman outputs connectivity results in human-readable format.
+func outputConnectivityHuman(result ConnectivityResult) {
+	statusStr := "\033[32mPASS\033[0m"
+	if !result.Passed {
+		statusStr = "\033[31mFAIL\033[0m"
+	}
+	fmt.Printf("Connectivity to %s: %s\n", result.Server, statusStr)
+	fmt.Println()
+
+	for _, stage := range result.Stages {
+		name := strings.ToUpper(stage.Name)
+		if stage.Name == connectivityStageGRPC {
+			name = "gRPC"
+		}
+
+		switch stage.Status {
+		case "passed":
+			fmt.Printf("\033[32m✓\033[0m %s: %s (%dms)\n", name, stage.Detail, stage.LatencyMs)
+		case "failed":
+			fmt.Printf("\033[31m✗\033[0m %s: %s\n", name, stage.Error)
+			if stage.Hint != "" {
+				fmt.Printf("  Hint: %s\n", stage.Hint)
+			}
+		default:
+			detail := "skipped"
+			if stage.Detail != "" {
+				detail = "skipped (" + stage.Detail + ")"
+			}
+			fmt.Printf("- %s: %s\n", name, detail)
+		}
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestRunConnectivityChecks_InvalidAddress(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ServerAddress = "gateway-without-port"
+
+	result := runConnectivityChecks(context.Background(), cfg, time.Second)
+
+	if result.Passed || result.FailedStage != connectivityStageDNS {
+		t.Fatalf("expected dns failure, got passed=%v failed_stage=%q", result.Passed, result.FailedStage)
+	}
+	if len(result.Stages) != 4 {
+		t.Fatalf("expected 4 stages, got %d", len(result.Stages))
+	}
+	if result.Stages[0].Hint == "" {
+		t.Error("expected a remediation hint for the failed stage")
+	}
+	for _, stage := range result.Stages[1:] {
+		if stage.Status != "skipped" {
+			t.Errorf("stage %s status = %q, want skipped", stage.Name, stage.Status)
+		}
+	}
+}
+
+func TestRunConnectivityChecks_ConnectionRefused(t *testing.T) {
+	// Grab a free port, then close the listener so nothing is listening on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.ServerAddress = addr
+	cfg.Insecure = true
+
+	result := runConnectivityChecks(context.Background(), cfg, time.Second)
+
+	if result.FailedStage != connectivityStageTCP {
+		t.Fatalf("failed_stage = %q, want tcp", result.FailedStage)
+	}
+	if result.Stages[0].Status != "passed" {
+		t.Errorf("dns status = %q, want passed", result.Stages[0].Status)
+	}
+	if !strings.Contains(result.Stages[1].Hint, "nothing is listening") {
+		t.Errorf("unexpected tcp hint: %q", result.Stages[1].Hint)
+	}
+}
+
+func TestTLSHint(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{x509.UnknownAuthorityError{}, "configured CA"},
+		{x509.HostnameError{Host: "gw"}, "does not match the hostname"},
+		{errors.New("remote error: tls: bad certificate"), "rejected the client certificate"},
+		{errors.New("tls: first record does not look like a TLS handshake"), "not speaking TLS"},
+		{errors.New("EOF"), "TLS handshake failed"},
+	}
+
+	for _, tc := range tests {
+		if got := tlsHint(tc.err); !strings.Contains(got, tc.want) {
+			t.Errorf("tlsHint(%v) = %q, want it to contain %q", tc.err, got, tc.want)
+		}
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	gmailv1 "github.com/otherjamesbrown/penf-cli/api/proto/gmail/v1"
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
@@ -1342,7 +1341,7 @@ func outputIngestJob(format config.OutputFormat, job IngestJob) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(job)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(job)
 	default:
 		return outputIngestJobText(job)
@@ -1395,7 +1394,7 @@ func outputIngestStatus(format config.OutputFormat, status IngestStatusResponse)
 		enc.SetIndent("", "  ")
 		return enc.Encode(status)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(status)
 	default:
 		return outputIngestStatusText(status)
@@ -1442,7 +1441,7 @@ func outputIngestQueue(format config.OutputFormat, jobs []IngestJob) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(jobs)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(jobs)
 	default:
 		return outputIngestQueueText(jobs)
@@ -1487,7 +1486,7 @@ func outputGmailStatus(format config.OutputFormat, status GmailSyncStatus) error
 		enc.SetIndent("", "  ")
 		return enc.Encode(status)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(status)
 	default:
 		return outputGmailStatusText(status)
@@ -1532,7 +1531,7 @@ func outputGmailHistory(format config.OutputFormat, history []GmailSyncHistoryEn
 		enc.SetIndent("", "  ")
 		return enc.Encode(history)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(history)
 	default:
 		return outputGmailHistoryText(history)
@@ -1577,7 +1576,7 @@ func outputIngestConfig(format config.OutputFormat, cfg IngestConfig) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(cfg)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(cfg)
 	default:
 		return outputIngestConfigText(cfg)
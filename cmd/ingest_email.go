@@ -15,7 +15,6 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
@@ -27,11 +26,14 @@ import (
 
 // Email ingest specific flags
 var (
-	emailSource      string
-	emailLabels      []string
-	emailConcurrency int
-	emailDryRun      bool
-	emailResumeJob   string
+	emailSource         string
+	emailLabels         []string
+	emailConcurrency    int
+	emailDryRun         bool
+	emailResumeJob      string
+	emailWatchDir       bool
+	emailPollInterval   time.Duration
+	emailListDuplicates bool
 )
 
 // emailIngestResult tracks the result of email ingestion.
@@ -45,7 +47,8 @@ type emailIngestResult struct {
 	CompletedAt   time.Time
 	Success       bool
 	Errors        []emailFileError
-	ContentIDs    []string // Content IDs of successfully ingested emails
+	ContentIDs    []string          // Content IDs of successfully ingested emails
+	FileStatus    map[string]string // Outcome per file path
 }
 
 // emailFileError records an error for a specific file.
@@ -77,22 +80,12 @@ func (p *emailProgress) snapshot() emailProgressSnapshot {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	elapsed := time.Since(p.StartedAt).Seconds()
-	var estimatedRemaining *float64
-	if p.ProcessedCount > 0 {
-		remaining := p.TotalFiles - p.ProcessedCount
-		rate := elapsed / float64(p.ProcessedCount)
-		est := rate * float64(remaining)
-		estimatedRemaining = &est
-	}
-
 	return emailProgressSnapshot{
-		TotalFiles:                p.TotalFiles,
-		ProcessedCount:            p.ProcessedCount,
-		ImportedCount:             p.ImportedCount,
-		SkippedCount:              p.SkippedCount,
-		FailedCount:               p.FailedCount,
-		EstimatedRemainingSeconds: estimatedRemaining,
+		TotalFiles:     p.TotalFiles,
+		ProcessedCount: p.ProcessedCount,
+		ImportedCount:  p.ImportedCount,
+		SkippedCount:   p.SkippedCount,
+		FailedCount:    p.FailedCount,
 	}
 }
 
@@ -124,19 +117,11 @@ func (p *emailProgress) setCurrentFile(path string) {
 }
 
 type emailProgressSnapshot struct {
-	TotalFiles                int
-	ProcessedCount            int
-	ImportedCount             int
-	SkippedCount              int
-	FailedCount               int
-	EstimatedRemainingSeconds *float64
-}
-
-func (s emailProgressSnapshot) percentComplete() float64 {
-	if s.TotalFiles == 0 {
-		return 0
-	}
-	return float64(s.ProcessedCount) / float64(s.TotalFiles) * 100
+	TotalFiles     int
+	ProcessedCount int
+	ImportedCount  int
+	SkippedCount   int
+	FailedCount    int
 }
 
 // newIngestEmailCommand creates the 'ingest email' subcommand.
@@ -153,6 +138,17 @@ entity extraction, and search indexing.
 Supports single files, directories (recursive), and glob patterns.
 Duplicate detection is performed by message-id and content hash.
 
+--dry-run parses every file without uploading anything and checks it against
+the emails already ingested for the tenant, reporting "N new, M duplicates,
+K unsupported". Files repeated within the directory count as duplicates;
+non-.eml files and files that fail to parse count as unsupported.
+
+With --watch-dir the command keeps running after the initial import, polling
+the directory every --poll-interval and ingesting .eml files as they appear.
+Files are ingested once they have stopped changing. Ingested files are
+recorded in a local manifest under ~/.penf/ingest-manifests/ so that
+restarting the watch does not re-send them. Stop with Ctrl+C.
+
 Examples:
   # Ingest a single email
   penf ingest email message.eml --source "archive"
@@ -166,8 +162,14 @@ Examples:
   # Preview without importing (dry run)
   penf ingest email ./emails/ --source "test" --dry-run
 
+  # Preview and list the files that are already ingested
+  penf ingest email ./emails/ --source "test" --dry-run --list-duplicates
+
   # Resume an interrupted job
-  penf ingest email ./emails/ --source "backup" --resume job-abc123`,
+  penf ingest email ./emails/ --source "backup" --resume job-abc123
+
+  # Keep watching a mailbox dump and ingest new files as they appear
+  penf ingest email ./mailbox/ --source "mailbox" --watch-dir --poll-interval 30s`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runIngestEmail(cmd.Context(), deps, args[0])
@@ -178,8 +180,11 @@ Examples:
 	cmd.Flags().StringVarP(&emailSource, "source", "s", "", "Source tag identifier (required)")
 	cmd.Flags().StringSliceVarP(&emailLabels, "labels", "l", nil, "Comma-separated labels to apply")
 	cmd.Flags().IntVarP(&emailConcurrency, "concurrency", "w", 4, "Number of concurrent workers")
-	cmd.Flags().BoolVar(&emailDryRun, "dry-run", false, "Preview import without persisting")
+	cmd.Flags().BoolVar(&emailDryRun, "dry-run", false, "Preview import without persisting: count new, duplicate and unsupported files")
+	cmd.Flags().BoolVar(&emailListDuplicates, "list-duplicates", false, "With --dry-run, list the files that would be skipped as duplicates")
 	cmd.Flags().StringVar(&emailResumeJob, "resume", "", "Resume an interrupted job by ID")
+	cmd.Flags().BoolVar(&emailWatchDir, "watch-dir", false, "Keep watching the directory and ingest new files as they appear")
+	cmd.Flags().DurationVar(&emailPollInterval, "poll-interval", defaultEmailPollInterval, "How often --watch-dir checks for new files")
 
 	cmd.MarkFlagRequired("source")
 
@@ -209,6 +214,18 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 		return fmt.Errorf("--source flag is required")
 	}
 
+	if emailWatchDir {
+		if !info.IsDir() {
+			return fmt.Errorf("--watch-dir requires a directory: %s", path)
+		}
+		if emailDryRun || emailResumeJob != "" {
+			return fmt.Errorf("--watch-dir cannot be combined with --dry-run or --resume")
+		}
+		if emailPollInterval < time.Second {
+			return fmt.Errorf("--poll-interval must be at least 1s")
+		}
+	}
+
 	// Determine tenant ID
 	tenantID := ingestTenantID
 	if tenantID == "" {
@@ -235,6 +252,9 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 	if emailResumeJob != "" {
 		fmt.Printf("  Resuming:    %s\n", emailResumeJob)
 	}
+	if emailWatchDir {
+		fmt.Printf("  Watching:    every %s\n", emailPollInterval)
+	}
 	if info.IsDir() {
 		fmt.Printf("  Path type:   directory (recursive)\n")
 	} else {
@@ -242,6 +262,15 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 	}
 	fmt.Println()
 
+	if emailWatchDir {
+		conn, err := connectIngestToGateway(cfg)
+		if err != nil {
+			return fmt.Errorf("connecting to gateway: %w", err)
+		}
+		defer conn.Close()
+		return runEmailWatch(ctx, conn, newEmailIngestParser(), tenantID, path, format)
+	}
+
 	// Discover files locally (CLI keeps file discovery)
 	files, err := discoverEmailFiles(path)
 	if err != nil {
@@ -256,13 +285,11 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 	fmt.Printf("Found %d .eml files\n\n", len(files))
 
 	// Create email parser (CLI keeps parsing)
-	parseOpts := eml.DefaultParseOptions()
-	parseOpts.IncludeAttachmentContent = false // Only need metadata for gRPC
-	parser := eml.NewParser(parseOpts)
+	parser := newEmailIngestParser()
 
 	// For dry-run mode, just parse and display without calling gRPC
 	if emailDryRun {
-		return runEmailDryRun(ctx, parser, files, format)
+		return runEmailDryRun(ctx, cfg, parser, tenantID, path, files, format)
 	}
 
 	// Connect to gateway for gRPC operations
@@ -272,10 +299,46 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 	}
 	defer conn.Close()
 
+	result, err := ingestEmailFiles(ctx, conn, parser, tenantID, path, files, emailResumeJob, format)
+	if err != nil {
+		return err
+	}
+
+	// Display results
+	fmt.Println()
+	displayEmailResults(result, format)
+
+	// Return error if there were failures
+	if result.FailedCount > 0 {
+		return fmt.Errorf("%d files failed to import", result.FailedCount)
+	}
+
+	return nil
+}
+
+// newEmailIngestParser creates the parser used for ingest. Attachment
+// content is not parsed since only metadata is sent over gRPC.
+func newEmailIngestParser() *eml.Parser {
+	parseOpts := eml.DefaultParseOptions()
+	parseOpts.IncludeAttachmentContent = false
+	return eml.NewParser(parseOpts)
+}
+
+// ingestEmailFiles imports files as one ingest job, creating the job unless
+// jobID resumes an existing one, and kicks pipeline processing for anything
+// imported.
+func ingestEmailFiles(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	parser *eml.Parser,
+	tenantID, path string,
+	files []string,
+	jobID string,
+	format config.OutputFormat,
+) (*emailIngestResult, error) {
 	client := ingestv1.NewIngestServiceClient(conn)
 
 	// Create or resume job via gRPC
-	jobID := emailResumeJob
 	if jobID == "" {
 		// Create job record via gRPC
 		resp, err := client.CreateIngestJob(ctx, &ingestv1.CreateIngestJobRequest{
@@ -290,7 +353,7 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 			},
 		})
 		if err != nil {
-			return fmt.Errorf("creating ingest job: %w", err)
+			return nil, fmt.Errorf("creating ingest job: %w", err)
 		}
 		// Use the job ID returned by the gateway
 		jobID = resp.Job.Id
@@ -303,14 +366,17 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 		TotalFiles: len(files),
 		StartedAt:  time.Now(),
 		Errors:     []emailFileError{},
+		FileStatus: make(map[string]string, len(files)),
 	}
 
 	// Process files
+	bar := newProgressBar(progressOut(format), len(files), "files")
 	if emailConcurrency == 1 {
-		processEmailsSequential(ctx, client, parser, tenantID, jobID, files, progress, result, format)
+		processEmailsSequential(ctx, client, parser, tenantID, jobID, files, progress, bar, result)
 	} else {
-		processEmailsParallel(ctx, client, parser, tenantID, jobID, files, progress, result, format)
+		processEmailsParallel(ctx, client, parser, tenantID, jobID, files, progress, bar, result)
 	}
+	bar.Finish()
 
 	result.CompletedAt = time.Now()
 	result.ImportedCount = progress.ImportedCount
@@ -319,7 +385,7 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 	result.Success = result.FailedCount == 0
 
 	// Complete job via gRPC
-	_, err = client.CompleteIngestJob(ctx, &ingestv1.CompleteIngestJobRequest{
+	_, err := client.CompleteIngestJob(ctx, &ingestv1.CompleteIngestJobRequest{
 		JobId:        jobID,
 		Success:      result.Success,
 		ErrorMessage: "",
@@ -343,21 +409,12 @@ func runIngestEmail(ctx context.Context, deps *IngestCommandDeps, path string) e
 		}
 	}
 
-	// Display results
-	fmt.Println()
-	displayEmailResults(result, format)
-
-	// Return error if there were failures
-	if result.FailedCount > 0 {
-		return fmt.Errorf("%d files failed to import", result.FailedCount)
-	}
-
-	return nil
+	return result, nil
 }
 
 // connectIngestToGateway creates a gRPC connection to the gateway service.
 func connectIngestToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -381,9 +438,11 @@ func connectIngestToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -431,60 +490,6 @@ func discoverEmailFiles(path string) ([]string, error) {
 	return files, nil
 }
 
-// runEmailDryRun processes files in dry-run mode (parse only, no gRPC calls).
-func runEmailDryRun(ctx context.Context, parser *eml.Parser, files []string, format config.OutputFormat) error {
-	progress := newEmailProgress(len(files))
-	result := &emailIngestResult{
-		JobID:      "dry-run",
-		TotalFiles: len(files),
-		StartedAt:  time.Now(),
-		Errors:     []emailFileError{},
-	}
-
-	for _, file := range files {
-		if ctx.Err() != nil {
-			break
-		}
-
-		progress.setCurrentFile(file)
-
-		// Parse the email
-		parseResult, err := parser.ParseFile(file)
-		if err != nil {
-			result.Errors = append(result.Errors, emailFileError{
-				FilePath: file,
-				Error:    err.Error(),
-			})
-			progress.recordFailed()
-			continue
-		}
-
-		email := parseResult.Email
-		fmt.Printf("  [DRY RUN] Would import: %s\n", filepath.Base(file))
-		fmt.Printf("            Subject: %s\n", truncateIngestString(email.Subject, 60))
-		fmt.Printf("            From: %s\n", email.From.Email)
-		fmt.Printf("            Date: %s\n", email.Date.Format(time.RFC3339))
-		if email.HasAttachments() {
-			fmt.Printf("            Attachments: %d\n", email.AttachmentCount())
-		}
-		fmt.Println()
-
-		progress.recordImported()
-	}
-
-	result.CompletedAt = time.Now()
-	result.ImportedCount = progress.ImportedCount
-	result.SkippedCount = progress.SkippedCount
-	result.FailedCount = progress.FailedCount
-	result.Success = result.FailedCount == 0
-
-	fmt.Println()
-	fmt.Println("=== DRY RUN COMPLETE ===")
-	displayEmailResults(result, format)
-
-	return nil
-}
-
 // processEmailsSequential processes files one at a time.
 func processEmailsSequential(
 	ctx context.Context,
@@ -493,8 +498,8 @@ func processEmailsSequential(
 	tenantID, jobID string,
 	files []string,
 	progress *emailProgress,
+	bar *progressBar,
 	result *emailIngestResult,
-	format config.OutputFormat,
 ) {
 	for _, file := range files {
 		if ctx.Err() != nil {
@@ -504,7 +509,7 @@ func processEmailsSequential(
 		progress.setCurrentFile(file)
 		outcome := processEmailFile(ctx, client, parser, tenantID, jobID, file)
 		recordEmailOutcome(ctx, client, jobID, file, outcome, progress, result)
-		displayEmailProgress(progress, format)
+		displayEmailProgress(progress, bar)
 	}
 }
 
@@ -516,8 +521,8 @@ func processEmailsParallel(
 	tenantID, jobID string,
 	files []string,
 	progress *emailProgress,
+	bar *progressBar,
 	result *emailIngestResult,
-	format config.OutputFormat,
 ) {
 	filesCh := make(chan string, len(files))
 	resultsCh := make(chan emailFileOutcome, len(files))
@@ -555,7 +560,7 @@ func processEmailsParallel(
 	// Collect results
 	for fo := range resultsCh {
 		recordEmailOutcome(ctx, client, jobID, fo.file, fo.outcome, progress, result)
-		displayEmailProgress(progress, format)
+		displayEmailProgress(progress, bar)
 	}
 }
 
@@ -718,6 +723,10 @@ func recordEmailOutcome(
 	progress *emailProgress,
 	result *emailIngestResult,
 ) {
+	if result.FileStatus != nil {
+		result.FileStatus[filePath] = o.status
+	}
+
 	switch o.status {
 	case "imported":
 		progress.recordImported()
@@ -768,32 +777,13 @@ func recordEmailOutcome(
 	}
 }
 
-// displayEmailProgress shows progress updates.
-func displayEmailProgress(progress *emailProgress, format config.OutputFormat) {
-	if format != config.OutputFormatText {
-		return
-	}
-
+// displayEmailProgress updates the progress bar with the latest counts.
+func displayEmailProgress(progress *emailProgress, bar *progressBar) {
 	snapshot := progress.snapshot()
-	if snapshot.ProcessedCount == 0 {
-		return
-	}
-
-	// Simple progress line
-	pct := snapshot.percentComplete()
-	remaining := ""
-	if snapshot.EstimatedRemainingSeconds != nil {
-		remaining = fmt.Sprintf(" ETA: %s", formatDuration(time.Duration(*snapshot.EstimatedRemainingSeconds)*time.Second))
-	}
-
-	fmt.Printf("\r  [%3.0f%%] %d/%d files (imported: %d, skipped: %d, failed: %d)%s   ",
-		pct,
-		snapshot.ProcessedCount,
-		snapshot.TotalFiles,
+	bar.Set(snapshot.ProcessedCount, fmt.Sprintf("(imported: %d, skipped: %d, failed: %d)",
 		snapshot.ImportedCount,
 		snapshot.SkippedCount,
-		snapshot.FailedCount,
-		remaining)
+		snapshot.FailedCount))
 }
 
 // displayEmailResults shows the final results.
@@ -904,7 +894,7 @@ func outputEmailYAML(result *emailIngestResult) {
 		CompletedAt: result.CompletedAt.Format(time.RFC3339),
 		ContentIDs:  result.ContentIDs,
 	}
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	if err := enc.Encode(output); err != nil {
 		fmt.Fprintf(os.Stderr, "Error encoding YAML: %v\n", err)
 	}
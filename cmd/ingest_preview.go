@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/ingest/eml"
+)
+
+// existingSourcesPageSize is the page size used to list existing email
+// sources for a dry-run duplicate check.
+const existingSourcesPageSize = 1000
+
+// existingEmailSources indexes the tenant's ingested emails by the
+// identifiers the gateway deduplicates on.
+type existingEmailSources struct {
+	byContentHash map[string]string // content hash -> content ID
+	byMessageID   map[string]string // message ID -> content ID
+}
+
+// emailDryRunFile is one file scanned by an email dry run.
+type emailDryRunFile struct {
+	FilePath    string
+	MessageID   string
+	ContentHash string
+	Subject     string
+	From        string
+	Date        time.Time
+	Attachments int
+	ParseError  error
+}
+
+// EmailDuplicateFile is a file a dry run found to be already ingested, or
+// repeated within the scanned files.
+type EmailDuplicateFile struct {
+	FilePath  string `json:"file_path" yaml:"file_path"`
+	MatchedBy string `json:"matched_by" yaml:"matched_by"`
+	ContentID string `json:"content_id,omitempty" yaml:"content_id,omitempty"`
+	SameAs    string `json:"same_as,omitempty" yaml:"same_as,omitempty"`
+}
+
+// EmailUnsupportedFile is a file a dry run found that would not be ingested.
+type EmailUnsupportedFile struct {
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Reason   string `json:"reason" yaml:"reason"`
+}
+
+// EmailDryRunPreview summarizes what an email ingest would do.
+type EmailDryRunPreview struct {
+	New              int                    `json:"new" yaml:"new"`
+	Duplicates       int                    `json:"duplicates" yaml:"duplicates"`
+	Unsupported      int                    `json:"unsupported" yaml:"unsupported"`
+	CheckedExisting  bool                   `json:"checked_existing" yaml:"checked_existing"`
+	NewFiles         []string               `json:"new_files,omitempty" yaml:"new_files,omitempty"`
+	DuplicateFiles   []EmailDuplicateFile   `json:"duplicate_files,omitempty" yaml:"duplicate_files,omitempty"`
+	UnsupportedFiles []EmailUnsupportedFile `json:"unsupported_files,omitempty" yaml:"unsupported_files,omitempty"`
+}
+
+// runEmailDryRun parses files without ingesting them and reports how many
+// are new, already ingested, or unsupported. Existing sources are checked
+// through the gateway; if it is unreachable the preview covers parsing and
+// duplicates within the scanned files only.
+func runEmailDryRun(ctx context.Context, cfg *config.CLIConfig, parser *eml.Parser, tenantID, path string, files []string, format config.OutputFormat) error {
+	scanned := make([]emailDryRunFile, 0, len(files))
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		f := emailDryRunFile{FilePath: file}
+		parseResult, err := parser.ParseFile(file)
+		if err != nil {
+			f.ParseError = err
+		} else {
+			email := parseResult.Email
+			f.MessageID = email.MessageID
+			f.ContentHash = email.ContentHash
+			f.Subject = email.Subject
+			f.From = email.From.Email
+			f.Date = email.Date
+			f.Attachments = email.AttachmentCount()
+		}
+		scanned = append(scanned, f)
+	}
+
+	existing, err := loadExistingEmailSources(ctx, cfg, tenantID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not checking against existing sources: %v\n", err)
+	}
+
+	otherFiles, err := discoverUnsupportedFiles(path)
+	if err != nil {
+		return fmt.Errorf("discovering files: %w", err)
+	}
+
+	preview := buildEmailDryRunPreview(scanned, existing, otherFiles)
+
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(preview)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(os.Stdout).Encode(preview)
+	default:
+		outputEmailDryRunText(os.Stdout, scanned, preview, emailListDuplicates)
+		return nil
+	}
+}
+
+// buildEmailDryRunPreview classifies scanned files as new, duplicate or
+// unsupported. existing may be nil when the gateway was not checked.
+func buildEmailDryRunPreview(scanned []emailDryRunFile, existing *existingEmailSources, otherFiles []string) EmailDryRunPreview {
+	preview := EmailDryRunPreview{CheckedExisting: existing != nil}
+	seenHash := make(map[string]string)
+	seenMessageID := make(map[string]string)
+
+	for _, f := range scanned {
+		if f.ParseError != nil {
+			preview.UnsupportedFiles = append(preview.UnsupportedFiles, EmailUnsupportedFile{FilePath: f.FilePath, Reason: f.ParseError.Error()})
+			continue
+		}
+
+		if dup, ok := existing.match(f); ok {
+			preview.DuplicateFiles = append(preview.DuplicateFiles, dup)
+		} else if first, ok := seenMessageID[f.MessageID]; ok {
+			preview.DuplicateFiles = append(preview.DuplicateFiles, EmailDuplicateFile{FilePath: f.FilePath, MatchedBy: "message_id", SameAs: first})
+		} else if first, ok := seenHash[f.ContentHash]; ok {
+			preview.DuplicateFiles = append(preview.DuplicateFiles, EmailDuplicateFile{FilePath: f.FilePath, MatchedBy: "content_hash", SameAs: first})
+		} else {
+			preview.NewFiles = append(preview.NewFiles, f.FilePath)
+		}
+
+		if _, ok := seenMessageID[f.MessageID]; !ok {
+			seenMessageID[f.MessageID] = f.FilePath
+		}
+		if _, ok := seenHash[f.ContentHash]; !ok {
+			seenHash[f.ContentHash] = f.FilePath
+		}
+	}
+
+	for _, file := range otherFiles {
+		preview.UnsupportedFiles = append(preview.UnsupportedFiles, EmailUnsupportedFile{FilePath: file, Reason: "not an .eml file"})
+	}
+
+	preview.New = len(preview.NewFiles)
+	preview.Duplicates = len(preview.DuplicateFiles)
+	preview.Unsupported = len(preview.UnsupportedFiles)
+	return preview
+}
+
+// match reports whether a file is already ingested, by message ID or
+// content hash.
+func (s *existingEmailSources) match(f emailDryRunFile) (EmailDuplicateFile, bool) {
+	if s == nil {
+		return EmailDuplicateFile{}, false
+	}
+	if id, ok := s.byMessageID[f.MessageID]; ok && f.MessageID != "" {
+		return EmailDuplicateFile{FilePath: f.FilePath, MatchedBy: "message_id", ContentID: id}, true
+	}
+	if id, ok := s.byContentHash[f.ContentHash]; ok && f.ContentHash != "" {
+		return EmailDuplicateFile{FilePath: f.FilePath, MatchedBy: "content_hash", ContentID: id}, true
+	}
+	return EmailDuplicateFile{}, false
+}
+
+// loadExistingEmailSources lists the tenant's email content items and
+// indexes them by content hash and message ID.
+func loadExistingEmailSources(ctx context.Context, cfg *config.CLIConfig, tenantID string) (*existingEmailSources, error) {
+	conn, err := connectIngestToGateway(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := contentv1.NewContentProcessorServiceClient(conn)
+	existing := &existingEmailSources{
+		byContentHash: make(map[string]string),
+		byMessageID:   make(map[string]string),
+	}
+	emailType := contentv1.ContentType_CONTENT_TYPE_EMAIL
+	req := &contentv1.ListContentItemsRequest{
+		TenantId:          tenantID,
+		PageSize:          existingSourcesPageSize,
+		ContentTypeFilter: &emailType,
+	}
+	for {
+		resp, err := client.ListContentItems(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("listing existing emails: %w", err)
+		}
+		for _, item := range resp.GetItems() {
+			if item.GetContentHash() != "" {
+				existing.byContentHash[item.GetContentHash()] = item.GetId()
+			}
+			if id := item.GetMetadata()["message_id"]; id != "" {
+				existing.byMessageID[id] = item.GetId()
+			}
+		}
+		if resp.GetNextPageToken() == "" {
+			return existing, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// discoverUnsupportedFiles returns the files under path that email ingest
+// would ignore: anything that is not an .eml file. Hidden files are left
+// out.
+func discoverUnsupportedFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") || strings.HasSuffix(strings.ToLower(d.Name()), ".eml") {
+			return nil
+		}
+		absPath, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, absPath)
+		return nil
+	})
+	return files, err
+}
+
+// outputEmailDryRunText prints the files that would be imported, then the
+// counts. Duplicates are listed only when listDuplicates is set.
+func outputEmailDryRunText(w io.Writer, scanned []emailDryRunFile, preview EmailDryRunPreview, listDuplicates bool) {
+	isNew := make(map[string]bool, len(preview.NewFiles))
+	for _, f := range preview.NewFiles {
+		isNew[f] = true
+	}
+	for _, f := range scanned {
+		if !isNew[f.FilePath] {
+			continue
+		}
+		fmt.Fprintf(w, "  [DRY RUN] Would import: %s\n", filepath.Base(f.FilePath))
+		fmt.Fprintf(w, "            Subject: %s\n", truncateIngestString(f.Subject, 60))
+		fmt.Fprintf(w, "            From: %s\n", f.From)
+		fmt.Fprintf(w, "            Date: %s\n", f.Date.Format(time.RFC3339))
+		if f.Attachments > 0 {
+			fmt.Fprintf(w, "            Attachments: %d\n", f.Attachments)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if listDuplicates && len(preview.DuplicateFiles) > 0 {
+		fmt.Fprintln(w, "Would skip (duplicates):")
+		for _, d := range preview.DuplicateFiles {
+			if d.ContentID != "" {
+				fmt.Fprintf(w, "  %s  (%s matches %s)\n", d.FilePath, d.MatchedBy, d.ContentID)
+			} else {
+				fmt.Fprintf(w, "  %s  (%s same as %s)\n", d.FilePath, d.MatchedBy, filepath.Base(d.SameAs))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(preview.UnsupportedFiles) > 0 {
+		fmt.Fprintln(w, "Unsupported:")
+		for _, u := range preview.UnsupportedFiles {
+			fmt.Fprintf(w, "  %s  (%s)\n", u.FilePath, u.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "=== DRY RUN COMPLETE ===")
+	fmt.Fprintf(w, "%d new, %d duplicates, %d unsupported\n", preview.New, preview.Duplicates, preview.Unsupported)
+	if !preview.CheckedExisting {
+		fmt.Fprintln(w, "Note: existing sources were not checked; duplicates are within these files only.")
+	} else if preview.Duplicates > 0 && !listDuplicates {
+		fmt.Fprintln(w, "Use --list-duplicates to see which files would be skipped.")
+	}
+}
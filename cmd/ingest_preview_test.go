@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildEmailDryRunPreview(t *testing.T) {
+	scanned := []emailDryRunFile{
+		{FilePath: "/mail/a.eml", MessageID: "<a@x>", ContentHash: "ha"},
+		{FilePath: "/mail/b.eml", MessageID: "<b@x>", ContentHash: "hb"},
+		{FilePath: "/mail/c.eml", MessageID: "<c@x>", ContentHash: "hc"},
+		{FilePath: "/mail/a-copy.eml", MessageID: "<a@x>", ContentHash: "ha"},
+		{FilePath: "/mail/broken.eml", ParseError: errors.New("parsing: malformed header")},
+	}
+	existing := &existingEmailSources{
+		byContentHash: map[string]string{"hc": "em-0003"},
+		byMessageID:   map[string]string{"<b@x>": "em-0002"},
+	}
+
+	preview := buildEmailDryRunPreview(scanned, existing, []string{"/mail/notes.txt"})
+
+	if preview.New != 1 || preview.Duplicates != 3 || preview.Unsupported != 2 || !preview.CheckedExisting {
+		t.Fatalf("preview = %d new, %d duplicates, %d unsupported, want 1, 3, 2", preview.New, preview.Duplicates, preview.Unsupported)
+	}
+	want := []EmailDuplicateFile{
+		{FilePath: "/mail/b.eml", MatchedBy: "message_id", ContentID: "em-0002"},
+		{FilePath: "/mail/c.eml", MatchedBy: "content_hash", ContentID: "em-0003"},
+		{FilePath: "/mail/a-copy.eml", MatchedBy: "message_id", SameAs: "/mail/a.eml"},
+	}
+	for i, d := range preview.DuplicateFiles {
+		if d != want[i] {
+			t.Errorf("duplicate %d = %+v, want %+v", i, d, want[i])
+		}
+	}
+
+	unchecked := buildEmailDryRunPreview(scanned, nil, nil)
+	if unchecked.CheckedExisting || unchecked.New != 3 || unchecked.Duplicates != 1 {
+		t.Errorf("without existing sources = %+v, want 3 new and the in-batch duplicate", unchecked)
+	}
+}
+
+func TestOutputEmailDryRunText_ListDuplicates(t *testing.T) {
+	scanned := []emailDryRunFile{
+		{FilePath: "/mail/a.eml", MessageID: "<a@x>", ContentHash: "ha", Subject: "Status"},
+		{FilePath: "/mail/b.eml", MessageID: "<b@x>", ContentHash: "hb"},
+	}
+	existing := &existingEmailSources{byMessageID: map[string]string{"<b@x>": "em-0002"}}
+	preview := buildEmailDryRunPreview(scanned, existing, nil)
+
+	var buf bytes.Buffer
+	outputEmailDryRunText(&buf, scanned, preview, false)
+	if got := buf.String(); strings.Contains(got, "/mail/b.eml") || !strings.Contains(got, "1 new, 1 duplicates, 0 unsupported") {
+		t.Errorf("output without --list-duplicates:\n%s", got)
+	}
+
+	buf.Reset()
+	outputEmailDryRunText(&buf, scanned, preview, true)
+	if got := buf.String(); !strings.Contains(got, "/mail/b.eml  (message_id matches em-0002)") {
+		t.Errorf("output with --list-duplicates:\n%s", got)
+	}
+}
+
+func TestDiscoverUnsupportedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.eml", "B.EML", "notes.txt", ".DS_Store"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := discoverUnsupportedFiles(dir)
+	if err != nil {
+		t.Fatalf("discoverUnsupportedFiles() error = %v", err)
+	}
+	if len(files) != 1 || filepath.Base(files[0]) != "notes.txt" {
+		t.Errorf("files = %v, want only notes.txt", files)
+	}
+}
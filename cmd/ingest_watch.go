@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/ingest/eml"
+)
+
+// defaultEmailPollInterval is how often 'ingest email --watch-dir' checks
+// the directory for new files.
+const defaultEmailPollInterval = 10 * time.Second
+
+// ingestManifestDir is the directory under the config dir holding watch
+// manifests, one per watched directory, tenant and source tag.
+const ingestManifestDir = "ingest-manifests"
+
+// watchedFile is the size and modification time of a file when it was seen.
+type watchedFile struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ingestManifestEntry is one line of an ingest manifest.
+type ingestManifestEntry struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Status  string    `json:"status"`
+}
+
+// ingestManifest records the files a watch has ingested, one JSON entry per
+// line, appended as each file is done so an interrupted watch loses nothing.
+type ingestManifest struct {
+	path  string
+	file  *os.File
+	files map[string]watchedFile
+}
+
+// emailWatchManifestPath returns the manifest path for watching dir into a
+// tenant and source tag.
+func emailWatchManifestPath(dir, tenantID, sourceTag string) (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(absDir + "\x00" + tenantID + "\x00" + sourceTag))
+	return filepath.Join(configDir, ingestManifestDir, hex.EncodeToString(sum[:8])+".jsonl"), nil
+}
+
+// openIngestManifest loads the manifest at path, creating it if needed, and
+// opens it for appending. Unreadable lines are skipped.
+func openIngestManifest(path string) (*ingestManifest, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("creating manifest directory: %w", err)
+	}
+
+	m := &ingestManifest{path: path, files: make(map[string]watchedFile)}
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e ingestManifestEntry
+			if json.Unmarshal(scanner.Bytes(), &e) == nil && e.Path != "" {
+				m.files[e.Path] = watchedFile{Size: e.Size, ModTime: e.ModTime}
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening manifest: %w", err)
+	}
+	m.file = f
+	return m, nil
+}
+
+// Has reports whether the file was ingested as it is now.
+func (m *ingestManifest) Has(path string, state watchedFile) bool {
+	recorded, ok := m.files[path]
+	return ok && recorded.Size == state.Size && recorded.ModTime.Equal(state.ModTime)
+}
+
+// Record appends an ingested file to the manifest.
+func (m *ingestManifest) Record(path string, state watchedFile, status string) error {
+	data, err := json.Marshal(ingestManifestEntry{Path: path, Size: state.Size, ModTime: state.ModTime, Status: status})
+	if err != nil {
+		return err
+	}
+	if _, err := m.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	m.files[path] = state
+	return nil
+}
+
+// Close closes the manifest file.
+func (m *ingestManifest) Close() error {
+	return m.file.Close()
+}
+
+// emailWatchState tracks files between polls: files seen changing are held
+// back until they settle, and files that failed are not retried until they
+// change.
+type emailWatchState struct {
+	settle  time.Duration
+	pending map[string]watchedFile
+	failed  map[string]watchedFile
+}
+
+func newEmailWatchState(settle time.Duration) *emailWatchState {
+	return &emailWatchState{
+		settle:  settle,
+		pending: make(map[string]watchedFile),
+		failed:  make(map[string]watchedFile),
+	}
+}
+
+// ready returns the scanned files that should be ingested now, sorted. A
+// file is ready when it is not in the manifest and has not failed as it is
+// now, and has either stayed the same since the previous poll or was last
+// modified at least one settle period ago.
+func (s *emailWatchState) ready(scan map[string]watchedFile, manifest *ingestManifest, now time.Time) []string {
+	var files []string
+	for path, state := range scan {
+		if manifest.Has(path, state) {
+			continue
+		}
+		if failed, ok := s.failed[path]; ok && failed == state {
+			continue
+		}
+		if prev, ok := s.pending[path]; (ok && prev == state) || now.Sub(state.ModTime) >= s.settle {
+			delete(s.pending, path)
+			files = append(files, path)
+			continue
+		}
+		s.pending[path] = state
+	}
+	for path := range s.pending {
+		if _, ok := scan[path]; !ok {
+			delete(s.pending, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// scanEmailDir returns the .eml files under dir with their size and
+// modification time.
+func scanEmailDir(dir string) (map[string]watchedFile, error) {
+	files, err := discoverEmailFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	scan := make(map[string]watchedFile, len(files))
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			// Removed between the walk and the stat.
+			continue
+		}
+		scan[path] = watchedFile{Size: info.Size(), ModTime: info.ModTime()}
+	}
+	return scan, nil
+}
+
+// runEmailWatch ingests the .eml files in dir and keeps polling for new ones
+// until ctx is cancelled. Each poll that finds new files runs them as one
+// ingest job.
+func runEmailWatch(ctx context.Context, conn *grpc.ClientConn, parser *eml.Parser, tenantID, dir string, format config.OutputFormat) error {
+	manifestPath, err := emailWatchManifestPath(dir, tenantID, emailSource)
+	if err != nil {
+		return err
+	}
+	manifest, err := openIngestManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	defer manifest.Close()
+
+	fmt.Printf("Watching %s for .eml files (Ctrl+C to stop)\n", dir)
+	fmt.Printf("  Manifest: %s (%d files already ingested)\n\n", manifestPath, len(manifest.files))
+
+	state := newEmailWatchState(emailPollInterval)
+	var imported, skipped, failed int
+
+	ticker := time.NewTicker(emailPollInterval)
+	defer ticker.Stop()
+	for {
+		scan, err := scanEmailDir(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: scanning %s: %v\n", dir, err)
+		}
+
+		if files := state.ready(scan, manifest, time.Now()); len(files) > 0 && ctx.Err() == nil {
+			result, err := ingestEmailFiles(ctx, conn, parser, tenantID, dir, files, "", format)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v (will retry)\n", err)
+			} else {
+				for _, path := range files {
+					status := result.FileStatus[path]
+					// After cancellation, files the workers never started are
+					// reported as skipped; only trust skips from a full batch.
+					if status == "imported" || (status == "skipped" && ctx.Err() == nil) {
+						if err := manifest.Record(path, scan[path], status); err != nil {
+							fmt.Fprintf(os.Stderr, "Warning: recording %s in manifest: %v\n", path, err)
+						}
+					} else if status == "failed" {
+						state.failed[path] = scan[path]
+					}
+				}
+				for _, e := range result.Errors {
+					fmt.Fprintf(os.Stderr, "  Failed: %s: %s\n", e.FilePath, e.Error)
+				}
+
+				imported += result.ImportedCount
+				skipped += result.SkippedCount
+				failed += result.FailedCount
+				fmt.Printf("[%s] %d new files: %d imported, %d skipped, %d failed (total: %d imported, %d skipped, %d failed)\n",
+					time.Now().Format("15:04:05"), len(files), result.ImportedCount, result.SkippedCount, result.FailedCount,
+					imported, skipped, failed)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\nStopped watching %s: %d imported, %d skipped, %d failed\n", dir, imported, skipped, failed)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIngestManifest_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ingestManifestDir, "watch.jsonl")
+	state := watchedFile{Size: 120, ModTime: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)}
+
+	m, err := openIngestManifest(path)
+	if err != nil {
+		t.Fatalf("openIngestManifest() error = %v", err)
+	}
+	if err := m.Record("/mail/a.eml", state, "imported"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	m.Close()
+
+	m, err = openIngestManifest(path)
+	if err != nil {
+		t.Fatalf("reopening manifest: %v", err)
+	}
+	defer m.Close()
+	if !m.Has("/mail/a.eml", state) {
+		t.Error("reopened manifest is missing the recorded file")
+	}
+	if m.Has("/mail/a.eml", watchedFile{Size: 130, ModTime: state.ModTime}) {
+		t.Error("manifest matches a file that has since changed")
+	}
+}
+
+func TestEmailWatchState_Ready(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m, err := openIngestManifest(filepath.Join(t.TempDir(), "watch.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	old := watchedFile{Size: 10, ModTime: now.Add(-time.Hour)}
+	if err := m.Record("/mail/done.eml", old, "imported"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newEmailWatchState(10 * time.Second)
+	s.failed["/mail/bad.eml"] = old
+
+	writing := watchedFile{Size: 5, ModTime: now.Add(-time.Second)}
+	scan := map[string]watchedFile{
+		"/mail/done.eml":    old,
+		"/mail/bad.eml":     old,
+		"/mail/settled.eml": old,
+		"/mail/new.eml":     writing,
+	}
+	if got := strings.Join(s.ready(scan, m, now), ","); got != "/mail/settled.eml" {
+		t.Errorf("first poll ready = %s, want only the settled file", got)
+	}
+	if err := m.Record("/mail/settled.eml", old, "imported"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Still being written: the size changed since the last poll.
+	scan["/mail/new.eml"] = watchedFile{Size: 50, ModTime: now.Add(time.Second)}
+	if got := s.ready(scan, m, now.Add(2*time.Second)); len(got) != 0 {
+		t.Errorf("second poll ready = %v, want nothing while the file changes", got)
+	}
+
+	// Unchanged since the last poll.
+	if got := strings.Join(s.ready(scan, m, now.Add(4*time.Second)), ","); got != "/mail/new.eml" {
+		t.Errorf("third poll ready = %s, want the new file once it stopped changing", got)
+	}
+	if err := m.Record("/mail/new.eml", scan["/mail/new.eml"], "imported"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed file is retried once it changes.
+	scan["/mail/bad.eml"] = watchedFile{Size: 11, ModTime: now.Add(-time.Minute)}
+	if got := strings.Join(s.ready(scan, m, now.Add(6*time.Second)), ","); got != "/mail/bad.eml" {
+		t.Errorf("fourth poll ready = %s, want the changed failed file", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
 )
 
@@ -30,6 +31,8 @@ var acronymReviewTemplate string
 var (
 	initServerAddr     string
 	initNonInteractive bool
+	initImportFrom     string
+	initDryRun         bool
 )
 
 // NewInitCommand creates the init command.
@@ -51,12 +54,26 @@ Run this from your project directory. Global config goes to ~/.penf/,
 but context files (CLAUDE.md, preferences.md, processes/) are created
 in the current directory so Claude Code can find them.
 
-After init, run 'penf init entities' to seed known people, products, and glossary.`,
+After init, run 'penf init entities' to seed known people, products, and glossary.
+
+Importing:
+  --import-from bootstraps from something you already have:
+    - an export directory of .eml files or meeting transcripts (.vtt, Transcript_*.txt)
+    - a Gmail Takeout export (.mbox), which is pointed at Gmail sync instead
+    - a config .yaml from another penf install, or a .env file with PENF_* settings
+  Settings found are written to the config; exports produce a first ingest plan.
+  Use --dry-run to see what was detected and what would be done.
+
+Examples:
+  penf init --import-from ~/Downloads/mail-export --dry-run
+  penf init --import-from ./old-machine/config.yaml --non-interactive`,
 		RunE: runInit,
 	}
 
 	initCmd.Flags().StringVar(&initServerAddr, "server", "", "Gateway server address (host:port)")
 	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Skip prompts, use defaults or flags")
+	initCmd.Flags().StringVar(&initImportFrom, "import-from", "", "Bootstrap from an export directory, config .yaml, or .env file")
+	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "With --import-from, show what was detected without changing anything")
 
 	// Add subcommands
 	initCmd.AddCommand(NewInitEntitiesCommand())
@@ -65,6 +82,24 @@ After init, run 'penf init entities' to seed known people, products, and glossar
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initDryRun && initImportFrom == "" {
+		return fmt.Errorf("--dry-run requires --import-from")
+	}
+
+	// Detect import source before changing anything.
+	var importPlan *initImportPlan
+	if initImportFrom != "" {
+		plan, err := detectInitImport(initImportFrom)
+		if err != nil {
+			return err
+		}
+		importPlan = plan
+		if initDryRun {
+			printInitImportPlan(importPlan, true)
+			return nil
+		}
+	}
+
 	fmt.Println("Penfold CLI Initialization")
 	fmt.Println("==========================")
 	fmt.Println()
@@ -77,14 +112,18 @@ func runInit(cmd *cobra.Command, args []string) error {
 	serverAddr := initServerAddr
 	if serverAddr == "" && !initNonInteractive {
 		defaultAddr := config.DefaultServerAddress
-		if existingCfg != nil && existingCfg.ServerAddress != "" {
+		if imported := importPlan.importedValue("server_address"); imported != "" {
+			defaultAddr = imported
+		} else if existingCfg != nil && existingCfg.ServerAddress != "" {
 			defaultAddr = existingCfg.ServerAddress
 		}
 
 		serverAddr = promptWithDefault("Gateway server address", defaultAddr)
 	} else if serverAddr == "" {
 		serverAddr = config.DefaultServerAddress
-		if existingCfg != nil && existingCfg.ServerAddress != "" {
+		if imported := importPlan.importedValue("server_address"); imported != "" {
+			serverAddr = imported
+		} else if existingCfg != nil && existingCfg.ServerAddress != "" {
 			serverAddr = existingCfg.ServerAddress
 		}
 	}
@@ -100,6 +139,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Imported settings take precedence over the existing config.
+	if err := applyInitImportConfig(cfg, importPlan); err != nil {
+		return err
+	}
+
 	// Step 3: Test connection.
 	fmt.Println()
 	fmt.Printf("Testing connection to %s...\n", serverAddr)
@@ -173,6 +217,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println("  • Run 'penf health' to check system health")
 	fmt.Println()
 
+	if importPlan != nil {
+		printInitImportPlan(importPlan, false)
+	}
+
 	return nil
 }
 
@@ -211,7 +259,7 @@ func testGatewayConnection(serverAddr string) error {
 
 	conn, err := grpc.DialContext(ctx, serverAddr, opts...)
 	if err != nil {
-		return fmt.Errorf("connecting to gateway: %w", err)
+		return client.NewConnectError("gateway", err)
 	}
 	defer conn.Close()
 
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Import source kinds detected by 'penf init --import-from'.
+const (
+	initImportKindExport = "export"      // directory of .eml, transcripts, or a Gmail Takeout
+	initImportKindConfig = "penf-config" // YAML config file from another install
+	initImportKindEnv    = "env"         // .env file with PENF_* variables
+)
+
+// initImportPlan describes what 'penf init --import-from' detected and would do.
+type initImportPlan struct {
+	Path            string
+	Kind            string
+	ConfigChanges   []initConfigChange
+	EmailFiles      int
+	TranscriptFiles int
+	MboxFiles       int
+	IngestCommands  []string
+	Notes           []string
+}
+
+// initConfigChange is a single config value picked up from an import source.
+type initConfigChange struct {
+	Key   string
+	Value string
+	From  string
+}
+
+// initConfigEnvKeys maps config keys to the PENF_* environment variables that set them.
+var initConfigEnvKeys = map[string]string{
+	"server_address": "PENF_SERVER_ADDRESS",
+	"tenant_id":      "PENF_TENANT_ID",
+	"timeout":        "PENF_TIMEOUT",
+	"output_format":  "PENF_OUTPUT_FORMAT",
+	"insecure":       "PENF_INSECURE",
+}
+
+// initImportConfigKeys lists the config keys that can be imported, in display order.
+var initImportConfigKeys = []string{"server_address", "tenant_id", "timeout", "output_format", "insecure"}
+
+// detectInitImport inspects path and builds an import plan without changing anything.
+func detectInitImport(path string) (*initImportPlan, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("import path not found: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("accessing import path: %w", err)
+	}
+
+	plan := &initImportPlan{Path: path}
+
+	if !info.IsDir() {
+		name := strings.ToLower(filepath.Base(path))
+		switch {
+		case strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml"):
+			plan.Kind = initImportKindConfig
+			plan.ConfigChanges, err = readInitImportYAML(path)
+		case name == ".env" || strings.HasSuffix(name, ".env"):
+			plan.Kind = initImportKindEnv
+			plan.ConfigChanges, err = readInitImportEnv(path)
+		case strings.HasSuffix(name, ".mbox"):
+			plan.Kind = initImportKindExport
+			plan.MboxFiles = 1
+		case strings.HasSuffix(name, ".eml"):
+			plan.Kind = initImportKindExport
+			plan.EmailFiles = 1
+		case isTranscriptFileName(name):
+			plan.Kind = initImportKindExport
+			plan.TranscriptFiles = 1
+		default:
+			return nil, fmt.Errorf("unsupported import file: %s (expected a config .yaml, .env, .mbox, .eml, or transcript)", path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		plan.Kind = initImportKindExport
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			name := strings.ToLower(d.Name())
+			switch {
+			case strings.HasSuffix(name, ".eml"):
+				plan.EmailFiles++
+			case strings.HasSuffix(name, ".mbox"):
+				plan.MboxFiles++
+			case isTranscriptFileName(name):
+				plan.TranscriptFiles++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning import directory: %w", err)
+		}
+	}
+
+	if plan.Kind == initImportKindExport {
+		if plan.EmailFiles == 0 && plan.TranscriptFiles == 0 && plan.MboxFiles == 0 {
+			return nil, fmt.Errorf("nothing to import in %s (no .eml, .mbox, or meeting transcript files found)", path)
+		}
+		planInitImportIngest(plan)
+	} else if len(plan.ConfigChanges) == 0 {
+		return nil, fmt.Errorf("no penf settings found in %s", path)
+	}
+
+	return plan, nil
+}
+
+// planInitImportIngest fills in the ingest commands for a detected export.
+func planInitImportIngest(plan *initImportPlan) {
+	source := initImportSourceTag(plan.Path)
+	quoted := strconv.Quote(plan.Path)
+
+	if plan.EmailFiles > 0 {
+		plan.IngestCommands = append(plan.IngestCommands,
+			fmt.Sprintf("penf ingest email %s --source %s --dry-run", quoted, source),
+			fmt.Sprintf("penf ingest email %s --source %s", quoted, source))
+	}
+	if plan.TranscriptFiles > 0 {
+		plan.IngestCommands = append(plan.IngestCommands,
+			fmt.Sprintf("penf ingest meeting %s --source %s --dry-run", quoted, source),
+			fmt.Sprintf("penf ingest meeting %s --source %s", quoted, source))
+	}
+	if plan.MboxFiles > 0 {
+		plan.Notes = append(plan.Notes,
+			fmt.Sprintf("Gmail Takeout export detected (%d .mbox files). Mailbox files cannot be ingested directly;", plan.MboxFiles),
+			"connect the account instead with 'penf ingest gmail auth' and run 'penf ingest gmail sync'.")
+		plan.IngestCommands = append(plan.IngestCommands,
+			"penf ingest gmail auth --credentials <credentials.json> --tenant <tenant>",
+			"penf ingest gmail sync")
+	}
+}
+
+var initSourceTagInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// initImportSourceTag derives an ingest source tag from the import path.
+func initImportSourceTag(path string) string {
+	base := strings.ToLower(filepath.Base(filepath.Clean(path)))
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	tag := strings.Trim(initSourceTagInvalid.ReplaceAllString(base, "-"), "-")
+	if tag == "" {
+		return "import"
+	}
+	return "import-" + tag
+}
+
+// isTranscriptFileName reports whether a lowercase file name looks like a meeting transcript.
+func isTranscriptFileName(name string) bool {
+	return strings.HasSuffix(name, ".vtt") ||
+		(strings.HasPrefix(name, "transcript_") && strings.HasSuffix(name, ".txt"))
+}
+
+// readInitImportYAML reads importable settings from another penf config file.
+func readInitImportYAML(path string) ([]initConfigChange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	var changes []initConfigChange
+	for _, key := range initImportConfigKeys {
+		if v, ok := raw[key]; ok && v != nil {
+			changes = append(changes, initConfigChange{Key: key, Value: fmt.Sprint(v), From: key})
+		}
+	}
+	return changes, nil
+}
+
+// readInitImportEnv reads PENF_* settings from a .env file.
+func readInitImportEnv(path string) ([]initConfigChange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading env file: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading env file: %w", err)
+	}
+
+	var changes []initConfigChange
+	for _, key := range initImportConfigKeys {
+		envKey := initConfigEnvKeys[key]
+		if values[envKey] != "" {
+			changes = append(changes, initConfigChange{Key: key, Value: values[envKey], From: envKey})
+		}
+	}
+	return changes, nil
+}
+
+// importedValue returns the imported value for a config key, if any.
+func (p *initImportPlan) importedValue(key string) string {
+	if p == nil {
+		return ""
+	}
+	for _, c := range p.ConfigChanges {
+		if c.Key == key {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// applyInitImportConfig applies imported settings other than the server address,
+// which runInit resolves alongside the --server flag and prompt.
+func applyInitImportConfig(cfg *config.CLIConfig, plan *initImportPlan) error {
+	if plan == nil {
+		return nil
+	}
+	for _, c := range plan.ConfigChanges {
+		switch c.Key {
+		case "tenant_id":
+			cfg.TenantID = c.Value
+		case "timeout":
+			d, err := time.ParseDuration(c.Value)
+			if err != nil {
+				return fmt.Errorf("invalid imported timeout %q: %w", c.Value, err)
+			}
+			cfg.Timeout = d
+		case "output_format":
+			format := config.OutputFormat(c.Value)
+			if !format.IsValid() {
+				return fmt.Errorf("invalid imported output_format %q", c.Value)
+			}
+			cfg.OutputFormat = format
+		case "insecure":
+			cfg.Insecure = c.Value == "true" || c.Value == "1"
+		}
+	}
+	return nil
+}
+
+// printInitImportPlan prints what was detected and what init will do with it.
+func printInitImportPlan(plan *initImportPlan, dryRun bool) {
+	if dryRun {
+		fmt.Printf("Import plan for %s (dry run - nothing will be changed)\n", plan.Path)
+	} else {
+		fmt.Printf("Import plan for %s\n", plan.Path)
+	}
+	fmt.Println()
+
+	fmt.Println("Detected:")
+	switch plan.Kind {
+	case initImportKindConfig:
+		fmt.Println("  penf config file")
+	case initImportKindEnv:
+		fmt.Println("  environment file")
+	default:
+		if plan.EmailFiles > 0 {
+			fmt.Printf("  %d email files (.eml)\n", plan.EmailFiles)
+		}
+		if plan.TranscriptFiles > 0 {
+			fmt.Printf("  %d meeting transcripts\n", plan.TranscriptFiles)
+		}
+		if plan.MboxFiles > 0 {
+			fmt.Printf("  %d mailbox files (.mbox)\n", plan.MboxFiles)
+		}
+	}
+	fmt.Println()
+
+	if len(plan.ConfigChanges) > 0 {
+		fmt.Println("Configuration:")
+		for _, c := range plan.ConfigChanges {
+			fmt.Printf("  %-15s = %s  (from %s)\n", c.Key, c.Value, c.From)
+		}
+		fmt.Println()
+	}
+
+	for _, note := range plan.Notes {
+		fmt.Printf("  \033[33mNote:\033[0m %s\n", note)
+	}
+	if len(plan.Notes) > 0 {
+		fmt.Println()
+	}
+
+	if len(plan.IngestCommands) > 0 {
+		fmt.Println("Ingest plan:")
+		for i, c := range plan.IngestCommands {
+			fmt.Printf("  %d. %s\n", i+1, c)
+		}
+		fmt.Println()
+	}
+}
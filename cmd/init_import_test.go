@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func writeInitImportFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("creating dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+func TestDetectInitImport_ExportDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Mail Export")
+	writeInitImportFile(t, filepath.Join(dir, "inbox", "a.eml"), "Subject: a\n")
+	writeInitImportFile(t, filepath.Join(dir, "inbox", "b.EML"), "Subject: b\n")
+	writeInitImportFile(t, filepath.Join(dir, "meetings", "standup.vtt"), "WEBVTT\n")
+	writeInitImportFile(t, filepath.Join(dir, "notes.md"), "ignored\n")
+
+	plan, err := detectInitImport(dir)
+	if err != nil {
+		t.Fatalf("detectInitImport: %v", err)
+	}
+
+	if plan.EmailFiles != 2 || plan.TranscriptFiles != 1 || plan.MboxFiles != 0 {
+		t.Errorf("counts = eml:%d transcripts:%d mbox:%d, want 2/1/0", plan.EmailFiles, plan.TranscriptFiles, plan.MboxFiles)
+	}
+	if len(plan.IngestCommands) != 4 {
+		t.Fatalf("expected 4 ingest commands, got %v", plan.IngestCommands)
+	}
+	if !strings.Contains(plan.IngestCommands[0], "penf ingest email") || !strings.Contains(plan.IngestCommands[0], "--source import-mail-export --dry-run") {
+		t.Errorf("unexpected first command: %s", plan.IngestCommands[0])
+	}
+}
+
+func TestDetectInitImport_GmailTakeout(t *testing.T) {
+	dir := t.TempDir()
+	writeInitImportFile(t, filepath.Join(dir, "Takeout", "Mail", "All mail.mbox"), "From x\n")
+
+	plan, err := detectInitImport(dir)
+	if err != nil {
+		t.Fatalf("detectInitImport: %v", err)
+	}
+	if plan.MboxFiles != 1 || len(plan.Notes) == 0 {
+		t.Errorf("expected mbox detection with a note, got %+v", plan)
+	}
+	if plan.IngestCommands[len(plan.IngestCommands)-1] != "penf ingest gmail sync" {
+		t.Errorf("expected gmail sync in plan, got %v", plan.IngestCommands)
+	}
+}
+
+func TestDetectInitImport_EmptyDirectory(t *testing.T) {
+	if _, err := detectInitImport(t.TempDir()); err == nil {
+		t.Error("expected error for a directory with nothing to import")
+	}
+}
+
+func TestDetectInitImport_EnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeInitImportFile(t, path, "# penf\nexport PENF_SERVER_ADDRESS=gw.example.com:50051\nPENF_TENANT_ID=\"acme\"\nOTHER=1\n")
+
+	plan, err := detectInitImport(path)
+	if err != nil {
+		t.Fatalf("detectInitImport: %v", err)
+	}
+	if plan.importedValue("server_address") != "gw.example.com:50051" {
+		t.Errorf("server_address = %q", plan.importedValue("server_address"))
+	}
+	if plan.importedValue("tenant_id") != "acme" {
+		t.Errorf("tenant_id = %q", plan.importedValue("tenant_id"))
+	}
+}
+
+func TestDetectInitImport_ConfigYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeInitImportFile(t, path, "server_address: gw:50051\ntimeout: 45s\noutput_format: json\n")
+
+	plan, err := detectInitImport(path)
+	if err != nil {
+		t.Fatalf("detectInitImport: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := applyInitImportConfig(cfg, plan); err != nil {
+		t.Fatalf("applyInitImportConfig: %v", err)
+	}
+	if cfg.Timeout != 45*time.Second || cfg.OutputFormat != config.OutputFormatJSON {
+		t.Errorf("applied config = timeout %v, format %s", cfg.Timeout, cfg.OutputFormat)
+	}
+	if cfg.ServerAddress == "gw:50051" {
+		t.Error("server address should be left to runInit to resolve")
+	}
+}
+
+func TestInitCommandHasImportFlags(t *testing.T) {
+	cmd := NewInitCommand()
+	for _, flag := range []string{"import-from", "dry-run"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag on init", flag)
+		}
+	}
+}
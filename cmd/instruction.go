@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	instructionv1 "github.com/otherjamesbrown/penf-cli/api/proto/instruction/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -308,7 +307,7 @@ func runInstructionEdit(ctx context.Context, deps *InstructionCommandDeps, idStr
 // ==================== delete ====================
 
 func newInstructionDeleteCommand(deps *InstructionCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:     "delete <id>",
 		Short:   "Delete an instruction",
 		Aliases: []string{"rm", "remove"},
@@ -316,7 +315,7 @@ func newInstructionDeleteCommand(deps *InstructionCommandDeps) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInstructionDelete(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 func runInstructionDelete(ctx context.Context, deps *InstructionCommandDeps, idStr string) error {
@@ -351,6 +350,10 @@ func runInstructionDelete(ctx context.Context, deps *InstructionCommandDeps, idS
 		return fmt.Errorf("deleting instruction: %w", err)
 	}
 
+	if getInstructionOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, InstructionActionResult{ID: id, Action: "delete"}, fmt.Sprintf("Deleted instruction %d", id))
+	}
+
 	fmt.Printf("Deleted instruction %d.\n", id)
 	return nil
 }
@@ -358,27 +361,27 @@ func runInstructionDelete(ctx context.Context, deps *InstructionCommandDeps, idS
 // ==================== enable ====================
 
 func newInstructionEnableCommand(deps *InstructionCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "enable <id>",
 		Short: "Enable an instruction",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInstructionSetEnabled(cmd.Context(), deps, args[0], true)
 		},
-	}
+	})
 }
 
 // ==================== disable ====================
 
 func newInstructionDisableCommand(deps *InstructionCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "disable <id>",
 		Short: "Disable an instruction",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInstructionSetEnabled(cmd.Context(), deps, args[0], false)
 		},
-	}
+	})
 }
 
 func runInstructionSetEnabled(ctx context.Context, deps *InstructionCommandDeps, idStr string, enable bool) error {
@@ -413,6 +416,9 @@ func runInstructionSetEnabled(ctx context.Context, deps *InstructionCommandDeps,
 		if err != nil {
 			return fmt.Errorf("enabling instruction: %w", err)
 		}
+		if getInstructionOutputFormat(cfg) == config.OutputFormatJSON {
+			return writeOutcomeJSON(true, resp.Instruction, "Enabled instruction: "+resp.Instruction.Name)
+		}
 		fmt.Printf("Enabled instruction %d: %s\n", resp.Instruction.Id, resp.Instruction.Name)
 	} else {
 		resp, err := client.DisableInstruction(ctx, &instructionv1.DisableInstructionRequest{
@@ -422,6 +428,9 @@ func runInstructionSetEnabled(ctx context.Context, deps *InstructionCommandDeps,
 		if err != nil {
 			return fmt.Errorf("disabling instruction: %w", err)
 		}
+		if getInstructionOutputFormat(cfg) == config.OutputFormatJSON {
+			return writeOutcomeJSON(true, resp.Instruction, "Disabled instruction: "+resp.Instruction.Name)
+		}
 		fmt.Printf("Disabled instruction %d: %s\n", resp.Instruction.Id, resp.Instruction.Name)
 	}
 
@@ -491,6 +500,12 @@ func getTenantIDForInstruction(deps *InstructionCommandDeps) (string, error) {
 	return "", fmt.Errorf("tenant ID required: set PENF_TENANT_ID env var or tenant_id in config")
 }
 
+// InstructionActionResult is the JSON result of an instruction delete.
+type InstructionActionResult struct {
+	ID     int64  `json:"id"`
+	Action string `json:"action"`
+}
+
 func getInstructionOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if instructionOutput != "" {
 		return config.OutputFormat(instructionOutput)
@@ -649,7 +664,7 @@ func instructionJSON(v interface{}) error {
 }
 
 func instructionYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
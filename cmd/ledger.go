@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	ledgerv1 "github.com/otherjamesbrown/penf-cli/api/proto/ledger/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -904,7 +903,7 @@ func outputLedgerJSON(v interface{}) error {
 }
 
 func outputLedgerYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
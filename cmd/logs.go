@@ -6,11 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -56,6 +56,8 @@ type LogQuery struct {
 	Since    time.Time `json:"since,omitempty" yaml:"since,omitempty"`
 	Until    time.Time `json:"until,omitempty" yaml:"until,omitempty"`
 	Contains string    `json:"contains,omitempty" yaml:"contains,omitempty"`
+	Grep     string    `json:"grep,omitempty" yaml:"grep,omitempty"`
+	Invert   bool      `json:"invert,omitempty" yaml:"invert,omitempty"`
 	Limit    int       `json:"limit" yaml:"limit"`
 }
 
@@ -87,6 +89,10 @@ var (
 	logsFollow   bool
 	logsOutput   string
 	logsNoColor  bool
+	logsFile     logFileOptions
+	logsNoEcho   bool
+	logsGrep     string
+	logsInvert   bool
 )
 
 // NewLogsCommand creates the logs command.
@@ -116,6 +122,10 @@ Examples:
   # Search logs containing a specific term
   penf logs --contains="connection refused"
 
+  # Filter messages by regular expression, or exclude matches with --invert
+  penf logs --grep 'timeout after \d+ms'
+  penf logs --follow --grep 'health check' --invert
+
   # View logs from the last hour
   penf logs --since=1h
 
@@ -123,7 +133,13 @@ Examples:
   penf logs --follow
 
   # Combine filters
-  penf logs --service=orchestrator --level=warn --since=30m`,
+  penf logs --service=orchestrator --level=warn --since=30m
+
+  # Capture a long follow session to disk, rotating at 100MB and keeping 10 files
+  penf logs --follow --output-file run.log --rotate-size 100MB --max-files 10
+
+  # Write to the file only
+  penf logs --follow --output-file run.log --no-echo`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLogs(cmd.Context(), deps)
 		},
@@ -139,6 +155,8 @@ Examples:
 	cmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow logs in real-time")
 	cmd.Flags().StringVarP(&logsOutput, "output", "o", "", "Output format: text, json, yaml")
 	cmd.Flags().BoolVar(&logsNoColor, "no-color", false, "Disable colored output")
+	addLogFileFlags(cmd, &logsFile, &logsNoEcho)
+	addLogGrepFlags(cmd, &logsGrep, &logsInvert)
 
 	return cmd
 }
@@ -176,6 +194,15 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 		}
 	}
 
+	if err := validateLogFileFlags(logsFile, logsNoEcho, logsFollow, "--follow"); err != nil {
+		return err
+	}
+
+	grep, err := compileLogGrep(logsGrep, logsInvert)
+	if err != nil {
+		return err
+	}
+
 	// Determine output format.
 	outputFormat := cfg.OutputFormat
 	if logsOutput != "" {
@@ -191,6 +218,8 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 		Since:    since,
 		Until:    until,
 		Contains: logsContains,
+		Grep:     logsGrep,
+		Invert:   logsInvert,
 		Limit:    logsLimit,
 	}
 
@@ -203,13 +232,13 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 	deps.GRPCClient = grpcClient
 
 	if logsFollow {
-		return runLogsFollow(ctx, deps, query, outputFormat)
+		return runLogsFollow(ctx, deps, query, outputFormat, grep)
 	}
 
 	// Build filter for gRPC call.
 	filter := client.LogFilter{
-		Service:  query.Service,
-		Level:    query.Level,
+		Services: logFilterValues(query.Service),
+		Levels:   logFilterValues(query.Level),
 		Since:    query.Since,
 		Until:    query.Until,
 		Contains: query.Contains,
@@ -221,6 +250,12 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 		return fmt.Errorf("fetching logs: %w", err)
 	}
 
+	totalCount := int(resp.TotalCount)
+	if grep.active() {
+		resp.Entries = grep.filter(resp.Entries)
+		totalCount = len(resp.Entries)
+	}
+
 	// Convert client response to CLI response format.
 	entries := make([]LogEntry, len(resp.Entries))
 	for i, e := range resp.Entries {
@@ -236,7 +271,7 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 
 	response := LogsResponse{
 		Entries:    entries,
-		TotalCount: int(resp.TotalCount),
+		TotalCount: totalCount,
 		Truncated:  resp.Truncated,
 		Query:      query,
 		FetchedAt:  time.Now(),
@@ -246,20 +281,38 @@ func runLogs(ctx context.Context, deps *LogsCommandDeps) error {
 }
 
 // runLogsFollow streams logs in real-time.
-func runLogsFollow(ctx context.Context, deps *LogsCommandDeps, query LogQuery, outputFormat config.OutputFormat) error {
-	fmt.Println("Following logs (press Ctrl+C to stop)...")
-	fmt.Println()
+func runLogsFollow(ctx context.Context, deps *LogsCommandDeps, query LogQuery, outputFormat config.OutputFormat, grep logGrep) error {
+	var file *logFileWriter
+	if logsFile.Path != "" {
+		opts := logsFile
+		opts.JSON = outputFormat == config.OutputFormatJSON
+		w, err := openLogFileWriter(opts)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		file = w
+		fmt.Fprintf(os.Stderr, "Writing logs to %s\n", opts.Path)
+	}
+
+	if !logsNoEcho && !quietOutput {
+		fmt.Println("Following logs (press Ctrl+C to stop)...")
+		fmt.Println()
+	}
 
 	// Build filter for streaming.
 	filter := client.LogFilter{
-		Service:  query.Service,
-		Level:    query.Level,
+		Services: logFilterValues(query.Service),
+		Levels:   logFilterValues(query.Level),
 		Since:    time.Now(), // Start from now for follow mode
 		Contains: query.Contains,
 	}
 
 	// Stream logs with 1 second poll interval.
-	err := deps.GRPCClient.StreamLogs(ctx, filter, 1000, func(entry client.LogEntry) {
+	stream := func(ctx context.Context, callback func(client.LogEntry)) error {
+		return deps.GRPCClient.StreamLogs(ctx, filter, 1000, grep.wrap(callback))
+	}
+	err := teeLogStream(ctx, stream, file, logsNoEcho, func(entry client.LogEntry) {
 		logEntry := LogEntry{
 			Timestamp: entry.Timestamp,
 			Level:     LogLevel(entry.Level),
@@ -275,10 +328,101 @@ func runLogsFollow(ctx context.Context, deps *LogsCommandDeps, query LogQuery, o
 		return fmt.Errorf("streaming logs: %w", err)
 	}
 
-	fmt.Println("\nStopped following logs.")
+	if file != nil {
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("closing log file: %w", err)
+		}
+	}
+	if !logsNoEcho && !quietOutput {
+		fmt.Println("\nStopped following logs.")
+	}
 	return nil
 }
 
+// logGrep filters log entries by a regular expression on the message.
+type logGrep struct {
+	re     *regexp.Regexp
+	invert bool
+}
+
+// addLogGrepFlags registers --grep and --invert on a log command.
+func addLogGrepFlags(cmd *cobra.Command, pattern *string, invert *bool) {
+	cmd.Flags().StringVar(pattern, "grep", "", "Only show entries whose message matches this regular expression")
+	cmd.Flags().BoolVar(invert, "invert", false, "With --grep, show entries whose message does not match")
+}
+
+// compileLogGrep compiles --grep once up front. An empty pattern matches
+// every entry.
+func compileLogGrep(pattern string, invert bool) (logGrep, error) {
+	if pattern == "" {
+		if invert {
+			return logGrep{}, fmt.Errorf("--invert requires --grep")
+		}
+		return logGrep{}, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return logGrep{}, fmt.Errorf("invalid --grep pattern: %w", err)
+	}
+	return logGrep{re: re, invert: invert}, nil
+}
+
+// active reports whether a pattern was given.
+func (g logGrep) active() bool {
+	return g.re != nil
+}
+
+// keep reports whether an entry with this message passes the filter.
+func (g logGrep) keep(message string) bool {
+	if g.re == nil {
+		return true
+	}
+	return g.re.MatchString(message) != g.invert
+}
+
+// filter returns the entries that pass the filter.
+func (g logGrep) filter(entries []client.LogEntry) []client.LogEntry {
+	kept := []client.LogEntry{}
+	for _, e := range entries {
+		if g.keep(e.Message) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// wrap returns a stream callback that only passes on entries that pass the
+// filter.
+func (g logGrep) wrap(callback func(client.LogEntry)) func(client.LogEntry) {
+	if g.re == nil {
+		return callback
+	}
+	return func(e client.LogEntry) {
+		if g.keep(e.Message) {
+			callback(e)
+		}
+	}
+}
+
+// logFilterValues returns a single filter value as a list, or nil if unset.
+func logFilterValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// trimLogFilterValues trims multi-value filter flags and drops empty values.
+func trimLogFilterValues(values []string) []string {
+	var trimmed []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			trimmed = append(trimmed, v)
+		}
+	}
+	return trimmed
+}
+
 // logLevelMatches checks if entry level meets minimum level.
 func logLevelMatches(entryLevel, minLevel LogLevel) bool {
 	levels := map[LogLevel]int{
@@ -299,7 +443,7 @@ func outputLogs(format config.OutputFormat, response LogsResponse) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputLogsText(response)
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+// defaultLogMaxFiles is how many rotated log files are kept by default.
+const defaultLogMaxFiles = 5
+
+// logFileOptions configures writing streamed logs to a file.
+type logFileOptions struct {
+	Path       string
+	RotateSize string // e.g. 100MB; empty means never rotate
+	MaxFiles   int
+	JSON       bool // write JSON lines instead of plain text
+}
+
+// addLogFileFlags registers --output-file, --rotate-size, --max-files and
+// --no-echo on a command that streams logs. --no-echo is not --quiet: the
+// root --quiet only suppresses progress messages.
+func addLogFileFlags(cmd *cobra.Command, opts *logFileOptions, noEcho *bool) {
+	cmd.Flags().StringVar(&opts.Path, "output-file", "", "Also write streamed log entries to this file")
+	cmd.Flags().StringVar(&opts.RotateSize, "rotate-size", "", "Rotate the output file when it exceeds this size (e.g., 100MB)")
+	cmd.Flags().IntVar(&opts.MaxFiles, "max-files", defaultLogMaxFiles, "Number of rotated output files to keep")
+	cmd.Flags().BoolVar(noEcho, "no-echo", false, "Don't print streamed log entries (requires --output-file)")
+}
+
+// validateLogFileFlags checks the log file flags against whether the command
+// is streaming; streamFlag names the flag that enables streaming.
+func validateLogFileFlags(opts logFileOptions, noEcho, streaming bool, streamFlag string) error {
+	if noEcho && opts.Path == "" {
+		return fmt.Errorf("--no-echo requires --output-file")
+	}
+	if opts.Path != "" && !streaming {
+		return fmt.Errorf("--output-file requires %s", streamFlag)
+	}
+	return nil
+}
+
+// teeLogStream runs stream, writing each entry to file (if set) and passing
+// it to print unless noEcho. A file write error stops the stream and is
+// returned.
+func teeLogStream(ctx context.Context, stream func(context.Context, func(client.LogEntry)) error, file *logFileWriter, noEcho bool, print func(client.LogEntry)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var writeErr error
+	err := stream(ctx, func(entry client.LogEntry) {
+		if writeErr != nil {
+			return
+		}
+		if file != nil {
+			if writeErr = file.WriteEntry(entry); writeErr != nil {
+				cancel()
+				return
+			}
+		}
+		if !noEcho {
+			print(entry)
+		}
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// logFileWriter writes streamed log entries to a file, rotating it when it
+// grows past a size limit. Entries are written straight to the file without
+// buffering, so everything received is on disk even if the process is
+// interrupted.
+type logFileWriter struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	json     bool
+	file     *os.File
+	size     int64
+}
+
+// openLogFileWriter opens (or appends to) the file at opts.Path.
+func openLogFileWriter(opts logFileOptions) (*logFileWriter, error) {
+	var maxSize int64
+	if opts.RotateSize != "" {
+		size, err := parseByteSize(opts.RotateSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --rotate-size: %w", err)
+		}
+		maxSize = size
+	}
+	if opts.MaxFiles < 1 {
+		return nil, fmt.Errorf("--max-files must be at least 1")
+	}
+
+	w := &logFileWriter{
+		path:     opts.Path,
+		maxSize:  maxSize,
+		maxFiles: opts.MaxFiles,
+		json:     opts.JSON,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens the current log file for appending.
+func (w *logFileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// WriteEntry appends one log entry, rotating first if it would take the file
+// past the size limit.
+func (w *logFileWriter) WriteEntry(entry client.LogEntry) error {
+	line, err := formatLogFileLine(entry, w.json)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return fmt.Errorf("writing log file: file is closed")
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(line)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.WriteString(line)
+	w.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("writing log file: %w", err)
+	}
+	return nil
+}
+
+// rotate renames path to path.1, path.1 to path.2 and so on, dropping files
+// beyond maxFiles, and starts a new file at path.
+func (w *logFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("closing log file: %w", err)
+	}
+
+	// Remove anything past the retention limit, including leftovers from a
+	// previous run with a larger --max-files.
+	removeExpiredLogFiles(w.path, w.maxFiles)
+
+	for i := w.maxFiles - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", w.path, i)
+		if _, err := os.Stat(from); err == nil {
+			if err := os.Rename(from, fmt.Sprintf("%s.%d", w.path, i+1)); err != nil {
+				return fmt.Errorf("rotating log file: %w", err)
+			}
+		}
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+
+	return w.open()
+}
+
+// Close syncs and closes the log file.
+func (w *logFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	syncErr := w.file.Sync()
+	err := w.file.Close()
+	w.file = nil
+	if err == nil {
+		err = syncErr
+	}
+	return err
+}
+
+// removeExpiredLogFiles removes rotated copies of path (path.N) with N of
+// at least keepBelow.
+func removeExpiredLogFiles(path string, keepBelow int) {
+	matches, _ := filepath.Glob(path + ".*")
+	for _, m := range matches {
+		if idx, err := strconv.Atoi(strings.TrimPrefix(m, path+".")); err == nil && idx >= keepBelow {
+			os.Remove(m)
+		}
+	}
+}
+
+// formatLogFileLine renders a log entry as one line of the log file: a JSON
+// object, or the plain-text form shown on the terminal without color.
+func formatLogFileLine(entry client.LogEntry, asJSON bool) (string, error) {
+	if asJSON {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return "", fmt.Errorf("encoding log entry: %w", err)
+		}
+		return string(data) + "\n", nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%-5s] %s: %s",
+		entry.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"), strings.ToUpper(entry.Level), entry.Service, entry.Message)
+	if entry.TraceID != "" {
+		fmt.Fprintf(&b, " trace_id=%s", entry.TraceID)
+	}
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%s", k, entry.Fields[k])
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// parseByteSize parses a size such as 512K, 100MB or 1G into bytes. Units
+// are powers of 1024; a bare number is bytes.
+func parseByteSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	str = strings.TrimSuffix(str, "B")
+
+	multiplier := int64(1)
+	if str != "" {
+		switch str[len(str)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			str = str[:len(str)-1]
+		}
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 512K, 100MB, 1G)", s)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":   512,
+		"1K":    1024,
+		"1kb":   1024,
+		"100MB": 100 << 20,
+		"2G":    2 << 30,
+	}
+	for in, want := range tests {
+		got, err := parseByteSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, bad := range []string{"", "MB", "-1K", "ten"} {
+		_, err := parseByteSize(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestLogFileWriter_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	// Leftover from an earlier run with a larger --max-files.
+	require.NoError(t, os.WriteFile(path+".5", []byte("old\n"), 0644))
+
+	w, err := openLogFileWriter(logFileOptions{Path: path, RotateSize: "100", MaxFiles: 2})
+	require.NoError(t, err)
+
+	entry := client.LogEntry{Timestamp: time.Now(), Level: "info", Service: "worker", Message: strings.Repeat("x", 40)}
+	for i := 0; i < 8; i++ {
+		require.NoError(t, w.WriteEntry(entry))
+	}
+	require.NoError(t, w.Close())
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err, p)
+		assert.LessOrEqual(t, info.Size(), int64(100), p)
+	}
+	for _, p := range []string{path + ".3", path + ".5"} {
+		_, err := os.Stat(p)
+		assert.True(t, os.IsNotExist(err), "%s should have been removed", p)
+	}
+}
+
+func TestFormatLogFileLine(t *testing.T) {
+	entry := client.LogEntry{
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     "warn",
+		Service:   "gateway",
+		Message:   "slow request",
+		Fields:    map[string]string{"path": "/search", "ms": "900"},
+	}
+
+	line, err := formatLogFileLine(entry, false)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-02T03:04:05.000Z [WARN ] gateway: slow request ms=900 path=/search\n", line)
+
+	line, err = formatLogFileLine(entry, true)
+	require.NoError(t, err)
+	var decoded client.LogEntry
+	require.NoError(t, json.Unmarshal([]byte(line), &decoded))
+	assert.Equal(t, entry.Message, decoded.Message)
+}
+
+func TestValidateLogFileFlags(t *testing.T) {
+	assert.NoError(t, validateLogFileFlags(logFileOptions{}, false, false, "--follow"))
+	assert.NoError(t, validateLogFileFlags(logFileOptions{Path: "x.log"}, true, true, "--follow"))
+	assert.ErrorContains(t, validateLogFileFlags(logFileOptions{}, true, true, "--follow"), "--no-echo requires --output-file")
+	assert.ErrorContains(t, validateLogFileFlags(logFileOptions{Path: "x.log"}, false, false, "--tail"), "requires --tail")
+}
+
+func TestTeeLogStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tee.log")
+	w, err := openLogFileWriter(logFileOptions{Path: path, MaxFiles: 1})
+	require.NoError(t, err)
+
+	entries := []client.LogEntry{{Message: "one"}, {Message: "two"}}
+	stream := func(ctx context.Context, callback func(client.LogEntry)) error {
+		for _, e := range entries {
+			callback(e)
+		}
+		return nil
+	}
+
+	var printed []string
+	print := func(e client.LogEntry) { printed = append(printed, e.Message) }
+
+	require.NoError(t, teeLogStream(t.Context(), stream, w, false, print))
+	assert.Equal(t, []string{"one", "two"}, printed)
+
+	printed = nil
+	require.NoError(t, teeLogStream(t.Context(), stream, w, true, print))
+	assert.Empty(t, printed, "no-echo should not print")
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 4, strings.Count(string(data), "\n"))
+
+	// Writing to a closed file stops the stream with the write error.
+	err = teeLogStream(t.Context(), stream, w, false, print)
+	assert.Error(t, err)
+}
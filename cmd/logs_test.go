@@ -567,3 +567,39 @@ func getMockLogs(query LogQuery) []LogEntry {
 
 	return filtered
 }
+
+func TestCompileLogGrep(t *testing.T) {
+	_, err := compileLogGrep("timeout(", false)
+	assert.ErrorContains(t, err, "invalid --grep pattern")
+
+	_, err = compileLogGrep("", true)
+	assert.ErrorContains(t, err, "--invert requires --grep")
+
+	none, err := compileLogGrep("", false)
+	require.NoError(t, err)
+	assert.False(t, none.active())
+	assert.True(t, none.keep("anything"))
+}
+
+func TestLogGrep_Filter(t *testing.T) {
+	entries := []client.LogEntry{
+		{Message: "request timeout after 500ms"},
+		{Message: "health check ok"},
+		{Message: "timeout after 30ms"},
+	}
+
+	grep, err := compileLogGrep(`timeout after \d{3}ms`, false)
+	require.NoError(t, err)
+	kept := grep.filter(entries)
+	require.Len(t, kept, 1)
+	assert.Equal(t, entries[0].Message, kept[0].Message)
+
+	invert, err := compileLogGrep("timeout", true)
+	require.NoError(t, err)
+	var streamed []string
+	cb := invert.wrap(func(e client.LogEntry) { streamed = append(streamed, e.Message) })
+	for _, e := range entries {
+		cb(e)
+	}
+	assert.Equal(t, []string{"health check ok"}, streamed)
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -63,6 +62,9 @@ Examples:
   # Filter meetings by series
   penf meeting list --series "TER Weekly"
 
+  # Search within a meeting
+  penf meeting search cnt-abc123xyz budget --speaker alice
+
   # Output as JSON
   penf meeting list -o json`,
 		Aliases: []string{"meetings"},
@@ -75,6 +77,7 @@ Examples:
 	cmd.AddCommand(newMeetingUnsetSeriesCommand(DefaultMeetingSeriesDeps()))
 	cmd.AddCommand(newMeetingUpdateCommand(DefaultMeetingSeriesDeps()))
 	cmd.AddCommand(newMeetingRecapCommand(deps))
+	cmd.AddCommand(newMeetingSearchCommand(deps))
 
 	return cmd
 }
@@ -204,7 +207,7 @@ func outputMeetingList(format config.OutputFormat, meetings []*ingestv1.MeetingI
 			"count":    len(meetings),
 		})
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(map[string]interface{}{
 			"meetings": meetings,
 			"count":    len(meetings),
@@ -361,7 +364,7 @@ func outputMeetingRecap(format config.OutputFormat, recap *ingestv1.MeetingRecap
 		enc.SetIndent("", "  ")
 		return enc.Encode(recap)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(recap)
 	default:
 		return outputMeetingRecapText(recap)
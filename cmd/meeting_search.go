@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/ingest/meeting"
+)
+
+// Meeting search match sources.
+const (
+	meetingMatchTranscript = "transcript"
+	meetingMatchAssertion  = "assertion"
+)
+
+// meetingSnippetContext is how many characters of context are kept on each
+// side of a match in a snippet.
+const meetingSnippetContext = 60
+
+// Meeting search flags.
+var meetingSearchSpeaker string
+
+// MeetingSearchMatch is one transcript segment or assertion matching a
+// meeting search.
+type MeetingSearchMatch struct {
+	Source        string `json:"source" yaml:"source"` // transcript, assertion
+	Speaker       string `json:"speaker,omitempty" yaml:"speaker,omitempty"`
+	OffsetSeconds *int   `json:"offset_seconds,omitempty" yaml:"offset_seconds,omitempty"`
+	AssertionID   int64  `json:"assertion_id,omitempty" yaml:"assertion_id,omitempty"`
+	AssertionType string `json:"assertion_type,omitempty" yaml:"assertion_type,omitempty"`
+	Snippet       string `json:"snippet" yaml:"snippet"`
+}
+
+// MeetingSearchResult is the structured output of 'meeting search'.
+type MeetingSearchResult struct {
+	MeetingID string               `json:"meeting_id" yaml:"meeting_id"`
+	Query     string               `json:"query" yaml:"query"`
+	Speaker   string               `json:"speaker,omitempty" yaml:"speaker,omitempty"`
+	Matches   []MeetingSearchMatch `json:"matches" yaml:"matches"`
+	Count     int                  `json:"count" yaml:"count"`
+}
+
+// newMeetingSearchCommand creates the 'meeting search' subcommand.
+func newMeetingSearchCommand(deps *MeetingCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <meeting-id> <query>",
+		Short: "Search within a meeting's transcript and assertions",
+		Long: `Search the transcript and extracted assertions of a single meeting.
+
+Matching is case-insensitive. Transcript matches show the speaker and the
+offset from the start of the meeting (when the transcript has timestamps);
+assertion matches show the assertion type and ID.
+
+Use --speaker to only show what one participant said. The speaker filter is
+a case-insensitive substring match on the speaker name, and leaves out
+assertions, which have no speaker.
+
+Examples:
+  # Find where budget came up
+  penf meeting search cnt-abc123xyz budget
+
+  # What did Alice say about the migration?
+  penf meeting search cnt-abc123xyz "migration" --speaker alice
+
+  # Output as JSON
+  penf meeting search cnt-abc123xyz budget -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMeetingSearch(cmd.Context(), deps, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().StringVar(&meetingSearchSpeaker, "speaker", "", "Only show transcript matches from this speaker")
+	cmd.Flags().StringVarP(&meetingOutputFormat, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runMeetingSearch executes the meeting search command.
+func runMeetingSearch(ctx context.Context, deps *MeetingCommandDeps, meetingID, query string) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	// Determine output format
+	outputFormat := cfg.OutputFormat
+	if meetingOutputFormat != "" {
+		outputFormat = config.OutputFormat(meetingOutputFormat)
+		if !outputFormat.IsValid() {
+			return fmt.Errorf("invalid output format: %s", meetingOutputFormat)
+		}
+	}
+
+	// Connect to gateway via gRPC
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to gateway: %w", err)
+	}
+	defer conn.Close()
+
+	contentClient := contentv1.NewContentProcessorServiceClient(conn)
+
+	textResp, err := contentClient.GetContentText(ctx, &contentv1.GetContentTextRequest{
+		ContentId: meetingID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting meeting transcript: %w", err)
+	}
+
+	result := MeetingSearchResult{
+		MeetingID: meetingID,
+		Query:     query,
+		Speaker:   meetingSearchSpeaker,
+	}
+
+	result.Matches, err = searchMeetingTranscript(textResp.Text, query, meetingSearchSpeaker)
+	if err != nil {
+		return fmt.Errorf("parsing meeting transcript: %w", err)
+	}
+
+	// Assertions carry no speaker, so a speaker filter leaves them out.
+	if meetingSearchSpeaker == "" {
+		assertResp, err := contentClient.GetAssertions(ctx, &contentv1.GetAssertionsRequest{
+			ContentId: meetingID,
+		})
+		if err != nil {
+			return fmt.Errorf("getting meeting assertions: %w", err)
+		}
+		result.Matches = append(result.Matches, searchMeetingAssertions(assertResp.Assertions, query)...)
+	}
+
+	if result.Matches == nil {
+		result.Matches = []MeetingSearchMatch{}
+	}
+	result.Count = len(result.Matches)
+
+	return outputMeetingSearch(outputFormat, result)
+}
+
+// searchMeetingTranscript parses a stored transcript and returns the segments
+// containing query, optionally limited to speakers matching speaker.
+func searchMeetingTranscript(text, query, speaker string) ([]MeetingSearchMatch, error) {
+	var transcript *meeting.TranscriptResult
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(text), "WEBVTT") {
+		transcript, err = meeting.ParseVTT(strings.NewReader(text))
+	} else {
+		transcript, err = meeting.ParseTXTAuto(strings.NewReader(text))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Speaker-label transcripts have no timestamps; every segment is at 0.
+	timestamped := false
+	for _, seg := range transcript.Segments {
+		if seg.StartMs > 0 {
+			timestamped = true
+			break
+		}
+	}
+
+	speaker = strings.ToLower(speaker)
+	var matches []MeetingSearchMatch
+	for _, seg := range transcript.Segments {
+		if speaker != "" && !strings.Contains(strings.ToLower(seg.Speaker), speaker) {
+			continue
+		}
+		snippet, ok := meetingSnippet(seg.Text, query)
+		if !ok {
+			continue
+		}
+		match := MeetingSearchMatch{
+			Source:  meetingMatchTranscript,
+			Speaker: seg.Speaker,
+			Snippet: snippet,
+		}
+		if timestamped {
+			offset := seg.StartMs / 1000
+			match.OffsetSeconds = &offset
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// searchMeetingAssertions returns the assertions whose description or source
+// quote contains query.
+func searchMeetingAssertions(assertions []*contentv1.Assertion, query string) []MeetingSearchMatch {
+	var matches []MeetingSearchMatch
+	for _, a := range assertions {
+		snippet, ok := meetingSnippet(a.Description, query)
+		if !ok && a.SourceQuote != nil {
+			snippet, ok = meetingSnippet(*a.SourceQuote, query)
+		}
+		if !ok {
+			continue
+		}
+		matches = append(matches, MeetingSearchMatch{
+			Source:        meetingMatchAssertion,
+			AssertionID:   a.Id,
+			AssertionType: a.AssertionType,
+			Snippet:       snippet,
+		})
+	}
+	return matches
+}
+
+// meetingSnippet returns the part of text around the first case-insensitive
+// occurrence of query, trimmed to meetingSnippetContext characters either side.
+func meetingSnippet(text, query string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	q := []rune(strings.ToLower(query))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the length; snippet from the lowered text
+		// so offsets line up.
+		runes = lower
+	}
+
+	idx := -1
+	for i := 0; i+len(q) <= len(lower); i++ {
+		if string(lower[i:i+len(q)]) == string(q) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return "", false
+	}
+
+	start := max(0, idx-meetingSnippetContext)
+	end := min(len(runes), idx+len(q)+meetingSnippetContext)
+
+	snippet := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return snippet, true
+}
+
+// formatMeetingOffset formats an offset from the start of a meeting as
+// m:ss or h:mm:ss.
+func formatMeetingOffset(seconds int) string {
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// outputMeetingSearch formats and outputs meeting search results.
+func outputMeetingSearch(format config.OutputFormat, result MeetingSearchResult) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(result)
+	default:
+		return outputMeetingSearchText(result)
+	}
+}
+
+// outputMeetingSearchText formats meeting search results for terminal display.
+func outputMeetingSearchText(result MeetingSearchResult) error {
+	if len(result.Matches) == 0 {
+		fmt.Printf("No matches for %q in meeting %s.\n", result.Query, result.MeetingID)
+		return nil
+	}
+
+	fmt.Printf("Matches for %q in meeting %s (%d):\n\n", result.Query, result.MeetingID, result.Count)
+
+	for _, m := range result.Matches {
+		switch m.Source {
+		case meetingMatchAssertion:
+			fmt.Printf("  \033[36m[%s #%d]\033[0m\n", m.AssertionType, m.AssertionID)
+		default:
+			offset := "-"
+			if m.OffsetSeconds != nil {
+				offset = formatMeetingOffset(*m.OffsetSeconds)
+			}
+			speaker := m.Speaker
+			if speaker == "" {
+				speaker = "Unknown"
+			}
+			fmt.Printf("  \033[2m%8s\033[0m  \033[1m%s\033[0m\n", offset, speaker)
+		}
+		fmt.Printf("            %s\n\n", m.Snippet)
+	}
+
+	return nil
+}
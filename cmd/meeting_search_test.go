@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+const timestampedTranscript = `0:05 : Alice Smith : Welcome everyone, let's start with the budget.
+1:10 : Bob Jones : The budget for Q3 is still pending approval.
+2:30 : Alice Smith : Moving on to the migration plan.
+`
+
+func TestSearchMeetingTranscript_MatchesWithSpeakerAndOffset(t *testing.T) {
+	matches, err := searchMeetingTranscript(timestampedTranscript, "BUDGET", "")
+	if err != nil {
+		t.Fatalf("searchMeetingTranscript() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+
+	if matches[0].Speaker != "Alice Smith" || matches[1].Speaker != "Bob Jones" {
+		t.Errorf("speakers = %q, %q", matches[0].Speaker, matches[1].Speaker)
+	}
+	if matches[1].OffsetSeconds == nil || *matches[1].OffsetSeconds != 70 {
+		t.Errorf("second match offset = %v, want 70", matches[1].OffsetSeconds)
+	}
+	if matches[0].Source != meetingMatchTranscript {
+		t.Errorf("source = %q, want %q", matches[0].Source, meetingMatchTranscript)
+	}
+}
+
+func TestSearchMeetingTranscript_SpeakerFilter(t *testing.T) {
+	matches, err := searchMeetingTranscript(timestampedTranscript, "budget", "bob")
+	if err != nil {
+		t.Fatalf("searchMeetingTranscript() error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].Speaker != "Bob Jones" {
+		t.Fatalf("matches = %+v, want one match from Bob Jones", matches)
+	}
+}
+
+func TestSearchMeetingTranscript_SpeakerLabelsHaveNoOffset(t *testing.T) {
+	text := "ALICE: The budget looks fine.\n\nBOB: Agreed.\n"
+
+	matches, err := searchMeetingTranscript(text, "budget", "")
+	if err != nil {
+		t.Fatalf("searchMeetingTranscript() error = %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	if matches[0].OffsetSeconds != nil {
+		t.Errorf("offset = %d, want none for an untimed transcript", *matches[0].OffsetSeconds)
+	}
+}
+
+func TestSearchMeetingAssertions(t *testing.T) {
+	quote := "we need more budget for hiring"
+	assertions := []*contentv1.Assertion{
+		{Id: 1, AssertionType: "risk", Description: "Budget overrun on Q3"},
+		{Id: 2, AssertionType: "action_item", Description: "Hire two engineers", SourceQuote: &quote},
+		{Id: 3, AssertionType: "decision", Description: "Ship on Friday"},
+	}
+
+	matches := searchMeetingAssertions(assertions, "budget")
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	if matches[0].AssertionID != 1 || matches[1].AssertionID != 2 {
+		t.Errorf("assertion IDs = %d, %d, want 1, 2", matches[0].AssertionID, matches[1].AssertionID)
+	}
+	if matches[1].Snippet != quote {
+		t.Errorf("snippet = %q, want source quote", matches[1].Snippet)
+	}
+}
+
+func TestMeetingSnippet_TrimsLongText(t *testing.T) {
+	text := strings.Repeat("a", 100) + " needle " + strings.Repeat("b", 100)
+
+	snippet, ok := meetingSnippet(text, "NEEDLE")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if !strings.HasPrefix(snippet, "...") || !strings.HasSuffix(snippet, "...") {
+		t.Errorf("snippet %q should be elided on both sides", snippet)
+	}
+	if !strings.Contains(snippet, "needle") {
+		t.Errorf("snippet %q should contain the match", snippet)
+	}
+
+	if _, ok := meetingSnippet("nothing here", "needle"); ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestFormatMeetingOffset(t *testing.T) {
+	tests := map[int]string{0: "0:00", 70: "1:10", 3725: "1:02:05"}
+	for in, want := range tests {
+		if got := formatMeetingOffset(in); got != want {
+			t.Errorf("formatMeetingOffset(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
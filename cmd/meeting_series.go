@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -347,7 +346,7 @@ func outputSeriesList(format config.OutputFormat, series []*ingestv1.MeetingSeri
 			"count":  len(series),
 		})
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(map[string]interface{}{
 			"series": series,
 			"count":  len(series),
@@ -400,7 +399,7 @@ func outputSeriesDetails(format config.OutputFormat, series *ingestv1.MeetingSer
 		}
 		return enc.Encode(output)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		output := map[string]interface{}{
 			"series": series,
 		}
@@ -7,7 +7,6 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -147,6 +146,6 @@ func outputSetSeriesResultYAML(meetingID, seriesName string, resp *ingestv1.SetM
 		"series_created": resp.SeriesCreated,
 	}
 
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(result)
 }
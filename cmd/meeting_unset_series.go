@@ -7,7 +7,6 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -131,6 +130,6 @@ func outputUnsetSeriesResultYAML(meetingID string, resp *ingestv1.UnsetMeetingSe
 		"updated":    resp.Updated,
 	}
 
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(result)
 }
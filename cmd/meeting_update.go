@@ -7,7 +7,6 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	ingestv1 "github.com/otherjamesbrown/penf-cli/api/proto/ingest/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -187,6 +186,6 @@ func outputUpdateMeetingResultYAML(resp *ingestv1.UpdateMeetingResponse) error {
 		"series_created": resp.SeriesCreated,
 	}
 
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(result)
 }
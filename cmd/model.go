@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"os"
 	"os/exec"
@@ -19,7 +21,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -147,7 +148,8 @@ Model Types:
   - Remote models: Cloud API models (Gemini, OpenAI, Anthropic)
 
 Commands:
-  list       List downloaded local models
+  list         List available models from the gateway, marking the default
+  set-default  Set the model used when --model isn't given
   registry   List all registered models (local + remote) from AI service
   add        Register a new remote model
   enable     Enable a registered model
@@ -158,9 +160,15 @@ Commands:
   stop       Stop local model server(s)
 
 Examples:
-  # List local downloaded models
+  # List available models and the current default
   penf model list
 
+  # Use a model by default in reprocess and ai commands
+  penf model set-default gemini-2.0-flash
+
+  # List local downloaded models
+  penf model list --catalog
+
   # Show all registered models (local + remote)
   penf model registry
 
@@ -189,6 +197,7 @@ Model configuration details are in Context Palace knowledge shards.`,
 
 	// Add subcommands - local model management.
 	cmd.AddCommand(newModelListCommand(deps))
+	cmd.AddCommand(newModelSetDefaultCommand(deps))
 	cmd.AddCommand(newModelStatusCommand(deps))
 	cmd.AddCommand(newModelServeCommand(deps))
 	cmd.AddCommand(newModelStopCommand(deps))
@@ -210,26 +219,74 @@ Model configuration details are in Context Palace knowledge shards.`,
 // newModelListCommand creates the 'model list' subcommand.
 func newModelListCommand(deps *ModelCommandDeps) *cobra.Command {
 	var showAll bool
+	var catalog bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List available models",
-		Long: `List available LLM models.
+		Long: `List the enabled models available from the gateway, with their IDs, context
+sizes and status. The default model (see 'penf model set-default') is marked
+with *.
 
-Shows both downloaded models and models available for download.
-By default, only shows downloaded models. Use --all to show all available models.
+With --catalog, list the built-in catalog of local MLX models instead: only
+downloaded models, or every catalog model with --all.
 
 Examples:
   penf model list
-  penf model list --all
-  penf model list -o json`,
+  penf model list -o json
+  penf model list --catalog
+  penf model list --catalog --all`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runModelList(cmd.Context(), deps, showAll)
+			if catalog {
+				return runModelCatalogList(cmd.Context(), deps, showAll)
+			}
+			if showAll {
+				return fmt.Errorf("--all requires --catalog (use 'penf model registry' to include disabled models)")
+			}
+			return runModelList(cmd.Context(), deps)
+		},
+	}
+
+	cmd.Flags().BoolVar(&catalog, "catalog", false, "List the built-in local MLX model catalog instead")
+	cmd.Flags().BoolVar(&showAll, "all", false, "With --catalog, include models that are not downloaded")
+
+	return cmd
+}
+
+// newModelSetDefaultCommand creates the 'model set-default' subcommand.
+func newModelSetDefaultCommand(deps *ModelCommandDeps) *cobra.Command {
+	var clearDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "set-default <model-id>",
+		Short: "Set the default model for reprocess and ai commands",
+		Long: `Store a default model in the config file (default_model). It is used by
+'penf pipeline reprocess' and the 'penf ai' commands when --model isn't given.
+
+The model must be registered with the gateway, by registry ID or provider
+model name as shown by 'penf model list'. Use --clear to go back to letting
+the server choose.
+
+Examples:
+  penf model set-default gemini-2.0-flash
+  penf model set-default --clear`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if clearDefault {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modelID := ""
+			if len(args) > 0 {
+				modelID = args[0]
+			}
+			return runModelSetDefault(cmd.Context(), deps, modelID)
 		},
 	}
 
-	cmd.Flags().BoolVar(&showAll, "all", false, "Show all available models (including not downloaded)")
+	cmd.Flags().BoolVar(&clearDefault, "clear", false, "Clear the default model")
 
 	return cmd
 }
@@ -576,7 +633,84 @@ Examples:
 // ==================== Command Execution Functions ====================
 
 // runModelList executes the model list command.
-func runModelList(ctx context.Context, deps *ModelCommandDeps, showAll bool) error {
+func runModelList(ctx context.Context, deps *ModelCommandDeps) error {
+	cfg, err := loadModelConfig(deps)
+	if err != nil {
+		return err
+	}
+
+	models, err := listEnabledModels(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	return outputAvailableModels(deps, models, cfg.DefaultModel)
+}
+
+// runModelSetDefault stores modelID as the default model, or clears the
+// default if modelID is empty.
+func runModelSetDefault(ctx context.Context, deps *ModelCommandDeps, modelID string) error {
+	cfg, err := loadModelConfig(deps)
+	if err != nil {
+		return err
+	}
+
+	if modelID != "" {
+		models, err := listEnabledModels(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		m := findModel(models, modelID)
+		if m == nil {
+			return fmt.Errorf("model %q is not an enabled model on the gateway (see 'penf model list')", modelID)
+		}
+		if m.Status == aiv1.ModelStatus_MODEL_STATUS_ERROR {
+			fmt.Fprintf(os.Stderr, "Warning: model %s is currently in an error state\n", modelID)
+		}
+	}
+
+	cfg.DefaultModel = modelID
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("saving configuration: %w", err)
+	}
+
+	if modelID == "" {
+		fmt.Println("Cleared the default model; the server will choose.")
+	} else {
+		fmt.Printf("Default model set to %s\n", modelID)
+	}
+	return nil
+}
+
+// listEnabledModels fetches the enabled models from the AI service registry.
+func listEnabledModels(ctx context.Context, cfg *config.CLIConfig) ([]*aiv1.ModelInfo, error) {
+	conn, err := connectModelToGateway(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	enabled := true
+	resp, err := aiv1.NewAICoordinatorServiceClient(conn).ListModels(ctx, &aiv1.ListModelsRequest{IsEnabled: &enabled})
+	if err != nil {
+		return nil, fmt.Errorf("listing models: %w\n\nEnsure the Gateway service is running at %s", err, cfg.ServerAddress)
+	}
+	return resp.Models, nil
+}
+
+// findModel returns the model whose registry ID or provider model name is
+// modelID, or nil.
+func findModel(models []*aiv1.ModelInfo, modelID string) *aiv1.ModelInfo {
+	for _, m := range models {
+		if m.Id == modelID || m.ModelName == modelID {
+			return m
+		}
+	}
+	return nil
+}
+
+// runModelCatalogList lists the built-in local model catalog.
+func runModelCatalogList(ctx context.Context, deps *ModelCommandDeps, showAll bool) error {
 	// Get list of downloaded models from HuggingFace cache.
 	downloadedModels := getDownloadedModels(deps)
 
@@ -1131,7 +1265,7 @@ func loadModelConfig(deps *ModelCommandDeps) (*config.CLIConfig, error) {
 
 // connectModelToGateway creates a gRPC connection to the gateway service.
 func connectModelToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -1154,9 +1288,11 @@ func connectModelToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -1363,7 +1499,7 @@ func outputModelCatalog(deps *ModelCommandDeps, catalog []ModelCatalogEntry, sho
 		enc.SetIndent("", "  ")
 		return enc.Encode(catalog)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(catalog)
 	default:
 		return outputModelCatalogText(catalog, showAll)
@@ -1423,7 +1559,7 @@ func outputModelStatus(deps *ModelCommandDeps, servers []ModelServerStatus) erro
 		enc.SetIndent("", "  ")
 		return enc.Encode(servers)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(servers)
 	default:
 		return outputModelStatusText(servers)
@@ -1480,7 +1616,7 @@ func outputModelInfo(deps *ModelCommandDeps, entry *ModelCatalogEntry) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(entry)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(entry)
 	default:
 		return outputModelInfoText(entry)
@@ -1570,7 +1706,7 @@ func outputRegistryModels(deps *ModelCommandDeps, models []*aiv1.ModelInfo, tota
 		enc.SetIndent("", "  ")
 		return enc.Encode(entries)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(entries)
 	default:
 		return outputRegistryModelsText(entries, totalCount)
@@ -1644,6 +1780,113 @@ func outputRegistryModelsText(models []RegistryModelEntry, totalCount int32) err
 	return nil
 }
 
+// AvailableModelEntry is a model in 'model list' output.
+type AvailableModelEntry struct {
+	ID            string `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	Provider      string `json:"provider" yaml:"provider"`
+	ModelName     string `json:"model_name" yaml:"model_name"`
+	ContextLength int32  `json:"context_length,omitempty" yaml:"context_length,omitempty"`
+	Status        string `json:"status" yaml:"status"`
+	IsDefault     bool   `json:"is_default" yaml:"is_default"`
+}
+
+// ModelListOutput is the output of 'model list'.
+type ModelListOutput struct {
+	DefaultModel string                `json:"default_model,omitempty" yaml:"default_model,omitempty"`
+	Models       []AvailableModelEntry `json:"models" yaml:"models"`
+}
+
+// buildModelListOutput converts registry models for 'model list', marking
+// the model matching defaultModel.
+func buildModelListOutput(models []*aiv1.ModelInfo, defaultModel string) ModelListOutput {
+	output := ModelListOutput{DefaultModel: defaultModel, Models: make([]AvailableModelEntry, len(models))}
+	for i, m := range models {
+		output.Models[i] = AvailableModelEntry{
+			ID:            m.Id,
+			Name:          m.Name,
+			Provider:      m.Provider,
+			ModelName:     m.ModelName,
+			ContextLength: m.GetMaxContextLength(),
+			Status:        modelStatusToString(m.Status),
+			IsDefault:     defaultModel != "" && (m.Id == defaultModel || m.ModelName == defaultModel),
+		}
+	}
+	return output
+}
+
+// outputAvailableModels outputs the models for 'model list'.
+func outputAvailableModels(deps *ModelCommandDeps, models []*aiv1.ModelInfo, defaultModel string) error {
+	output := buildModelListOutput(models, defaultModel)
+
+	switch getModelOutputFormat(deps) {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(os.Stdout).Encode(output)
+	default:
+		outputAvailableModelsText(os.Stdout, output)
+		return nil
+	}
+}
+
+// outputAvailableModelsText outputs 'model list' in human-readable format.
+func outputAvailableModelsText(w io.Writer, output ModelListOutput) {
+	if len(output.Models) == 0 {
+		fmt.Fprintln(w, "No enabled models on the gateway.")
+		fmt.Fprintln(w, "\nUse 'penf model registry' to see all registered models.")
+		return
+	}
+
+	fmt.Fprintf(w, "Available Models (%d)\n", len(output.Models))
+	fmt.Fprintf(w, "  %-36s %-28s %-10s %8s  %s\n", "ID", "MODEL", "PROVIDER", "CONTEXT", "STATUS")
+	fmt.Fprintf(w, "  %-36s %-28s %-10s %8s  %s\n", "--", "-----", "--------", "-------", "------")
+
+	defaultFound := false
+	for _, m := range output.Models {
+		marker := " "
+		if m.IsDefault {
+			marker = "*"
+			defaultFound = true
+		}
+		contextLen := "-"
+		if m.ContextLength > 0 {
+			contextLen = formatContextLength(m.ContextLength)
+		}
+		fmt.Fprintf(w, "%s %-36s %-28s %-10s %8s  %s\n", marker, m.ID, truncate(m.ModelName, 28), m.Provider, contextLen, m.Status)
+	}
+
+	fmt.Fprintln(w)
+	switch {
+	case output.DefaultModel == "":
+		fmt.Fprintln(w, "Default model: none (server chooses; set one with 'penf model set-default <id>')")
+	case defaultFound:
+		fmt.Fprintf(w, "* Default model: %s\n", output.DefaultModel)
+	default:
+		fmt.Fprintf(w, "Default model: %s (not an enabled model on the gateway)\n", output.DefaultModel)
+	}
+}
+
+// formatContextLength formats a token count compactly, e.g. 8K or 1M. Counts
+// that are whole thousands use decimal units (128000 is 128K); others use
+// binary units (131072 is 128K).
+func formatContextLength(tokens int32) string {
+	unit := int32(1000)
+	if tokens%1000 != 0 {
+		unit = 1024
+	}
+	switch {
+	case tokens >= unit*unit:
+		return fmt.Sprintf("%sM", strconv.FormatFloat(math.Round(float64(tokens)/float64(unit*unit)*10)/10, 'f', -1, 64))
+	case tokens >= unit:
+		return fmt.Sprintf("%dK", tokens/unit)
+	default:
+		return strconv.Itoa(int(tokens))
+	}
+}
+
 // outputRoutingRules outputs the routing rules.
 func outputRoutingRules(deps *ModelCommandDeps, rules []*aiv1.RoutingRule) error {
 	format := getModelOutputFormat(deps)
@@ -1673,7 +1916,7 @@ func outputRoutingRules(deps *ModelCommandDeps, rules []*aiv1.RoutingRule) error
 		enc.SetIndent("", "  ")
 		return enc.Encode(entries)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(entries)
 	default:
 		return outputRoutingRulesText(entries)
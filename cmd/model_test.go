@@ -2,12 +2,16 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
+
+	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
 )
 
 // TestModelCatalogEntryJSON tests JSON output formatting for model catalog entries.
@@ -239,6 +243,7 @@ func TestNewModelCommand(t *testing.T) {
 	expectedSubcommands := []string{
 		// Local model commands
 		"list",
+		"set-default",
 		"status",
 		"serve",
 		"stop",
@@ -507,3 +512,41 @@ func TestRoutingRuleEntryJSON(t *testing.T) {
 		t.Errorf("PreferredModels length = %v, want %v", len(decoded.PreferredModels), len(entry.PreferredModels))
 	}
 }
+
+func TestBuildModelListOutput(t *testing.T) {
+	ctxLen := int32(32768)
+	models := []*aiv1.ModelInfo{
+		{Id: "m-1", Name: "Gemini Flash", Provider: "gemini", ModelName: "gemini-2.0-flash", MaxContextLength: &ctxLen, Status: aiv1.ModelStatus_MODEL_STATUS_READY},
+		{Id: "m-2", Name: "Qwen", Provider: "ollama", ModelName: "qwen2.5:7b"},
+	}
+
+	out := buildModelListOutput(models, "gemini-2.0-flash")
+	if !out.Models[0].IsDefault || out.Models[1].IsDefault {
+		t.Errorf("default marking = %v, %v; want only the model named gemini-2.0-flash", out.Models[0].IsDefault, out.Models[1].IsDefault)
+	}
+	if out.Models[0].ContextLength != 32768 || out.Models[1].ContextLength != 0 {
+		t.Errorf("context lengths = %d, %d", out.Models[0].ContextLength, out.Models[1].ContextLength)
+	}
+
+	var buf bytes.Buffer
+	outputAvailableModelsText(&buf, out)
+	if !strings.Contains(buf.String(), "* m-1") || !strings.Contains(buf.String(), "32K") {
+		t.Errorf("text output missing default marker or context size:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "* Default model: gemini-2.0-flash") {
+		t.Errorf("text output missing default model line:\n%s", buf.String())
+	}
+
+	if findModel(models, "m-2") != models[1] || findModel(models, "qwen2.5:7b") != models[1] || findModel(models, "gpt-4") != nil {
+		t.Error("findModel should match by registry ID or model name")
+	}
+}
+
+func TestFormatContextLength(t *testing.T) {
+	tests := map[int32]string{512: "512", 8192: "8K", 128000: "128K", 131072: "128K", 1 << 20: "1M", 2000000: "2M", 1500000: "1.5M"}
+	for in, want := range tests {
+		if got := formatContextLength(in); got != want {
+			t.Errorf("formatContextLength(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
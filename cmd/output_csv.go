@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+// outputCSV writes a header row followed by rows as RFC 4180 CSV.
+func outputCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// outputKeyValueCSV writes a single record as two-column key,value CSV. It is
+// used for detail views that have no natural tabular shape.
+func outputKeyValueCSV(w io.Writer, pairs [][2]string) error {
+	rows := make([][]string, 0, len(pairs))
+	for _, kv := range pairs {
+		rows = append(rows, []string{kv[0], kv[1]})
+	}
+	return outputCSV(w, []string{"key", "value"}, rows)
+}
+
+// csvFloat formats a float without padding or rounding.
+func csvFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// csvTime formats a time as RFC 3339, or empty when zero.
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// csvList joins list values into a single cell.
+func csvList(values []string) string {
+	return strings.Join(values, "; ")
+}
+
+// csvTimestamp formats an optional timestamp as RFC 3339, or empty when unset.
+func csvTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+// jobSummaryCSVHeader matches the columns of the ingest jobs text table.
+var jobSummaryCSVHeader = []string{"job_id", "status", "tag", "files", "imported", "failed"}
+
+// outputJobSummariesCSV writes ingest jobs as CSV.
+func outputJobSummariesCSV(w io.Writer, jobs []*pipelinev1.JobSummary) error {
+	rows := make([][]string, 0, len(jobs))
+	for _, job := range jobs {
+		rows = append(rows, []string{
+			job.Id,
+			job.Status,
+			job.SourceTag,
+			strconv.Itoa(int(job.TotalFiles)),
+			strconv.Itoa(int(job.ImportedCount)),
+			strconv.Itoa(int(job.FailedCount)),
+		})
+	}
+	return outputCSV(w, jobSummaryCSVHeader, rows)
+}
+
+// deletedSourceCSVHeader matches the columns of the deleted sources text table.
+var deletedSourceCSVHeader = []string{"id", "source", "external_id", "status", "deleted_at", "deleted_by"}
+
+// outputDeletedSourcesCSV writes soft-deleted sources as CSV.
+func outputDeletedSourcesCSV(w io.Writer, sources []*pipelinev1.DeletedSource) error {
+	rows := make([][]string, 0, len(sources))
+	for _, src := range sources {
+		rows = append(rows, []string{
+			strconv.FormatInt(src.Id, 10),
+			src.SourceSystem,
+			src.ExternalId,
+			src.ProcessingStatus,
+			csvTimestamp(src.DeletedAt),
+			src.DeletedBy,
+		})
+	}
+	return outputCSV(w, deletedSourceCSVHeader, rows)
+}
+
+// entityCSVHeader matches the columns of the entity list text table.
+var entityCSVHeader = []string{"id", "name", "type", "account_type", "relations", "confidence"}
+
+// outputEntitiesCSV writes entities as CSV.
+func outputEntitiesCSV(w io.Writer, entities []Entity) error {
+	rows := make([][]string, 0, len(entities))
+	for _, e := range entities {
+		rows = append(rows, []string{
+			e.ID,
+			e.Name,
+			string(e.Type),
+			e.AccountType,
+			strconv.Itoa(e.RelationCount),
+			csvFloat(e.Confidence),
+		})
+	}
+	return outputCSV(w, entityCSVHeader, rows)
+}
+
+// outputEntityDetailCSV writes a single entity as key,value CSV. Metadata keys
+// are prefixed with "metadata." and sorted for stable output.
+func outputEntityDetailCSV(w io.Writer, e Entity) error {
+	pairs := [][2]string{
+		{"id", e.ID},
+		{"name", e.Name},
+		{"type", string(e.Type)},
+		{"account_type", e.AccountType},
+		{"aliases", csvList(e.Aliases)},
+		{"confidence", csvFloat(e.Confidence)},
+		{"source_count", strconv.Itoa(e.SourceCount)},
+		{"relation_count", strconv.Itoa(e.RelationCount)},
+		{"sent_count", strconv.Itoa(e.SentCount)},
+		{"received_count", strconv.Itoa(e.ReceivedCount)},
+		{"expertise_areas", csvList(e.ExpertiseAreas)},
+		{"first_seen", csvTime(e.FirstSeen)},
+		{"last_seen", csvTime(e.LastSeen)},
+	}
+	keys := make([]string, 0, len(e.Metadata))
+	for k := range e.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pairs = append(pairs, [2]string{"metadata." + k, e.Metadata[k]})
+	}
+	return outputKeyValueCSV(w, pairs)
+}
+
+// relationshipCSVHeader matches the columns of the relationship list text table,
+// plus the entity IDs on each end.
+var relationshipCSVHeader = []string{"id", "source_id", "source", "type", "target_id", "target", "confidence"}
+
+// outputRelationshipsCSV writes relationships as CSV.
+func outputRelationshipsCSV(w io.Writer, relationships []Relationship) error {
+	rows := make([][]string, 0, len(relationships))
+	for _, r := range relationships {
+		rows = append(rows, []string{
+			r.ID,
+			r.SourceID,
+			r.SourceName,
+			string(r.Type),
+			r.TargetID,
+			r.TargetName,
+			csvFloat(r.Confidence),
+		})
+	}
+	return outputCSV(w, relationshipCSVHeader, rows)
+}
+
+// outputRelationshipDetailCSV writes a single relationship as key,value CSV.
+func outputRelationshipDetailCSV(w io.Writer, r Relationship) error {
+	return outputKeyValueCSV(w, [][2]string{
+		{"id", r.ID},
+		{"type", string(r.Type)},
+		{"source_id", r.SourceID},
+		{"source", r.SourceName},
+		{"target_id", r.TargetID},
+		{"target", r.TargetName},
+		{"confidence", csvFloat(r.Confidence)},
+		{"weight", csvFloat(r.Weight)},
+		{"source_count", strconv.Itoa(r.SourceCount)},
+		{"first_seen", csvTime(r.FirstSeen)},
+		{"last_seen", csvTime(r.LastSeen)},
+		{"evidence", csvList(r.Evidence)},
+	})
+}
+
+// duplicatePairCSVHeader matches the columns of the duplicate pairs text table,
+// plus the entity IDs needed for merge-preview and merge.
+var duplicatePairCSVHeader = []string{"entity_id_1", "entity_1", "entity_id_2", "entity_2", "similarity", "signals"}
+
+// outputDuplicatePairsCSV writes duplicate entity pairs as CSV.
+func outputDuplicatePairsCSV(w io.Writer, pairs []*client.DuplicatePair) error {
+	rows := make([][]string, 0, len(pairs))
+	for _, p := range pairs {
+		rows = append(rows, []string{
+			p.EntityID1,
+			p.EntityName1,
+			p.EntityID2,
+			p.EntityName2,
+			strconv.FormatFloat(float64(p.Similarity), 'f', -1, 32),
+			csvList(p.Signals),
+		})
+	}
+	return outputCSV(w, duplicatePairCSVHeader, rows)
+}
+
+// reviewQueueCSVHeader matches the columns of the review queue text table, with
+// the absolute creation time in place of the relative age.
+var reviewQueueCSVHeader = []string{"priority", "id", "title", "type", "source", "created_at"}
+
+// outputReviewQueueCSV writes review queue items as CSV.
+func outputReviewQueueCSV(w io.Writer, items []ReviewItem) error {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			string(item.Priority),
+			item.ID,
+			item.Title,
+			item.ContentType,
+			item.Source,
+			csvTime(item.CreatedAt),
+		})
+	}
+	return outputCSV(w, reviewQueueCSVHeader, rows)
+}
+
+// assertionExportCSVHeader holds the columns of 'assertions export -o csv'.
+var assertionExportCSVHeader = []string{
+	"id", "type", "text", "confidence", "created_at", "entities",
+	"source_content_id", "source_type", "source_subject", "source_from", "source_date", "excerpt",
+}
+
+// outputAssertionExportCSV writes exported assertions as CSV, one row per
+// assertion with its entities joined into one cell.
+func outputAssertionExportCSV(w io.Writer, assertions []ExportedAssertion) error {
+	rows := make([][]string, 0, len(assertions))
+	for _, a := range assertions {
+		entities := make([]string, 0, len(a.Entities))
+		for _, e := range a.Entities {
+			entities = append(entities, fmt.Sprintf("%s (%s) [%s]", e.Name, e.Role, e.ID))
+		}
+
+		var sourceID, sourceType, subject, from, date string
+		if a.Source != nil {
+			sourceID, sourceType, subject, from = a.Source.ContentID, a.Source.Type, a.Source.Subject, a.Source.From
+			if a.Source.Date != nil {
+				date = csvTime(*a.Source.Date)
+			}
+		}
+
+		rows = append(rows, []string{
+			strconv.FormatInt(a.ID, 10),
+			a.Type,
+			a.Text,
+			csvFloat(a.Confidence),
+			csvTime(a.CreatedAt),
+			csvList(entities),
+			sourceID,
+			sourceType,
+			subject,
+			from,
+			date,
+			a.Excerpt,
+		})
+	}
+	return outputCSV(w, assertionExportCSVHeader, rows)
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestOutputCSV_QuotesFields(t *testing.T) {
+	var buf bytes.Buffer
+	err := outputCSV(&buf, []string{"name", "note"}, [][]string{
+		{"a,b", `say "hi"`},
+		{"plain", "line1\nline2"},
+	})
+	if err != nil {
+		t.Fatalf("outputCSV: %v", err)
+	}
+
+	want := "name,note\n\"a,b\",\"say \"\"hi\"\"\"\nplain,\"line1\nline2\"\n"
+	if buf.String() != want {
+		t.Errorf("outputCSV =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestOutputJobSummariesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	jobs := []*pipelinev1.JobSummary{
+		{Id: "job-1", Status: "completed", SourceTag: "gmail, import", TotalFiles: 10, ImportedCount: 9, FailedCount: 1},
+	}
+	if err := outputJobSummariesCSV(&buf, jobs); err != nil {
+		t.Fatalf("outputJobSummariesCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+	want := []string{"job-1", "completed", "gmail, import", "10", "9", "1"}
+	for i, v := range want {
+		if records[1][i] != v {
+			t.Errorf("column %s = %q, want %q", jobSummaryCSVHeader[i], records[1][i], v)
+		}
+	}
+}
+
+func TestOutputDeletedSourcesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sources := []*pipelinev1.DeletedSource{
+		{Id: 42, SourceSystem: "gmail", ExternalId: "msg-1", ProcessingStatus: "completed", DeletedAt: timestamppb.New(deletedAt), DeletedBy: "ops"},
+		{Id: 43, SourceSystem: "slack"},
+	}
+	if err := outputDeletedSourcesCSV(&buf, sources); err != nil {
+		t.Fatalf("outputDeletedSourcesCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d records", len(records))
+	}
+	if records[1][4] != "2026-03-01T12:00:00Z" {
+		t.Errorf("deleted_at = %q", records[1][4])
+	}
+	if records[2][4] != "" || records[2][5] != "" {
+		t.Errorf("expected empty optional columns, got %v", records[2])
+	}
+}
+
+func TestOutputEntitiesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	entities := []Entity{
+		{ID: "ent-1", Name: "Smith, Jane", Type: EntityTypePerson, AccountType: "person", RelationCount: 3, Confidence: 0.875},
+	}
+	if err := outputEntitiesCSV(&buf, entities); err != nil {
+		t.Fatalf("outputEntitiesCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+	want := []string{"ent-1", "Smith, Jane", "person", "person", "3", "0.875"}
+	for i, v := range want {
+		if records[1][i] != v {
+			t.Errorf("column %s = %q, want %q", entityCSVHeader[i], records[1][i], v)
+		}
+	}
+}
+
+func TestOutputEntityDetailCSV_KeyValue(t *testing.T) {
+	var buf bytes.Buffer
+	e := Entity{
+		ID:       "ent-1",
+		Name:     "Jane",
+		Aliases:  []string{"jane@example.com", "J. Smith"},
+		Metadata: map[string]string{"team": "infra", "account_type": "person"},
+	}
+	if err := outputEntityDetailCSV(&buf, e); err != nil {
+		t.Fatalf("outputEntityDetailCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV: %v", err)
+	}
+	if records[0][0] != "key" || records[0][1] != "value" {
+		t.Errorf("header = %v, want key,value", records[0])
+	}
+	values := make(map[string]string)
+	for _, r := range records[1:] {
+		if len(r) != 2 {
+			t.Fatalf("expected two columns, got %v", r)
+		}
+		values[r[0]] = r[1]
+	}
+	if values["aliases"] != "jane@example.com; J. Smith" {
+		t.Errorf("aliases = %q", values["aliases"])
+	}
+	if values["metadata.team"] != "infra" {
+		t.Errorf("metadata.team = %q", values["metadata.team"])
+	}
+	if values["first_seen"] != "" {
+		t.Errorf("expected empty first_seen for zero time, got %q", values["first_seen"])
+	}
+	last := records[len(records)-1]
+	if last[0] != "metadata.team" {
+		t.Errorf("expected sorted metadata keys last, got %v", last)
+	}
+}
+
+func TestOutputDuplicatePairsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	pairs := []*client.DuplicatePair{
+		{EntityID1: "e1", EntityName1: "Jane", EntityID2: "e2", EntityName2: "J. Smith", Similarity: 0.92, Signals: []string{"name", "email"}},
+	}
+	if err := outputDuplicatePairsCSV(&buf, pairs); err != nil {
+		t.Fatalf("outputDuplicatePairsCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV: %v", err)
+	}
+	want := []string{"e1", "Jane", "e2", "J. Smith", "0.92", "name; email"}
+	for i, v := range want {
+		if records[1][i] != v {
+			t.Errorf("column %s = %q, want %q", duplicatePairCSVHeader[i], records[1][i], v)
+		}
+	}
+}
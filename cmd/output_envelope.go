@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// EnvelopeAnnotation marks a command whose --output json result is a
+// ResultEnvelope. A failed run of such a command is reported as an envelope
+// too, by WriteErrorJSON.
+const EnvelopeAnnotation = "penf.json-envelope"
+
+// rootOutputFormat is set by the root --output flag.
+var rootOutputFormat string
+
+// SetRootOutputFormat sets the format requested with the root --output flag,
+// for commands that have no --output flag of their own.
+func SetRootOutputFormat(format string) {
+	rootOutputFormat = format
+}
+
+// ResultEnvelope is the --output json result of commands that perform an
+// action rather than show a document.
+type ResultEnvelope struct {
+	Success bool          `json:"success"`
+	Data    any           `json:"data"`
+	Message string        `json:"message,omitempty"`
+	Error   *CommandError `json:"error,omitempty"`
+}
+
+// withEnvelope marks cmd as reporting its --output json result in a
+// ResultEnvelope, and returns it.
+func withEnvelope(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[EnvelopeAnnotation] = "true"
+	return cmd
+}
+
+// envelopeJSON reports whether the root --output flag asks for JSON.
+func envelopeJSON() bool {
+	return config.OutputFormat(rootOutputFormat) == config.OutputFormatJSON
+}
+
+// resultOut returns the writer for the human-readable output of an envelope
+// command, which is discarded when the result is written as JSON.
+func resultOut() io.Writer {
+	if envelopeJSON() {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+// writeResult writes a successful result as a ResultEnvelope when --output
+// json is requested. Otherwise the command has already printed its text and
+// writeResult does nothing.
+func writeResult(data any, message string) error {
+	return writeOutcome(true, data, message)
+}
+
+// writeOutcome is writeResult for commands that finish without an error but
+// may not have done what was asked, such as a request the server declined.
+func writeOutcome(success bool, data any, message string) error {
+	if !envelopeJSON() {
+		return nil
+	}
+	return writeOutcomeJSON(success, data, message)
+}
+
+// writeOutcomeJSON writes a ResultEnvelope to stdout. Envelope commands with
+// an --output flag of their own call it when that flag asks for JSON.
+func writeOutcomeJSON(success bool, data any, message string) error {
+	return writeEnvelope(os.Stdout, ResultEnvelope{Success: success, Data: data, Message: message})
+}
+
+// writeEnvelope writes an envelope as indented JSON.
+func writeEnvelope(w io.Writer, env ResultEnvelope) error {
+	return writeJSON(w, env)
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteResult_TextIsNoOp(t *testing.T) {
+	SetRootOutputFormat("text")
+	defer SetRootOutputFormat("")
+
+	output := captureStdout(func() {
+		require.NoError(t, writeResult(map[string]int{"n": 1}, "done"))
+	})
+	assert.Empty(t, output)
+}
+
+func TestWriteResult_JSONEnvelope(t *testing.T) {
+	SetRootOutputFormat("json")
+	defer SetRootOutputFormat("")
+
+	output := captureStdout(func() {
+		w := resultOut()
+		_, _ = w.Write([]byte("human text\n"))
+		require.NoError(t, writeOutcome(false, map[string]int{"n": 1}, "declined"))
+	})
+
+	var env struct {
+		Success bool           `json:"success"`
+		Data    map[string]int `json:"data"`
+		Message string         `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &env), "stdout must be only the envelope: %q", output)
+	assert.False(t, env.Success)
+	assert.Equal(t, 1, env.Data["n"])
+	assert.Equal(t, "declined", env.Message)
+}
+
+func TestWriteErrorJSON_Envelope(t *testing.T) {
+	root := &cobra.Command{Use: "penf"}
+	wrapped := withEnvelope(&cobra.Command{Use: "delete"})
+	root.AddCommand(wrapped)
+	cmdErr := notFoundError("rule not found: nightly")
+
+	SetRootOutputFormat("json")
+	defer SetRootOutputFormat("")
+
+	var buf bytes.Buffer
+	require.True(t, WriteErrorJSON(&buf, wrapped, cmdErr))
+	assert.JSONEq(t, `{
+		"success": false,
+		"data": null,
+		"message": "rule not found: nightly",
+		"error": {"code": "not_found", "message": "rule not found: nightly", "command": "penf delete"}
+	}`, buf.String())
+
+	SetRootOutputFormat("text")
+	buf.Reset()
+	assert.False(t, WriteErrorJSON(&buf, wrapped, cmdErr))
+	assert.Empty(t, buf.String())
+}
+
+func TestRunWorkflowCancel_JSONEnvelope(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, _ := createWorkflowTestDepsWithMocks(cfg)
+
+	oldYes := workflowYes
+	workflowYes = true
+	defer func() {
+		workflowYes = oldYes
+	}()
+	SetRootOutputFormat("json")
+	defer SetRootOutputFormat("")
+
+	var err error
+	output := captureStdout(func() {
+		err = runWorkflowCancel(context.Background(), deps, "wf-test-001")
+	})
+	require.NoError(t, err)
+
+	var env struct {
+		Success bool                  `json:"success"`
+		Data    WorkflowControlResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &env), "stdout must be only the envelope: %q", output)
+	assert.True(t, env.Success)
+	assert.Equal(t, "wf-test-001", env.Data.WorkflowID)
+	assert.Equal(t, "cancel", env.Data.Action)
+	assert.True(t, env.Data.Accepted)
+	assert.Equal(t, string(WorkflowStatusRunning), env.Data.StateBefore)
+}
+
+func TestRunEntityMerge_JSONEnvelope(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{})
+
+	var err error
+	output := captureStdout(func() {
+		err = runEntityMerge(context.Background(), deps, "ent-person-1", "ent-person-2", false)
+	})
+	require.NoError(t, err)
+
+	var env struct {
+		Success bool              `json:"success"`
+		Data    EntityMergeResult `json:"data"`
+		Message string            `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &env), "stdout must be only the envelope: %q", output)
+	assert.True(t, env.Success)
+	assert.Equal(t, "ent-person-1", env.Data.PrimaryEntityID)
+	assert.Equal(t, 3, env.Data.RelationshipsTransferred)
+	assert.Equal(t, "entities merged", env.Message)
+}
+
+func TestActionCommands_EnvelopeAnnotation(t *testing.T) {
+	roots := map[string]*cobra.Command{
+		"glossary":    NewGlossaryCommand(nil),
+		"schedule":    NewScheduleCommand(nil),
+		"trust":       NewTrustCommand(nil),
+		"seniority":   NewSeniorityCommand(nil),
+		"team":        NewTeamCommand(nil),
+		"project":     NewProjectCommand(nil),
+		"product":     NewProductCommand(nil),
+		"entity":      NewEntityCommand(nil),
+		"instruction": NewInstructionCommand(nil),
+		"source":      NewSourceCommand(nil),
+		"tenant":      NewTenantCommand(nil),
+	}
+	actions := map[string][][]string{
+		"glossary":  {{"add"}, {"remove"}, {"alias"}, {"link"}, {"unlink"}},
+		"schedule":  {{"update"}, {"pause"}, {"resume"}, {"trigger"}, {"delete"}},
+		"trust":     {{"set"}, {"clear"}},
+		"seniority": {{"set"}, {"clear"}},
+		"team":      {{"create"}, {"delete"}, {"add-member"}, {"remove-member"}},
+		"project":   {{"add"}, {"delete"}, {"update"}},
+		"product":   {{"add"}, {"alias", "add"}, {"alias", "remove"}},
+		"entity": {{"reject"}, {"restore"}, {"delete"}, {"update"}, {"bulk-enrich"},
+			{"filter", "add"}, {"filter", "remove"}, {"pattern", "add"}, {"pattern", "remove"},
+			{"group", "add"}, {"group", "remove"}},
+		"instruction": {{"delete"}, {"enable"}, {"disable"}},
+		"source":      {{"remove"}},
+		"tenant":      {{"switch"}, {"create"}},
+	}
+
+	for name, paths := range actions {
+		for _, path := range paths {
+			sub, _, err := roots[name].Find(path)
+			require.NoError(t, err, "%s %v", name, path)
+			assert.Equal(t, "true", sub.Annotations[EnvelopeAnnotation], "%s %v should report a JSON envelope", name, path)
+		}
+	}
+}
+
+func TestWriteRosterResult_JSONEnvelope(t *testing.T) {
+	output := captureStdout(func() {
+		require.NoError(t, writeRosterResult("trust", false, 2, 1))
+	})
+
+	var env struct {
+		Success bool         `json:"success"`
+		Data    RosterResult `json:"data"`
+		Message string       `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &env), "stdout must be only the envelope: %q", output)
+	assert.False(t, env.Success)
+	assert.Equal(t, RosterResult{Attribute: "trust", Succeeded: 2, Failed: 1}, env.Data)
+	assert.Equal(t, "2 set, 1 failed", env.Message)
+}
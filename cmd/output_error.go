@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+// Error codes reported in the --output json error object. The set is stable;
+// scripts may switch on it.
+const (
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeUnauthenticated = "unauthenticated"
+	ErrorCodeUnavailable     = "unavailable"
+	ErrorCodeInvalidArgument = "invalid_argument"
+	ErrorCodeInternal        = "internal"
+)
+
+// Process exit codes of a failed command, by cause. Scripts may rely on them;
+// see ExitCode.
+const (
+	ExitGeneral          = 1
+	ExitInvalidArgument  = 2
+	ExitNotFound         = 4
+	ExitPermissionDenied = 5
+	ExitUnauthenticated  = 6
+	ExitUnavailable      = 7
+	ExitDeadlineExceeded = 8
+	ExitUpdateAvailable  = 10
+	ExitInterrupted      = 130
+)
+
+// CommandError is the --output json error object of a failed command.
+type CommandError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Command string `json:"command,omitempty"`
+}
+
+// codedError attaches an error code to an error that does not carry a gRPC
+// status, such as a lookup that came back empty.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// notFoundError returns a formatted error reported as not_found.
+func notFoundError(format string, args ...any) error {
+	return &codedError{code: ErrorCodeNotFound, err: fmt.Errorf(format, args...)}
+}
+
+// InvalidArgumentError marks err, such as a flag parsing error, as
+// invalid_argument.
+func InvalidArgumentError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: ErrorCodeInvalidArgument, err: err}
+}
+
+// statusExit is returned by a command that ran successfully but reports its
+// result through the exit code alone, like 'update --check-only'. It is not
+// printed as an error.
+type statusExit struct{ code int }
+
+func (e *statusExit) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+
+// IsStatusExit reports whether err only carries an exit code, not a failure
+// to report.
+func IsStatusExit(err error) bool {
+	var exit *statusExit
+	return errors.As(err, &exit)
+}
+
+// ErrorCode maps err to one of the ErrorCode constants. Codes attached with
+// notFoundError or InvalidArgumentError win, then a server that could not be
+// reached, then the gRPC status anywhere in the wrap chain, then a few
+// well-known local errors.
+func ErrorCode(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	if serverUnreachable(err) {
+		return ErrorCodeUnavailable
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.NotFound:
+			return ErrorCodeNotFound
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return ErrorCodeUnauthenticated
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return ErrorCodeUnavailable
+		case codes.InvalidArgument, codes.OutOfRange:
+			return ErrorCodeInvalidArgument
+		}
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return ErrorCodeNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeUnavailable
+	}
+	return ErrorCodeInternal
+}
+
+// ExitCode maps err to the process exit code: 0 for nil, one of the Exit
+// constants for a known cause, and ExitGeneral otherwise. Like ErrorCode it
+// honors codes attached with notFoundError or InvalidArgumentError, a server
+// that could not be reached and the gRPC status anywhere in the wrap chain,
+// but keeps permission denied and deadline exceeded apart.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exit *statusExit
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	var coded *codedError
+	if errors.As(err, &coded) {
+		switch coded.code {
+		case ErrorCodeNotFound:
+			return ExitNotFound
+		case ErrorCodeInvalidArgument:
+			return ExitInvalidArgument
+		}
+	}
+	if serverUnreachable(err) {
+		return ExitUnavailable
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.NotFound:
+			return ExitNotFound
+		case codes.PermissionDenied:
+			return ExitPermissionDenied
+		case codes.Unauthenticated:
+			return ExitUnauthenticated
+		case codes.Unavailable:
+			return ExitUnavailable
+		case codes.DeadlineExceeded:
+			return ExitDeadlineExceeded
+		case codes.InvalidArgument, codes.OutOfRange:
+			return ExitInvalidArgument
+		}
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return ExitNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitDeadlineExceeded
+	}
+	return ExitGeneral
+}
+
+// serverUnreachable reports whether err means the server could not be
+// reached: the circuit breaker is open, or the connection could not be
+// established, whatever the dial error (typically its deadline passing).
+func serverUnreachable(err error) bool {
+	var connErr *client.ConnectError
+	return errors.Is(err, client.ErrServerUnavailable) || errors.As(err, &connErr)
+}
+
+// NewCommandError builds the error object for err returned by executed.
+func NewCommandError(executed *cobra.Command, err error) CommandError {
+	ce := CommandError{Code: ErrorCode(err), Message: err.Error()}
+	if executed != nil {
+		ce.Command = executed.CommandPath()
+	}
+	return ce
+}
+
+// commandArgs holds the command-line arguments penf was run with.
+var commandArgs []string
+
+// SetCommandArgs records the command-line arguments, so that errors raised
+// before cobra parses the flags can still honour --output json.
+func SetCommandArgs(args []string) {
+	commandArgs = args
+}
+
+// jsonOutputRequested reports whether the executed command was asked for JSON,
+// through its own --output flag or the root one. The flags are read from the
+// command rather than the value set on initialization, which cobra skips when
+// flag parsing fails; for an unknown command, where no flags are parsed at
+// all, the value is read from the command line.
+func jsonOutputRequested(executed *cobra.Command) bool {
+	if executed != nil {
+		if f := executed.Flags().Lookup("output"); f != nil && f.Changed {
+			return f.Value.String() == "json"
+		}
+		if f := executed.Root().PersistentFlags().Lookup("output"); f != nil && f.Changed {
+			return f.Value.String() == "json"
+		}
+	}
+	if format := outputFlagFromArgs(commandArgs); format != "" {
+		return format == "json"
+	}
+	if executed != nil {
+		if f := executed.Flags().Lookup("output"); f != nil {
+			return f.Value.String() == "json"
+		}
+	}
+	return envelopeJSON()
+}
+
+// outputFlagFromArgs returns the value of the --output (-o) flag in args, or
+// "" when it is not given. Other flags are ignored.
+func outputFlagFromArgs(args []string) string {
+	fs := pflag.NewFlagSet("output", pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.SetOutput(io.Discard)
+	format := fs.StringP("output", "o", "", "")
+	_ = fs.Parse(args)
+	return *format
+}
+
+// WriteErrorJSON writes err to w as JSON when --output json is requested and
+// reports whether it wrote anything. Commands that report in a ResultEnvelope
+// get a failed envelope carrying the error object; all others get
+// {"error": {...}}.
+func WriteErrorJSON(w io.Writer, executed *cobra.Command, err error) bool {
+	if err == nil || !jsonOutputRequested(executed) {
+		return false
+	}
+	ce := NewCommandError(executed, err)
+
+	var werr error
+	if executed != nil && executed.Annotations[EnvelopeAnnotation] == "true" {
+		werr = writeEnvelope(w, ResultEnvelope{Success: false, Message: ce.Message, Error: &ce})
+	} else {
+		werr = writeJSON(w, struct {
+			Error CommandError `json:"error"`
+		}{ce})
+	}
+	if werr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: writing JSON error: %v\n", werr)
+	}
+	return true
+}
+
+// MarkArgErrors makes the argument validation errors of root and all its
+// subcommands report as invalid_argument.
+func MarkArgErrors(root *cobra.Command) {
+	if validate := root.Args; validate != nil {
+		root.Args = func(c *cobra.Command, args []string) error {
+			return InvalidArgumentError(validate(c, args))
+		}
+	}
+	for _, c := range root.Commands() {
+		MarkArgErrors(c)
+	}
+}
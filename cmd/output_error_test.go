@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"grpc not found", status.Error(codes.NotFound, "no such rule"), ErrorCodeNotFound},
+		{"wrapped grpc", fmt.Errorf("listing rules: %w", status.Error(codes.Unauthenticated, "token expired")), ErrorCodeUnauthenticated},
+		{"permission denied", status.Error(codes.PermissionDenied, "no"), ErrorCodeUnauthenticated},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), ErrorCodeUnavailable},
+		{"deadline", fmt.Errorf("connecting to gateway: %w", context.DeadlineExceeded), ErrorCodeUnavailable},
+		{"invalid grpc", status.Error(codes.InvalidArgument, "bad id"), ErrorCodeInvalidArgument},
+		{"circuit open", fmt.Errorf("connecting to server: %w", client.ErrServerUnavailable), ErrorCodeUnavailable},
+		{"dial failed", client.NewConnectError("gateway at localhost:50051", context.DeadlineExceeded), ErrorCodeUnavailable},
+		{"flag error", InvalidArgumentError(errors.New("unknown flag: --bogus")), ErrorCodeInvalidArgument},
+		{"lookup", notFoundError("schedule not found: %s", "nightly"), ErrorCodeNotFound},
+		{"missing file", fmt.Errorf("reading manifest: %w", os.ErrNotExist), ErrorCodeNotFound},
+		{"grpc internal", status.Error(codes.Internal, "boom"), ErrorCodeInternal},
+		{"plain", errors.New("something broke"), ErrorCodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCode(tt.err))
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"not found", fmt.Errorf("getting rule: %w", status.Error(codes.NotFound, "no such rule")), ExitNotFound},
+		{"lookup", notFoundError("rule not found: %s", "nightly"), ExitNotFound},
+		{"permission denied", status.Error(codes.PermissionDenied, "no"), ExitPermissionDenied},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "token expired"), ExitUnauthenticated},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), ExitUnavailable},
+		{"grpc deadline", status.Error(codes.DeadlineExceeded, "slow"), ExitDeadlineExceeded},
+		{"request deadline", fmt.Errorf("listing rules: %w", context.DeadlineExceeded), ExitDeadlineExceeded},
+		{"circuit open", fmt.Errorf("connecting to server: %w", client.ErrServerUnavailable), ExitUnavailable},
+		{"dial failed", fmt.Errorf("connecting to server: %w", client.NewConnectError("localhost:50051", context.DeadlineExceeded)), ExitUnavailable},
+		{"usage", InvalidArgumentError(errors.New("accepts 1 arg(s), received 0")), ExitInvalidArgument},
+		{"update available", &statusExit{code: ExitUpdateAvailable}, ExitUpdateAvailable},
+		{"other", errors.New("something broke"), ExitGeneral},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestWriteErrorJSON(t *testing.T) {
+	root := &cobra.Command{Use: "penf"}
+	root.PersistentFlags().String("output", "", "")
+	list := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	list.Flags().StringP("output", "o", "text", "")
+	show := &cobra.Command{Use: "show", RunE: func(*cobra.Command, []string) error { return nil }}
+	root.AddCommand(list, show)
+
+	cmdErr := fmt.Errorf("listing rules: %w", status.Error(codes.Unavailable, "connection refused"))
+
+	var buf bytes.Buffer
+	require.NoError(t, list.Flags().Set("output", "json"))
+	require.True(t, WriteErrorJSON(&buf, list, cmdErr), "a command's own --output json is honored")
+	assert.JSONEq(t, `{"error": {
+		"code": "unavailable",
+		"message": "listing rules: rpc error: code = Unavailable desc = connection refused",
+		"command": "penf list"
+	}}`, buf.String())
+
+	buf.Reset()
+	assert.False(t, WriteErrorJSON(&buf, show, cmdErr), "human mode writes nothing to stdout")
+	assert.Empty(t, buf.String())
+
+	SetRootOutputFormat("json")
+	defer SetRootOutputFormat("")
+	assert.True(t, WriteErrorJSON(&buf, nil, cmdErr), "errors before a command is resolved follow the root flag")
+}
+
+func TestWriteErrorJSON_BeforeInitialize(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{Use: "penf", SilenceErrors: true, SilenceUsage: true}
+		root.PersistentFlags().String("output", "", "")
+		show := &cobra.Command{Use: "show", RunE: func(*cobra.Command, []string) error { return nil }}
+		show.Flags().Int("limit", 0, "")
+		root.AddCommand(show)
+		return root
+	}
+	defer SetCommandArgs(nil)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"bad flag value", []string{"show", "--output", "json", "--limit", "many"}},
+		{"unknown flag", []string{"show", "--bogus", "--output", "json"}},
+		{"unknown command", []string{"shwo", "--output=json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+			root.SetArgs(tt.args)
+			SetCommandArgs(tt.args)
+
+			executed, err := root.ExecuteC()
+			require.Error(t, err)
+
+			var buf bytes.Buffer
+			require.True(t, WriteErrorJSON(&buf, executed, err), "--output json is honored without initialization")
+			assert.Contains(t, buf.String(), `"error"`)
+		})
+	}
+
+	root := newRoot()
+	args := []string{"show", "--limit", "many"}
+	root.SetArgs(args)
+	SetCommandArgs(args)
+	executed, err := root.ExecuteC()
+	require.Error(t, err)
+	assert.False(t, WriteErrorJSON(&bytes.Buffer{}, executed, err), "no --output writes nothing")
+}
+
+func TestOutputFlagFromArgs(t *testing.T) {
+	assert.Equal(t, "json", outputFlagFromArgs([]string{"pipeline", "--tenant", "t1", "-o", "json"}))
+	assert.Equal(t, "yaml", outputFlagFromArgs([]string{"--output=yaml", "--unknown", "list"}))
+	assert.Equal(t, "", outputFlagFromArgs([]string{"list", "--limit", "5"}))
+	assert.Equal(t, "", outputFlagFromArgs(nil))
+}
+
+func TestMarkArgErrors(t *testing.T) {
+	root := &cobra.Command{Use: "penf"}
+	sub := &cobra.Command{Use: "show <id>", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+	root.AddCommand(sub)
+	MarkArgErrors(root)
+
+	err := sub.Args(sub, nil)
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeInvalidArgument, ErrorCode(err))
+	assert.NoError(t, sub.Args(sub, []string{"1"}))
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonLinesEncoder writes records as newline-delimited JSON, one compact
+// object per line, so list commands can emit each record as it arrives
+// instead of buffering the whole result set.
+type jsonLinesEncoder struct {
+	enc   *json.Encoder
+	count int
+	err   error
+}
+
+// newJSONLinesEncoder returns an encoder that writes to w.
+func newJSONLinesEncoder(w io.Writer) *jsonLinesEncoder {
+	return &jsonLinesEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes v as a single line. After the first error further records
+// are dropped; the error is reported by Err.
+func (e *jsonLinesEncoder) Encode(v interface{}) {
+	if e.err != nil {
+		return
+	}
+	if e.err = e.enc.Encode(v); e.err == nil {
+		e.count++
+	}
+}
+
+// Count returns the number of records written.
+func (e *jsonLinesEncoder) Count() int {
+	return e.count
+}
+
+// Err returns the first write error, if any.
+func (e *jsonLinesEncoder) Err() error {
+	return e.err
+}
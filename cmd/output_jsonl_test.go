@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("closed pipe")
+}
+
+func TestJSONLinesEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	stream := newJSONLinesEncoder(&buf)
+	stream.Encode(map[string]string{"id": "a"})
+	stream.Encode(map[string]string{"id": "b"})
+
+	if want := "{\"id\":\"a\"}\n{\"id\":\"b\"}\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if stream.Count() != 2 || stream.Err() != nil {
+		t.Errorf("count = %d, err = %v", stream.Count(), stream.Err())
+	}
+}
+
+func TestJSONLinesEncoder_StopsAfterError(t *testing.T) {
+	stream := newJSONLinesEncoder(failingWriter{})
+	stream.Encode("a")
+	stream.Encode("b")
+
+	if stream.Err() == nil {
+		t.Fatal("expected write error")
+	}
+	if stream.Count() != 0 {
+		t.Errorf("count = %d, want 0", stream.Count())
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// outputTemplateText is set by the root --template flag.
+var outputTemplateText string
+
+// SetOutputTemplate sets the Go template used by --output template.
+func SetOutputTemplate(text string) {
+	outputTemplateText = text
+}
+
+// templateFuncs are the helpers available to --template, in addition to the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"red":    func(v interface{}) string { return colorize("31", v) },
+	"green":  func(v interface{}) string { return colorize("32", v) },
+	"yellow": func(v interface{}) string { return colorize("33", v) },
+	"blue":   func(v interface{}) string { return colorize("34", v) },
+	"bold":   func(v interface{}) string { return colorize("1", v) },
+	"dim":    func(v interface{}) string { return colorize("2", v) },
+	"upper":  func(v interface{}) string { return strings.ToUpper(fmt.Sprint(v)) },
+	"lower":  func(v interface{}) string { return strings.ToLower(fmt.Sprint(v)) },
+	"trunc":  func(n int, v interface{}) string { return truncateString(fmt.Sprint(v), n) },
+	"join":   strings.Join,
+	"date":   templateDate,
+	"ago":    templateAgo,
+}
+
+// colorize wraps the value in an ANSI color sequence.
+func colorize(code string, v interface{}) string {
+	return "\033[" + code + "m" + fmt.Sprint(v) + "\033[0m"
+}
+
+// templateTime converts the time values found in output structs. ok is false
+// for nil or unset times.
+func templateTime(v interface{}) (t time.Time, ok bool) {
+	switch tv := v.(type) {
+	case time.Time:
+		t = tv
+	case *time.Time:
+		if tv != nil {
+			t = *tv
+		}
+	case *timestamppb.Timestamp:
+		if tv != nil {
+			t = tv.AsTime()
+		}
+	case string:
+		t, _ = time.Parse(time.RFC3339, tv)
+	}
+	return t, !t.IsZero()
+}
+
+// templateDate formats a time with a Go layout, e.g. {{date "2006-01-02" .CreatedAt}}.
+// Unset times are rendered as "-".
+func templateDate(layout string, v interface{}) string {
+	t, ok := templateTime(v)
+	if !ok {
+		return "-"
+	}
+	return t.Local().Format(layout)
+}
+
+// templateAgo formats a time relative to now, e.g. "3 hours ago".
+func templateAgo(v interface{}) string {
+	t, ok := templateTime(v)
+	if !ok {
+		return "-"
+	}
+	return formatRelativeTime(t)
+}
+
+// parseOutputTemplate compiles the --template text. Commands call it before
+// their RPCs so a broken template fails before any work is done.
+func parseOutputTemplate() (*template.Template, error) {
+	if outputTemplateText == "" {
+		return nil, errors.New("--output template requires --template, e.g. --template '{{.ID}} {{.Name}}'")
+	}
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(outputTemplateText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// checkOutputTemplate validates the template when format is template.
+func checkOutputTemplate(format config.OutputFormat) error {
+	if format != config.OutputFormatTemplate {
+		return nil
+	}
+	_, err := parseOutputTemplate()
+	return err
+}
+
+// outputWithTemplate writes v with the --template, for detail commands.
+func outputWithTemplate(w io.Writer, v interface{}) error {
+	tmpl, err := parseOutputTemplate()
+	if err != nil {
+		return err
+	}
+	enc := &templateEncoder{w: w, tmpl: tmpl}
+	enc.Encode(v)
+	return enc.Err()
+}
+
+// templateEncoder writes each record with the --template, one per line.
+type templateEncoder struct {
+	w     io.Writer
+	tmpl  *template.Template
+	count int
+	err   error
+}
+
+// Encode writes v followed by a newline. After the first error further
+// records are dropped; the error is reported by Err.
+func (e *templateEncoder) Encode(v interface{}) {
+	if e.err != nil {
+		return
+	}
+	if e.err = e.tmpl.Execute(e.w, v); e.err != nil {
+		e.err = fmt.Errorf("executing --template: %w", e.err)
+		return
+	}
+	if _, e.err = io.WriteString(e.w, "\n"); e.err == nil {
+		e.count++
+	}
+}
+
+// Count returns the number of records written.
+func (e *templateEncoder) Count() int {
+	return e.count
+}
+
+// Err returns the first error, if any.
+func (e *templateEncoder) Err() error {
+	return e.err
+}
+
+// recordEncoder writes list records as they arrive.
+type recordEncoder interface {
+	Encode(v interface{})
+	Count() int
+	Err() error
+}
+
+// newRecordStream returns the encoder for list formats that are written per
+// record (jsonl and template), or nil for formats that need the whole result.
+// The template is compiled here, before any RPC.
+func newRecordStream(w io.Writer, format config.OutputFormat) (recordEncoder, error) {
+	switch format {
+	case config.OutputFormatJSONL:
+		return newJSONLinesEncoder(w), nil
+	case config.OutputFormatTemplate:
+		tmpl, err := parseOutputTemplate()
+		if err != nil {
+			return nil, err
+		}
+		return &templateEncoder{w: w, tmpl: tmpl}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func withOutputTemplate(t *testing.T, text string) {
+	t.Helper()
+	SetOutputTemplate(text)
+	t.Cleanup(func() { SetOutputTemplate("") })
+}
+
+func TestTemplateEncoder(t *testing.T) {
+	withOutputTemplate(t, "{{.Name}} ({{.Type}}): {{.Confidence}}")
+
+	var buf bytes.Buffer
+	stream, err := newRecordStream(&buf, config.OutputFormatTemplate)
+	if err != nil {
+		t.Fatalf("newRecordStream: %v", err)
+	}
+	stream.Encode(Entity{Name: "Alice", Type: EntityTypePerson, Confidence: 0.9})
+	stream.Encode(Entity{Name: "Acme", Type: EntityTypeOrganization, Confidence: 0.5})
+
+	want := "Alice (person): 0.9\nAcme (organization): 0.5\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if stream.Count() != 2 || stream.Err() != nil {
+		t.Errorf("count = %d, err = %v", stream.Count(), stream.Err())
+	}
+}
+
+func TestTemplateEncoder_ExecuteError(t *testing.T) {
+	withOutputTemplate(t, "{{.Missing}}")
+
+	stream, err := newRecordStream(&bytes.Buffer{}, config.OutputFormatTemplate)
+	if err != nil {
+		t.Fatalf("newRecordStream: %v", err)
+	}
+	stream.Encode(Entity{Name: "Alice"})
+	stream.Encode(Entity{Name: "Bob"})
+
+	if stream.Err() == nil || !strings.Contains(stream.Err().Error(), "executing --template") {
+		t.Errorf("err = %v, want execute error", stream.Err())
+	}
+	if stream.Count() != 0 {
+		t.Errorf("count = %d, want 0", stream.Count())
+	}
+}
+
+func TestNewRecordStream_ValidatesTemplate(t *testing.T) {
+	withOutputTemplate(t, "")
+	if _, err := newRecordStream(&bytes.Buffer{}, config.OutputFormatTemplate); err == nil || !strings.Contains(err.Error(), "requires --template") {
+		t.Errorf("err = %v, want missing template error", err)
+	}
+
+	withOutputTemplate(t, "{{.Name")
+	if _, err := newRecordStream(&bytes.Buffer{}, config.OutputFormatTemplate); err == nil || !strings.Contains(err.Error(), "invalid --template") {
+		t.Errorf("err = %v, want parse error", err)
+	}
+	if err := checkOutputTemplate(config.OutputFormatTemplate); err == nil {
+		t.Error("checkOutputTemplate accepted an invalid template")
+	}
+	if err := checkOutputTemplate(config.OutputFormatJSON); err != nil {
+		t.Errorf("checkOutputTemplate(json) = %v", err)
+	}
+}
+
+func TestNewRecordStream_BufferedFormats(t *testing.T) {
+	for _, format := range []config.OutputFormat{config.OutputFormatText, config.OutputFormatJSON, config.OutputFormatCSV} {
+		stream, err := newRecordStream(&bytes.Buffer{}, format)
+		if stream != nil || err != nil {
+			t.Errorf("newRecordStream(%s) = %v, %v; want nil", format, stream, err)
+		}
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	created := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)
+	withOutputTemplate(t, `{{bold .Name}} {{upper .Type}} {{date "2006-01-02" .Created}} {{date "2006-01-02" .Processed}} {{date "2006-01-02" .Unset}} {{trunc 3 .Name}}`)
+
+	var buf bytes.Buffer
+	err := outputWithTemplate(&buf, struct {
+		Name      string
+		Type      string
+		Created   time.Time
+		Processed *timestamppb.Timestamp
+		Unset     *time.Time
+	}{"Alice", "person", created, timestamppb.New(created), nil})
+	if err != nil {
+		t.Fatalf("outputWithTemplate: %v", err)
+	}
+
+	want := "\033[1mAlice\033[0m PERSON 2026-03-04 2026-03-04 - Ali\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutputContentItem_TemplateMatchesList(t *testing.T) {
+	const text = "{{.ID}} {{.State}}"
+	withOutputTemplate(t, text)
+
+	item := &contentv1.ContentItem{Id: "em-3f9a1c2b", State: contentv1.ProcessingState_PROCESSING_STATE_COMPLETED}
+	out := captureStdout(func() {
+		if err := outputContentItem(config.OutputFormatTemplate, item, nil); err != nil {
+			t.Errorf("outputContentItem() error = %v", err)
+		}
+	})
+	if out != "em-3f9a1c2b COMPLETED\n" {
+		t.Errorf("content show template output = %q, want the content list fields", out)
+	}
+}
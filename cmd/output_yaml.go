@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlFlow is set by the root --yaml-flow flag.
+var yamlFlow bool
+
+// SetYAMLFlow makes YAML output use compact flow style ({a: 1, b: [x, y]})
+// instead of block style.
+func SetYAMLFlow(flow bool) {
+	yamlFlow = flow
+}
+
+// YAMLEncoder writes --output yaml documents. Map keys are always written in
+// sorted order, so output is stable across runs and diffs cleanly; with
+// --yaml-flow, collections are written in flow style.
+type YAMLEncoder struct {
+	enc *yaml.Encoder
+}
+
+// NewYAMLEncoder returns a YAML encoder that writes to w. All YAML output
+// should go through it so --yaml-flow applies everywhere.
+func NewYAMLEncoder(w io.Writer) *YAMLEncoder {
+	return &YAMLEncoder{enc: yaml.NewEncoder(w)}
+}
+
+// Encode writes v as a YAML document.
+func (e *YAMLEncoder) Encode(v interface{}) error {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return err
+	}
+	if yamlFlow {
+		setYAMLFlowStyle(&node)
+	}
+	return e.enc.Encode(&node)
+}
+
+// Close flushes any buffered output.
+func (e *YAMLEncoder) Close() error {
+	return e.enc.Close()
+}
+
+// marshalYAML returns v as a YAML document, like yaml.Marshal.
+func marshalYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewYAMLEncoder(&buf)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setYAMLFlowStyle switches every mapping and sequence under node to flow
+// style.
+func setYAMLFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style |= yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		setYAMLFlowStyle(child)
+	}
+}
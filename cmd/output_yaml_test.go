@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+type yamlTestStats struct {
+	Total           int64             `yaml:"total"`
+	SourcesByStatus map[string]int64  `yaml:"sources_by_status"`
+	Metadata        map[string]string `yaml:"metadata,omitempty"`
+	Tags            []string          `yaml:"tags"`
+}
+
+func newYAMLTestStats() yamlTestStats {
+	return yamlTestStats{
+		Total:           6,
+		SourcesByStatus: map[string]int64{"pending": 1, "completed": 3, "failed": 2, "active": 0},
+		Metadata:        map[string]string{"zone": "b", "app": "a"},
+		Tags:            []string{"x", "y"},
+	}
+}
+
+func TestYAMLEncoder_SortsMapKeys(t *testing.T) {
+	want := `total: 6
+sources_by_status:
+    active: 0
+    completed: 3
+    failed: 2
+    pending: 1
+metadata:
+    app: a
+    zone: b
+tags:
+    - x
+    - "y"
+`
+	// Map iteration order is random, so a single run can pass by chance.
+	for i := 0; i < 20; i++ {
+		var buf bytes.Buffer
+		if err := NewYAMLEncoder(&buf).Encode(newYAMLTestStats()); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		if buf.String() != want {
+			t.Fatalf("output =\n%s\nwant\n%s", buf.String(), want)
+		}
+	}
+}
+
+func TestYAMLEncoder_Flow(t *testing.T) {
+	SetYAMLFlow(true)
+	t.Cleanup(func() { SetYAMLFlow(false) })
+
+	data, err := marshalYAML(newYAMLTestStats())
+	if err != nil {
+		t.Fatalf("marshalYAML: %v", err)
+	}
+	want := "{total: 6, sources_by_status: {active: 0, completed: 3, failed: 2, pending: 1}, metadata: {app: a, zone: b}, tags: [x, \"y\"]}\n"
+	if string(data) != want {
+		t.Errorf("output = %q, want %q", data, want)
+	}
+}
@@ -2,12 +2,15 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -90,6 +93,7 @@ Related Commands:
 	cmd.AddCommand(newPipelineErrorsCmd(pipelineDeps))
 	cmd.AddCommand(newPipelineInspectCmd(pipelineDeps))
 	cmd.AddCommand(newPipelineDiffCmd(pipelineDeps))
+	cmd.AddCommand(newPipelineTailCmd(pipelineDeps))
 	cmd.AddCommand(newPipelineStageCmd(pipelineDeps))
 	cmd.AddCommand(newPipelineRulesCmd(pipelineDeps))
 	cmd.AddCommand(newPipelineRoutingCmd(pipelineDeps))
@@ -104,6 +108,9 @@ func newPipelineStatusCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var outputFormat string
 	var sinceLastSession bool
 	var since string
+	var watch bool
+	var interval time.Duration
+	var allTenants bool
 
 	cmd := &cobra.Command{
 		Use:   "status",
@@ -116,19 +123,40 @@ func newPipelineStatusCmd(deps *PipelineCommandDeps) *cobra.Command {
 
 Flags:
   --since-last-session: Show stats since the last closed session
-  --since: Show stats since a specific timestamp (e.g., "2h", "yesterday", ISO timestamp)`,
+  --since: Show stats since a specific timestamp (e.g., "2h", "yesterday", ISO timestamp)
+  --watch, -w: Re-render every --interval, with the change in pending sources
+               and recent embeddings since the previous refresh
+  --output csv: Write the recent jobs table as CSV (filtered by --since when set)
+  --all-tenants: Show source and embedding counts for every tenant, with totals
+                 (text, json or yaml output)`,
+		Annotations: map[string]string{ReadOnlyAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allTenants {
+				if watch || sinceLastSession || since != "" || outputFormat == "csv" {
+					return fmt.Errorf("--all-tenants cannot be combined with --watch, --since, --since-last-session or CSV output")
+				}
+				return runPipelineStatusAllTenants(cmd.Context(), deps, outputFormat)
+			}
 			// Validate mutual exclusivity
 			if sinceLastSession && since != "" {
 				return fmt.Errorf("cannot specify both --since-last-session and --since flags")
 			}
-			return runPipelineStatus(cmd.Context(), deps, outputFormat, sinceLastSession, since)
+			if watch && (outputFormat == "json" || outputFormat == "csv") {
+				return fmt.Errorf("--watch mode not supported with %s output", strings.ToUpper(outputFormat))
+			}
+			if watch && interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			return runPipelineStatus(cmd.Context(), deps, outputFormat, sinceLastSession, since, watch, interval)
 		},
 	}
 
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, csv")
 	cmd.Flags().BoolVar(&sinceLastSession, "since-last-session", false, "Show stats since the last closed session")
 	cmd.Flags().StringVar(&since, "since", "", "Show stats since this time (e.g., '2h', 'yesterday', ISO timestamp)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Continuously refresh pipeline status")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Watch refresh interval")
+	AddAllTenantsFlag(cmd, &allTenants)
 	return cmd
 }
 
@@ -136,9 +164,10 @@ func newPipelineJobCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var outputFormat string
 
 	cmd := &cobra.Command{
-		Use:   "job <job-id>",
-		Short: "Show details for a specific ingest job",
-		Args:  cobra.ExactArgs(1),
+		Use:               "job <job-id>",
+		Short:             "Show details for a specific ingest job",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeJobIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPipelineJob(cmd.Context(), deps, args[0], outputFormat)
 		},
@@ -155,13 +184,21 @@ func newPipelineJobsCmd(deps *PipelineCommandDeps) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "jobs",
 		Short: "List recent ingest jobs",
+		Long: `List recent ingest jobs.
+
+Examples:
+  # List the 10 most recent jobs
+  penf pipeline jobs
+
+  # Export jobs to a spreadsheet
+  penf pipeline jobs --limit 100 -o csv > jobs.csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPipelineJobs(cmd.Context(), deps, limit, outputFormat)
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Maximum number of jobs to show")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, csv")
 	return cmd
 }
 
@@ -181,8 +218,8 @@ func filterJobsSince(jobs []*pipelinev1.JobSummary, sinceTime time.Time) []*pipe
 
 // parseTimeFilter parses a time filter string (relative or absolute).
 func parseTimeFilter(filter string) (time.Time, error) {
-	// Try parsing as duration (e.g., "2h", "30m")
-	if duration, err := time.ParseDuration(filter); err == nil {
+	// Try parsing as duration (e.g., "2h", "30m", "7d")
+	if duration, err := parseDuration(filter); err == nil {
 		return time.Now().Add(-duration), nil
 	}
 
@@ -213,12 +250,12 @@ func parseTimeFilter(filter string) (time.Time, error) {
 		return time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location()), nil
 	}
 
-	return time.Time{}, fmt.Errorf("invalid time filter: %s (use duration like '2h', ISO timestamp, or 'yesterday')", filter)
+	return time.Time{}, fmt.Errorf("invalid time filter: %s (use duration like '2h' or '7d', ISO timestamp, or 'yesterday')", filter)
 }
 
 // connectPipelineToGateway creates a gRPC connection to the gateway service.
 func connectPipelineToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -241,9 +278,11 @@ func connectPipelineToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -251,7 +290,7 @@ func connectPipelineToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 
 // Command execution functions
 
-func runPipelineStatus(ctx context.Context, deps *PipelineCommandDeps, outputFormat string, sinceLastSession bool, since string) error {
+func runPipelineStatus(ctx context.Context, deps *PipelineCommandDeps, outputFormat string, sinceLastSession bool, since string, watch bool, interval time.Duration) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -314,6 +353,10 @@ func runPipelineStatus(ctx context.Context, deps *PipelineCommandDeps, outputFor
 
 	client := pipelinev1.NewPipelineServiceClient(conn)
 
+	if watch {
+		return watchPipelineStatus(ctx, client, interval, sinceTime, sinceSource, sessionID, sessionTitle)
+	}
+
 	resp, err := client.GetStats(ctx, &pipelinev1.GetStatsRequest{})
 	if err != nil {
 		return fmt.Errorf("getting pipeline stats: %w", err)
@@ -322,9 +365,123 @@ func runPipelineStatus(ctx context.Context, deps *PipelineCommandDeps, outputFor
 	if outputFormat == "json" {
 		return outputPipelineStatsJSON(resp.Stats, sinceTime, sinceSource, sessionID)
 	}
+	if outputFormat == "csv" {
+		jobs := resp.Stats.GetRecentJobs()
+		if sinceTime != nil {
+			jobs = filterJobsSince(jobs, *sinceTime)
+		}
+		return outputJobSummariesCSV(os.Stdout, jobs)
+	}
 	return outputPipelineStatsHuman(resp.Stats, sinceTime, sinceSource, sessionID, sessionTitle)
 }
 
+// watchPipelineStatus re-renders pipeline status every interval until the context
+// is cancelled, followed by the throughput since the previous refresh.
+func watchPipelineStatus(ctx context.Context, client pipelinev1.PipelineServiceClient, interval time.Duration, sinceTime *time.Time, sinceSource, sessionID, sessionTitle string) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev *pipelinev1.PipelineStats
+	for {
+		resp, err := client.GetStats(ctx, &pipelinev1.GetStatsRequest{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: getting pipeline stats: %v\n", err)
+		} else {
+			fmt.Print("\033[H\033[2J") // Clear screen
+			if err := outputPipelineStatsHuman(resp.Stats, sinceTime, sinceSource, sessionID, sessionTitle); err != nil {
+				return err
+			}
+			outputPipelineStatsDelta(prev, resp.Stats, interval)
+			prev = resp.Stats
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\nStopped watching.")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// outputPipelineStatsDelta prints how pending sources and recent embeddings
+// changed since the previous watch refresh.
+func outputPipelineStatsDelta(prev, cur *pipelinev1.PipelineStats, interval time.Duration) {
+	fmt.Println("Since Last Refresh")
+	fmt.Println("-" + fmt.Sprintf("%49s", "-"))
+	if prev == nil {
+		fmt.Printf("  Waiting for next refresh (every %s)...\n", interval)
+		fmt.Println()
+		return
+	}
+
+	pendingDelta := pendingSourceCount(cur) - pendingSourceCount(prev)
+	pendingColor := "\033[32m" // Green when draining
+	if pendingDelta > 0 {
+		pendingColor = "\033[33m" // Yellow when growing
+	}
+	fmt.Printf("  Pending:          %s%+d\033[0m (now %d)\n", pendingColor, pendingDelta, pendingSourceCount(cur))
+	fmt.Printf("  Embeddings (1h):  %+d (now %d)\n", cur.EmbeddingsRecent-prev.EmbeddingsRecent, cur.EmbeddingsRecent)
+	fmt.Println()
+}
+
+// PipelineETA estimates how long the pending backlog will take to drain.
+type PipelineETA struct {
+	Pending     int64
+	RatePerHour float64
+	ETASeconds  int64
+	Stalled     bool
+}
+
+// estimatePipelineETA derives a drain estimate from the pending source count and
+// the embeddings produced in the last hour. The pipeline is stalled when items are
+// pending but nothing was embedded recently (the same condition the health check
+// reports as an idle worker).
+func estimatePipelineETA(stats *pipelinev1.PipelineStats) PipelineETA {
+	eta := PipelineETA{
+		Pending:     pendingSourceCount(stats),
+		RatePerHour: float64(stats.EmbeddingsRecent),
+	}
+	if eta.Pending == 0 {
+		return eta
+	}
+	if eta.RatePerHour <= 0 {
+		eta.Stalled = true
+		return eta
+	}
+	eta.ETASeconds = int64(float64(eta.Pending) / eta.RatePerHour * 3600)
+	return eta
+}
+
+// formatETA formats a duration as a coarse estimate such as "2h 15m" or "40m".
+func formatETA(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d%(24*time.Hour)) / int(time.Hour)
+	mins := int(d%time.Hour) / int(time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+// pendingSourceCount returns the number of sources in the pending status.
+func pendingSourceCount(stats *pipelinev1.PipelineStats) int64 {
+	for _, sc := range sortedStatusCounts(stats.SourcesByStatus) {
+		if sc.Status == "pending" {
+			return sc.Count
+		}
+	}
+	return 0
+}
+
 func runPipelineJob(ctx context.Context, deps *PipelineCommandDeps, jobID string, outputFormat string) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
@@ -376,9 +533,69 @@ func runPipelineJobs(ctx context.Context, deps *PipelineCommandDeps, limit int,
 		enc.SetIndent("", "  ")
 		return enc.Encode(resp.Jobs)
 	}
+	if outputFormat == "csv" {
+		return outputJobSummariesCSV(os.Stdout, resp.Jobs)
+	}
 	return outputPipelineJobsHuman(resp.Jobs)
 }
 
+// pipelineRollupColumns are the per-tenant counts shown by
+// 'pipeline status --all-tenants'.
+var pipelineRollupColumns = []string{"sources", "pending", "processing", "completed", "failed", "embeddings"}
+
+// runPipelineStatusAllTenants shows pipeline counts for every tenant.
+func runPipelineStatusAllTenants(ctx context.Context, deps *PipelineCommandDeps, outputFormat string) error {
+	format := config.OutputFormat(outputFormat)
+	if !format.IsValid() {
+		return fmt.Errorf("invalid output format: %s", outputFormat)
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	tenants, err := ListAllTenants(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connectPipelineToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pipelinev1.NewPipelineServiceClient(conn)
+	rollup := FanOutTenants(ctx, tenants, pipelineRollupColumns, func(ctx context.Context, tenantID string) (map[string]int64, error) {
+		rpcCtx, rpcCancel := context.WithTimeout(ctx, 30*time.Second)
+		defer rpcCancel()
+		resp, err := client.GetStats(rpcCtx, &pipelinev1.GetStatsRequest{TenantId: tenantID})
+		if err != nil {
+			return nil, err
+		}
+		return pipelineRollupValues(resp.GetStats()), nil
+	})
+
+	return OutputTenantRollup(os.Stdout, format, rollup)
+}
+
+// pipelineRollupValues extracts the rollup columns from pipeline stats.
+func pipelineRollupValues(stats *pipelinev1.PipelineStats) map[string]int64 {
+	values := map[string]int64{
+		"sources":    stats.GetSourcesTotal(),
+		"embeddings": stats.GetEmbeddingsTotal(),
+	}
+	for _, sc := range stats.GetSourcesByStatus() {
+		switch sc.GetStatus() {
+		case "pending", "processing", "completed", "failed":
+			values[sc.GetStatus()] += sc.GetCount()
+		}
+	}
+	return values
+}
+
 // Output functions
 
 func outputPipelineStatsJSON(stats *pipelinev1.PipelineStats, sinceTime *time.Time, sinceSource, sessionID string) error {
@@ -386,6 +603,15 @@ func outputPipelineStatsJSON(stats *pipelinev1.PipelineStats, sinceTime *time.Ti
 		"stats": stats,
 	}
 
+	eta := estimatePipelineETA(stats)
+	output["rate_per_hour"] = eta.RatePerHour
+	output["stalled"] = eta.Stalled
+	if eta.Stalled {
+		output["eta_seconds"] = nil
+	} else {
+		output["eta_seconds"] = eta.ETASeconds
+	}
+
 	if sinceTime != nil {
 		output["since"] = sinceTime.Format(time.RFC3339)
 		output["since_source"] = sinceSource
@@ -424,7 +650,7 @@ func outputPipelineStatsHuman(stats *pipelinev1.PipelineStats, sinceTime *time.T
 	fmt.Printf("  Total: %d\n", stats.SourcesTotal)
 	if len(stats.SourcesByStatus) > 0 {
 		fmt.Println("  By Status:")
-		for _, sc := range stats.SourcesByStatus {
+		for _, sc := range sortedStatusCounts(stats.SourcesByStatus) {
 			color := "\033[33m" // Yellow for pending
 			if sc.Status == "completed" {
 				color = "\033[32m" // Green
@@ -447,6 +673,12 @@ func outputPipelineStatsHuman(stats *pipelinev1.PipelineStats, sinceTime *time.T
 	fmt.Println("-" + fmt.Sprintf("%49s", "-"))
 	fmt.Printf("  Total: %d\n", stats.EmbeddingsTotal)
 	fmt.Printf("  Last Hour: %d\n", stats.EmbeddingsRecent)
+	eta := estimatePipelineETA(stats)
+	if eta.Stalled {
+		fmt.Printf("  ETA: \033[31mstalled\033[0m (%d pending but no embeddings in last hour)\n", eta.Pending)
+	} else if eta.Pending > 0 {
+		fmt.Printf("  ETA: ~%s at current rate (%.0f/hour)\n", formatETA(time.Duration(eta.ETASeconds)*time.Second), eta.RatePerHour)
+	}
 
 	// Calculate coverage
 	if stats.SourcesTotal > 0 {
@@ -479,7 +711,7 @@ func outputPipelineStatsHuman(stats *pipelinev1.PipelineStats, sinceTime *time.T
 	fmt.Printf("  Total: %d\n", stats.JobsTotal)
 	if len(stats.JobsByStatus) > 0 {
 		fmt.Println("  By Status:")
-		for _, sc := range stats.JobsByStatus {
+		for _, sc := range sortedStatusCounts(stats.JobsByStatus) {
 			fmt.Printf("    %-16s %d\n", sc.Status, sc.Count)
 		}
 	}
@@ -573,7 +805,7 @@ func outputPipelineJobHuman(job *pipelinev1.JobDetails, sources *pipelinev1.Sour
 		fmt.Println("Source Processing")
 		fmt.Println("-" + fmt.Sprintf("%49s", "-"))
 		fmt.Printf("  Total Sources: %d\n", sources.Total)
-		for _, sc := range sources.ByStatus {
+		for _, sc := range sortedStatusCounts(sources.ByStatus) {
 			color := "\033[33m"
 			if sc.Status == "completed" {
 				color = "\033[32m"
@@ -629,6 +861,9 @@ func newPipelineReprocessCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var timeout int32
 	var model string
 	var promptVersion int32
+	var concurrency int
+	var checkpointPath string
+	var resumeFrom string
 
 	cmd := &cobra.Command{
 		Use:   "reprocess <content-id>",
@@ -649,8 +884,22 @@ Stages that can be reprocessed:
 Processing Overrides:
   --timeout         Override timeout for this reprocessing run (seconds)
   --model           Override model ID for this reprocessing run
+                    (default: the model set with 'penf model set-default', if any)
   --prompt-version  Override prompt version for this reprocessing run
 
+Bulk Reprocessing:
+  --all             Reprocess every content item (optionally filtered by --source-tag).
+                    Shows the number of affected items first and asks for confirmation;
+                    pass --confirm to skip the prompt (required when not on a terminal).
+                    With -o json and no --confirm, the impact is written with
+                    "needs_confirm": true and nothing is reprocessed
+  --concurrency     Number of reprocess requests in flight at once (default 4, max 32)
+  --checkpoint      File that records each successfully reprocessed content ID
+                    (default ~/.penf/reprocess-checkpoint.txt; truncated on a fresh run)
+  --resume-from     Skip content IDs already recorded in a checkpoint file and keep
+                    appending to it; with a different --checkpoint, that file is
+                    started with the resumed IDs
+
 Examples:
   # Reprocess all stages for a content item
   penf pipeline reprocess content-123
@@ -667,6 +916,12 @@ Examples:
   # Reprocess with a specific prompt version
   penf pipeline reprocess content-123 --prompt-version 2
 
+  # Reprocess everything from a source tag with 8 workers
+  penf pipeline reprocess --all --source-tag gmail-import --concurrency 8 --confirm
+
+  # Resume an interrupted bulk reprocess
+  penf pipeline reprocess --all --source-tag gmail-import --resume-from ~/.penf/reprocess-checkpoint.txt --confirm
+
   # Dry-run to see impact
   penf pipeline reprocess --stage triage --dry-run
 
@@ -678,30 +933,39 @@ Examples:
 			if len(args) > 0 {
 				contentID = args[0]
 			}
-			return runPipelineReprocess(cmd.Context(), deps, contentID, stage, reason, outputFormat, dryRun, all, sourceTag, timeout, model, promptVersion)
+			if (checkpointPath != "" || resumeFrom != "") && !all {
+				return fmt.Errorf("--checkpoint and --resume-from require --all")
+			}
+			return runPipelineReprocess(cmd.Context(), deps, contentID, stage, reason, outputFormat, dryRun, all, sourceTag, timeout, model, promptVersion, concurrency, checkpointPath, resumeFrom, confirm)
 		},
 	}
 
 	cmd.Flags().StringVar(&stage, "stage", "", "Specific stage to reprocess: embeddings, entities, keywords, summary")
-	cmd.Flags().BoolVar(&confirm, "confirm", false, "Required for bulk operations (future: --source, --all flags)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Proceed with --all without prompting")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
 	cmd.Flags().StringVar(&reason, "reason", "Manual reprocess via CLI", "Reason for reprocessing (for audit trail)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Calculate impact without executing")
 	cmd.Flags().BoolVar(&all, "all", false, "Reprocess all sources (for bulk operations)")
 	cmd.Flags().StringVar(&sourceTag, "source-tag", "", "Filter by source tag")
 	cmd.Flags().Int32Var(&timeout, "timeout", 0, "Timeout override in seconds (0 = use default)")
-	cmd.Flags().StringVar(&model, "model", "", "Model ID override (empty = use default)")
+	cmd.Flags().StringVar(&model, "model", "", "Model ID override (default: default_model from config, see 'penf model set-default')")
 	cmd.Flags().Int32Var(&promptVersion, "prompt-version", 0, "Prompt version override (0 = use active)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultReprocessConcurrency, fmt.Sprintf("Concurrent workers for --all (max %d)", maxReprocessConcurrency))
+	cmd.Flags().StringVar(&checkpointPath, "checkpoint", "", "Checkpoint file for --all (default ~/.penf/reprocess-checkpoint.txt)")
+	cmd.Flags().StringVar(&resumeFrom, "resume-from", "", "Resume --all from a checkpoint file, skipping recorded content IDs")
 
 	return cmd
 }
 
-func runPipelineReprocess(ctx context.Context, deps *PipelineCommandDeps, contentID string, stage string, reason string, outputFormat string, dryRun bool, all bool, sourceTag string, timeout int32, model string, promptVersion int32) error {
+func runPipelineReprocess(ctx context.Context, deps *PipelineCommandDeps, contentID string, stage string, reason string, outputFormat string, dryRun bool, all bool, sourceTag string, timeout int32, model string, promptVersion int32, concurrency int, checkpointPath string, resumeFrom string, confirm bool) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	if model == "" {
+		model = cfg.DefaultModel
+	}
 
 	conn, err := connectPipelineToGateway(cfg)
 	if err != nil {
@@ -717,17 +981,18 @@ func runPipelineReprocess(ctx context.Context, deps *PipelineCommandDeps, conten
 
 		// Show overrides that would be applied
 		if model != "" || timeout > 0 || promptVersion > 0 {
-			fmt.Println("Overrides:")
+			progress := progressOut(config.OutputFormat(outputFormat))
+			fmt.Fprintln(progress, "Overrides:")
 			if model != "" {
-				fmt.Printf("  Model: %s\n", model)
+				fmt.Fprintf(progress, "  Model: %s\n", model)
 			}
 			if promptVersion > 0 {
-				fmt.Printf("  Prompt Version: v%d\n", promptVersion)
+				fmt.Fprintf(progress, "  Prompt Version: v%d\n", promptVersion)
 			}
 			if timeout > 0 {
-				fmt.Printf("  Timeout: %d seconds\n", timeout)
+				fmt.Fprintf(progress, "  Timeout: %d seconds\n", timeout)
 			}
-			fmt.Println()
+			fmt.Fprintln(progress)
 		}
 
 		pipelineClient := pipelinev1.NewPipelineServiceClient(conn)
@@ -757,7 +1022,7 @@ func runPipelineReprocess(ctx context.Context, deps *PipelineCommandDeps, conten
 			return fmt.Errorf("cannot specify both content-id and --all flag")
 		}
 
-		return runBulkReprocess(ctx, conn, cfg, sourceTag, stage, reason, outputFormat, timeout, model)
+		return runBulkReprocess(ctx, conn, cfg, sourceTag, stage, reason, outputFormat, timeout, model, concurrency, checkpointPath, resumeFrom, confirm)
 	}
 
 	// Otherwise, call ReprocessContent for single item
@@ -811,7 +1076,7 @@ func runPipelineReprocess(ctx context.Context, deps *PipelineCommandDeps, conten
 }
 
 // runBulkReprocess reprocesses all content items matching filters.
-func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CLIConfig, sourceTag string, stage string, reason string, outputFormat string, timeout int32, model string) error {
+func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CLIConfig, sourceTag string, stage string, reason string, outputFormat string, timeout int32, model string, concurrency int, checkpointPath string, resumeFrom string, confirm bool) error {
 	contentClient := contentv1.NewContentProcessorServiceClient(conn)
 
 	// Use tenant ID from config
@@ -831,11 +1096,13 @@ func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CL
 		listReq.SourceTag = &sourceTag
 	}
 
-	fmt.Printf("Querying content items for tenant %s", tenantID)
+	progress := progressOut(config.OutputFormat(outputFormat))
+
+	fmt.Fprintf(progress, "Querying content items for tenant %s", tenantID)
 	if sourceTag != "" {
-		fmt.Printf(" (source_tag: %s)", sourceTag)
+		fmt.Fprintf(progress, " (source_tag: %s)", sourceTag)
 	}
-	fmt.Println("...")
+	fmt.Fprintln(progress, "...")
 
 	// Get all content items
 	var allContentIDs []string
@@ -858,11 +1125,79 @@ func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CL
 	}
 
 	if len(allContentIDs) == 0 {
+		if outputFormat == "json" {
+			return outputBulkReprocessJSON(0, 0, 0, nil)
+		}
 		fmt.Println("No content items found to reprocess.")
 		return nil
 	}
 
-	fmt.Printf("Found %d content items to reprocess.\n", len(allContentIDs))
+	fmt.Fprintf(progress, "Found %d content items to reprocess.\n", len(allContentIDs))
+
+	// Resolve the checkpoint file. Resuming appends to the checkpoint being
+	// resumed; if --checkpoint points somewhere else, that file starts with
+	// the resumed checkpoint's IDs.
+	if checkpointPath == "" {
+		checkpointPath = resumeFrom
+	}
+	if checkpointPath == "" {
+		path, err := defaultReprocessCheckpointPath()
+		if err != nil {
+			return fmt.Errorf("resolving checkpoint path: %w", err)
+		}
+		checkpointPath = path
+	}
+
+	if resumeFrom != "" {
+		done, err := loadReprocessCheckpoint(resumeFrom)
+		if err != nil {
+			return err
+		}
+		remaining := filterCheckpointed(allContentIDs, done)
+		fmt.Fprintf(progress, "Resuming from %s: skipping %d already reprocessed, %d remaining.\n", resumeFrom, len(allContentIDs)-len(remaining), len(remaining))
+		allContentIDs = remaining
+		if len(allContentIDs) == 0 {
+			if outputFormat == "json" {
+				return outputBulkReprocessJSON(0, 0, 0, nil)
+			}
+			fmt.Println("Nothing left to reprocess.")
+			return nil
+		}
+	}
+
+	if err := guardDestructiveTenant(cfg, tenantID); err != nil {
+		return err
+	}
+
+	// Show the impact and confirm before touching anything, including the checkpoint.
+	// Without --confirm the impact is what the user acts on, so it is shown even
+	// when quiet; in JSON mode it is the output, marked as needing confirmation.
+	impact := estimateBulkReprocessImpact(ctx, pipelinev1.NewPipelineServiceClient(conn), allContentIDs, sourceTag, stage)
+	if !confirm && outputFormat == "json" {
+		return outputBulkReprocessNeedsConfirmJSON(impact)
+	}
+	impactOut := progress
+	if !confirm {
+		impactOut = os.Stdout
+	}
+	printBulkReprocessImpact(impactOut, impact)
+	if !confirm {
+		if !isInteractiveStdin() {
+			fmt.Fprintln(impactOut, "Re-run with --confirm to proceed.")
+			return nil
+		}
+		if !promptBulkReprocessConfirm(os.Stdin, len(allContentIDs)) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	checkpoint, err := openReprocessCheckpoint(checkpointPath, resumeFrom)
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Close()
+	fmt.Fprintf(progress, "Recording progress in %s\n", checkpointPath)
 
 	// Build stage filter if provided
 	var stagesToReprocess []contentv1.ProcessingStage
@@ -874,16 +1209,18 @@ func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CL
 		stagesToReprocess = []contentv1.ProcessingStage{stageEnum}
 	}
 
-	// Reprocess each content item with backpressure handling (pf-f69608).
-	// The gateway returns ResourceExhausted when the pipeline concurrency limit
-	// is reached. We retry with exponential backoff instead of failing immediately.
-	const maxRetries = 6 // up to ~63s total backoff per item
+	concurrency = clampReprocessConcurrency(concurrency)
+	if concurrency > 1 {
+		fmt.Fprintf(progress, "Reprocessing with %d concurrent workers.\n", concurrency)
+	}
 
-	successCount := 0
-	failCount := 0
-	var jobIDs []string
+	recordCheckpoint := func(contentID string) {
+		if err := checkpoint.Record(contentID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: writing checkpoint for %s: %v\n", contentID, err)
+		}
+	}
 
-	for i, contentID := range allContentIDs {
+	result := reprocessContentPool(ctx, contentClient, allContentIDs, concurrency, progress, recordCheckpoint, func(contentID string) *contentv1.ReprocessContentRequest {
 		req := &contentv1.ReprocessContentRequest{
 			ContentId:         contentID,
 			Reason:            reason,
@@ -900,62 +1237,21 @@ func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CL
 				req.Options.TimeoutSeconds = &timeout
 			}
 		}
+		return req
+	})
+	successCount, failCount, jobIDs := result.Succeeded, result.Failed, result.JobIDs
 
-		// Retry loop with exponential backoff for ResourceExhausted.
-		var resp *contentv1.ReprocessContentResponse
-		var err error
-		for attempt := 0; attempt <= maxRetries; attempt++ {
-			resp, err = contentClient.ReprocessContent(ctx, req)
-			if err == nil {
-				break
-			}
-			st, _ := status.FromError(err)
-			if st.Code() != codes.ResourceExhausted {
-				break // non-retryable error
-			}
-			if attempt == maxRetries {
-				break // exhausted retries
-			}
-			backoff := time.Duration(1<<uint(attempt+1)) * time.Second
-			fmt.Printf("  Concurrency limit reached for %s, waiting %v (retry %d/%d)...\n",
-				contentID, backoff, attempt+1, maxRetries)
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
-
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to reprocess %s: %v\n", contentID, err)
-			failCount++
-			continue
-		}
-
-		successCount++
-		if resp.JobId != "" {
-			jobIDs = append(jobIDs, resp.JobId)
-		}
-
-		// Show progress every 10 items
-		if (i+1)%10 == 0 || i == len(allContentIDs)-1 {
-			fmt.Printf("Progress: %d/%d reprocessed (%d succeeded, %d failed)\n", i+1, len(allContentIDs), successCount, failCount)
-		}
+	if ctx.Err() != nil {
+		fmt.Fprintf(os.Stderr, "\nBulk reprocess interrupted after %d/%d items: %d succeeded, %d failed\n", result.Done, len(allContentIDs), successCount, failCount)
+		fmt.Fprintf(os.Stderr, "Resume with: --resume-from %s\n", checkpointPath)
+		return ctx.Err()
 	}
 
-	fmt.Println()
-	fmt.Printf("Bulk reprocess complete: %d succeeded, %d failed\n", successCount, failCount)
+	fmt.Fprintln(progress)
+	fmt.Fprintf(progress, "Bulk reprocess complete: %d succeeded, %d failed\n", successCount, failCount)
 
 	if outputFormat == "json" {
-		result := map[string]interface{}{
-			"total_count":   len(allContentIDs),
-			"success_count": successCount,
-			"fail_count":    failCount,
-			"job_ids":       jobIDs,
-		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(result)
+		return outputBulkReprocessJSON(len(allContentIDs), successCount, failCount, jobIDs)
 	}
 
 	if len(jobIDs) > 0 {
@@ -968,6 +1264,226 @@ func runBulkReprocess(ctx context.Context, conn *grpc.ClientConn, cfg *config.CL
 	return nil
 }
 
+// outputBulkReprocessJSON writes the bulk reprocess summary as JSON.
+func outputBulkReprocessJSON(total, succeeded, failed int, jobIDs []string) error {
+	if jobIDs == nil {
+		jobIDs = []string{}
+	}
+	result := map[string]interface{}{
+		"needs_confirm": false,
+		"total_count":   total,
+		"success_count": succeeded,
+		"fail_count":    failed,
+		"job_ids":       jobIDs,
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// outputBulkReprocessNeedsConfirmJSON writes the impact of a bulk reprocess
+// that was not run because --confirm was not given. Nothing was reprocessed,
+// so the counts are zero; needs_confirm tells scripts apart from a run that
+// found nothing to do.
+func outputBulkReprocessNeedsConfirmJSON(impact BulkReprocessImpact) error {
+	result := map[string]interface{}{
+		"needs_confirm": true,
+		"total_count":   0,
+		"success_count": 0,
+		"fail_count":    0,
+		"job_ids":       []string{},
+		"impact":        impact,
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// Bulk reprocess worker pool limits.
+const (
+	defaultReprocessConcurrency = 4
+	maxReprocessConcurrency     = 32
+)
+
+// clampReprocessConcurrency bounds the bulk reprocess worker count to [1, maxReprocessConcurrency].
+func clampReprocessConcurrency(n int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > maxReprocessConcurrency {
+		return maxReprocessConcurrency
+	}
+	return n
+}
+
+// bulkReprocessResult aggregates the outcome of a bulk reprocess run.
+type bulkReprocessResult struct {
+	Done      int
+	Succeeded int
+	Failed    int
+	JobIDs    []string
+}
+
+// BulkReprocessImpact is what a bulk reprocess would touch.
+type BulkReprocessImpact struct {
+	ContentCount     int      `json:"content_count"`
+	SourceTag        string   `json:"source_tag,omitempty"`
+	Stage            string   `json:"stage,omitempty"`
+	AffectedStages   []string `json:"affected_stages,omitempty"`
+	EstimatedSeconds int64    `json:"estimated_seconds,omitempty"`
+	FirstItems       []string `json:"first_items"`
+}
+
+// estimateBulkReprocessImpact summarises what a bulk reprocess would touch. When
+// a stage is given, ReprocessDryRun adds the downstream stages and a time estimate.
+func estimateBulkReprocessImpact(ctx context.Context, client pipelinev1.PipelineServiceClient, contentIDs []string, sourceTag string, stage string) BulkReprocessImpact {
+	impact := BulkReprocessImpact{
+		ContentCount: len(contentIDs),
+		SourceTag:    sourceTag,
+		Stage:        stage,
+		FirstItems:   contentIDs[:min(5, len(contentIDs))],
+	}
+	if stage != "" {
+		resp, err := client.ReprocessDryRun(ctx, &pipelinev1.ReprocessDryRunRequest{
+			Stage:     stage,
+			SourceTag: sourceTag,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: dry-run estimate unavailable: %v\n", err)
+		} else {
+			impact.AffectedStages = resp.AffectedStages
+			impact.EstimatedSeconds = resp.EstimatedDurationSeconds
+		}
+	}
+	return impact
+}
+
+// printBulkReprocessImpact prints the impact of a bulk reprocess.
+func printBulkReprocessImpact(w io.Writer, impact BulkReprocessImpact) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Bulk reprocess impact:")
+	fmt.Fprintf(w, "  Content items: %d\n", impact.ContentCount)
+	if impact.SourceTag != "" {
+		fmt.Fprintf(w, "  Source tag:    %s\n", impact.SourceTag)
+	} else {
+		fmt.Fprintln(w, "  Source tag:    (none - entire tenant)")
+	}
+	if impact.Stage != "" {
+		fmt.Fprintf(w, "  Stage:         %s\n", impact.Stage)
+	} else {
+		fmt.Fprintln(w, "  Stage:         all stages")
+	}
+	if len(impact.AffectedStages) > 0 {
+		fmt.Fprintf(w, "  Re-runs:       %s\n", strings.Join(impact.AffectedStages, ", "))
+	}
+	if impact.EstimatedSeconds > 0 {
+		fmt.Fprintf(w, "  Estimated:     %s\n", formatETA(time.Duration(impact.EstimatedSeconds)*time.Second))
+	}
+
+	fmt.Fprintf(w, "  First items:   %s", strings.Join(impact.FirstItems, ", "))
+	if impact.ContentCount > len(impact.FirstItems) {
+		fmt.Fprintf(w, " (+%d more)", impact.ContentCount-len(impact.FirstItems))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+}
+
+// promptBulkReprocessConfirm asks the user to confirm a bulk reprocess.
+func promptBulkReprocessConfirm(in io.Reader, count int) bool {
+	fmt.Printf("Reprocess %d content items? [y/N] ", count)
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	line = strings.TrimSpace(strings.ToLower(line))
+	return line == "y" || line == "yes"
+}
+
+// isInteractiveStdin reports whether stdin is a terminal that can answer a prompt.
+func isInteractiveStdin() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// reprocessContentPool reprocesses content items through a bounded worker pool.
+// A semaphore channel limits in-flight ReprocessContent calls; counts and job IDs
+// are guarded by a mutex so workers can record results as they finish. Dispatch
+// stops when ctx is cancelled and in-flight workers are waited for. onSuccess, if
+// non-nil, is called from the worker for each item that reprocessed successfully.
+func reprocessContentPool(ctx context.Context, client contentv1.ContentProcessorServiceClient, contentIDs []string, concurrency int, progress io.Writer, onSuccess func(contentID string), newRequest func(contentID string) *contentv1.ReprocessContentRequest) bulkReprocessResult {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result bulkReprocessResult
+	)
+	sem := make(chan struct{}, clampReprocessConcurrency(concurrency))
+	bar := newProgressBar(progress, len(contentIDs), "reprocessed")
+	defer bar.Finish()
+
+dispatch:
+	for _, contentID := range contentIDs {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(contentID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := reprocessContentWithBackoff(ctx, client, newRequest(contentID))
+			if err == nil && onSuccess != nil {
+				onSuccess(contentID)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Done++
+			if err != nil {
+				bar.Printf(os.Stderr, "Failed to reprocess %s: %v\n", contentID, err)
+				result.Failed++
+			} else {
+				result.Succeeded++
+				if resp.JobId != "" {
+					result.JobIDs = append(result.JobIDs, resp.JobId)
+				}
+			}
+			bar.Set(result.Done, fmt.Sprintf("(%d succeeded, %d failed)", result.Succeeded, result.Failed))
+		}(contentID)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// reprocessContentWithBackoff calls ReprocessContent, retrying with exponential
+// backoff when the gateway reports ResourceExhausted (pf-f69608). The gateway
+// returns it when the pipeline concurrency limit is reached.
+func reprocessContentWithBackoff(ctx context.Context, client contentv1.ContentProcessorServiceClient, req *contentv1.ReprocessContentRequest) (*contentv1.ReprocessContentResponse, error) {
+	const maxRetries = 6 // up to ~63s total backoff per item
+
+	for attempt := 0; ; attempt++ {
+		resp, err := client.ReprocessContent(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		st, _ := status.FromError(err)
+		if st.Code() != codes.ResourceExhausted || attempt == maxRetries {
+			return nil, err
+		}
+		backoff := time.Duration(1<<uint(attempt+1)) * time.Second
+		fmt.Fprintf(os.Stderr, "  Concurrency limit reached for %s, waiting %v (retry %d/%d)...\n",
+			req.ContentId, backoff, attempt+1, maxRetries)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
 // min returns the minimum of two integers.
 func min(a, b int) int {
 	if a < b {
@@ -1027,6 +1543,8 @@ func newPipelineKickCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var limit int
 	var source string
 	var outputFormat string
+	var dryRun bool
+	var list bool
 
 	cmd := &cobra.Command{
 		Use:   "kick",
@@ -1036,6 +1554,9 @@ func newPipelineKickCmd(deps *PipelineCommandDeps) *cobra.Command {
 This command starts processing for items that are in pending state,
 useful for manually triggering a processing batch.
 
+Use --dry-run to see how many items would be queued without queuing
+anything, and --list to also show which sources they are.
+
 Examples:
   # Kick all pending items
   penf pipeline kick
@@ -1047,8 +1568,17 @@ Examples:
   penf pipeline kick --source=gmail-import-2024
 
   # Kick for specific tenant
-  penf pipeline kick --tenant=tenant-123`,
+  penf pipeline kick --tenant=tenant-123
+
+  # Preview what a kick would queue, listing the sources
+  penf pipeline kick --source=gmail-import-2024 --dry-run --list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if list && !dryRun {
+				return fmt.Errorf("--list requires --dry-run")
+			}
+			if dryRun {
+				return runPipelineKickDryRun(cmd.Context(), deps, tenant, limit, source, list, outputFormat)
+			}
 			return runPipelineKick(cmd.Context(), deps, tenant, limit, source, outputFormat)
 		},
 	}
@@ -1057,8 +1587,150 @@ Examples:
 	cmd.Flags().IntVarP(&limit, "limit", "l", 0, "Maximum number of items to queue (0 = no limit)")
 	cmd.Flags().StringVar(&source, "source", "", "Filter by source tag")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show how many items would be queued without queuing them")
+	cmd.Flags().BoolVar(&list, "list", false, "With --dry-run, list the sources that would be queued")
 
-	return cmd
+	return withEnvelope(cmd)
+}
+
+// kickDryRunListMax caps the sources listed by 'pipeline kick --dry-run --list'.
+const kickDryRunListMax = 100
+
+// KickDryRunSource is a pending source a kick would queue.
+type KickDryRunSource struct {
+	ID          int64     `json:"id"`
+	ContentType string    `json:"content_type"`
+	SourceTag   string    `json:"source_tag"`
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// KickResult is the output of 'pipeline kick'.
+type KickResult struct {
+	TenantID      string `json:"tenant_id,omitempty"`
+	SourceTag     string `json:"source_tag,omitempty"`
+	Limit         int    `json:"limit,omitempty"`
+	QueuedCount   int64  `json:"queued_count"`
+	InFlightCount int32  `json:"in_flight_count"`
+	PendingCount  int64  `json:"pending_count"`
+	MaxConcurrent int32  `json:"max_concurrent,omitempty"`
+}
+
+// KickDryRunResult is the output of 'pipeline kick --dry-run'.
+type KickDryRunResult struct {
+	TenantID     string             `json:"tenant_id,omitempty"`
+	SourceTag    string             `json:"source_tag,omitempty"`
+	Limit        int                `json:"limit,omitempty"`
+	PendingCount int64              `json:"pending_count"`
+	WouldQueue   int64              `json:"would_queue"`
+	Sources      []KickDryRunSource `json:"sources,omitempty"`
+}
+
+// runPipelineKickDryRun reports what 'pipeline kick' would queue with the
+// same filters, without queuing anything.
+func runPipelineKickDryRun(ctx context.Context, deps *PipelineCommandDeps, tenant string, limit int, source string, list bool, outputFormat string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectPipelineToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pipelinev1.NewPipelineServiceClient(conn)
+
+	// ListPendingSources has no tenant field; the tenant travels as metadata.
+	reqCtx := AddTenantMetadata(ctx, tenant)
+
+	req := &pipelinev1.ListPendingSourcesRequest{Limit: 1, SourceTag: source}
+	if list {
+		req.Limit = kickDryRunListMax
+		if limit > 0 && limit < kickDryRunListMax {
+			req.Limit = int32(limit)
+		}
+	}
+	resp, err := client.ListPendingSources(reqCtx, req)
+	if err != nil {
+		return fmt.Errorf("listing pending sources: %w", err)
+	}
+
+	result := KickDryRunResult{
+		TenantID:     tenant,
+		SourceTag:    source,
+		Limit:        limit,
+		PendingCount: resp.TotalCount,
+		WouldQueue:   kickWouldQueue(resp.TotalCount, limit),
+	}
+	if list {
+		for _, src := range resp.Sources {
+			s := KickDryRunSource{
+				ID:          src.Id,
+				ContentType: src.ContentType,
+				SourceTag:   src.SourceTag,
+				SizeBytes:   src.SizeBytes,
+			}
+			if src.CreatedAt != nil {
+				s.CreatedAt = src.CreatedAt.AsTime()
+			}
+			result.Sources = append(result.Sources, s)
+		}
+	}
+
+	if outputFormat == "json" {
+		return writeOutcomeJSON(true, result, "dry run: nothing was queued")
+	}
+	outputKickDryRunHuman(result)
+	return nil
+}
+
+// kickWouldQueue is the number of pending items a kick with limit would
+// queue; a limit of 0 means no limit.
+func kickWouldQueue(pending int64, limit int) int64 {
+	if limit > 0 && int64(limit) < pending {
+		return int64(limit)
+	}
+	return pending
+}
+
+func outputKickDryRunHuman(result KickDryRunResult) {
+	fmt.Printf("Would queue %d items", result.WouldQueue)
+	if result.WouldQueue < result.PendingCount {
+		fmt.Printf(" (%d pending, --limit %d)", result.PendingCount, result.Limit)
+	}
+	fmt.Println()
+
+	var filters []string
+	if result.TenantID != "" {
+		filters = append(filters, "tenant "+result.TenantID)
+	}
+	if result.SourceTag != "" {
+		filters = append(filters, "source "+result.SourceTag)
+	}
+	if len(filters) > 0 {
+		fmt.Printf("Filters: %s\n", strings.Join(filters, ", "))
+	}
+
+	if len(result.Sources) > 0 {
+		fmt.Println()
+		fmt.Printf("%-10s %-12s %-30s %-10s %s\n", "ID", "TYPE", "SOURCE", "SIZE", "CREATED")
+		for _, src := range result.Sources {
+			created := "-"
+			if !src.CreatedAt.IsZero() {
+				created = src.CreatedAt.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%-10d %-12s %-30s %-10s %s\n", src.ID, src.ContentType, truncateString(src.SourceTag, 30), formatBytes(src.SizeBytes), created)
+		}
+		if shown := int64(len(result.Sources)); shown < result.WouldQueue {
+			fmt.Printf("... and %d more\n", result.WouldQueue-shown)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Dry run: nothing was queued. Run without --dry-run to queue.")
 }
 
 func runPipelineKick(ctx context.Context, deps *PipelineCommandDeps, tenant string, limit int, source string, outputFormat string) error {
@@ -1088,9 +1760,15 @@ func runPipelineKick(ctx context.Context, deps *PipelineCommandDeps, tenant stri
 	}
 
 	if outputFormat == "json" {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(resp)
+		return writeOutcomeJSON(true, KickResult{
+			TenantID:      tenant,
+			SourceTag:     source,
+			Limit:         limit,
+			QueuedCount:   resp.QueuedCount,
+			InFlightCount: resp.InFlightCount,
+			PendingCount:  resp.PendingCount,
+			MaxConcurrent: resp.MaxConcurrent,
+		}, resp.Message)
 	}
 
 	fmt.Printf("Queued %d items for processing\n", resp.QueuedCount)
@@ -1104,6 +1782,8 @@ func newPipelineRetryCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var stage string
 	var tenant string
 	var outputFormat string
+	var maxLocalRetries int
+	var olderThan string
 
 	cmd := &cobra.Command{
 		Use:   "retry [job-id]",
@@ -1113,6 +1793,22 @@ func newPipelineRetryCmd(deps *PipelineCommandDeps) *cobra.Command {
 If a job ID is provided, retries only that specific job.
 Otherwise, retries all failed items matching the filters.
 
+Guards against retry storms:
+  --max-local-retries N  Only retry jobs that have been retried fewer than N
+                         times from this machine. Retries are recorded in
+                         ~/.penf/retry-ledger.json when penf issues them here;
+                         retries from other hosts or tools are not counted,
+                         because the gateway does not report how many times
+                         a job has been retried. --max-attempts is a
+                         deprecated alias.
+  --older-than D         Only retry jobs that failed at least D ago (e.g., 30m, 2h, 1d),
+                         giving transient issues time to settle.
+
+With either guard set, jobs with failed items (failed jobs, and completed
+jobs with a failed count) of the tenant are retried one at a time. Up to
+2000 jobs per status are examined; the summary reports how many were
+retried, how many were skipped, and how many lay beyond that cap.
+
 Examples:
   # Retry all failed items
   penf pipeline retry
@@ -1124,25 +1820,46 @@ Examples:
   penf pipeline retry --stage=embedding
 
   # Retry for specific tenant
-  penf pipeline retry --tenant=tenant-123`,
+  penf pipeline retry --tenant=tenant-123
+
+  # Retry jobs retried from here under 3 times that failed at least an hour ago
+  penf pipeline retry --max-local-retries 3 --older-than 1h`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jobID := ""
 			if len(args) > 0 {
 				jobID = args[0]
 			}
-			return runPipelineRetry(cmd.Context(), deps, jobID, stage, tenant, outputFormat)
+			if maxLocalRetries < 0 {
+				return fmt.Errorf("--max-local-retries must not be negative")
+			}
+			guards := retryGuards{MaxLocalRetries: maxLocalRetries}
+			if olderThan != "" {
+				if jobID != "" {
+					return fmt.Errorf("--older-than cannot be used with a job ID")
+				}
+				d, err := parseDuration(olderThan)
+				if err != nil || d <= 0 {
+					return fmt.Errorf("invalid --older-than value %q (e.g., 30m, 2h, 1d)", olderThan)
+				}
+				guards.OlderThan = d
+			}
+			return runPipelineRetry(cmd.Context(), deps, jobID, stage, tenant, guards, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVar(&stage, "stage", "", "Filter by pipeline stage (embedding, attachment)")
 	cmd.Flags().StringVar(&tenant, "tenant", "", "Filter by tenant ID")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().IntVar(&maxLocalRetries, "max-local-retries", 0, "Only retry jobs retried fewer than N times from this machine (0 = no cap)")
+	cmd.Flags().IntVar(&maxLocalRetries, "max-attempts", 0, "Deprecated alias of --max-local-retries")
+	_ = cmd.Flags().MarkDeprecated("max-attempts", "use --max-local-retries; only retries issued from this machine are counted")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only retry jobs that failed at least this long ago (e.g., 30m, 2h, 1d)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
-func runPipelineRetry(ctx context.Context, deps *PipelineCommandDeps, jobID string, stage string, tenant string, outputFormat string) error {
+func runPipelineRetry(ctx context.Context, deps *PipelineCommandDeps, jobID string, stage string, tenant string, guards retryGuards, outputFormat string) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -1157,6 +1874,10 @@ func runPipelineRetry(ctx context.Context, deps *PipelineCommandDeps, jobID stri
 
 	client := pipelinev1.NewPipelineServiceClient(conn)
 
+	if guards.active() {
+		return runPipelineRetryGuarded(ctx, client, jobID, stage, tenant, guards, outputFormat)
+	}
+
 	req := &pipelinev1.RetryFailedRequest{
 		TenantId: tenant,
 		JobId:    jobID,
@@ -1169,9 +1890,12 @@ func runPipelineRetry(ctx context.Context, deps *PipelineCommandDeps, jobID stri
 	}
 
 	if outputFormat == "json" {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(resp)
+		return writeOutcomeJSON(true, RetryResult{
+			TenantID:     tenant,
+			JobID:        jobID,
+			Stage:        stage,
+			RetriedCount: resp.RetriedCount,
+		}, resp.Message)
 	}
 
 	fmt.Printf("Retried %d failed items\n", resp.RetriedCount)
@@ -1287,10 +2011,14 @@ func newPipelineLogsCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var contentID string
 	var tail bool
 	var since string
-	var level string
-	var service string
+	var levels []string
+	var services []string
 	var outputFormat string
 	var limit int
+	var file logFileOptions
+	var noEcho bool
+	var grepPattern string
+	var invert bool
 
 	cmd := &cobra.Command{
 		Use:   "logs [job-id]",
@@ -1300,6 +2028,10 @@ func newPipelineLogsCmd(deps *PipelineCommandDeps) *cobra.Command {
 Filter logs by job ID (trace_id), content ID, service, level, or time range.
 Use --tail to stream logs in real-time.
 
+--level and --service accept several values, repeated or comma-separated, and
+match any of them. A single --level is a minimum (--level warn includes
+errors); several levels match exactly those levels.
+
 Examples:
   # Logs for ingest job
   penf pipeline logs job-abc123
@@ -1310,14 +2042,23 @@ Examples:
   # Stream live logs for a job
   penf pipeline logs job-abc123 --tail
 
+  # Capture a long run to disk, rotating at 50MB
+  penf pipeline logs job-abc123 --tail --output-file job.log --rotate-size 50MB
+
   # Logs from last hour
   penf pipeline logs job-abc123 --since 1h
 
   # Filter by level
   penf pipeline logs job-abc123 --level error
 
+  # Warnings and errors only, from the worker or gateway
+  penf pipeline logs job-abc123 --level warn,error --service worker --service gateway
+
   # Filter by service
-  penf pipeline logs job-abc123 --service worker`,
+  penf pipeline logs job-abc123 --service worker
+
+  # Only messages matching a regular expression (--invert to exclude them)
+  penf pipeline logs job-abc123 --tail --grep 'retry|timeout'`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jobID := ""
@@ -1330,22 +2071,31 @@ Examples:
 			if jobID != "" && contentID != "" {
 				return fmt.Errorf("cannot specify both job-id and --content flag")
 			}
-			return runPipelineLogs(cmd.Context(), deps, jobID, contentID, tail, since, level, service, outputFormat, limit)
+			if err := validateLogFileFlags(file, noEcho, tail, "--tail"); err != nil {
+				return err
+			}
+			grep, err := compileLogGrep(grepPattern, invert)
+			if err != nil {
+				return err
+			}
+			return runPipelineLogs(cmd.Context(), deps, jobID, contentID, tail, since, levels, services, outputFormat, limit, file, noEcho, grep)
 		},
 	}
 
 	cmd.Flags().StringVar(&contentID, "content", "", "Filter by content ID")
 	cmd.Flags().BoolVar(&tail, "tail", false, "Stream logs in real-time")
 	cmd.Flags().StringVar(&since, "since", "15m", "Show logs since this time ago (e.g., 5m, 1h, 24h)")
-	cmd.Flags().StringVar(&level, "level", "", "Filter by log level (debug, info, warn, error)")
-	cmd.Flags().StringVar(&service, "service", "", "Filter by service name")
+	cmd.Flags().StringSliceVar(&levels, "level", nil, "Filter by log level (debug, info, warn, error); repeatable")
+	cmd.Flags().StringSliceVar(&services, "service", nil, "Filter by service name; repeatable")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
 	cmd.Flags().IntVarP(&limit, "limit", "n", 100, "Maximum number of log entries (not used with --tail)")
+	addLogFileFlags(cmd, &file, &noEcho)
+	addLogGrepFlags(cmd, &grepPattern, &invert)
 
 	return cmd
 }
 
-func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID string, contentID string, tail bool, since string, level string, service string, outputFormat string, limit int) error {
+func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID string, contentID string, tail bool, since string, levels []string, services []string, outputFormat string, limit int, file logFileOptions, noEcho bool, grep logGrep) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -1362,9 +2112,12 @@ func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID strin
 		sinceTime = time.Now().Add(-duration)
 	}
 
-	// Validate log level if provided
-	if level != "" {
-		validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	levels = trimLogFilterValues(levels)
+	services = trimLogFilterValues(services)
+
+	// Validate log levels if provided
+	validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	for _, level := range levels {
 		if !validLevels[level] {
 			return fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", level)
 		}
@@ -1384,21 +2137,37 @@ func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID strin
 	}
 
 	filter := client.LogFilter{
-		Service: service,
-		Level:   level,
-		Since:   sinceTime,
-		TraceID: traceID,
+		Services: services,
+		Levels:   levels,
+		Since:    sinceTime,
+		TraceID:  traceID,
 	}
 
 	// Stream logs if --tail is specified
 	if tail {
-		fmt.Printf("Following logs for %s (press Ctrl+C to stop)...\n\n", traceID)
+		var writer *logFileWriter
+		if file.Path != "" {
+			file.JSON = outputFormat == "json"
+			writer, err = openLogFileWriter(file)
+			if err != nil {
+				return err
+			}
+			defer writer.Close()
+			fmt.Fprintf(os.Stderr, "Writing logs to %s\n", file.Path)
+		}
+
+		if !noEcho && !quietOutput {
+			fmt.Printf("Following logs for %s (press Ctrl+C to stop)...\n\n", traceID)
+		}
 
 		// For tail mode, start from now
 		filter.Since = time.Now()
 
 		// StreamLogs uses context for cancellation, no timeout needed
-		err := grpcClient.StreamLogs(ctx, filter, 1000, func(entry client.LogEntry) {
+		stream := func(ctx context.Context, callback func(client.LogEntry)) error {
+			return grpcClient.StreamLogs(ctx, filter, 1000, grep.wrap(callback))
+		}
+		err := teeLogStream(ctx, stream, writer, noEcho, func(entry client.LogEntry) {
 			if outputFormat == "json" {
 				enc := json.NewEncoder(os.Stdout)
 				_ = enc.Encode(entry)
@@ -1411,7 +2180,14 @@ func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID strin
 			return fmt.Errorf("streaming logs: %w", err)
 		}
 
-		fmt.Println("\nStopped following logs.")
+		if writer != nil {
+			if err := writer.Close(); err != nil {
+				return fmt.Errorf("closing log file: %w", err)
+			}
+		}
+		if !noEcho && !quietOutput {
+			fmt.Println("\nStopped following logs.")
+		}
 		return nil
 	}
 
@@ -1422,6 +2198,10 @@ func runPipelineLogs(ctx context.Context, deps *PipelineCommandDeps, jobID strin
 	if err != nil {
 		return fmt.Errorf("fetching logs: %w", err)
 	}
+	if grep.active() {
+		resp.Entries = grep.filter(resp.Entries)
+		resp.TotalCount = int64(len(resp.Entries))
+	}
 
 	if outputFormat == "json" {
 		enc := json.NewEncoder(os.Stdout)
@@ -1482,6 +2262,9 @@ func newPipelineQueueCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var stage string
 	var stuck bool
 	var outputFormat string
+	var stuckThreshold time.Duration
+	var requeue bool
+	var confirm bool
 
 	cmd := &cobra.Command{
 		Use:   "queue",
@@ -1491,6 +2274,17 @@ func newPipelineQueueCmd(deps *PipelineCommandDeps) *cobra.Command {
 Displays pending items, processing items, processing rates, and worker counts
 for each pipeline stage (embeddings, entities, keywords, etc.).
 
+A queue is stuck when its oldest item has waited longer than --stuck-threshold
+(default: stuck_threshold_seconds in config, else 5m). With --stuck --requeue, the
+items of each stuck stage that have waited longer than the threshold are requeued
+for the current tenant; --confirm is required to actually requeue them,
+otherwise the command only reports what it would requeue.
+
+Per-stage requeue needs a gateway that serves RequeueStuckItems. Against an
+older gateway the command falls back to kicking processing for the tenant,
+which is not stage-specific: it queues up to the number of stuck items from
+any stage.
+
 Examples:
   # Show all queues
   penf pipeline queue
@@ -1498,29 +2292,42 @@ Examples:
   # Show specific stage
   penf pipeline queue --stage embeddings
 
-  # Show stuck items (older than 5 minutes)
+  # Show stuck items (older than the stuck threshold, 5 minutes by default)
   penf pipeline queue --stuck
 
+  # Show queues stuck for over 30 minutes and requeue their items
+  penf pipeline queue --stuck --stuck-threshold 30m --requeue --confirm
+
   # Output as JSON
   penf pipeline queue -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPipelineQueue(cmd.Context(), deps, stage, stuck, outputFormat)
+			if requeue && !stuck {
+				return fmt.Errorf("--requeue requires --stuck")
+			}
+			if stuckThreshold < 0 {
+				return fmt.Errorf("--stuck-threshold must not be negative")
+			}
+			return runPipelineQueue(cmd.Context(), deps, stage, stuck, stuckThreshold, requeue, confirm, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVar(&stage, "stage", "", "Filter by specific stage (embeddings, entities, keywords)")
-	cmd.Flags().BoolVar(&stuck, "stuck", false, "Show only items stuck > 5 minutes")
+	cmd.Flags().BoolVar(&stuck, "stuck", false, "Show only queues whose oldest item is older than --stuck-threshold")
+	cmd.Flags().DurationVar(&stuckThreshold, "stuck-threshold", 0, "Age of the oldest item at which a queue counts as stuck (default: stuck_threshold_seconds in config, else 5m)")
+	cmd.Flags().BoolVar(&requeue, "requeue", false, "Requeue the pending items of stuck queues (requires --confirm)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm --requeue")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
 
 	return cmd
 }
 
-func runPipelineQueue(ctx context.Context, deps *PipelineCommandDeps, stage string, stuck bool, outputFormat string) error {
+func runPipelineQueue(ctx context.Context, deps *PipelineCommandDeps, stage string, stuck bool, stuckThreshold time.Duration, requeue bool, confirm bool, outputFormat string) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	stuckThreshold = ResolveStuckThreshold(cfg, stuckThreshold)
 
 	// Initialize gRPC client
 	grpcClient, err := client.ConnectFromConfig(cfg)
@@ -1540,13 +2347,15 @@ func runPipelineQueue(ctx context.Context, deps *PipelineCommandDeps, stage stri
 	// Filter stuck items if requested
 	queues := resp.Queues
 	if stuck {
-		var stuckQueues []*pipelinev1.QueueStats
-		for _, q := range queues {
-			if q.OldestItemAgeSeconds > 300 { // 5 minutes
-				stuckQueues = append(stuckQueues, q)
-			}
+		queues = StuckQueues(queues, stuckThreshold)
+	}
+
+	if requeue {
+		pipelineClient, err := grpcClient.PipelineServiceClient()
+		if err != nil {
+			return err
 		}
-		queues = stuckQueues
+		return requeueStuckQueues(ctx, pipelineClient, cfg.EffectiveTenantID(), queues, stuckThreshold, confirm, outputFormat)
 	}
 
 	if outputFormat == "json" {
@@ -1557,10 +2366,154 @@ func runPipelineQueue(ctx context.Context, deps *PipelineCommandDeps, stage stri
 		})
 	}
 
-	return outputPipelineQueueText(queues, stuck)
+	return outputPipelineQueueText(queues, stuck, stuckThreshold)
 }
 
-func outputPipelineQueueText(queues []*pipelinev1.QueueStats, stuckOnly bool) error {
+// ResolveStuckThreshold returns the stuck threshold to use: the flag value
+// when set, else the configured threshold.
+func ResolveStuckThreshold(cfg *config.CLIConfig, flag time.Duration) time.Duration {
+	if flag > 0 {
+		return flag
+	}
+	return cfg.GetStuckThreshold()
+}
+
+// StuckQueues returns the queues whose oldest item has waited longer than
+// threshold.
+func StuckQueues(queues []*pipelinev1.QueueStats, threshold time.Duration) []*pipelinev1.QueueStats {
+	var stuckQueues []*pipelinev1.QueueStats
+	for _, q := range queues {
+		if time.Duration(q.OldestItemAgeSeconds)*time.Second > threshold {
+			stuckQueues = append(stuckQueues, q)
+		}
+	}
+	return stuckQueues
+}
+
+// StuckQueueRequeue is the requeue outcome for one stuck stage.
+type StuckQueueRequeue struct {
+	Stage    string `json:"stage"`
+	Pending  int64  `json:"pending"`
+	Requeued int64  `json:"requeued"`
+	Message  string `json:"message,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// requeueStuckQueues requeues, stage by stage, the tenant's items that have
+// waited longer than threshold in the stuck queues. Without confirm it only
+// reports what it would requeue. A gateway without RequeueStuckItems gets a
+// single tenant-wide KickProcessing for the stuck items instead.
+func requeueStuckQueues(ctx context.Context, pipelineClient pipelinev1.PipelineServiceClient, tenantID string, queues []*pipelinev1.QueueStats, threshold time.Duration, confirm bool, outputFormat string) error {
+	var stuckItems, requeued int64
+	var failed int
+	var kicked bool
+	names := make([]string, 0, len(queues))
+	stages := make([]StuckQueueRequeue, 0, len(queues))
+	for _, q := range queues {
+		stuckItems += q.PendingCount
+		names = append(names, q.Name)
+		stage := StuckQueueRequeue{Stage: q.Name, Pending: q.PendingCount}
+
+		if confirm && q.PendingCount > 0 && !kicked {
+			rpcCtx, rpcCancel := context.WithTimeout(ctx, 30*time.Second)
+			resp, err := pipelineClient.RequeueStuckItems(rpcCtx, &pipelinev1.RequeueStuckItemsRequest{
+				TenantId:         tenantID,
+				Stage:            q.Name,
+				OlderThanSeconds: int64(threshold / time.Second),
+				Limit:            int32(q.PendingCount),
+			})
+			rpcCancel()
+			switch {
+			case status.Code(err) == codes.Unimplemented:
+				kicked = true
+			case err != nil:
+				stage.Error = err.Error()
+				failed++
+			default:
+				stage.Requeued = resp.RequeuedCount
+				stage.Message = resp.Message
+				requeued += resp.RequeuedCount
+			}
+		}
+		stages = append(stages, stage)
+	}
+
+	// The gateway predates per-stage requeue: kick the tenant's pending items
+	// instead. Stages requeued before the first Unimplemented keep their counts.
+	var kickMessage string
+	if kicked {
+		var remaining int64
+		for _, st := range stages {
+			if st.Requeued == 0 && st.Error == "" {
+				remaining += st.Pending
+			}
+		}
+		rpcCtx, rpcCancel := context.WithTimeout(ctx, 30*time.Second)
+		resp, err := pipelineClient.KickProcessing(rpcCtx, &pipelinev1.KickProcessingRequest{
+			TenantId: tenantID,
+			Limit:    int32(remaining),
+		})
+		rpcCancel()
+		if err != nil {
+			return fmt.Errorf("requeuing stuck items: %w", err)
+		}
+		requeued += resp.QueuedCount
+		kickMessage = resp.Message
+	}
+
+	if outputFormat == "json" {
+		result := map[string]interface{}{
+			"tenant_id":   tenantID,
+			"queues":      queues,
+			"stages":      stages,
+			"stuck_items": stuckItems,
+			"requeued":    requeued,
+			"confirmed":   confirm,
+			"kicked":      kicked,
+		}
+		if kickMessage != "" {
+			result["message"] = kickMessage
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		switch {
+		case stuckItems == 0:
+			fmt.Println("No stuck items to requeue.")
+		case !confirm:
+			fmt.Printf("Would requeue up to %d stuck items (%s).\n", stuckItems, strings.Join(names, ", "))
+			fmt.Println("Re-run with --confirm to requeue.")
+		case kicked:
+			fmt.Println("The gateway does not support per-stage requeue; kicked processing for the tenant instead (not stage-specific).")
+			if kickMessage != "" {
+				fmt.Println(kickMessage)
+			}
+			fmt.Printf("Requeued %d of %d stuck items\n", requeued, stuckItems)
+		default:
+			for _, st := range stages {
+				if st.Error != "" {
+					fmt.Printf("  %s: \033[31merror: %s\033[0m\n", st.Stage, st.Error)
+					continue
+				}
+				fmt.Printf("  %s: requeued %d of %d pending\n", st.Stage, st.Requeued, st.Pending)
+				if st.Message != "" {
+					fmt.Printf("    %s\n", st.Message)
+				}
+			}
+			fmt.Printf("Requeued %d of %d stuck items\n", requeued, stuckItems)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("requeuing stuck items failed for %d stage(s)", failed)
+	}
+	return nil
+}
+
+func outputPipelineQueueText(queues []*pipelinev1.QueueStats, stuckOnly bool, stuckThreshold time.Duration) error {
 	if len(queues) == 0 {
 		if stuckOnly {
 			fmt.Println("No stuck items found.")
@@ -1595,7 +2548,7 @@ func outputPipelineQueueText(queues []*pipelinev1.QueueStats, stuckOnly bool) er
 		if q.PendingCount > 100 {
 			nameColor = "\033[33m" // Yellow for high pending
 		}
-		if q.OldestItemAgeSeconds > 300 { // > 5 minutes
+		if time.Duration(q.OldestItemAgeSeconds)*time.Second > stuckThreshold {
 			nameColor = "\033[31m" // Red for stuck
 		}
 
@@ -1781,14 +2734,17 @@ Examples:
   penf pipeline deleted --limit=100
 
   # Output as JSON
-  penf pipeline deleted -o json`,
+  penf pipeline deleted -o json
+
+  # Export to a spreadsheet
+  penf pipeline deleted -o csv > deleted.csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPipelineDeleted(cmd.Context(), deps, limit, outputFormat)
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum number of sources to show")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, csv")
 
 	return cmd
 }
@@ -1820,6 +2776,9 @@ func runPipelineDeleted(ctx context.Context, deps *PipelineCommandDeps, limit in
 		enc.SetIndent("", "  ")
 		return enc.Encode(resp.Sources)
 	}
+	if outputFormat == "csv" {
+		return outputDeletedSourcesCSV(os.Stdout, resp.Sources)
+	}
 
 	return outputDeletedSourcesHuman(resp.Sources)
 }
@@ -1889,7 +2848,13 @@ Examples:
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
 
-	return cmd
+	return withEnvelope(cmd)
+}
+
+// UndeleteResult is the output of 'pipeline undelete'.
+type UndeleteResult struct {
+	SourceID int64 `json:"source_id"`
+	Restored bool  `json:"restored"`
 }
 
 func runPipelineUndelete(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, outputFormat string) error {
@@ -1915,9 +2880,7 @@ func runPipelineUndelete(ctx context.Context, deps *PipelineCommandDeps, sourceI
 	}
 
 	if outputFormat == "json" {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(resp)
+		return writeOutcomeJSON(resp.Success, UndeleteResult{SourceID: sourceID, Restored: resp.Success}, resp.Message)
 	}
 
 	if resp.Success {
@@ -1962,7 +2925,7 @@ func outputReprocessDryRunHuman(resp *pipelinev1.ReprocessDryRunResponse, stage
 	}
 
 	fmt.Println()
-	fmt.Printf("To execute: penf pipeline reprocess --stage %s --all\n", stage)
+	fmt.Printf("To execute: penf pipeline reprocess --stage %s --all --confirm\n", stage)
 
 	return nil
 }
@@ -1971,6 +2934,8 @@ func newPipelineDiffCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var runA int64
 	var runB int64
 	var outputFormat string
+	var stage string
+	var groupBy string
 
 	cmd := &cobra.Command{
 		Use:   "diff <source-id>",
@@ -1992,26 +2957,45 @@ Examples:
   # Compare specific runs
   penf pipeline diff 123 --run-a 456 --run-b 789
 
+  # Group changes by stage, with added/removed/modified counts per stage
+  penf pipeline diff 123 --group-by stage
+
+  # Did the model change affect summaries?
+  penf pipeline diff 123 --stage summarize
+
   # Output as JSON for programmatic analysis
-  penf pipeline diff 123 --output json`,
+  penf pipeline diff 123 --output json
+
+  # Output as a markdown table for pasting into a PR comment
+  penf pipeline diff 123 --output markdown`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			sourceID, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
 				return fmt.Errorf("invalid source-id: %s (must be a number)", args[0])
 			}
-			return runPipelineDiff(cmd.Context(), deps, sourceID, runA, runB, outputFormat)
+			switch outputFormat {
+			case "text", "json", "markdown", "md":
+			default:
+				return fmt.Errorf("invalid output format: %s (must be text, json, or markdown)", outputFormat)
+			}
+			if groupBy != "" && groupBy != "stage" {
+				return fmt.Errorf("invalid --group-by: %s (must be stage)", groupBy)
+			}
+			return runPipelineDiff(cmd.Context(), deps, sourceID, runA, runB, outputFormat, stage, groupBy == "stage")
 		},
 	}
 
 	cmd.Flags().Int64Var(&runA, "run-a", 0, "First run ID to compare (default: second most recent)")
 	cmd.Flags().Int64Var(&runB, "run-b", 0, "Second run ID to compare (default: most recent)")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, markdown")
+	cmd.Flags().StringVar(&stage, "stage", "", "Only show differences for this stage")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Group text output: stage")
 
 	return cmd
 }
 
-func runPipelineDiff(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, runA int64, runB int64, outputFormat string) error {
+func runPipelineDiff(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, runA int64, runB int64, outputFormat string, stage string, groupByStage bool) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -2042,67 +3026,236 @@ func runPipelineDiff(ctx context.Context, deps *PipelineCommandDeps, sourceID in
 	if err != nil {
 		return fmt.Errorf("comparing pipeline runs: %w", err)
 	}
+	if stage != "" {
+		resp.Diffs = filterPipelineDiffsByStage(resp.Diffs, stage)
+	}
 
 	if outputFormat == "json" {
+		if resp.Diffs == nil {
+			resp.Diffs = []*pipelinev1.StageDiff{}
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(resp)
+		return enc.Encode(PipelineDiffOutput{
+			Diffs:   resp.Diffs,
+			Summary: summarizePipelineDiffs(resp.Diffs),
+		})
+	}
+	if outputFormat == "markdown" || outputFormat == "md" {
+		fmt.Print(renderPipelineDiffMarkdown(resp, sourceID))
+		return nil
+	}
+	if groupByStage {
+		return outputPipelineDiffGrouped(resp, sourceID)
 	}
 
 	return outputPipelineDiffHuman(resp, sourceID)
 }
 
-func outputPipelineDiffHuman(resp *pipelinev1.DiffPipelineRunsResponse, sourceID int64) error {
-	fmt.Printf("Pipeline Diff for Source %d\n", sourceID)
-	fmt.Println("====================================")
+// PipelineDiffOutput is the JSON output of 'pipeline diff'.
+type PipelineDiffOutput struct {
+	Diffs   []*pipelinev1.StageDiff `json:"diffs"`
+	Summary PipelineDiffSummary     `json:"summary"`
+}
+
+// PipelineDiffCounts tallies differences by change type.
+type PipelineDiffCounts struct {
+	Total    int `json:"total"`
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+// PipelineDiffSummary tallies differences overall and per stage.
+type PipelineDiffSummary struct {
+	PipelineDiffCounts
+	ByStage map[string]PipelineDiffCounts `json:"by_stage"`
+}
+
+// add counts one difference.
+func (c *PipelineDiffCounts) add(changeType pipelinev1.ChangeType) {
+	c.Total++
+	switch changeType {
+	case pipelinev1.ChangeType_CHANGE_TYPE_ADDED:
+		c.Added++
+	case pipelinev1.ChangeType_CHANGE_TYPE_REMOVED:
+		c.Removed++
+	case pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED:
+		c.Modified++
+	}
+}
+
+// summarizePipelineDiffs tallies change types overall and per stage.
+func summarizePipelineDiffs(diffs []*pipelinev1.StageDiff) PipelineDiffSummary {
+	summary := PipelineDiffSummary{ByStage: make(map[string]PipelineDiffCounts)}
+	for _, diff := range diffs {
+		summary.add(diff.ChangeType)
+		counts := summary.ByStage[diff.Stage]
+		counts.add(diff.ChangeType)
+		summary.ByStage[diff.Stage] = counts
+	}
+	return summary
+}
+
+// filterPipelineDiffsByStage keeps the differences for one stage; the stage
+// name is matched case-insensitively.
+func filterPipelineDiffsByStage(diffs []*pipelinev1.StageDiff, stage string) []*pipelinev1.StageDiff {
+	var kept []*pipelinev1.StageDiff
+	for _, diff := range diffs {
+		if strings.EqualFold(diff.Stage, stage) {
+			kept = append(kept, diff)
+		}
+	}
+	return kept
+}
+
+// renderPipelineDiffMarkdown renders a pipeline diff as a markdown table suitable
+// for code review comments.
+func renderPipelineDiffMarkdown(resp *pipelinev1.DiffPipelineRunsResponse, sourceID int64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Pipeline diff for source %d\n\n", sourceID)
 
 	if len(resp.Diffs) == 0 {
-		fmt.Println("No differences found between runs.")
-		return nil
+		b.WriteString("No differences found between runs.\n")
+		return b.String()
 	}
 
-	fmt.Printf("Found %d differences:\n\n", len(resp.Diffs))
-	fmt.Println("STAGE            FIELD                      OLD VALUE                  NEW VALUE                  CHANGE")
-	fmt.Println("-----            -----                      ---------                  ---------                  ------")
+	counts := make(map[pipelinev1.ChangeType]int)
+	for _, diff := range resp.Diffs {
+		counts[diff.ChangeType]++
+	}
+	fmt.Fprintf(&b, "%d differences: %d added, %d removed, %d modified\n\n",
+		len(resp.Diffs),
+		counts[pipelinev1.ChangeType_CHANGE_TYPE_ADDED],
+		counts[pipelinev1.ChangeType_CHANGE_TYPE_REMOVED],
+		counts[pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED])
 
+	b.WriteString("| Stage | Field | Old Value | New Value | Change |\n")
+	b.WriteString("|-------|-------|-----------|-----------|--------|\n")
 	for _, diff := range resp.Diffs {
-		// Format change type
-		changeType := ""
+		change := "UNKNOWN"
 		switch diff.ChangeType {
 		case pipelinev1.ChangeType_CHANGE_TYPE_ADDED:
-			changeType = "\033[32mADDED\033[0m"
+			change = "ADDED"
 		case pipelinev1.ChangeType_CHANGE_TYPE_REMOVED:
-			changeType = "\033[31mREMOVED\033[0m"
+			change = "REMOVED"
 		case pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED:
-			changeType = "\033[33mMODIFIED\033[0m"
-		default:
-			changeType = "UNKNOWN"
+			change = "MODIFIED"
 		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | **%s** |\n",
+			markdownCell(diff.Stage), markdownCell(diff.Field),
+			markdownCell(diff.OldValue), markdownCell(diff.NewValue), change)
+	}
+	return b.String()
+}
 
-		// Truncate long values
-		oldValue := diff.OldValue
-		if len(oldValue) > 26 {
-			oldValue = oldValue[:23] + "..."
-		}
-		newValue := diff.NewValue
-		if len(newValue) > 26 {
-			newValue = newValue[:23] + "..."
-		}
+// markdownCell escapes a value for use inside a markdown table cell.
+func markdownCell(value string) string {
+	if value == "" {
+		return "—"
+	}
+	value = truncateString(value, 120)
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r\n", "<br>")
+	value = strings.ReplaceAll(value, "\n", "<br>")
+	return value
+}
 
-		stage := diff.Stage
-		if len(stage) > 16 {
-			stage = stage[:13] + "..."
+// outputPipelineDiffGrouped prints differences grouped by stage, in the order
+// stages first appear, with change counts for each stage.
+func outputPipelineDiffGrouped(resp *pipelinev1.DiffPipelineRunsResponse, sourceID int64) error {
+	fmt.Printf("Pipeline Diff for Source %d\n", sourceID)
+	fmt.Println("====================================")
+
+	if len(resp.Diffs) == 0 {
+		fmt.Println("No differences found between runs.")
+		return nil
+	}
+
+	var stages []string
+	byStage := make(map[string][]*pipelinev1.StageDiff)
+	for _, diff := range resp.Diffs {
+		if _, ok := byStage[diff.Stage]; !ok {
+			stages = append(stages, diff.Stage)
 		}
+		byStage[diff.Stage] = append(byStage[diff.Stage], diff)
+	}
+	summary := summarizePipelineDiffs(resp.Diffs)
+
+	fmt.Printf("Found %d differences across %d stages: %d added, %d removed, %d modified\n",
+		summary.Total, len(stages), summary.Added, summary.Removed, summary.Modified)
 
-		field := diff.Field
-		if len(field) > 26 {
-			field = field[:23] + "..."
+	for _, stage := range stages {
+		counts := summary.ByStage[stage]
+		fmt.Printf("\n\033[1m%s\033[0m  (%d added, %d removed, %d modified)\n",
+			stage, counts.Added, counts.Removed, counts.Modified)
+		fmt.Println("  FIELD                      OLD VALUE                  NEW VALUE                  CHANGE")
+
+		for _, diff := range byStage[stage] {
+			fmt.Printf("  %-26s %-26s %-26s %s\n",
+				truncateDiffValue(diff.Field), truncateDiffValue(diff.OldValue),
+				truncateDiffValue(diff.NewValue), formatPipelineChangeType(diff.ChangeType))
 		}
+	}
 
-		fmt.Printf("%-16s %-26s %-26s %-26s %s\n",
-			stage, field, oldValue, newValue, changeType)
+	fmt.Println()
+	return nil
+}
+
+// formatPipelineChangeType returns a colored label for a change type.
+func formatPipelineChangeType(changeType pipelinev1.ChangeType) string {
+	switch changeType {
+	case pipelinev1.ChangeType_CHANGE_TYPE_ADDED:
+		return "\033[32mADDED\033[0m"
+	case pipelinev1.ChangeType_CHANGE_TYPE_REMOVED:
+		return "\033[31mREMOVED\033[0m"
+	case pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED:
+		return "\033[33mMODIFIED\033[0m"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// truncateDiffValue shortens a value to fit a 26-column diff table cell.
+func truncateDiffValue(value string) string {
+	if len(value) > 26 {
+		return value[:23] + "..."
+	}
+	return value
+}
+
+func outputPipelineDiffHuman(resp *pipelinev1.DiffPipelineRunsResponse, sourceID int64) error {
+	fmt.Printf("Pipeline Diff for Source %d\n", sourceID)
+	fmt.Println("====================================")
+
+	if len(resp.Diffs) == 0 {
+		fmt.Println("No differences found between runs.")
+		return nil
 	}
 
+	fmt.Printf("Found %d differences:\n\n", len(resp.Diffs))
+	printStageDiffTable(os.Stdout, resp.Diffs, "STAGE", "OLD VALUE", "NEW VALUE")
+
 	fmt.Println()
 	return nil
 }
+
+// printStageDiffTable prints differences as a table with the given headings
+// for the stage and value columns.
+func printStageDiffTable(w io.Writer, diffs []*pipelinev1.StageDiff, stageHeading, oldHeading, newHeading string) {
+	fmt.Fprintf(w, "%-16s %-26s %-26s %-26s %s\n", stageHeading, "FIELD", oldHeading, newHeading, "CHANGE")
+	fmt.Fprintf(w, "%-16s %-26s %-26s %-26s %s\n",
+		strings.Repeat("-", len(stageHeading)), "-----",
+		strings.Repeat("-", len(oldHeading)), strings.Repeat("-", len(newHeading)), "------")
+
+	for _, diff := range diffs {
+		stage := diff.Stage
+		if len(stage) > 16 {
+			stage = stage[:13] + "..."
+		}
+
+		fmt.Fprintf(w, "%-16s %-26s %-26s %-26s %s\n",
+			stage, truncateDiffValue(diff.Field), truncateDiffValue(diff.OldValue),
+			truncateDiffValue(diff.NewValue), formatPipelineChangeType(diff.ChangeType))
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// defaultReprocessCheckpointFile is the checkpoint file name used under the config dir.
+const defaultReprocessCheckpointFile = "reprocess-checkpoint.txt"
+
+// reprocessCheckpoint records content IDs that were reprocessed successfully,
+// one per line, so an interrupted bulk reprocess can be resumed.
+type reprocessCheckpoint struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// defaultReprocessCheckpointPath returns the default checkpoint path under ~/.penf/.
+func defaultReprocessCheckpointPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, defaultReprocessCheckpointFile), nil
+}
+
+// openReprocessCheckpoint opens the checkpoint file for recording. A fresh run
+// (empty resumeFrom) truncates it and a run resumed from the same file appends
+// to it. A run resumed from a different file starts path with resumeFrom's
+// recorded IDs, so path alone is enough to resume again.
+func openReprocessCheckpoint(path string, resumeFrom string) (*reprocessCheckpoint, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("creating checkpoint directory: %w", err)
+	}
+
+	if resumeFrom != "" && sameCheckpointFile(path, resumeFrom) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("opening checkpoint file: %w", err)
+		}
+		return &reprocessCheckpoint{file: f}, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening checkpoint file: %w", err)
+	}
+	if resumeFrom != "" {
+		if err := seedReprocessCheckpoint(f, resumeFrom); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return &reprocessCheckpoint{file: f}, nil
+}
+
+// sameCheckpointFile reports whether a and b name the same checkpoint file.
+func sameCheckpointFile(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
+
+// seedReprocessCheckpoint copies the content IDs recorded in the checkpoint at
+// from into f.
+func seedReprocessCheckpoint(f *os.File, from string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return fmt.Errorf("reading checkpoint: %w", err)
+	}
+	defer src.Close()
+
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			if _, err := fmt.Fprintln(f, id); err != nil {
+				return fmt.Errorf("seeding checkpoint file: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Record appends a successfully reprocessed content ID to the checkpoint.
+func (c *reprocessCheckpoint) Record(contentID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := fmt.Fprintln(c.file, contentID)
+	return err
+}
+
+// Close closes the checkpoint file.
+func (c *reprocessCheckpoint) Close() error {
+	return c.file.Close()
+}
+
+// loadReprocessCheckpoint reads the content IDs recorded in a checkpoint file.
+func loadReprocessCheckpoint(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	done := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			done[id] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	return done, nil
+}
+
+// filterCheckpointed returns the content IDs not yet recorded in done, preserving order.
+func filterCheckpointed(contentIDs []string, done map[string]bool) []string {
+	if len(done) == 0 {
+		return contentIDs
+	}
+	remaining := make([]string, 0, len(contentIDs))
+	for _, id := range contentIDs {
+		if !done[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+func TestReprocessCheckpoint_FreshRunTruncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "checkpoint.txt")
+
+	cp, err := openReprocessCheckpoint(path, "")
+	if err != nil {
+		t.Fatalf("openReprocessCheckpoint: %v", err)
+	}
+	for _, id := range []string{"c-1", "c-2"} {
+		if err := cp.Record(id); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	cp.Close()
+
+	cp, err = openReprocessCheckpoint(path, "")
+	if err != nil {
+		t.Fatalf("reopening checkpoint: %v", err)
+	}
+	cp.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading checkpoint: %v", err)
+	}
+	if len(data) != 0 {
+		t.Errorf("expected fresh run to truncate checkpoint, got %q", data)
+	}
+}
+
+func TestReprocessCheckpoint_ResumeAppendsAndSkips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+	if err := os.WriteFile(path, []byte("c-1\n\nc-3\n"), 0600); err != nil {
+		t.Fatalf("writing checkpoint: %v", err)
+	}
+
+	done, err := loadReprocessCheckpoint(path)
+	if err != nil {
+		t.Fatalf("loadReprocessCheckpoint: %v", err)
+	}
+	remaining := filterCheckpointed([]string{"c-1", "c-2", "c-3", "c-4"}, done)
+	if want := []string{"c-2", "c-4"}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("filterCheckpointed = %v, want %v", remaining, want)
+	}
+
+	cp, err := openReprocessCheckpoint(path, path)
+	if err != nil {
+		t.Fatalf("openReprocessCheckpoint: %v", err)
+	}
+	if err := cp.Record("c-2"); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	cp.Close()
+
+	done, err = loadReprocessCheckpoint(path)
+	if err != nil {
+		t.Fatalf("reloading checkpoint: %v", err)
+	}
+	if len(done) != 3 || !done["c-2"] {
+		t.Errorf("expected c-1, c-2, c-3 after resume, got %v", done)
+	}
+}
+
+func TestReprocessCheckpoint_ResumeIntoOtherFileSeeds(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "old.txt")
+	path := filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(from, []byte("c-1\nc-3\n"), 0600); err != nil {
+		t.Fatalf("writing checkpoint: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("stale\n"), 0600); err != nil {
+		t.Fatalf("writing checkpoint: %v", err)
+	}
+
+	cp, err := openReprocessCheckpoint(path, from)
+	if err != nil {
+		t.Fatalf("openReprocessCheckpoint: %v", err)
+	}
+	if err := cp.Record("c-2"); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	cp.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading checkpoint: %v", err)
+	}
+	if want := "c-1\nc-3\nc-2\n"; string(data) != want {
+		t.Errorf("checkpoint = %q, want %q", data, want)
+	}
+}
+
+func TestLoadReprocessCheckpoint_Missing(t *testing.T) {
+	if _, err := loadReprocessCheckpoint(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing checkpoint file")
+	}
+}
+
+func TestDefaultReprocessCheckpointPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PENF_CONFIG_DIR", dir)
+
+	path, err := defaultReprocessCheckpointPath()
+	if err != nil {
+		t.Fatalf("defaultReprocessCheckpointPath: %v", err)
+	}
+	if want := filepath.Join(dir, defaultReprocessCheckpointFile); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+}
+
+// TestReprocessContentPool_RecordsSuccesses verifies onSuccess only sees items that succeeded.
+func TestReprocessContentPool_RecordsSuccesses(t *testing.T) {
+	client := &fakeReprocessClient{failIDs: map[string]bool{"c2": true}}
+	var mu sync.Mutex
+	recorded := map[string]bool{}
+
+	reprocessContentPool(context.Background(), client, []string{"c1", "c2", "c3"}, 2, io.Discard, func(id string) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded[id] = true
+	}, func(id string) *contentv1.ReprocessContentRequest {
+		return &contentv1.ReprocessContentRequest{ContentId: id}
+	})
+
+	if want := map[string]bool{"c1": true, "c3": true}; !reflect.DeepEqual(recorded, want) {
+		t.Errorf("recorded = %v, want %v", recorded, want)
+	}
+}
+
+func TestPipelineReprocessHasCheckpointFlags(t *testing.T) {
+	cmd := newPipelineReprocessCmd(DefaultPipelineDeps())
+	for _, flag := range []string{"checkpoint", "resume-from"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag to exist", flag)
+		}
+	}
+}
@@ -140,7 +140,7 @@ Examples:
 	cmd.Flags().Int32Var(&promptVer, "prompt", 0, "Prompt version override (0 to clear)")
 	cmd.Flags().Int32Var(&timeout, "timeout", 0, "Timeout in seconds")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func newPipelineDefineCreateCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -162,7 +162,7 @@ Examples:
 
 	cmd.Flags().StringVar(&fromPipeline, "from", "", "Clone stages from this pipeline")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // =============================================================================
@@ -303,6 +303,10 @@ func runPipelineDefineSet(ctx context.Context, deps *PipelineCommandDeps, pipeli
 		return fmt.Errorf("updating stage config: %w", err)
 	}
 
+	if envelopeJSON() {
+		return writeResult(resp.Stage, resp.Message)
+	}
+
 	fmt.Printf("Updated %s/%s: %s\n", pipeline, stage, resp.Message)
 	if resp.Stage != nil {
 		outputStageDefinition(resp.Stage)
@@ -340,6 +344,10 @@ func runPipelineDefineCreate(ctx context.Context, deps *PipelineCommandDeps, pip
 		return fmt.Errorf("creating pipeline definition: %w", err)
 	}
 
+	if envelopeJSON() {
+		return writeResult(resp.Definition, resp.Message)
+	}
+
 	fmt.Printf("%s\n\n", resp.Message)
 	if resp.Definition != nil {
 		return outputDefineShowHuman(resp.Definition)
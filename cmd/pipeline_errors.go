@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
@@ -14,13 +18,14 @@ import (
 
 func newPipelineErrorsCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var (
-		since      string
-		code       string
-		sourceID   string
-		retryable  *bool
-		outputFmt  string
-		limit      int
-		groupBy    string
+		since     string
+		code      string
+		sourceID  string
+		retryable *bool
+		outputFmt string
+		limit     int
+		groupBy   string
+		top       int
 	)
 
 	cmd := &cobra.Command{
@@ -46,10 +51,41 @@ Examples:
   # Group errors by code
   penf pipeline errors --since 24h --group-by code
 
+  # Triage view: failed sources per failure category
+  penf pipeline errors --group-by category
+
+  # Top 5 distinct error messages (numbers and IDs collapsed)
+  penf pipeline errors --since 24h --group-by message --top 5
+
+Grouping:
+  code      Error code of each error event
+  stage     Pipeline stage that raised the error
+  message   Error message, with numbers collapsed so similar errors group together
+  category  Failed sources per failure category, from pipeline stats. This counts
+            every currently failed source in the tenant and cannot be combined
+            with --since, --code, --source or --retryable.
+
+Grouping by code, stage or message pages through every matching error rather
+than only the first --limit events; past 10000 events the groups are built from
+a sample and the output says so.
+
   # Output as JSON
   penf pipeline errors --since 24h -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPipelineErrors(cmd.Context(), deps, since, code, sourceID, retryable, outputFmt, limit, groupBy)
+			if groupBy != "" && !isValidErrorGroupBy(groupBy) {
+				return fmt.Errorf("invalid group-by value: %s (must be: %s)", groupBy, strings.Join(pipelineErrorGroupBys, ", "))
+			}
+			if top <= 0 {
+				return fmt.Errorf("--top must be positive")
+			}
+			if groupBy == "category" {
+				for _, name := range []string{"since", "code", "source", "retryable"} {
+					if cmd.Flags().Changed(name) {
+						return InvalidArgumentError(fmt.Errorf("--%s cannot be combined with --group-by category: failure categories are counted over all currently failed sources", name))
+					}
+				}
+			}
+			return runPipelineErrors(cmd.Context(), deps, since, code, sourceID, retryable, outputFmt, limit, groupBy, top)
 		},
 	}
 
@@ -59,12 +95,13 @@ Examples:
 	retryable = cmd.Flags().Bool("retryable", false, "Show only retryable errors")
 	cmd.Flags().StringVarP(&outputFmt, "output", "o", "text", "Output format: text, json")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 100, "Maximum number of errors to show")
-	cmd.Flags().StringVar(&groupBy, "group-by", "", "Group results by: code, stage")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Group results by: code, stage, message, category")
+	cmd.Flags().IntVar(&top, "top", 10, "Maximum number of groups to show with --group-by")
 
 	return cmd
 }
 
-func runPipelineErrors(ctx context.Context, deps *PipelineCommandDeps, since string, code string, sourceID string, retryable *bool, outputFmt string, limit int, groupBy string) error {
+func runPipelineErrors(ctx context.Context, deps *PipelineCommandDeps, since string, code string, sourceID string, retryable *bool, outputFmt string, limit int, groupBy string, top int) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -79,6 +116,19 @@ func runPipelineErrors(ctx context.Context, deps *PipelineCommandDeps, since str
 
 	client := pipelinev1.NewPipelineServiceClient(conn)
 
+	// Failure categories are tracked per source in pipeline stats, not per error event.
+	if groupBy == "category" {
+		resp, err := client.GetStats(ctx, &pipelinev1.GetStatsRequest{TenantId: cfg.EffectiveTenantID()})
+		if err != nil {
+			return fmt.Errorf("getting pipeline stats: %w", err)
+		}
+		summary := groupFailureCategories(resp.Stats.GetSourcesByFailureCategory(), top)
+		if outputFmt == "json" {
+			return outputErrorGroupsJSON(summary)
+		}
+		return outputErrorGroupsText(summary)
+	}
+
 	// Build request
 	req := &pipelinev1.GetPipelineErrorsRequest{
 		Limit:     int32(limit),
@@ -95,34 +145,90 @@ func runPipelineErrors(ctx context.Context, deps *PipelineCommandDeps, since str
 		req.Since = timestamppb.New(sinceTime)
 	}
 
+	onlyRetryable := retryable != nil && *retryable
+
+	// Grouping counts every matching error, not just the first --limit.
+	if groupBy != "" {
+		events, matched, err := fetchPipelineErrorsForGrouping(ctx, client, req)
+		if err != nil {
+			return fmt.Errorf("getting pipeline errors: %w", err)
+		}
+		summary := groupPipelineErrors(filterRetryableErrors(events, onlyRetryable), groupBy, top)
+		if int64(len(events)) < matched {
+			summary.Sampled = true
+			summary.SampleSize = int64(len(events))
+			summary.Matched = matched
+		}
+		if outputFmt == "json" {
+			return outputErrorGroupsJSON(summary)
+		}
+		return outputErrorGroupsText(summary)
+	}
+
 	// Fetch errors
 	resp, err := client.GetPipelineErrors(ctx, req)
 	if err != nil {
 		return fmt.Errorf("getting pipeline errors: %w", err)
 	}
 
-	// Filter by retryable if specified
-	var filteredErrors []*pipelinev1.PipelineErrorEvent
-	if retryable != nil && *retryable {
-		for _, e := range resp.Errors {
-			if e.Retryable {
-				filteredErrors = append(filteredErrors, e)
-			}
-		}
-	} else {
-		filteredErrors = resp.Errors
-	}
+	filteredErrors := filterRetryableErrors(resp.Errors, onlyRetryable)
 
-	// Output results
 	if outputFmt == "json" {
 		return outputErrorsJSON(filteredErrors, resp.TotalCount)
 	}
 
-	if groupBy != "" {
-		return outputErrorsGrouped(filteredErrors, groupBy)
+	return outputErrorsText(filteredErrors, resp.TotalCount, since)
+}
+
+// filterRetryableErrors returns only the retryable events when onlyRetryable is set.
+func filterRetryableErrors(events []*pipelinev1.PipelineErrorEvent, onlyRetryable bool) []*pipelinev1.PipelineErrorEvent {
+	if !onlyRetryable {
+		return events
+	}
+	var filtered []*pipelinev1.PipelineErrorEvent
+	for _, e := range events {
+		if e.Retryable {
+			filtered = append(filtered, e)
+		}
 	}
+	return filtered
+}
 
-	return outputErrorsText(filteredErrors, resp.TotalCount, since)
+// errorGroupPageSize is the page size used when fetching errors to group.
+const errorGroupPageSize = 500
+
+// errorGroupMaxEvents caps how many errors are fetched for grouping.
+const errorGroupMaxEvents = 10000
+
+// fetchPipelineErrorsForGrouping pages through the errors matching req, up to
+// errorGroupMaxEvents. It returns the fetched events and the server's total
+// count of matching errors.
+func fetchPipelineErrorsForGrouping(ctx context.Context, client pipelinev1.PipelineServiceClient, req *pipelinev1.GetPipelineErrorsRequest) ([]*pipelinev1.PipelineErrorEvent, int64, error) {
+	page := proto.Clone(req).(*pipelinev1.GetPipelineErrorsRequest)
+	page.Limit = errorGroupPageSize
+	page.Offset = 0
+
+	var events []*pipelinev1.PipelineErrorEvent
+	var total int64
+	for len(events) < errorGroupMaxEvents {
+		resp, err := client.GetPipelineErrors(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = resp.TotalCount
+		events = append(events, resp.Errors...)
+		if len(resp.Errors) == 0 || int64(len(events)) >= total {
+			break
+		}
+		page.Offset += int32(len(resp.Errors))
+	}
+	if len(events) > errorGroupMaxEvents {
+		events = events[:errorGroupMaxEvents]
+	}
+	if total < int64(len(events)) {
+		total = int64(len(events))
+	}
+	return events, total, nil
 }
 
 func outputErrorsJSON(errors []*pipelinev1.PipelineErrorEvent, totalCount int64) error {
@@ -184,14 +290,58 @@ func outputErrorsText(errors []*pipelinev1.PipelineErrorEvent, totalCount int64,
 	return nil
 }
 
-func outputErrorsGrouped(errors []*pipelinev1.PipelineErrorEvent, groupBy string) error {
-	if len(errors) == 0 {
-		fmt.Println("No errors to group")
-		return nil
+// pipelineErrorGroupBys lists the supported --group-by values.
+var pipelineErrorGroupBys = []string{"code", "stage", "message", "category"}
+
+// errorGroupSampleSize is the number of sample errors kept per group.
+const errorGroupSampleSize = 3
+
+// PipelineErrorGroup is one group of pipeline errors.
+type PipelineErrorGroup struct {
+	Key             string                           `json:"key"`
+	Count           int64                            `json:"count"`
+	Retryable       bool                             `json:"retryable"`
+	SuggestedAction string                           `json:"suggested_action,omitempty"`
+	Samples         []*pipelinev1.PipelineErrorEvent `json:"samples,omitempty"`
+}
+
+// PipelineErrorGroups is the grouped triage view of pipeline errors.
+type PipelineErrorGroups struct {
+	GroupBy     string               `json:"group_by"`
+	Total       int64                `json:"total"`
+	GroupCount  int                  `json:"group_count"`
+	Groups      []PipelineErrorGroup `json:"groups"`
+	OtherCount  int64                `json:"other_count,omitempty"`
+	OtherGroups int                  `json:"other_groups,omitempty"`
+	// Sampled is set when only the first SampleSize of Matched errors were grouped.
+	Sampled    bool  `json:"sampled,omitempty"`
+	SampleSize int64 `json:"sample_size,omitempty"`
+	Matched    int64 `json:"matched,omitempty"`
+}
+
+// isValidErrorGroupBy reports whether groupBy is a supported --group-by value.
+func isValidErrorGroupBy(groupBy string) bool {
+	for _, g := range pipelineErrorGroupBys {
+		if g == groupBy {
+			return true
+		}
 	}
+	return false
+}
+
+var errorMessageNumbers = regexp.MustCompile(`[0-9]+`)
 
-	// Group errors
-	groups := make(map[string][]*pipelinev1.PipelineErrorEvent)
+// normalizeErrorMessage collapses numbers and whitespace so errors that differ
+// only by IDs, durations, or counts share a group.
+func normalizeErrorMessage(message string) string {
+	return strings.Join(strings.Fields(errorMessageNumbers.ReplaceAllString(message, "N")), " ")
+}
+
+// groupPipelineErrors groups error events by code, stage, or message, keeping the
+// largest top groups and rolling the rest into OtherCount.
+func groupPipelineErrors(errors []*pipelinev1.PipelineErrorEvent, groupBy string, top int) PipelineErrorGroups {
+	index := make(map[string]*PipelineErrorGroup)
+	var groups []*PipelineErrorGroup
 	for _, e := range errors {
 		var key string
 		switch groupBy {
@@ -199,39 +349,123 @@ func outputErrorsGrouped(errors []*pipelinev1.PipelineErrorEvent, groupBy string
 			key = e.Code
 		case "stage":
 			key = e.Stage
-		default:
-			return fmt.Errorf("invalid group-by value: %s (must be: code, stage)", groupBy)
+		case "message":
+			key = normalizeErrorMessage(e.Message)
+		}
+		if key == "" {
+			key = "(none)"
 		}
 
-		groups[key] = append(groups[key], e)
+		g, ok := index[key]
+		if !ok {
+			g = &PipelineErrorGroup{Key: key, Retryable: e.Retryable, SuggestedAction: e.SuggestedAction}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.Count++
+		if len(g.Samples) < errorGroupSampleSize {
+			g.Samples = append(g.Samples, e)
+		}
 	}
 
-	fmt.Printf("Pipeline Errors (grouped by %s):\n\n", groupBy)
+	flat := make([]PipelineErrorGroup, len(groups))
+	for i, g := range groups {
+		flat[i] = *g
+	}
+	return rankErrorGroups(groupBy, flat, top)
+}
 
-	// Output groups
-	for key, groupErrors := range groups {
-		count := len(groupErrors)
-		retryable := groupErrors[0].Retryable
-		suggestedAction := groupErrors[0].SuggestedAction
+// groupFailureCategories builds groups from the per-category failed source counts
+// in pipeline stats.
+func groupFailureCategories(counts []*pipelinev1.StatusCount, top int) PipelineErrorGroups {
+	groups := make([]PipelineErrorGroup, 0, len(counts))
+	for _, sc := range counts {
+		if sc.Count == 0 {
+			continue
+		}
+		groups = append(groups, PipelineErrorGroup{Key: sc.Status, Count: sc.Count})
+	}
+	return rankErrorGroups("category", groups, top)
+}
 
-		retryableStr := "no"
-		if retryable {
-			retryableStr = "yes"
+// rankErrorGroups sorts groups by count and keeps the top ones.
+func rankErrorGroups(groupBy string, groups []PipelineErrorGroup, top int) PipelineErrorGroups {
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
 		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	result := PipelineErrorGroups{GroupBy: groupBy, GroupCount: len(groups), Groups: []PipelineErrorGroup{}}
+	for i, g := range groups {
+		result.Total += g.Count
+		if i < top {
+			result.Groups = append(result.Groups, g)
+		} else {
+			result.OtherCount += g.Count
+			result.OtherGroups++
+		}
+	}
+	return result
+}
+
+// errorGroupNoun names what a grouping counts.
+func errorGroupNoun(groupBy string) string {
+	if groupBy == "category" {
+		return "failed sources"
+	}
+	return "errors"
+}
+
+// errorGroupsHeadline summarises groups, e.g. "180 failed sources: 150 timeout, 30 oom".
+func errorGroupsHeadline(summary PipelineErrorGroups) string {
+	parts := make([]string, 0, len(summary.Groups)+1)
+	for _, g := range summary.Groups {
+		parts = append(parts, fmt.Sprintf("%d %s", g.Count, truncate(g.Key, 40)))
+	}
+	if summary.OtherGroups > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", summary.OtherCount))
+	}
+	return fmt.Sprintf("%d %s: %s", summary.Total, errorGroupNoun(summary.GroupBy), strings.Join(parts, ", "))
+}
+
+func outputErrorGroupsJSON(summary PipelineErrorGroups) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summary)
+}
 
-		fmt.Printf("\033[1m%s\033[0m (count: %d, retryable: %s)\n", key, count, retryableStr)
-		if suggestedAction != "" {
-			fmt.Printf("  Suggested action: %s\n", suggestedAction)
+func outputErrorGroupsText(summary PipelineErrorGroups) error {
+	if summary.Total == 0 {
+		fmt.Println("No errors to group")
+		return nil
+	}
+
+	fmt.Printf("Pipeline Errors (grouped by %s):\n", summary.GroupBy)
+	fmt.Println(errorGroupsHeadline(summary))
+	if summary.Sampled {
+		fmt.Printf("Grouped the first %d of %d matching errors; narrow --since or --code to see all.\n", summary.SampleSize, summary.Matched)
+	}
+	fmt.Println()
+
+	for _, g := range summary.Groups {
+		pct := float64(g.Count) / float64(summary.Total) * 100
+		if summary.GroupBy == "category" {
+			fmt.Printf("  %-24s %6d  %3.0f%%\n", truncate(g.Key, 24), g.Count, pct)
+			continue
 		}
 
-		// Show sample errors (first 3)
-		sampleSize := 3
-		if len(groupErrors) < sampleSize {
-			sampleSize = len(groupErrors)
+		retryableStr := "no"
+		if g.Retryable {
+			retryableStr = "yes"
+		}
+		fmt.Printf("\033[1m%s\033[0m (count: %d, %.0f%%, retryable: %s)\n", g.Key, g.Count, pct, retryableStr)
+		if g.SuggestedAction != "" {
+			fmt.Printf("  Suggested action: %s\n", g.SuggestedAction)
 		}
 
-		for i := 0; i < sampleSize; i++ {
-			e := groupErrors[i]
+		for _, e := range g.Samples {
 			timestamp := "-"
 			if e.OccurredAt != nil {
 				timestamp = e.OccurredAt.AsTime().Format("2006-01-02 15:04:05")
@@ -245,12 +479,19 @@ func outputErrorsGrouped(errors []*pipelinev1.PipelineErrorEvent, groupBy string
 			fmt.Printf("  [%s] %s: %s\n", timestamp, e.Stage, message)
 		}
 
-		if len(groupErrors) > sampleSize {
-			fmt.Printf("  ... and %d more\n", len(groupErrors)-sampleSize)
+		if g.Count > int64(len(g.Samples)) {
+			fmt.Printf("  ... and %d more\n", g.Count-int64(len(g.Samples)))
 		}
 
 		fmt.Println()
 	}
 
+	if summary.GroupBy == "category" {
+		fmt.Println()
+	}
+	if summary.OtherGroups > 0 {
+		fmt.Printf("... and %d more groups (%d %s). Use --top to show more.\n", summary.OtherGroups, summary.OtherCount, errorGroupNoun(summary.GroupBy))
+	}
+
 	return nil
 }
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
 )
@@ -60,3 +65,115 @@ func TestOutputErrorsGrouped_Validation(t *testing.T) {
 	assert.Equal(t, 2, codeCounts["timeout"])
 	assert.Equal(t, 1, codeCounts["rate_limit"])
 }
+
+func TestGroupPipelineErrors_ByMessage(t *testing.T) {
+	errors := []*pipelinev1.PipelineErrorEvent{
+		{Code: "timeout", Stage: "embed", Message: "timed out after 120s on source 41"},
+		{Code: "timeout", Stage: "embed", Message: "timed out after 90s on  source 7"},
+		{Code: "oom", Stage: "analyze", Message: "out of memory"},
+	}
+
+	summary := groupPipelineErrors(errors, "message", 10)
+
+	assert.Equal(t, int64(3), summary.Total)
+	assert.Equal(t, 2, summary.GroupCount)
+	assert.Equal(t, "timed out after Ns on source N", summary.Groups[0].Key)
+	assert.Equal(t, int64(2), summary.Groups[0].Count)
+	assert.Len(t, summary.Groups[0].Samples, 2)
+}
+
+func TestGroupPipelineErrors_TopRollsUpRemainder(t *testing.T) {
+	var errors []*pipelinev1.PipelineErrorEvent
+	for code, n := range map[string]int{"timeout": 5, "rate_limit": 3, "oom": 2, "parse": 1} {
+		for i := 0; i < n; i++ {
+			errors = append(errors, &pipelinev1.PipelineErrorEvent{Code: code})
+		}
+	}
+
+	summary := groupPipelineErrors(errors, "code", 2)
+
+	assert.Equal(t, int64(11), summary.Total)
+	assert.Len(t, summary.Groups, 2)
+	assert.Equal(t, "timeout", summary.Groups[0].Key)
+	assert.Equal(t, "rate_limit", summary.Groups[1].Key)
+	assert.Equal(t, 2, summary.OtherGroups)
+	assert.Equal(t, int64(3), summary.OtherCount)
+	assert.Equal(t, "11 errors: 5 timeout, 3 rate_limit, 3 other", errorGroupsHeadline(summary))
+}
+
+func TestGroupFailureCategories(t *testing.T) {
+	summary := groupFailureCategories([]*pipelinev1.StatusCount{
+		{Status: "oom", Count: 30},
+		{Status: "timeout", Count: 150},
+		{Status: "empty", Count: 0},
+	}, 10)
+
+	assert.Equal(t, "category", summary.GroupBy)
+	assert.Equal(t, int64(180), summary.Total)
+	assert.Equal(t, "180 failed sources: 150 timeout, 30 oom", errorGroupsHeadline(summary))
+}
+
+func TestPipelineErrorsCommand_GroupByValidation(t *testing.T) {
+	cmd := newPipelineErrorsCmd(DefaultPipelineDeps())
+	assert.NotNil(t, cmd.Flags().Lookup("top"))
+
+	cmd.SetArgs([]string{"--group-by", "severity"})
+	cmd.SetOut(&strings.Builder{})
+	cmd.SetErr(&strings.Builder{})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "invalid group-by value")
+}
+
+func TestPipelineErrorsCommand_CategoryRejectsFilters(t *testing.T) {
+	for _, args := range [][]string{
+		{"--group-by", "category", "--since", "24h"},
+		{"--group-by", "category", "--code", "TIMEOUT"},
+		{"--group-by", "category", "--source", "em-abc123"},
+		{"--group-by", "category", "--retryable"},
+	} {
+		cmd := newPipelineErrorsCmd(DefaultPipelineDeps())
+		cmd.SetArgs(args)
+		cmd.SetOut(&strings.Builder{})
+		cmd.SetErr(&strings.Builder{})
+		err := cmd.Execute()
+		assert.ErrorContains(t, err, "cannot be combined with --group-by category", "args %v", args)
+		assert.Equal(t, ErrorCodeInvalidArgument, ErrorCode(err), "args %v", args)
+	}
+}
+
+// pagedErrorsClient serves GetPipelineErrors from a fixed list of events.
+type pagedErrorsClient struct {
+	pipelinev1.PipelineServiceClient
+	events []*pipelinev1.PipelineErrorEvent
+	calls  int
+}
+
+func (c *pagedErrorsClient) GetPipelineErrors(ctx context.Context, req *pipelinev1.GetPipelineErrorsRequest, opts ...grpc.CallOption) (*pipelinev1.GetPipelineErrorsResponse, error) {
+	c.calls++
+	start := min(int(req.Offset), len(c.events))
+	end := min(start+int(req.Limit), len(c.events))
+	return &pipelinev1.GetPipelineErrorsResponse{Errors: c.events[start:end], TotalCount: int64(len(c.events))}, nil
+}
+
+func TestFetchPipelineErrorsForGrouping(t *testing.T) {
+	makeEvents := func(n int) []*pipelinev1.PipelineErrorEvent {
+		events := make([]*pipelinev1.PipelineErrorEvent, n)
+		for i := range events {
+			events[i] = &pipelinev1.PipelineErrorEvent{Code: fmt.Sprintf("code-%d", i%3)}
+		}
+		return events
+	}
+
+	c := &pagedErrorsClient{events: makeEvents(errorGroupPageSize*2 + 7)}
+	events, matched, err := fetchPipelineErrorsForGrouping(context.Background(), c, &pipelinev1.GetPipelineErrorsRequest{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, events, errorGroupPageSize*2+7, "every matching error should be fetched, not just --limit")
+	assert.Equal(t, int64(errorGroupPageSize*2+7), matched)
+	assert.Equal(t, 3, c.calls)
+
+	c = &pagedErrorsClient{events: makeEvents(errorGroupMaxEvents + 50)}
+	events, matched, err = fetchPipelineErrorsForGrouping(context.Background(), c, &pipelinev1.GetPipelineErrorsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, events, errorGroupMaxEvents)
+	assert.Equal(t, int64(errorGroupMaxEvents+50), matched)
+}
@@ -2,9 +2,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -21,6 +23,8 @@ func newPipelineInspectCmd(deps *PipelineCommandDeps) *cobra.Command {
 	var showParsed bool
 	var diff bool
 	var noTruncate bool
+	var all bool
+	var raw bool
 	var limit int
 	var outputFormat string
 
@@ -32,7 +36,13 @@ func newPipelineInspectCmd(deps *PipelineCommandDeps) *cobra.Command {
 Without IO flags (--show-input, --show-output, --show-parsed), displays an
 overview table of all pipeline runs for the source.
 
-With IO flags, displays detailed IO data for each run.
+With IO flags, displays detailed IO data for each run. JSON payloads are
+pretty-printed. Use --all to show everything stored for each stage (extracted
+text, entities, summary, keywords, embeddings metadata) in one view.
+
+With --raw, dumps the stored payloads exactly as stored, without formatting
+or truncation, for debugging serialization issues. --raw shows every payload
+unless IO flags narrow it.
 
 With --diff, compares the two most recent runs of the filtered stage.
 
@@ -50,14 +60,29 @@ Examples:
   penf pipeline inspect 42 --stage triage --diff
 
   # Show full data without truncation
-  penf pipeline inspect 42 --stage triage --show-input --no-truncate`,
+  penf pipeline inspect 42 --stage triage --show-input --no-truncate
+
+  # Everything stored for the source, one stage after another
+  penf pipeline inspect 42 --all --limit 1
+
+  # The stored summary payload, byte for byte
+  penf pipeline inspect 42 --stage summarize --show-parsed --raw --limit 1`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			sourceID, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
 				return fmt.Errorf("invalid source ID: %s", args[0])
 			}
-			return runPipelineInspect(cmd.Context(), deps, sourceID, stage, showInput, showOutput, showParsed, diff, noTruncate, limit, outputFormat)
+			if raw && outputFormat == "json" {
+				return fmt.Errorf("--raw cannot be combined with --output json")
+			}
+			if raw && diff {
+				return fmt.Errorf("--raw cannot be combined with --diff")
+			}
+			if all || (raw && !showInput && !showOutput && !showParsed) {
+				showInput, showOutput, showParsed = true, true, true
+			}
+			return runPipelineInspect(cmd.Context(), deps, sourceID, stage, showInput, showOutput, showParsed, diff, noTruncate, raw, limit, outputFormat)
 		},
 	}
 
@@ -67,13 +92,15 @@ Examples:
 	cmd.Flags().BoolVar(&showParsed, "show-parsed", false, "Show parsed/structured data")
 	cmd.Flags().BoolVar(&diff, "diff", false, "Compare two most recent runs")
 	cmd.Flags().BoolVar(&noTruncate, "no-truncate", false, "Show full data without truncation")
+	cmd.Flags().BoolVar(&all, "all", false, "Show input, output, and parsed data for every stage")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Dump stored data exactly as stored, without formatting")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 3, "Maximum number of runs per stage")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
 
 	return cmd
 }
 
-func runPipelineInspect(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, stage string, showInput bool, showOutput bool, showParsed bool, diff bool, noTruncate bool, limit int, outputFormat string) error {
+func runPipelineInspect(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, stage string, showInput bool, showOutput bool, showParsed bool, diff bool, noTruncate bool, raw bool, limit int, outputFormat string) error {
 	cfg := deps.Config
 	if cfg == nil {
 		var err error
@@ -116,6 +143,10 @@ func runPipelineInspect(ctx context.Context, deps *PipelineCommandDeps, sourceID
 		return enc.Encode(resp)
 	}
 
+	if raw {
+		return outputPipelineInspectRaw(os.Stdout, resp.Runs, showInput, showOutput, showParsed)
+	}
+
 	// Determine if we're showing IO data
 	showIOData := showInput || showOutput || showParsed
 
@@ -309,7 +340,7 @@ func outputPipelineInspectIOHuman(runs []*pipelinev1.PipelineRunDetail, sourceID
 			fmt.Println(strings.Repeat("-", 40))
 			fmt.Println("Input")
 			fmt.Println(strings.Repeat("-", 40))
-			fmt.Println(truncateInspectData(run.Io.InputData, noTruncate))
+			fmt.Println(truncateInspectData(prettyInspectData(run.Io.InputData), noTruncate))
 			fmt.Println()
 		}
 
@@ -317,7 +348,7 @@ func outputPipelineInspectIOHuman(runs []*pipelinev1.PipelineRunDetail, sourceID
 			fmt.Println(strings.Repeat("-", 40))
 			fmt.Println("Output")
 			fmt.Println(strings.Repeat("-", 40))
-			fmt.Println(truncateInspectData(run.Io.OutputData, noTruncate))
+			fmt.Println(truncateInspectData(prettyInspectData(run.Io.OutputData), noTruncate))
 			fmt.Println()
 		}
 
@@ -325,7 +356,7 @@ func outputPipelineInspectIOHuman(runs []*pipelinev1.PipelineRunDetail, sourceID
 			fmt.Println(strings.Repeat("-", 40))
 			fmt.Println("Parsed")
 			fmt.Println(strings.Repeat("-", 40))
-			fmt.Println(truncateInspectData(run.Io.ParsedData, noTruncate))
+			fmt.Println(truncateInspectData(prettyInspectData(run.Io.ParsedData), noTruncate))
 			fmt.Println()
 		}
 	}
@@ -368,6 +399,69 @@ func outputPipelineInspectDiffHuman(diffResp *pipelinev1.DiffStageRunsResponse,
 	return nil
 }
 
+// inspectPayload is one stored payload of a pipeline run.
+type inspectPayload struct {
+	run  *pipelinev1.PipelineRunDetail
+	kind string // input, output, parsed
+	data string
+}
+
+// outputPipelineInspectRaw writes the selected payloads exactly as stored.
+// When more than one payload is written, each is preceded by a "# stage run
+// #id kind" header line.
+func outputPipelineInspectRaw(w io.Writer, runs []*pipelinev1.PipelineRunDetail, showInput bool, showOutput bool, showParsed bool) error {
+	var payloads []inspectPayload
+	for _, run := range runs {
+		if run.Io == nil {
+			continue
+		}
+		if showInput && run.Io.InputData != "" {
+			payloads = append(payloads, inspectPayload{run, "input", run.Io.InputData})
+		}
+		if showOutput && run.Io.OutputData != "" {
+			payloads = append(payloads, inspectPayload{run, "output", run.Io.OutputData})
+		}
+		if showParsed && run.Io.ParsedData != "" {
+			payloads = append(payloads, inspectPayload{run, "parsed", run.Io.ParsedData})
+		}
+	}
+
+	if len(payloads) == 0 {
+		return fmt.Errorf("no stored data found")
+	}
+
+	for _, p := range payloads {
+		if len(payloads) > 1 {
+			if _, err := fmt.Fprintf(w, "# %s run #%d %s\n", p.run.Stage, p.run.Id, p.kind); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, p.data); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(p.data, "\n") {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prettyInspectData indents data that is a JSON object or array; anything
+// else is returned unchanged.
+func prettyInspectData(data string) string {
+	trimmed := strings.TrimSpace(data)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return data
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+		return data
+	}
+	return buf.String()
+}
+
 // truncateInspectData truncates data for display unless noTruncate is true.
 func truncateInspectData(data string, noTruncate bool) string {
 	const maxLen = 10000
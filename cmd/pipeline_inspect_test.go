@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+func TestOutputPipelineInspectRaw_SinglePayloadVerbatim(t *testing.T) {
+	stored := `{"summary":"aé",  "keywords":[1,2]}`
+	runs := []*pipelinev1.PipelineRunDetail{
+		{Id: 7, Stage: "summarize", Io: &pipelinev1.StageIOData{InputData: "prompt", ParsedData: stored}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputPipelineInspectRaw(&buf, runs, false, false, true); err != nil {
+		t.Fatalf("outputPipelineInspectRaw() error = %v", err)
+	}
+	if got := buf.String(); got != stored+"\n" {
+		t.Errorf("raw output = %q, want the stored payload unchanged", got)
+	}
+}
+
+func TestOutputPipelineInspectRaw_HeadersForSeveralPayloads(t *testing.T) {
+	runs := []*pipelinev1.PipelineRunDetail{
+		{Id: 7, Stage: "summarize", Io: &pipelinev1.StageIOData{InputData: "prompt\n", ParsedData: "{}"}},
+		{Id: 8, Stage: "extract_ner"},
+	}
+
+	var buf bytes.Buffer
+	if err := outputPipelineInspectRaw(&buf, runs, true, true, true); err != nil {
+		t.Fatalf("outputPipelineInspectRaw() error = %v", err)
+	}
+	want := "# summarize run #7 input\nprompt\n# summarize run #7 parsed\n{}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("raw output = %q, want %q", got, want)
+	}
+
+	if err := outputPipelineInspectRaw(&buf, runs[1:], true, true, true); err == nil {
+		t.Error("expected an error when no data is stored")
+	}
+}
+
+func TestPrettyInspectData(t *testing.T) {
+	if got := prettyInspectData(`{"a":1}`); got != "{\n  \"a\": 1\n}" {
+		t.Errorf("prettyInspectData(object) = %q", got)
+	}
+	for _, in := range []string{"plain text", "{not json", ""} {
+		if got := prettyInspectData(in); got != in {
+			t.Errorf("prettyInspectData(%q) = %q, want unchanged", in, got)
+		}
+	}
+}
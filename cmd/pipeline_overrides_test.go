@@ -2,10 +2,20 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
 )
@@ -277,3 +287,222 @@ func TestPipelineDiffCommandShortHelp(t *testing.T) {
 		t.Errorf("diff command Short help is too long (%d chars): %q", len(diffCmd.Short), diffCmd.Short)
 	}
 }
+
+// TestRenderPipelineDiffMarkdown verifies the markdown diff table and escaping.
+func TestRenderPipelineDiffMarkdown(t *testing.T) {
+	resp := &pipelinev1.DiffPipelineRunsResponse{
+		Diffs: []*pipelinev1.StageDiff{
+			{Stage: "triage", Field: "category", OldValue: "email", NewValue: "meeting", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED},
+			{Stage: "extract_ner", Field: "entities", NewValue: "a|b\nc", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_ADDED},
+		},
+	}
+
+	out := renderPipelineDiffMarkdown(resp, 42)
+
+	for _, want := range []string{
+		"### Pipeline diff for source 42",
+		"2 differences: 1 added, 0 removed, 1 modified",
+		"| Stage | Field | Old Value | New Value | Change |",
+		"| triage | category | email | meeting | **MODIFIED** |",
+		"| extract_ner | entities | — | a\\|b<br>c | **ADDED** |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown output missing %q\ngot:\n%s", want, out)
+		}
+	}
+
+	empty := renderPipelineDiffMarkdown(&pipelinev1.DiffPipelineRunsResponse{}, 42)
+	if !strings.Contains(empty, "No differences found") {
+		t.Errorf("expected no-differences message, got:\n%s", empty)
+	}
+}
+
+// TestPipelineReprocessHasConcurrencyFlag verifies the bulk reprocess worker flag and its bounds.
+func TestPipelineReprocessHasConcurrencyFlag(t *testing.T) {
+	cmd := newPipelineReprocessCmd(DefaultPipelineDeps())
+
+	flag := cmd.Flags().Lookup("concurrency")
+	if flag == nil {
+		t.Fatal("reprocess command missing --concurrency flag")
+	}
+	if flag.DefValue != "4" {
+		t.Errorf("--concurrency default = %s, want 4", flag.DefValue)
+	}
+
+	for in, want := range map[int]int{-1: 1, 0: 1, 4: 4, 1000: maxReprocessConcurrency} {
+		if got := clampReprocessConcurrency(in); got != want {
+			t.Errorf("clampReprocessConcurrency(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+// fakeReprocessClient returns queued errors from ReprocessContent, then succeeds.
+// Content IDs listed in failIDs always fail with NotFound.
+type fakeReprocessClient struct {
+	contentv1.ContentProcessorServiceClient
+	mu      sync.Mutex
+	errs    []error
+	failIDs map[string]bool
+	calls   int
+}
+
+func (f *fakeReprocessClient) ReprocessContent(ctx context.Context, in *contentv1.ReprocessContentRequest, opts ...grpc.CallOption) (*contentv1.ReprocessContentResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.failIDs[in.ContentId] {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return &contentv1.ReprocessContentResponse{JobId: "job-" + in.ContentId}, nil
+}
+
+// TestReprocessContentPool verifies the worker pool aggregates results across workers.
+func TestReprocessContentPool(t *testing.T) {
+	var ids []string
+	for i := 0; i < 25; i++ {
+		ids = append(ids, fmt.Sprintf("c%d", i))
+	}
+	client := &fakeReprocessClient{failIDs: map[string]bool{"c3": true, "c17": true}}
+
+	result := reprocessContentPool(context.Background(), client, ids, 6, io.Discard, nil, func(id string) *contentv1.ReprocessContentRequest {
+		return &contentv1.ReprocessContentRequest{ContentId: id}
+	})
+
+	if result.Done != 25 || result.Succeeded != 23 || result.Failed != 2 {
+		t.Errorf("result = %+v, want 25 done, 23 succeeded, 2 failed", result)
+	}
+	if len(result.JobIDs) != 23 {
+		t.Errorf("expected 23 job IDs, got %d", len(result.JobIDs))
+	}
+	if client.calls != 25 {
+		t.Errorf("expected 25 calls, got %d", client.calls)
+	}
+}
+
+// TestReprocessContentWithBackoff_NonRetryable verifies non-backpressure errors fail immediately.
+func TestReprocessContentWithBackoff_NonRetryable(t *testing.T) {
+	client := &fakeReprocessClient{errs: []error{status.Error(codes.NotFound, "missing")}}
+
+	_, err := reprocessContentWithBackoff(context.Background(), client, &contentv1.ReprocessContentRequest{ContentId: "c1"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if client.calls != 1 {
+		t.Errorf("expected 1 call for non-retryable error, got %d", client.calls)
+	}
+}
+
+// TestReprocessContentWithBackoff_CancelledDuringBackoff verifies cancellation stops retries.
+func TestReprocessContentWithBackoff_CancelledDuringBackoff(t *testing.T) {
+	client := &fakeReprocessClient{errs: []error{status.Error(codes.ResourceExhausted, "busy")}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := reprocessContentWithBackoff(ctx, client, &contentv1.ReprocessContentRequest{ContentId: "c1"})
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPromptBulkReprocessConfirm(t *testing.T) {
+	cases := map[string]bool{
+		"y\n":   true,
+		"YES\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+	}
+	for input, want := range cases {
+		if got := promptBulkReprocessConfirm(strings.NewReader(input), 10); got != want {
+			t.Errorf("promptBulkReprocessConfirm(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestOutputBulkReprocessJSON_Empty(t *testing.T) {
+	out := captureStdout(func() {
+		if err := outputBulkReprocessJSON(0, 0, 0, nil); err != nil {
+			t.Errorf("outputBulkReprocessJSON: %v", err)
+		}
+	})
+
+	var result struct {
+		TotalCount int      `json:"total_count"`
+		JobIDs     []string `json:"job_ids"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, out)
+	}
+	if result.JobIDs == nil || len(result.JobIDs) != 0 {
+		t.Errorf("expected empty job_ids array, got %v", result.JobIDs)
+	}
+}
+
+func TestOutputBulkReprocessNeedsConfirmJSON(t *testing.T) {
+	out := captureStdout(func() {
+		impact := BulkReprocessImpact{ContentCount: 7, Stage: "embed", FirstItems: []string{"c1", "c2"}}
+		if err := outputBulkReprocessNeedsConfirmJSON(impact); err != nil {
+			t.Errorf("outputBulkReprocessNeedsConfirmJSON: %v", err)
+		}
+	})
+
+	var result struct {
+		NeedsConfirm bool                `json:"needs_confirm"`
+		SuccessCount int                 `json:"success_count"`
+		Impact       BulkReprocessImpact `json:"impact"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, out)
+	}
+	if !result.NeedsConfirm || result.SuccessCount != 0 || result.Impact.ContentCount != 7 {
+		t.Errorf("got %+v, want needs_confirm with the impact of 7 items", result)
+	}
+}
+
+// TestSummarizePipelineDiffs verifies change types are tallied overall and per stage.
+func TestSummarizePipelineDiffs(t *testing.T) {
+	diffs := []*pipelinev1.StageDiff{
+		{Stage: "summarize", Field: "summary", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_MODIFIED},
+		{Stage: "entities", Field: "person", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_ADDED},
+		{Stage: "entities", Field: "org", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_REMOVED},
+		{Stage: "entities", Field: "place", ChangeType: pipelinev1.ChangeType_CHANGE_TYPE_ADDED},
+	}
+
+	summary := summarizePipelineDiffs(diffs)
+	if summary.Total != 4 || summary.Added != 2 || summary.Removed != 1 || summary.Modified != 1 {
+		t.Errorf("overall counts = %+v", summary.PipelineDiffCounts)
+	}
+	want := PipelineDiffCounts{Total: 3, Added: 2, Removed: 1}
+	if got := summary.ByStage["entities"]; got != want {
+		t.Errorf("entities counts = %+v, want %+v", got, want)
+	}
+
+	data, err := json.Marshal(PipelineDiffOutput{Diffs: diffs, Summary: summary})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"summary":{"total":4,"added":2,"removed":1,"modified":1,"by_stage":`) {
+		t.Errorf("JSON summary not flattened as expected: %s", data)
+	}
+}
+
+// TestFilterPipelineDiffsByStage verifies --stage keeps only one stage's diffs.
+func TestFilterPipelineDiffsByStage(t *testing.T) {
+	diffs := []*pipelinev1.StageDiff{
+		{Stage: "summarize", Field: "summary"},
+		{Stage: "entities", Field: "person"},
+	}
+
+	kept := filterPipelineDiffsByStage(diffs, "Summarize")
+	if len(kept) != 1 || kept[0].Field != "summary" {
+		t.Errorf("filterPipelineDiffsByStage() = %v", kept)
+	}
+	if kept := filterPipelineDiffsByStage(diffs, "keywords"); len(kept) != 0 {
+		t.Errorf("expected no diffs for unknown stage, got %v", kept)
+	}
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// retryLedgerFile is the file under the config dir that records the retries
+// issued per job from this machine, so --max-local-retries can stop
+// retrying poison jobs.
+const retryLedgerFile = "retry-ledger.json"
+
+// retryListPageSize and retryListMaxPages bound the jobs listed per status
+// by a guarded retry. Jobs beyond them are reported, not retried.
+const (
+	retryListPageSize = 100
+	retryListMaxPages = 20
+)
+
+// retryListStatuses are the job statuses whose failed items a guarded retry
+// considers: failed jobs, and completed jobs with some failed items, as an
+// unguarded retry does.
+var retryListStatuses = []string{"failed", "completed"}
+
+// Reasons a failed job is skipped by a guarded retry.
+const (
+	retrySkipLocalRetries = "local_retry_cap"
+	retrySkipTooNew       = "too_recent"
+)
+
+// retryLedgerEntry records the retries issued for one job.
+type retryLedgerEntry struct {
+	Retries   int       `json:"retries"`
+	LastRetry time.Time `json:"last_retry"`
+}
+
+// retryLedger maps job IDs to the retries issued for them.
+type retryLedger map[string]retryLedgerEntry
+
+// retryGuards are the --max-local-retries and --older-than limits.
+type retryGuards struct {
+	MaxLocalRetries int
+	OlderThan       time.Duration
+}
+
+// active reports whether any guard is set.
+func (g retryGuards) active() bool {
+	return g.MaxLocalRetries > 0 || g.OlderThan > 0
+}
+
+// RetryResult is the output of an unguarded 'pipeline retry'.
+type RetryResult struct {
+	TenantID     string `json:"tenant_id,omitempty"`
+	JobID        string `json:"job_id,omitempty"`
+	Stage        string `json:"stage,omitempty"`
+	RetriedCount int64  `json:"retried_count"`
+}
+
+// RetryJobResult is the outcome for one failed job in a guarded retry.
+// LocalRetries counts the retries issued for the job from this machine
+// before this run, as recorded in the local retry ledger.
+type RetryJobResult struct {
+	JobID        string     `json:"job_id"`
+	SourceTag    string     `json:"source_tag,omitempty"`
+	FailedAt     *time.Time `json:"failed_at,omitempty"`
+	LocalRetries int        `json:"local_retries"`
+	Retried      bool       `json:"retried"`
+	RetriedN     int64      `json:"retried_items,omitempty"`
+	SkipReason   string     `json:"skip_reason,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// RetryGuardedResult is the output of 'pipeline retry' with guards.
+// LedgerPath is the ledger of retries issued from this machine, which
+// --max-local-retries is checked against. SkippedBeyondCap counts listed
+// jobs past the page cap, which were neither examined nor retried.
+type RetryGuardedResult struct {
+	TenantID            string           `json:"tenant_id,omitempty"`
+	MaxLocalRetries     int              `json:"max_local_retries,omitempty"`
+	OlderThan           string           `json:"older_than,omitempty"`
+	LedgerPath          string           `json:"ledger_path"`
+	Retried             int              `json:"retried"`
+	RetriedItems        int64            `json:"retried_items"`
+	SkippedLocalRetries int              `json:"skipped_local_retry_cap"`
+	SkippedTooNew       int              `json:"skipped_too_recent"`
+	SkippedBeyondCap    int64            `json:"skipped_beyond_page_cap"`
+	Failed              int              `json:"failed"`
+	Jobs                []RetryJobResult `json:"jobs"`
+}
+
+// retryLedgerPath returns the ledger path under ~/.penf/.
+func retryLedgerPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, retryLedgerFile), nil
+}
+
+// loadRetryLedger reads the ledger; a missing file is an empty ledger.
+func loadRetryLedger(path string) (retryLedger, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return retryLedger{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading retry ledger: %w", err)
+	}
+	ledger := retryLedger{}
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("parsing retry ledger %s: %w", path, err)
+	}
+	return ledger, nil
+}
+
+// saveRetryLedger writes the ledger.
+func saveRetryLedger(path string, ledger retryLedger) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// retries is how many retries have been issued for a job from this machine.
+func (l retryLedger) retries(jobID string) int {
+	return l[jobID].Retries
+}
+
+// record notes a retry issued for a job.
+func (l retryLedger) record(jobID string, at time.Time) {
+	entry := l[jobID]
+	entry.Retries++
+	entry.LastRetry = at
+	l[jobID] = entry
+}
+
+// jobFailedAt is when a failed job finished, or when it was created if it
+// has no completion time. It is nil when the job has neither.
+func jobFailedAt(job *pipelinev1.JobSummary) *time.Time {
+	ts := job.GetCompletedAt()
+	if ts == nil {
+		ts = job.GetCreatedAt()
+	}
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// retrySkipReason returns why a failed job should not be retried, or "" if
+// it should.
+func retrySkipReason(guards retryGuards, localRetries int, failedAt *time.Time, now time.Time) string {
+	if guards.MaxLocalRetries > 0 && localRetries >= guards.MaxLocalRetries {
+		return retrySkipLocalRetries
+	}
+	if guards.OlderThan > 0 && failedAt != nil && now.Sub(*failedAt) < guards.OlderThan {
+		return retrySkipTooNew
+	}
+	return ""
+}
+
+// listFailedJobs pages through the ingest jobs of tenant that have failed
+// items: failed jobs and completed jobs with a failed count. ListJobs has no
+// tenant field, so the tenant travels as metadata. It also returns how many
+// jobs lie beyond the page cap and were not listed.
+func listFailedJobs(ctx context.Context, client pipelinev1.PipelineServiceClient, tenant string) ([]*pipelinev1.JobSummary, int64, error) {
+	ctx = AddTenantMetadata(ctx, tenant)
+
+	var jobs []*pipelinev1.JobSummary
+	var beyondCap int64
+	for _, status := range retryListStatuses {
+		listed := 0
+		var total int64
+		for page := 0; page < retryListMaxPages; page++ {
+			resp, err := client.ListJobs(ctx, &pipelinev1.ListJobsRequest{
+				Limit:  retryListPageSize,
+				Offset: int32(listed),
+				Status: status,
+			})
+			if err != nil {
+				return nil, 0, fmt.Errorf("listing %s jobs: %w", status, err)
+			}
+			listed += len(resp.Jobs)
+			total = resp.TotalCount
+			for _, job := range resp.Jobs {
+				if status == "failed" || job.GetFailedCount() > 0 {
+					jobs = append(jobs, job)
+				}
+			}
+			if len(resp.Jobs) < retryListPageSize || int64(listed) >= total {
+				break
+			}
+		}
+		if total > int64(listed) {
+			beyondCap += total - int64(listed)
+		}
+	}
+	return jobs, beyondCap, nil
+}
+
+// runPipelineRetryGuarded retries failed jobs one at a time, skipping those
+// that have reached --max-local-retries or failed more recently than --older-than.
+func runPipelineRetryGuarded(ctx context.Context, client pipelinev1.PipelineServiceClient, jobID, stage, tenant string, guards retryGuards, outputFormat string) error {
+	ledgerPath, err := retryLedgerPath()
+	if err != nil {
+		return fmt.Errorf("resolving retry ledger path: %w", err)
+	}
+	ledger, err := loadRetryLedger(ledgerPath)
+	if err != nil {
+		return err
+	}
+
+	result := RetryGuardedResult{
+		TenantID:        tenant,
+		MaxLocalRetries: guards.MaxLocalRetries,
+		LedgerPath:      ledgerPath,
+		Jobs:            []RetryJobResult{},
+	}
+
+	var jobs []*pipelinev1.JobSummary
+	if jobID != "" {
+		jobs = []*pipelinev1.JobSummary{{Id: jobID}}
+	} else {
+		jobs, result.SkippedBeyondCap, err = listFailedJobs(ctx, client, tenant)
+		if err != nil {
+			return err
+		}
+	}
+	if guards.OlderThan > 0 {
+		result.OlderThan = guards.OlderThan.String()
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		jr := RetryJobResult{
+			JobID:        job.GetId(),
+			SourceTag:    job.GetSourceTag(),
+			FailedAt:     jobFailedAt(job),
+			LocalRetries: ledger.retries(job.GetId()),
+		}
+		jr.SkipReason = retrySkipReason(guards, jr.LocalRetries, jr.FailedAt, now)
+
+		switch jr.SkipReason {
+		case retrySkipLocalRetries:
+			result.SkippedLocalRetries++
+		case retrySkipTooNew:
+			result.SkippedTooNew++
+		default:
+			resp, err := client.RetryFailed(ctx, &pipelinev1.RetryFailedRequest{
+				TenantId: tenant,
+				JobId:    job.GetId(),
+				Stage:    stage,
+			})
+			if err != nil {
+				jr.Error = err.Error()
+				result.Failed++
+				break
+			}
+			ledger.record(job.GetId(), now)
+			jr.Retried = true
+			jr.RetriedN = resp.RetriedCount
+			result.Retried++
+			result.RetriedItems += resp.RetriedCount
+		}
+		result.Jobs = append(result.Jobs, jr)
+	}
+
+	if result.Retried > 0 {
+		if err := saveRetryLedger(ledgerPath, ledger); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save retry ledger: %v\n", err)
+		}
+	}
+
+	if outputFormat == "json" {
+		return writeOutcomeJSON(result.Failed == 0, result, retryGuardedSummary(result))
+	}
+	outputRetryGuardedHuman(result)
+	return nil
+}
+
+func outputRetryGuardedHuman(result RetryGuardedResult) {
+	if len(result.Jobs) == 0 && result.SkippedBeyondCap == 0 {
+		fmt.Println("No failed jobs to retry.")
+		return
+	}
+
+	for _, jr := range result.Jobs {
+		switch {
+		case jr.Retried:
+			fmt.Printf("  \033[32m✓\033[0m %s  retried %d items (local retry %d)\n", jr.JobID, jr.RetriedN, jr.LocalRetries+1)
+		case jr.Error != "":
+			fmt.Printf("  \033[31m✗\033[0m %s  %s\n", jr.JobID, jr.Error)
+		case jr.SkipReason == retrySkipLocalRetries:
+			fmt.Printf("  \033[33m-\033[0m %s  skipped: retried %d times from this machine (--max-local-retries %d)\n", jr.JobID, jr.LocalRetries, result.MaxLocalRetries)
+		case jr.SkipReason == retrySkipTooNew:
+			fmt.Printf("  \033[33m-\033[0m %s  skipped: failed %s ago (--older-than %s)\n", jr.JobID, formatETA(time.Since(*jr.FailedAt)), result.OlderThan)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Retried %d jobs (%d items), skipped %d at local retry cap, %d too recent",
+		result.Retried, result.RetriedItems, result.SkippedLocalRetries, result.SkippedTooNew)
+	if result.SkippedBeyondCap > 0 {
+		fmt.Printf(", %d beyond the listing cap", result.SkippedBeyondCap)
+	}
+	if result.Failed > 0 {
+		fmt.Printf(", \033[31m%d failed\033[0m", result.Failed)
+	}
+	fmt.Println()
+	if result.SkippedBeyondCap > 0 {
+		fmt.Printf("Only the first %d jobs per status were examined; run again to reach the rest.\n", retryListPageSize*retryListMaxPages)
+	}
+	if result.MaxLocalRetries > 0 {
+		fmt.Printf("Only retries issued from this machine are counted (ledger %s).\n", result.LedgerPath)
+	}
+}
+
+// retryGuardedSummary is the one-line summary of a guarded retry.
+func retryGuardedSummary(result RetryGuardedResult) string {
+	msg := fmt.Sprintf("retried %d jobs (%d items), skipped %d at local retry cap, %d too recent",
+		result.Retried, result.RetriedItems, result.SkippedLocalRetries, result.SkippedTooNew)
+	if result.SkippedBeyondCap > 0 {
+		msg += fmt.Sprintf(", %d beyond the listing cap", result.SkippedBeyondCap)
+	}
+	if result.Failed > 0 {
+		msg += fmt.Sprintf(", %d failed", result.Failed)
+	}
+	return msg
+}
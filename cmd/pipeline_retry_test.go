@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+func TestRetrySkipReason(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-time.Hour)
+	minuteAgo := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		guards   retryGuards
+		retries  int
+		failedAt *time.Time
+		want     string
+	}{
+		{name: "no guards", retries: 10, failedAt: &minuteAgo, want: ""},
+		{name: "never retried", guards: retryGuards{MaxLocalRetries: 1}, retries: 0, want: ""},
+		{name: "under cap", guards: retryGuards{MaxLocalRetries: 3}, retries: 2, want: ""},
+		{name: "at cap", guards: retryGuards{MaxLocalRetries: 3}, retries: 3, want: retrySkipLocalRetries},
+		{name: "old enough", guards: retryGuards{OlderThan: 30 * time.Minute}, failedAt: &hourAgo, want: ""},
+		{name: "too recent", guards: retryGuards{OlderThan: 30 * time.Minute}, failedAt: &minuteAgo, want: retrySkipTooNew},
+		{name: "unknown failure time", guards: retryGuards{OlderThan: 30 * time.Minute}, want: ""},
+		{name: "cap checked first", guards: retryGuards{MaxLocalRetries: 2, OlderThan: 30 * time.Minute}, retries: 2, failedAt: &minuteAgo, want: retrySkipLocalRetries},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := retrySkipReason(tc.guards, tc.retries, tc.failedAt, now); got != tc.want {
+				t.Errorf("retrySkipReason() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryLedger_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "penf", retryLedgerFile)
+
+	ledger, err := loadRetryLedger(path)
+	if err != nil {
+		t.Fatalf("loadRetryLedger(missing) error = %v", err)
+	}
+	if got := ledger.retries("job-1"); got != 0 {
+		t.Errorf("retries for unseen job = %d, want 0", got)
+	}
+
+	now := time.Now()
+	ledger.record("job-1", now)
+	ledger.record("job-1", now)
+	if err := saveRetryLedger(path, ledger); err != nil {
+		t.Fatalf("saveRetryLedger() error = %v", err)
+	}
+
+	loaded, err := loadRetryLedger(path)
+	if err != nil {
+		t.Fatalf("loadRetryLedger() error = %v", err)
+	}
+	if got := loaded.retries("job-1"); got != 2 {
+		t.Errorf("retries after two retries = %d, want 2", got)
+	}
+}
+
+// listJobsClient serves ListJobs from jobs by status and records the tenant
+// metadata of each call.
+type listJobsClient struct {
+	pipelinev1.PipelineServiceClient
+	jobs    map[string][]*pipelinev1.JobSummary
+	tenants []string
+}
+
+func (c *listJobsClient) ListJobs(ctx context.Context, req *pipelinev1.ListJobsRequest, opts ...grpc.CallOption) (*pipelinev1.ListJobsResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.tenants = append(c.tenants, md.Get("x-tenant-id")...)
+
+	all := c.jobs[req.Status]
+	start := min(int(req.Offset), len(all))
+	end := min(start+int(req.Limit), len(all))
+	return &pipelinev1.ListJobsResponse{Jobs: all[start:end], TotalCount: int64(len(all))}, nil
+}
+
+func TestListFailedJobs(t *testing.T) {
+	failed := make([]*pipelinev1.JobSummary, retryListPageSize*retryListMaxPages+5)
+	for i := range failed {
+		failed[i] = &pipelinev1.JobSummary{Id: fmt.Sprintf("failed-%d", i), Status: "failed"}
+	}
+	c := &listJobsClient{jobs: map[string][]*pipelinev1.JobSummary{
+		"failed": failed,
+		"completed": {
+			{Id: "clean", Status: "completed"},
+			{Id: "partial", Status: "completed", FailedCount: 2},
+		},
+	}}
+
+	jobs, beyondCap, err := listFailedJobs(context.Background(), c, "tenant-a")
+	if err != nil {
+		t.Fatalf("listFailedJobs() error = %v", err)
+	}
+	if want := retryListPageSize*retryListMaxPages + 1; len(jobs) != want {
+		t.Errorf("listed %d jobs, want %d (capped failed jobs plus the partial completed one)", len(jobs), want)
+	}
+	if last := jobs[len(jobs)-1].GetId(); last != "partial" {
+		t.Errorf("last job = %s, want the completed job with failed items", last)
+	}
+	if beyondCap != 5 {
+		t.Errorf("beyondCap = %d, want 5", beyondCap)
+	}
+	for _, tenant := range c.tenants {
+		if tenant != "tenant-a" {
+			t.Fatalf("ListJobs sent tenant %q, want tenant-a", tenant)
+		}
+	}
+	if len(c.tenants) == 0 {
+		t.Error("ListJobs was called without tenant metadata")
+	}
+}
+
+func TestPipelineRetryCmd_MaxAttemptsAlias(t *testing.T) {
+	cmd := newPipelineRetryCmd(&PipelineCommandDeps{})
+	flag := cmd.Flags().Lookup("max-attempts")
+	if flag == nil || flag.Deprecated == "" {
+		t.Fatal("expected --max-attempts to remain as a deprecated alias")
+	}
+	if err := cmd.Flags().Parse([]string{"--max-attempts", "4"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := cmd.Flags().Lookup("max-local-retries").Value.String(); got != "4" {
+		t.Errorf("--max-attempts set --max-local-retries to %s, want 4", got)
+	}
+}
@@ -273,18 +273,18 @@ func newPipelineRulesAddCmd(deps *PipelineCommandDeps) *cobra.Command {
 	cmd.Flags().BoolVar(&inactive, "inactive", false, "Create rule as inactive")
 	_ = cmd.MarkFlagRequired("priority")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func newPipelineRulesDeleteCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "delete <name>",
 		Short: "Delete a classification rule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPipelineRulesDelete(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 func newPipelineRulesCopyCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -301,7 +301,7 @@ func newPipelineRulesCopyCmd(deps *PipelineCommandDeps) *cobra.Command {
 	cmd.Flags().StringVar(&fromTenant, "from-tenant", "", "Source tenant UUID (required)")
 	_ = cmd.MarkFlagRequired("from-tenant")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runPipelineRulesAdd(ctx context.Context, deps *PipelineCommandDeps, name string, priority int32, ctype, subtype, source, scope string, conditions []string, inactive bool) error {
@@ -319,6 +319,7 @@ func runPipelineRulesAdd(ctx context.Context, deps *PipelineCommandDeps, name st
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -353,12 +354,12 @@ func runPipelineRulesAdd(ctx context.Context, deps *PipelineCommandDeps, name st
 	}
 
 	rule := resp.Rule
-	fmt.Printf("Created rule: %s (priority %d, scope %s, type %s", rule.Name, rule.Priority, rule.Scope, rule.ContentType)
+	fmt.Fprintf(w, "Created rule: %s (priority %d, scope %s, type %s", rule.Name, rule.Priority, rule.Scope, rule.ContentType)
 	if rule.ContentSubtype != "" {
-		fmt.Printf("/%s", rule.ContentSubtype)
+		fmt.Fprintf(w, "/%s", rule.ContentSubtype)
 	}
-	fmt.Println(")")
-	return nil
+	fmt.Fprintln(w, ")")
+	return writeResult(rule, "Created rule: "+rule.Name)
 }
 
 func runPipelineRulesDelete(ctx context.Context, deps *PipelineCommandDeps, name string) error {
@@ -367,6 +368,7 @@ func runPipelineRulesDelete(ctx context.Context, deps *PipelineCommandDeps, name
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -387,13 +389,13 @@ func runPipelineRulesDelete(ctx context.Context, deps *PipelineCommandDeps, name
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("deleting classification rule: %w", err)
 	}
 
-	fmt.Printf("Deleted rule: %s\n", name)
-	return nil
+	fmt.Fprintf(w, "Deleted rule: %s\n", name)
+	return writeResult(RuleActionResult{Rule: name, Action: "delete"}, "Deleted rule: "+name)
 }
 
 func runPipelineRulesCopy(ctx context.Context, deps *PipelineCommandDeps, fromTenantID string) error {
@@ -402,6 +404,7 @@ func runPipelineRulesCopy(ctx context.Context, deps *PipelineCommandDeps, fromTe
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	destTenantID := cfg.EffectiveTenantID()
 	if destTenantID == "" {
@@ -423,6 +426,7 @@ func runPipelineRulesCopy(ctx context.Context, deps *PipelineCommandDeps, fromTe
 		return fmt.Errorf("listing source rules: %w", err)
 	}
 
+	result := RulesCopyResult{FromTenantID: fromTenantID, ToTenantID: destTenantID, Copied: []string{}, Skipped: []string{}}
 	copied, skipped := 0, 0
 	for _, rule := range listResp.Rules {
 		_, err := client.CreateClassificationRule(ctx, &pipelinev1.CreateClassificationRuleRequest{
@@ -438,22 +442,32 @@ func runPipelineRulesCopy(ctx context.Context, deps *PipelineCommandDeps, fromTe
 		})
 		if err != nil {
 			if status.Code(err) == codes.AlreadyExists {
-				fmt.Printf("  skip (exists): %s\n", rule.Name)
+				fmt.Fprintf(w, "  skip (exists): %s\n", rule.Name)
 				skipped++
+				result.Skipped = append(result.Skipped, rule.Name)
 				continue
 			}
 			return fmt.Errorf("copying rule %q: %w", rule.Name, err)
 		}
-		fmt.Printf("  copied: %s\n", rule.Name)
+		fmt.Fprintf(w, "  copied: %s\n", rule.Name)
 		copied++
+		result.Copied = append(result.Copied, rule.Name)
 	}
 
-	fmt.Printf("\nCopied %d rules from %s to %s", copied, fromTenantID, destTenantID)
+	fmt.Fprintf(w, "\nCopied %d rules from %s to %s", copied, fromTenantID, destTenantID)
 	if skipped > 0 {
-		fmt.Printf(" (%d skipped, already exist)", skipped)
+		fmt.Fprintf(w, " (%d skipped, already exist)", skipped)
 	}
-	fmt.Println()
-	return nil
+	fmt.Fprintln(w)
+	return writeResult(result, fmt.Sprintf("Copied %d rules", copied))
+}
+
+// RulesCopyResult is the --output json result of 'pipeline rules copy'.
+type RulesCopyResult struct {
+	FromTenantID string   `json:"from_tenant_id"`
+	ToTenantID   string   `json:"to_tenant_id"`
+	Copied       []string `json:"copied"`
+	Skipped      []string `json:"skipped"`
 }
 
 func parseConditions(raw []string) ([]*pipelinev1.ClassificationMatchCondition, error) {
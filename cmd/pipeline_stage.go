@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +28,8 @@ var knownPipelineStages = []string{
 }
 
 func newPipelineStageCmd(deps *PipelineCommandDeps) *cobra.Command {
+	var outputFormat string
+
 	cmd := &cobra.Command{
 		Use:   "stage",
 		Short: "View and manage per-stage pipeline configuration",
@@ -35,12 +39,21 @@ Each pipeline stage can have its own model, timeout, and LLM parameter settings.
 This command provides a unified view, making it easy to see and adjust how each
 stage is configured.
 
+Given a source ID, shows a per-source timeline of which stages completed (✓),
+are still pending (⧖), or failed (✗), with start times, durations, and the
+wait between consecutive stages.
+
 Commands:
-  list   - Show all stages with model + timeout configuration
-  set    - Update model and/or timeout for a stage
-  reset  - Reset a stage to default configuration
+  list     - Show all stages with model + timeout configuration
+  set      - Update model and/or timeout for a stage
+  reset    - Reset a stage to default configuration
+  timings  - Show p50/p95 durations per stage over recent runs
 
 Examples:
+  # Show the stage timeline for a source
+  penf pipeline stage 42
+  penf pipeline stage 42 -o json
+
   # Show all stage configurations
   penf pipeline stage list
 
@@ -48,18 +61,32 @@ Examples:
   penf pipeline stage set triage --model qwen2.5:7b --timeout 60s
 
   # Reset triage to defaults
-  penf pipeline stage reset triage`,
+  penf pipeline stage reset triage
+
+  # Find the slowest stage over the last day
+  penf pipeline stage timings --since 24h`,
 	}
 
 	cmd.AddCommand(newPipelineStageListCmd(deps))
 	cmd.AddCommand(newPipelineStageSetCmd(deps))
 	cmd.AddCommand(newPipelineStageResetCmd(deps))
+	cmd.AddCommand(newPipelineStageTimingsCmd(deps))
 
-	// Default action is list
+	// Default action is list; a source ID shows that source's stage timeline
+	cmd.Args = cobra.MaximumNArgs(1)
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		return runPipelineStageList(cmd.Context(), deps, "text")
+		if len(args) == 1 {
+			sourceID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid source ID: %s", args[0])
+			}
+			return runPipelineStageTimeline(cmd.Context(), deps, sourceID, outputFormat)
+		}
+		return runPipelineStageList(cmd.Context(), deps, outputFormat)
 	}
 
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+
 	return cmd
 }
 
@@ -149,7 +176,7 @@ Examples:
 	cmd.Flags().Int32Var(&maxTokens, "max-tokens", 0, "Max output tokens")
 	cmd.Flags().Int32Var(&maxRetries, "max-retries", 0, "Max retry attempts on LLM failure")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func newPipelineStageResetCmd(deps *PipelineCommandDeps) *cobra.Command {
@@ -180,7 +207,7 @@ Examples:
 
 	cmd.Flags().StringVar(&reason, "reason", "Reset to defaults via CLI", "Reason for the reset")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func isValidPipelineStage(stage string) bool {
@@ -223,6 +250,14 @@ func runPipelineStageList(ctx context.Context, deps *PipelineCommandDeps, output
 	return outputStageConfigListHuman(resp.Stages)
 }
 
+// StageChangeResult is the --output json result of 'pipeline stage set' and
+// 'pipeline stage reset'. Updated maps each changed setting to its new value.
+type StageChangeResult struct {
+	Stage    string                       `json:"stage"`
+	Updated  map[string]string            `json:"updated"`
+	Previous *pipelinev1.StageConfigEntry `json:"previous,omitempty"`
+}
+
 func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage string, model string, timeout string, heartbeat string, reason string, pipeline string,
 	temperature float32, hasTemperature bool, maxTokens int32, hasMaxTokens bool, maxRetries int32, hasMaxRetries bool) error {
 	cfg, err := deps.LoadConfig()
@@ -230,6 +265,8 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
+	result := StageChangeResult{Stage: stage, Updated: map[string]string{}}
 
 	conn, err := connectPipelineToGateway(cfg)
 	if err != nil {
@@ -261,7 +298,8 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		if err != nil {
 			return fmt.Errorf("updating timeout for %s: %w", stage, err)
 		}
-		fmt.Printf("Updated %s start_to_close timeout: %s\n", stage, timeout)
+		fmt.Fprintf(w, "Updated %s start_to_close timeout: %s\n", stage, timeout)
+		result.Updated["start_to_close"] = timeout
 
 		// Set heartbeat: explicit value, or default to timeout/4
 		hbValue := heartbeat
@@ -283,7 +321,8 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		if err != nil {
 			return fmt.Errorf("updating heartbeat for %s: %w", stage, err)
 		}
-		fmt.Printf("Updated %s heartbeat timeout: %s\n", stage, hbValue)
+		fmt.Fprintf(w, "Updated %s heartbeat timeout: %s\n", stage, hbValue)
+		result.Updated["heartbeat"] = hbValue
 	} else if heartbeat != "" {
 		// Only heartbeat specified (no timeout)
 		if _, err := time.ParseDuration(heartbeat); err != nil {
@@ -300,7 +339,8 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		if err != nil {
 			return fmt.Errorf("updating heartbeat for %s: %w", stage, err)
 		}
-		fmt.Printf("Updated %s heartbeat timeout: %s\n", stage, heartbeat)
+		fmt.Fprintf(w, "Updated %s heartbeat timeout: %s\n", stage, heartbeat)
+		result.Updated["heartbeat"] = heartbeat
 	}
 
 	// Update model if specified
@@ -315,7 +355,8 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		if err != nil {
 			return fmt.Errorf("updating model for %s: %w", stage, err)
 		}
-		fmt.Printf("Updated %s model: %s\n", stage, model)
+		fmt.Fprintf(w, "Updated %s model: %s\n", stage, model)
+		result.Updated["model"] = model
 	}
 
 	// Update LLM parameters via UpdatePipelineStageConfig
@@ -346,19 +387,22 @@ func runPipelineStageSet(ctx context.Context, deps *PipelineCommandDeps, stage s
 		}
 
 		if hasTemperature {
-			fmt.Printf("Updated %s temperature: %.2f\n", stage, temperature)
+			fmt.Fprintf(w, "Updated %s temperature: %.2f\n", stage, temperature)
+			result.Updated["temperature"] = fmt.Sprintf("%.2f", temperature)
 		}
 		if hasMaxTokens {
-			fmt.Printf("Updated %s max_tokens: %d\n", stage, maxTokens)
+			fmt.Fprintf(w, "Updated %s max_tokens: %d\n", stage, maxTokens)
+			result.Updated["max_tokens"] = fmt.Sprint(maxTokens)
 		}
 		if hasMaxRetries {
-			fmt.Printf("Updated %s max_retries: %d\n", stage, maxRetries)
+			fmt.Fprintf(w, "Updated %s max_retries: %d\n", stage, maxRetries)
+			result.Updated["max_retries"] = fmt.Sprint(maxRetries)
 		}
 		_ = resp
 	}
 
-	fmt.Println("\nConfiguration changes take effect for new workflow activities.")
-	return nil
+	fmt.Fprintln(w, "\nConfiguration changes take effect for new workflow activities.")
+	return writeResult(result, "Configuration changes take effect for new workflow activities.")
 }
 
 func runPipelineStageReset(ctx context.Context, deps *PipelineCommandDeps, stage string, reason string) error {
@@ -367,6 +411,7 @@ func runPipelineStageReset(ctx context.Context, deps *PipelineCommandDeps, stage
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	conn, err := connectPipelineToGateway(cfg)
 	if err != nil {
@@ -390,18 +435,18 @@ func runPipelineStageReset(ctx context.Context, deps *PipelineCommandDeps, stage
 	}
 
 	current := resp.Stages[0]
-	fmt.Printf("Resetting %s configuration:\n", stage)
-	fmt.Printf("  Current model:     %s (source: %s)\n", current.Model, current.ModelSource)
-	fmt.Printf("  Current timeout:   %s (source: %s)\n", current.Timeout, current.TimeoutSource)
-	fmt.Printf("  Current heartbeat: %s\n", current.Heartbeat)
+	fmt.Fprintf(w, "Resetting %s configuration:\n", stage)
+	fmt.Fprintf(w, "  Current model:     %s (source: %s)\n", current.Model, current.ModelSource)
+	fmt.Fprintf(w, "  Current timeout:   %s (source: %s)\n", current.Timeout, current.TimeoutSource)
+	fmt.Fprintf(w, "  Current heartbeat: %s\n", current.Heartbeat)
 	if current.Temperature != nil {
-		fmt.Printf("  Current temperature: %.2f\n", *current.Temperature)
+		fmt.Fprintf(w, "  Current temperature: %.2f\n", *current.Temperature)
 	}
 	if current.MaxTokens != nil {
-		fmt.Printf("  Current max_tokens:  %d\n", *current.MaxTokens)
+		fmt.Fprintf(w, "  Current max_tokens:  %d\n", *current.MaxTokens)
 	}
 	if current.MaxRetries != nil {
-		fmt.Printf("  Current max_retries: %d\n", *current.MaxRetries)
+		fmt.Fprintf(w, "  Current max_retries: %d\n", *current.MaxRetries)
 	}
 
 	updatedBy := os.Getenv("USER")
@@ -450,11 +495,15 @@ func runPipelineStageReset(ctx context.Context, deps *PipelineCommandDeps, stage
 		}
 	}
 
-	fmt.Printf("\nReset %s timeouts to defaults (timeout: %s, heartbeat: %s)\n",
+	fmt.Fprintf(w, "\nReset %s timeouts to defaults (timeout: %s, heartbeat: %s)\n",
 		stage, defaults[stage], heartbeatDefaults[stage])
-	fmt.Println("Configuration changes take effect for new workflow activities.")
+	fmt.Fprintln(w, "Configuration changes take effect for new workflow activities.")
 
-	return nil
+	return writeResult(StageChangeResult{
+		Stage:    stage,
+		Previous: current,
+		Updated:  map[string]string{"start_to_close": defaults[stage], "heartbeat": heartbeatDefaults[stage]},
+	}, "Configuration changes take effect for new workflow activities.")
 }
 
 func outputStageConfigListHuman(stages []*pipelinev1.StageConfigEntry) error {
@@ -508,3 +557,166 @@ func outputStageConfigListHuman(stages []*pipelinev1.StageConfigEntry) error {
 
 	return nil
 }
+
+// StageTimelineEntry is one stage in a source's processing timeline.
+type StageTimelineEntry struct {
+	Stage      string     `json:"stage"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms,omitempty"`
+	WaitMs     int64      `json:"wait_ms,omitempty"`
+	ModelID    string     `json:"model_id,omitempty"`
+	Attempts   int        `json:"attempts,omitempty"`
+}
+
+// Stage timeline statuses.
+const (
+	stageTimelineCompleted = "completed"
+	stageTimelineFailed    = "failed"
+	stageTimelinePending   = "pending"
+)
+
+func runPipelineStageTimeline(ctx context.Context, deps *PipelineCommandDeps, sourceID int64, outputFormat string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectPipelineToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pipelinev1.NewPipelineServiceClient(conn)
+
+	resp, err := client.GetSourceHistory(ctx, &pipelinev1.GetSourceHistoryRequest{
+		SourceId: sourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting source history: %w", err)
+	}
+
+	timeline := buildStageTimeline(resp.Runs)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(timeline)
+	}
+
+	return outputStageTimelineHuman(timeline, sourceID)
+}
+
+// buildStageTimeline reduces a source's run history to one entry per stage.
+// The latest non-superseded run decides each stage's status. Stages that ran are
+// ordered by start time; known stages with no run are appended as pending.
+func buildStageTimeline(runs []*pipelinev1.PipelineRun) []StageTimelineEntry {
+	latest := make(map[string]*pipelinev1.PipelineRun)
+	attempts := make(map[string]int)
+
+	for _, run := range runs {
+		if run.Status == "superseded" {
+			continue
+		}
+		attempts[run.Stage]++
+		prev, ok := latest[run.Stage]
+		if !ok || run.CreatedAt.AsTime().After(prev.CreatedAt.AsTime()) {
+			latest[run.Stage] = run
+		}
+	}
+
+	var timeline []StageTimelineEntry
+	for stage, run := range latest {
+		entry := StageTimelineEntry{
+			Stage:      stage,
+			Status:     stageTimelineCompleted,
+			DurationMs: run.DurationMs,
+			ModelID:    run.ModelId,
+			Attempts:   attempts[stage],
+		}
+		if run.Status == "failed" {
+			entry.Status = stageTimelineFailed
+		}
+		if run.CreatedAt != nil {
+			started := run.CreatedAt.AsTime()
+			finished := started.Add(time.Duration(run.DurationMs) * time.Millisecond)
+			entry.StartedAt = &started
+			entry.FinishedAt = &finished
+		}
+		timeline = append(timeline, entry)
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		a, b := timeline[i].StartedAt, timeline[j].StartedAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+
+	// Wait time is the gap between the previous stage finishing and this one starting.
+	for i := 1; i < len(timeline); i++ {
+		prev, cur := timeline[i-1], &timeline[i]
+		if prev.FinishedAt != nil && cur.StartedAt != nil {
+			if wait := cur.StartedAt.Sub(*prev.FinishedAt); wait > 0 {
+				cur.WaitMs = wait.Milliseconds()
+			}
+		}
+	}
+
+	for _, stage := range knownPipelineStages {
+		if _, ok := latest[stage]; !ok {
+			timeline = append(timeline, StageTimelineEntry{
+				Stage:  stage,
+				Status: stageTimelinePending,
+			})
+		}
+	}
+
+	return timeline
+}
+
+func outputStageTimelineHuman(timeline []StageTimelineEntry, sourceID int64) error {
+	fmt.Printf("Stage Timeline for Source %d\n", sourceID)
+	fmt.Println("=============================")
+	fmt.Println()
+
+	for i, entry := range timeline {
+		var marker, color string
+		switch entry.Status {
+		case stageTimelineCompleted:
+			marker, color = "✓", "\033[32m" // Green
+		case stageTimelineFailed:
+			marker, color = "✗", "\033[31m" // Red
+		default:
+			marker, color = "⧖", "\033[33m" // Yellow
+		}
+
+		if i > 0 {
+			if entry.WaitMs > 0 {
+				fmt.Printf("  │   \033[90mwaited %s\033[0m\n", formatDurationMs(int(entry.WaitMs)))
+			} else {
+				fmt.Println("  │")
+			}
+		}
+
+		detail := entry.Status
+		if entry.StartedAt != nil {
+			detail = fmt.Sprintf("%s at %s, took %s",
+				entry.Status,
+				entry.StartedAt.Local().Format("2006-01-02 15:04:05"),
+				formatDurationMs(int(entry.DurationMs)))
+		}
+		if entry.Attempts > 1 {
+			detail += fmt.Sprintf(" (%d attempts)", entry.Attempts)
+		}
+
+		fmt.Printf("  %s%s\033[0m %-20s %s\n", color, marker, entry.Stage, detail)
+	}
+
+	fmt.Println()
+	return nil
+}
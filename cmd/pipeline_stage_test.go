@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
 )
 
 func TestPipelineStageCommand(t *testing.T) {
@@ -135,7 +144,7 @@ func TestPipelineStageSubcommands(t *testing.T) {
 	deps := DefaultPipelineDeps()
 	cmd := newPipelineStageCmd(deps)
 
-	expectedCommands := []string{"list", "set", "reset"}
+	expectedCommands := []string{"list", "set", "reset", "timings"}
 	subcommands := cmd.Commands()
 
 	for _, expected := range expectedCommands {
@@ -160,6 +169,7 @@ func TestPipelineStageAllCommandsHaveHelp(t *testing.T) {
 		newPipelineStageListCmd(deps),
 		newPipelineStageSetCmd(deps),
 		newPipelineStageResetCmd(deps),
+		newPipelineStageTimingsCmd(deps),
 	}
 
 	for _, cmd := range commands {
@@ -171,3 +181,135 @@ func TestPipelineStageAllCommandsHaveHelp(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildStageTimeline(t *testing.T) {
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	runs := []*pipelinev1.PipelineRun{
+		{Stage: "segment", Status: "completed", CreatedAt: timestamppb.New(base.Add(10 * time.Second)), DurationMs: 2000},
+		{Stage: "triage", Status: "superseded", CreatedAt: timestamppb.New(base.Add(-time.Hour)), DurationMs: 500},
+		{Stage: "triage", Status: "completed", CreatedAt: timestamppb.New(base), DurationMs: 5000},
+		{Stage: "extract_ner", Status: "failed", CreatedAt: timestamppb.New(base.Add(20 * time.Second)), DurationMs: 1000},
+	}
+
+	timeline := buildStageTimeline(runs)
+
+	if len(timeline) != len(knownPipelineStages) {
+		t.Fatalf("expected %d entries, got %d", len(knownPipelineStages), len(timeline))
+	}
+
+	expected := []struct {
+		stage  string
+		status string
+		waitMs int64
+	}{
+		{"triage", stageTimelineCompleted, 0},
+		{"segment", stageTimelineCompleted, 5000},
+		{"extract_ner", stageTimelineFailed, 8000},
+		{"extract_semantic", stageTimelinePending, 0},
+	}
+	for i, want := range expected {
+		got := timeline[i]
+		if got.Stage != want.stage || got.Status != want.status || got.WaitMs != want.waitMs {
+			t.Errorf("entry %d = {%s %s wait=%d}, want {%s %s wait=%d}",
+				i, got.Stage, got.Status, got.WaitMs, want.stage, want.status, want.waitMs)
+		}
+	}
+
+	// Superseded runs are not counted as attempts.
+	if timeline[0].Attempts != 1 {
+		t.Errorf("triage attempts = %d, want 1", timeline[0].Attempts)
+	}
+}
+
+func TestPipelineStageCommandAcceptsSourceID(t *testing.T) {
+	cmd := newPipelineStageCmd(DefaultPipelineDeps())
+
+	if err := cmd.Args(cmd, []string{"42"}); err != nil {
+		t.Errorf("expected a single source ID to be accepted: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"42", "43"}); err == nil {
+		t.Error("expected error for more than one source ID")
+	}
+	if cmd.Flags().Lookup("output") == nil {
+		t.Error("expected --output flag on stage command")
+	}
+}
+
+func TestBuildStageTimingsReport(t *testing.T) {
+	since := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := timestamppb.New(since.Add(time.Hour))
+	runs := []*pipelinev1.PipelineRun{
+		{Stage: "triage", Status: "completed", CreatedAt: at, DurationMs: 100},
+		{Stage: "triage", Status: "completed", CreatedAt: at, DurationMs: 300},
+		{Stage: "triage", Status: "superseded", CreatedAt: at, DurationMs: 90000},
+		{Stage: "extract_semantic", Status: "completed", CreatedAt: at, DurationMs: 4000},
+		{Stage: "extract_semantic", Status: "failed", CreatedAt: at, DurationMs: 6000},
+		{Stage: "extract_semantic", Status: "completed", CreatedAt: at, DurationMs: 20000},
+		{Stage: "embed", Status: "completed", CreatedAt: timestamppb.New(since.Add(-time.Hour)), DurationMs: 50000},
+	}
+
+	report := buildStageTimingsReport(runs, since)
+
+	if len(report.Stages) != 2 {
+		t.Fatalf("expected 2 stages (superseded and old runs ignored), got %+v", report.Stages)
+	}
+	if report.Dominant != "extract_semantic" {
+		t.Errorf("dominant = %q, want extract_semantic", report.Dominant)
+	}
+
+	sem := report.Stages[0]
+	if sem.Runs != 3 || sem.Failed != 1 || sem.P50Ms != 6000 || sem.P95Ms != 20000 || sem.MeanMs != 10000 {
+		t.Errorf("extract_semantic = %+v", sem)
+	}
+	if report.Stages[1].P50Ms != 100 || report.Stages[1].P95Ms != 300 {
+		t.Errorf("triage = %+v", report.Stages[1])
+	}
+	if total := sem.SharePct + report.Stages[1].SharePct; total < 99.9 || total > 100.1 {
+		t.Errorf("shares sum to %.2f, want 100", total)
+	}
+}
+
+// fakeSourceIDClient maps content IDs to pipeline source IDs.
+type fakeSourceIDClient struct {
+	contentv1.ContentProcessorServiceClient
+	sources map[string]int64
+}
+
+func (c fakeSourceIDClient) GetProcessingStatus(ctx context.Context, in *contentv1.GetProcessingStatusRequest, opts ...grpc.CallOption) (*contentv1.ProcessingStatus, error) {
+	id, ok := c.sources[in.ContentId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "content not found")
+	}
+	return &contentv1.ProcessingStatus{ContentId: in.ContentId, SourceId: id}, nil
+}
+
+func TestResolvePipelineSourceID(t *testing.T) {
+	client := fakeSourceIDClient{sources: map[string]int64{"em-3f9a1c2b": 4217, "em-unqueued": 0}}
+	ctx := context.Background()
+
+	if id, err := resolvePipelineSourceID(ctx, client, "em-3f9a1c2b"); err != nil || id != 4217 {
+		t.Errorf("em- content ID resolved to %d, %v; want 4217", id, err)
+	}
+	if id, err := resolvePipelineSourceID(ctx, client, "42"); err != nil || id != 42 {
+		t.Errorf("numeric ID resolved to %d, %v; want 42", id, err)
+	}
+	if _, err := resolvePipelineSourceID(ctx, client, "em-unqueued"); err == nil {
+		t.Error("expected error for content with no pipeline source")
+	}
+	if _, err := resolvePipelineSourceID(ctx, client, "em-missing"); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown content, got %v", err)
+	}
+}
+
+func TestPercentileMs(t *testing.T) {
+	sorted := []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	cases := map[float64]int64{50: 50, 95: 100, 10: 10, 1: 10}
+	for p, want := range cases {
+		if got := percentileMs(sorted, p); got != want {
+			t.Errorf("percentileMs(p%.0f) = %d, want %d", p, got, want)
+		}
+	}
+	if got := percentileMs(nil, 50); got != 0 {
+		t.Errorf("percentileMs(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+// StageTiming aggregates run durations for one pipeline stage.
+type StageTiming struct {
+	Stage    string  `json:"stage"`
+	Runs     int     `json:"runs"`
+	Failed   int     `json:"failed"`
+	P50Ms    int64   `json:"p50_ms"`
+	P95Ms    int64   `json:"p95_ms"`
+	MeanMs   int64   `json:"mean_ms"`
+	TotalMs  int64   `json:"total_ms"`
+	SharePct float64 `json:"share_pct"`
+}
+
+// StageTimingsReport is the aggregate stage timing view over recent runs.
+type StageTimingsReport struct {
+	Since          time.Time     `json:"since"`
+	SourcesSampled int           `json:"sources_sampled"`
+	Stages         []StageTiming `json:"stages"`
+	Dominant       string        `json:"dominant,omitempty"`
+}
+
+func newPipelineStageTimingsCmd(deps *PipelineCommandDeps) *cobra.Command {
+	var since string
+	var limit int
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "timings",
+		Short: "Show p50/p95 processing time per stage over recent runs",
+		Long: `Aggregate per-stage processing durations over recent pipeline runs.
+
+Samples sources created within --since, reads each source's run history, and
+reports p50/p95/mean duration per stage along with each stage's share of total
+processing time. The stage with the largest share dominates end-to-end latency
+and is the best candidate for optimization or a faster model.
+
+Superseded runs are ignored. Failed runs count towards timings since they
+still consumed time.
+
+Examples:
+  # Stage timings over the last 24 hours
+  penf pipeline stage timings
+
+  # Last week, sampling up to 500 sources
+  penf pipeline stage timings --since 168h --limit 500
+
+  # JSON output
+  penf pipeline stage timings -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+			return runPipelineStageTimings(cmd.Context(), deps, since, limit, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "24h", "Include runs since (duration like 24h, or date)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of recent sources to sample")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json")
+
+	return cmd
+}
+
+func runPipelineStageTimings(ctx context.Context, deps *PipelineCommandDeps, since string, limit int, outputFormat string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	if cfg.TenantID == "" {
+		return fmt.Errorf("tenant_id not configured")
+	}
+
+	sinceTime, err := parseTimeFilter(since)
+	if err != nil {
+		return fmt.Errorf("invalid --since value: %w", err)
+	}
+
+	conn, err := connectPipelineToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	contentClient := contentv1.NewContentProcessorServiceClient(conn)
+	pipelineClient := pipelinev1.NewPipelineServiceClient(conn)
+
+	// There is no cross-source run listing, so sample recent sources and derive
+	// timings from each one's run history.
+	listReq := &contentv1.ListContentItemsRequest{
+		TenantId:     cfg.TenantID,
+		CreatedAfter: timestamppb.New(sinceTime),
+		PageSize:     int32(min(limit, 100)),
+	}
+	var sourceIDs []int64
+	for len(sourceIDs) < limit {
+		resp, err := contentClient.ListContentItems(ctx, listReq)
+		if err != nil {
+			return fmt.Errorf("listing recent sources: %w", err)
+		}
+		for _, item := range resp.Items {
+			id, err := resolvePipelineSourceID(ctx, contentClient, item.Id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: resolving source for %s: %v\n", item.Id, err)
+				continue
+			}
+			sourceIDs = append(sourceIDs, id)
+			if len(sourceIDs) == limit {
+				break
+			}
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		listReq.PageToken = resp.NextPageToken
+	}
+
+	var runs []*pipelinev1.PipelineRun
+	for _, id := range sourceIDs {
+		resp, err := pipelineClient.GetSourceHistory(ctx, &pipelinev1.GetSourceHistoryRequest{SourceId: id})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: getting history for source %d: %v\n", id, err)
+			continue
+		}
+		runs = append(runs, resp.Runs...)
+	}
+
+	report := buildStageTimingsReport(runs, sinceTime)
+	report.SourcesSampled = len(sourceIDs)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	return outputStageTimingsHuman(report)
+}
+
+// resolvePipelineSourceID returns the numeric pipeline source ID for a content
+// item. Content IDs such as "em-…" are not source IDs, so they are looked up
+// through the item's processing status; bare numeric IDs are used as-is.
+func resolvePipelineSourceID(ctx context.Context, client contentv1.ContentProcessorServiceClient, contentID string) (int64, error) {
+	if id, err := strconv.ParseInt(contentID, 10, 64); err == nil {
+		return id, nil
+	}
+	status, err := client.GetProcessingStatus(ctx, &contentv1.GetProcessingStatusRequest{ContentId: contentID})
+	if err != nil {
+		return 0, err
+	}
+	if status.SourceId == 0 {
+		return 0, fmt.Errorf("no pipeline source recorded")
+	}
+	return status.SourceId, nil
+}
+
+// buildStageTimingsReport aggregates run durations per stage. Superseded runs and
+// runs started before since are ignored. Stages are ordered by share of total time.
+func buildStageTimingsReport(runs []*pipelinev1.PipelineRun, since time.Time) StageTimingsReport {
+	durations := make(map[string][]int64)
+	failed := make(map[string]int)
+
+	for _, run := range runs {
+		if run.Status == "superseded" || run.DurationMs <= 0 {
+			continue
+		}
+		if run.CreatedAt != nil && run.CreatedAt.AsTime().Before(since) {
+			continue
+		}
+		durations[run.Stage] = append(durations[run.Stage], run.DurationMs)
+		if run.Status == "failed" {
+			failed[run.Stage]++
+		}
+	}
+
+	report := StageTimingsReport{Since: since, Stages: []StageTiming{}}
+	var grandTotal int64
+	for stage, ds := range durations {
+		sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+		var total int64
+		for _, d := range ds {
+			total += d
+		}
+		grandTotal += total
+		report.Stages = append(report.Stages, StageTiming{
+			Stage:   stage,
+			Runs:    len(ds),
+			Failed:  failed[stage],
+			P50Ms:   percentileMs(ds, 50),
+			P95Ms:   percentileMs(ds, 95),
+			MeanMs:  total / int64(len(ds)),
+			TotalMs: total,
+		})
+	}
+
+	for i := range report.Stages {
+		report.Stages[i].SharePct = float64(report.Stages[i].TotalMs) / float64(grandTotal) * 100
+	}
+	sort.Slice(report.Stages, func(i, j int) bool {
+		if report.Stages[i].TotalMs != report.Stages[j].TotalMs {
+			return report.Stages[i].TotalMs > report.Stages[j].TotalMs
+		}
+		return report.Stages[i].Stage < report.Stages[j].Stage
+	})
+	if len(report.Stages) > 0 {
+		report.Dominant = report.Stages[0].Stage
+	}
+
+	return report
+}
+
+// percentileMs returns the nearest-rank percentile p of sorted durations.
+func percentileMs(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func outputStageTimingsHuman(report StageTimingsReport) error {
+	fmt.Printf("Stage Timings since %s (%d sources sampled)\n", report.Since.Local().Format("2006-01-02 15:04"), report.SourcesSampled)
+	fmt.Println("==============================================")
+	fmt.Println()
+
+	if len(report.Stages) == 0 {
+		fmt.Println("No stage runs found in this window.")
+		return nil
+	}
+
+	fmt.Printf("  %-20s %6s %6s %10s %10s %10s %7s\n", "STAGE", "RUNS", "FAILED", "P50", "P95", "MEAN", "SHARE")
+	for _, s := range report.Stages {
+		color := ""
+		if s.Stage == report.Dominant {
+			color = "\033[33m" // Yellow
+		}
+		fmt.Printf("  %s%-20s %6d %6d %10s %10s %10s %6.1f%%\033[0m\n",
+			color,
+			s.Stage,
+			s.Runs,
+			s.Failed,
+			formatDurationMs(int(s.P50Ms)),
+			formatDurationMs(int(s.P95Ms)),
+			formatDurationMs(int(s.MeanMs)),
+			s.SharePct)
+	}
+
+	fmt.Println()
+	fmt.Printf("Slowest stage: %s (%.0f%% of processing time)\n", report.Dominant, report.Stages[0].SharePct)
+	fmt.Println("Inspect a single source with: penf pipeline stage <source-id>")
+	return nil
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+func newPipelineTailCmd(deps *PipelineCommandDeps) *cobra.Command {
+	var interval time.Duration
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tail <source-id>",
+		Short: "Follow a single source through the pipeline",
+		Long: `Follow a single source as it moves through the pipeline.
+
+Polls the source's processing status and prints a line each time its state or
+current stage changes, until it reaches a terminal state (completed, failed,
+cancelled, rejected, skipped) or you press Ctrl+C.
+
+Exits with an error if the source fails or --timeout elapses first. Transient
+errors (server unavailable, rate limited) are reported and polling continues;
+any other error, such as an unknown source ID or an auth failure, stops
+immediately.
+
+Examples:
+  # Follow a source until it finishes
+  penf pipeline tail content-123
+
+  # Give up after 10 minutes
+  penf pipeline tail content-123 --timeout 10m
+
+  # Poll more often
+  penf pipeline tail content-123 --interval 1s`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			return runPipelineTail(cmd.Context(), deps, args[0], interval, timeout)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Polling interval")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Stop waiting for a terminal state after this long (0 = no limit)")
+
+	return cmd
+}
+
+func runPipelineTail(ctx context.Context, deps *PipelineCommandDeps, sourceID string, interval, timeout time.Duration) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectPipelineToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := contentv1.NewContentProcessorServiceClient(conn)
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	fmt.Printf("Following %s (press Ctrl+C to stop)...\n", sourceID)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	tracker := &sourceTailTracker{}
+	for {
+		status, err := client.GetProcessingStatus(ctx, &contentv1.GetProcessingStatusRequest{
+			ContentId: sourceID,
+		})
+		if err != nil && ctx.Err() == nil {
+			if !isTransientTailError(err) {
+				return fmt.Errorf("getting processing status for %s: %w", sourceID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: getting processing status: %v\n", err)
+		} else if err == nil {
+			if line, changed := tracker.observe(status); changed {
+				fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), line)
+			}
+			if isTerminalProcessingState(status.State) {
+				fmt.Printf("\n%s (%s)\n", tracker.summary(), time.Since(start).Round(time.Second))
+				if status.State == contentv1.ProcessingState_PROCESSING_STATE_FAILED {
+					return fmt.Errorf("source %s failed", sourceID)
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for %s to finish (last state: %s)", timeout, sourceID, tracker.lastState())
+			}
+			fmt.Println("\nStopped following.")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTransientTailError reports whether a status poll failed for a reason that
+// may clear up on the next poll. Anything else, such as an unknown source ID or
+// an auth failure, will fail the same way every time.
+func isTransientTailError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// sourceTailTracker records the state transitions observed for a source.
+type sourceTailTracker struct {
+	states []string
+	stage  string
+	seen   bool
+}
+
+// observe records a status poll. It returns a display line and true when the
+// overall state or the current stage differs from the previous poll.
+func (t *sourceTailTracker) observe(status *contentv1.ProcessingStatus) (string, bool) {
+	state := processingStateName(status.State)
+	stage := currentProcessingStage(status)
+
+	if t.seen && state == t.lastState() && stage == t.stage {
+		return "", false
+	}
+	t.seen = true
+	t.stage = stage
+	if len(t.states) == 0 || t.states[len(t.states)-1] != state {
+		t.states = append(t.states, state)
+	}
+
+	line := formatProcessingState(status.State)
+	if stage != "" {
+		line += fmt.Sprintf("  (stage: %s)", stage)
+	}
+	return line, true
+}
+
+// lastState returns the most recently observed state, or "unknown".
+func (t *sourceTailTracker) lastState() string {
+	if len(t.states) == 0 {
+		return "unknown"
+	}
+	return t.states[len(t.states)-1]
+}
+
+// summary returns the observed state transitions, e.g. "pending → in_progress → completed".
+func (t *sourceTailTracker) summary() string {
+	return strings.Join(t.states, " → ")
+}
+
+// processingStateName returns the lowercase name of a processing state.
+func processingStateName(state contentv1.ProcessingState) string {
+	return strings.ToLower(stripEnumPrefix(state.String(), "PROCESSING_STATE_"))
+}
+
+// currentProcessingStage returns the stage that is currently running, if any.
+func currentProcessingStage(status *contentv1.ProcessingStatus) string {
+	for _, s := range status.Stages {
+		if s.Status == contentv1.StageStatus_STAGE_STATUS_RUNNING {
+			return formatProcessingStage(s.Stage)
+		}
+	}
+	return ""
+}
+
+// isTerminalProcessingState reports whether processing has finished for good.
+func isTerminalProcessingState(state contentv1.ProcessingState) bool {
+	switch state {
+	case contentv1.ProcessingState_PROCESSING_STATE_COMPLETED,
+		contentv1.ProcessingState_PROCESSING_STATE_FAILED,
+		contentv1.ProcessingState_PROCESSING_STATE_CANCELLED,
+		contentv1.ProcessingState_PROCESSING_STATE_REJECTED,
+		contentv1.ProcessingState_PROCESSING_STATE_SKIPPED:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+)
+
+func TestPipelineTailCommand(t *testing.T) {
+	cmd := newPipelineTailCmd(DefaultPipelineDeps())
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("expected error when source ID is missing")
+	}
+	if err := cmd.Args(cmd, []string{"content-123"}); err != nil {
+		t.Errorf("expected single source ID to be accepted: %v", err)
+	}
+	for _, flag := range []string{"interval", "timeout"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag to exist", flag)
+		}
+	}
+}
+
+func TestSourceTailTracker(t *testing.T) {
+	running := func(stage contentv1.ProcessingStage) []*contentv1.StageResult {
+		return []*contentv1.StageResult{{Stage: stage, Status: contentv1.StageStatus_STAGE_STATUS_RUNNING}}
+	}
+	polls := []struct {
+		status      *contentv1.ProcessingStatus
+		wantChanged bool
+	}{
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_PENDING}, true},
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_PENDING}, false},
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_IN_PROGRESS, Stages: running(contentv1.ProcessingStage_PROCESSING_STAGE_TRIAGE)}, true},
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_IN_PROGRESS, Stages: running(contentv1.ProcessingStage_PROCESSING_STAGE_TRIAGE)}, false},
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_IN_PROGRESS, Stages: running(contentv1.ProcessingStage_PROCESSING_STAGE_EMBED)}, true},
+		{&contentv1.ProcessingStatus{State: contentv1.ProcessingState_PROCESSING_STATE_COMPLETED}, true},
+	}
+
+	tracker := &sourceTailTracker{}
+	for i, p := range polls {
+		if _, changed := tracker.observe(p.status); changed != p.wantChanged {
+			t.Errorf("poll %d: changed = %v, want %v", i, changed, p.wantChanged)
+		}
+	}
+
+	if got, want := tracker.summary(), "pending → in_progress → completed"; got != want {
+		t.Errorf("summary() = %q, want %q", got, want)
+	}
+}
+
+func TestIsTerminalProcessingState(t *testing.T) {
+	if isTerminalProcessingState(contentv1.ProcessingState_PROCESSING_STATE_IN_PROGRESS) {
+		t.Error("IN_PROGRESS should not be terminal")
+	}
+	if !isTerminalProcessingState(contentv1.ProcessingState_PROCESSING_STATE_FAILED) {
+		t.Error("FAILED should be terminal")
+	}
+}
+
+func TestIsTransientTailError(t *testing.T) {
+	for _, c := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted} {
+		if !isTransientTailError(status.Error(c, "x")) {
+			t.Errorf("%s should be transient", c)
+		}
+	}
+	for _, c := range []codes.Code{codes.NotFound, codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied} {
+		if isTransientTailError(status.Error(c, "x")) {
+			t.Errorf("%s should not be transient", c)
+		}
+	}
+	if isTransientTailError(errors.New("boom")) {
+		t.Error("a non-gRPC error should not be transient")
+	}
+}
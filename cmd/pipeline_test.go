@@ -2,10 +2,15 @@
 package cmd
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -31,6 +36,11 @@ func TestParseTimeFilter(t *testing.T) {
 			input:     "24h",
 			wantError: false,
 		},
+		{
+			name:      "duration - day suffix",
+			input:     "7d",
+			wantError: false,
+		},
 		{
 			name:      "ISO timestamp",
 			input:     "2026-02-04T10:00:00Z",
@@ -288,3 +298,266 @@ func TestPipelineStatusFlags(t *testing.T) {
 		})
 	}
 }
+
+// TestPipelineStatusWatchRejectsJSON tests that --watch cannot be combined with JSON output.
+func TestPipelineStatusWatchRejectsJSON(t *testing.T) {
+	cmd := newPipelineStatusCmd(DefaultPipelineDeps())
+	cmd.SetArgs([]string{"--watch", "-o", "json"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for --watch with --output json")
+	}
+	if err.Error() != "--watch mode not supported with JSON output" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if f := cmd.Flags().Lookup("interval"); f == nil || f.DefValue != "5s" {
+		t.Error("expected --interval flag defaulting to 5s")
+	}
+}
+
+// TestPendingSourceCount tests extraction of the pending count from pipeline stats.
+func TestPendingSourceCount(t *testing.T) {
+	stats := &pipelinev1.PipelineStats{
+		SourcesByStatus: []*pipelinev1.StatusCount{
+			{Status: "completed", Count: 10},
+			{Status: "pending", Count: 4},
+		},
+	}
+	if got := pendingSourceCount(stats); got != 4 {
+		t.Errorf("pendingSourceCount() = %d, want 4", got)
+	}
+	if got := pendingSourceCount(&pipelinev1.PipelineStats{}); got != 0 {
+		t.Errorf("pendingSourceCount(empty) = %d, want 0", got)
+	}
+}
+
+// TestEstimatePipelineETA tests drain estimation from pending count and recent rate.
+func TestEstimatePipelineETA(t *testing.T) {
+	tests := []struct {
+		name        string
+		pending     int64
+		recent      int64
+		wantStalled bool
+		wantETA     int64
+	}{
+		{name: "nothing pending", pending: 0, recent: 0},
+		{name: "stalled", pending: 50, recent: 0, wantStalled: true},
+		{name: "draining", pending: 90, recent: 40, wantETA: 8100},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := &pipelinev1.PipelineStats{
+				SourcesByStatus:  []*pipelinev1.StatusCount{{Status: "pending", Count: tc.pending}},
+				EmbeddingsRecent: tc.recent,
+			}
+			eta := estimatePipelineETA(stats)
+			if eta.Stalled != tc.wantStalled {
+				t.Errorf("Stalled = %v, want %v", eta.Stalled, tc.wantStalled)
+			}
+			if eta.ETASeconds != tc.wantETA {
+				t.Errorf("ETASeconds = %d, want %d", eta.ETASeconds, tc.wantETA)
+			}
+		})
+	}
+}
+
+// TestFormatETA tests coarse duration formatting for drain estimates.
+func TestFormatETA(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:              "<1m",
+		40 * time.Minute:              "40m",
+		2*time.Hour + 15*time.Minute:  "2h 15m",
+		26*time.Hour + 10*time.Minute: "1d 2h",
+	}
+	for d, want := range tests {
+		if got := formatETA(d); got != want {
+			t.Errorf("formatETA(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+// TestKickWouldQueue tests the dry-run count for 'pipeline kick'.
+func TestKickWouldQueue(t *testing.T) {
+	tests := []struct {
+		pending int64
+		limit   int
+		want    int64
+	}{
+		{pending: 500, limit: 0, want: 500},
+		{pending: 500, limit: 100, want: 100},
+		{pending: 50, limit: 100, want: 50},
+		{pending: 0, limit: 10, want: 0},
+	}
+	for _, tc := range tests {
+		if got := kickWouldQueue(tc.pending, tc.limit); got != tc.want {
+			t.Errorf("kickWouldQueue(%d, %d) = %d, want %d", tc.pending, tc.limit, got, tc.want)
+		}
+	}
+}
+
+// TestPipelineKickDryRunFlags tests the kick command's dry-run flags.
+func TestPipelineKickDryRunFlags(t *testing.T) {
+	cmd := newPipelineKickCmd(DefaultPipelineDeps())
+	for _, name := range []string{"dry-run", "list"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to exist", name)
+		}
+	}
+
+	cmd.SetArgs([]string{"--list"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || err.Error() != "--list requires --dry-run" {
+		t.Errorf("Execute(--list) error = %v, want --list requires --dry-run", err)
+	}
+}
+
+// TestStuckQueues tests filtering queues by the stuck threshold.
+func TestStuckQueues(t *testing.T) {
+	queues := []*pipelinev1.QueueStats{
+		{Name: "embeddings", OldestItemAgeSeconds: 120},
+		{Name: "entities", OldestItemAgeSeconds: 600},
+		{Name: "keywords", OldestItemAgeSeconds: 3600},
+	}
+
+	tests := []struct {
+		threshold time.Duration
+		want      []string
+	}{
+		{5 * time.Minute, []string{"entities", "keywords"}},
+		{time.Minute, []string{"embeddings", "entities", "keywords"}},
+		{30 * time.Minute, []string{"keywords"}},
+		{2 * time.Hour, nil},
+	}
+
+	for _, tc := range tests {
+		got := StuckQueues(queues, tc.threshold)
+		var names []string
+		for _, q := range got {
+			names = append(names, q.Name)
+		}
+		if len(names) != len(tc.want) {
+			t.Errorf("StuckQueues(%v) = %v, want %v", tc.threshold, names, tc.want)
+			continue
+		}
+		for i := range names {
+			if names[i] != tc.want[i] {
+				t.Errorf("StuckQueues(%v) = %v, want %v", tc.threshold, names, tc.want)
+				break
+			}
+		}
+	}
+}
+
+// TestPipelineQueueRequeueFlags tests the queue command's requeue flags.
+func TestPipelineQueueRequeueFlags(t *testing.T) {
+	cmd := newPipelineQueueCmd(DefaultPipelineDeps())
+	for _, name := range []string{"stuck-threshold", "requeue", "confirm"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to exist", name)
+		}
+	}
+
+	cmd.SetArgs([]string{"--requeue"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || err.Error() != "--requeue requires --stuck" {
+		t.Errorf("Execute(--requeue) error = %v, want --requeue requires --stuck", err)
+	}
+}
+
+// requeueClient records RequeueStuckItems requests.
+type requeueClient struct {
+	pipelinev1.PipelineServiceClient
+	unimplemented bool
+	requests      []*pipelinev1.RequeueStuckItemsRequest
+	kicks         []*pipelinev1.KickProcessingRequest
+}
+
+func (c *requeueClient) RequeueStuckItems(ctx context.Context, req *pipelinev1.RequeueStuckItemsRequest, opts ...grpc.CallOption) (*pipelinev1.RequeueStuckItemsResponse, error) {
+	c.requests = append(c.requests, req)
+	if c.unimplemented {
+		return nil, status.Error(codes.Unimplemented, "unknown method RequeueStuckItems")
+	}
+	return &pipelinev1.RequeueStuckItemsResponse{RequeuedCount: int64(req.Limit)}, nil
+}
+
+func (c *requeueClient) KickProcessing(ctx context.Context, req *pipelinev1.KickProcessingRequest, opts ...grpc.CallOption) (*pipelinev1.KickProcessingResponse, error) {
+	c.kicks = append(c.kicks, req)
+	return &pipelinev1.KickProcessingResponse{QueuedCount: int64(req.Limit)}, nil
+}
+
+// TestRequeueStuckQueues tests that --requeue is scoped to the tenant and
+// each stuck stage, and only sends requests with --confirm.
+func TestRequeueStuckQueues(t *testing.T) {
+	queues := []*pipelinev1.QueueStats{
+		{Name: "embeddings", PendingCount: 12, OldestItemAgeSeconds: 900},
+		{Name: "entities", PendingCount: 3, OldestItemAgeSeconds: 600},
+	}
+
+	fake := &requeueClient{}
+	captureStdout(func() {
+		if err := requeueStuckQueues(context.Background(), fake, "tenant-1", queues, 5*time.Minute, false, "text"); err != nil {
+			t.Fatalf("requeueStuckQueues(dry run) error = %v", err)
+		}
+	})
+	if len(fake.requests) != 0 {
+		t.Fatalf("dry run sent %d requests, want 0", len(fake.requests))
+	}
+
+	out := captureStdout(func() {
+		if err := requeueStuckQueues(context.Background(), fake, "tenant-1", queues, 5*time.Minute, true, "text"); err != nil {
+			t.Fatalf("requeueStuckQueues() error = %v", err)
+		}
+	})
+	if len(fake.requests) != 2 {
+		t.Fatalf("sent %d requests, want 2", len(fake.requests))
+	}
+	for i, req := range fake.requests {
+		if req.TenantId != "tenant-1" {
+			t.Errorf("request %d tenant = %q, want tenant-1", i, req.TenantId)
+		}
+		if req.Stage != queues[i].Name {
+			t.Errorf("request %d stage = %q, want %q", i, req.Stage, queues[i].Name)
+		}
+		if req.OlderThanSeconds != 300 {
+			t.Errorf("request %d older_than_seconds = %d, want 300", i, req.OlderThanSeconds)
+		}
+	}
+	if want := "Requeued 15 of 15 stuck items"; !strings.Contains(out, want) {
+		t.Errorf("output = %q, want it to contain %q", out, want)
+	}
+}
+
+// TestRequeueStuckQueues_KickFallback tests that a gateway without the
+// per-stage RPC gets one tenant-wide kick for the stuck items.
+func TestRequeueStuckQueues_KickFallback(t *testing.T) {
+	queues := []*pipelinev1.QueueStats{
+		{Name: "embeddings", PendingCount: 12, OldestItemAgeSeconds: 900},
+		{Name: "entities", PendingCount: 3, OldestItemAgeSeconds: 600},
+	}
+
+	fake := &requeueClient{unimplemented: true}
+	out := captureStdout(func() {
+		if err := requeueStuckQueues(context.Background(), fake, "tenant-1", queues, 5*time.Minute, true, "text"); err != nil {
+			t.Fatalf("requeueStuckQueues() error = %v", err)
+		}
+	})
+	if len(fake.requests) != 1 {
+		t.Errorf("sent %d per-stage requests, want 1 before falling back", len(fake.requests))
+	}
+	if len(fake.kicks) != 1 {
+		t.Fatalf("sent %d kicks, want 1", len(fake.kicks))
+	}
+	if kick := fake.kicks[0]; kick.TenantId != "tenant-1" || kick.Limit != 15 {
+		t.Errorf("kick = {tenant %q, limit %d}, want {tenant-1, 15}", kick.TenantId, kick.Limit)
+	}
+	if want := "Requeued 15 of 15 stuck items"; !strings.Contains(out, want) {
+		t.Errorf("output = %q, want it to contain %q", out, want)
+	}
+}
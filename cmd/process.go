@@ -148,8 +148,17 @@ Example workflow:
   penf process mentions batch-resolve '{"resolutions":[...]}'
   penf process onboarding batch '{"confirm_people":[...]}'
 
+Throughput:
+  Batch commands pace their requests to the gateway with a token bucket
+  (--rate-limit requests per minute, default 60) and send at most
+  --batch-size items per batch request (default 20). When the gateway
+  reports it is rate limited, they back off, lower the rate and warn.
+
+  penf process mentions batch-resolve --batch-size 50 --rate-limit 120 '{...}'
+
 Workflows are documented in Context Palace knowledge shards.`,
 	}
+	addProcessThroughputFlags(cmd)
 
 	// Add workflow subcommands.
 	cmd.AddCommand(newProcessAcronymsCommand(deps))
@@ -247,7 +256,7 @@ Example:
 
 	cmd.Flags().BoolVar(&processDryRun, "dry-run", false, "Preview changes without executing them")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runAcronymsContext executes the context command.
@@ -390,6 +399,7 @@ func runAcronymsContext(ctx context.Context, deps *ProcessCommandDeps) error {
 
 // runAcronymsBatchResolve executes the batch-resolve command.
 func runAcronymsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, jsonInput string) error {
+	w := resultOut()
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -401,30 +411,34 @@ func runAcronymsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, json
 		return fmt.Errorf("parsing JSON input: %w", err)
 	}
 
+	if err := validateProcessThroughputFlags(); err != nil {
+		return err
+	}
+
 	// Dry-run mode: preview changes without executing.
 	if processDryRun {
-		fmt.Println("\033[1m=== DRY RUN - No changes will be made ===\033[0m")
-		fmt.Println()
+		fmt.Fprintln(w, "\033[1m=== DRY RUN - No changes will be made ===\033[0m")
+		fmt.Fprintln(w)
 
 		if len(req.Resolutions) > 0 {
-			fmt.Printf("Would resolve %d acronyms:\n", len(req.Resolutions))
+			fmt.Fprintf(w, "Would resolve %d acronyms:\n", len(req.Resolutions))
 			for _, r := range req.Resolutions {
-				fmt.Printf("  \033[32m#%d:\033[0m %s\n", r.ID, r.Expansion)
+				fmt.Fprintf(w, "  \033[32m#%d:\033[0m %s\n", r.ID, r.Expansion)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.Dismissals) > 0 {
-			fmt.Printf("Would dismiss %d items:\n", len(req.Dismissals))
+			fmt.Fprintf(w, "Would dismiss %d items:\n", len(req.Dismissals))
 			for _, d := range req.Dismissals {
-				fmt.Printf("  \033[33m#%d:\033[0m %s\n", d.ID, d.Reason)
+				fmt.Fprintf(w, "  \033[33m#%d:\033[0m %s\n", d.ID, d.Reason)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
-		fmt.Printf("Summary: %d resolutions, %d dismissals\n", len(req.Resolutions), len(req.Dismissals))
-		fmt.Println("\n\033[2mRun without --dry-run to apply these changes.\033[0m")
-		return nil
+		fmt.Fprintf(w, "Summary: %d resolutions, %d dismissals\n", len(req.Resolutions), len(req.Dismissals))
+		fmt.Fprintln(w, "\n\033[2mRun without --dry-run to apply these changes.\033[0m")
+		return writeResult(req, "dry run: no changes made")
 	}
 
 	conn, err := connectToProcessGateway(cfg)
@@ -434,58 +448,65 @@ func runAcronymsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, json
 	defer conn.Close()
 
 	questionsClient := questionsv1.NewQuestionsServiceClient(conn)
+	limiter := newProcessLimiter(processRateLimit)
 
 	var result BatchResolveResult
 	var errors []string
 
 	// Process resolutions.
 	for _, r := range req.Resolutions {
-		_, err := questionsClient.ResolveQuestion(ctx, &questionsv1.ResolveQuestionRequest{
-			Id:     r.ID,
-			Answer: r.Expansion,
+		err := limiter.Do(ctx, fmt.Sprintf("resolve %d", r.ID), func(ctx context.Context) error {
+			_, err := questionsClient.ResolveQuestion(ctx, &questionsv1.ResolveQuestionRequest{
+				Id:     r.ID,
+				Answer: r.Expansion,
+			})
+			return err
 		})
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("resolve %d: %v", r.ID, err))
 		} else {
 			result.Resolved++
-			fmt.Printf("\033[32mResolved #%d:\033[0m %s\n", r.ID, r.Expansion)
+			fmt.Fprintf(w, "\033[32mResolved #%d:\033[0m %s\n", r.ID, r.Expansion)
 		}
 	}
 
 	// Process dismissals.
 	for _, d := range req.Dismissals {
-		_, err := questionsClient.DismissQuestion(ctx, &questionsv1.DismissQuestionRequest{
-			Id:     d.ID,
-			Reason: d.Reason,
+		err := limiter.Do(ctx, fmt.Sprintf("dismiss %d", d.ID), func(ctx context.Context) error {
+			_, err := questionsClient.DismissQuestion(ctx, &questionsv1.DismissQuestionRequest{
+				Id:     d.ID,
+				Reason: d.Reason,
+			})
+			return err
 		})
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("dismiss %d: %v", d.ID, err))
 		} else {
 			result.Dismissed++
-			fmt.Printf("\033[33mDismissed #%d:\033[0m %s\n", d.ID, d.Reason)
+			fmt.Fprintf(w, "\033[33mDismissed #%d:\033[0m %s\n", d.ID, d.Reason)
 		}
 	}
 
 	result.Errors = errors
 
 	// Summary.
-	fmt.Println()
-	fmt.Printf("Batch complete: %d resolved, %d dismissed", result.Resolved, result.Dismissed)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Batch complete: %d resolved, %d dismissed", result.Resolved, result.Dismissed)
 	if len(errors) > 0 {
-		fmt.Printf(", %d errors\n", len(errors))
+		fmt.Fprintf(w, ", %d errors\n", len(errors))
 		for _, e := range errors {
-			fmt.Printf("  \033[31mError:\033[0m %s\n", e)
+			fmt.Fprintf(w, "  \033[31mError:\033[0m %s\n", e)
 		}
 	} else {
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
-	return nil
+	return writeOutcome(len(errors) == 0, result, "")
 }
 
 // connectToProcessGateway creates a gRPC connection to the gateway.
 func connectToProcessGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -508,9 +529,11 @@ func connectToProcessGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
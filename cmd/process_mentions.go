@@ -138,7 +138,7 @@ Example:
 
 	cmd.Flags().BoolVar(&mentionProcessDryRun, "dry-run", false, "Preview changes without executing them")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runMentionsContext executes the context command.
@@ -186,6 +186,7 @@ func runMentionsContext(ctx context.Context, deps *ProcessCommandDeps) error {
 
 // runMentionsBatchResolve executes the batch-resolve command.
 func runMentionsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, jsonInput string) error {
+	w := resultOut()
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -197,43 +198,47 @@ func runMentionsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, json
 		return fmt.Errorf("parsing JSON input: %w", err)
 	}
 
+	if err := validateProcessThroughputFlags(); err != nil {
+		return err
+	}
+
 	// Dry-run mode: preview changes without executing
 	if mentionProcessDryRun {
-		fmt.Println("\033[1m=== DRY RUN - No changes will be made ===\033[0m")
-		fmt.Println()
+		fmt.Fprintln(w, "\033[1m=== DRY RUN - No changes will be made ===\033[0m")
+		fmt.Fprintln(w)
 
 		if len(req.Resolutions) > 0 {
-			fmt.Printf("Would resolve %d mentions:\n", len(req.Resolutions))
+			fmt.Fprintf(w, "Would resolve %d mentions:\n", len(req.Resolutions))
 			for _, r := range req.Resolutions {
 				pattern := ""
 				if r.CreatePattern {
 					pattern = " (+ pattern)"
 				}
-				fmt.Printf("  \033[32m#%d → %s:%d%s\033[0m\n", r.MentionId, r.EntityType.String(), r.EntityId, pattern)
+				fmt.Fprintf(w, "  \033[32m#%d → %s:%d%s\033[0m\n", r.MentionId, r.EntityType.String(), r.EntityId, pattern)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.NewPatterns) > 0 {
-			fmt.Printf("Would create %d patterns:\n", len(req.NewPatterns))
+			fmt.Fprintf(w, "Would create %d patterns:\n", len(req.NewPatterns))
 			for _, p := range req.NewPatterns {
-				fmt.Printf("  \033[34m\"%s\" → %s:%d\033[0m\n", p.MentionText, p.EntityType.String(), p.EntityId)
+				fmt.Fprintf(w, "  \033[34m\"%s\" → %s:%d\033[0m\n", p.MentionText, p.EntityType.String(), p.EntityId)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.Dismissals) > 0 {
-			fmt.Printf("Would dismiss %d mentions:\n", len(req.Dismissals))
+			fmt.Fprintf(w, "Would dismiss %d mentions:\n", len(req.Dismissals))
 			for _, d := range req.Dismissals {
-				fmt.Printf("  \033[33m#%d:\033[0m %s\n", d.MentionId, d.Reason)
+				fmt.Fprintf(w, "  \033[33m#%d:\033[0m %s\n", d.MentionId, d.Reason)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
-		fmt.Printf("Summary: %d resolutions, %d patterns, %d dismissals\n",
+		fmt.Fprintf(w, "Summary: %d resolutions, %d patterns, %d dismissals\n",
 			len(req.Resolutions), len(req.NewPatterns), len(req.Dismissals))
-		fmt.Println("\n\033[2mRun without --dry-run to apply these changes.\033[0m")
-		return nil
+		fmt.Fprintln(w, "\n\033[2mRun without --dry-run to apply these changes.\033[0m")
+		return writeResult(&req, "dry run: no changes made")
 	}
 
 	conn, err := connectToMentionsGateway(cfg)
@@ -243,31 +248,50 @@ func runMentionsBatchResolve(ctx context.Context, deps *ProcessCommandDeps, json
 	defer conn.Close()
 
 	client := mentionsv1.NewMentionsServiceClient(conn)
-
-	resp, err := client.BatchResolveMentions(ctx, &req)
-	if err != nil {
-		return fmt.Errorf("batch resolving mentions: %w", err)
+	limiter := newProcessLimiter(processRateLimit)
+
+	// Send the batch in requests of at most --batch-size items.
+	resp := &mentionsv1.BatchResolveMentionsResponse{}
+	batches := splitMentionBatch(&req, processBatchSize)
+	for i, batch := range batches {
+		var batchResp *mentionsv1.BatchResolveMentionsResponse
+		err := limiter.Do(ctx, fmt.Sprintf("batch %d of %d", i+1, len(batches)), func(ctx context.Context) error {
+			var err error
+			batchResp, err = client.BatchResolveMentions(ctx, batch)
+			return err
+		})
+		if err != nil {
+			if resp.Resolved+resp.PatternsCreated+resp.Dismissed > 0 {
+				fmt.Fprintf(os.Stderr, "Applied before the failure: %d resolved, %d patterns, %d dismissed\n",
+					resp.Resolved, resp.PatternsCreated, resp.Dismissed)
+			}
+			return fmt.Errorf("batch resolving mentions (batch %d of %d): %w", i+1, len(batches), err)
+		}
+		resp.Resolved += batchResp.Resolved
+		resp.PatternsCreated += batchResp.PatternsCreated
+		resp.Dismissed += batchResp.Dismissed
+		resp.Errors = append(resp.Errors, batchResp.Errors...)
 	}
 
 	// Output results
-	fmt.Println()
-	fmt.Printf("Batch complete: %d resolved, %d patterns, %d dismissed",
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Batch complete: %d resolved, %d patterns, %d dismissed",
 		resp.Resolved, resp.PatternsCreated, resp.Dismissed)
 	if len(resp.Errors) > 0 {
-		fmt.Printf(", %d errors\n", len(resp.Errors))
+		fmt.Fprintf(w, ", %d errors\n", len(resp.Errors))
 		for _, e := range resp.Errors {
-			fmt.Printf("  \033[31mError:\033[0m %s\n", e)
+			fmt.Fprintf(w, "  \033[31mError:\033[0m %s\n", e)
 		}
 	} else {
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
-	return nil
+	return writeOutcome(len(resp.Errors) == 0, resp, "")
 }
 
 // connectToMentionsGateway creates a gRPC connection to the gateway.
 func connectToMentionsGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -290,9 +314,11 @@ func connectToMentionsGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -560,11 +586,12 @@ Examples:
 
 	cmd.MarkFlagRequired("entity-type")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runMentionsResolve executes the resolve command.
 func runMentionsResolve(ctx context.Context, deps *ProcessCommandDeps, mentionIDStr, entityIDStr string) error {
+	w := resultOut()
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -611,15 +638,15 @@ func runMentionsResolve(ctx context.Context, deps *ProcessCommandDeps, mentionID
 
 	// Output result
 	if resp.Resolved {
-		fmt.Printf("✓ Mention #%d resolved to entity %d\n", mentionID, entityID)
+		fmt.Fprintf(w, "✓ Mention #%d resolved to entity %d\n", mentionID, entityID)
 		if resp.PatternCreated {
-			fmt.Println("✓ Pattern created for future auto-resolution")
+			fmt.Fprintln(w, "✓ Pattern created for future auto-resolution")
 		}
 	} else {
-		fmt.Printf("✗ Failed to resolve mention #%d\n", mentionID)
+		fmt.Fprintf(w, "✗ Failed to resolve mention #%d\n", mentionID)
 	}
 
-	return nil
+	return writeOutcome(resp.Resolved, resp, "")
 }
 
 // =============================================================================
@@ -645,11 +672,12 @@ Examples:
 	cmd.Flags().StringVar(&mentionDismissReason, "reason", "", "Reason for dismissal (required)")
 	cmd.MarkFlagRequired("reason")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runMentionsDismiss executes the dismiss command.
 func runMentionsDismiss(ctx context.Context, deps *ProcessCommandDeps, mentionIDStr string) error {
+	w := resultOut()
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -685,12 +713,12 @@ func runMentionsDismiss(ctx context.Context, deps *ProcessCommandDeps, mentionID
 
 	// Output result
 	if resp.Dismissed {
-		fmt.Printf("✓ Mention #%d dismissed: %s\n", mentionID, mentionDismissReason)
+		fmt.Fprintf(w, "✓ Mention #%d dismissed: %s\n", mentionID, mentionDismissReason)
 	} else {
-		fmt.Printf("✗ Failed to dismiss mention #%d\n", mentionID)
+		fmt.Fprintf(w, "✗ Failed to dismiss mention #%d\n", mentionID)
 	}
 
-	return nil
+	return writeOutcome(resp.Dismissed, resp, "")
 }
 
 // =============================================================================
@@ -922,24 +950,24 @@ func outputMentionStatsText(stats *mentionsv1.MentionStats) error {
 
 	if len(stats.ByStatus) > 0 {
 		fmt.Printf("By Status:\n")
-		for status, count := range stats.ByStatus {
-			fmt.Printf("  %-25s %d\n", status, count)
+		for _, status := range SortedStatuses(stats.ByStatus) {
+			fmt.Printf("  %-25s %d\n", status, stats.ByStatus[status])
 		}
 		fmt.Println()
 	}
 
 	if len(stats.ByEntityType) > 0 {
 		fmt.Printf("By Entity Type:\n")
-		for entityType, count := range stats.ByEntityType {
-			fmt.Printf("  %-25s %d\n", entityType, count)
+		for _, entityType := range orderedKeys(stats.ByEntityType, nil) {
+			fmt.Printf("  %-25s %d\n", entityType, stats.ByEntityType[entityType])
 		}
 		fmt.Println()
 	}
 
 	if len(stats.ByContentType) > 0 {
 		fmt.Printf("By Content Type:\n")
-		for contentType, count := range stats.ByContentType {
-			fmt.Printf("  %-25s %d\n", contentType, count)
+		for _, contentType := range orderedKeys(stats.ByContentType, nil) {
+			fmt.Printf("  %-25s %d\n", contentType, stats.ByContentType[contentType])
 		}
 		fmt.Println()
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
@@ -20,8 +21,11 @@ import (
 
 // Onboarding command flags.
 var (
-	onboardingOutput   string
-	onboardingCategory string
+	onboardingOutput    string
+	onboardingCategory  string
+	onboardingResume    bool
+	onboardingRestart   bool
+	onboardingStatePath string
 )
 
 // OnboardingContext represents the full context for post-import review.
@@ -32,6 +36,7 @@ type OnboardingContext struct {
 	UnresolvedMentions  []OnboardingMention   `json:"unresolved_mentions,omitempty"`
 	PotentialDuplicates []OnboardingDuplicate `json:"potential_duplicates,omitempty"`
 	Workflow            OnboardingWorkflow    `json:"workflow"`
+	Progress            *OnboardingProgress   `json:"progress,omitempty"`
 }
 
 // OnboardingSummary provides counts for each category.
@@ -146,10 +151,21 @@ Returns:
 This single command provides everything Claude needs to guide you through
 reviewing and confirming the entities discovered during import.
 
+Progress is saved to a state file (default ~/.penf/onboarding-state.json).
+The first run starts a review: the acronyms found are grouped into batches
+of --batch-size items, and 'onboarding batch' records the acronyms it
+resolves or dismisses. Other categories are listed but not tracked, as
+their batch actions are not recorded. With --resume, acronyms already
+processed are left out and the output shows overall progress (X of Y
+batches); new acronyms found since the review started are added to the end.
+A saved review is never overwritten unless --restart is given.
+
 Examples:
   penf process onboarding context
   penf process onboarding context --output json
-  penf process onboarding context --category acronyms`,
+  penf process onboarding context --category acronyms
+  penf process onboarding context --resume --output text
+  penf process onboarding context --restart`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runOnboardingContext(cmd.Context(), deps)
 		},
@@ -157,6 +173,10 @@ Examples:
 
 	cmd.Flags().StringVarP(&onboardingOutput, "output", "o", "json", "Output format: json, text")
 	cmd.Flags().StringVar(&onboardingCategory, "category", "", "Filter to specific category: people, acronyms, mentions, duplicates")
+	cmd.Flags().BoolVar(&onboardingResume, "resume", false, "Resume the saved review, skipping items already processed")
+	cmd.Flags().BoolVar(&onboardingRestart, "restart", false, "Discard the saved review and start a new one")
+	cmd.Flags().StringVar(&onboardingStatePath, "state", "", "Onboarding state file (default ~/.penf/onboarding-state.json)")
+	cmd.MarkFlagsMutuallyExclusive("resume", "restart")
 
 	return cmd
 }
@@ -190,9 +210,15 @@ Accepts JSON with actions for each category:
 
 Use --dry-run to preview changes without executing them.
 
+Processed items are recorded in the onboarding state file started by
+'onboarding context', so the review can be resumed. With --resume, actions
+on items already processed are skipped, so an interrupted batch can be
+re-run as is.
+
 Example:
   penf process onboarding batch '{"confirm_people":[12,14]}'
-  penf process onboarding batch --dry-run '{"merge_people":[...]}'`,
+  penf process onboarding batch --dry-run '{"merge_people":[...]}'
+  penf process onboarding batch --resume '{"acronym_resolutions":[...]}'`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runOnboardingBatch(cmd.Context(), deps, args[0])
@@ -200,8 +226,10 @@ Example:
 	}
 
 	cmd.Flags().BoolVar(&processDryRun, "dry-run", false, "Preview changes without executing them")
+	cmd.Flags().BoolVar(&onboardingResume, "resume", false, "Skip actions on items already processed")
+	cmd.Flags().StringVar(&onboardingStatePath, "state", "", "Onboarding state file (default ~/.penf/onboarding-state.json)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runOnboardingContext executes the context command.
@@ -279,12 +307,110 @@ func runOnboardingContext(ctx context.Context, deps *ProcessCommandDeps) error {
 		}
 	}
 
+	statePath, err := resolveOnboardingStatePath(onboardingStatePath)
+	if err != nil {
+		return err
+	}
+	if err := trackOnboardingProgress(&result, statePath, onboardingResume, onboardingRestart, processBatchSize, time.Now()); err != nil {
+		return err
+	}
+
 	// Output
 	return outputOnboardingContext(onboardingOutput, result)
 }
 
+// onboardingContextItems returns the state keys of the items in result that
+// progress is tracked for. Only acronyms are: they are the only items whose
+// batch actions 'onboarding batch' records as processed.
+func onboardingContextItems(result *OnboardingContext) []string {
+	var items []string
+	for _, a := range result.NewAcronyms {
+		items = append(items, onboardingItemKey("acronyms", a.ID))
+	}
+	return items
+}
+
+// trackOnboardingProgress starts a new review in the state file, or with
+// resume continues the saved one, leaving processed items out of result.
+// An existing review is only replaced when restart is set. It sets
+// result.Progress.
+func trackOnboardingProgress(result *OnboardingContext, statePath string, resume, restart bool, batchSize int, now time.Time) error {
+	items := onboardingContextItems(result)
+
+	state, err := loadOnboardingState(statePath)
+	if err != nil && !restart {
+		return err
+	}
+	if resume {
+		if state == nil {
+			return fmt.Errorf("no onboarding review to resume at %s: run without --resume to start one", statePath)
+		}
+		state.addItems(items)
+		state.UpdatedAt = now
+		filterProcessedOnboardingItems(result, state)
+	} else {
+		if state != nil && !restart {
+			p := state.progress()
+			return fmt.Errorf("an onboarding review is in progress at %s (%d of %d items processed): use --resume to continue it or --restart to discard it",
+				statePath, p.ItemsDone, p.ItemsTotal)
+		}
+		if batchSize <= 0 {
+			return fmt.Errorf("--batch-size must be positive")
+		}
+		state = newOnboardingState(items, batchSize, now)
+	}
+
+	if err := state.save(statePath); err != nil {
+		return err
+	}
+	progress := state.progress()
+	progress.Resumed = resume
+	progress.StatePath = statePath
+	result.Progress = &progress
+	return nil
+}
+
+// filterProcessedOnboardingItems removes the acronyms state has recorded as
+// processed from result and updates the summary count.
+func filterProcessedOnboardingItems(result *OnboardingContext, state *onboardingState) {
+	var acronyms []OnboardingAcronym
+	for _, a := range result.NewAcronyms {
+		if !state.isProcessed(onboardingItemKey("acronyms", a.ID)) {
+			acronyms = append(acronyms, a)
+		}
+	}
+
+	result.NewAcronyms = acronyms
+	result.Summary.NewAcronyms = len(acronyms)
+}
+
+// skipProcessedOnboardingActions removes the acronym actions on items state
+// has recorded as processed, and returns how many were removed.
+func skipProcessedOnboardingActions(req *OnboardingBatchRequest, state *onboardingState) int {
+	skipped := 0
+	var resolutions []AcronymResolution
+	for _, r := range req.AcronymResolutions {
+		if state.isProcessed(onboardingItemKey("acronyms", r.ID)) {
+			skipped++
+			continue
+		}
+		resolutions = append(resolutions, r)
+	}
+	var dismissals []AcronymDismissal
+	for _, d := range req.AcronymDismissals {
+		if state.isProcessed(onboardingItemKey("acronyms", d.ID)) {
+			skipped++
+			continue
+		}
+		dismissals = append(dismissals, d)
+	}
+	req.AcronymResolutions, req.AcronymDismissals = resolutions, dismissals
+	return skipped
+}
+
 // runOnboardingBatch executes the batch command.
 func runOnboardingBatch(ctx context.Context, deps *ProcessCommandDeps, jsonInput string) error {
+	w := resultOut()
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -295,63 +421,83 @@ func runOnboardingBatch(ctx context.Context, deps *ProcessCommandDeps, jsonInput
 	if err := json.Unmarshal([]byte(jsonInput), &req); err != nil {
 		return fmt.Errorf("parsing JSON input: %w", err)
 	}
+	if err := validateProcessThroughputFlags(); err != nil {
+		return err
+	}
+
+	statePath, err := resolveOnboardingStatePath(onboardingStatePath)
+	if err != nil {
+		return err
+	}
+	state, err := loadOnboardingState(statePath)
+	if err != nil {
+		return err
+	}
+	if onboardingResume {
+		if state == nil {
+			return fmt.Errorf("no onboarding review to resume at %s: run 'penf process onboarding context' to start one", statePath)
+		}
+		if skipped := skipProcessedOnboardingActions(&req, state); skipped > 0 {
+			fmt.Fprintf(w, "Skipping %d actions on items already processed\n", skipped)
+		}
+	}
 
 	// Dry-run mode
 	if processDryRun {
-		fmt.Println("\033[1m=== DRY RUN - No changes will be made ===\033[0m")
-		fmt.Println()
+		fmt.Fprintln(w, "\033[1m=== DRY RUN - No changes will be made ===\033[0m")
+		fmt.Fprintln(w)
 
 		if len(req.MergePeople) > 0 {
-			fmt.Printf("Would merge %d people:\n", len(req.MergePeople))
+			fmt.Fprintf(w, "Would merge %d people:\n", len(req.MergePeople))
 			for _, m := range req.MergePeople {
-				fmt.Printf("  Merge #%d into #%d\n", m.MergeID, m.KeepID)
+				fmt.Fprintf(w, "  Merge #%d into #%d\n", m.MergeID, m.KeepID)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.ConfirmPeople) > 0 {
-			fmt.Printf("Would confirm %d people: %v\n", len(req.ConfirmPeople), req.ConfirmPeople)
-			fmt.Println()
+			fmt.Fprintf(w, "Would confirm %d people: %v\n", len(req.ConfirmPeople), req.ConfirmPeople)
+			fmt.Fprintln(w)
 		}
 
 		if len(req.AcronymResolutions) > 0 {
-			fmt.Printf("Would resolve %d acronyms:\n", len(req.AcronymResolutions))
+			fmt.Fprintf(w, "Would resolve %d acronyms:\n", len(req.AcronymResolutions))
 			for _, r := range req.AcronymResolutions {
-				fmt.Printf("  #%d: %s\n", r.ID, r.Expansion)
+				fmt.Fprintf(w, "  #%d: %s\n", r.ID, r.Expansion)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.AcronymDismissals) > 0 {
-			fmt.Printf("Would dismiss %d acronyms:\n", len(req.AcronymDismissals))
+			fmt.Fprintf(w, "Would dismiss %d acronyms:\n", len(req.AcronymDismissals))
 			for _, d := range req.AcronymDismissals {
-				fmt.Printf("  #%d: %s\n", d.ID, d.Reason)
+				fmt.Fprintf(w, "  #%d: %s\n", d.ID, d.Reason)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.MentionResolutions) > 0 {
-			fmt.Printf("Would resolve %d mentions:\n", len(req.MentionResolutions))
+			fmt.Fprintf(w, "Would resolve %d mentions:\n", len(req.MentionResolutions))
 			for _, r := range req.MentionResolutions {
 				pattern := ""
 				if r.CreatePattern {
 					pattern = " (+ pattern)"
 				}
-				fmt.Printf("  #%d → person #%d%s\n", r.MentionID, r.PersonID, pattern)
+				fmt.Fprintf(w, "  #%d → person #%d%s\n", r.MentionID, r.PersonID, pattern)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
 		if len(req.MentionDismissals) > 0 {
-			fmt.Printf("Would dismiss %d mentions:\n", len(req.MentionDismissals))
+			fmt.Fprintf(w, "Would dismiss %d mentions:\n", len(req.MentionDismissals))
 			for _, d := range req.MentionDismissals {
-				fmt.Printf("  #%d: %s\n", d.MentionID, d.Reason)
+				fmt.Fprintf(w, "  #%d: %s\n", d.MentionID, d.Reason)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 
-		fmt.Println("\033[2mRun without --dry-run to apply these changes.\033[0m")
-		return nil
+		fmt.Fprintln(w, "\033[2mRun without --dry-run to apply these changes.\033[0m")
+		return writeResult(req, "dry run: no changes made")
 	}
 
 	conn, err := connectToOnboardingGateway(cfg)
@@ -365,61 +511,84 @@ func runOnboardingBatch(ctx context.Context, deps *ProcessCommandDeps, jsonInput
 	// Process acronym resolutions
 	if len(req.AcronymResolutions) > 0 || len(req.AcronymDismissals) > 0 {
 		questionsClient := questionsv1.NewQuestionsServiceClient(conn)
+		limiter := newProcessLimiter(processRateLimit)
 
 		for _, r := range req.AcronymResolutions {
-			_, err := questionsClient.ResolveQuestion(ctx, &questionsv1.ResolveQuestionRequest{
-				Id:     r.ID,
-				Answer: r.Expansion,
+			err := limiter.Do(ctx, fmt.Sprintf("resolve acronym %d", r.ID), func(ctx context.Context) error {
+				_, err := questionsClient.ResolveQuestion(ctx, &questionsv1.ResolveQuestionRequest{
+					Id:     r.ID,
+					Answer: r.Expansion,
+				})
+				return err
 			})
 			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("resolve acronym %d: %v", r.ID, err))
 			} else {
 				result.AcronymsResolved++
-				fmt.Printf("\033[32m✓\033[0m Resolved acronym #%d: %s\n", r.ID, r.Expansion)
+				if state != nil {
+					state.record(onboardingItemKey("acronyms", r.ID), time.Now())
+				}
+				fmt.Fprintf(w, "\033[32m✓\033[0m Resolved acronym #%d: %s\n", r.ID, r.Expansion)
 			}
 		}
 
 		for _, d := range req.AcronymDismissals {
-			_, err := questionsClient.DismissQuestion(ctx, &questionsv1.DismissQuestionRequest{
-				Id:     d.ID,
-				Reason: d.Reason,
+			err := limiter.Do(ctx, fmt.Sprintf("dismiss acronym %d", d.ID), func(ctx context.Context) error {
+				_, err := questionsClient.DismissQuestion(ctx, &questionsv1.DismissQuestionRequest{
+					Id:     d.ID,
+					Reason: d.Reason,
+				})
+				return err
 			})
 			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("dismiss acronym %d: %v", d.ID, err))
 			} else {
 				result.AcronymsDismissed++
-				fmt.Printf("\033[33m✓\033[0m Dismissed acronym #%d: %s\n", d.ID, d.Reason)
+				if state != nil {
+					state.record(onboardingItemKey("acronyms", d.ID), time.Now())
+				}
+				fmt.Fprintf(w, "\033[33m✓\033[0m Dismissed acronym #%d: %s\n", d.ID, d.Reason)
 			}
 		}
 	}
 
 	// People and mentions operations would go here when services are available
 	if len(req.MergePeople) > 0 {
-		fmt.Printf("\033[33m⚠\033[0m People merge requires service support (coming soon)\n")
+		fmt.Fprintf(w, "\033[33m⚠\033[0m People merge requires service support (coming soon)\n")
 	}
 
 	if len(req.ConfirmPeople) > 0 {
-		fmt.Printf("\033[33m⚠\033[0m People confirm requires service support (coming soon)\n")
+		fmt.Fprintf(w, "\033[33m⚠\033[0m People confirm requires service support (coming soon)\n")
 	}
 
 	if len(req.MentionResolutions) > 0 || len(req.MentionDismissals) > 0 {
-		fmt.Printf("\033[33m⚠\033[0m Mention resolution requires service support (coming soon)\n")
+		fmt.Fprintf(w, "\033[33m⚠\033[0m Mention resolution requires service support (coming soon)\n")
 	}
 
 	// Summary
-	fmt.Println()
-	fmt.Printf("Batch complete: %d acronyms resolved, %d dismissed",
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Batch complete: %d acronyms resolved, %d dismissed",
 		result.AcronymsResolved, result.AcronymsDismissed)
 	if len(result.Errors) > 0 {
-		fmt.Printf(", %d errors\n", len(result.Errors))
+		fmt.Fprintf(w, ", %d errors\n", len(result.Errors))
 		for _, e := range result.Errors {
-			fmt.Printf("  \033[31mError:\033[0m %s\n", e)
+			fmt.Fprintf(w, "  \033[31mError:\033[0m %s\n", e)
 		}
 	} else {
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
-	return nil
+	if state != nil {
+		if err := state.save(statePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save onboarding progress: %v\n", err)
+		}
+		p := state.progress()
+		p.StatePath = statePath
+		result.Progress = &p
+		fmt.Fprintf(w, "Onboarding progress: %d of %d batches (%d of %d items)\n", p.BatchesDone, p.BatchesTotal, p.ItemsDone, p.ItemsTotal)
+	}
+
+	return writeOutcome(len(result.Errors) == 0, result, "")
 }
 
 // OnboardingBatchRequest represents a batch of onboarding actions.
@@ -472,11 +641,14 @@ type OnboardingBatchResult struct {
 	MentionsResolved  int      `json:"mentions_resolved"`
 	MentionsDismissed int      `json:"mentions_dismissed"`
 	Errors            []string `json:"errors,omitempty"`
+
+	// Progress is set when an onboarding review is being tracked.
+	Progress *OnboardingProgress `json:"progress,omitempty"`
 }
 
 // connectToOnboardingGateway creates a gRPC connection to the gateway.
 func connectToOnboardingGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -499,9 +671,11 @@ func connectToOnboardingGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error)
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -536,6 +710,11 @@ func outputOnboardingContextText(ctx OnboardingContext) error {
 	fmt.Printf("  Potential duplicates: %d\n", ctx.Summary.PotentialDuplicates)
 	fmt.Println()
 
+	if p := ctx.Progress; p != nil {
+		fmt.Printf("Progress: %d of %d batches (%d of %d acronyms processed)\n", p.BatchesDone, p.BatchesTotal, p.ItemsDone, p.ItemsTotal)
+		fmt.Println()
+	}
+
 	if len(ctx.NewAcronyms) > 0 {
 		fmt.Printf("Unknown Acronyms (%d):\n", len(ctx.NewAcronyms))
 		for _, a := range ctx.NewAcronyms {
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// onboardingStateFile is the state file name used under the config dir.
+const onboardingStateFile = "onboarding-state.json"
+
+// onboardingState records an onboarding review: the acronyms to review, in
+// review order, and those already processed by 'onboarding batch'. It lets
+// an interrupted review be resumed.
+type onboardingState struct {
+	StartedAt time.Time            `json:"started_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	BatchSize int                  `json:"batch_size"`
+	Items     []string             `json:"items"`
+	Processed map[string]time.Time `json:"processed"`
+}
+
+// OnboardingProgress is the progress of an onboarding review. Items are
+// grouped into batches of BatchSize in review order; a batch is done when
+// all its items are processed.
+type OnboardingProgress struct {
+	BatchesDone  int    `json:"batches_done"`
+	BatchesTotal int    `json:"batches_total"`
+	ItemsDone    int    `json:"items_done"`
+	ItemsTotal   int    `json:"items_total"`
+	BatchSize    int    `json:"batch_size"`
+	Resumed      bool   `json:"resumed"`
+	StatePath    string `json:"state_path"`
+}
+
+// onboardingItemKey identifies a review item in the state, e.g. "acronyms:123".
+func onboardingItemKey(category string, id int64) string {
+	return fmt.Sprintf("%s:%d", category, id)
+}
+
+// defaultOnboardingStatePath returns the default state path under ~/.penf/.
+func defaultOnboardingStatePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, onboardingStateFile), nil
+}
+
+// resolveOnboardingStatePath returns path, or the default path if it is empty.
+func resolveOnboardingStatePath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	path, err := defaultOnboardingStatePath()
+	if err != nil {
+		return "", fmt.Errorf("resolving onboarding state path: %w", err)
+	}
+	return path, nil
+}
+
+// newOnboardingState starts a review of items.
+func newOnboardingState(items []string, batchSize int, now time.Time) *onboardingState {
+	return &onboardingState{
+		StartedAt: now,
+		UpdatedAt: now,
+		BatchSize: batchSize,
+		Items:     items,
+		Processed: map[string]time.Time{},
+	}
+}
+
+// loadOnboardingState reads the state file. It returns nil, nil if there is
+// no state file.
+func loadOnboardingState(path string) (*onboardingState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading onboarding state: %w", err)
+	}
+	var state onboardingState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing onboarding state %s: %w", path, err)
+	}
+	if state.Processed == nil {
+		state.Processed = map[string]time.Time{}
+	}
+	if state.BatchSize <= 0 {
+		state.BatchSize = defaultProcessBatchSize
+	}
+	return &state, nil
+}
+
+// save writes the state file.
+func (s *onboardingState) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("writing onboarding state: %w", err)
+	}
+	return nil
+}
+
+// addItems appends items discovered since the review started.
+func (s *onboardingState) addItems(items []string) {
+	known := make(map[string]bool, len(s.Items))
+	for _, key := range s.Items {
+		known[key] = true
+	}
+	for _, key := range items {
+		if !known[key] {
+			s.Items = append(s.Items, key)
+			known[key] = true
+		}
+	}
+}
+
+// isProcessed reports whether an item has been processed.
+func (s *onboardingState) isProcessed(key string) bool {
+	_, ok := s.Processed[key]
+	return ok
+}
+
+// record marks an item processed.
+func (s *onboardingState) record(key string, now time.Time) {
+	s.Processed[key] = now
+	s.UpdatedAt = now
+}
+
+// progress counts the processed items and completed batches.
+func (s *onboardingState) progress() OnboardingProgress {
+	p := OnboardingProgress{ItemsTotal: len(s.Items), BatchSize: s.BatchSize}
+	for start := 0; start < len(s.Items); start += s.BatchSize {
+		end := min(start+s.BatchSize, len(s.Items))
+		done := true
+		for _, key := range s.Items[start:end] {
+			if s.isProcessed(key) {
+				p.ItemsDone++
+			} else {
+				done = false
+			}
+		}
+		p.BatchesTotal++
+		if done {
+			p.BatchesDone++
+		}
+	}
+	return p
+}
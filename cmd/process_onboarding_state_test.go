@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOnboardingStateProgress(t *testing.T) {
+	now := time.Now()
+	items := []string{"acronyms:1", "acronyms:2", "acronyms:3", "acronyms:4", "acronyms:5"}
+	state := newOnboardingState(items, 2, now)
+
+	if p := state.progress(); p.BatchesTotal != 3 || p.BatchesDone != 0 || p.ItemsTotal != 5 || p.ItemsDone != 0 {
+		t.Errorf("fresh progress = %+v, want 0 of 3 batches, 0 of 5 items", p)
+	}
+
+	state.record("acronyms:1", now)
+	state.record("acronyms:2", now)
+	state.record("acronyms:4", now)
+	if p := state.progress(); p.BatchesDone != 1 || p.ItemsDone != 3 {
+		t.Errorf("progress = %+v, want 1 batch and 3 items done", p)
+	}
+
+	state.record("acronyms:5", now)
+	if p := state.progress(); p.BatchesDone != 2 {
+		t.Errorf("progress = %+v, want last (partial) batch done", p)
+	}
+}
+
+func TestTrackOnboardingProgress_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "onboarding-state.json")
+	now := time.Now()
+
+	onboardingContext := func(ids ...int64) *OnboardingContext {
+		result := &OnboardingContext{}
+		for _, id := range ids {
+			result.NewAcronyms = append(result.NewAcronyms, OnboardingAcronym{ID: id})
+		}
+		result.Summary.NewAcronyms = len(ids)
+		return result
+	}
+
+	if err := trackOnboardingProgress(onboardingContext(1, 2), path, true, false, 10, now); err == nil || !strings.Contains(err.Error(), "no onboarding review to resume") {
+		t.Fatalf("resume without state: error = %v", err)
+	}
+
+	result := onboardingContext(1, 2, 3)
+	if err := trackOnboardingProgress(result, path, false, false, 2, now); err != nil {
+		t.Fatalf("trackOnboardingProgress: %v", err)
+	}
+	if result.Progress.BatchesTotal != 2 || result.Progress.Resumed {
+		t.Errorf("fresh progress = %+v, want 2 batches, not resumed", result.Progress)
+	}
+
+	// 'onboarding batch' processes acronym 1 and 2.
+	state, err := loadOnboardingState(path)
+	if err != nil || state == nil {
+		t.Fatalf("loadOnboardingState = %v, %v", state, err)
+	}
+	req := &OnboardingBatchRequest{
+		AcronymResolutions: []AcronymResolution{{ID: 1, Expansion: "one"}},
+		AcronymDismissals:  []AcronymDismissal{{ID: 2, Reason: "initials"}},
+	}
+	if skipped := skipProcessedOnboardingActions(req, state); skipped != 0 {
+		t.Errorf("skipped = %d before any were processed", skipped)
+	}
+	state.record(onboardingItemKey("acronyms", 1), now)
+	state.record(onboardingItemKey("acronyms", 2), now)
+	if err := state.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if skipped := skipProcessedOnboardingActions(req, state); skipped != 2 || len(req.AcronymResolutions)+len(req.AcronymDismissals) != 0 {
+		t.Errorf("skipped = %d, remaining %+v, want both actions skipped", skipped, req)
+	}
+
+	// The server still returns acronym 2 and has found acronym 4 since.
+	result = onboardingContext(2, 3, 4)
+	if err := trackOnboardingProgress(result, path, true, false, 10, now); err != nil {
+		t.Fatalf("trackOnboardingProgress resume: %v", err)
+	}
+	if len(result.NewAcronyms) != 2 || result.NewAcronyms[0].ID != 3 || result.NewAcronyms[1].ID != 4 || result.Summary.NewAcronyms != 2 {
+		t.Errorf("resumed acronyms = %+v (summary %d), want 3 and 4", result.NewAcronyms, result.Summary.NewAcronyms)
+	}
+	p := result.Progress
+	if !p.Resumed || p.BatchSize != 2 || p.BatchesDone != 1 || p.BatchesTotal != 2 || p.ItemsDone != 2 || p.ItemsTotal != 4 {
+		t.Errorf("resumed progress = %+v, want 1 of 2 batches, 2 of 4 items, batch size kept at 2", p)
+	}
+}
+
+func TestTrackOnboardingProgress_Restart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onboarding-state.json")
+	now := time.Now()
+
+	// Only acronyms are tracked: their batch actions are the only ones recorded.
+	result := &OnboardingContext{
+		NewPeople:          []OnboardingPerson{{ID: 7}},
+		NewAcronyms:        []OnboardingAcronym{{ID: 1}, {ID: 2}},
+		UnresolvedMentions: []OnboardingMention{{ID: 9}},
+	}
+	if err := trackOnboardingProgress(result, path, false, false, 10, now); err != nil {
+		t.Fatalf("trackOnboardingProgress: %v", err)
+	}
+	if result.Progress.ItemsTotal != 2 {
+		t.Errorf("items total = %d, want 2 acronyms", result.Progress.ItemsTotal)
+	}
+
+	state, err := loadOnboardingState(path)
+	if err != nil || state == nil {
+		t.Fatalf("loadOnboardingState = %v, %v", state, err)
+	}
+	state.record(onboardingItemKey("acronyms", 1), now)
+	if err := state.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	err = trackOnboardingProgress(&OnboardingContext{NewAcronyms: []OnboardingAcronym{{ID: 3}}}, path, false, false, 10, now)
+	if err == nil || !strings.Contains(err.Error(), "--restart") {
+		t.Fatalf("start over a saved review: error = %v, want a hint to --resume or --restart", err)
+	}
+	if state, _ := loadOnboardingState(path); state == nil || !state.isProcessed(onboardingItemKey("acronyms", 1)) {
+		t.Fatalf("saved review was overwritten without --restart")
+	}
+
+	result = &OnboardingContext{NewAcronyms: []OnboardingAcronym{{ID: 3}}}
+	if err := trackOnboardingProgress(result, path, false, true, 10, now); err != nil {
+		t.Fatalf("trackOnboardingProgress restart: %v", err)
+	}
+	if p := result.Progress; p.ItemsTotal != 1 || p.ItemsDone != 0 {
+		t.Errorf("restarted progress = %+v, want a fresh review of 1 item", p)
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mentionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/mentions/v1"
+)
+
+// Throughput defaults for the process commands. They are deliberately
+// conservative; raise them with --batch-size and --rate-limit when the
+// backend has the capacity.
+const (
+	defaultProcessBatchSize = 20
+	defaultProcessRateLimit = 60 // requests per minute
+	processRateBurst        = 5
+	processMaxRetries       = 5
+)
+
+// Throughput flags shared by all process commands.
+var (
+	processBatchSize int
+	processRateLimit float64
+)
+
+// addProcessThroughputFlags adds --batch-size and --rate-limit to the process
+// command and its subcommands.
+func addProcessThroughputFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVar(&processBatchSize, "batch-size", defaultProcessBatchSize, "Items per batch request (and per review batch for onboarding)")
+	cmd.PersistentFlags().Float64Var(&processRateLimit, "rate-limit", defaultProcessRateLimit, "Maximum requests per minute to the gateway (0 for no limit)")
+}
+
+// validateProcessThroughputFlags checks --batch-size and --rate-limit.
+func validateProcessThroughputFlags() error {
+	if processBatchSize <= 0 {
+		return fmt.Errorf("--batch-size must be positive")
+	}
+	if processRateLimit < 0 {
+		return fmt.Errorf("--rate-limit must not be negative")
+	}
+	return nil
+}
+
+// processLimiter is a token bucket that paces the requests the process
+// commands send. It refills at perMinute tokens a minute and holds at most
+// burst tokens. When the gateway reports it is rate limited, the refill
+// rate is halved so the run settles below the backend's limit.
+type processLimiter struct {
+	mu        sync.Mutex
+	perMinute float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+	warn      io.Writer
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newProcessLimiter returns a limiter allowing perMinute requests a minute.
+// A perMinute of zero disables limiting, though backoff on rate-limit
+// errors still applies.
+func newProcessLimiter(perMinute float64) *processLimiter {
+	burst := math.Min(processRateBurst, perMinute)
+	if burst < 1 {
+		burst = 1
+	}
+	return &processLimiter{
+		perMinute: perMinute,
+		burst:     burst,
+		tokens:    burst,
+		warn:      os.Stderr,
+		now:       time.Now,
+		sleep:     sleepContext,
+	}
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// reserve takes a token, returning how long to wait before using it.
+func (l *processLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.perMinute <= 0 {
+		return 0
+	}
+	now := l.now()
+	if l.last.IsZero() {
+		l.last = now
+	}
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Minutes()*l.perMinute)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.perMinute * float64(time.Minute))
+}
+
+// Wait blocks until a request may be sent.
+func (l *processLimiter) Wait(ctx context.Context) error {
+	if d := l.reserve(); d > 0 {
+		return l.sleep(ctx, d)
+	}
+	return ctx.Err()
+}
+
+// slowDown halves the refill rate, down to one request a minute.
+func (l *processLimiter) slowDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.perMinute <= 0 {
+		return
+	}
+	l.perMinute = math.Max(1, l.perMinute/2)
+	fmt.Fprintf(l.warn, "Warning: lowering request rate to %.0f/min\n", l.perMinute)
+}
+
+// Do sends one request with call, waiting for the limiter first. When the
+// gateway reports it is rate limited (ResourceExhausted), Do backs off
+// exponentially, slows the limiter and retries, up to processMaxRetries.
+func (l *processLimiter) Do(ctx context.Context, what string, call func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		err := call(ctx)
+		if status.Code(err) != codes.ResourceExhausted || attempt == processMaxRetries {
+			return err
+		}
+		backoff := time.Duration(1<<uint(attempt+1)) * time.Second
+		fmt.Fprintf(l.warn, "Warning: gateway rate limited %s, backing off %v (retry %d/%d)\n", what, backoff, attempt+1, processMaxRetries)
+		l.slowDown()
+		if err := l.sleep(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// splitMentionBatch splits a mentions batch into requests of at most size
+// items, taking resolutions, then new patterns, then dismissals.
+func splitMentionBatch(req *mentionsv1.BatchResolveMentionsRequest, size int) []*mentionsv1.BatchResolveMentionsRequest {
+	var batches []*mentionsv1.BatchResolveMentionsRequest
+	cur := &mentionsv1.BatchResolveMentionsRequest{}
+	n := 0
+	flush := func() {
+		if n > 0 {
+			batches = append(batches, cur)
+			cur = &mentionsv1.BatchResolveMentionsRequest{}
+			n = 0
+		}
+	}
+	add := func() {
+		n++
+		if n == size {
+			flush()
+		}
+	}
+
+	for _, r := range req.Resolutions {
+		cur.Resolutions = append(cur.Resolutions, r)
+		add()
+	}
+	for _, p := range req.NewPatterns {
+		cur.NewPatterns = append(cur.NewPatterns, p)
+		add()
+	}
+	for _, d := range req.Dismissals {
+		cur.Dismissals = append(cur.Dismissals, d)
+		add()
+	}
+	flush()
+	return batches
+}
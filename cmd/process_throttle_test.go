@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mentionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/mentions/v1"
+)
+
+// newTestProcessLimiter returns a limiter on a fake clock that records the
+// waits it would sleep instead of sleeping.
+func newTestProcessLimiter(perMinute float64) (*processLimiter, *[]time.Duration, *bytes.Buffer) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	var warn bytes.Buffer
+
+	l := newProcessLimiter(perMinute)
+	l.warn = &warn
+	l.now = func() time.Time { return clock }
+	l.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		clock = clock.Add(d)
+		return nil
+	}
+	return l, &waits, &warn
+}
+
+func TestProcessLimiter_Paces(t *testing.T) {
+	l, waits, _ := newTestProcessLimiter(60)
+	ctx := context.Background()
+
+	for i := 0; i < processRateBurst; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	if len(*waits) != 0 {
+		t.Fatalf("burst of %d waited %v, want no waits", processRateBurst, *waits)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	for _, d := range *waits {
+		if d != time.Second {
+			t.Errorf("waits after the burst = %v, want 1s each at 60/min", *waits)
+			break
+		}
+	}
+}
+
+func TestProcessLimiter_Unlimited(t *testing.T) {
+	l, waits, _ := newTestProcessLimiter(0)
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	if len(*waits) != 0 {
+		t.Errorf("--rate-limit 0 waited %d times, want none", len(*waits))
+	}
+}
+
+func TestProcessLimiter_DoBacksOffOnRateLimit(t *testing.T) {
+	l, waits, warn := newTestProcessLimiter(60)
+
+	calls := 0
+	err := l.Do(context.Background(), "resolve 1", func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.ResourceExhausted, "slow down")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if len(*waits) < 2 || (*waits)[0] != 2*time.Second || (*waits)[1] != 4*time.Second {
+		t.Errorf("waits = %v, want 2s then 4s backoff", *waits)
+	}
+	if l.perMinute != 15 {
+		t.Errorf("perMinute = %v, want 15 after two slow-downs", l.perMinute)
+	}
+	if !strings.Contains(warn.String(), "gateway rate limited resolve 1") {
+		t.Errorf("warnings = %q, want a rate limit warning", warn.String())
+	}
+}
+
+func TestProcessLimiter_DoReturnsOtherErrors(t *testing.T) {
+	l, _, _ := newTestProcessLimiter(60)
+
+	calls := 0
+	err := l.Do(context.Background(), "resolve 1", func(ctx context.Context) error {
+		calls++
+		return status.Error(codes.NotFound, "no such question")
+	})
+	if status.Code(err) != codes.NotFound || calls != 1 {
+		t.Errorf("Do = %v after %d calls, want NotFound after 1 call", err, calls)
+	}
+}
+
+func TestSplitMentionBatch(t *testing.T) {
+	req := &mentionsv1.BatchResolveMentionsRequest{
+		Resolutions: []*mentionsv1.MentionResolution{{MentionId: 1}, {MentionId: 2}, {MentionId: 3}},
+		NewPatterns: []*mentionsv1.NewPattern{{MentionText: "JB"}},
+		Dismissals:  []*mentionsv1.MentionDismissal{{MentionId: 4}},
+	}
+
+	batches := splitMentionBatch(req, 2)
+	if len(batches) != 3 {
+		t.Fatalf("got %d batches, want 3", len(batches))
+	}
+	if len(batches[0].Resolutions) != 2 || len(batches[1].Resolutions) != 1 || len(batches[1].NewPatterns) != 1 || len(batches[2].Dismissals) != 1 {
+		t.Errorf("batches not split in order: %v", batches)
+	}
+
+	if got := splitMentionBatch(&mentionsv1.BatchResolveMentionsRequest{}, 2); len(got) != 0 {
+		t.Errorf("empty request split into %d batches, want 0", len(got))
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	productv1 "github.com/otherjamesbrown/penf-cli/api/proto/product/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -31,6 +30,8 @@ var (
 	productStatus      string
 	productDescription string
 	productKeywords    []string
+	productOwner       string
+	productNameSearch  string
 )
 
 // ProductCommandDeps holds the dependencies for product commands.
@@ -46,13 +47,18 @@ type ProductCommandDeps struct {
 	Pool       *pgxpool.Pool
 	Repository *products.Repository
 	InitPool   func(*config.CLIConfig) (*pgxpool.Pool, error)
+
+	// InitRelClient connects to the relationship service for ownership
+	// filters and the 'product show' relationship sections.
+	InitRelClient func(*config.CLIConfig) (*client.RelationshipClient, error)
 }
 
 // DefaultProductDeps returns the default dependencies for production use.
 func DefaultProductDeps() *ProductCommandDeps {
 	return &ProductCommandDeps{
-		LoadConfig: config.LoadConfig,
-		InitPool:   initProductPool,
+		LoadConfig:    config.LoadConfig,
+		InitPool:      initProductPool,
+		InitRelClient: DefaultRelationshipDeps().InitRelClient,
 	}
 }
 
@@ -230,7 +236,13 @@ Examples:
   penf product list --type sub_product
 
   # Filter by status
-  penf product list --status active`,
+  penf product list --status active
+
+  # Filter by name
+  penf product list --all --name-contains "api"
+
+  # Products owned or created by a person or team (entity ID)
+  penf product list --all --owner ent-person-123`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			listAll, _ := cmd.Flags().GetBool("all")
@@ -241,6 +253,8 @@ Examples:
 	cmd.Flags().StringVar(&productParent, "parent", "", "Filter by parent product name")
 	cmd.Flags().StringVar(&productType, "type", "", "Filter by product type (product, sub_product, feature)")
 	cmd.Flags().StringVar(&productStatus, "status", "", "Filter by status (active, beta, sunset, deprecated)")
+	cmd.Flags().StringVar(&productNameSearch, "name-contains", "", "Filter by name or alias substring (case-insensitive)")
+	cmd.Flags().StringVar(&productOwner, "owner", "", "Filter by owning entity ID (owns/created relationships)")
 	cmd.Flags().Bool("all", false, "List all products (not just top-level)")
 
 	return cmd
@@ -284,22 +298,29 @@ Examples:
 	cmd.Flags().StringVar(&productDescription, "description", "", "Product description")
 	cmd.Flags().StringSliceVar(&productKeywords, "keywords", nil, "Keywords (comma-separated)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newProductShowCommand creates the 'product show' subcommand.
 func newProductShowCommand(deps *ProductCommandDeps) *cobra.Command {
 	return &cobra.Command{
-		Use:   "show <name>",
+		Use:   "show <name|id>",
 		Short: "Show product details",
 		Long: `Show detailed information about a specific product.
 
-Displays the product's properties, aliases, and associated teams.
-The name can be either the product name or an alias.
+Displays the product's properties and aliases, followed by what the
+relationship graph knows about it:
+  - Owners: people and teams with owns/created relationships
+  - Related projects: project entities linked to the product
+  - Recent mentions: content cited as evidence for its relationships
+
+The identifier can be the product ID, name or an alias.
 
 Examples:
   penf product show "My Product"
-  penf product show "MP"  # Using an alias`,
+  penf product show 42
+  penf product show "MP"  # Using an alias
+  penf product show "My Product" --output json`,
 		Aliases: []string{"info"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -355,7 +376,7 @@ For example, "LKE" could have an alias "Kubernetes Engine".`,
 
 // newProductAliasAddCommand creates the 'product alias add' subcommand.
 func newProductAliasAddCommand(deps *ProductCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "add <product> <alias>",
 		Short: "Add an alias to a product",
 		Long: `Add an alternative name (alias) to a product.
@@ -366,12 +387,12 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runProductAliasAdd(cmd.Context(), deps, args[0], args[1])
 		},
-	}
+	})
 }
 
 // newProductAliasRemoveCommand creates the 'product alias remove' subcommand.
 func newProductAliasRemoveCommand(deps *ProductCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove <product> <alias>",
 		Short: "Remove an alias from a product",
 		Long: `Remove an alias from a product.
@@ -383,7 +404,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runProductAliasRemove(cmd.Context(), deps, args[0], args[1])
 		},
-	}
+	})
 }
 
 // newProductAliasListCommand creates the 'product alias list' subcommand.
@@ -407,7 +428,7 @@ Example:
 
 // connectProductToGateway creates a gRPC connection to the gateway service.
 func connectProductToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -430,9 +451,11 @@ func connectProductToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -489,12 +512,30 @@ func runProductList(ctx context.Context, deps *ProductCommandDeps, listAll bool)
 		filter.Status = productStatusToProto(productStatus)
 	}
 
+	if productNameSearch != "" {
+		filter.NameSearch = productNameSearch
+	}
+
 	resp, err := client.ListProducts(ctx, &productv1.ListProductsRequest{Filter: filter})
 	if err != nil {
 		return fmt.Errorf("listing products: %w", err)
 	}
 
-	return outputProducts(cfg, resp.Products)
+	var owned map[string]bool
+	if productOwner != "" {
+		relClient, err := deps.relationshipClient(cfg)
+		if err != nil {
+			return fmt.Errorf("connecting to relationship service: %w", err)
+		}
+		defer relClient.Close()
+
+		owned, err = listOwnedEntityNames(ctx, relClient, tenantID, productOwner)
+		if err != nil {
+			return fmt.Errorf("listing entities owned by %s: %w", productOwner, err)
+		}
+	}
+
+	return outputProducts(cfg, filterProducts(resp.Products, productNameSearch, owned))
 }
 
 // runProductAdd executes the product add command.
@@ -531,6 +572,10 @@ func runProductAdd(ctx context.Context, deps *ProductCommandDeps, name string) e
 		return fmt.Errorf("creating product: %w", err)
 	}
 
+	if getProductOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Product, "Created product: "+name)
+	}
+
 	fmt.Printf("\033[32mCreated product:\033[0m %s (ID: %d)\n", name, resp.Product.Id)
 	return nil
 }
@@ -560,7 +605,12 @@ func runProductShow(ctx context.Context, deps *ProductCommandDeps, name string)
 		return fmt.Errorf("product not found: %s", name)
 	}
 
-	return outputProductDetail(cfg, resp.Product)
+	relClient, relErr := deps.relationshipClient(cfg)
+	if relErr == nil {
+		defer relClient.Close()
+	}
+	overview := collectProductOverview(ctx, relClient, relErr, tenantID, resp.Product)
+	return outputProductOverview(cfg, overview)
 }
 
 // runProductHierarchy executes the product hierarchy command.
@@ -617,6 +667,11 @@ func runProductAliasAdd(ctx context.Context, deps *ProductCommandDeps, productNa
 		return fmt.Errorf("adding alias: %w", err)
 	}
 
+	if getProductOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ProductAliasResult{Product: resp.ProductName, Alias: resp.Alias, Action: "add"},
+			fmt.Sprintf("Added alias '%s' to %s", resp.Alias, resp.ProductName))
+	}
+
 	fmt.Printf("\033[32mAdded alias:\033[0m '%s' -> %s\n", resp.Alias, resp.ProductName)
 	return nil
 }
@@ -647,6 +702,11 @@ func runProductAliasRemove(ctx context.Context, deps *ProductCommandDeps, produc
 		return fmt.Errorf("removing alias: %w", err)
 	}
 
+	if getProductOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ProductAliasResult{Product: resp.ProductName, Alias: resp.Alias, Action: "remove"},
+			fmt.Sprintf("Removed alias '%s' from %s", resp.Alias, resp.ProductName))
+	}
+
 	fmt.Printf("\033[32mRemoved alias:\033[0m '%s' from %s\n", resp.Alias, resp.ProductName)
 	return nil
 }
@@ -681,6 +741,13 @@ func runProductAliasList(ctx context.Context, deps *ProductCommandDeps, productN
 
 // ==================== Output Functions ====================
 
+// ProductAliasResult is the JSON result of a product alias add or remove.
+type ProductAliasResult struct {
+	Product string `json:"product"`
+	Alias   string `json:"alias"`
+	Action  string `json:"action"`
+}
+
 // getProductOutputFormat returns the output format from flag or config.
 func getProductOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if productOutput != "" {
@@ -735,20 +802,6 @@ func outputProductsTable(productsList []*productv1.Product) error {
 	return nil
 }
 
-// outputProductDetail outputs detailed product information.
-func outputProductDetail(cfg *config.CLIConfig, product *productv1.Product) error {
-	format := getProductOutputFormat(cfg)
-
-	switch format {
-	case config.OutputFormatJSON:
-		return outputProductJSON(product)
-	case config.OutputFormatYAML:
-		return outputProductYAML(product)
-	default:
-		return outputProductDetailText(product)
-	}
-}
-
 // outputProductDetailText outputs product info in human-readable format.
 func outputProductDetailText(product *productv1.Product) error {
 	typeStr := productTypeFromProtoToString(product.ProductType)
@@ -918,7 +971,7 @@ func outputProductJSON(v interface{}) error {
 }
 
 func outputProductYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	productv1 "github.com/otherjamesbrown/penf-cli/api/proto/product/v1"
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// productMentionLimit is how many recent mentions 'product show' lists.
+const productMentionLimit = 10
+
+// ProductOwner is a person or team linked to a product by an owns or created
+// relationship.
+type ProductOwner struct {
+	EntityID     string  `json:"entity_id" yaml:"entity_id"`
+	Name         string  `json:"name" yaml:"name"`
+	Type         string  `json:"type" yaml:"type"`
+	Relationship string  `json:"relationship" yaml:"relationship"`
+	Confidence   float32 `json:"confidence" yaml:"confidence"`
+}
+
+// ProductRelatedProject is a project entity linked to a product.
+type ProductRelatedProject struct {
+	EntityID     string  `json:"entity_id" yaml:"entity_id"`
+	Name         string  `json:"name" yaml:"name"`
+	Relationship string  `json:"relationship" yaml:"relationship"`
+	Confidence   float32 `json:"confidence" yaml:"confidence"`
+}
+
+// ProductMention is a piece of content cited as evidence for one of the
+// product's relationships.
+type ProductMention struct {
+	SourceID     string    `json:"source_id" yaml:"source_id"`
+	SourceType   string    `json:"source_type" yaml:"source_type"`
+	Excerpt      string    `json:"excerpt,omitempty" yaml:"excerpt,omitempty"`
+	DiscoveredAt time.Time `json:"discovered_at" yaml:"discovered_at"`
+}
+
+// ProductOverview is the 'product show' view: the product's fields (inlined,
+// as before) plus what the relationship graph knows about it. Parts that
+// could not be fetched are reported in Warnings.
+type ProductOverview struct {
+	*productv1.Product `yaml:",inline"`
+	EntityID           string                  `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	Owners             []ProductOwner          `json:"owners" yaml:"owners"`
+	RelatedProjects    []ProductRelatedProject `json:"related_projects" yaml:"related_projects"`
+	RecentMentions     []ProductMention        `json:"recent_mentions" yaml:"recent_mentions"`
+	Warnings           []string                `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// relationshipClient connects to the relationship service, which supplies
+// product ownership, related projects and mentions.
+func (d *ProductCommandDeps) relationshipClient(cfg *config.CLIConfig) (*client.RelationshipClient, error) {
+	if d.InitRelClient == nil {
+		return nil, errors.New("relationship service not configured")
+	}
+	return d.InitRelClient(cfg)
+}
+
+// collectProductOverview looks the product up in the relationship graph and
+// gathers its owners, related projects and recent mentions.
+func collectProductOverview(ctx context.Context, relClient *client.RelationshipClient, relErr error, tenantID string, product *productv1.Product) *ProductOverview {
+	overview := &ProductOverview{
+		Product:         product,
+		Owners:          []ProductOwner{},
+		RelatedProjects: []ProductRelatedProject{},
+		RecentMentions:  []ProductMention{},
+	}
+	if relClient == nil {
+		overview.Warnings = append(overview.Warnings, fmt.Sprintf("relationships unavailable: %v", relErr))
+		return overview
+	}
+
+	entity, err := findProductEntity(ctx, relClient, tenantID, product)
+	if err != nil {
+		overview.Warnings = append(overview.Warnings, fmt.Sprintf("relationships unavailable: %v", err))
+		return overview
+	}
+	if entity == nil {
+		return overview
+	}
+	overview.EntityID = entity.ID
+
+	rels, _, err := relClient.ListRelationships(ctx, &client.ListRelationshipsRequest{
+		TenantID: tenantID,
+		EntityID: entity.ID,
+		PageSize: 500,
+	})
+	if err != nil {
+		overview.Warnings = append(overview.Warnings, fmt.Sprintf("relationships unavailable: %v", err))
+		return overview
+	}
+	overview.Owners, overview.RelatedProjects, overview.RecentMentions = summarizeProductRelationships(entity.ID, rels)
+	return overview
+}
+
+// findProductEntity returns the product entity matching the product's name or
+// one of its aliases, or nil when the graph has none.
+func findProductEntity(ctx context.Context, relClient *client.RelationshipClient, tenantID string, product *productv1.Product) (*client.RelEntity, error) {
+	for _, name := range append([]string{product.Name}, product.Aliases...) {
+		candidates, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+			TenantID:   tenantID,
+			EntityType: relationshipv1.EntityType_ENTITY_TYPE_PRODUCT,
+			Search:     name,
+			PageSize:   50,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if entity := matchEntityByName(candidates, name); entity != nil {
+			return entity, nil
+		}
+	}
+	return nil, nil
+}
+
+// summarizeProductRelationships splits the relationships touching
+// productEntityID into owners (owns/created), related projects, and the
+// most recent evidence citing the product.
+func summarizeProductRelationships(productEntityID string, rels []*client.Relationship) ([]ProductOwner, []ProductRelatedProject, []ProductMention) {
+	owners := []ProductOwner{}
+	projects := []ProductRelatedProject{}
+	mentions := []ProductMention{}
+	seenMention := map[string]bool{}
+
+	for _, r := range rels {
+		for _, ev := range r.Evidence {
+			if ev.SourceID == "" || seenMention[ev.SourceID] {
+				continue
+			}
+			seenMention[ev.SourceID] = true
+			mentions = append(mentions, ProductMention{
+				SourceID:     ev.SourceID,
+				SourceType:   ev.SourceType,
+				Excerpt:      ev.Excerpt,
+				DiscoveredAt: ev.DiscoveredAt,
+			})
+		}
+
+		other := r.SourceEntity
+		if other != nil && other.ID == productEntityID {
+			other = r.TargetEntity
+		}
+		if other == nil || other.ID == productEntityID {
+			continue
+		}
+		relName := productRelationshipName(r.RelationshipType)
+
+		switch {
+		case r.RelationshipType == relationshipv1.RelationshipType_RELATIONSHIP_TYPE_OWNS.String(),
+			r.RelationshipType == relationshipv1.RelationshipType_RELATIONSHIP_TYPE_CREATED.String():
+			owners = append(owners, ProductOwner{
+				EntityID:     other.ID,
+				Name:         other.Name,
+				Type:         productEntityTypeName(other.Type),
+				Relationship: relName,
+				Confidence:   r.Confidence,
+			})
+		case other.Type == relationshipv1.EntityType_ENTITY_TYPE_PROJECT.String():
+			projects = append(projects, ProductRelatedProject{
+				EntityID:     other.ID,
+				Name:         other.Name,
+				Relationship: relName,
+				Confidence:   r.Confidence,
+			})
+		}
+	}
+
+	sort.SliceStable(owners, func(i, j int) bool { return owners[i].Confidence > owners[j].Confidence })
+	sort.SliceStable(projects, func(i, j int) bool { return projects[i].Confidence > projects[j].Confidence })
+	sort.SliceStable(mentions, func(i, j int) bool { return mentions[i].DiscoveredAt.After(mentions[j].DiscoveredAt) })
+	if len(mentions) > productMentionLimit {
+		mentions = mentions[:productMentionLimit]
+	}
+	return owners, projects, mentions
+}
+
+// productRelationshipName turns "RELATIONSHIP_TYPE_OWNS" into "owns".
+func productRelationshipName(relType string) string {
+	return strings.ToLower(strings.TrimPrefix(relType, "RELATIONSHIP_TYPE_"))
+}
+
+// productEntityTypeName turns "ENTITY_TYPE_PERSON" into "person".
+func productEntityTypeName(entityType string) string {
+	return strings.ToLower(strings.TrimPrefix(entityType, "ENTITY_TYPE_"))
+}
+
+// listOwnedEntityNames returns the lowercased names and aliases of every
+// entity the owner owns or created.
+func listOwnedEntityNames(ctx context.Context, relClient *client.RelationshipClient, tenantID, ownerID string) (map[string]bool, error) {
+	names := map[string]bool{}
+	for _, relType := range []string{"owns", "created"} {
+		rels, _, err := relClient.ListRelationships(ctx, &client.ListRelationshipsRequest{
+			TenantID:         tenantID,
+			EntityID:         ownerID,
+			RelationshipType: stringToRelType(relType),
+			PageSize:         500,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rels {
+			owned := r.TargetEntity
+			if owned != nil && owned.ID == ownerID {
+				owned = r.SourceEntity
+			}
+			if owned == nil || owned.ID == ownerID {
+				continue
+			}
+			for _, name := range append([]string{owned.Name, owned.CanonicalName}, owned.Aliases...) {
+				if name != "" {
+					names[strings.ToLower(name)] = true
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// filterProducts keeps the products whose name or an alias contains
+// nameContains (case-insensitive) and, when owned is non-nil, whose name or
+// an alias is one of the owned entity names.
+func filterProducts(productsList []*productv1.Product, nameContains string, owned map[string]bool) []*productv1.Product {
+	needle := strings.ToLower(nameContains)
+	filtered := []*productv1.Product{}
+	for _, p := range productsList {
+		names := append([]string{p.Name}, p.Aliases...)
+		if needle != "" && !anyName(names, func(n string) bool { return strings.Contains(strings.ToLower(n), needle) }) {
+			continue
+		}
+		if owned != nil && !anyName(names, func(n string) bool { return owned[strings.ToLower(n)] }) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+// anyName reports whether match holds for any of names.
+func anyName(names []string, match func(string) bool) bool {
+	for _, n := range names {
+		if match(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputProductOverview writes 'product show' output.
+func outputProductOverview(cfg *config.CLIConfig, overview *ProductOverview) error {
+	switch getProductOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		return outputProductJSON(overview)
+	case config.OutputFormatYAML:
+		return outputProductYAML(overview)
+	default:
+		if err := outputProductDetailText(overview.Product); err != nil {
+			return err
+		}
+		outputProductOverviewText(overview)
+		return nil
+	}
+}
+
+// outputProductOverviewText prints the ownership sections under the product
+// details.
+func outputProductOverviewText(overview *ProductOverview) {
+	fmt.Println()
+	fmt.Printf("  \033[1mOwners (%d):\033[0m\n", len(overview.Owners))
+	if len(overview.Owners) == 0 {
+		fmt.Println("    (none - link an owner with 'penf relationship create <person> <product> --type owns')")
+	}
+	for _, o := range overview.Owners {
+		fmt.Printf("    %-30s %-12s %-8s %.2f\n", truncateString(o.Name, 30), o.Type, o.Relationship, o.Confidence)
+	}
+
+	fmt.Println()
+	fmt.Printf("  \033[1mRelated projects (%d):\033[0m\n", len(overview.RelatedProjects))
+	if len(overview.RelatedProjects) == 0 {
+		fmt.Println("    (none)")
+	}
+	for _, p := range overview.RelatedProjects {
+		fmt.Printf("    %-30s %-16s %.2f\n", truncateString(p.Name, 30), p.Relationship, p.Confidence)
+	}
+
+	fmt.Println()
+	fmt.Printf("  \033[1mRecent mentions (%d):\033[0m\n", len(overview.RecentMentions))
+	if len(overview.RecentMentions) == 0 {
+		fmt.Println("    (none)")
+	}
+	for _, m := range overview.RecentMentions {
+		date := "-"
+		if !m.DiscoveredAt.IsZero() {
+			date = m.DiscoveredAt.Format("2006-01-02")
+		}
+		fmt.Printf("    %s  %-10s %-12s %s\n", date, m.SourceType, truncateString(m.SourceID, 12), truncateString(m.Excerpt, 60))
+	}
+
+	for _, w := range overview.Warnings {
+		fmt.Printf("\n  \033[33mWarning:\033[0m %s", w)
+	}
+	if len(overview.Warnings) > 0 {
+		fmt.Println()
+	}
+}
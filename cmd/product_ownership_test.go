@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	productv1 "github.com/otherjamesbrown/penf-cli/api/proto/product/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestSummarizeProductRelationships(t *testing.T) {
+	product := &client.RelEntity{ID: "prod-1", Name: "LKE", Type: "ENTITY_TYPE_PRODUCT"}
+	alice := &client.RelEntity{ID: "p-1", Name: "Alice Smith", Type: "ENTITY_TYPE_PERSON"}
+	team := &client.RelEntity{ID: "o-1", Name: "Platform Team", Type: "ENTITY_TYPE_ORGANIZATION"}
+	migration := &client.RelEntity{ID: "proj-1", Name: "Q1 Migration", Type: "ENTITY_TYPE_PROJECT"}
+
+	older := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	owners, projects, mentions := summarizeProductRelationships("prod-1", []*client.Relationship{
+		{SourceEntity: alice, TargetEntity: product, RelationshipType: "RELATIONSHIP_TYPE_CREATED", Confidence: 0.6,
+			Evidence: []client.Evidence{{SourceID: "c-1", SourceType: "email", DiscoveredAt: older}}},
+		{SourceEntity: team, TargetEntity: product, RelationshipType: "RELATIONSHIP_TYPE_OWNS", Confidence: 0.9,
+			Evidence: []client.Evidence{{SourceID: "c-2", SourceType: "meeting", DiscoveredAt: newer}, {SourceID: "c-1", DiscoveredAt: older}}},
+		{SourceEntity: product, TargetEntity: migration, RelationshipType: "RELATIONSHIP_TYPE_RELATED_TO", Confidence: 0.7},
+		{SourceEntity: alice, TargetEntity: product, RelationshipType: "RELATIONSHIP_TYPE_DISCUSSED", Confidence: 0.5},
+	})
+
+	require.Len(t, owners, 2)
+	assert.Equal(t, ProductOwner{EntityID: "o-1", Name: "Platform Team", Type: "organization", Relationship: "owns", Confidence: 0.9}, owners[0])
+	assert.Equal(t, "created", owners[1].Relationship)
+
+	require.Len(t, projects, 1)
+	assert.Equal(t, "Q1 Migration", projects[0].Name)
+	assert.Equal(t, "related_to", projects[0].Relationship)
+
+	require.Len(t, mentions, 2, "mentions are deduplicated by source")
+	assert.Equal(t, "c-2", mentions[0].SourceID, "newest first")
+	assert.Equal(t, "c-1", mentions[1].SourceID)
+}
+
+func TestFilterProducts(t *testing.T) {
+	products := []*productv1.Product{
+		{Id: 1, Name: "LKE", Aliases: []string{"Kubernetes Engine"}},
+		{Id: 2, Name: "Object Storage"},
+		{Id: 3, Name: "Block Storage", Aliases: []string{"Volumes"}},
+	}
+	ids := func(ps []*productv1.Product) []int64 {
+		var out []int64
+		for _, p := range ps {
+			out = append(out, p.Id)
+		}
+		return out
+	}
+
+	assert.Equal(t, []int64{1, 2, 3}, ids(filterProducts(products, "", nil)))
+	assert.Equal(t, []int64{2, 3}, ids(filterProducts(products, "storage", nil)))
+	assert.Equal(t, []int64{1}, ids(filterProducts(products, "kubernetes", nil)), "aliases are searched")
+	assert.Equal(t, []int64{1, 3}, ids(filterProducts(products, "", map[string]bool{"lke": true, "volumes": true})))
+	assert.Equal(t, []int64{3}, ids(filterProducts(products, "block", map[string]bool{"lke": true, "volumes": true})))
+	assert.Empty(t, filterProducts(products, "", map[string]bool{}), "an owner with nothing owned matches nothing")
+}
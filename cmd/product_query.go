@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	productv1 "github.com/otherjamesbrown/penf-cli/api/proto/product/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -245,7 +244,7 @@ func outputQueryResultFromProto(format config.OutputFormat, result *productv1.Qu
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputQueryResultTextFromProto(response, result)
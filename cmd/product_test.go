@@ -109,7 +109,7 @@ func TestProductListCommand_Structure(t *testing.T) {
 	}
 
 	// Check flags.
-	flags := []string{"parent", "type", "status", "all"}
+	flags := []string{"parent", "type", "status", "name-contains", "owner", "all"}
 	for _, flag := range flags {
 		if listCmd.Flags().Lookup(flag) == nil {
 			t.Errorf("expected flag %q to exist on list command", flag)
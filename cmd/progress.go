@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// quietOutput is set by the root --quiet flag.
+var quietOutput bool
+
+// SetQuiet suppresses informational progress output ("Discovering...",
+// "Merging...", progress counters) for all commands. Result payloads, warnings
+// and errors are unaffected.
+func SetQuiet(quiet bool) {
+	quietOutput = quiet
+}
+
+// progressOut returns the writer for informational progress lines. Quiet is
+// implied for machine-readable formats so structured output on stdout is never
+// preceded by human prose.
+func progressOut(format config.OutputFormat) io.Writer {
+	if quietOutput {
+		return io.Discard
+	}
+	switch format {
+	case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatCSV, config.OutputFormatJSONL, config.OutputFormatTemplate:
+		return io.Discard
+	default:
+		return os.Stdout
+	}
+}
+
+const (
+	// progressLineEvery is how many items pass between progress lines when
+	// the output is not a terminal.
+	progressLineEvery = 10
+
+	// progressBarWidth is the number of cells in the drawn bar.
+	progressBarWidth = 24
+
+	// progressRedrawInterval limits how often the bar is redrawn.
+	progressRedrawInterval = 100 * time.Millisecond
+)
+
+// progressBar reports the progress of a long-running operation over a known
+// number of items. On a terminal it redraws a single line with a bar,
+// percentage, rate and ETA; anywhere else it prints a "Progress: n/total"
+// line every progressLineEvery items so logs stay readable. It is safe for
+// concurrent use.
+type progressBar struct {
+	mu          sync.Mutex
+	w           io.Writer
+	interactive bool
+	total       int
+	noun        string
+	every       int
+	done        int
+	detail      string
+	started     time.Time
+	drawn       time.Time
+	now         func() time.Time
+}
+
+// newProgressBar creates a progress bar writing to w for total items, each
+// described by noun in the progress line (e.g. "reprocessed", "files"). The
+// bar is drawn only when w is a terminal.
+func newProgressBar(w io.Writer, total int, noun string) *progressBar {
+	b := &progressBar{
+		w:           w,
+		interactive: isTerminalWriter(w),
+		total:       total,
+		noun:        noun,
+		every:       progressLineEvery,
+		now:         time.Now,
+	}
+	b.started = b.now()
+	return b
+}
+
+// isTerminalWriter reports whether w is a terminal.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Set records that done items have finished. detail is shown after the
+// counts, e.g. "(3 succeeded, 1 failed)".
+func (b *progressBar) Set(done int, detail string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.done = done
+	b.detail = detail
+	if !b.interactive {
+		if b.every > 0 && done > 0 && (done%b.every == 0 || done == b.total) {
+			fmt.Fprintf(b.w, "Progress: %d/%d %s", done, b.total, b.noun)
+			if detail != "" {
+				fmt.Fprintf(b.w, " %s", detail)
+			}
+			fmt.Fprintln(b.w)
+		}
+		return
+	}
+	if now := b.now(); done == b.total || now.Sub(b.drawn) >= progressRedrawInterval {
+		b.draw(now)
+	}
+}
+
+// Printf prints a line to w above the bar, such as a per-item failure. The
+// bar is redrawn below it.
+func (b *progressBar) Printf(w io.Writer, format string, args ...interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.interactive && !b.drawn.IsZero() {
+		fmt.Fprint(b.w, "\r\033[K")
+	}
+	fmt.Fprintf(w, format, args...)
+	if b.interactive && !b.drawn.IsZero() {
+		b.draw(b.now())
+	}
+}
+
+// Finish ends the bar's line. It must be called before printing anything
+// else once the operation is over.
+func (b *progressBar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.interactive && !b.drawn.IsZero() {
+		b.draw(b.now())
+		fmt.Fprintln(b.w)
+		b.drawn = time.Time{}
+	}
+}
+
+// draw redraws the bar line. b.mu must be held.
+func (b *progressBar) draw(now time.Time) {
+	line := b.render(now)
+	if width, _, err := term.GetSize(int(b.w.(*os.File).Fd())); err == nil && width > 1 && len(line) >= width {
+		line = line[:width-1]
+	}
+	fmt.Fprintf(b.w, "\r\033[K%s", line)
+	b.drawn = now
+}
+
+// render formats the bar line, e.g.
+// "  [#########...............]  38%  38/100 files  12.5/s  ETA 5.0s  (2 failed)".
+func (b *progressBar) render(now time.Time) string {
+	pct := 0.0
+	if b.total > 0 {
+		pct = float64(b.done) / float64(b.total)
+	}
+	filled := int(pct * progressBarWidth)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "  [%s%s] %3.0f%%  %d/%d %s",
+		strings.Repeat("#", filled), strings.Repeat(".", progressBarWidth-filled),
+		pct*100, b.done, b.total, b.noun)
+
+	if elapsed := now.Sub(b.started); b.done > 0 && elapsed > 0 {
+		rate := float64(b.done) / elapsed.Seconds()
+		fmt.Fprintf(&sb, "  %.1f/s", rate)
+		if remaining := b.total - b.done; remaining > 0 {
+			fmt.Fprintf(&sb, "  ETA %s", formatDuration(time.Duration(float64(remaining)/rate*float64(time.Second))))
+		}
+	}
+	if b.detail != "" {
+		sb.WriteString("  " + b.detail)
+	}
+	return sb.String()
+}
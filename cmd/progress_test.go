@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestProgressOut(t *testing.T) {
+	defer SetQuiet(false)
+
+	SetQuiet(false)
+	if progressOut(config.OutputFormatText) != os.Stdout {
+		t.Error("expected progress on stdout for text output")
+	}
+	for _, format := range []config.OutputFormat{config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatCSV} {
+		if progressOut(format) != io.Discard {
+			t.Errorf("expected progress suppressed for %s output", format)
+		}
+	}
+
+	SetQuiet(true)
+	if progressOut(config.OutputFormatText) != io.Discard {
+		t.Error("expected progress suppressed with --quiet")
+	}
+}
+
+func TestProgressBar_NotTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 12, "reprocessed")
+	if bar.interactive {
+		t.Fatal("expected a buffer not to be treated as a terminal")
+	}
+	for i := 1; i <= 12; i++ {
+		bar.Set(i, fmt.Sprintf("(%d succeeded, 0 failed)", i))
+	}
+	bar.Finish()
+
+	want := "Progress: 10/12 reprocessed (10 succeeded, 0 failed)\n" +
+		"Progress: 12/12 reprocessed (12 succeeded, 0 failed)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	bar = newProgressBar(&buf, 3, "resolved")
+	bar.every = 0
+	bar.Set(3, "")
+	bar.Printf(&buf, "failed %s\n", "c1")
+	if got := buf.String(); got != "failed c1\n" {
+		t.Errorf("output with periodic lines off = %q", got)
+	}
+}
+
+func TestProgressBar_Render(t *testing.T) {
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	bar := newProgressBar(io.Discard, 100, "files")
+	bar.started = start
+	bar.done = 25
+	bar.detail = "(1 failed)"
+
+	got := bar.render(start.Add(5 * time.Second))
+	want := "  [######..................]  25%  25/100 files  5.0/s  ETA 15.0s  (1 failed)"
+	if got != want {
+		t.Errorf("render = %q, want %q", got, want)
+	}
+
+	bar.done = 100
+	if got := bar.render(start.Add(20 * time.Second)); strings.Contains(got, "ETA") || !strings.Contains(got, "100%") {
+		t.Errorf("render when complete = %q, want 100%% and no ETA", got)
+	}
+}
@@ -14,7 +14,6 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
 	projectv1 "github.com/otherjamesbrown/penf-cli/api/proto/project/v1"
@@ -34,14 +33,16 @@ var (
 // ProjectCommandDeps holds the dependencies for project commands.
 // All commands use gRPC via the gateway - CLI must NEVER access the database directly.
 type ProjectCommandDeps struct {
-	Config     *config.CLIConfig
-	LoadConfig func() (*config.CLIConfig, error)
+	Config        *config.CLIConfig
+	LoadConfig    func() (*config.CLIConfig, error)
+	InitRelClient func(*config.CLIConfig) (*client.RelationshipClient, error)
 }
 
 // DefaultProjectDeps returns the default dependencies for production use.
 func DefaultProjectDeps() *ProjectCommandDeps {
 	return &ProjectCommandDeps{
-		LoadConfig: config.LoadConfig,
+		LoadConfig:    config.LoadConfig,
+		InitRelClient: DefaultRelationshipDeps().InitRelClient,
 	}
 }
 
@@ -117,6 +118,8 @@ func newProjectListCommand(deps *ProjectCommandDeps) *cobra.Command {
 	var sortBy string
 	var limit int32
 	var alwaysInclude []string
+	var withStats bool
+	var statsSince string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -133,6 +136,8 @@ Filtering Options:
   --sort            Sort by: "name", "activity", "created" (default: "name")
   --limit           Maximum number of results (default: 100)
   --always-include  Always include these project names (can be repeated)
+  --with-stats      Show linked entities, recent content and last activity
+  --since           Window for recent content with --with-stats (default: 30d)
 
 Examples:
   # List all projects (table format)
@@ -150,6 +155,10 @@ Examples:
   # Always include a specific project even if filtered
   penf project list --status active --always-include "MTC 2026"
 
+  # See which projects are active
+  penf project list --with-stats
+  penf project list --with-stats --since 7d --output json
+
   # List as JSON for programmatic use
   penf project list --output json
 
@@ -157,6 +166,9 @@ Examples:
   penf project list --output yaml`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if withStats {
+				return runProjectListWithStats(cmd.Context(), deps, nameSearch, keyword, statusFilter, sortBy, limit, alwaysInclude, statsSince)
+			}
 			return runProjectList(cmd.Context(), deps, nameSearch, keyword, statusFilter, sortBy, limit, alwaysInclude)
 		},
 	}
@@ -167,6 +179,8 @@ Examples:
 	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort by: name, activity, created")
 	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of results")
 	cmd.Flags().StringSliceVar(&alwaysInclude, "always-include", nil, "Always include these project names (can be repeated)")
+	cmd.Flags().BoolVar(&withStats, "with-stats", false, "Show linked entities, recent content count and last activity per project")
+	cmd.Flags().StringVar(&statsSince, "since", "30d", "Window for recent content with --with-stats (e.g. 7d, 2026-01-01)")
 
 	return cmd
 }
@@ -208,7 +222,7 @@ Examples:
 	cmd.Flags().StringVar(&projectDescription, "description", "", "Project description")
 	cmd.Flags().StringSliceVar(&projectKeywords, "keywords", nil, "Keywords for auto-tagging (comma-separated)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newProjectShowCommand creates the 'project show' subcommand.
@@ -218,8 +232,10 @@ func newProjectShowCommand(deps *ProjectCommandDeps) *cobra.Command {
 		Short: "Show project details",
 		Long: `Show detailed information about a specific project.
 
-Displays all project properties including keywords and Jira integrations.
-Use --output json for full structured data.
+Displays all project properties including keywords and Jira integrations,
+followed by an activity summary: members (entities linked to the project by
+works_on relationships), the most recent assertions and the most recent
+attributed content. Use --output json for full structured data.
 
 The identifier can be:
   - Project name (case-insensitive)
@@ -272,7 +288,7 @@ Examples:
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newProjectUpdateCommand creates the 'project update' subcommand.
@@ -313,14 +329,14 @@ Examples:
 	cmd.Flags().StringVarP(&updateDescription, "description", "d", "", "New description")
 	cmd.Flags().StringSliceVarP(&updateKeywords, "keywords", "k", nil, "New keywords (comma-separated)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // ==================== gRPC Connection ====================
 
 // connectProjectToGateway creates a gRPC connection to the gateway service.
 func connectProjectToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -343,9 +359,11 @@ func connectProjectToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -443,15 +461,19 @@ func runProjectAdd(ctx context.Context, deps *ProjectCommandDeps, name string) e
 		return fmt.Errorf("creating project: %w", err)
 	}
 
+	// Reset flags for next call.
+	projectDescription = ""
+	projectKeywords = nil
+
+	if getProjectOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Project, "Created project: "+name)
+	}
+
 	fmt.Printf("\033[32mCreated project:\033[0m %s (ID: %d)\n", name, resp.Project.Id)
 	if len(cleanKeywords) > 0 {
 		fmt.Printf("  Keywords: %s\n", strings.Join(cleanKeywords, ", "))
 	}
 
-	// Reset flags for next call.
-	projectDescription = ""
-	projectKeywords = nil
-
 	return nil
 }
 
@@ -480,7 +502,59 @@ func runProjectShow(ctx context.Context, deps *ProjectCommandDeps, identifier st
 		return fmt.Errorf("project not found: %s", identifier)
 	}
 
-	return outputProjectDetailProto(cfg, resp.Project)
+	relClient, relErr := deps.relationshipClient(cfg)
+	if relErr == nil {
+		defer relClient.Close()
+	}
+	summary := collectProjectSummary(ctx, conn, relClient, relErr, tenantID, resp.Project)
+	return outputProjectSummary(cfg, summary)
+}
+
+// runProjectListWithStats lists projects with their activity stats.
+func runProjectListWithStats(ctx context.Context, deps *ProjectCommandDeps, nameSearch, keyword, statusFilter, sortBy string, limit int32, alwaysInclude []string, since string) error {
+	sinceTime, err := parseDateOrDuration(since)
+	if err != nil || sinceTime == nil {
+		return fmt.Errorf("invalid --since %q: use a duration like 30d or a date (YYYY-MM-DD)", since)
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectProjectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tenantID := getTenantIDForProject(deps)
+	resp, err := projectv1.NewProjectServiceClient(conn).ListProjects(ctx, &projectv1.ListProjectsRequest{Filter: &projectv1.ProjectFilter{
+		TenantId:           tenantID,
+		NameSearch:         nameSearch,
+		Keyword:            keyword,
+		Status:             statusFilter,
+		SortBy:             sortBy,
+		Limit:              limit,
+		AlwaysIncludeNames: alwaysInclude,
+	}})
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	// Linked entity counts come from the relationship graph; without it the
+	// listing still shows content activity.
+	relClient, err := deps.relationshipClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: linked entity counts unavailable: %v\n", err)
+		relClient = nil
+	} else {
+		defer relClient.Close()
+	}
+
+	activity := collectProjectActivity(ctx, conn, relClient, tenantID, resp.Projects, *sinceTime)
+	return outputProjectActivity(cfg, activity, *sinceTime)
 }
 
 // runProjectDelete executes the project delete command via gRPC.
@@ -530,6 +604,10 @@ func runProjectDelete(ctx context.Context, deps *ProjectCommandDeps, identifier
 		return fmt.Errorf("deleting project: %w", err)
 	}
 
+	if getProjectOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ProjectActionResult{ProjectID: project.Id, Project: project.Name, Action: "delete"}, "Deleted project: "+project.Name)
+	}
+
 	fmt.Printf("\033[32mDeleted project:\033[0m %s (ID: %d)\n", project.Name, project.Id)
 	return nil
 }
@@ -598,6 +676,10 @@ func runProjectUpdate(ctx context.Context, deps *ProjectCommandDeps, identifier,
 	}
 
 	p := resp.Project
+	if getProjectOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, p, "Updated project: "+p.Name)
+	}
+
 	fmt.Printf("\033[32mUpdated project:\033[0m %s (ID: %d)\n", p.Name, p.Id)
 	if descSet {
 		fmt.Printf("  Description: %s\n", p.Description)
@@ -611,6 +693,13 @@ func runProjectUpdate(ctx context.Context, deps *ProjectCommandDeps, identifier,
 
 // ==================== Output Functions ====================
 
+// ProjectActionResult is the JSON result of a project delete.
+type ProjectActionResult struct {
+	ProjectID int64  `json:"project_id"`
+	Project   string `json:"project"`
+	Action    string `json:"action"`
+}
+
 // getProjectOutputFormat returns the output format from flag or config.
 func getProjectOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if projectOutput != "" {
@@ -753,7 +842,7 @@ func outputProjectJSON(v interface{}) error {
 }
 
 func outputProjectYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -1008,7 +1097,7 @@ func runProjectContent(ctx context.Context, deps *ProjectCommandDeps, identifier
 			"items":       resp.Items,
 		})
 	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+		return NewYAMLEncoder(os.Stdout).Encode(map[string]interface{}{
 			"project":     projResp.Project.Name,
 			"project_id":  projectID,
 			"total_count": resp.TotalCount,
@@ -1071,7 +1160,7 @@ func runProjectStats(ctx context.Context, deps *ProjectCommandDeps, identifier s
 			"breakdown":                 resp.Breakdown,
 		})
 	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+		return NewYAMLEncoder(os.Stdout).Encode(map[string]interface{}{
 			"project":                   projResp.Project.Name,
 			"total_attributed_sources":  resp.TotalAttributedSources,
 			"total_attributed_assertions": resp.TotalAttributedAssertions,
@@ -1131,7 +1220,7 @@ func runProjectUnattributed(ctx context.Context, deps *ProjectCommandDeps, since
 			"items":       resp.Items,
 		})
 	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+		return NewYAMLEncoder(os.Stdout).Encode(map[string]interface{}{
 			"total_count": resp.TotalCount,
 			"items":       resp.Items,
 		})
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assertionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/assertions/v1"
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	projectv1 "github.com/otherjamesbrown/penf-cli/api/proto/project/v1"
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// projectSummaryLimit is how many recent assertions and content items
+// 'project show' lists.
+const projectSummaryLimit = 5
+
+// ProjectActivity is a project with the activity stats shown by
+// 'project list --with-stats'. The project's fields are inlined, so the JSON
+// is a superset of plain 'project list' output.
+type ProjectActivity struct {
+	*projectv1.Project `yaml:",inline"`
+	LinkedEntities     int64      `json:"linked_entities" yaml:"linked_entities"`
+	RecentContent      int64      `json:"recent_content" yaml:"recent_content"`
+	LastActivity       *time.Time `json:"last_activity,omitempty" yaml:"last_activity,omitempty"`
+}
+
+// ProjectMember is an entity linked to a project by a works_on relationship.
+type ProjectMember struct {
+	EntityID   string  `json:"entity_id" yaml:"entity_id"`
+	Name       string  `json:"name" yaml:"name"`
+	Type       string  `json:"type" yaml:"type"`
+	Confidence float32 `json:"confidence" yaml:"confidence"`
+}
+
+// ProjectSummary is the 'project show' view: the project's fields (inlined,
+// as before) plus its members, recent assertions and attributed content.
+// Parts that could not be fetched are reported in Warnings.
+type ProjectSummary struct {
+	*projectv1.Project `yaml:",inline"`
+	Members            []ProjectMember                 `json:"members" yaml:"members"`
+	AssertionCount     int64                           `json:"assertion_count" yaml:"assertion_count"`
+	RecentAssertions   []*assertionsv1.AssertionDetail `json:"recent_assertions" yaml:"recent_assertions"`
+	ContentCount       int64                           `json:"content_count" yaml:"content_count"`
+	RecentContent      []*contentv1.ProjectContentItem `json:"recent_content" yaml:"recent_content"`
+	Warnings           []string                        `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// relationshipClient connects to the relationship service, which supplies
+// project members and linked entity counts.
+func (d *ProjectCommandDeps) relationshipClient(cfg *config.CLIConfig) (*client.RelationshipClient, error) {
+	if d.InitRelClient == nil {
+		return nil, errors.New("relationship service not configured")
+	}
+	return d.InitRelClient(cfg)
+}
+
+// collectProjectActivity adds activity stats to each project: the relation
+// count of the matching project entity in the relationship graph, and the
+// amount and recency of attributed content. A project whose stats cannot be
+// fetched keeps zero values rather than failing the listing.
+func collectProjectActivity(ctx context.Context, conn *grpc.ClientConn, relClient *client.RelationshipClient, tenantID string, projects []*projectv1.Project, since time.Time) []ProjectActivity {
+	entities := map[string]*client.RelEntity{}
+	if relClient != nil {
+		if list, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+			TenantID:   tenantID,
+			EntityType: relationshipv1.EntityType_ENTITY_TYPE_PROJECT,
+			PageSize:   500,
+		}); err == nil {
+			for _, e := range list {
+				for _, name := range append([]string{e.Name, e.CanonicalName}, e.Aliases...) {
+					if name != "" {
+						entities[strings.ToLower(name)] = e
+					}
+				}
+			}
+		}
+	}
+
+	contentClient := contentv1.NewContentProcessorServiceClient(conn)
+	activity := make([]ProjectActivity, 0, len(projects))
+	for _, p := range projects {
+		a := ProjectActivity{Project: p}
+		if e := entities[strings.ToLower(p.Name)]; e != nil {
+			a.LinkedEntities = int64(e.RelationCount)
+		}
+
+		a.RecentContent, a.LastActivity = projectContentActivity(ctx, contentClient, tenantID, p.Id, since)
+		activity = append(activity, a)
+	}
+	return activity
+}
+
+// projectContentActivity returns how much content was attributed to the
+// project since the given time, and when the newest attributed item was
+// created. The server reports the newest time, so it does not depend on the
+// order items are listed in; servers that predate latest_created_at fall back
+// to the listed item. Older content is only looked up when nothing is recent.
+// Stats that cannot be fetched are left zero.
+func projectContentActivity(ctx context.Context, contentClient contentv1.ContentProcessorServiceClient, tenantID string, projectID int64, since time.Time) (int64, *time.Time) {
+	resp, err := contentClient.ListProjectContent(ctx, &contentv1.ListProjectContentRequest{
+		TenantId:  tenantID,
+		ProjectId: projectID,
+		Since:     timestamppb.New(since),
+		PageSize:  1,
+	})
+	if err != nil {
+		return 0, nil
+	}
+	if resp.TotalCount > 0 {
+		return resp.TotalCount, latestCreatedAt(resp)
+	}
+
+	resp, err = contentClient.ListProjectContent(ctx, &contentv1.ListProjectContentRequest{
+		TenantId:  tenantID,
+		ProjectId: projectID,
+		PageSize:  1,
+	})
+	if err != nil {
+		return 0, nil
+	}
+	return 0, latestCreatedAt(resp)
+}
+
+// latestCreatedAt returns the newest creation time the server reported. A
+// server that does not set latest_created_at gets the newest creation time
+// among the returned items instead. It returns nil if there is neither.
+func latestCreatedAt(resp *contentv1.ListProjectContentResponse) *time.Time {
+	if resp.LatestCreatedAt != nil {
+		t := resp.LatestCreatedAt.AsTime()
+		return &t
+	}
+	var latest *time.Time
+	for _, item := range resp.Items {
+		if item.CreatedAt == nil {
+			continue
+		}
+		if t := item.CreatedAt.AsTime(); latest == nil || t.After(*latest) {
+			latest = &t
+		}
+	}
+	return latest
+}
+
+// collectProjectSummary gathers the members, recent assertions and recent
+// content shown by 'project show'.
+func collectProjectSummary(ctx context.Context, conn *grpc.ClientConn, relClient *client.RelationshipClient, relErr error, tenantID string, project *projectv1.Project) *ProjectSummary {
+	summary := &ProjectSummary{
+		Project:          project,
+		Members:          []ProjectMember{},
+		RecentAssertions: []*assertionsv1.AssertionDetail{},
+		RecentContent:    []*contentv1.ProjectContentItem{},
+	}
+	warn := func(format string, args ...interface{}) {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	if relClient == nil {
+		warn("members unavailable: %v", relErr)
+	} else if members, err := listProjectMembers(ctx, relClient, tenantID, project.Name); err != nil {
+		warn("members unavailable: %v", err)
+	} else {
+		summary.Members = members
+	}
+
+	projectID := project.Id
+	assertResp, err := assertionsv1.NewAssertionsServiceClient(conn).ListAssertions(ctx, &assertionsv1.ListAssertionsRequest{
+		TenantId:  tenantID,
+		ProjectId: &projectID,
+		Limit:     projectSummaryLimit,
+	})
+	if err != nil {
+		warn("assertions unavailable: %v", err)
+	} else {
+		summary.AssertionCount = assertResp.TotalCount
+		summary.RecentAssertions = assertResp.Assertions
+	}
+
+	contentResp, err := contentv1.NewContentProcessorServiceClient(conn).ListProjectContent(ctx, &contentv1.ListProjectContentRequest{
+		TenantId:  tenantID,
+		ProjectId: projectID,
+		PageSize:  projectSummaryLimit,
+	})
+	if err != nil {
+		warn("content unavailable: %v", err)
+	} else {
+		summary.ContentCount = contentResp.TotalCount
+		summary.RecentContent = contentResp.Items
+	}
+
+	return summary
+}
+
+// listProjectMembers finds the project's entity in the relationship graph by
+// name and returns the entities on the other side of its works_on
+// relationships. A project with no graph entity has no members.
+func listProjectMembers(ctx context.Context, relClient *client.RelationshipClient, tenantID, projectName string) ([]ProjectMember, error) {
+	candidates, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+		TenantID:   tenantID,
+		EntityType: relationshipv1.EntityType_ENTITY_TYPE_PROJECT,
+		Search:     projectName,
+		PageSize:   50,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entity := matchEntityByName(candidates, projectName)
+	if entity == nil {
+		return []ProjectMember{}, nil
+	}
+
+	rels, _, err := relClient.ListRelationships(ctx, &client.ListRelationshipsRequest{
+		TenantID:         tenantID,
+		EntityID:         entity.ID,
+		RelationshipType: stringToRelType("works_on"),
+		PageSize:         100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projectMembersFromRelationships(entity.ID, rels), nil
+}
+
+// matchEntityByName returns the entity whose name, canonical name or alias
+// matches name case-insensitively.
+func matchEntityByName(entities []*client.RelEntity, name string) *client.RelEntity {
+	for _, e := range entities {
+		if strings.EqualFold(e.Name, name) || strings.EqualFold(e.CanonicalName, name) {
+			return e
+		}
+		for _, alias := range e.Aliases {
+			if strings.EqualFold(alias, name) {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// projectMembersFromRelationships returns the far side of each relationship
+// touching projectEntityID, once per entity, in relationship order.
+func projectMembersFromRelationships(projectEntityID string, rels []*client.Relationship) []ProjectMember {
+	members := []ProjectMember{}
+	seen := map[string]bool{}
+	for _, r := range rels {
+		other := r.SourceEntity
+		if other != nil && other.ID == projectEntityID {
+			other = r.TargetEntity
+		}
+		if other == nil || other.ID == projectEntityID || seen[other.ID] {
+			continue
+		}
+		seen[other.ID] = true
+		members = append(members, ProjectMember{
+			EntityID:   other.ID,
+			Name:       other.Name,
+			Type:       other.Type,
+			Confidence: r.Confidence,
+		})
+	}
+	return members
+}
+
+// outputProjectActivity writes 'project list --with-stats' output.
+func outputProjectActivity(cfg *config.CLIConfig, activity []ProjectActivity, since time.Time) error {
+	switch getProjectOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		return outputProjectJSON(activity)
+	case config.OutputFormatYAML:
+		return outputProjectYAML(activity)
+	default:
+		return outputProjectActivityTable(activity, since)
+	}
+}
+
+// outputProjectActivityTable prints projects with their activity stats.
+func outputProjectActivityTable(activity []ProjectActivity, since time.Time) error {
+	if len(activity) == 0 {
+		fmt.Println("No projects found.")
+		return nil
+	}
+
+	fmt.Printf("Projects (%d), recent = since %s:\n\n", len(activity), since.Format("2006-01-02"))
+	fmt.Printf("  %-6s  %-30s  %-8s  %8s  %8s  %s\n", "ID", "NAME", "STATUS", "ENTITIES", "RECENT", "LAST ACTIVITY")
+	fmt.Printf("  %-6s  %-30s  %-8s  %8s  %8s  %s\n", "--", "----", "------", "--------", "------", "-------------")
+	for _, a := range activity {
+		status := a.Status
+		if status == "" {
+			status = "-"
+		}
+		last := "-"
+		if a.LastActivity != nil {
+			last = formatRelativeTime(*a.LastActivity)
+		}
+		fmt.Printf("  %-6d  %-30s  %-8s  %8d  %8d  %s\n",
+			a.Id, truncateString(a.Name, 30), status, a.LinkedEntities, a.RecentContent, last)
+	}
+	fmt.Println()
+	return nil
+}
+
+// outputProjectSummary writes 'project show' output.
+func outputProjectSummary(cfg *config.CLIConfig, summary *ProjectSummary) error {
+	switch getProjectOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		return outputProjectJSON(summary)
+	case config.OutputFormatYAML:
+		return outputProjectYAML(summary)
+	default:
+		if err := outputProjectDetailTextProto(summary.Project); err != nil {
+			return err
+		}
+		outputProjectSummaryText(summary)
+		return nil
+	}
+}
+
+// outputProjectSummaryText prints the activity sections under the project
+// details.
+func outputProjectSummaryText(summary *ProjectSummary) {
+	fmt.Println()
+	fmt.Printf("  \033[1mMembers (%d):\033[0m\n", len(summary.Members))
+	if len(summary.Members) == 0 {
+		fmt.Println("    (none - link people with 'penf relationship create <person> <project> --type works_on')")
+	}
+	for _, m := range summary.Members {
+		fmt.Printf("    %-30s %-12s %.2f\n", truncateString(m.Name, 30), m.Type, m.Confidence)
+	}
+
+	fmt.Println()
+	fmt.Printf("  \033[1mRecent assertions (%d total):\033[0m\n", summary.AssertionCount)
+	if len(summary.RecentAssertions) == 0 {
+		fmt.Println("    (none)")
+	}
+	for _, a := range summary.RecentAssertions {
+		date := "-"
+		if a.CreatedAt != nil {
+			date = a.CreatedAt.AsTime().Format("2006-01-02")
+		}
+		fmt.Printf("    %s  %-10s %s\n", date, a.AssertionType, truncateString(a.Description, 70))
+	}
+
+	fmt.Println()
+	fmt.Printf("  \033[1mRecent content (%d total):\033[0m\n", summary.ContentCount)
+	if len(summary.RecentContent) == 0 {
+		fmt.Println("    (none)")
+	}
+	for _, item := range summary.RecentContent {
+		date := "-"
+		if item.CreatedAt != nil {
+			date = item.CreatedAt.AsTime().Format("2006-01-02")
+		}
+		fmt.Printf("    %s  %-8d %s\n", date, item.SourceId, truncateString(item.Title, 60))
+	}
+
+	for _, w := range summary.Warnings {
+		fmt.Printf("\n  \033[33mWarning:\033[0m %s", w)
+	}
+	if len(summary.Warnings) > 0 {
+		fmt.Println()
+	}
+}
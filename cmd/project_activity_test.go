@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	contentv1 "github.com/otherjamesbrown/penf-cli/api/proto/content/v1"
+	projectv1 "github.com/otherjamesbrown/penf-cli/api/proto/project/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestProjectListCommand_WithStatsFlags(t *testing.T) {
+	cmd := NewProjectCommand(createProjectTestDeps(projectMockConfig()))
+	listCmd, _, _ := cmd.Find([]string{"list"})
+	require.NotNil(t, listCmd)
+
+	for _, flag := range []string{"with-stats", "since"} {
+		assert.NotNil(t, listCmd.Flags().Lookup(flag), flag)
+	}
+	assert.Equal(t, "30d", listCmd.Flags().Lookup("since").DefValue)
+}
+
+func TestProjectActivity_JSONInlinesProject(t *testing.T) {
+	last := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(ProjectActivity{
+		Project:        &projectv1.Project{Id: 7, Name: "MTC"},
+		LinkedEntities: 4,
+		RecentContent:  12,
+		LastActivity:   &last,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 7, "name": "MTC", "linked_entities": 4, "recent_content": 12, "last_activity": "2026-03-01T12:00:00Z"}`, string(data))
+}
+
+// projectContentClient answers ListProjectContent with recent for requests
+// with a since time and all otherwise.
+type projectContentClient struct {
+	contentv1.ContentProcessorServiceClient
+	recent, all *contentv1.ListProjectContentResponse
+	calls       int
+}
+
+func (c *projectContentClient) ListProjectContent(ctx context.Context, req *contentv1.ListProjectContentRequest, opts ...grpc.CallOption) (*contentv1.ListProjectContentResponse, error) {
+	c.calls++
+	if req.Since != nil {
+		return c.recent, nil
+	}
+	return c.all, nil
+}
+
+func TestProjectContentActivity(t *testing.T) {
+	since := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	older := time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)
+
+	// The newest time comes from the server, not from the first listed item.
+	c := &projectContentClient{recent: &contentv1.ListProjectContentResponse{
+		TotalCount:      3,
+		Items:           []*contentv1.ProjectContentItem{{CreatedAt: timestamppb.New(since)}},
+		LatestCreatedAt: timestamppb.New(newest),
+	}}
+	recent, last := projectContentActivity(context.Background(), c, "t1", 7, since)
+	assert.Equal(t, int64(3), recent)
+	require.NotNil(t, last)
+	assert.Equal(t, newest, *last)
+	assert.Equal(t, 1, c.calls, "recent content already gives the last activity")
+
+	c = &projectContentClient{
+		recent: &contentv1.ListProjectContentResponse{},
+		all:    &contentv1.ListProjectContentResponse{TotalCount: 9, LatestCreatedAt: timestamppb.New(older)},
+	}
+	recent, last = projectContentActivity(context.Background(), c, "t1", 7, since)
+	assert.Equal(t, int64(0), recent)
+	require.NotNil(t, last)
+	assert.Equal(t, older, *last)
+
+	// Servers without latest_created_at fall back to the listed items.
+	c = &projectContentClient{recent: &contentv1.ListProjectContentResponse{
+		TotalCount: 2,
+		Items:      []*contentv1.ProjectContentItem{{CreatedAt: timestamppb.New(newest)}},
+	}}
+	recent, last = projectContentActivity(context.Background(), c, "t1", 7, since)
+	assert.Equal(t, int64(2), recent)
+	require.NotNil(t, last)
+	assert.Equal(t, newest, *last)
+}
+
+func TestMatchEntityByName(t *testing.T) {
+	entities := []*client.RelEntity{
+		{ID: "ent-1", Name: "MTC Migration"},
+		{ID: "ent-2", Name: "Mobile", Aliases: []string{"MTC"}},
+	}
+	assert.Equal(t, "ent-1", matchEntityByName(entities, "mtc migration").ID)
+	assert.Equal(t, "ent-2", matchEntityByName(entities, "mtc").ID)
+	assert.Nil(t, matchEntityByName(entities, "Other"))
+}
+
+func TestProjectMembersFromRelationships(t *testing.T) {
+	project := &client.RelEntity{ID: "ent-proj", Name: "MTC"}
+	alice := &client.RelEntity{ID: "ent-alice", Name: "Alice", Type: "person"}
+	bob := &client.RelEntity{ID: "ent-bob", Name: "Bob", Type: "person"}
+	rels := []*client.Relationship{
+		{SourceEntity: alice, TargetEntity: project, Confidence: 0.9},
+		{SourceEntity: project, TargetEntity: bob, Confidence: 0.7},
+		{SourceEntity: alice, TargetEntity: project, Confidence: 0.5},
+	}
+
+	members := projectMembersFromRelationships("ent-proj", rels)
+	assert.Equal(t, []ProjectMember{
+		{EntityID: "ent-alice", Name: "Alice", Type: "person", Confidence: 0.9},
+		{EntityID: "ent-bob", Name: "Bob", Type: "person", Confidence: 0.7},
+	}, members)
+}
@@ -9,7 +9,6 @@ import (
 	"sort"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	qualityv1 "github.com/otherjamesbrown/penf-cli/api/proto/quality/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -20,6 +19,10 @@ type QualityCommandDeps struct {
 	Config       *config.CLIConfig
 	OutputFormat config.OutputFormat
 	LoadConfig   func() (*config.CLIConfig, error)
+
+	// FetchCoverage returns embedding coverage from pipeline stats for
+	// 'quality report'. Nil leaves coverage out of the report.
+	FetchCoverage func(ctx context.Context) (*QualityCoverage, error)
 }
 
 // QualitySummary represents aggregated quality metrics.
@@ -96,6 +99,8 @@ Quality and entity details are in Context Palace knowledge shards.`,
 	cmd.AddCommand(newQualitySummaryCommand(deps))
 	cmd.AddCommand(newQualityEntitiesCommand(deps))
 	cmd.AddCommand(newQualityExtractionsCommand(deps))
+	cmd.AddCommand(newQualityReportCommand(deps))
+	cmd.AddCommand(newQualityTrendCommand(deps))
 
 	return cmd
 }
@@ -633,6 +638,6 @@ func outputQualityJSON(v interface{}) error {
 
 // outputQualityYAML outputs data as YAML.
 func outputQualityYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
@@ -0,0 +1,529 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	qualityv1 "github.com/otherjamesbrown/penf-cli/api/proto/quality/v1"
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// qualityHistoryFile is the file under the config dir that 'quality report'
+// appends snapshots to, one JSON report per line.
+const qualityHistoryFile = "quality-history.jsonl"
+
+// lowConfidenceThreshold is the entity confidence below which an entity
+// counts as low confidence in reports and trends.
+const lowConfidenceThreshold = 0.7
+
+// confidenceBucketBounds are the lower bounds of the entity confidence
+// distribution buckets, highest first.
+var confidenceBucketBounds = []struct {
+	label string
+	min   float32
+}{
+	{">=0.90", 0.9},
+	{"0.70-0.89", 0.7},
+	{"0.50-0.69", 0.5},
+	{"<0.50", 0},
+}
+
+// QualityCoverage is embedding coverage derived from pipeline stats.
+type QualityCoverage struct {
+	SourcesTotal     int64   `json:"sources_total" yaml:"sources_total"`
+	SourcesCompleted int64   `json:"sources_completed" yaml:"sources_completed"`
+	SourcesPending   int64   `json:"sources_pending" yaml:"sources_pending"`
+	EmbeddingsTotal  int64   `json:"embeddings_total" yaml:"embeddings_total"`
+	CoveragePercent  float64 `json:"coverage_percent" yaml:"coverage_percent"`
+}
+
+// ConfidenceBucket is the number of entities in one confidence range.
+type ConfidenceBucket struct {
+	Range string `json:"range" yaml:"range"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// EntityConfidenceDistribution summarizes the confidence of the entities
+// flagged by quality checks.
+type EntityConfidenceDistribution struct {
+	Entities          int                `json:"entities" yaml:"entities"`
+	LowConfidence     int                `json:"low_confidence" yaml:"low_confidence"`
+	AverageConfidence float64            `json:"average_confidence" yaml:"average_confidence"`
+	Buckets           []ConfidenceBucket `json:"buckets" yaml:"buckets"`
+}
+
+// QualityReport is a point-in-time quality assessment. Sections that could
+// not be fetched are omitted and the reason recorded in Errors.
+type QualityReport struct {
+	GeneratedAt      time.Time                     `json:"generated_at" yaml:"generated_at"`
+	Server           string                        `json:"server" yaml:"server"`
+	TenantID         string                        `json:"tenant_id" yaml:"tenant_id"`
+	Coverage         *QualityCoverage              `json:"coverage,omitempty" yaml:"coverage,omitempty"`
+	Issues           *QualitySummary               `json:"issues,omitempty" yaml:"issues,omitempty"`
+	EntityConfidence *EntityConfidenceDistribution `json:"entity_confidence,omitempty" yaml:"entity_confidence,omitempty"`
+	Conflicts        *int64                        `json:"pending_conflicts,omitempty" yaml:"pending_conflicts,omitempty"`
+	Duplicates       *int64                        `json:"duplicate_pairs,omitempty" yaml:"duplicate_pairs,omitempty"`
+	Errors           []string                      `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// QualityTrendPoint is the state of the key quality metrics on one day.
+type QualityTrendPoint struct {
+	Date                  string   `json:"date" yaml:"date"`
+	CoveragePercent       *float64 `json:"coverage_percent,omitempty" yaml:"coverage_percent,omitempty"`
+	HighIssues            *int64   `json:"high_issues,omitempty" yaml:"high_issues,omitempty"`
+	TotalIssues           *int64   `json:"total_issues,omitempty" yaml:"total_issues,omitempty"`
+	LowConfidenceEntities *int     `json:"low_confidence_entities,omitempty" yaml:"low_confidence_entities,omitempty"`
+	Conflicts             *int64   `json:"pending_conflicts,omitempty" yaml:"pending_conflicts,omitempty"`
+	Duplicates            *int64   `json:"duplicate_pairs,omitempty" yaml:"duplicate_pairs,omitempty"`
+}
+
+// QualityTrend is the output of 'penf quality trend'.
+type QualityTrend struct {
+	TenantID string              `json:"tenant_id" yaml:"tenant_id"`
+	Days     int                 `json:"days" yaml:"days"`
+	Points   []QualityTrendPoint `json:"points" yaml:"points"`
+}
+
+// newQualityReportCommand creates the 'quality report' subcommand.
+func newQualityReportCommand(deps *QualityCommandDeps) *cobra.Command {
+	var (
+		outputFormat string
+		limit        int
+		noRecord     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Produce a full quality assessment",
+		Long: `Produce a structured quality assessment of the knowledge base.
+
+The report combines:
+  - Embedding coverage: completed sources out of all sources, from pipeline stats
+  - Quality issues by severity (as in 'penf quality summary')
+  - Confidence distribution of the entities flagged by quality checks
+  - Pending relationship conflicts
+  - Likely duplicate entity pairs
+
+A section that cannot be fetched is left out and the error is listed at the
+end of the report, so one unavailable service does not hide the rest.
+
+Each run is appended to ~/.penf/quality-history.jsonl so that
+'penf quality trend' can show how the metrics move over time. Use
+--no-record to skip this.`,
+		Example: `  # Show the quality report
+  penf quality report
+
+  # Export as JSON
+  penf quality report -o json > quality.json
+
+  # Report without recording a history snapshot
+  penf quality report --no-record`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQualityReport(cmd.Context(), deps, outputFormat, limit, noRecord)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 500, "Maximum number of flagged entities to sample for the confidence distribution")
+	cmd.Flags().BoolVar(&noRecord, "no-record", false, "Do not record this report in the local quality history")
+
+	return cmd
+}
+
+// newQualityTrendCommand creates the 'quality trend' subcommand.
+func newQualityTrendCommand(deps *QualityCommandDeps) *cobra.Command {
+	var (
+		outputFormat string
+		days         int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show how quality metrics changed over time",
+		Long: `Show how the quality report metrics moved over the last N days.
+
+The backend does not keep quality history, so the trend is built from the
+snapshots recorded locally by 'penf quality report' for the current server
+and tenant. Run the report regularly (for example daily from cron) to build
+up history. When several reports ran on the same day, the latest one is used.`,
+		Example: `  # Show the trend for the last 30 days
+  penf quality trend
+
+  # Last week as JSON
+  penf quality trend --days 7 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQualityTrend(deps, outputFormat, days)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().IntVar(&days, "days", 30, "Number of days of history to show")
+
+	return cmd
+}
+
+// runQualityReport executes the quality report command.
+func runQualityReport(ctx context.Context, deps *QualityCommandDeps, format string, limit int, noRecord bool) error {
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tenantID := cfg.EffectiveTenantID()
+	report := &QualityReport{
+		GeneratedAt: time.Now().UTC(),
+		Server:      cfg.ServerAddress,
+		TenantID:    tenantID,
+	}
+
+	if deps.FetchCoverage != nil {
+		if coverage, err := deps.FetchCoverage(ctx); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("embedding coverage: %v", err))
+		} else {
+			report.Coverage = coverage
+		}
+	}
+
+	qualityClient := qualityv1.NewQualityServiceClient(conn)
+	if resp, err := qualityClient.GetQualitySummary(ctx, &qualityv1.GetQualitySummaryRequest{TenantId: tenantID}); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("quality summary: %v", err))
+	} else {
+		report.Issues = &QualitySummary{
+			HighCount:   resp.GetHighCount(),
+			MediumCount: resp.GetMediumCount(),
+			LowCount:    resp.GetLowCount(),
+		}
+	}
+
+	if resp, err := qualityClient.GetEntityQuality(ctx, &qualityv1.GetEntityQualityRequest{TenantId: tenantID, Limit: int32(limit)}); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("entity confidence: %v", err))
+	} else {
+		confidences := make([]float32, len(resp.GetItems()))
+		for i, item := range resp.GetItems() {
+			confidences[i] = item.GetConfidence()
+		}
+		report.EntityConfidence = buildConfidenceDistribution(confidences)
+	}
+
+	relClient := relationshipv1.NewRelationshipServiceClient(conn)
+	if resp, err := relClient.ListConflicts(ctx, &relationshipv1.ListConflictsRequest{
+		TenantId: tenantID,
+		Status:   relationshipv1.ConflictStatus_CONFLICT_STATUS_PENDING.Enum(),
+		Limit:    1,
+	}); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("conflicts: %v", err))
+	} else {
+		count := resp.GetTotalCount()
+		report.Conflicts = &count
+	}
+
+	if resp, err := relClient.FindDuplicates(ctx, &relationshipv1.FindDuplicatesRequest{TenantId: tenantID}); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("duplicates: %v", err))
+	} else {
+		count := int64(resp.GetTotalCount())
+		if n := int64(len(resp.GetDuplicatePairs())); n > count {
+			count = n
+		}
+		report.Duplicates = &count
+	}
+
+	if !noRecord {
+		if path, err := qualityHistoryPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: not recording quality history: %v\n", err)
+		} else if err := appendQualityHistory(path, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: not recording quality history: %v\n", err)
+		}
+	}
+
+	outFormat := cfg.OutputFormat
+	if format != "" {
+		outFormat = config.OutputFormat(format)
+	}
+
+	switch outFormat {
+	case config.OutputFormatJSON:
+		return outputQualityJSON(report)
+	case config.OutputFormatYAML:
+		return outputQualityYAML(report)
+	default:
+		outputQualityReportText(os.Stdout, report)
+		return nil
+	}
+}
+
+// runQualityTrend executes the quality trend command.
+func runQualityTrend(deps *QualityCommandDeps, format string, days int) error {
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	path, err := qualityHistoryPath()
+	if err != nil {
+		return err
+	}
+	reports, err := loadQualityHistory(path)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	trend := buildQualityTrend(reports, cfg.ServerAddress, cfg.EffectiveTenantID(), since)
+	trend.Days = days
+	if len(trend.Points) == 0 {
+		return fmt.Errorf("no quality history in the last %d days; run 'penf quality report' to record a snapshot", days)
+	}
+
+	outFormat := cfg.OutputFormat
+	if format != "" {
+		outFormat = config.OutputFormat(format)
+	}
+
+	switch outFormat {
+	case config.OutputFormatJSON:
+		return outputQualityJSON(trend)
+	case config.OutputFormatYAML:
+		return outputQualityYAML(trend)
+	default:
+		outputQualityTrendText(os.Stdout, trend)
+		return nil
+	}
+}
+
+// buildConfidenceDistribution buckets entity confidence scores.
+func buildConfidenceDistribution(confidences []float32) *EntityConfidenceDistribution {
+	dist := &EntityConfidenceDistribution{Entities: len(confidences)}
+	counts := make([]int, len(confidenceBucketBounds))
+
+	var sum float64
+	for _, c := range confidences {
+		sum += float64(c)
+		if c < lowConfidenceThreshold {
+			dist.LowConfidence++
+		}
+		for i, b := range confidenceBucketBounds {
+			if c >= b.min {
+				counts[i]++
+				break
+			}
+		}
+	}
+	if len(confidences) > 0 {
+		dist.AverageConfidence = sum / float64(len(confidences))
+	}
+
+	for i, b := range confidenceBucketBounds {
+		dist.Buckets = append(dist.Buckets, ConfidenceBucket{Range: b.label, Count: counts[i]})
+	}
+	return dist
+}
+
+// qualityHistoryPath returns the quality history path under ~/.penf/.
+func qualityHistoryPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, qualityHistoryFile), nil
+}
+
+// appendQualityHistory appends a report to the history file.
+func appendQualityHistory(path string, report *QualityReport) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("opening history file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing history file: %w", err)
+	}
+	return f.Close()
+}
+
+// loadQualityHistory reads the reports recorded in the history file. A
+// missing file is an empty history; unreadable lines are skipped.
+func loadQualityHistory(path string) ([]QualityReport, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading quality history: %w", err)
+	}
+	defer f.Close()
+
+	var reports []QualityReport
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r QualityReport
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		reports = append(reports, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading quality history: %w", err)
+	}
+	return reports, nil
+}
+
+// buildQualityTrend selects the reports for server and tenant generated
+// since the given time and reduces them to one point per day, oldest first.
+func buildQualityTrend(reports []QualityReport, server, tenantID string, since time.Time) QualityTrend {
+	latest := make(map[string]QualityReport)
+	for _, r := range reports {
+		if r.Server != server || r.TenantID != tenantID || r.GeneratedAt.Before(since) {
+			continue
+		}
+		day := r.GeneratedAt.Local().Format("2006-01-02")
+		if prev, ok := latest[day]; !ok || r.GeneratedAt.After(prev.GeneratedAt) {
+			latest[day] = r
+		}
+	}
+
+	trend := QualityTrend{TenantID: tenantID, Points: []QualityTrendPoint{}}
+	for day, r := range latest {
+		point := QualityTrendPoint{Date: day, Conflicts: r.Conflicts, Duplicates: r.Duplicates}
+		if r.Coverage != nil {
+			pct := r.Coverage.CoveragePercent
+			point.CoveragePercent = &pct
+		}
+		if r.Issues != nil {
+			high := r.Issues.HighCount
+			total := r.Issues.HighCount + r.Issues.MediumCount + r.Issues.LowCount
+			point.HighIssues, point.TotalIssues = &high, &total
+		}
+		if r.EntityConfidence != nil {
+			low := r.EntityConfidence.LowConfidence
+			point.LowConfidenceEntities = &low
+		}
+		trend.Points = append(trend.Points, point)
+	}
+	sort.Slice(trend.Points, func(i, j int) bool { return trend.Points[i].Date < trend.Points[j].Date })
+	return trend
+}
+
+// outputQualityReportText prints the quality report in human-readable form.
+func outputQualityReportText(w io.Writer, report *QualityReport) {
+	fmt.Fprintf(w, "Quality Report (tenant %s, %s)\n\n", report.TenantID, report.GeneratedAt.Local().Format("2006-01-02 15:04"))
+
+	if c := report.Coverage; c != nil {
+		fmt.Fprintf(w, "  Embedding coverage:  %.1f%% (%d/%d sources completed, %d pending, %d embeddings)\n",
+			c.CoveragePercent, c.SourcesCompleted, c.SourcesTotal, c.SourcesPending, c.EmbeddingsTotal)
+	}
+	if s := report.Issues; s != nil {
+		fmt.Fprintf(w, "  Quality issues:      %d high, %d medium, %d low\n", s.HighCount, s.MediumCount, s.LowCount)
+	}
+	if report.Conflicts != nil {
+		fmt.Fprintf(w, "  Pending conflicts:   %d\n", *report.Conflicts)
+	}
+	if report.Duplicates != nil {
+		fmt.Fprintf(w, "  Duplicate pairs:     %d\n", *report.Duplicates)
+	}
+	if d := report.EntityConfidence; d != nil {
+		fmt.Fprintf(w, "  Entity confidence:   %d flagged entities, average %.2f, %d below %.2f\n",
+			d.Entities, d.AverageConfidence, d.LowConfidence, lowConfidenceThreshold)
+		for _, b := range d.Buckets {
+			fmt.Fprintf(w, "    %-10s %d\n", b.Range, b.Count)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Unavailable:")
+		for _, e := range report.Errors {
+			fmt.Fprintf(w, "  - %s\n", e)
+		}
+	}
+}
+
+// outputQualityTrendText prints the quality trend as a table with the
+// change between the first and last day.
+func outputQualityTrendText(w io.Writer, trend QualityTrend) {
+	fmt.Fprintf(w, "Quality Trend (tenant %s, last %d days)\n\n", trend.TenantID, trend.Days)
+	fmt.Fprintf(w, "  %-10s  %8s  %5s  %6s  %8s  %9s  %10s\n", "DATE", "COVERAGE", "HIGH", "ISSUES", "LOW-CONF", "CONFLICTS", "DUPLICATES")
+	for _, p := range trend.Points {
+		fmt.Fprintf(w, "  %-10s  %8s  %5s  %6s  %8s  %9s  %10s\n", p.Date,
+			formatTrendPercent(p.CoveragePercent), formatTrendCount(p.HighIssues), formatTrendCount(p.TotalIssues),
+			formatTrendCount(p.LowConfidenceEntities), formatTrendCount(p.Conflicts), formatTrendCount(p.Duplicates))
+	}
+
+	if len(trend.Points) < 2 {
+		return
+	}
+	first, last := trend.Points[0], trend.Points[len(trend.Points)-1]
+	fmt.Fprintf(w, "  %-10s  %8s  %5s  %6s  %8s  %9s  %10s\n", "change",
+		formatTrendPercentDelta(first.CoveragePercent, last.CoveragePercent),
+		formatTrendDelta(first.HighIssues, last.HighIssues), formatTrendDelta(first.TotalIssues, last.TotalIssues),
+		formatTrendDelta(first.LowConfidenceEntities, last.LowConfidenceEntities),
+		formatTrendDelta(first.Conflicts, last.Conflicts), formatTrendDelta(first.Duplicates, last.Duplicates))
+}
+
+// formatTrendPercent formats an optional percentage, "-" when absent.
+func formatTrendPercent(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *v)
+}
+
+// formatTrendCount formats an optional count, "-" when absent.
+func formatTrendCount[T int | int64](v *T) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+// formatTrendPercentDelta formats the signed change between two optional
+// percentages.
+func formatTrendPercentDelta(from, to *float64) string {
+	if from == nil || to == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f", *to-*from)
+}
+
+// formatTrendDelta formats the signed change between two optional counts.
+func formatTrendDelta[T int | int64](from, to *T) string {
+	if from == nil || to == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+d", *to-*from)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildConfidenceDistribution(t *testing.T) {
+	dist := buildConfidenceDistribution([]float32{0.95, 0.9, 0.75, 0.6, 0.2})
+
+	if dist.Entities != 5 || dist.LowConfidence != 2 {
+		t.Errorf("entities = %d, low confidence = %d, want 5 and 2", dist.Entities, dist.LowConfidence)
+	}
+	if dist.AverageConfidence < 0.679 || dist.AverageConfidence > 0.681 {
+		t.Errorf("average = %f, want 0.68", dist.AverageConfidence)
+	}
+
+	want := map[string]int{">=0.90": 2, "0.70-0.89": 1, "0.50-0.69": 1, "<0.50": 1}
+	for _, b := range dist.Buckets {
+		if b.Count != want[b.Range] {
+			t.Errorf("bucket %s = %d, want %d", b.Range, b.Count, want[b.Range])
+		}
+	}
+}
+
+func qualityTestReport(at time.Time, tenantID string, high int64, conflicts int64) *QualityReport {
+	return &QualityReport{
+		GeneratedAt: at,
+		Server:      "localhost:50051",
+		TenantID:    tenantID,
+		Coverage:    &QualityCoverage{SourcesTotal: 100, SourcesCompleted: 80, CoveragePercent: 80},
+		Issues:      &QualitySummary{HighCount: high, MediumCount: 2, LowCount: 1},
+		Conflicts:   &conflicts,
+	}
+}
+
+func TestQualityHistory_Trend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), qualityHistoryFile)
+	now := time.Now().UTC()
+
+	for _, r := range []*QualityReport{
+		qualityTestReport(now.AddDate(0, 0, -40), "acme", 9, 9),
+		qualityTestReport(now.AddDate(0, 0, -3), "acme", 5, 4),
+		qualityTestReport(now.AddDate(0, 0, -3).Add(time.Minute), "acme", 4, 3),
+		qualityTestReport(now.AddDate(0, 0, -3), "other", 7, 7),
+		qualityTestReport(now, "acme", 1, 6),
+	} {
+		if err := appendQualityHistory(path, r); err != nil {
+			t.Fatalf("appendQualityHistory() error = %v", err)
+		}
+	}
+
+	reports, err := loadQualityHistory(path)
+	if err != nil {
+		t.Fatalf("loadQualityHistory() error = %v", err)
+	}
+	if len(reports) != 5 {
+		t.Fatalf("loaded %d reports, want 5", len(reports))
+	}
+
+	trend := buildQualityTrend(reports, "localhost:50051", "acme", now.AddDate(0, 0, -30))
+	if len(trend.Points) != 2 {
+		t.Fatalf("got %d points, want 2 (one per day, other tenant and old reports excluded)", len(trend.Points))
+	}
+	if got := *trend.Points[0].HighIssues; got != 4 {
+		t.Errorf("first day high issues = %d, want the latest report of the day (4)", got)
+	}
+	if trend.Points[0].LowConfidenceEntities != nil {
+		t.Errorf("low confidence = %d, want absent when the report had no entity section", *trend.Points[0].LowConfidenceEntities)
+	}
+
+	trend.Days = 30
+	var buf bytes.Buffer
+	outputQualityTrendText(&buf, trend)
+	got := buf.String()
+	for _, want := range []string{"80.0%", "+0.0", "-3", "+3"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trend output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestLoadQualityHistory_Missing(t *testing.T) {
+	reports, err := loadQualityHistory(filepath.Join(t.TempDir(), qualityHistoryFile))
+	if err != nil || len(reports) != 0 {
+		t.Errorf("loadQualityHistory() = %v, %v, want an empty history", reports, err)
+	}
+}
+
+func TestOutputQualityReportText(t *testing.T) {
+	report := qualityTestReport(time.Now(), "acme", 3, 2)
+	report.EntityConfidence = buildConfidenceDistribution([]float32{0.4, 0.9})
+	report.Errors = []string{"duplicates: unavailable"}
+
+	var buf bytes.Buffer
+	outputQualityReportText(&buf, report)
+	got := buf.String()
+	for _, want := range []string{
+		"Embedding coverage:  80.0% (80/100 sources completed",
+		"Quality issues:      3 high, 2 medium, 1 low",
+		"Pending conflicts:   2",
+		"2 flagged entities, average 0.65, 1 below 0.70",
+		"  - duplicates: unavailable",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Duplicate pairs") {
+		t.Errorf("report output shows duplicates that were not fetched:\n%s", got)
+	}
+}
@@ -62,7 +62,7 @@ func TestQualitySubcommands(t *testing.T) {
 	cmd := NewQualityCommand(deps)
 
 	subcommands := cmd.Commands()
-	expectedSubcmds := []string{"summary", "entities", "extractions"}
+	expectedSubcmds := []string{"summary", "entities", "extractions", "report", "trend"}
 
 	for _, expected := range expectedSubcmds {
 		found := false
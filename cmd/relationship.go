@@ -2,20 +2,25 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
+	"google.golang.org/grpc"
 
 	entityv1 "github.com/otherjamesbrown/penf-cli/api/proto/entity/v1"
 	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/pkg/enrichment/entities"
 )
 
 // Relationship types and structures.
@@ -72,8 +77,15 @@ type Entity struct {
 	CommunicationPatterns string            `json:"communication_patterns,omitempty" yaml:"communication_patterns,omitempty"`
 	ExpertiseAreas        []string          `json:"expertise_areas,omitempty" yaml:"expertise_areas,omitempty"`
 	OrgPosition           string            `json:"org_position,omitempty" yaml:"org_position,omitempty"`
+	AccountType           string            `json:"account_type,omitempty" yaml:"account_type,omitempty"`
 }
 
+// entityAccountTypes lists the account types accepted by 'entity update --account-type'.
+var entityAccountTypes = []string{"person", "role", "distribution", "bot", "external_service", "team", "service"}
+
+// accountTypeMetadataKey is the entity metadata key that carries the account type.
+const accountTypeMetadataKey = "account_type"
+
 // Relationship represents an edge in the relationship graph.
 type Relationship struct {
 	ID          string           `json:"id" yaml:"id"`
@@ -88,6 +100,50 @@ type Relationship struct {
 	FirstSeen   time.Time        `json:"first_seen" yaml:"first_seen"`
 	LastSeen    time.Time        `json:"last_seen" yaml:"last_seen"`
 	SourceCount int              `json:"source_count" yaml:"source_count"`
+
+	// EvidenceDetails is only filled in by 'relationship show --evidence full'.
+	EvidenceDetails []RelationshipEvidence `json:"evidence_details,omitempty" yaml:"evidence_details,omitempty"`
+}
+
+// RelationshipEvidence is one piece of evidence behind a relationship, with
+// a reference to the content it came from.
+type RelationshipEvidence struct {
+	SourceID     string    `json:"source_id" yaml:"source_id"`
+	SourceType   string    `json:"source_type" yaml:"source_type"`
+	Excerpt      string    `json:"excerpt" yaml:"excerpt"`
+	DiscoveredAt time.Time `json:"discovered_at" yaml:"discovered_at"`
+	Confidence   float64   `json:"confidence_contribution" yaml:"confidence_contribution"`
+}
+
+// Evidence display modes for 'relationship show'.
+const (
+	evidenceModeCompact = "compact"
+	evidenceModeFull    = "full"
+)
+
+// EntityMergeResult is the structured result of merging two entities.
+type EntityMergeResult struct {
+	PrimaryEntityID          string `json:"primary_entity_id" yaml:"primary_entity_id"`
+	MergedEntityID           string `json:"merged_entity_id" yaml:"merged_entity_id"`
+	RelationshipsTransferred int    `json:"relationships_transferred" yaml:"relationships_transferred"`
+}
+
+// RelationshipListResult is the structured output of 'relationship list'.
+type RelationshipListResult struct {
+	Relationships []Relationship `json:"relationships" yaml:"relationships"`
+	Count         int            `json:"count" yaml:"count"`
+	TotalCount    int64          `json:"total_count" yaml:"total_count"`
+	NextPageToken string         `json:"next_page_token,omitempty" yaml:"next_page_token,omitempty"`
+}
+
+// EntityListResult is the structured output of 'relationship entity list'.
+type EntityListResult struct {
+	Entities []Entity `json:"entities" yaml:"entities"`
+	Count    int      `json:"count" yaml:"count"`
+	// TotalCount is the number of matching entities. With --account-type it
+	// is only known once every page has been scanned, and is 0 otherwise.
+	TotalCount    int64  `json:"total_count" yaml:"total_count"`
+	NextPageToken string `json:"next_page_token,omitempty" yaml:"next_page_token,omitempty"`
 }
 
 // RelationshipConflict represents a detected conflict between relationships.
@@ -130,9 +186,12 @@ func DefaultRelationshipDeps() *RelationshipCommandDeps {
 			opts := client.DefaultOptions()
 			opts.Insecure = cfg.Insecure
 			opts.Debug = cfg.Debug
+			opts.DebugTrace = cfg.DebugTrace
 			opts.TenantID = cfg.EffectiveTenantID()
-			// Keep the default ConnectTimeout (10s) - don't use cfg.Timeout (10min)
-			// for connection establishment, as that causes long hangs on failures.
+			// Dial with the short connect timeout; the request timeout bounds RPCs.
+			opts.ConnectTimeout = cfg.GetConnectTimeout()
+			opts.RequestTimeout = cfg.Timeout
+			opts.ApplyConnectionConfig(cfg)
 
 			if !cfg.Insecure {
 				tlsConfig, err := client.LoadClientTLSConfig(&cfg.TLS)
@@ -154,15 +213,59 @@ func DefaultRelationshipDeps() *RelationshipCommandDeps {
 	}
 }
 
+// relationshipClient returns the shared relationship client, connecting on
+// first use. Subsequent calls within the same command reuse the connection.
+// Callers defer d.Close() rather than relying on a post-run hook, which cobra
+// skips when RunE fails and which would shadow the root command's own hook.
+func (d *RelationshipCommandDeps) relationshipClient(cfg *config.CLIConfig) (*client.RelationshipClient, error) {
+	if d.RelationshipClient != nil && d.RelationshipClient.IsConnected() {
+		return d.RelationshipClient, nil
+	}
+	relClient, err := d.InitRelClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	d.RelationshipClient = relClient
+	return relClient, nil
+}
+
+// gatewayConn returns the shared gateway connection for service clients other
+// than the relationship client, such as entity management.
+func (d *RelationshipCommandDeps) gatewayConn(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
+	relClient, err := d.relationshipClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return relClient.Conn(), nil
+}
+
+// Close closes the shared relationship connection, if one was opened.
+func (d *RelationshipCommandDeps) Close() error {
+	if d.RelationshipClient == nil {
+		return nil
+	}
+	err := d.RelationshipClient.Close()
+	d.RelationshipClient = nil
+	return err
+}
+
 // Relationship command flags.
 var (
-	relationshipTenant        string
-	relationshipOutput        string
-	relationshipLimit         int
-	relationshipConfidenceMin float64
-	relationshipType          string
-	relationshipEntityType    string
-	conflictStrategy          string
+	relationshipTenant         string
+	relationshipOutput         string
+	relationshipLimit          int
+	relationshipConfidenceMin  float64
+	relationshipType           string
+	relationshipEntityType     string
+	relationshipAccountType    string
+	relationshipPageToken      string
+	relationshipAllPages       bool
+	relationshipEvidenceMode   string
+	conflictStrategy           string
+	conflictResolveAll         bool
+	conflictResolveType        string
+	conflictResolveConfirm     bool
+	conflictResolveInteractive bool
 	// Discover flags
 	discoverMinConfidence float64
 	discoverMaxRels       int
@@ -172,6 +275,10 @@ var (
 	// Create flags
 	createType    string
 	createSubtype string
+	// Search flags
+	relSearchFuzzy     bool
+	relSearchExact     bool
+	relSearchThreshold float64
 	// Network graph flags
 	graphCenter        string
 	graphDepth         int
@@ -227,13 +334,14 @@ Entity resolution details are in Context Palace knowledge shards.`,
 
 	// Add persistent flags.
 	cmd.PersistentFlags().StringVarP(&relationshipTenant, "tenant", "t", "", "Tenant ID (overrides config)")
-	cmd.PersistentFlags().StringVarP(&relationshipOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.PersistentFlags().StringVarP(&relationshipOutput, "output", "o", "", "Output format: text, json, yaml; csv, jsonl and template where the subcommand supports them")
 	cmd.PersistentFlags().IntVarP(&relationshipLimit, "limit", "l", 100, "Maximum number of results")
 	cmd.PersistentFlags().Float64Var(&relationshipConfidenceMin, "confidence-min", 0.0, "Minimum confidence threshold (0.0-1.0)")
 
 	// Add subcommands.
 	cmd.AddCommand(newRelationshipListCommand(deps))
 	cmd.AddCommand(newRelationshipShowCommand(deps))
+	cmd.AddCommand(newRelationshipTimelineCommand(deps))
 	cmd.AddCommand(newRelationshipSearchCommand(deps))
 	cmd.AddCommand(newRelationshipDiscoverCommand(deps))
 	cmd.AddCommand(newRelationshipValidateCommand(deps))
@@ -253,7 +361,8 @@ func newRelationshipListCommand(deps *RelationshipCommandDeps) *cobra.Command {
 		Long: `List relationships in the Penfold knowledge graph.
 
 Displays relationships between entities with their confidence scores and
-relationship types. Use filters to narrow down the results.
+relationship types. Use filters to narrow down the results. Results are
+paginated: use --page-token to fetch the next page, or --all to fetch every page.
 
 Examples:
   # List all relationships
@@ -265,6 +374,16 @@ Examples:
   # Filter by relationship type
   penf relationship list --type colleague
 
+  # Paging
+  penf relationship list --page-token <token>
+  penf relationship list --type colleague --all
+
+  # Stream one JSON object per line while paging
+  penf relationship list --all -o jsonl
+
+  # One line per relationship from a Go template
+  penf relationship list -o template --template '{{.SourceName}} -{{.Type}}-> {{.TargetName}}'
+
   # Output as JSON
   penf relationship list --format json`,
 		Aliases: []string{"ls"},
@@ -274,13 +393,15 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&relationshipType, "type", "", "Filter by relationship type")
+	cmd.Flags().StringVar(&relationshipPageToken, "page-token", "", "Page token from a previous response")
+	cmd.Flags().BoolVar(&relationshipAllPages, "all", false, "Fetch all pages")
 
 	return cmd
 }
 
 // newRelationshipShowCommand creates the 'relationship show' subcommand.
 func newRelationshipShowCommand(deps *RelationshipCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "show <relationship-id>",
 		Short: "Show details of a specific relationship",
 		Long: `Show detailed information about a specific relationship.
@@ -288,18 +409,30 @@ func newRelationshipShowCommand(deps *RelationshipCommandDeps) *cobra.Command {
 Displays full relationship details including evidence, timestamps,
 and confidence scores.
 
-Example:
-  penf relationship show rel-abc123`,
-		Args: cobra.ExactArgs(1),
+Evidence is shown as excerpts by default. Use --evidence full to show each
+piece of evidence with the content it came from, when it was discovered and
+how much it contributed to the confidence score, along with the command to
+open that content.
+
+Examples:
+  penf relationship show rel-abc123
+  penf relationship show rel-abc123 --evidence full
+  penf relationship show rel-abc123 --evidence full -o json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRelationshipIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRelationshipShow(cmd.Context(), deps, args[0], getRelInsecureFlag(cmd))
 		},
 	}
+
+	cmd.Flags().StringVar(&relationshipEvidenceMode, "evidence", evidenceModeCompact, "Evidence detail: compact, full")
+
+	return cmd
 }
 
 // newRelationshipSearchCommand creates the 'relationship search' subcommand.
 func newRelationshipSearchCommand(deps *RelationshipCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "search <query>",
 		Short: "Search relationships by entity name or type",
 		Long: `Search relationships in the knowledge graph.
@@ -307,18 +440,46 @@ func newRelationshipSearchCommand(deps *RelationshipCommandDeps) *cobra.Command
 Searches for relationships involving entities matching the query string.
 The search matches against entity names and aliases.
 
+Match Modes:
+  (default)  Server-side search on names and aliases
+  --exact    Only entities whose name equals the query (case-insensitive)
+  --fuzzy    Typo-tolerant matching, so "Jon" also finds "John". Results
+             scoring below --threshold (0.0-1.0) are dropped. Up to 10000
+             relationships are scored; the output says when more exist.
+
+Each result reports a match score (1.0 = exact name match) against the
+closer of its source and target entity names.
+
 Examples:
   # Search for relationships involving "John"
   penf relationship search "John"
 
   # Search with confidence threshold
-  penf relationship search "Alice" --confidence-min 0.7`,
+  penf relationship search "Alice" --confidence-min 0.7
+
+  # Typo-tolerant search
+  penf relationship search "Jon" --fuzzy --threshold 0.6
+
+  # Exact name match only
+  penf relationship search "John Smith" --exact`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if relSearchFuzzy && relSearchExact {
+				return fmt.Errorf("--fuzzy and --exact are mutually exclusive")
+			}
+			if relSearchThreshold < 0 || relSearchThreshold > 1 {
+				return fmt.Errorf("--threshold must be between 0.0 and 1.0")
+			}
 			query := strings.Join(args, " ")
 			return runRelationshipSearch(cmd.Context(), deps, query, getRelInsecureFlag(cmd))
 		},
 	}
+
+	cmd.Flags().BoolVar(&relSearchFuzzy, "fuzzy", false, "Typo-tolerant matching on entity names")
+	cmd.Flags().BoolVar(&relSearchExact, "exact", false, "Only match entity names exactly (case-insensitive)")
+	cmd.Flags().Float64Var(&relSearchThreshold, "threshold", 0.7, "Minimum match score for --fuzzy (0.0-1.0)")
+
+	return cmd
 }
 
 // newRelationshipDiscoverCommand creates the 'relationship discover' subcommand.
@@ -447,7 +608,7 @@ Examples:
 	cmd.Flags().StringVar(&createSubtype, "subtype", "", "Optional relationship subtype for additional specificity")
 	cmd.MarkFlagRequired("type")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newRelationshipEntityCommand creates the 'relationship entity' subcommand group.
@@ -481,6 +642,8 @@ func newEntityListCommand(deps *RelationshipCommandDeps) *cobra.Command {
 		Long: `List entities in the Penfold knowledge graph.
 
 Displays entities with their types, confidence scores, and relationship counts.
+Results are paginated: use --page-token to fetch the next page, or --all to
+fetch every page.
 
 Examples:
   # List all entities
@@ -490,7 +653,21 @@ Examples:
   penf relationship entity list --type person
 
   # List entities with minimum confidence
-  penf relationship entity list --confidence-min 0.8`,
+  penf relationship entity list --confidence-min 0.8
+
+  # Audit non-human accounts
+  penf relationship entity list --account-type bot
+  penf relationship entity list --account-type service
+
+  # Paging
+  penf relationship entity list --page-token <token>
+  penf relationship entity list --type person --all -o json
+
+  # Stream one JSON object per line while paging
+  penf relationship entity list --all -o jsonl
+
+  # One line per entity from a Go template
+  penf relationship entity list -o template --template '{{.Name}} ({{.Type}}): {{.Confidence}}'`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityList(cmd.Context(), deps, getRelInsecureFlag(cmd))
@@ -498,6 +675,9 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&relationshipEntityType, "type", "", "Filter by entity type (person, organization, topic, project, location)")
+	cmd.Flags().StringVar(&relationshipAccountType, "account-type", "", "Filter by account type, applied client-side ("+strings.Join(entityAccountTypes, ", ")+")")
+	cmd.Flags().StringVar(&relationshipPageToken, "page-token", "", "Page token from a previous response")
+	cmd.Flags().BoolVar(&relationshipAllPages, "all", false, "Fetch all pages")
 
 	return cmd
 }
@@ -517,7 +697,8 @@ Numeric IDs are auto-prefixed with "ent-person-" for compatibility.
 Examples:
   penf relationship entity show ent-person-123
   penf relationship entity show 123`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeEntityIDs(deps, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityShow(cmd.Context(), deps, args[0], getRelInsecureFlag(cmd))
 		},
@@ -526,7 +707,7 @@ Examples:
 
 // newEntityMergeCommand creates the 'relationship entity merge' subcommand.
 func newEntityMergeCommand(deps *RelationshipCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "merge <entity-id-1> <entity-id-2>",
 		Short: "Merge two entities into one",
 		Long: `Merge two entities, combining their properties and relationships.
@@ -541,11 +722,12 @@ Numeric IDs are auto-prefixed with "ent-person-" for compatibility.
 Examples:
   penf relationship entity merge ent-person-123 ent-person-456
   penf relationship entity merge 123 456`,
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeEntityIDs(deps, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityMerge(cmd.Context(), deps, args[0], args[1], getRelInsecureFlag(cmd))
 		},
-	}
+	})
 }
 
 // entityUpdateFlags holds flags for the entity update command.
@@ -585,14 +767,15 @@ Examples:
 
   # Update both name and account type
   penf relationship entity update 789 --name "Engineering Bot" --account-type bot`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeEntityIDs(deps, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityUpdate(cmd.Context(), deps, args[0], getRelInsecureFlag(cmd))
 		},
 	}
 
 	cmd.Flags().StringVar(&entityUpdateName, "name", "", "New name for the entity")
-	cmd.Flags().StringVar(&entityUpdateAccountType, "account-type", "", "New account type (person, role, distribution, bot, external_service, team, service)")
+	cmd.Flags().StringVar(&entityUpdateAccountType, "account-type", "", "New account type ("+strings.Join(entityAccountTypes, ", ")+")")
 	cmd.Flags().StringSliceVar(&entityUpdateMetadata, "metadata", []string{}, "Metadata key=value pairs (can be specified multiple times)")
 
 	return cmd
@@ -635,7 +818,8 @@ Examples:
 
   # Delete without confirmation
   penf relationship entity delete 123 --force`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeEntityIDs(deps, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityDelete(cmd.Context(), deps, args[0], getRelInsecureFlag(cmd))
 		},
@@ -685,7 +869,7 @@ Use --output json for programmatic processing of duplicate pairs.`,
 	}
 
 	cmd.Flags().Float64Var(&duplicatesMinSimilarity, "min-similarity", 0.7, "Minimum similarity score threshold (0.0-1.0)")
-	cmd.Flags().StringVar(&duplicatesOutput, "output", "table", "Output format (table, json)")
+	cmd.Flags().StringVar(&duplicatesOutput, "output", "table", "Output format (table, json, csv; --auto-merge supports table, json)")
 	cmd.Flags().BoolVar(&duplicatesAutoMerge, "auto-merge", false, "Auto-merge high-confidence duplicate pairs")
 	cmd.Flags().BoolVar(&duplicatesConfirm, "confirm", false, "Confirm auto-merge (required with --auto-merge to execute)")
 	cmd.Flags().BoolVar(&duplicatesDryRun, "dry-run", false, "Show what would be merged without executing")
@@ -712,7 +896,8 @@ Use this before 'penf entity merge' to understand the impact of the merge.`,
 
   # Get preview as JSON for analysis
   penf entity merge-preview ent-person-1 ent-person-2 --output json`,
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeEntityIDs(deps, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runEntityMergePreview(cmd.Context(), deps, args[0], args[1], getRelInsecureFlag(cmd))
 		},
@@ -885,19 +1070,63 @@ Resolution Strategies:
   - merge:       Attempt to merge conflicting data
   - manual:      Mark for manual review (no automatic changes)
 
-Example:
-  penf relationship conflict resolve conf-abc123 --strategy merge`,
-		Args: cobra.ExactArgs(1),
+Use --all to resolve every pending conflict with the same strategy, optionally
+limited to one conflict type with --type (duplicate_entity,
+contradictory_relationship, low_confidence). Batch resolution runs in dry-run
+mode unless --confirm is given, and does not accept the manual strategy.
+
+Use --interactive to walk through pending conflicts one at a time, choosing a
+strategy (or skipping) for each. Each choice is applied immediately, so
+quitting with q or Ctrl+C leaves earlier resolutions in place. --type limits
+the walkthrough to one conflict type.
+
+Examples:
+  penf relationship conflict resolve conf-abc123 --strategy merge
+
+  # Preview, then resolve all low-confidence conflicts
+  penf relationship conflict resolve --all --type low_confidence --strategy keep_latest
+  penf relationship conflict resolve --all --type low_confidence --strategy keep_latest --confirm
+
+  # Triage conflicts one by one
+  penf relationship conflict resolve --interactive`,
+		Args: conflictResolveArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConflictResolve(cmd.Context(), deps, args[0], ConflictResolutionStrategy(conflictStrategy), getRelInsecureFlag(cmd))
+			strategy := ConflictResolutionStrategy(conflictStrategy)
+			if conflictResolveAll {
+				return runConflictResolveAll(cmd.Context(), deps, strategy, getRelInsecureFlag(cmd))
+			}
+			if conflictResolveInteractive {
+				return runConflictResolveInteractive(cmd.Context(), deps, os.Stdin, getRelInsecureFlag(cmd))
+			}
+			if conflictResolveType != "" || conflictResolveConfirm {
+				return fmt.Errorf("--type and --confirm only apply with --all")
+			}
+			return runConflictResolve(cmd.Context(), deps, args[0], strategy, getRelInsecureFlag(cmd))
 		},
 	}
 
 	cmd.Flags().StringVarP(&conflictStrategy, "strategy", "s", "keep_latest", "Resolution strategy: keep_latest, keep_first, merge, manual")
+	cmd.Flags().BoolVar(&conflictResolveAll, "all", false, "Resolve all pending conflicts")
+	cmd.Flags().StringVar(&conflictResolveType, "type", "", "With --all or --interactive, only include conflicts of this type")
+	cmd.Flags().BoolVar(&conflictResolveConfirm, "confirm", false, "Confirm batch resolution (dry-run without it)")
+	cmd.Flags().BoolVarP(&conflictResolveInteractive, "interactive", "i", false, "Walk through pending conflicts one at a time")
+	cmd.MarkFlagsMutuallyExclusive("all", "interactive")
 
 	return cmd
 }
 
+// conflictResolveArgs requires a conflict ID, or no arguments when --all or
+// --interactive is set.
+func conflictResolveArgs(cmd *cobra.Command, args []string) error {
+	if conflictResolveAll || conflictResolveInteractive {
+		if len(args) != 0 {
+			return fmt.Errorf("cannot combine a conflict ID with --all or --interactive")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
 // Command execution functions.
 
 // runRelationshipList executes the relationship list command.
@@ -920,46 +1149,85 @@ func runRelationshipList(ctx context.Context, deps *RelationshipCommandDeps, ins
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Build request.
 	req := &client.ListRelationshipsRequest{
 		TenantID:      cfg.EffectiveTenantID(),
 		PageSize:      int32(relationshipLimit),
 		MinConfidence: float32(relationshipConfidenceMin),
+		PageToken:     relationshipPageToken,
 	}
 
 	if relationshipType != "" {
 		req.RelationshipType = stringToRelType(relationshipType)
 	}
 
-	// Get relationships via gRPC.
-	rels, _, err := relClient.ListRelationships(ctx, req)
+	// Determine output format.
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+
+	// JSON lines and templates are written as each page arrives rather than
+	// collected.
+	stream, err := newRecordStream(os.Stdout, format)
 	if err != nil {
-		return fmt.Errorf("listing relationships: %w", err)
+		return err
 	}
 
-	// Convert to local types for output.
-	relationships := make([]Relationship, len(rels))
-	for i, r := range rels {
-		relationships[i] = clientRelToLocal(r)
+	// Fetch one page, or every page when --all is set.
+	result := RelationshipListResult{Relationships: []Relationship{}}
+	for {
+		page, err := relClient.ListRelationshipsPage(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing relationships: %w", err)
+		}
+
+		for _, r := range page.Relationships {
+			if stream != nil {
+				stream.Encode(clientRelToLocal(r))
+				continue
+			}
+			result.Relationships = append(result.Relationships, clientRelToLocal(r))
+		}
+		if stream != nil && stream.Err() != nil {
+			return fmt.Errorf("writing output: %w", stream.Err())
+		}
+		result.TotalCount = page.TotalCount
+		result.NextPageToken = page.NextPageToken
+
+		if !relationshipAllPages || page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
 	}
 
-	// Determine output format.
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
+	if stream != nil {
+		printNextPageHint(os.Stderr, result.NextPageToken)
+		return nil
 	}
 
-	return outputRelationships(format, relationships)
+	result.Count = len(result.Relationships)
+	if result.TotalCount == 0 {
+		result.TotalCount = int64(result.Count)
+	}
+
+	return outputRelationshipList(format, result)
 }
 
 // runRelationshipShow executes the relationship show command.
 func runRelationshipShow(ctx context.Context, deps *RelationshipCommandDeps, relationshipID string, insecureFlag bool) error {
+	switch relationshipEvidenceMode {
+	case "", evidenceModeCompact, evidenceModeFull:
+	default:
+		return fmt.Errorf("invalid --evidence value: %s (must be compact or full)", relationshipEvidenceMode)
+	}
+
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
@@ -978,11 +1246,19 @@ func runRelationshipShow(ctx context.Context, deps *RelationshipCommandDeps, rel
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
+
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+	if err := checkOutputTemplate(format); err != nil {
+		return err
+	}
 
 	// Get relationship details via gRPC.
 	rel, err := relClient.GetRelationship(ctx, cfg.EffectiveTenantID(), relationshipID)
@@ -991,10 +1267,8 @@ func runRelationshipShow(ctx context.Context, deps *RelationshipCommandDeps, rel
 	}
 
 	relationship := clientRelToLocal(rel)
-
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
+	if relationshipEvidenceMode == evidenceModeFull {
+		relationship.EvidenceDetails = clientEvidenceToLocal(rel.Evidence)
 	}
 
 	return outputRelationshipDetail(format, relationship)
@@ -1020,11 +1294,11 @@ func runRelationshipSearch(ctx context.Context, deps *RelationshipCommandDeps, q
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Search relationships via gRPC.
 	rels, err := relClient.SearchRelationships(ctx, cfg.EffectiveTenantID(), query, int32(relationshipLimit))
@@ -1032,18 +1306,168 @@ func runRelationshipSearch(ctx context.Context, deps *RelationshipCommandDeps, q
 		return fmt.Errorf("searching relationships: %w", err)
 	}
 
+	// Server-side search is substring based, so widen the candidate pool for
+	// fuzzy matching to catch misspellings it would never return.
+	if relSearchFuzzy {
+		candidates, truncated, err := fetchFuzzyCandidates(ctx, relClient.ListRelationshipsPage, &client.ListRelationshipsRequest{
+			TenantID:      cfg.EffectiveTenantID(),
+			MinConfidence: float32(relationshipConfidenceMin),
+		})
+		if err != nil {
+			return fmt.Errorf("listing fuzzy match candidates: %w", err)
+		}
+		if truncated {
+			fmt.Fprintf(os.Stderr, "Note: fuzzy matching scored the first %d relationships only; raise --confidence-min to narrow the candidates.\n", fuzzyCandidateMax)
+		}
+		rels = append(rels, candidates...)
+	}
+
 	// Convert to local types for output.
 	relationships := make([]Relationship, len(rels))
 	for i, r := range rels {
 		relationships[i] = clientRelToLocal(r)
 	}
 
+	mode := relationshipMatchDefault
+	if relSearchFuzzy {
+		mode = relationshipMatchFuzzy
+	} else if relSearchExact {
+		mode = relationshipMatchExact
+	}
+	matches := scoreRelationshipMatches(query, relationships, mode, relSearchThreshold)
+	if len(matches) > relationshipLimit {
+		matches = matches[:relationshipLimit]
+	}
+
 	format := cfg.OutputFormat
 	if relationshipOutput != "" {
 		format = config.OutputFormat(relationshipOutput)
 	}
 
-	return outputRelationships(format, relationships)
+	return outputRelationshipMatches(format, matches)
+}
+
+// Relationship search match modes.
+const (
+	relationshipMatchDefault = "default"
+	relationshipMatchExact   = "exact"
+	relationshipMatchFuzzy   = "fuzzy"
+)
+
+// fuzzyCandidatePageSize is the page size used when fetching fuzzy match
+// candidates.
+const fuzzyCandidatePageSize = 500
+
+// fuzzyCandidateMax caps how many relationships are scored by --fuzzy.
+const fuzzyCandidateMax = 10000
+
+// fetchFuzzyCandidates pages through the relationships matching req, up to
+// fuzzyCandidateMax. It reports whether the cap cut the listing short.
+func fetchFuzzyCandidates(ctx context.Context, listPage func(context.Context, *client.ListRelationshipsRequest) (*client.RelationshipPage, error), req *client.ListRelationshipsRequest) ([]*client.Relationship, bool, error) {
+	page := *req
+	page.PageSize = fuzzyCandidatePageSize
+	page.PageToken = ""
+
+	var rels []*client.Relationship
+	for len(rels) < fuzzyCandidateMax {
+		resp, err := listPage(ctx, &page)
+		if err != nil {
+			return nil, false, err
+		}
+		rels = append(rels, resp.Relationships...)
+		if resp.NextPageToken == "" || len(resp.Relationships) == 0 {
+			if len(rels) > fuzzyCandidateMax {
+				return rels[:fuzzyCandidateMax], true, nil
+			}
+			return rels, false, nil
+		}
+		page.PageToken = resp.NextPageToken
+	}
+	if len(rels) > fuzzyCandidateMax {
+		rels = rels[:fuzzyCandidateMax]
+	}
+	return rels, true, nil
+}
+
+// RelationshipMatch is a relationship search result with its match score.
+type RelationshipMatch struct {
+	Relationship `yaml:",inline"`
+	MatchScore   float64 `json:"match_score" yaml:"match_score"`
+	MatchedName  string  `json:"matched_name" yaml:"matched_name"`
+}
+
+// scoreRelationshipMatches scores each relationship against the query and
+// filters by match mode. Default mode keeps every result in server order;
+// exact and fuzzy modes drop non-matches and sort by descending score.
+// Duplicate relationships (by ID) are collapsed.
+func scoreRelationshipMatches(query string, relationships []Relationship, mode string, threshold float64) []RelationshipMatch {
+	seen := make(map[string]bool, len(relationships))
+	matches := make([]RelationshipMatch, 0, len(relationships))
+	for _, r := range relationships {
+		if r.ID != "" {
+			if seen[r.ID] {
+				continue
+			}
+			seen[r.ID] = true
+		}
+
+		m := RelationshipMatch{Relationship: r}
+		for _, name := range []string{r.SourceName, r.TargetName} {
+			if score := nameMatchScore(query, name); score > m.MatchScore {
+				m.MatchScore = score
+				m.MatchedName = name
+			}
+		}
+
+		switch mode {
+		case relationshipMatchExact:
+			if m.MatchScore < 1.0 {
+				continue
+			}
+		case relationshipMatchFuzzy:
+			if m.MatchScore < threshold {
+				continue
+			}
+		}
+		matches = append(matches, m)
+	}
+
+	if mode != relationshipMatchDefault {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].MatchScore > matches[j].MatchScore
+		})
+	}
+	return matches
+}
+
+// nameMatchScore returns how closely an entity name matches a query (0.0 to 1.0).
+// An exact case-insensitive match scores 1.0; otherwise the best of the full-name
+// similarity and the similarity to any single word of the name is used, so a
+// first name query matches a full name. A query that prefixes a word of the name
+// ("Jon" in "Jonathan") scores 0.9.
+func nameMatchScore(query, name string) float64 {
+	q := strings.ToLower(strings.TrimSpace(query))
+	n := strings.ToLower(strings.TrimSpace(name))
+	if q == "" || n == "" {
+		return 0
+	}
+	if q == n {
+		return 1.0
+	}
+
+	best := entities.NameSimilarity(q, n)
+	for _, word := range strings.Fields(n) {
+		if word == q {
+			return 0.95
+		}
+		if len(q) >= 2 && strings.HasPrefix(word, q) && best < 0.9 {
+			best = 0.9
+		}
+		if score := entities.NameSimilarity(q, word); score > best {
+			best = score
+		}
+	}
+	return best
 }
 
 // runRelationshipDiscover executes the relationship discover command.
@@ -1066,13 +1490,19 @@ func runRelationshipDiscover(ctx context.Context, deps *RelationshipCommandDeps,
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
-	fmt.Printf("Discovering relationships in content %s...\n", contentID)
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+	progress := progressOut(format)
+
+	fmt.Fprintf(progress, "Discovering relationships in content %s...\n", contentID)
 
 	// Build discovery options.
 	opts := &client.DiscoverOptions{
@@ -1087,15 +1517,15 @@ func runRelationshipDiscover(ctx context.Context, deps *RelationshipCommandDeps,
 		return fmt.Errorf("discovering relationships: %w", err)
 	}
 
-	fmt.Printf("\n\033[32mSuccess!\033[0m Discovered %d relationships.\n", result.TotalDiscovered)
+	fmt.Fprintf(progress, "\n\033[32mSuccess!\033[0m Discovered %d relationships.\n", result.TotalDiscovered)
 	if result.Metadata != nil {
-		fmt.Printf("  Processing time: %dms\n", result.Metadata.ProcessingTimeMs)
+		fmt.Fprintf(progress, "  Processing time: %dms\n", result.Metadata.ProcessingTimeMs)
 		if result.Metadata.ModelName != "" {
-			fmt.Printf("  Model: %s\n", result.Metadata.ModelName)
+			fmt.Fprintf(progress, "  Model: %s\n", result.Metadata.ModelName)
 		}
-		fmt.Printf("  Entities analyzed: %d\n", result.Metadata.EntitiesAnalyzed)
+		fmt.Fprintf(progress, "  Entities analyzed: %d\n", result.Metadata.EntitiesAnalyzed)
 	}
-	fmt.Println()
+	fmt.Fprintln(progress)
 
 	// Convert to local types for output.
 	relationships := make([]Relationship, len(result.Relationships))
@@ -1103,11 +1533,6 @@ func runRelationshipDiscover(ctx context.Context, deps *RelationshipCommandDeps,
 		relationships[i] = clientRelToLocal(r)
 	}
 
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
-	}
-
 	return outputRelationships(format, relationships)
 }
 
@@ -1131,11 +1556,11 @@ func runRelationshipValidate(ctx context.Context, deps *RelationshipCommandDeps,
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	actionName := "validating"
 	switch action {
@@ -1147,7 +1572,16 @@ func runRelationshipValidate(ctx context.Context, deps *RelationshipCommandDeps,
 		actionName = "archiving"
 	}
 
-	fmt.Printf("Validating relationship %s (%s)...\n", relationshipID, actionName)
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+	if err := checkOutputTemplate(format); err != nil {
+		return err
+	}
+	progress := progressOut(format)
+
+	fmt.Fprintf(progress, "Validating relationship %s (%s)...\n", relationshipID, actionName)
 
 	// Validate relationship via gRPC.
 	req := &client.ValidateRelationshipRequest{
@@ -1163,14 +1597,9 @@ func runRelationshipValidate(ctx context.Context, deps *RelationshipCommandDeps,
 	}
 
 	if result.Success {
-		fmt.Printf("\n\033[32mSuccess!\033[0m %s\n", result.Message)
+		fmt.Fprintf(progress, "\n\033[32mSuccess!\033[0m %s\n", result.Message)
 	} else {
-		fmt.Printf("\n\033[33mWarning:\033[0m %s\n", result.Message)
-	}
-
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
+		fmt.Fprintf(os.Stderr, "\n\033[33mWarning:\033[0m %s\n", result.Message)
 	}
 
 	relationship := clientRelToLocal(result.Relationship)
@@ -1202,13 +1631,22 @@ func runRelationshipCreate(ctx context.Context, deps *RelationshipCommandDeps, f
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
+
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+	if err := checkOutputTemplate(format); err != nil {
+		return err
+	}
+	progress := progressOut(format)
 
-	fmt.Printf("Creating relationship: %s -> %s (%s)...\n", fromEntityID, toEntityID, createType)
+	fmt.Fprintf(progress, "Creating relationship: %s -> %s (%s)...\n", fromEntityID, toEntityID, createType)
 
 	// Create relationship via gRPC.
 	rel, err := relClient.CreateRelationship(ctx, cfg.EffectiveTenantID(), fromEntityID, toEntityID, stringToRelType(createType), createSubtype)
@@ -1216,21 +1654,19 @@ func runRelationshipCreate(ctx context.Context, deps *RelationshipCommandDeps, f
 		return fmt.Errorf("creating relationship: %w", err)
 	}
 
-	fmt.Printf("\n\033[32mSuccess!\033[0m Relationship created.\n")
-	fmt.Printf("  ID: %s\n", rel.ID)
-	fmt.Printf("  Type: %s\n", createType)
+	fmt.Fprintf(progress, "\n\033[32mSuccess!\033[0m Relationship created.\n")
+	fmt.Fprintf(progress, "  ID: %s\n", rel.ID)
+	fmt.Fprintf(progress, "  Type: %s\n", createType)
 	if createSubtype != "" {
-		fmt.Printf("  Subtype: %s\n", createSubtype)
-	}
-	fmt.Printf("  Confidence: %.2f (user-confirmed)\n", rel.Confidence)
-	fmt.Println()
-
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
+		fmt.Fprintf(progress, "  Subtype: %s\n", createSubtype)
 	}
+	fmt.Fprintf(progress, "  Confidence: %.2f (user-confirmed)\n", rel.Confidence)
+	fmt.Fprintln(progress)
 
 	relationship := clientRelToLocal(rel)
+	if format == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, relationship, "relationship created")
+	}
 	return outputRelationshipDetail(format, relationship)
 }
 
@@ -1253,47 +1689,141 @@ func runEntityList(ctx context.Context, deps *RelationshipCommandDeps, insecureF
 		cfg.TenantUUID = "" // flag overrides cached UUID
 	}
 
+	if relationshipAccountType != "" && !isValidEntityAccountType(relationshipAccountType) {
+		return fmt.Errorf("invalid account type: %s (must be one of: %s)", relationshipAccountType, strings.Join(entityAccountTypes, ", "))
+	}
+
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
+
+	offset, err := parseEntityPageToken(relationshipPageToken)
+	if err != nil {
+		return err
+	}
 
 	// Build request.
 	req := &client.ListEntitiesRequest{
 		TenantID:      cfg.EffectiveTenantID(),
 		PageSize:      int32(relationshipLimit),
 		MinConfidence: float32(relationshipConfidenceMin),
+		Offset:        offset,
 	}
 
 	if relationshipEntityType != "" {
 		req.EntityType = stringToEntityType(relationshipEntityType)
 	}
 
-	// Get entities via gRPC.
-	ents, _, err := relClient.ListEntities(ctx, req)
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+
+	// JSON lines and templates are written as each page arrives rather than
+	// collected.
+	stream, err := newRecordStream(os.Stdout, format)
 	if err != nil {
-		return fmt.Errorf("listing entities: %w", err)
+		return err
 	}
 
-	// Convert to local types for output.
-	entities := make([]Entity, len(ents))
-	for i, e := range ents {
-		entities[i] = clientEntityToLocal(e)
+	// Get entities via gRPC. ListEntities has no account type filter and no
+	// read RPC returns account_type as a field, so when a filter is given it
+	// runs client-side against entity metadata and pages until the limit is
+	// filled. The offset tracks every entity consumed so the next page resumes
+	// exactly where this one stopped.
+	result := EntityListResult{Entities: []Entity{}}
+	matched, scanned, typed := 0, 0, 0
+	for {
+		ents, total, err := relClient.ListEntities(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing entities: %w", err)
+		}
+		result.TotalCount = total
+
+		full := false
+		for _, e := range ents {
+			req.Offset++
+			scanned++
+			entity := clientEntityToLocal(e)
+			if entity.AccountType != "" {
+				typed++
+			}
+			if entityHasAccountType(entity, relationshipAccountType) {
+				matched++
+				if stream != nil {
+					stream.Encode(entity)
+				} else {
+					result.Entities = append(result.Entities, entity)
+				}
+			}
+			if !relationshipAllPages && matched >= relationshipLimit {
+				full = true
+				break
+			}
+		}
+		if stream != nil && stream.Err() != nil {
+			return fmt.Errorf("writing output: %w", stream.Err())
+		}
+
+		if len(ents) == 0 || int64(req.Offset) >= total {
+			break
+		}
+		if full || (!relationshipAllPages && relationshipAccountType == "") {
+			result.NextPageToken = formatEntityPageToken(req.Offset)
+			break
+		}
 	}
 
-	// Warn if results were truncated.
-	if len(entities) == relationshipLimit {
-		fmt.Fprintf(os.Stderr, "Warning: showing %d results (limit reached). Use --limit to see more.\n", relationshipLimit)
+	// The server total counts every entity, not the filtered ones. The
+	// filtered total is only known once every page has been scanned.
+	if relationshipAccountType != "" {
+		result.TotalCount = 0
+		if result.NextPageToken == "" {
+			result.TotalCount = int64(matched)
+		}
+		warnAccountTypeFilter(os.Stderr, matched, scanned, typed)
 	}
 
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
+	if stream != nil {
+		printNextPageHint(os.Stderr, result.NextPageToken)
+		return nil
 	}
+	result.Count = len(result.Entities)
 
-	return outputEntities(format, entities)
+	return outputEntityList(format, result)
+}
+
+// warnAccountTypeFilter notes that --account-type is applied client-side, and
+// explains an empty result when none of the scanned entities carry an
+// account type at all.
+func warnAccountTypeFilter(w io.Writer, matched, scanned, typed int) {
+	fmt.Fprintln(w, "Note: --account-type is filtered client-side from entity metadata; the server does not filter by account type.")
+	if matched == 0 && scanned > 0 && typed == 0 {
+		fmt.Fprintf(w, "Warning: none of the %d entities scanned carry an %q metadata value, so the filter cannot match anything.\n", scanned, accountTypeMetadataKey)
+	}
+}
+
+// formatEntityPageToken returns the page token for the given offset.
+// ListEntities pages by offset rather than token, so entity page tokens carry
+// the offset of the next entity. Callers should treat them as opaque.
+func formatEntityPageToken(offset int32) string {
+	return strconv.FormatInt(int64(offset), 10)
+}
+
+// parseEntityPageToken returns the offset encoded in a page token. An empty
+// token starts from the beginning.
+func parseEntityPageToken(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.ParseInt(token, 10, 32)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page token: %s", token)
+	}
+	return int32(offset), nil
 }
 
 // runEntityShow executes the entity show command.
@@ -1327,11 +1857,19 @@ func runEntityShow(ctx context.Context, deps *RelationshipCommandDeps, entityID
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
+
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+	if err := checkOutputTemplate(format); err != nil {
+		return err
+	}
 
 	// Get entity details via gRPC.
 	ent, err := relClient.GetEntity(ctx, cfg.EffectiveTenantID(), entityID)
@@ -1341,11 +1879,6 @@ func runEntityShow(ctx context.Context, deps *RelationshipCommandDeps, entityID
 
 	entity := clientEntityToLocal(ent)
 
-	format := cfg.OutputFormat
-	if relationshipOutput != "" {
-		format = config.OutputFormat(relationshipOutput)
-	}
-
 	return outputEntityDetail(format, entity)
 }
 
@@ -1377,14 +1910,23 @@ func runEntityMerge(ctx context.Context, deps *RelationshipCommandDeps, entityID
 		entityID2 = FormatEntityID(numericID, "person")
 	}
 
+	if err := guardDestructiveTenant(cfg, cfg.EffectiveTenantID()); err != nil {
+		return err
+	}
+
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
-	fmt.Printf("Merging entity %s into %s...\n", entityID2, entityID1)
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+
+	fmt.Fprintf(progressOut(format), "Merging entity %s into %s...\n", entityID2, entityID1)
 
 	// Merge entities via gRPC.
 	_, transferred, err := relClient.MergeEntities(ctx, cfg.EffectiveTenantID(), entityID1, entityID2)
@@ -1392,6 +1934,18 @@ func runEntityMerge(ctx context.Context, deps *RelationshipCommandDeps, entityID
 		return fmt.Errorf("merging entities: %w", err)
 	}
 
+	result := EntityMergeResult{
+		PrimaryEntityID:          entityID1,
+		MergedEntityID:           entityID2,
+		RelationshipsTransferred: int(transferred),
+	}
+	switch format {
+	case config.OutputFormatJSON:
+		return writeOutcomeJSON(true, result, "entities merged")
+	case config.OutputFormatYAML:
+		return outputRelYAML(result)
+	}
+
 	fmt.Printf("\n\033[32mSuccess!\033[0m Entities merged.\n")
 	fmt.Printf("  Primary entity: %s\n", entityID1)
 	fmt.Printf("  Merged entity:  %s (now archived)\n", entityID2)
@@ -1440,12 +1994,12 @@ func runEntityUpdate(ctx context.Context, deps *RelationshipCommandDeps, entityI
 		return fmt.Errorf("at least one of --name, --account-type, or --metadata must be specified")
 	}
 
-	// Connect to gateway.
-	conn, err := connectToGateway(cfg)
+	// Reuse the relationship service connection for the entity management service.
+	conn, err := deps.gatewayConn(cfg)
 	if err != nil {
 		return err
 	}
-	defer conn.Close()
+	defer deps.Close()
 
 	entityClient := entityv1.NewEntityManagementServiceClient(conn)
 
@@ -1514,6 +2068,10 @@ func runEntityDelete(ctx context.Context, deps *RelationshipCommandDeps, entityI
 		return fmt.Errorf("invalid entity ID: %w", err)
 	}
 
+	if err := guardDestructiveTenant(cfg, cfg.EffectiveTenantID()); err != nil {
+		return err
+	}
+
 	// Show confirmation prompt unless --force is used.
 	if !entityDeleteForce {
 		fmt.Printf("\n\033[33mWARNING:\033[0m This will permanently delete entity %d and all related records.\n", entityID)
@@ -1528,12 +2086,12 @@ func runEntityDelete(ctx context.Context, deps *RelationshipCommandDeps, entityI
 		}
 	}
 
-	// Connect to gateway.
-	conn, err := connectToGateway(cfg)
+	// Reuse the relationship service connection for the entity management service.
+	conn, err := deps.gatewayConn(cfg)
 	if err != nil {
 		return err
 	}
-	defer conn.Close()
+	defer deps.Close()
 
 	entityClient := entityv1.NewEntityManagementServiceClient(conn)
 
@@ -1573,11 +2131,11 @@ func runEntityDuplicates(ctx context.Context, deps *RelationshipCommandDeps, ins
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Determine output format.
 	format := cfg.OutputFormat
@@ -1587,6 +2145,13 @@ func runEntityDuplicates(ctx context.Context, deps *RelationshipCommandDeps, ins
 
 	// Handle auto-merge flow.
 	if duplicatesAutoMerge {
+		// Reject a format the result cannot be rendered in before merging anything.
+		switch format {
+		case config.OutputFormatJSON, config.OutputFormatText, "", "table":
+		default:
+			return unsupportedRelOutput(format)
+		}
+
 		// Auto-merge requires explicit confirm or dry-run.
 		isDryRun := duplicatesDryRun || !duplicatesConfirm
 
@@ -1642,11 +2207,11 @@ func runEntityMergePreview(ctx context.Context, deps *RelationshipCommandDeps, e
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Get merge preview.
 	preview, err := relClient.MergePreview(ctx, cfg.EffectiveTenantID(), entityID1, entityID2)
@@ -1683,11 +2248,11 @@ func runNetworkGraph(ctx context.Context, deps *RelationshipCommandDeps, insecur
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Build graph options.
 	opts := &client.GetNetworkGraphOptions{
@@ -1773,11 +2338,11 @@ func runNetworkCentral(ctx context.Context, deps *RelationshipCommandDeps, insec
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Get central entities via gRPC.
 	ents, err := relClient.GetCentralEntities(ctx, cfg.EffectiveTenantID(), int32(relationshipLimit))
@@ -1819,11 +2384,11 @@ func runNetworkClusters(ctx context.Context, deps *RelationshipCommandDeps, inse
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Get clusters via gRPC.
 	clusterList, err := relClient.GetClusters(ctx, cfg.EffectiveTenantID())
@@ -1865,11 +2430,11 @@ func runConflictList(ctx context.Context, deps *RelationshipCommandDeps, insecur
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Get conflicts via gRPC.
 	req := &client.ListConflictsRequest{
@@ -1916,11 +2481,11 @@ func runConflictShow(ctx context.Context, deps *RelationshipCommandDeps, conflic
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	// Get conflict details via gRPC.
 	c, err := relClient.GetConflict(ctx, cfg.EffectiveTenantID(), conflictID)
@@ -1957,20 +2522,16 @@ func runConflictResolve(ctx context.Context, deps *RelationshipCommandDeps, conf
 		cfg.TenantUUID = "" // flag overrides cached UUID
 	}
 
-	// Validate strategy.
-	switch strategy {
-	case ConflictStrategyKeepLatest, ConflictStrategyKeepFirst, ConflictStrategyMerge, ConflictStrategyManual:
-		// Valid strategies.
-	default:
-		return fmt.Errorf("invalid resolution strategy: %s (must be keep_latest, keep_first, merge, or manual)", strategy)
+	if err := validateConflictStrategy(strategy); err != nil {
+		return err
 	}
 
 	// Initialize relationship client.
-	relClient, err := deps.InitRelClient(cfg)
+	relClient, err := deps.relationshipClient(cfg)
 	if err != nil {
 		return fmt.Errorf("initializing relationship client: %w", err)
 	}
-	defer relClient.Close()
+	defer deps.Close()
 
 	fmt.Printf("Resolving conflict %s with strategy '%s'...\n", conflictID, strategy)
 
@@ -1993,6 +2554,252 @@ func runConflictResolve(ctx context.Context, deps *RelationshipCommandDeps, conf
 	return nil
 }
 
+// runConflictResolveAll resolves every pending conflict, optionally of one
+// type, with the same strategy. Without --confirm it only lists the
+// conflicts that would be resolved.
+func runConflictResolveAll(ctx context.Context, deps *RelationshipCommandDeps, strategy ConflictResolutionStrategy, insecureFlag bool) error {
+	if err := validateConflictStrategy(strategy); err != nil {
+		return err
+	}
+	if strategy == ConflictStrategyManual {
+		return fmt.Errorf("--strategy manual makes no changes and cannot be used with --all")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	// Override insecure if flag is set.
+	if insecureFlag {
+		cfg.Insecure = true
+	}
+
+	// Override tenant if specified.
+	if relationshipTenant != "" {
+		cfg.TenantID = relationshipTenant
+		cfg.TenantUUID = "" // flag overrides cached UUID
+	}
+
+	// Initialize relationship client.
+	relClient, err := deps.relationshipClient(cfg)
+	if err != nil {
+		return fmt.Errorf("initializing relationship client: %w", err)
+	}
+	defer deps.Close()
+
+	conflicts, err := listPendingConflicts(ctx, relClient, cfg.EffectiveTenantID(), conflictResolveType)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Println("No pending conflicts match.")
+		return nil
+	}
+
+	if !conflictResolveConfirm {
+		fmt.Printf("Dry run: %d conflicts would be resolved with strategy '%s' (use --confirm to apply):\n\n", len(conflicts), strategy)
+		for _, c := range conflicts {
+			fmt.Printf("  %-20s  %-28s  %s\n", truncateString(c.ID, 20), c.Type, truncateString(c.Description, 50))
+		}
+		return nil
+	}
+
+	// On a terminal, a progress bar replaces the per-conflict lines; failures
+	// are still printed.
+	bar := newProgressBar(progressOut(config.OutputFormatText), len(conflicts), "resolved")
+	bar.every = 0
+
+	resolved, failed, updatedTotal := 0, 0, 0
+	for i, c := range conflicts {
+		_, updated, err := relClient.ResolveConflict(ctx, &client.ResolveConflictRequest{
+			TenantID:   cfg.EffectiveTenantID(),
+			ConflictID: c.ID,
+			Strategy:   stringToConflictStrategy(string(strategy)),
+		})
+		if err != nil {
+			failed++
+			bar.Printf(os.Stdout, "[%d/%d] \033[31mfailed\033[0m %s: %v\n", i+1, len(conflicts), c.ID, err)
+		} else {
+			resolved++
+			updatedTotal += int(updated)
+			if !bar.interactive {
+				fmt.Printf("[%d/%d] resolved %s (%d relationships updated)\n", i+1, len(conflicts), c.ID, updated)
+			}
+		}
+		bar.Set(i+1, fmt.Sprintf("(%d failed)", failed))
+	}
+	bar.Finish()
+
+	fmt.Println()
+	fmt.Printf("%d conflicts resolved, %d relationships updated", resolved, updatedTotal)
+	if failed > 0 {
+		fmt.Printf(", %d failed\n", failed)
+		return fmt.Errorf("%d of %d conflicts failed", failed, len(conflicts))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// listPendingConflicts collects every pending conflict, optionally of one
+// type. All pages are read before anything is resolved so that resolutions
+// don't shift the pages.
+func listPendingConflicts(ctx context.Context, relClient *client.RelationshipClient, tenantID, conflictType string) ([]*client.RelationshipConflict, error) {
+	req := &client.ListConflictsRequest{
+		TenantID: tenantID,
+		Status:   relationshipv1.ConflictStatus_CONFLICT_STATUS_PENDING,
+		Limit:    100,
+	}
+	var conflicts []*client.RelationshipConflict
+	for {
+		page, total, err := relClient.ListConflicts(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("listing conflicts: %w", err)
+		}
+		for _, c := range page {
+			if conflictType == "" || strings.EqualFold(c.Type, conflictType) {
+				conflicts = append(conflicts, c)
+			}
+		}
+		req.Offset += int32(len(page))
+		if len(page) == 0 || int64(req.Offset) >= total {
+			break
+		}
+	}
+	return conflicts, nil
+}
+
+// runConflictResolveInteractive walks through each pending conflict, shows
+// its detail and prompts for a strategy. Each choice is applied immediately,
+// so quitting or interrupting part way leaves earlier resolutions in place.
+func runConflictResolveInteractive(ctx context.Context, deps *RelationshipCommandDeps, in io.Reader, insecureFlag bool) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	// Override insecure if flag is set.
+	if insecureFlag {
+		cfg.Insecure = true
+	}
+
+	// Override tenant if specified.
+	if relationshipTenant != "" {
+		cfg.TenantID = relationshipTenant
+		cfg.TenantUUID = "" // flag overrides cached UUID
+	}
+
+	// Initialize relationship client.
+	relClient, err := deps.relationshipClient(cfg)
+	if err != nil {
+		return fmt.Errorf("initializing relationship client: %w", err)
+	}
+	defer deps.Close()
+
+	conflicts, err := listPendingConflicts(ctx, relClient, cfg.EffectiveTenantID(), conflictResolveType)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("No pending conflicts match.")
+		return nil
+	}
+
+	reader := bufio.NewReader(in)
+	resolved, skipped, failed, updatedTotal := 0, 0, 0, 0
+	reviewed := 0
+
+walk:
+	for i, c := range conflicts {
+		if ctx.Err() != nil {
+			break
+		}
+
+		fmt.Printf("\n\033[1m── Conflict %d of %d ──\033[0m\n\n", i+1, len(conflicts))
+		detail := clientConflictToLocal(c)
+		if err := outputConflictDetailText(detail); err != nil {
+			return err
+		}
+		fmt.Println()
+
+		strategy, ok := promptConflictStrategy(reader)
+		if !ok {
+			break walk
+		}
+		reviewed++
+		if strategy == "" {
+			skipped++
+			fmt.Printf("Skipped %s\n", c.ID)
+			continue
+		}
+
+		_, updated, err := relClient.ResolveConflict(ctx, &client.ResolveConflictRequest{
+			TenantID:   cfg.EffectiveTenantID(),
+			ConflictID: c.ID,
+			Strategy:   stringToConflictStrategy(string(strategy)),
+		})
+		if err != nil {
+			failed++
+			fmt.Printf("\033[31mFailed\033[0m to resolve %s: %v\n", c.ID, err)
+			continue
+		}
+		resolved++
+		updatedTotal += int(updated)
+		fmt.Printf("\033[32mResolved\033[0m %s with %s (%d relationships updated)\n", c.ID, strategy, updated)
+	}
+
+	fmt.Println()
+	fmt.Printf("%d resolved, %d skipped, %d failed, %d relationships updated", resolved, skipped, failed, updatedTotal)
+	if remaining := len(conflicts) - reviewed; remaining > 0 {
+		fmt.Printf(" (%d not reviewed)", remaining)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// promptConflictStrategy asks for a resolution strategy. It returns an empty
+// strategy to skip the conflict, and ok=false to quit (on "q" or end of input).
+func promptConflictStrategy(reader *bufio.Reader) (strategy ConflictResolutionStrategy, ok bool) {
+	for {
+		fmt.Print("Strategy? [1] keep_latest  [2] keep_first  [3] merge  [s]kip  [q]uit: ")
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Println()
+			return "", false
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "1", string(ConflictStrategyKeepLatest):
+			return ConflictStrategyKeepLatest, true
+		case "2", string(ConflictStrategyKeepFirst):
+			return ConflictStrategyKeepFirst, true
+		case "3", string(ConflictStrategyMerge):
+			return ConflictStrategyMerge, true
+		case "s", "skip", "":
+			return "", true
+		case "q", "quit":
+			return "", false
+		default:
+			fmt.Println("Please choose 1, 2, 3, s or q.")
+		}
+	}
+}
+
+// validateConflictStrategy reports an error for unknown resolution strategies.
+func validateConflictStrategy(strategy ConflictResolutionStrategy) error {
+	switch strategy {
+	case ConflictStrategyKeepLatest, ConflictStrategyKeepFirst, ConflictStrategyMerge, ConflictStrategyManual:
+		return nil
+	default:
+		return fmt.Errorf("invalid resolution strategy: %s (must be keep_latest, keep_first, merge, or manual)", strategy)
+	}
+}
+
 // Type conversion helpers
 
 // stringToEntityType converts a string to a proto EntityType.
@@ -2060,9 +2867,78 @@ func outputRelationships(format config.OutputFormat, relationships []Relationshi
 		return outputRelJSON(relationships)
 	case config.OutputFormatYAML:
 		return outputRelYAML(relationships)
-	default:
+	case config.OutputFormatCSV:
+		return outputRelationshipsCSV(os.Stdout, relationships)
+	case config.OutputFormatText, "", "table":
 		return outputRelationshipsText(relationships)
+	default:
+		return unsupportedRelOutput(format)
+	}
+}
+
+// outputRelationshipList outputs one page of 'relationship list' results.
+func outputRelationshipList(format config.OutputFormat, result RelationshipListResult) error {
+	switch format {
+	case config.OutputFormatJSON:
+		return outputRelJSON(result)
+	case config.OutputFormatYAML:
+		return outputRelYAML(result)
+	case config.OutputFormatCSV:
+		if err := outputRelationshipsCSV(os.Stdout, result.Relationships); err != nil {
+			return err
+		}
+		printNextPageHint(os.Stderr, result.NextPageToken)
+		return nil
+	case config.OutputFormatText, "", "table":
+		if err := outputRelationshipsText(result.Relationships); err != nil {
+			return err
+		}
+		printNextPageHint(os.Stdout, result.NextPageToken)
+		return nil
+	default:
+		return unsupportedRelOutput(format)
+	}
+}
+
+// outputRelationshipMatches outputs relationship search results with match scores.
+func outputRelationshipMatches(format config.OutputFormat, matches []RelationshipMatch) error {
+	switch format {
+	case config.OutputFormatJSON:
+		return outputRelJSON(matches)
+	case config.OutputFormatYAML:
+		return outputRelYAML(matches)
+	case config.OutputFormatText, "", "table":
+		return outputRelationshipMatchesText(matches)
+	default:
+		return unsupportedRelOutput(format)
+	}
+}
+
+// outputRelationshipMatchesText outputs relationship search results in human-readable format.
+func outputRelationshipMatchesText(matches []RelationshipMatch) error {
+	if len(matches) == 0 {
+		fmt.Println("No relationships found.")
+		return nil
+	}
+
+	fmt.Printf("Relationships (%d):\n\n", len(matches))
+	fmt.Println("  ID           SOURCE               TYPE           TARGET               CONFIDENCE  MATCH")
+	fmt.Println("  --           ------               ----           ------               ----------  -----")
+
+	for _, m := range matches {
+		confidenceColor := getConfidenceColor(m.Confidence)
+		fmt.Printf("  %-12s %-20s %-14s %-20s %s%-10.2f\033[0m  %.2f\n",
+			truncateString(m.ID, 12),
+			truncateString(m.SourceName, 20),
+			m.Type,
+			truncateString(m.TargetName, 20),
+			confidenceColor,
+			m.Confidence,
+			m.MatchScore)
 	}
+
+	fmt.Println()
+	return nil
 }
 
 // outputRelationshipsText outputs relationships in human-readable format.
@@ -2098,8 +2974,14 @@ func outputRelationshipDetail(format config.OutputFormat, r Relationship) error
 		return outputRelJSON(r)
 	case config.OutputFormatYAML:
 		return outputRelYAML(r)
-	default:
+	case config.OutputFormatCSV:
+		return outputRelationshipDetailCSV(os.Stdout, r)
+	case config.OutputFormatTemplate:
+		return outputWithTemplate(os.Stdout, r)
+	case config.OutputFormatText, "", "table":
 		return outputRelationshipDetailText(r)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2120,7 +3002,9 @@ func outputRelationshipDetailText(r Relationship) error {
 	fmt.Printf("  \033[1mFirst Seen:\033[0m  %s\n", r.FirstSeen.Format(time.RFC3339))
 	fmt.Printf("  \033[1mLast Seen:\033[0m   %s\n", r.LastSeen.Format(time.RFC3339))
 	fmt.Println()
-	if len(r.Evidence) > 0 {
+	if len(r.EvidenceDetails) > 0 {
+		outputEvidenceDetailsText(r.EvidenceDetails)
+	} else if len(r.Evidence) > 0 {
 		fmt.Println("  \033[1mEvidence:\033[0m")
 		for _, e := range r.Evidence {
 			fmt.Printf("    - %s\n", e)
@@ -2130,6 +3014,31 @@ func outputRelationshipDetailText(r Relationship) error {
 	return nil
 }
 
+// outputEvidenceDetailsText lists each piece of evidence with its source and
+// the command to open the underlying content.
+func outputEvidenceDetailsText(evidence []RelationshipEvidence) {
+	fmt.Printf("  \033[1mEvidence (%d):\033[0m\n", len(evidence))
+	for i, e := range evidence {
+		fmt.Println()
+		source := e.SourceID
+		if e.SourceType != "" {
+			source = fmt.Sprintf("%s %s", e.SourceType, e.SourceID)
+		}
+		discovered := "-"
+		if !e.DiscoveredAt.IsZero() {
+			discovered = e.DiscoveredAt.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("    [%d] %s  \033[2m%s\033[0m  confidence %s%+.2f\033[0m\n",
+			i+1, source, discovered, getConfidenceColor(e.Confidence), e.Confidence)
+		if e.Excerpt != "" {
+			fmt.Printf("        %q\n", e.Excerpt)
+		}
+		if e.SourceID != "" {
+			fmt.Printf("        \033[2m→ penf content show %s\033[0m\n", e.SourceID)
+		}
+	}
+}
+
 // outputEntities outputs entities in the specified format.
 func outputEntities(format config.OutputFormat, entities []Entity) error {
 	switch format {
@@ -2137,9 +3046,60 @@ func outputEntities(format config.OutputFormat, entities []Entity) error {
 		return outputRelJSON(entities)
 	case config.OutputFormatYAML:
 		return outputRelYAML(entities)
-	default:
+	case config.OutputFormatCSV:
+		return outputEntitiesCSV(os.Stdout, entities)
+	case config.OutputFormatText, "", "table":
 		return outputEntitiesText(entities)
+	default:
+		return unsupportedRelOutput(format)
+	}
+}
+
+// outputEntityList outputs one page of 'relationship entity list' results.
+func outputEntityList(format config.OutputFormat, result EntityListResult) error {
+	switch format {
+	case config.OutputFormatJSON:
+		return outputRelJSON(result)
+	case config.OutputFormatYAML:
+		return outputRelYAML(result)
+	case config.OutputFormatCSV:
+		if err := outputEntitiesCSV(os.Stdout, result.Entities); err != nil {
+			return err
+		}
+		printNextPageHint(os.Stderr, result.NextPageToken)
+		return nil
+	case config.OutputFormatText, "", "table":
+		if err := outputEntitiesText(result.Entities); err != nil {
+			return err
+		}
+		printNextPageHint(os.Stdout, result.NextPageToken)
+		return nil
+	default:
+		return unsupportedRelOutput(format)
+	}
+}
+
+// printNextPageHint tells the user how to fetch the next page, if there is one.
+func printNextPageHint(w io.Writer, nextPageToken string) {
+	if nextPageToken != "" {
+		fmt.Fprintf(w, "More results available. Use --page-token=%s to fetch next page, or --all to fetch every page.\n", nextPageToken)
+	}
+}
+
+// isValidEntityAccountType reports whether accountType is a known account type.
+func isValidEntityAccountType(accountType string) bool {
+	for _, t := range entityAccountTypes {
+		if t == accountType {
+			return true
+		}
 	}
+	return false
+}
+
+// entityHasAccountType reports whether e has the given account type. An empty
+// account type matches every entity.
+func entityHasAccountType(e Entity, accountType string) bool {
+	return accountType == "" || strings.EqualFold(e.AccountType, accountType)
 }
 
 // outputEntitiesText outputs entities in human-readable format.
@@ -2150,17 +3110,22 @@ func outputEntitiesText(entities []Entity) error {
 	}
 
 	fmt.Printf("Entities (%d):\n\n", len(entities))
-	fmt.Println("  ID               NAME                 TYPE           RELATIONS  CONFIDENCE")
-	fmt.Println("  --               ----                 ----           ---------  ----------")
+	fmt.Println("  ID               NAME                 TYPE           ACCOUNT           RELATIONS  CONFIDENCE")
+	fmt.Println("  --               ----                 ----           -------           ---------  ----------")
 
 	for _, e := range entities {
 		confidenceColor := getConfidenceColor(e.Confidence)
 		typeColor := getEntityTypeColor(e.Type)
-		fmt.Printf("  %-16s %-20s %s%-14s\033[0m %4d       %s%.2f\033[0m\n",
+		accountType := e.AccountType
+		if accountType == "" {
+			accountType = "-"
+		}
+		fmt.Printf("  %-16s %-20s %s%-14s\033[0m %-17s %4d       %s%.2f\033[0m\n",
 			truncateString(e.ID, 16),
 			truncateString(e.Name, 20),
 			typeColor,
 			e.Type,
+			truncateString(accountType, 17),
 			e.RelationCount,
 			confidenceColor,
 			e.Confidence)
@@ -2177,8 +3142,14 @@ func outputEntityDetail(format config.OutputFormat, e Entity) error {
 		return outputRelJSON(e)
 	case config.OutputFormatYAML:
 		return outputRelYAML(e)
-	default:
+	case config.OutputFormatCSV:
+		return outputEntityDetailCSV(os.Stdout, e)
+	case config.OutputFormatTemplate:
+		return outputWithTemplate(os.Stdout, e)
+	case config.OutputFormatText, "", "table":
 		return outputEntityDetailText(e)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2252,8 +3223,10 @@ func outputCentralEntities(format config.OutputFormat, entities []Entity) error
 		return outputRelJSON(entities)
 	case config.OutputFormatYAML:
 		return outputRelYAML(entities)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputCentralEntitiesText(entities)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2293,8 +3266,10 @@ func outputClusters(format config.OutputFormat, clusters []NetworkCluster) error
 		return outputRelJSON(clusters)
 	case config.OutputFormatYAML:
 		return outputRelYAML(clusters)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputClustersText(clusters)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2332,8 +3307,10 @@ func outputConflicts(format config.OutputFormat, conflicts []RelationshipConflic
 		return outputRelJSON(conflicts)
 	case config.OutputFormatYAML:
 		return outputRelYAML(conflicts)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputConflictsText(conflicts)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2374,8 +3351,10 @@ func outputConflictDetail(format config.OutputFormat, c RelationshipConflict) er
 		return outputRelJSON(c)
 	case config.OutputFormatYAML:
 		return outputRelYAML(c)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputConflictDetailText(c)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2418,8 +3397,12 @@ func outputDuplicatePairs(format config.OutputFormat, pairs []*client.DuplicateP
 	switch format {
 	case config.OutputFormatJSON:
 		return outputRelJSON(pairs)
-	default:
+	case config.OutputFormatCSV:
+		return outputDuplicatePairsCSV(os.Stdout, pairs)
+	case config.OutputFormatText, "", "table":
 		return outputDuplicatePairsText(pairs)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2456,8 +3439,10 @@ func outputAutoMergeResult(format config.OutputFormat, result *client.AutoMergeR
 	switch format {
 	case config.OutputFormatJSON:
 		return outputRelJSON(result)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputAutoMergeResultText(result, isDryRun)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2508,8 +3493,10 @@ func outputMergePreview(format config.OutputFormat, preview *client.MergePreview
 	switch format {
 	case config.OutputFormatJSON:
 		return outputRelJSON(preview)
-	default:
+	case config.OutputFormatText, "", "table":
 		return outputMergePreviewText(preview, entityID1, entityID2)
+	default:
+		return unsupportedRelOutput(format)
 	}
 }
 
@@ -2597,6 +3584,12 @@ func getEntityTypeColor(t EntityType) string {
 	}
 }
 
+// unsupportedRelOutput rejects an output format that a relationship renderer
+// cannot produce, rather than silently falling back to text.
+func unsupportedRelOutput(format config.OutputFormat) error {
+	return InvalidArgumentError(fmt.Errorf("--output %s is not supported by this command", format))
+}
+
 // outputRelJSON outputs data as JSON.
 func outputRelJSON(v interface{}) error {
 	enc := json.NewEncoder(os.Stdout)
@@ -2606,7 +3599,7 @@ func outputRelJSON(v interface{}) error {
 
 // outputRelYAML outputs data as YAML.
 func outputRelYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -2633,6 +3626,7 @@ func clientEntityToLocal(e *client.RelEntity) Entity {
 		CommunicationPatterns: e.CommunicationPatterns,
 		ExpertiseAreas:        e.ExpertiseAreas,
 		OrgPosition:           e.OrgPosition,
+		AccountType:           e.Metadata[accountTypeMetadataKey],
 	}
 }
 
@@ -2674,6 +3668,21 @@ func clientConflictToLocal(c *client.RelationshipConflict) RelationshipConflict
 	}
 }
 
+// clientEvidenceToLocal converts client evidence to its full local form.
+func clientEvidenceToLocal(evidence []client.Evidence) []RelationshipEvidence {
+	details := make([]RelationshipEvidence, len(evidence))
+	for i, e := range evidence {
+		details[i] = RelationshipEvidence{
+			SourceID:     e.SourceID,
+			SourceType:   e.SourceType,
+			Excerpt:      e.Excerpt,
+			DiscoveredAt: e.DiscoveredAt,
+			Confidence:   float64(e.ConfidenceContribution),
+		}
+	}
+	return details
+}
+
 // clientRelToLocal converts a client Relationship to a local Relationship.
 func clientRelToLocal(r *client.Relationship) Relationship {
 	if r == nil {
@@ -0,0 +1,471 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// pagingRelationshipServer serves entities by offset and relationships by
+// page token so list paging can be tested end to end.
+type pagingRelationshipServer struct {
+	relationshipv1.UnimplementedRelationshipServiceServer
+	entities      []*relationshipv1.Entity
+	relationships []*relationshipv1.Relationship
+	conflicts     []*relationshipv1.Conflict
+	resolved      []string
+}
+
+func (s *pagingRelationshipServer) ListEntities(ctx context.Context, req *relationshipv1.ListEntitiesRequest) (*relationshipv1.ListEntitiesResponse, error) {
+	start := min(int(req.Offset), len(s.entities))
+	end := min(start+int(req.PageSize), len(s.entities))
+	return &relationshipv1.ListEntitiesResponse{
+		Entities:   s.entities[start:end],
+		TotalCount: int64(len(s.entities)),
+	}, nil
+}
+
+func (s *pagingRelationshipServer) ListRelationships(ctx context.Context, req *relationshipv1.ListRelationshipsRequest) (*relationshipv1.ListRelationshipsResponse, error) {
+	start := 0
+	if req.PageToken != "" {
+		start, _ = strconv.Atoi(strings.TrimPrefix(req.PageToken, "tok-"))
+	}
+	end := min(start+int(req.PageSize), len(s.relationships))
+	resp := &relationshipv1.ListRelationshipsResponse{Relationships: s.relationships[start:end]}
+	if end < len(s.relationships) {
+		resp.NextPageToken = fmt.Sprintf("tok-%d", end)
+	}
+	total := int64(len(s.relationships))
+	resp.TotalCount = &total
+	return resp, nil
+}
+
+func (s *pagingRelationshipServer) ListConflicts(ctx context.Context, req *relationshipv1.ListConflictsRequest) (*relationshipv1.ListConflictsResponse, error) {
+	start := min(int(req.Offset), len(s.conflicts))
+	end := min(start+int(req.Limit), len(s.conflicts))
+	return &relationshipv1.ListConflictsResponse{
+		Conflicts:  s.conflicts[start:end],
+		TotalCount: int64(len(s.conflicts)),
+	}, nil
+}
+
+func (s *pagingRelationshipServer) ResolveConflict(ctx context.Context, req *relationshipv1.ResolveConflictRequest) (*relationshipv1.ResolveConflictResponse, error) {
+	if strings.HasSuffix(req.ConflictId, "-fail") {
+		return nil, fmt.Errorf("conflict is locked")
+	}
+	s.resolved = append(s.resolved, req.ConflictId)
+	return &relationshipv1.ResolveConflictResponse{
+		Conflict:             &relationshipv1.Conflict{Id: req.ConflictId},
+		RelationshipsUpdated: 2,
+		Success:              true,
+	}, nil
+}
+
+func (s *pagingRelationshipServer) MergeEntities(ctx context.Context, req *relationshipv1.MergeEntitiesRequest) (*relationshipv1.MergeEntitiesResponse, error) {
+	return &relationshipv1.MergeEntitiesResponse{
+		PrimaryEntity:            &relationshipv1.Entity{Id: req.PrimaryEntityId},
+		RelationshipsTransferred: 3,
+		Success:                  true,
+	}, nil
+}
+
+// startPagingRelationshipServer starts a relationship server and returns deps
+// wired to it. Flags touched by list commands are reset when the test ends.
+func startPagingRelationshipServer(t *testing.T, srv *pagingRelationshipServer) *RelationshipCommandDeps {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	relationshipv1.RegisterRelationshipServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	cfg := mockConfig()
+	cfg.OutputFormat = config.OutputFormatJSON
+	deps := createRelationshipTestDeps(cfg)
+	deps.InitRelClient = func(c *config.CLIConfig) (*client.RelationshipClient, error) {
+		relClient := client.NewRelationshipClient(lis.Addr().String(), client.DefaultOptions())
+		if err := relClient.Connect(context.Background()); err != nil {
+			return nil, err
+		}
+		return relClient, nil
+	}
+	t.Cleanup(func() { deps.Close() })
+
+	oldLimit, oldToken, oldAll, oldAccount := relationshipLimit, relationshipPageToken, relationshipAllPages, relationshipAccountType
+	t.Cleanup(func() {
+		relationshipLimit, relationshipPageToken, relationshipAllPages, relationshipAccountType = oldLimit, oldToken, oldAll, oldAccount
+	})
+	return deps
+}
+
+func testEntities(n int) []*relationshipv1.Entity {
+	entities := make([]*relationshipv1.Entity, n)
+	for i := range entities {
+		accountType := "person"
+		if i%2 == 1 {
+			accountType = "bot"
+		}
+		entities[i] = &relationshipv1.Entity{
+			Id:       fmt.Sprintf("ent-%d", i),
+			Name:     fmt.Sprintf("Entity %d", i),
+			Metadata: map[string]string{accountTypeMetadataKey: accountType},
+		}
+	}
+	return entities
+}
+
+func runEntityListJSON(t *testing.T, deps *RelationshipCommandDeps) EntityListResult {
+	t.Helper()
+	var runErr error
+	out := captureStdout(func() {
+		runErr = runEntityList(context.Background(), deps, false)
+	})
+	if runErr != nil {
+		t.Fatalf("runEntityList: %v", runErr)
+	}
+	var result EntityListResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, out)
+	}
+	return result
+}
+
+func TestRunEntityList_PageToken(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{entities: testEntities(5)})
+	relationshipLimit = 2
+
+	first := runEntityListJSON(t, deps)
+	if first.Count != 2 || first.TotalCount != 5 || first.NextPageToken == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	relationshipPageToken = first.NextPageToken
+	second := runEntityListJSON(t, deps)
+	if second.Entities[0].ID != "ent-2" {
+		t.Errorf("second page starts at %s, want ent-2", second.Entities[0].ID)
+	}
+
+	relationshipPageToken = "4"
+	last := runEntityListJSON(t, deps)
+	if last.Count != 1 || last.NextPageToken != "" {
+		t.Errorf("last page = %+v, want one entity and no token", last)
+	}
+}
+
+func TestRunEntityList_AccountTypeResumesAfterLastConsumed(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{entities: testEntities(8)})
+	relationshipLimit = 2
+	relationshipAccountType = "bot"
+
+	first := runEntityListJSON(t, deps)
+	if first.Count != 2 || first.Entities[0].ID != "ent-1" || first.Entities[1].ID != "ent-3" {
+		t.Fatalf("first page = %+v", first.Entities)
+	}
+	if first.TotalCount != 0 {
+		t.Errorf("filtered first page total = %d, want 0 rather than the unfiltered total", first.TotalCount)
+	}
+
+	relationshipPageToken = first.NextPageToken
+	second := runEntityListJSON(t, deps)
+	if second.Count != 2 || second.Entities[0].ID != "ent-5" || second.Entities[1].ID != "ent-7" {
+		t.Errorf("second page = %+v, want ent-5 and ent-7", second.Entities)
+	}
+	if second.NextPageToken != "" {
+		t.Errorf("expected no token after the last entity, got %q", second.NextPageToken)
+	}
+}
+
+func TestWarnAccountTypeFilter(t *testing.T) {
+	var buf strings.Builder
+	warnAccountTypeFilter(&buf, 0, 5, 0)
+	if !strings.Contains(buf.String(), "client-side") || !strings.Contains(buf.String(), "none of the 5 entities") {
+		t.Errorf("missing-key warning = %q", buf.String())
+	}
+
+	buf.Reset()
+	warnAccountTypeFilter(&buf, 0, 5, 3)
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("unexpected warning when entities carry account types: %q", buf.String())
+	}
+}
+
+func TestRunEntityList_All(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{entities: testEntities(7)})
+	relationshipLimit = 3
+	relationshipAllPages = true
+
+	result := runEntityListJSON(t, deps)
+	if result.Count != 7 || result.NextPageToken != "" {
+		t.Errorf("--all = %d entities, token %q; want 7 and none", result.Count, result.NextPageToken)
+	}
+}
+
+func TestRunRelationshipList_PageTokenAndAll(t *testing.T) {
+	rels := make([]*relationshipv1.Relationship, 5)
+	for i := range rels {
+		rels[i] = &relationshipv1.Relationship{Id: fmt.Sprintf("rel-%d", i)}
+	}
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{relationships: rels})
+	relationshipLimit = 2
+
+	run := func() RelationshipListResult {
+		t.Helper()
+		var runErr error
+		out := captureStdout(func() {
+			runErr = runRelationshipList(context.Background(), deps, false)
+		})
+		if runErr != nil {
+			t.Fatalf("runRelationshipList: %v", runErr)
+		}
+		var result RelationshipListResult
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("decoding output: %v\n%s", err, out)
+		}
+		return result
+	}
+
+	first := run()
+	if first.Count != 2 || first.NextPageToken != "tok-2" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	relationshipPageToken = first.NextPageToken
+	if second := run(); second.Relationships[0].ID != "rel-2" {
+		t.Errorf("second page starts at %s, want rel-2", second.Relationships[0].ID)
+	}
+
+	relationshipPageToken = ""
+	relationshipAllPages = true
+	if all := run(); all.Count != 5 || all.NextPageToken != "" {
+		t.Errorf("--all = %+v, want 5 relationships and no token", all)
+	}
+}
+
+func TestParseEntityPageToken(t *testing.T) {
+	if offset, err := parseEntityPageToken(""); err != nil || offset != 0 {
+		t.Errorf("empty token = %d, %v", offset, err)
+	}
+	if offset, err := parseEntityPageToken(formatEntityPageToken(40)); err != nil || offset != 40 {
+		t.Errorf("round trip = %d, %v", offset, err)
+	}
+	for _, bad := range []string{"abc", "-1"} {
+		if _, err := parseEntityPageToken(bad); err == nil {
+			t.Errorf("expected error for token %q", bad)
+		}
+	}
+}
+
+func TestOutputEntityList_TextNextPageHint(t *testing.T) {
+	out := captureStdout(func() {
+		_ = outputEntityList(config.OutputFormatText, EntityListResult{
+			Entities:      []Entity{{ID: "ent-1", Name: "Jane", Type: EntityTypePerson}},
+			Count:         1,
+			NextPageToken: "100",
+		})
+	})
+	if !strings.Contains(out, "--page-token=100") {
+		t.Errorf("expected next page hint, got:\n%s", out)
+	}
+}
+
+func TestRunEntityList_JSONLinesStreamsEveryPage(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{entities: testEntities(5)})
+	relationshipLimit = 2
+	relationshipAllPages = true
+	oldOutput := relationshipOutput
+	relationshipOutput = string(config.OutputFormatJSONL)
+	t.Cleanup(func() { relationshipOutput = oldOutput })
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = runEntityList(context.Background(), deps, false)
+	})
+	if runErr != nil {
+		t.Fatalf("runEntityList: %v", runErr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want 5:\n%s", len(lines), out)
+	}
+	for i, line := range lines {
+		var entity Entity
+		if err := json.Unmarshal([]byte(line), &entity); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", i, err)
+		}
+		if want := fmt.Sprintf("ent-%d", i); entity.ID != want {
+			t.Errorf("line %d = %s, want %s", i, entity.ID, want)
+		}
+	}
+}
+
+func TestRunConflictResolveAll(t *testing.T) {
+	srv := &pagingRelationshipServer{}
+	for i, typ := range []string{"low_confidence", "duplicate_entity", "low_confidence", "low_confidence"} {
+		id := fmt.Sprintf("conf-%d", i)
+		if i == 3 {
+			id += "-fail"
+		}
+		srv.conflicts = append(srv.conflicts, &relationshipv1.Conflict{Id: id, Type: typ})
+	}
+	deps := startPagingRelationshipServer(t, srv)
+
+	oldAll, oldType, oldConfirm := conflictResolveAll, conflictResolveType, conflictResolveConfirm
+	t.Cleanup(func() { conflictResolveAll, conflictResolveType, conflictResolveConfirm = oldAll, oldType, oldConfirm })
+	conflictResolveAll = true
+	conflictResolveType = "low_confidence"
+
+	// Dry run lists matching conflicts and resolves nothing.
+	out := captureStdout(func() {
+		if err := runConflictResolveAll(context.Background(), deps, ConflictStrategyKeepLatest, false); err != nil {
+			t.Errorf("dry run: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Dry run: 3 conflicts") || len(srv.resolved) != 0 {
+		t.Fatalf("dry run resolved %v, output:\n%s", srv.resolved, out)
+	}
+
+	conflictResolveConfirm = true
+	var runErr error
+	out = captureStdout(func() {
+		runErr = runConflictResolveAll(context.Background(), deps, ConflictStrategyKeepLatest, false)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "1 of 3 conflicts failed") {
+		t.Errorf("err = %v, want one failure", runErr)
+	}
+	if strings.Join(srv.resolved, ",") != "conf-0,conf-2" {
+		t.Errorf("resolved %v, want conf-0 and conf-2", srv.resolved)
+	}
+	if !strings.Contains(out, "2 conflicts resolved, 4 relationships updated, 1 failed") {
+		t.Errorf("missing summary in:\n%s", out)
+	}
+}
+
+func TestRunConflictResolveAll_RefusesManual(t *testing.T) {
+	err := runConflictResolveAll(context.Background(), createRelationshipTestDeps(mockConfig()), ConflictStrategyManual, false)
+	if err == nil || !strings.Contains(err.Error(), "manual") {
+		t.Errorf("err = %v, want manual strategy refused", err)
+	}
+}
+
+func TestRunConflictResolveInteractive(t *testing.T) {
+	srv := &pagingRelationshipServer{}
+	for i := 0; i < 4; i++ {
+		srv.conflicts = append(srv.conflicts, &relationshipv1.Conflict{Id: fmt.Sprintf("conf-%d", i), Type: "low_confidence"})
+	}
+	deps := startPagingRelationshipServer(t, srv)
+
+	// Resolve the first, re-prompt on bad input, skip the second, resolve the
+	// third with merge, then quit before the fourth.
+	in := strings.NewReader("1\nbogus\ns\nmerge\nq\n")
+	out := captureStdout(func() {
+		if err := runConflictResolveInteractive(context.Background(), deps, in, false); err != nil {
+			t.Errorf("runConflictResolveInteractive: %v", err)
+		}
+	})
+
+	if strings.Join(srv.resolved, ",") != "conf-0,conf-2" {
+		t.Errorf("resolved %v, want conf-0 and conf-2", srv.resolved)
+	}
+	for _, want := range []string{"Conflict 1 of 4", "Please choose", "Skipped conf-1", "2 resolved, 1 skipped, 0 failed, 4 relationships updated (1 not reviewed)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestPromptConflictStrategy_EOFQuits(t *testing.T) {
+	captureStdout(func() {
+		if _, ok := promptConflictStrategy(bufio.NewReader(strings.NewReader(""))); ok {
+			t.Error("expected end of input to quit")
+		}
+	})
+}
+
+func TestFetchFuzzyCandidates_CapsAndReportsTruncation(t *testing.T) {
+	calls := 0
+	listPage := func(ctx context.Context, req *client.ListRelationshipsRequest) (*client.RelationshipPage, error) {
+		calls++
+		offset, _ := strconv.Atoi(req.PageToken)
+		page := &client.RelationshipPage{}
+		for i := 0; i < int(req.PageSize); i++ {
+			page.Relationships = append(page.Relationships, &client.Relationship{ID: fmt.Sprintf("rel-%d", offset+i)})
+		}
+		page.NextPageToken = strconv.Itoa(offset + int(req.PageSize))
+		return page, nil
+	}
+
+	rels, truncated, err := fetchFuzzyCandidates(context.Background(), listPage, &client.ListRelationshipsRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("fetchFuzzyCandidates: %v", err)
+	}
+	if !truncated {
+		t.Error("expected truncated when more pages remain past the cap")
+	}
+	if len(rels) != fuzzyCandidateMax {
+		t.Errorf("got %d candidates, want %d", len(rels), fuzzyCandidateMax)
+	}
+	if want := fuzzyCandidateMax / fuzzyCandidatePageSize; calls != want {
+		t.Errorf("got %d page calls, want %d", calls, want)
+	}
+}
+
+func TestFetchFuzzyCandidates_AllPages(t *testing.T) {
+	rels := make([]*relationshipv1.Relationship, 1200)
+	for i := range rels {
+		rels[i] = &relationshipv1.Relationship{Id: fmt.Sprintf("rel-%d", i)}
+	}
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{relationships: rels})
+	relClient, err := deps.InitRelClient(mockConfig())
+	if err != nil {
+		t.Fatalf("init client: %v", err)
+	}
+	defer relClient.Close()
+
+	got, truncated, err := fetchFuzzyCandidates(context.Background(), relClient.ListRelationshipsPage, &client.ListRelationshipsRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("fetchFuzzyCandidates: %v", err)
+	}
+	if truncated {
+		t.Error("did not expect truncation below the cap")
+	}
+	if len(got) != len(rels) {
+		t.Errorf("got %d candidates, want %d", len(got), len(rels))
+	}
+}
+
+func TestRunEntityList_ClosesClientOnError(t *testing.T) {
+	deps := startPagingRelationshipServer(t, &pagingRelationshipServer{entities: testEntities(3)})
+	relationshipPageToken = "not-a-number"
+
+	if err := runEntityList(context.Background(), deps, false); err == nil {
+		t.Fatal("expected an invalid page token error")
+	}
+	if deps.RelationshipClient != nil {
+		t.Error("expected the relationship client to be closed after a failed run")
+	}
+}
+
+func TestRelationshipCommand_KeepsRootPostRun(t *testing.T) {
+	// A subcommand post-run hook would replace the root's and is skipped when
+	// RunE fails, so the shared connection is closed by the run functions.
+	cmd := NewRelationshipCommand(nil)
+	if cmd.PersistentPostRunE != nil || cmd.PersistentPostRun != nil {
+		t.Error("relationship command must not override the root post-run hook")
+	}
+}
@@ -3,13 +3,16 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
 	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -813,6 +816,31 @@ func TestOutputEntities_JSON(t *testing.T) {
 	}
 }
 
+func TestRelationshipRenderers_RejectUnsupportedFormats(t *testing.T) {
+	cases := map[string]func() error{
+		"merge-preview csv": func() error {
+			return outputMergePreview(config.OutputFormatCSV, &client.MergePreview{}, "ent-person-1", "ent-person-2")
+		},
+		"matches csv":         func() error { return outputRelationshipMatches(config.OutputFormatCSV, nil) },
+		"central jsonl":       func() error { return outputCentralEntities(config.OutputFormatJSONL, nil) },
+		"clusters template":   func() error { return outputClusters(config.OutputFormatTemplate, nil) },
+		"conflicts csv":       func() error { return outputConflicts(config.OutputFormatCSV, nil) },
+		"conflict template":   func() error { return outputConflictDetail(config.OutputFormatTemplate, RelationshipConflict{}) },
+		"auto-merge csv":      func() error { return outputAutoMergeResult(config.OutputFormatCSV, &client.AutoMergeResult{}, true) },
+		"relationships jsonl": func() error { return outputRelationships(config.OutputFormatJSONL, nil) },
+	}
+	for name, render := range cases {
+		err := render()
+		if err == nil {
+			t.Errorf("%s: expected an error, got nil", name)
+			continue
+		}
+		if got := ErrorCode(err); got != ErrorCodeInvalidArgument {
+			t.Errorf("%s: error code = %s, want %s", name, got, ErrorCodeInvalidArgument)
+		}
+	}
+}
+
 func TestOutputConflicts_Empty(t *testing.T) {
 	var conflicts []RelationshipConflict
 
@@ -1378,3 +1406,206 @@ func TestClientEntityToLocal_SentReceivedCount(t *testing.T) {
 		t.Errorf("BUG REPRODUCED: ReceivedCount not mapped. Expected %d, got %d", clientEntity.ReceivedCount, localEntity.ReceivedCount)
 	}
 }
+
+func TestNameMatchScore(t *testing.T) {
+	tests := []struct {
+		query, name string
+		min, max    float64
+	}{
+		{"John Smith", "john smith", 1.0, 1.0},
+		{"John", "John Smith", 0.95, 0.95},
+		{"Jon", "John", 0.7, 0.99},
+		{"Jon", "Jonathan Price", 0.9, 0.9},
+		{"Alice", "Bob", 0, 0.3},
+		{"", "Bob", 0, 0},
+	}
+
+	for _, tt := range tests {
+		got := nameMatchScore(tt.query, tt.name)
+		if got < tt.min || got > tt.max {
+			t.Errorf("nameMatchScore(%q, %q) = %.2f, want in [%.2f, %.2f]", tt.query, tt.name, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestScoreRelationshipMatches(t *testing.T) {
+	rels := []Relationship{
+		{ID: "r1", SourceName: "Alice Brown", TargetName: "Bob Lee"},
+		{ID: "r2", SourceName: "John Smith", TargetName: "Acme"},
+		{ID: "r3", SourceName: "Jon", TargetName: "Acme"},
+		{ID: "r2", SourceName: "John Smith", TargetName: "Acme"},
+	}
+
+	def := scoreRelationshipMatches("Jon", rels, relationshipMatchDefault, 0.7)
+	if len(def) != 3 || def[0].ID != "r1" {
+		t.Errorf("default mode should keep all unique results in order, got %d", len(def))
+	}
+
+	exact := scoreRelationshipMatches("jon", rels, relationshipMatchExact, 0.7)
+	if len(exact) != 1 || exact[0].ID != "r3" || exact[0].MatchedName != "Jon" {
+		t.Errorf("exact mode = %+v, want only r3", exact)
+	}
+
+	fuzzy := scoreRelationshipMatches("Jon", rels, relationshipMatchFuzzy, 0.7)
+	if len(fuzzy) != 2 || fuzzy[0].ID != "r3" || fuzzy[1].ID != "r2" {
+		t.Errorf("fuzzy mode = %+v, want r3 then r2", fuzzy)
+	}
+}
+
+func TestRelationshipMatch_JSONSerialization(t *testing.T) {
+	m := RelationshipMatch{
+		Relationship: Relationship{ID: "r1", SourceName: "John"},
+		MatchScore:   0.75,
+		MatchedName:  "John",
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded["id"] != "r1" || decoded["match_score"] != 0.75 {
+		t.Errorf("expected flattened relationship with match_score, got %s", data)
+	}
+}
+
+func TestClientEntityToLocal_AccountType(t *testing.T) {
+	local := clientEntityToLocal(&client.RelEntity{
+		ID:       "person-002",
+		Name:     "Deploy Bot",
+		Metadata: map[string]string{accountTypeMetadataKey: "bot"},
+	})
+	if local.AccountType != "bot" {
+		t.Errorf("AccountType = %q, want bot", local.AccountType)
+	}
+}
+
+func TestEntityHasAccountType(t *testing.T) {
+	entities := []Entity{
+		{ID: "1", AccountType: "person"},
+		{ID: "2", AccountType: "bot"},
+		{ID: "3"},
+		{ID: "4", AccountType: "Bot"},
+	}
+
+	var bots []string
+	for _, e := range entities {
+		if !entityHasAccountType(e, "") {
+			t.Errorf("empty filter rejected entity %s", e.ID)
+		}
+		if entityHasAccountType(e, "bot") {
+			bots = append(bots, e.ID)
+		}
+	}
+	if strings.Join(bots, ",") != "2,4" {
+		t.Errorf("bot filter matched %v, want entities 2 and 4", bots)
+	}
+}
+
+func TestEntityListHasAccountTypeFlag(t *testing.T) {
+	cmd := newEntityListCommand(nil)
+	if cmd.Flags().Lookup("account-type") == nil {
+		t.Fatal("expected --account-type flag on entity list")
+	}
+	if !isValidEntityAccountType("external_service") || isValidEntityAccountType("robot") {
+		t.Error("isValidEntityAccountType does not match the documented account types")
+	}
+}
+
+func TestOutputEntitiesText_AccountColumn(t *testing.T) {
+	output := captureStdout(func() {
+		_ = outputEntitiesText([]Entity{{ID: "person-9", Name: "Build Bot", Type: EntityTypePerson, AccountType: "bot"}})
+	})
+	if !strings.Contains(output, "ACCOUNT") || !strings.Contains(output, "bot") {
+		t.Errorf("expected account type column in output, got:\n%s", output)
+	}
+}
+
+func TestRelationshipDeps_ReusesConnection(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cfg := mockConfig()
+	dials := 0
+	deps := createRelationshipTestDeps(cfg)
+	deps.InitRelClient = func(c *config.CLIConfig) (*client.RelationshipClient, error) {
+		dials++
+		opts := client.DefaultOptions()
+		relClient := client.NewRelationshipClient(lis.Addr().String(), opts)
+		if err := relClient.Connect(context.Background()); err != nil {
+			return nil, err
+		}
+		return relClient, nil
+	}
+
+	first, err := deps.relationshipClient(cfg)
+	if err != nil {
+		t.Fatalf("relationshipClient: %v", err)
+	}
+	conn, err := deps.gatewayConn(cfg)
+	if err != nil {
+		t.Fatalf("gatewayConn: %v", err)
+	}
+	if dials != 1 {
+		t.Errorf("expected a single dial, got %d", dials)
+	}
+	if conn != first.Conn() {
+		t.Error("expected gatewayConn to share the relationship client connection")
+	}
+
+	if err := deps.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if first.IsConnected() {
+		t.Error("expected Close to close the shared connection")
+	}
+	if _, err := deps.relationshipClient(cfg); err != nil {
+		t.Fatalf("relationshipClient after Close: %v", err)
+	}
+	if dials != 2 {
+		t.Errorf("expected a fresh dial after Close, got %d dials", dials)
+	}
+	deps.Close()
+}
+
+func TestClientEvidenceToLocal(t *testing.T) {
+	discovered := time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+	details := clientEvidenceToLocal([]client.Evidence{{
+		SourceID:               "4521",
+		SourceType:             "email",
+		Excerpt:                "Jane reports to Bob",
+		DiscoveredAt:           discovered,
+		ConfidenceContribution: 0.25,
+	}})
+
+	if len(details) != 1 {
+		t.Fatalf("got %d evidence items, want 1", len(details))
+	}
+	if d := details[0]; d.SourceID != "4521" || d.SourceType != "email" || !d.DiscoveredAt.Equal(discovered) || d.Confidence != 0.25 {
+		t.Errorf("evidence = %+v", d)
+	}
+}
+
+func TestOutputRelationshipDetailText_EvidenceModes(t *testing.T) {
+	rel := Relationship{ID: "rel-1", Evidence: []string{"Jane reports to Bob"}}
+
+	compact := captureStdout(func() { _ = outputRelationshipDetailText(rel) })
+	if !strings.Contains(compact, "- Jane reports to Bob") || strings.Contains(compact, "penf content show") {
+		t.Errorf("compact output should list bare excerpts:\n%s", compact)
+	}
+
+	rel.EvidenceDetails = []RelationshipEvidence{{SourceID: "4521", SourceType: "email", Excerpt: "Jane reports to Bob", Confidence: 0.25}}
+	full := captureStdout(func() { _ = outputRelationshipDetailText(rel) })
+	for _, want := range []string{"Evidence (1)", "email 4521", "+0.25", "penf content show 4521"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("full output missing %q:\n%s", want, full)
+		}
+	}
+}
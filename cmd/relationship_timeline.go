@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Timeline event kinds.
+const (
+	timelineEventFirstSeen = "first_seen"
+	timelineEventLastSeen  = "last_seen"
+)
+
+// timelineBarWidth is the width of the time axis in text output.
+const timelineBarWidth = 40
+
+// Timeline command flags.
+var (
+	relationshipTimelineFrom string
+	relationshipTimelineTo   string
+)
+
+// TimelineEvent is one point on an entity's relationship timeline.
+type TimelineEvent struct {
+	Time           time.Time        `json:"time" yaml:"time"`
+	Event          string           `json:"event" yaml:"event"` // first_seen, last_seen
+	RelationshipID string           `json:"relationship_id" yaml:"relationship_id"`
+	Type           RelationshipType `json:"type" yaml:"type"`
+	OtherID        string           `json:"other_id" yaml:"other_id"`
+	OtherName      string           `json:"other_name" yaml:"other_name"`
+	Confidence     float64          `json:"confidence" yaml:"confidence"`
+}
+
+// RelationshipTimeline is the structured output of 'relationship timeline'.
+type RelationshipTimeline struct {
+	EntityID      string          `json:"entity_id" yaml:"entity_id"`
+	EntityName    string          `json:"entity_name" yaml:"entity_name"`
+	From          *time.Time      `json:"from,omitempty" yaml:"from,omitempty"`
+	To            *time.Time      `json:"to,omitempty" yaml:"to,omitempty"`
+	Relationships []Relationship  `json:"-" yaml:"-"`
+	Events        []TimelineEvent `json:"events" yaml:"events"`
+	Count         int             `json:"count" yaml:"count"`
+}
+
+// newRelationshipTimelineCommand creates the 'relationship timeline' subcommand.
+func newRelationshipTimelineCommand(deps *RelationshipCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeline <entity-id>",
+		Short: "Show how an entity's relationships evolved over time",
+		Long: `Show when each of an entity's relationships was first and last seen,
+drawn on a shared time axis, so you can see how someone's connections grew.
+
+Each row is one relationship; the bar spans from first seen to last seen.
+Rows are ordered by when the relationship was first seen.
+
+Use --from and --to to window the timeline. Relationships that were not
+active in the window are left out and bars are clipped to it. Both accept
+dates ("2026-01-15"), RFC 3339 timestamps, durations ("720h") and "today"
+or "yesterday".
+
+JSON and YAML output is a chronological list of first_seen and last_seen
+events.
+
+Examples:
+  penf relationship timeline ent-person-123
+
+  # Connections made this year
+  penf relationship timeline ent-person-123 --from 2026-01-01
+
+  # Event list for further processing
+  penf relationship timeline ent-person-123 -o json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeEntityIDs(deps, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelationshipTimeline(cmd.Context(), deps, args[0], getRelInsecureFlag(cmd))
+		},
+	}
+
+	cmd.Flags().StringVar(&relationshipTimelineFrom, "from", "", "Only show activity at or after this time")
+	cmd.Flags().StringVar(&relationshipTimelineTo, "to", "", "Only show activity before this time")
+
+	return cmd
+}
+
+// runRelationshipTimeline executes the relationship timeline command.
+func runRelationshipTimeline(ctx context.Context, deps *RelationshipCommandDeps, entityID string, insecureFlag bool) error {
+	var from, to time.Time
+	var err error
+	if relationshipTimelineFrom != "" {
+		if from, err = parseTimeFilter(relationshipTimelineFrom); err != nil {
+			return fmt.Errorf("parsing --from value: %w", err)
+		}
+	}
+	if relationshipTimelineTo != "" {
+		if to, err = parseTimeFilter(relationshipTimelineTo); err != nil {
+			return fmt.Errorf("parsing --to value: %w", err)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return fmt.Errorf("--from must be before --to")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	// Override insecure if flag is set.
+	if insecureFlag {
+		cfg.Insecure = true
+	}
+
+	// Override tenant if specified.
+	if relationshipTenant != "" {
+		cfg.TenantID = relationshipTenant
+		cfg.TenantUUID = "" // flag overrides cached UUID
+	}
+
+	// Bare numeric IDs default to person entities, as in 'entity show'.
+	if numericID, err := ParseEntityID(entityID); err == nil && !strings.HasPrefix(entityID, "ent-") {
+		entityID = FormatEntityID(numericID, "person")
+	}
+
+	relClient, err := deps.relationshipClient(cfg)
+	if err != nil {
+		return fmt.Errorf("initializing relationship client: %w", err)
+	}
+	defer deps.Close()
+
+	ent, err := relClient.GetEntity(ctx, cfg.EffectiveTenantID(), entityID)
+	if err != nil {
+		return fmt.Errorf("getting entity: %w", err)
+	}
+
+	// Fetch every relationship involving the entity.
+	req := &client.ListRelationshipsRequest{
+		TenantID: cfg.EffectiveTenantID(),
+		EntityID: ent.ID,
+		PageSize: 100,
+	}
+	var rels []Relationship
+	for {
+		page, err := relClient.ListRelationshipsPage(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing relationships: %w", err)
+		}
+		for _, r := range page.Relationships {
+			rels = append(rels, clientRelToLocal(r))
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
+	}
+
+	timeline := buildRelationshipTimeline(clientEntityToLocal(ent), rels, from, to)
+
+	format := cfg.OutputFormat
+	if relationshipOutput != "" {
+		format = config.OutputFormat(relationshipOutput)
+	}
+
+	switch format {
+	case config.OutputFormatJSON:
+		return outputRelJSON(timeline)
+	case config.OutputFormatYAML:
+		return outputRelYAML(timeline)
+	default:
+		return outputRelationshipTimelineText(timeline)
+	}
+}
+
+// buildRelationshipTimeline keeps the relationships active within [from, to)
+// and turns them into chronological first/last seen events. Zero from or to
+// leaves that end of the window open.
+func buildRelationshipTimeline(entity Entity, rels []Relationship, from, to time.Time) RelationshipTimeline {
+	timeline := RelationshipTimeline{
+		EntityID:   entity.ID,
+		EntityName: entity.Name,
+		Events:     []TimelineEvent{},
+	}
+	if !from.IsZero() {
+		timeline.From = &from
+	}
+	if !to.IsZero() {
+		timeline.To = &to
+	}
+
+	inWindow := func(t time.Time) bool {
+		return !t.IsZero() && (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	}
+
+	for _, r := range rels {
+		if r.FirstSeen.IsZero() {
+			continue
+		}
+		last := r.LastSeen
+		if last.Before(r.FirstSeen) {
+			last = r.FirstSeen
+		}
+		// Skip relationships that ended before or started after the window.
+		if (!from.IsZero() && last.Before(from)) || (!to.IsZero() && !r.FirstSeen.Before(to)) {
+			continue
+		}
+		timeline.Relationships = append(timeline.Relationships, r)
+
+		otherID, otherName := r.TargetID, r.TargetName
+		if r.TargetID == entity.ID {
+			otherID, otherName = r.SourceID, r.SourceName
+		}
+		event := TimelineEvent{
+			RelationshipID: r.ID,
+			Type:           r.Type,
+			OtherID:        otherID,
+			OtherName:      otherName,
+			Confidence:     r.Confidence,
+		}
+
+		if inWindow(r.FirstSeen) {
+			event.Time, event.Event = r.FirstSeen, timelineEventFirstSeen
+			timeline.Events = append(timeline.Events, event)
+		}
+		if last.After(r.FirstSeen) && inWindow(last) {
+			event.Time, event.Event = last, timelineEventLastSeen
+			timeline.Events = append(timeline.Events, event)
+		}
+	}
+
+	sort.SliceStable(timeline.Relationships, func(i, j int) bool {
+		return timeline.Relationships[i].FirstSeen.Before(timeline.Relationships[j].FirstSeen)
+	})
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		return timeline.Events[i].Time.Before(timeline.Events[j].Time)
+	})
+	timeline.Count = len(timeline.Events)
+
+	return timeline
+}
+
+// outputRelationshipTimelineText draws each relationship as a bar on a shared
+// time axis, colored by confidence.
+func outputRelationshipTimelineText(timeline RelationshipTimeline) error {
+	fmt.Printf("Relationship timeline for \033[1m%s\033[0m (%s)\n\n", timeline.EntityName, timeline.EntityID)
+
+	if len(timeline.Relationships) == 0 {
+		fmt.Println("No relationships found in this time range.")
+		return nil
+	}
+
+	axisStart, axisEnd := timelineAxis(timeline)
+
+	fmt.Printf("  %-25s %-14s %s%*s\n", "", "",
+		axisStart.Format("2006-01-02"), timelineBarWidth-10, axisEnd.Format("2006-01-02"))
+	for _, r := range timeline.Relationships {
+		other := r.TargetName
+		if r.TargetID == timeline.EntityID {
+			other = r.SourceName
+		}
+		fmt.Printf("  %-25s %-14s %s%s\033[0m  %s → %s\n",
+			truncateString(other, 25),
+			r.Type,
+			getConfidenceColor(r.Confidence),
+			timelineBar(r.FirstSeen, r.LastSeen, axisStart, axisEnd, timelineBarWidth),
+			r.FirstSeen.Format("2006-01-02"), r.LastSeen.Format("2006-01-02"))
+	}
+
+	fmt.Printf("\n%d relationship(s)\n", len(timeline.Relationships))
+	return nil
+}
+
+// timelineAxis returns the range the bars are drawn against: the window if
+// given, otherwise the span of the relationships.
+func timelineAxis(timeline RelationshipTimeline) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, r := range timeline.Relationships {
+		if start.IsZero() || r.FirstSeen.Before(start) {
+			start = r.FirstSeen
+		}
+		if r.LastSeen.After(end) {
+			end = r.LastSeen
+		}
+	}
+	if end.Before(start) {
+		end = start
+	}
+	if timeline.From != nil {
+		start = *timeline.From
+	}
+	if timeline.To != nil {
+		end = *timeline.To
+	}
+	return start, end
+}
+
+// timelineBar renders [first, last] as a bar of width cells positioned
+// between axisStart and axisEnd. Spans outside the axis are clipped; every
+// span gets at least one cell.
+func timelineBar(first, last, axisStart, axisEnd time.Time, width int) string {
+	span := axisEnd.Sub(axisStart)
+	cell := func(t time.Time) int {
+		if span <= 0 {
+			return 0
+		}
+		c := int(float64(t.Sub(axisStart)) / float64(span) * float64(width))
+		return max(0, min(c, width-1))
+	}
+
+	startCell := cell(first)
+	endCell := max(cell(last), startCell)
+
+	return strings.Repeat("·", startCell) +
+		strings.Repeat("█", endCell-startCell+1) +
+		strings.Repeat("·", width-endCell-1)
+}
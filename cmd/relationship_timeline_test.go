@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func timelineTestRelationships() []Relationship {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	return []Relationship{
+		{ID: "rel-b", SourceID: "ent-1", TargetID: "ent-3", TargetName: "Bob", Type: RelationshipTypeColleague, FirstSeen: day(10), LastSeen: day(20)},
+		{ID: "rel-a", SourceID: "ent-2", SourceName: "Alice", TargetID: "ent-1", Type: RelationshipTypeReportsTo, FirstSeen: day(1), LastSeen: day(5)},
+		{ID: "rel-c", SourceID: "ent-1", TargetID: "ent-4", TargetName: "Carol", Type: RelationshipTypeColleague, FirstSeen: day(25), LastSeen: day(25)},
+	}
+}
+
+func TestBuildRelationshipTimeline(t *testing.T) {
+	timeline := buildRelationshipTimeline(Entity{ID: "ent-1", Name: "Jane"}, timelineTestRelationships(), time.Time{}, time.Time{})
+
+	if len(timeline.Relationships) != 3 || timeline.Relationships[0].ID != "rel-a" {
+		t.Fatalf("relationships not ordered by first seen: %+v", timeline.Relationships)
+	}
+
+	// rel-c was first and last seen at the same moment, so it has one event.
+	want := []struct{ id, event string }{
+		{"rel-a", timelineEventFirstSeen},
+		{"rel-a", timelineEventLastSeen},
+		{"rel-b", timelineEventFirstSeen},
+		{"rel-b", timelineEventLastSeen},
+		{"rel-c", timelineEventFirstSeen},
+	}
+	if timeline.Count != len(want) {
+		t.Fatalf("Count = %d, want %d: %+v", timeline.Count, len(want), timeline.Events)
+	}
+	for i, w := range want {
+		if e := timeline.Events[i]; e.RelationshipID != w.id || e.Event != w.event {
+			t.Errorf("event %d = %s %s, want %s %s", i, e.RelationshipID, e.Event, w.id, w.event)
+		}
+	}
+	if other := timeline.Events[0].OtherName; other != "Alice" {
+		t.Errorf("other side of rel-a = %q, want Alice", other)
+	}
+}
+
+func TestBuildRelationshipTimeline_Window(t *testing.T) {
+	from := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	timeline := buildRelationshipTimeline(Entity{ID: "ent-1"}, timelineTestRelationships(), from, to)
+
+	// Only rel-b overlaps the window, and only its first sighting falls in it.
+	if len(timeline.Relationships) != 1 || timeline.Relationships[0].ID != "rel-b" {
+		t.Fatalf("relationships = %+v, want only rel-b", timeline.Relationships)
+	}
+	if timeline.Count != 1 || timeline.Events[0].Event != timelineEventFirstSeen {
+		t.Errorf("events = %+v, want rel-b first_seen only", timeline.Events)
+	}
+	if timeline.From == nil || timeline.To == nil {
+		t.Error("window not recorded")
+	}
+}
+
+func TestTimelineBar(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * 24 * time.Hour)
+	at := func(d int) time.Time { return start.Add(time.Duration(d) * 24 * time.Hour) }
+
+	tests := []struct {
+		name        string
+		first, last time.Time
+		want        string
+	}{
+		{"middle", at(2), at(5), "··████····"},
+		{"point", at(3), at(3), "···█······"},
+		{"clipped", at(-5), at(20), "██████████"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timelineBar(tt.first, tt.last, start, end, 10); got != tt.want {
+				t.Errorf("timelineBar = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,17 +6,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gopkg.in/yaml.v3"
 
 	reviewv1 "github.com/otherjamesbrown/penf-cli/api/proto/review/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
+	"github.com/otherjamesbrown/penf-cli/contextpalace"
 )
 
 // ReviewPriority defines the priority levels for review items.
@@ -84,6 +85,24 @@ type ReviewSession struct {
 	Deferred      int                 `json:"deferred" yaml:"deferred"`
 }
 
+// ReviewBulkResult is the --output json result of a bulk accept or reject.
+type ReviewBulkResult struct {
+	Action    string       `json:"action"`
+	DryRun    bool         `json:"dry_run"`
+	Matched   int          `json:"matched"`
+	Succeeded int          `json:"succeeded"`
+	Items     []ReviewItem `json:"items,omitempty"`
+}
+
+// ReviewUndoResult is the --output json result of 'review undo'.
+type ReviewUndoResult struct {
+	ItemID         string `json:"item_id"`
+	Action         string `json:"action"`
+	PreviousStatus string `json:"previous_status"`
+	RestoredStatus string `json:"restored_status"`
+	CanUndoMore    bool   `json:"can_undo_more"`
+}
+
 // ReviewAction represents an action taken during a review session.
 type ReviewAction struct {
 	ID        string           `json:"id" yaml:"id"`
@@ -106,6 +125,38 @@ type ReviewAutoRule struct {
 	Action      string `json:"action" yaml:"action"`
 }
 
+// ReviewerStats summarizes one reviewer's decisions over a time range, as
+// recorded in the Context-Palace command log.
+type ReviewerStats struct {
+	Rank                       int     `json:"rank,omitempty" yaml:"rank,omitempty"`
+	ReviewerID                 string  `json:"reviewer_id" yaml:"reviewer_id"`
+	Decisions                  int     `json:"decisions" yaml:"decisions"`
+	Accepted                   int     `json:"accepted" yaml:"accepted"`
+	Rejected                   int     `json:"rejected" yaml:"rejected"`
+	Deferred                   int     `json:"deferred" yaml:"deferred"`
+	BulkRuns                   int     `json:"bulk_runs" yaml:"bulk_runs"`
+	AcceptRatio                float64 `json:"accept_ratio" yaml:"accept_ratio"`
+	AvgDecisionIntervalSeconds float64 `json:"avg_decision_interval_seconds" yaml:"avg_decision_interval_seconds"`
+}
+
+// reviewDecisionActions maps the logged review commands that decide an item
+// to the decision they record.
+var reviewDecisionActions = map[string]string{
+	"review accept": "accept",
+	"review reject": "reject",
+	"review defer":  "defer",
+}
+
+// reviewerIdleGap is the longest gap between two of a reviewer's decisions
+// still counted as time spent deciding; longer gaps are treated as breaks.
+const reviewerIdleGap = 15 * time.Minute
+
+// reviewerStatsPageSize is the command log page size used by reviewer stats.
+const reviewerStatsPageSize = 500
+
+// unattributedReviewer groups decisions logged without a Context-Palace agent.
+const unattributedReviewer = "(unattributed)"
+
 // ReviewQueueResponse contains the review queue and metadata.
 type ReviewQueueResponse struct {
 	Items      []ReviewItem `json:"items" yaml:"items"`
@@ -120,6 +171,8 @@ type ReviewCommandDeps struct {
 	OutputFormat config.OutputFormat
 	LoadConfig   func() (*config.CLIConfig, error)
 	InitClient   func(*config.CLIConfig) (*client.GRPCClient, error)
+	// GetEntity resolves the --reviewer entity for reviewer stats.
+	GetEntity func(context.Context, *config.CLIConfig, string) (*client.RelEntity, error)
 }
 
 // DefaultReviewDeps returns the default dependencies for production use.
@@ -127,13 +180,32 @@ func DefaultReviewDeps() *ReviewCommandDeps {
 	return &ReviewCommandDeps{
 		LoadConfig: config.LoadConfig,
 		InitClient: client.ConnectFromConfig,
+		GetEntity:  getReviewerEntity,
+	}
+}
+
+// getReviewerEntity looks up a reviewer entity through the relationship service.
+func getReviewerEntity(ctx context.Context, cfg *config.CLIConfig, entityID string) (*client.RelEntity, error) {
+	relClient, err := DefaultRelationshipDeps().InitRelClient(cfg)
+	if err != nil {
+		return nil, err
 	}
+	defer relClient.Close()
+	return relClient.GetEntity(ctx, cfg.EffectiveTenantID(), entityID)
 }
 
 // Review command flags.
 var (
 	reviewPriority        string
 	reviewCountOnly       bool
+	reviewSort            string
+	reviewReverse         bool
+	reviewBulkAll         bool
+	reviewBulkSource      string
+	reviewBulkType        string
+	reviewBulkConfirm     bool
+	reviewReviewer        string
+	reviewLeaderboard     bool
 	reviewReason          string
 	reviewUntil           string
 	reviewOutput          string
@@ -141,6 +213,7 @@ var (
 	reviewIncludeProcessed bool
 	reviewFrom            string
 	reviewTo              string
+	reviewAllTenants      bool
 )
 
 // NewReviewCommand creates the root review command with all subcommands.
@@ -223,7 +296,7 @@ Review workflows are documented in Context Palace knowledge shards.`,
 
 // newReviewStartCommand creates the 'review start' subcommand.
 func newReviewStartCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "start",
 		Short: "Start a new review session",
 		Long: `Start a new review session.
@@ -236,12 +309,12 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewStart(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 // newReviewPauseCommand creates the 'review pause' subcommand.
 func newReviewPauseCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "pause",
 		Short: "Pause the current review session",
 		Long: `Pause the current review session.
@@ -253,12 +326,12 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewPause(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 // newReviewResumeCommand creates the 'review resume' subcommand.
 func newReviewResumeCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "resume",
 		Short: "Resume a paused review session",
 		Long: `Resume a previously paused review session.
@@ -268,12 +341,12 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewResume(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 // newReviewEndCommand creates the 'review end' subcommand.
 func newReviewEndCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "end",
 		Short: "End the current review session",
 		Long: `End the current review session and display a summary.
@@ -285,7 +358,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewEnd(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 // newReviewQueueCommand creates the 'review queue' subcommand.
@@ -297,12 +370,25 @@ func newReviewQueueCommand(deps *ReviewCommandDeps) *cobra.Command {
 
 Use --priority to filter by priority level (high, medium, low).
 Use --count to show only the count of pending items.
+Use --sort to order items by priority, age, source, or type.
+Use --reverse to invert the sort order.
+Use --count --all-tenants to show the pending count for every tenant.
+
+Sort orders:
+  priority  Highest priority first (high, medium, low)
+  age       Oldest items first
+  source    Alphabetical by source
+  type      Alphabetical by content type
 
 Examples:
   penf review queue
   penf review queue --priority high
-  penf review queue --count`,
-		Aliases: []string{"q", "list"},
+  penf review queue --sort priority
+  penf review queue --sort age --reverse
+  penf review queue --count
+  penf review queue --count --all-tenants`,
+		Aliases:     []string{"q", "list"},
+		Annotations: map[string]string{ReadOnlyAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewQueue(cmd.Context(), deps)
 		},
@@ -310,25 +396,44 @@ Examples:
 
 	cmd.Flags().StringVarP(&reviewPriority, "priority", "p", "", "Filter by priority: high, medium, low")
 	cmd.Flags().BoolVarP(&reviewCountOnly, "count", "c", false, "Show count only")
-	cmd.Flags().StringVarP(&reviewOutput, "output", "o", "", "Output format: text, json, yaml")
+	cmd.Flags().StringVar(&reviewSort, "sort", "", "Sort by: priority, age, source, type")
+	cmd.Flags().BoolVar(&reviewReverse, "reverse", false, "Reverse the sort order")
+	cmd.Flags().StringVarP(&reviewOutput, "output", "o", "", "Output format: text, json, yaml, csv")
+	AddAllTenantsFlag(cmd, &reviewAllTenants)
 
 	return cmd
 }
 
 // newReviewAcceptCommand creates the 'review accept' subcommand.
 func newReviewAcceptCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "accept <id>",
 		Short: "Accept a review item",
 		Long: `Accept a review item, marking it as relevant and processed.
 
-Example:
-  penf review accept item-123`,
-		Args: cobra.ExactArgs(1),
+Use --all with at least one filter (--source, --priority, --type) to accept
+every pending item matching the filter. Bulk operations run in dry-run mode
+unless --confirm is given.
+
+Examples:
+  penf review accept item-123
+
+  # Preview, then accept everything from a trusted source
+  penf review accept --all --source gmail
+  penf review accept --all --source gmail --confirm`,
+		Args:              reviewBulkArgs,
+		ValidArgsFunction: completeReviewItemIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if reviewBulkAll {
+				return runReviewBulk(cmd.Context(), deps, "accept", "")
+			}
 			return runReviewAccept(cmd.Context(), deps, args[0])
 		},
 	}
+
+	addReviewBulkFlags(cmd)
+
+	return withEnvelope(cmd)
 }
 
 // newReviewRejectCommand creates the 'review reject' subcommand.
@@ -340,18 +445,51 @@ func newReviewRejectCommand(deps *ReviewCommandDeps) *cobra.Command {
 
 Use --reason to provide context for the rejection.
 
+Use --all with at least one filter (--source, --priority, --type) to reject
+every pending item matching the filter. Bulk operations run in dry-run mode
+unless --confirm is given.
+
 Examples:
   penf review reject item-123
-  penf review reject item-123 --reason "Duplicate content"`,
-		Args: cobra.ExactArgs(1),
+  penf review reject item-123 --reason "Duplicate content"
+
+  # Preview, then reject all low-priority items
+  penf review reject --all --priority low --reason "Low value"
+  penf review reject --all --priority low --reason "Low value" --confirm`,
+		Args:              reviewBulkArgs,
+		ValidArgsFunction: completeReviewItemIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if reviewBulkAll {
+				return runReviewBulk(cmd.Context(), deps, "reject", reviewReason)
+			}
 			return runReviewReject(cmd.Context(), deps, args[0], reviewReason)
 		},
 	}
 
 	cmd.Flags().StringVarP(&reviewReason, "reason", "r", "", "Reason for rejection")
+	addReviewBulkFlags(cmd)
 
-	return cmd
+	return withEnvelope(cmd)
+}
+
+// addReviewBulkFlags registers the bulk filter flags shared by accept and reject.
+func addReviewBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&reviewBulkAll, "all", false, "Act on all pending items matching the filters")
+	cmd.Flags().StringVar(&reviewBulkSource, "source", "", "Bulk filter by source")
+	cmd.Flags().StringVarP(&reviewPriority, "priority", "p", "", "Bulk filter by priority: high, medium, low")
+	cmd.Flags().StringVar(&reviewBulkType, "type", "", "Bulk filter by content type")
+	cmd.Flags().BoolVar(&reviewBulkConfirm, "confirm", false, "Confirm bulk operation (dry-run without it)")
+}
+
+// reviewBulkArgs requires an item ID, or no arguments when --all is set.
+func reviewBulkArgs(cmd *cobra.Command, args []string) error {
+	if reviewBulkAll {
+		if len(args) != 0 {
+			return fmt.Errorf("cannot combine an item ID with --all")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
 }
 
 // newReviewDeferCommand creates the 'review defer' subcommand.
@@ -367,7 +505,8 @@ Examples:
   penf review defer item-123
   penf review defer item-123 --until "tomorrow"
   penf review defer item-123 --until "2024-12-31"`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeReviewItemIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewDefer(cmd.Context(), deps, args[0], reviewUntil)
 		},
@@ -375,7 +514,7 @@ Examples:
 
 	cmd.Flags().StringVarP(&reviewUntil, "until", "u", "", "Defer until date (YYYY-MM-DD or relative: tomorrow, nextweek)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newReviewShowCommand creates the 'review show' subcommand.
@@ -387,7 +526,8 @@ func newReviewShowCommand(deps *ReviewCommandDeps) *cobra.Command {
 
 Example:
   penf review show item-123`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeReviewItemIDs(deps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewShow(cmd.Context(), deps, args[0])
 		},
@@ -400,7 +540,7 @@ Example:
 
 // newReviewUndoCommand creates the 'review undo' subcommand.
 func newReviewUndoCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "undo [item-id]",
 		Short: "Undo the last review action on an item",
 		Long: `Undo the last review action on a specific item.
@@ -417,12 +557,12 @@ Example:
 			}
 			return runReviewUndo(cmd.Context(), deps, itemID)
 		},
-	}
+	})
 }
 
 // newReviewRedoCommand creates the 'review redo' subcommand.
 func newReviewRedoCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "redo",
 		Short: "Redo the last undone action",
 		Long: `Redo the last undone review action.
@@ -434,7 +574,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewRedo(cmd.Context(), deps)
 		},
-	}
+	})
 }
 
 // newReviewHistoryCommand creates the 'review history' subcommand.
@@ -470,9 +610,11 @@ Subcommands:
   status   Show current automation rules and their status
   enable   Enable an automation rule
   disable  Disable an automation rule
+  test     Preview what a rule would do to the pending queue
 
 Examples:
   penf review auto status
+  penf review auto test --rule-file auto-archive-spam.yaml
   penf review auto enable auto-archive-spam
   penf review auto disable auto-accept-known`,
 	}
@@ -480,6 +622,7 @@ Examples:
 	cmd.AddCommand(newReviewAutoStatusCommand(deps))
 	cmd.AddCommand(newReviewAutoEnableCommand(deps))
 	cmd.AddCommand(newReviewAutoDisableCommand(deps))
+	cmd.AddCommand(newReviewAutoTestCommand(deps))
 
 	return cmd
 }
@@ -505,7 +648,7 @@ Example:
 
 // newReviewAutoEnableCommand creates the 'review auto enable' subcommand.
 func newReviewAutoEnableCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "enable <rule>",
 		Short: "Enable an automation rule",
 		Long: `Enable an automation rule by name or ID.
@@ -516,12 +659,12 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewAutoEnable(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 // newReviewAutoDisableCommand creates the 'review auto disable' subcommand.
 func newReviewAutoDisableCommand(deps *ReviewCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "disable <rule>",
 		Short: "Disable an automation rule",
 		Long: `Disable an automation rule by name or ID.
@@ -532,7 +675,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReviewAutoDisable(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 // runReviewStart executes the review start command.
@@ -542,6 +685,7 @@ func runReviewStart(ctx context.Context, deps *ReviewCommandDeps) error {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -558,15 +702,15 @@ func runReviewStart(ctx context.Context, deps *ReviewCommandDeps) error {
 
 	session := protoSessionToLocal(resp.Session)
 
-	fmt.Println("Review session started.")
-	fmt.Printf("  Session ID: %s\n", session.ID)
-	fmt.Printf("  Started at: %s\n", session.StartedAt.Format(time.RFC3339))
+	fmt.Fprintln(w, "Review session started.")
+	fmt.Fprintf(w, "  Session ID: %s\n", session.ID)
+	fmt.Fprintf(w, "  Started at: %s\n", session.StartedAt.Format(time.RFC3339))
 	if resp.PreviousSessionEnded {
-		fmt.Println("  (Previous session was automatically ended)")
+		fmt.Fprintln(w, "  (Previous session was automatically ended)")
 	}
-	fmt.Println("\nUse 'penf review queue' to see pending items.")
+	fmt.Fprintln(w, "\nUse 'penf review queue' to see pending items.")
 
-	return nil
+	return writeResult(session, "Review session started.")
 }
 
 // runReviewPause executes the review pause command.
@@ -576,6 +720,7 @@ func runReviewPause(ctx context.Context, deps *ReviewCommandDeps) error {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -592,12 +737,12 @@ func runReviewPause(ctx context.Context, deps *ReviewCommandDeps) error {
 
 	session := protoSessionToLocal(resp.Session)
 
-	fmt.Println("Review session paused.")
-	fmt.Printf("  Session ID: %s\n", session.ID)
-	fmt.Printf("  Items reviewed: %d\n", session.TotalReviewed)
-	fmt.Println("\nUse 'penf review resume' to continue.")
+	fmt.Fprintln(w, "Review session paused.")
+	fmt.Fprintf(w, "  Session ID: %s\n", session.ID)
+	fmt.Fprintf(w, "  Items reviewed: %d\n", session.TotalReviewed)
+	fmt.Fprintln(w, "\nUse 'penf review resume' to continue.")
 
-	return nil
+	return writeResult(session, "Review session paused.")
 }
 
 // runReviewResume executes the review resume command.
@@ -607,6 +752,7 @@ func runReviewResume(ctx context.Context, deps *ReviewCommandDeps) error {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -623,12 +769,12 @@ func runReviewResume(ctx context.Context, deps *ReviewCommandDeps) error {
 
 	session := protoSessionToLocal(resp.Session)
 
-	fmt.Println("Review session resumed.")
-	fmt.Printf("  Session ID: %s\n", session.ID)
-	fmt.Printf("  Items reviewed so far: %d\n", session.TotalReviewed)
-	fmt.Println("\nUse 'penf review queue' to see pending items.")
+	fmt.Fprintln(w, "Review session resumed.")
+	fmt.Fprintf(w, "  Session ID: %s\n", session.ID)
+	fmt.Fprintf(w, "  Items reviewed so far: %d\n", session.TotalReviewed)
+	fmt.Fprintln(w, "\nUse 'penf review queue' to see pending items.")
 
-	return nil
+	return writeResult(session, "Review session resumed.")
 }
 
 // runReviewEnd executes the review end command.
@@ -638,6 +784,7 @@ func runReviewEnd(ctx context.Context, deps *ReviewCommandDeps) error {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -656,19 +803,19 @@ func runReviewEnd(ctx context.Context, deps *ReviewCommandDeps) error {
 
 	duration := time.Duration(resp.Session.ActiveDurationSeconds) * time.Second
 
-	fmt.Println("Review session ended.")
-	fmt.Println()
-	fmt.Println("Session Summary:")
-	fmt.Printf("  Session ID:     %s\n", session.ID)
-	fmt.Printf("  Duration:       %s\n", formatReviewDuration(duration))
-	fmt.Printf("  Total reviewed: %d\n", session.TotalReviewed)
-	fmt.Println()
-	fmt.Println("Decisions:")
-	fmt.Printf("  Accepted: \033[32m%d\033[0m\n", session.Accepted)
-	fmt.Printf("  Rejected: \033[31m%d\033[0m\n", session.Rejected)
-	fmt.Printf("  Deferred: \033[33m%d\033[0m\n", session.Deferred)
+	fmt.Fprintln(w, "Review session ended.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Session Summary:")
+	fmt.Fprintf(w, "  Session ID:     %s\n", session.ID)
+	fmt.Fprintf(w, "  Duration:       %s\n", formatReviewDuration(duration))
+	fmt.Fprintf(w, "  Total reviewed: %d\n", session.TotalReviewed)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Decisions:")
+	fmt.Fprintf(w, "  Accepted: \033[32m%d\033[0m\n", session.Accepted)
+	fmt.Fprintf(w, "  Rejected: \033[31m%d\033[0m\n", session.Rejected)
+	fmt.Fprintf(w, "  Deferred: \033[33m%d\033[0m\n", session.Deferred)
 
-	return nil
+	return writeResult(session, "Review session ended.")
 }
 
 // runReviewQueue executes the review queue command.
@@ -687,15 +834,24 @@ func runReviewQueue(ctx context.Context, deps *ReviewCommandDeps) error {
 		}
 	}
 
+	// Validate sort key if provided.
+	if reviewSort != "" && !ValidateReviewSortKey(reviewSort) {
+		return fmt.Errorf("invalid sort key: %s (must be priority, age, source, or type)", reviewSort)
+	}
+
 	// Get output format.
 	outputFormat := cfg.OutputFormat
 	if reviewOutput != "" {
 		outputFormat = config.OutputFormat(reviewOutput)
-		if !outputFormat.IsValid() {
-			return fmt.Errorf("invalid output format: %s (must be text, json, or yaml)", reviewOutput)
+		if !outputFormat.IsValid() && outputFormat != config.OutputFormatCSV {
+			return fmt.Errorf("invalid output format: %s (must be text, json, yaml, or csv)", reviewOutput)
 		}
 	}
 
+	if reviewAllTenants && !reviewCountOnly {
+		return fmt.Errorf("--all-tenants requires --count")
+	}
+
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
 		return err
@@ -703,6 +859,36 @@ func runReviewQueue(ctx context.Context, deps *ReviewCommandDeps) error {
 	defer grpcClient.Close()
 
 	client := reviewv1.NewReviewServiceClient(grpcClient.GetConnection())
+
+	if reviewAllTenants {
+		tenants, err := ListAllTenants(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		rollup := FanOutTenants(ctx, tenants, []string{"pending"}, func(ctx context.Context, tenantID string) (map[string]int64, error) {
+			req := &reviewv1.ListReviewItemsRequest{
+				TenantId: &tenantID,
+				Statuses: []reviewv1.ReviewStatus{reviewv1.ReviewStatus_REVIEW_STATUS_PENDING},
+				PageSize: 1,
+			}
+			if reviewPriority != "" {
+				req.Priorities = []reviewv1.Priority{reviewPriorityToProto(ReviewPriority(reviewPriority))}
+			}
+			rpcCtx, rpcCancel := context.WithTimeout(ctx, 30*time.Second)
+			defer rpcCancel()
+			resp, err := client.ListReviewItems(rpcCtx, req)
+			if err != nil {
+				return nil, err
+			}
+			count := int64(len(resp.Items))
+			if resp.TotalCount != nil {
+				count = int64(*resp.TotalCount)
+			}
+			return map[string]int64{"pending": count}, nil
+		})
+		return OutputTenantRollup(os.Stdout, outputFormat, rollup)
+	}
+
 	tenantID := getTenantID()
 
 	req := &reviewv1.ListReviewItemsRequest{
@@ -713,16 +899,7 @@ func runReviewQueue(ctx context.Context, deps *ReviewCommandDeps) error {
 
 	// Add priority filter if specified
 	if reviewPriority != "" {
-		var protoPriority reviewv1.Priority
-		switch ReviewPriority(reviewPriority) {
-		case ReviewPriorityHigh:
-			protoPriority = reviewv1.Priority_PRIORITY_HIGH
-		case ReviewPriorityMedium:
-			protoPriority = reviewv1.Priority_PRIORITY_MEDIUM
-		case ReviewPriorityLow:
-			protoPriority = reviewv1.Priority_PRIORITY_LOW
-		}
-		req.Priorities = []reviewv1.Priority{protoPriority}
+		req.Priorities = []reviewv1.Priority{reviewPriorityToProto(ReviewPriority(reviewPriority))}
 	}
 
 	rpcCtx, rpcCancel := context.WithTimeout(ctx, 30*time.Second)
@@ -738,6 +915,10 @@ func runReviewQueue(ctx context.Context, deps *ReviewCommandDeps) error {
 		items[i] = protoItemToLocal(item)
 	}
 
+	if reviewSort != "" || reviewReverse {
+		items = sortReviewItems(items, reviewSort, reviewReverse)
+	}
+
 	totalCount := len(items)
 	if resp.TotalCount != nil {
 		totalCount = int(*resp.TotalCount)
@@ -763,6 +944,7 @@ func runReviewAccept(ctx context.Context, deps *ReviewCommandDeps, itemID string
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -787,10 +969,10 @@ func runReviewAccept(ctx context.Context, deps *ReviewCommandDeps, itemID string
 
 	item := protoItemToLocal(resp.Item)
 
-	fmt.Printf("Item accepted: %s\n", itemID)
-	fmt.Printf("  Title: %s\n", item.Title)
+	fmt.Fprintf(w, "Item accepted: %s\n", itemID)
+	fmt.Fprintf(w, "  Title: %s\n", item.Title)
 
-	return nil
+	return writeResult(item, "Item accepted: "+itemID)
 }
 
 // runReviewReject executes the review reject command.
@@ -800,6 +982,7 @@ func runReviewReject(ctx context.Context, deps *ReviewCommandDeps, itemID string
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
@@ -824,13 +1007,142 @@ func runReviewReject(ctx context.Context, deps *ReviewCommandDeps, itemID string
 
 	item := protoItemToLocal(resp.Item)
 
-	fmt.Printf("Item rejected: %s\n", itemID)
-	fmt.Printf("  Title: %s\n", item.Title)
+	fmt.Fprintf(w, "Item rejected: %s\n", itemID)
+	fmt.Fprintf(w, "  Title: %s\n", item.Title)
 	if reason != "" {
-		fmt.Printf("  Reason: %s\n", reason)
+		fmt.Fprintf(w, "  Reason: %s\n", reason)
 	}
 
-	return nil
+	return writeResult(item, "Item rejected: "+itemID)
+}
+
+// runReviewBulk accepts or rejects every pending item matching the bulk filters.
+// Without --confirm it only lists the items that would be actioned.
+func runReviewBulk(ctx context.Context, deps *ReviewCommandDeps, action string, reason string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+	w := resultOut()
+
+	// Guard against actioning the entire unfiltered queue.
+	if reviewBulkSource == "" && reviewPriority == "" && reviewBulkType == "" {
+		return fmt.Errorf("--all requires at least one filter: --source, --priority, or --type")
+	}
+	if reviewPriority != "" && !ValidateReviewPriority(reviewPriority) {
+		return fmt.Errorf("invalid priority: %s (must be high, medium, or low)", reviewPriority)
+	}
+
+	grpcClient, err := deps.InitClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close()
+
+	client := reviewv1.NewReviewServiceClient(grpcClient.GetConnection())
+	tenantID := getTenantID()
+
+	req := &reviewv1.ListReviewItemsRequest{
+		TenantId: &tenantID,
+		Statuses: []reviewv1.ReviewStatus{reviewv1.ReviewStatus_REVIEW_STATUS_PENDING},
+		PageSize: 500,
+	}
+	if reviewBulkSource != "" {
+		req.Sources = []string{reviewBulkSource}
+	}
+	if reviewBulkType != "" {
+		req.ContentTypes = []string{reviewBulkType}
+	}
+	if reviewPriority != "" {
+		req.Priorities = []reviewv1.Priority{reviewPriorityToProto(ReviewPriority(reviewPriority))}
+	}
+
+	// Collect every matching item before acting, so actions don't shift the pages.
+	var items []ReviewItem
+	for {
+		resp, err := client.ListReviewItems(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing review items: %w", err)
+		}
+		for _, item := range resp.Items {
+			items = append(items, protoItemToLocal(item))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintln(w, "No pending items match the filters.")
+		return writeResult(ReviewBulkResult{Action: action, DryRun: !reviewBulkConfirm}, "No pending items match the filters.")
+	}
+
+	if !reviewBulkConfirm {
+		fmt.Fprintf(w, "Dry run: %d items would be %s (use --confirm to apply):\n\n", len(items), reviewActionPastTense(action))
+		for _, item := range items {
+			fmt.Fprintf(w, "  %s%-8s\033[0m  %-10s  %-35s  %s\n",
+				getReviewPriorityColor(item.Priority),
+				item.Priority,
+				truncateString(item.ID, 10),
+				truncateString(item.Title, 35),
+				item.Source)
+		}
+		return writeResult(ReviewBulkResult{Action: action, DryRun: true, Matched: len(items), Items: items},
+			fmt.Sprintf("%d items would be %s", len(items), reviewActionPastTense(action)))
+	}
+
+	// On a terminal, a progress bar replaces the per-item lines; failures are
+	// still printed.
+	bar := newProgressBar(progressOut(config.OutputFormat(rootOutputFormat)), len(items), reviewActionPastTense(action))
+	bar.every = 0
+
+	succeeded, failed := 0, 0
+	for i, item := range items {
+		var actionErr error
+		switch action {
+		case "accept":
+			_, actionErr = client.ApproveItem(ctx, &reviewv1.ApproveItemRequest{
+				Id:   item.ID,
+				Note: "Bulk accepted via CLI",
+			})
+		case "reject":
+			_, actionErr = client.RejectItem(ctx, &reviewv1.RejectItemRequest{
+				Id:     item.ID,
+				Reason: reason,
+			})
+		}
+
+		if actionErr != nil {
+			failed++
+			bar.Printf(w, "[%d/%d] \033[31mfailed\033[0m %s: %v\n", i+1, len(items), item.ID, actionErr)
+		} else {
+			succeeded++
+			if !bar.interactive {
+				fmt.Fprintf(w, "[%d/%d] %s %s\n", i+1, len(items), reviewActionPastTense(action), item.ID)
+			}
+		}
+		bar.Set(i+1, fmt.Sprintf("(%d failed)", failed))
+	}
+	bar.Finish()
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Bulk %s complete: %d %s, %d failed\n", action, succeeded, reviewActionPastTense(action), failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d items failed", failed, len(items))
+	}
+
+	return writeResult(ReviewBulkResult{Action: action, Matched: len(items), Succeeded: succeeded, Items: items},
+		fmt.Sprintf("Bulk %s complete: %d %s", action, succeeded, reviewActionPastTense(action)))
+}
+
+// reviewActionPastTense returns the past tense of a bulk review action.
+func reviewActionPastTense(action string) string {
+	if action == "accept" {
+		return "accepted"
+	}
+	return "rejected"
 }
 
 // runReviewDefer executes the review defer command.
@@ -840,6 +1152,7 @@ func runReviewDefer(ctx context.Context, deps *ReviewCommandDeps, itemID string,
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	// Parse the until date if provided.
 	var deferredTo *time.Time
@@ -876,13 +1189,13 @@ func runReviewDefer(ctx context.Context, deps *ReviewCommandDeps, itemID string,
 
 	item := protoItemToLocal(resp.Item)
 
-	fmt.Printf("Item deferred: %s\n", itemID)
-	fmt.Printf("  Title: %s\n", item.Title)
+	fmt.Fprintf(w, "Item deferred: %s\n", itemID)
+	fmt.Fprintf(w, "  Title: %s\n", item.Title)
 	if deferredTo != nil {
-		fmt.Printf("  Deferred until: %s\n", deferredTo.Format("2006-01-02"))
+		fmt.Fprintf(w, "  Deferred until: %s\n", deferredTo.Format("2006-01-02"))
 	}
 
-	return nil
+	return writeResult(item, "Item deferred: "+itemID)
 }
 
 // runReviewShow executes the review show command.
@@ -929,11 +1242,12 @@ func runReviewUndo(ctx context.Context, deps *ReviewCommandDeps, itemID string)
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	if itemID == "" {
-		fmt.Println("Please specify an item ID to undo.")
-		fmt.Println("Usage: penf review undo <item-id>")
-		return nil
+		fmt.Fprintln(w, "Please specify an item ID to undo.")
+		fmt.Fprintln(w, "Usage: penf review undo <item-id>")
+		return writeOutcome(false, nil, "Please specify an item ID to undo.")
 	}
 
 	grpcClient, err := deps.InitClient(cfg)
@@ -952,20 +1266,26 @@ func runReviewUndo(ctx context.Context, deps *ReviewCommandDeps, itemID string)
 	}
 
 	if resp.UndoneAction == nil {
-		fmt.Println("Nothing to undo for this item.")
-		return nil
+		fmt.Fprintln(w, "Nothing to undo for this item.")
+		return writeOutcome(false, nil, "Nothing to undo for this item.")
 	}
 
-	fmt.Printf("Undone: %s on item %s\n", resp.UndoneAction.ActionType.String(), itemID)
-	fmt.Printf("  Status reverted from %s to %s\n",
+	fmt.Fprintf(w, "Undone: %s on item %s\n", resp.UndoneAction.ActionType.String(), itemID)
+	fmt.Fprintf(w, "  Status reverted from %s to %s\n",
 		resp.UndoneAction.NewStatus.String(),
 		resp.UndoneAction.PreviousStatus.String())
 
 	if resp.CanUndoMore {
-		fmt.Println("  (More actions available to undo)")
+		fmt.Fprintln(w, "  (More actions available to undo)")
 	}
 
-	return nil
+	return writeResult(ReviewUndoResult{
+		ItemID:         itemID,
+		Action:         resp.UndoneAction.ActionType.String(),
+		PreviousStatus: resp.UndoneAction.NewStatus.String(),
+		RestoredStatus: resp.UndoneAction.PreviousStatus.String(),
+		CanUndoMore:    resp.CanUndoMore,
+	}, "Undone: "+resp.UndoneAction.ActionType.String()+" on item "+itemID)
 }
 
 // runReviewRedo executes the review redo command.
@@ -975,14 +1295,15 @@ func runReviewRedo(ctx context.Context, deps *ReviewCommandDeps) error {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	// TODO: No redo RPC exists in the review service yet.
 	// The backend would need to track undone actions and provide a RedoAction RPC.
 	// For now, inform the user that redo is not yet implemented.
-	fmt.Println("Redo functionality is not yet implemented in the review service.")
-	fmt.Println("To re-apply an action, use the original command (accept/reject/defer) again.")
+	fmt.Fprintln(w, "Redo functionality is not yet implemented in the review service.")
+	fmt.Fprintln(w, "To re-apply an action, use the original command (accept/reject/defer) again.")
 
-	return nil
+	return writeOutcome(false, nil, "Redo functionality is not yet implemented in the review service.")
 }
 
 // runReviewHistory executes the review history command.
@@ -1065,14 +1386,15 @@ func runReviewAutoEnable(ctx context.Context, deps *ReviewCommandDeps, ruleName
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	// TODO: No automation rules backend exists yet.
 	// When implemented, this should call an EnableAutoRule RPC.
 	_ = ruleName // Suppress unused variable warning
-	fmt.Println("Automation rules are not yet implemented in the review service.")
-	fmt.Println("This feature will be available in a future release.")
+	fmt.Fprintln(w, "Automation rules are not yet implemented in the review service.")
+	fmt.Fprintln(w, "This feature will be available in a future release.")
 
-	return nil
+	return writeOutcome(false, nil, "Automation rules are not yet implemented in the review service.")
 }
 
 // runReviewAutoDisable executes the review auto disable command.
@@ -1082,14 +1404,15 @@ func runReviewAutoDisable(ctx context.Context, deps *ReviewCommandDeps, ruleName
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	// TODO: No automation rules backend exists yet.
 	// When implemented, this should call a DisableAutoRule RPC.
 	_ = ruleName // Suppress unused variable warning
-	fmt.Println("Automation rules are not yet implemented in the review service.")
-	fmt.Println("This feature will be available in a future release.")
+	fmt.Fprintln(w, "Automation rules are not yet implemented in the review service.")
+	fmt.Fprintln(w, "This feature will be available in a future release.")
 
-	return nil
+	return writeOutcome(false, nil, "Automation rules are not yet implemented in the review service.")
 }
 
 // protoSessionToLocal converts a proto ReviewSession to the local ReviewSession type.
@@ -1186,7 +1509,7 @@ func outputReviewCount(format config.OutputFormat, response ReviewQueueResponse)
 			"count":      response.TotalCount,
 			"fetched_at": response.FetchedAt,
 		}
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(output)
 	default:
 		fmt.Printf("Pending review items: %d\n", response.TotalCount)
@@ -1202,8 +1525,10 @@ func outputReviewQueue(format config.OutputFormat, response ReviewQueueResponse)
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
+	case config.OutputFormatCSV:
+		return outputReviewQueueCSV(os.Stdout, response.Items)
 	default:
 		return outputReviewQueueText(response)
 	}
@@ -1246,7 +1571,7 @@ func outputReviewItem(format config.OutputFormat, item *ReviewItem) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(item)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(item)
 	default:
 		return outputReviewItemText(item)
@@ -1291,7 +1616,7 @@ func outputReviewHistory(format config.OutputFormat, actions []ReviewAction) err
 		enc.SetIndent("", "  ")
 		return enc.Encode(actions)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(actions)
 	default:
 		return outputReviewHistoryText(actions)
@@ -1337,7 +1662,7 @@ func outputReviewAutoRules(format config.OutputFormat, rules []ReviewAutoRule) e
 		enc.SetIndent("", "  ")
 		return enc.Encode(rules)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(rules)
 	default:
 		return outputReviewAutoRulesText(rules)
@@ -1404,6 +1729,76 @@ func getReviewStatusColor(status ReviewItemStatus) string {
 	}
 }
 
+// sortReviewItems returns a copy of items ordered by the given sort key.
+// An empty key keeps server order, so --reverse alone simply flips it.
+func sortReviewItems(items []ReviewItem, key string, reverse bool) []ReviewItem {
+	// Create a copy to avoid modifying the input.
+	sorted := make([]ReviewItem, len(items))
+	copy(sorted, items)
+
+	var less func(a, b ReviewItem) bool
+	switch key {
+	case "priority":
+		less = func(a, b ReviewItem) bool {
+			return reviewPriorityRank(a.Priority) < reviewPriorityRank(b.Priority)
+		}
+	case "age":
+		less = func(a, b ReviewItem) bool {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	case "source":
+		less = func(a, b ReviewItem) bool {
+			return a.Source < b.Source
+		}
+	case "type":
+		less = func(a, b ReviewItem) bool {
+			return a.ContentType < b.ContentType
+		}
+	}
+
+	if less != nil {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return less(sorted[i], sorted[j])
+		})
+	}
+
+	if reverse {
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+
+	return sorted
+}
+
+// reviewPriorityToProto converts a local priority to the proto enum.
+func reviewPriorityToProto(priority ReviewPriority) reviewv1.Priority {
+	switch priority {
+	case ReviewPriorityHigh:
+		return reviewv1.Priority_PRIORITY_HIGH
+	case ReviewPriorityMedium:
+		return reviewv1.Priority_PRIORITY_MEDIUM
+	case ReviewPriorityLow:
+		return reviewv1.Priority_PRIORITY_LOW
+	default:
+		return reviewv1.Priority_PRIORITY_UNSPECIFIED
+	}
+}
+
+// reviewPriorityRank returns a numeric rank for sorting (lower is more urgent).
+func reviewPriorityRank(priority ReviewPriority) int {
+	switch priority {
+	case ReviewPriorityHigh:
+		return 1
+	case ReviewPriorityMedium:
+		return 2
+	case ReviewPriorityLow:
+		return 3
+	default:
+		return 4
+	}
+}
+
 // parseDeferDate parses a defer date string.
 func parseDeferDate(dateStr string) (time.Time, error) {
 	lower := strings.ToLower(dateStr)
@@ -1471,6 +1866,16 @@ func ValidateReviewPriority(priority string) bool {
 	}
 }
 
+// ValidateReviewSortKey checks if a sort key is valid for the review queue.
+func ValidateReviewSortKey(key string) bool {
+	switch key {
+	case "priority", "age", "source", "type":
+		return true
+	default:
+		return false
+	}
+}
+
 // ValidateReviewItemStatus validates a review item status string.
 func ValidateReviewItemStatus(status string) bool {
 	switch ReviewItemStatus(status) {
@@ -1541,17 +1946,40 @@ func newReviewStatsCommand(deps *ReviewCommandDeps) *cobra.Command {
 Provides insights into review queue health, processing velocity, and
 breakdowns by priority, content type, source, and category.
 
+Use --reviewer with an entity ID to scope metrics to one reviewer's
+decisions, or --leaderboard to rank all reviewers by throughput. Reviewer
+metrics are built from the Context-Palace command log: every successful
+'review accept', 'review reject' and 'review defer' counts as a decision by
+the agent that ran it. A decision belongs to the --reviewer entity when its
+agent is the entity's ID, name or one of its aliases. --from/--to bound the
+day the decision was made, both days included. Bulk runs
+(--all) are counted separately because the log does not record how many
+items they decided. The average decision interval is the mean gap between a
+reviewer's consecutive decisions, ignoring gaps over 15 minutes as breaks.
+Reviewer metrics require Context-Palace to be configured; without it
+--reviewer and --leaderboard fail rather than report empty stats.
+
 Examples:
   penf review stats
   penf review stats --from 2026-01-01 --to 2026-01-31
+  penf review stats --reviewer ent-person-42
+  penf review stats --leaderboard --from 2026-01-01
   penf review stats -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if reviewReviewer != "" && reviewLeaderboard {
+				return fmt.Errorf("--reviewer and --leaderboard cannot be combined")
+			}
+			if reviewReviewer != "" || reviewLeaderboard {
+				return runReviewReviewerStats(cmd.Context(), deps, cmd.Root())
+			}
 			return runReviewStats(cmd.Context(), deps)
 		},
 	}
 
 	cmd.Flags().StringVarP(&reviewFrom, "from", "f", "", "Start date in YYYY-MM-DD format")
 	cmd.Flags().StringVarP(&reviewTo, "to", "t", "", "End date in YYYY-MM-DD format")
+	cmd.Flags().StringVar(&reviewReviewer, "reviewer", "", "Scope metrics to one reviewer (entity ID)")
+	cmd.Flags().BoolVar(&reviewLeaderboard, "leaderboard", false, "Rank all reviewers by throughput")
 	cmd.Flags().StringVarP(&reviewOutput, "output", "o", "", "Output format: text, json, yaml")
 
 	return cmd
@@ -1689,6 +2117,180 @@ func runReviewStats(ctx context.Context, deps *ReviewCommandDeps) error {
 	return outputReviewStats(outputFormat, resp)
 }
 
+// runReviewReviewerStats executes review stats scoped by reviewer or as a
+// leaderboard, built from the review decisions in the Context-Palace command log.
+func runReviewReviewerStats(ctx context.Context, deps *ReviewCommandDeps, root *cobra.Command) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	// Get output format.
+	outputFormat := cfg.OutputFormat
+	if reviewOutput != "" {
+		outputFormat = config.OutputFormat(reviewOutput)
+		if !outputFormat.IsValid() {
+			return fmt.Errorf("invalid output format: %s (must be text, json, or yaml)", reviewOutput)
+		}
+	}
+
+	if !cfg.ContextPalace.IsConfigured() {
+		return fmt.Errorf("reviewer stats are read from the Context-Palace command log, which is not configured; set context_palace in the config (see 'penf doctor')")
+	}
+
+	query := contextpalace.CommandQuery{
+		Word:  "review",
+		Limit: reviewerStatsPageSize,
+	}
+
+	// Date filters bound when the decision was made.
+	if reviewFrom != "" {
+		t, err := time.Parse("2006-01-02", reviewFrom)
+		if err != nil {
+			return fmt.Errorf("invalid --from date: %w", err)
+		}
+		query.Since = t
+	}
+
+	// Until is exclusive, so stop at the start of the day after --to.
+	if reviewTo != "" {
+		t, err := time.Parse("2006-01-02", reviewTo)
+		if err != nil {
+			return fmt.Errorf("invalid --to date: %w", err)
+		}
+		query.Until = t.AddDate(0, 0, 1)
+	}
+
+	var reviewer *client.RelEntity
+	if reviewReviewer != "" {
+		reviewer, err = deps.GetEntity(ctx, cfg, reviewReviewer)
+		if err != nil {
+			return fmt.Errorf("looking up reviewer %s: %w", reviewReviewer, err)
+		}
+	}
+
+	cp, err := contextpalace.NewClient(cfg.ContextPalace)
+	if err != nil {
+		return fmt.Errorf("connecting to Context-Palace: %w", err)
+	}
+	defer cp.Close()
+
+	events, _, err := fetchAuditEvents(ctx, cp.QueryCommands, query, true, root)
+	if err != nil {
+		return fmt.Errorf("listing review decisions: %w", err)
+	}
+
+	if reviewer != nil {
+		events = reviewerEvents(events, reviewer)
+	}
+	stats := computeReviewerStats(events)
+
+	if reviewer != nil {
+		for _, s := range stats {
+			if s.ReviewerID == reviewer.ID {
+				return outputReviewerStats(outputFormat, &s)
+			}
+		}
+		return outputReviewerStats(outputFormat, &ReviewerStats{ReviewerID: reviewer.ID})
+	}
+
+	return outputReviewerLeaderboard(outputFormat, stats)
+}
+
+// reviewerEvents keeps the events run by an agent that names the reviewer
+// entity (its ID, name, canonical name or an alias) and attributes them to
+// the entity ID.
+func reviewerEvents(events []AuditEvent, reviewer *client.RelEntity) []AuditEvent {
+	names := append([]string{reviewer.ID, reviewer.Name, reviewer.CanonicalName}, reviewer.Aliases...)
+	var kept []AuditEvent
+	for _, event := range events {
+		for _, name := range names {
+			if name != "" && strings.EqualFold(event.Actor, name) {
+				event.Actor = reviewer.ID
+				kept = append(kept, event)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// computeReviewerStats aggregates the review decisions among events, which
+// must be in chronological order, per reviewer, ranked by throughput (most
+// decisions first). Failed commands and other review subcommands are ignored.
+func computeReviewerStats(events []AuditEvent) []ReviewerStats {
+	byReviewer := make(map[string]*ReviewerStats)
+	lastDecision := make(map[string]time.Time)
+	totalInterval := make(map[string]time.Duration)
+	intervals := make(map[string]int)
+
+	for _, event := range events {
+		decision, ok := reviewDecisionActions[event.Action]
+		if !ok || !event.Success {
+			continue
+		}
+
+		reviewer := event.Actor
+		if reviewer == "" {
+			reviewer = unattributedReviewer
+		}
+
+		s, ok := byReviewer[reviewer]
+		if !ok {
+			s = &ReviewerStats{ReviewerID: reviewer}
+			byReviewer[reviewer] = s
+		}
+
+		// A decision without an item ID is a bulk --all run.
+		if event.Target == "" {
+			s.BulkRuns++
+			continue
+		}
+
+		switch decision {
+		case "accept":
+			s.Accepted++
+		case "reject":
+			s.Rejected++
+		case "defer":
+			s.Deferred++
+		}
+		s.Decisions++
+
+		if last, ok := lastDecision[reviewer]; ok {
+			if gap := event.Time.Sub(last); gap > 0 && gap <= reviewerIdleGap {
+				totalInterval[reviewer] += gap
+				intervals[reviewer]++
+			}
+		}
+		lastDecision[reviewer] = event.Time
+	}
+
+	stats := make([]ReviewerStats, 0, len(byReviewer))
+	for reviewer, s := range byReviewer {
+		if decided := s.Accepted + s.Rejected; decided > 0 {
+			s.AcceptRatio = float64(s.Accepted) / float64(decided)
+		}
+		if intervals[reviewer] > 0 {
+			s.AvgDecisionIntervalSeconds = (totalInterval[reviewer] / time.Duration(intervals[reviewer])).Seconds()
+		}
+		stats = append(stats, *s)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Decisions != stats[j].Decisions {
+			return stats[i].Decisions > stats[j].Decisions
+		}
+		return stats[i].ReviewerID < stats[j].ReviewerID
+	})
+	for i := range stats {
+		stats[i].Rank = i + 1
+	}
+
+	return stats
+}
+
 // outputDailyReview outputs the daily review.
 func outputDailyReview(format config.OutputFormat, review *reviewv1.DailyReview) error {
 	switch format {
@@ -1697,7 +2299,7 @@ func outputDailyReview(format config.OutputFormat, review *reviewv1.DailyReview)
 		enc.SetIndent("", "  ")
 		return enc.Encode(review)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(review)
 	default:
 		return outputDailyReviewText(review)
@@ -1758,7 +2360,7 @@ func outputCurrentSession(format config.OutputFormat, session *reviewv1.ReviewSe
 		enc.SetIndent("", "  ")
 		return enc.Encode(session)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(session)
 	default:
 		return outputCurrentSessionText(session)
@@ -1805,13 +2407,85 @@ func outputReviewStats(format config.OutputFormat, stats *reviewv1.GetReviewStat
 		enc.SetIndent("", "  ")
 		return enc.Encode(stats)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(stats)
 	default:
 		return outputReviewStatsText(stats)
 	}
 }
 
+// outputReviewerStats outputs metrics for a single reviewer.
+func outputReviewerStats(format config.OutputFormat, stats *ReviewerStats) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(stats)
+	default:
+		if stats.Decisions == 0 && stats.BulkRuns == 0 {
+			fmt.Printf("No decisions recorded for reviewer %s.\n", stats.ReviewerID)
+			return nil
+		}
+
+		fmt.Printf("Reviewer Statistics: %s\n\n", stats.ReviewerID)
+		fmt.Printf("  Decisions:     %d\n", stats.Decisions)
+		fmt.Printf("  Accepted:      \033[32m%d\033[0m\n", stats.Accepted)
+		fmt.Printf("  Rejected:      \033[31m%d\033[0m\n", stats.Rejected)
+		fmt.Printf("  Deferred:      \033[33m%d\033[0m\n", stats.Deferred)
+		fmt.Printf("  Accept Ratio:  %.0f%%\n", stats.AcceptRatio*100)
+		if stats.BulkRuns > 0 {
+			fmt.Printf("  Bulk Runs:     %d\n", stats.BulkRuns)
+		}
+		if stats.AvgDecisionIntervalSeconds > 0 {
+			fmt.Printf("  Avg Interval:  %s\n", formatReviewDuration(time.Duration(stats.AvgDecisionIntervalSeconds)*time.Second))
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+// outputReviewerLeaderboard outputs all reviewers ranked by throughput.
+func outputReviewerLeaderboard(format config.OutputFormat, stats []ReviewerStats) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(stats)
+	default:
+		if len(stats) == 0 {
+			fmt.Println("No review decisions recorded.")
+			return nil
+		}
+
+		fmt.Println("Reviewer Leaderboard:")
+		fmt.Println()
+		fmt.Println("  RANK  REVIEWER                        DECISIONS  ACCEPTED  REJECTED  ACCEPT%  AVG INTERVAL")
+		fmt.Println("  ----  --------                        ---------  --------  --------  -------  ------------")
+		for _, s := range stats {
+			avgTime := "-"
+			if s.AvgDecisionIntervalSeconds > 0 {
+				avgTime = formatReviewDuration(time.Duration(s.AvgDecisionIntervalSeconds) * time.Second)
+			}
+			fmt.Printf("  %-4d  %-30s  %-9d  %-8d  %-8d  %6.0f%%  %s\n",
+				s.Rank,
+				truncateString(s.ReviewerID, 30),
+				s.Decisions,
+				s.Accepted,
+				s.Rejected,
+				s.AcceptRatio*100,
+				avgTime)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
 // outputReviewStatsText outputs review statistics in human-readable format.
 func outputReviewStatsText(stats *reviewv1.GetReviewStatsResponse) error {
 	if stats == nil {
@@ -1835,32 +2509,32 @@ func outputReviewStatsText(stats *reviewv1.GetReviewStatsResponse) error {
 
 	if len(stats.ByPriority) > 0 {
 		fmt.Println("By Priority:")
-		for priority, count := range stats.ByPriority {
-			fmt.Printf("  %-8s: %d\n", priority, count)
+		for _, priority := range orderedKeys(stats.ByPriority, priorityOrder) {
+			fmt.Printf("  %-8s: %d\n", priority, stats.ByPriority[priority])
 		}
 		fmt.Println()
 	}
 
 	if len(stats.ByContentType) > 0 {
 		fmt.Println("By Content Type:")
-		for contentType, count := range stats.ByContentType {
-			fmt.Printf("  %-12s: %d\n", contentType, count)
+		for _, contentType := range orderedKeys(stats.ByContentType, nil) {
+			fmt.Printf("  %-12s: %d\n", contentType, stats.ByContentType[contentType])
 		}
 		fmt.Println()
 	}
 
 	if len(stats.BySource) > 0 {
 		fmt.Println("By Source:")
-		for source, count := range stats.BySource {
-			fmt.Printf("  %-12s: %d\n", source, count)
+		for _, source := range orderedKeys(stats.BySource, nil) {
+			fmt.Printf("  %-12s: %d\n", source, stats.BySource[source])
 		}
 		fmt.Println()
 	}
 
 	if len(stats.ByCategory) > 0 {
 		fmt.Println("By Category:")
-		for category, count := range stats.ByCategory {
-			fmt.Printf("  %-20s: %d\n", category, count)
+		for _, category := range orderedKeys(stats.ByCategory, nil) {
+			fmt.Printf("  %-20s: %d\n", category, stats.ByCategory[category])
 		}
 		fmt.Println()
 	}
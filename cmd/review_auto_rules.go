@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	reviewv1 "github.com/otherjamesbrown/penf-cli/api/proto/review/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Review auto test flags.
+var (
+	reviewAutoRuleFile string
+	reviewAutoSample   int
+)
+
+// autoRuleCondition is a single "field op value" clause of a rule's criteria.
+type autoRuleCondition struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// autoRuleSymbolOps lists the symbolic criteria operators. Two-character
+// operators come first so "!=" is not read as "=".
+var autoRuleSymbolOps = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// ReviewAutoTestResult reports what a rule would do to the pending queue.
+type ReviewAutoTestResult struct {
+	Rule      ReviewAutoRule `json:"rule" yaml:"rule"`
+	Evaluated int            `json:"evaluated" yaml:"evaluated"`
+	Matched   int            `json:"matched" yaml:"matched"`
+	Unmatched int            `json:"unmatched" yaml:"unmatched"`
+	Sample    []ReviewItem   `json:"sample" yaml:"sample"`
+}
+
+// newReviewAutoTestCommand creates the 'review auto test' subcommand.
+func newReviewAutoTestCommand(deps *ReviewCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [rule]",
+		Short: "Preview what an automation rule would do",
+		Long: `Evaluate an automation rule against the current pending queue without
+changing anything.
+
+Reports how many pending items the rule's criteria match, and so would be
+accepted, rejected, or deferred, along with a sample of those items.
+
+Criteria are clauses joined with "and". Each clause is "field op value":
+  fields:    source, content_type (or type), priority, title, age_hours
+  operators: =, !=, in (comma-separated list), contains, >, <, >=, <=
+
+Rule file (YAML or JSON):
+  name: auto-defer-newsletters
+  action: defer
+  criteria: content_type = newsletter and source in gmail,outlook
+
+Examples:
+  penf review auto test --rule-file rules/defer-newsletters.yaml
+  penf review auto test --rule-file rule.yaml --sample 20 -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ruleName := ""
+			if len(args) > 0 {
+				ruleName = args[0]
+			}
+			return runReviewAutoTest(cmd.Context(), deps, ruleName, reviewAutoRuleFile, reviewAutoSample)
+		},
+	}
+
+	cmd.Flags().StringVar(&reviewAutoRuleFile, "rule-file", "", "YAML or JSON file containing the rule to test")
+	cmd.Flags().IntVar(&reviewAutoSample, "sample", 10, "Number of matching items to show")
+	cmd.Flags().StringVarP(&reviewOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runReviewAutoTest executes the review auto test command.
+func runReviewAutoTest(ctx context.Context, deps *ReviewCommandDeps, ruleName string, ruleFile string, sample int) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	outputFormat := cfg.OutputFormat
+	if reviewOutput != "" {
+		outputFormat = config.OutputFormat(reviewOutput)
+		if !outputFormat.IsValid() {
+			return fmt.Errorf("invalid output format: %s (must be text, json, or yaml)", reviewOutput)
+		}
+	}
+
+	if ruleFile == "" {
+		// TODO: No automation rules backend exists yet. When it does, look up
+		// ruleName with a GetAutoRule RPC.
+		if ruleName == "" {
+			return fmt.Errorf("specify a rule name or --rule-file")
+		}
+		return fmt.Errorf("automation rules are not yet stored in the review service; test %q with --rule-file", ruleName)
+	}
+
+	rule, err := loadReviewAutoRule(ruleFile)
+	if err != nil {
+		return err
+	}
+	conditions, err := parseAutoRuleCriteria(rule.Criteria)
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := deps.InitClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close()
+
+	client := reviewv1.NewReviewServiceClient(grpcClient.GetConnection())
+	tenantID := getTenantID()
+
+	req := &reviewv1.ListReviewItemsRequest{
+		TenantId: &tenantID,
+		Statuses: []reviewv1.ReviewStatus{reviewv1.ReviewStatus_REVIEW_STATUS_PENDING},
+		PageSize: 500,
+	}
+
+	var items []ReviewItem
+	for {
+		resp, err := client.ListReviewItems(ctx, req)
+		if err != nil {
+			return fmt.Errorf("listing review items: %w", err)
+		}
+		for _, item := range resp.Items {
+			items = append(items, protoItemToLocal(item))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	result := evaluateReviewAutoRule(*rule, conditions, items, time.Now(), sample)
+	return outputReviewAutoTest(outputFormat, result)
+}
+
+// loadReviewAutoRule reads and validates a rule from a YAML or JSON file.
+func loadReviewAutoRule(path string) (*ReviewAutoRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rule file: %w", err)
+	}
+
+	var rule ReviewAutoRule
+	// YAML is a superset of JSON, so one decoder handles both.
+	if err := yaml.Unmarshal(data, &rule); err != nil {
+		return nil, fmt.Errorf("parsing rule file: %w", err)
+	}
+
+	if rule.Name == "" {
+		rule.Name = rule.ID
+	}
+	switch rule.Action {
+	case "accept", "reject", "defer":
+	default:
+		return nil, fmt.Errorf("invalid rule action: %q (must be accept, reject, or defer)", rule.Action)
+	}
+	if strings.TrimSpace(rule.Criteria) == "" {
+		return nil, fmt.Errorf("rule %q has no criteria", rule.Name)
+	}
+
+	return &rule, nil
+}
+
+// parseAutoRuleCriteria parses criteria such as
+// "content_type = newsletter and source in gmail,outlook" into conditions.
+func parseAutoRuleCriteria(criteria string) ([]autoRuleCondition, error) {
+	var conditions []autoRuleCondition
+	for _, clause := range splitAutoRuleClauses(criteria) {
+		cond, err := parseAutoRuleClause(clause)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, cond)
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("empty rule criteria")
+	}
+	return conditions, nil
+}
+
+// splitAutoRuleClauses splits criteria on the "and" keyword, case-insensitively.
+func splitAutoRuleClauses(criteria string) []string {
+	var clauses, current []string
+	for _, word := range strings.Fields(criteria) {
+		if strings.EqualFold(word, "and") {
+			clauses = append(clauses, strings.Join(current, " "))
+			current = nil
+			continue
+		}
+		current = append(current, word)
+	}
+	clauses = append(clauses, strings.Join(current, " "))
+	return clauses
+}
+
+// parseAutoRuleClause parses a single "field op value" clause.
+func parseAutoRuleClause(clause string) (autoRuleCondition, error) {
+	fields := strings.Fields(clause)
+	if len(fields) >= 3 {
+		for _, op := range []string{"in", "contains"} {
+			if strings.EqualFold(fields[1], op) {
+				return newAutoRuleCondition(fields[0], op, strings.Join(fields[2:], " "))
+			}
+		}
+	}
+
+	// Use the leftmost operator so values may themselves contain operator characters.
+	bestIdx, bestOp := -1, ""
+	for _, op := range autoRuleSymbolOps {
+		if idx := strings.Index(clause, op); idx > 0 && (bestIdx < 0 || idx < bestIdx) {
+			bestIdx, bestOp = idx, op
+		}
+	}
+	if bestIdx > 0 {
+		return newAutoRuleCondition(clause[:bestIdx], bestOp, clause[bestIdx+len(bestOp):])
+	}
+	return autoRuleCondition{}, fmt.Errorf("invalid criteria clause: %q (expected \"field op value\")", clause)
+}
+
+// newAutoRuleCondition validates a clause's field and operator combination.
+func newAutoRuleCondition(field, op, value string) (autoRuleCondition, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	value = strings.TrimSpace(value)
+	if field == "type" {
+		field = "content_type"
+	}
+	if value == "" {
+		return autoRuleCondition{}, fmt.Errorf("criteria clause for %q has no value", field)
+	}
+
+	switch field {
+	case "source", "content_type", "priority", "title":
+		switch op {
+		case "=", "!=", "in", "contains":
+		default:
+			return autoRuleCondition{}, fmt.Errorf("operator %q is not supported for %s", op, field)
+		}
+	case "age_hours":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return autoRuleCondition{}, fmt.Errorf("age_hours must be a number, got %q", value)
+		}
+		if op == "in" || op == "contains" {
+			return autoRuleCondition{}, fmt.Errorf("operator %q is not supported for age_hours", op)
+		}
+	default:
+		return autoRuleCondition{}, fmt.Errorf("unsupported criteria field: %q (supported: source, content_type, priority, title, age_hours)", field)
+	}
+
+	return autoRuleCondition{Field: field, Op: op, Value: value}, nil
+}
+
+// matches reports whether a review item satisfies the condition.
+func (c autoRuleCondition) matches(item ReviewItem, now time.Time) bool {
+	if c.Field == "age_hours" {
+		want, _ := strconv.ParseFloat(c.Value, 64)
+		age := now.Sub(item.CreatedAt).Hours()
+		switch c.Op {
+		case "=":
+			return age == want
+		case "!=":
+			return age != want
+		case ">":
+			return age > want
+		case "<":
+			return age < want
+		case ">=":
+			return age >= want
+		case "<=":
+			return age <= want
+		}
+		return false
+	}
+
+	var actual string
+	switch c.Field {
+	case "source":
+		actual = item.Source
+	case "content_type":
+		actual = item.ContentType
+	case "priority":
+		actual = string(item.Priority)
+	case "title":
+		actual = item.Title
+	}
+
+	switch c.Op {
+	case "=":
+		return strings.EqualFold(actual, c.Value)
+	case "!=":
+		return !strings.EqualFold(actual, c.Value)
+	case "contains":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(c.Value))
+	case "in":
+		for _, v := range strings.Split(c.Value, ",") {
+			if strings.EqualFold(actual, strings.TrimSpace(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluateReviewAutoRule applies a rule's conditions to items without side effects.
+func evaluateReviewAutoRule(rule ReviewAutoRule, conditions []autoRuleCondition, items []ReviewItem, now time.Time, sample int) ReviewAutoTestResult {
+	result := ReviewAutoTestResult{Rule: rule, Evaluated: len(items), Sample: []ReviewItem{}}
+
+	for _, item := range items {
+		matched := true
+		for _, cond := range conditions {
+			if !cond.matches(item, now) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			result.Unmatched++
+			continue
+		}
+		result.Matched++
+		if len(result.Sample) < sample {
+			result.Sample = append(result.Sample, item)
+		}
+	}
+
+	return result
+}
+
+// outputReviewAutoTest outputs the result of testing a rule.
+func outputReviewAutoTest(format config.OutputFormat, result ReviewAutoTestResult) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(os.Stdout)
+		return enc.Encode(result)
+	default:
+		return outputReviewAutoTestText(result)
+	}
+}
+
+// outputReviewAutoTestText outputs the result of testing a rule in human-readable format.
+func outputReviewAutoTestText(result ReviewAutoTestResult) error {
+	fmt.Printf("Rule: %s (dry run - nothing was changed)\n", result.Rule.Name)
+	fmt.Printf("  Criteria: %s\n", result.Rule.Criteria)
+	fmt.Printf("  Action:   %s\n\n", result.Rule.Action)
+
+	fmt.Printf("Evaluated %d pending items:\n", result.Evaluated)
+	fmt.Printf("  Would %-8s %d\n", result.Rule.Action+":", result.Matched)
+	fmt.Printf("  Unaffected: %d\n", result.Unmatched)
+
+	if len(result.Sample) == 0 {
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("\nSample (%d of %d):\n\n", len(result.Sample), result.Matched)
+	for _, item := range result.Sample {
+		fmt.Printf("  %s%-8s\033[0m  %-10s  %-35s  %s\n",
+			getReviewPriorityColor(item.Priority),
+			item.Priority,
+			truncateString(item.ID, 10),
+			truncateString(item.Title, 35),
+			item.Source)
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAutoRuleCriteria(t *testing.T) {
+	conds, err := parseAutoRuleCriteria("type = newsletter AND source in gmail, outlook and age_hours >= 24")
+	if err != nil {
+		t.Fatalf("parseAutoRuleCriteria: %v", err)
+	}
+	want := []autoRuleCondition{
+		{Field: "content_type", Op: "=", Value: "newsletter"},
+		{Field: "source", Op: "in", Value: "gmail, outlook"},
+		{Field: "age_hours", Op: ">=", Value: "24"},
+	}
+	if len(conds) != len(want) {
+		t.Fatalf("got %d conditions, want %d: %+v", len(conds), len(want), conds)
+	}
+	for i := range want {
+		if conds[i] != want[i] {
+			t.Errorf("condition %d = %+v, want %+v", i, conds[i], want[i])
+		}
+	}
+}
+
+func TestParseAutoRuleCriteria_Invalid(t *testing.T) {
+	for _, criteria := range []string{
+		"spam_score > 0.8",
+		"source",
+		"priority > high",
+		"age_hours > soon",
+		"source = gmail and",
+	} {
+		if _, err := parseAutoRuleCriteria(criteria); err == nil {
+			t.Errorf("expected error for criteria %q", criteria)
+		}
+	}
+}
+
+func TestEvaluateReviewAutoRule(t *testing.T) {
+	now := time.Now()
+	items := []ReviewItem{
+		{ID: "1", Title: "Weekly Newsletter", ContentType: "email", Source: "gmail", Priority: ReviewPriorityLow, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "2", Title: "Newsletter digest", ContentType: "email", Source: "gmail", Priority: ReviewPriorityLow, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "3", Title: "Board update", ContentType: "email", Source: "gmail", Priority: ReviewPriorityHigh, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "4", Title: "Newsletter", ContentType: "email", Source: "outlook", Priority: ReviewPriorityLow, CreatedAt: now.Add(-30 * time.Hour)},
+	}
+	rule := ReviewAutoRule{Name: "defer-old-newsletters", Action: "defer", Criteria: "title contains newsletter and age_hours > 24"}
+
+	conds, err := parseAutoRuleCriteria(rule.Criteria)
+	if err != nil {
+		t.Fatalf("parseAutoRuleCriteria: %v", err)
+	}
+	result := evaluateReviewAutoRule(rule, conds, items, now, 1)
+
+	if result.Evaluated != 4 || result.Matched != 2 || result.Unmatched != 2 {
+		t.Errorf("result = evaluated %d, matched %d, unmatched %d; want 4/2/2", result.Evaluated, result.Matched, result.Unmatched)
+	}
+	if len(result.Sample) != 1 || result.Sample[0].ID != "1" {
+		t.Errorf("expected sample limited to first match, got %+v", result.Sample)
+	}
+}
+
+func TestLoadReviewAutoRule(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "rule.yaml")
+	if err := os.WriteFile(valid, []byte("id: rule-9\naction: reject\ncriteria: source = spam-trap\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rule, err := loadReviewAutoRule(valid)
+	if err != nil {
+		t.Fatalf("loadReviewAutoRule: %v", err)
+	}
+	if rule.Name != "rule-9" || rule.Action != "reject" {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+
+	invalid := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(invalid, []byte(`{"name": "x", "action": "archive", "criteria": "source = a"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadReviewAutoRule(invalid); err == nil {
+		t.Error("expected error for unsupported action")
+	}
+}
+
+func TestRunReviewAutoTest_RequiresRuleFile(t *testing.T) {
+	deps := createReviewTestDeps(mockConfig())
+	if err := runReviewAutoTest(context.Background(), deps, "auto-archive-spam", "", 10); err == nil {
+		t.Error("expected error when testing a named rule without --rule-file")
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	questionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/questions/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -269,7 +268,7 @@ Examples:
 
 // connectToQuestionsGateway creates a gRPC connection to the gateway service.
 func connectToQuestionsGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -292,9 +291,11 @@ func connectToQuestionsGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error)
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -735,8 +736,8 @@ func outputProtoQuestionsStatsText(stats *questionsv1.QueueStats) error {
 
 	if len(stats.ByType) > 0 {
 		fmt.Println("  By Type:")
-		for qtype, count := range stats.ByType {
-			fmt.Printf("    %-12s %d\n", qtype, count)
+		for _, qtype := range orderedKeys(stats.ByType, nil) {
+			fmt.Printf("    %-12s %d\n", qtype, stats.ByType[qtype])
 		}
 		fmt.Println()
 	}
@@ -830,7 +831,7 @@ func outputQuestionsJSON(v interface{}) error {
 }
 
 func outputQuestionsYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
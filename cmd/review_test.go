@@ -581,6 +581,164 @@ func TestValidateReviewItemStatus(t *testing.T) {
 	}
 }
 
+func TestValidateReviewSortKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"priority", true},
+		{"age", true},
+		{"source", true},
+		{"type", true},
+		{"title", false},
+		{"", false},
+		{"AGE", false}, // Case sensitive.
+	}
+
+	for _, tt := range tests {
+		result := ValidateReviewSortKey(tt.input)
+		if result != tt.expected {
+			t.Errorf("ValidateReviewSortKey(%q) = %v, want %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestSortReviewItems(t *testing.T) {
+	now := time.Now()
+	items := []ReviewItem{
+		{ID: "a", Priority: ReviewPriorityLow, Source: "slack", ContentType: "message", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "b", Priority: ReviewPriorityHigh, Source: "gmail", ContentType: "email", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "c", Priority: ReviewPriorityMedium, Source: "calendar", ContentType: "meeting", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		key      string
+		reverse  bool
+		expected []string
+	}{
+		{"priority", false, []string{"b", "c", "a"}},
+		{"priority", true, []string{"a", "c", "b"}},
+		{"age", false, []string{"b", "c", "a"}},
+		{"age", true, []string{"a", "c", "b"}},
+		{"source", false, []string{"c", "b", "a"}},
+		{"type", false, []string{"b", "c", "a"}},
+		{"", true, []string{"c", "b", "a"}},
+	}
+
+	for _, tt := range tests {
+		sorted := sortReviewItems(items, tt.key, tt.reverse)
+		var ids []string
+		for _, item := range sorted {
+			ids = append(ids, item.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("sortReviewItems(%q, reverse=%v) = %v, want %v", tt.key, tt.reverse, ids, tt.expected)
+		}
+	}
+
+	// Input must not be modified.
+	if items[0].ID != "a" || items[1].ID != "b" || items[2].ID != "c" {
+		t.Error("sortReviewItems should not modify its input")
+	}
+}
+
+func TestRunReviewBulk_RequiresFilter(t *testing.T) {
+	cfg := mockConfig()
+	deps := createReviewTestDeps(cfg)
+
+	reviewBulkSource = ""
+	reviewBulkType = ""
+	reviewPriority = ""
+
+	err := runReviewBulk(context.Background(), deps, "accept", "")
+	if err == nil {
+		t.Fatal("expected error when --all is used without filters")
+	}
+	if !strings.Contains(err.Error(), "at least one filter") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReviewBulkArgs(t *testing.T) {
+	cmd := newReviewAcceptCommand(createReviewTestDeps(mockConfig()))
+	defer func() { reviewBulkAll = false }()
+
+	reviewBulkAll = false
+	if err := reviewBulkArgs(cmd, nil); err == nil {
+		t.Error("expected error when no item ID is given without --all")
+	}
+	if err := reviewBulkArgs(cmd, []string{"123"}); err != nil {
+		t.Errorf("unexpected error for single item ID: %v", err)
+	}
+
+	reviewBulkAll = true
+	if err := reviewBulkArgs(cmd, nil); err != nil {
+		t.Errorf("unexpected error for --all without ID: %v", err)
+	}
+	if err := reviewBulkArgs(cmd, []string{"123"}); err == nil {
+		t.Error("expected error when combining an item ID with --all")
+	}
+}
+
+func TestComputeReviewerStats(t *testing.T) {
+	base := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	decided := func(reviewer, action, target string, at time.Duration) AuditEvent {
+		return AuditEvent{
+			Time:    base.Add(at),
+			Actor:   reviewer,
+			Action:  action,
+			Target:  target,
+			Success: true,
+		}
+	}
+
+	failed := decided("bob", "review accept", "item-9", 3*time.Minute)
+	failed.Success = false
+
+	events := []AuditEvent{
+		decided("alice", "review accept", "item-1", 0),
+		decided("alice", "review accept", "item-2", 2*time.Minute),
+		decided("bob", "review reject", "item-3", 3*time.Minute),
+		failed,
+		decided("alice", "review reject", "item-4", 6*time.Minute),
+		decided("alice", "review show", "item-5", 7*time.Minute),
+		decided("alice", "review accept", "", 8*time.Minute),
+		// After a break: counted as a decision, not as an interval.
+		decided("alice", "review defer", "item-6", 2*time.Hour),
+		decided("", "review defer", "item-7", 3*time.Hour),
+	}
+
+	stats := computeReviewerStats(events)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 reviewers, got %d: %+v", len(stats), stats)
+	}
+
+	alice := stats[0]
+	if alice.ReviewerID != "alice" || alice.Rank != 1 {
+		t.Errorf("expected alice ranked first, got %+v", alice)
+	}
+	if alice.Decisions != 4 || alice.Accepted != 2 || alice.Rejected != 1 || alice.Deferred != 1 {
+		t.Errorf("unexpected alice counts: %+v", alice)
+	}
+	if alice.BulkRuns != 1 {
+		t.Errorf("alice bulk runs = %d, want 1", alice.BulkRuns)
+	}
+	if alice.AcceptRatio < 0.66 || alice.AcceptRatio > 0.67 {
+		t.Errorf("alice accept ratio = %v, want ~0.667", alice.AcceptRatio)
+	}
+	if alice.AvgDecisionIntervalSeconds != 180 {
+		t.Errorf("alice avg decision interval = %v, want 180", alice.AvgDecisionIntervalSeconds)
+	}
+
+	// Ties on throughput are broken alphabetically.
+	if stats[1].ReviewerID != unattributedReviewer || stats[2].ReviewerID != "bob" {
+		t.Errorf("unexpected tie ordering: %s, %s", stats[1].ReviewerID, stats[2].ReviewerID)
+	}
+	if stats[2].Decisions != 1 {
+		t.Errorf("failed commands must not count: bob = %+v", stats[2])
+	}
+}
+
 func TestParseDeferDate_RelativeDates(t *testing.T) {
 	// Test tomorrow.
 	tomorrow, err := parseDeferDate("tomorrow")
@@ -1615,3 +1773,33 @@ func getMockActionHistory() []ReviewAction {
 		},
 	}
 }
+
+func TestReviewerEvents(t *testing.T) {
+	reviewer := &client.RelEntity{ID: "ent-person-42", Name: "Alice Smith", Aliases: []string{"alice"}}
+	events := []AuditEvent{
+		{Actor: "alice", Action: "review accept", Target: "item-1", Success: true},
+		{Actor: "Alice Smith", Action: "review reject", Target: "item-2", Success: true},
+		{Actor: "ent-person-42", Action: "review defer", Target: "item-3", Success: true},
+		{Actor: "bob", Action: "review accept", Target: "item-4", Success: true},
+	}
+
+	stats := computeReviewerStats(reviewerEvents(events, reviewer))
+	if len(stats) != 1 {
+		t.Fatalf("expected only the reviewer's decisions, got %+v", stats)
+	}
+	if stats[0].ReviewerID != "ent-person-42" || stats[0].Decisions != 3 {
+		t.Errorf("reviewer stats = %+v, want 3 decisions for ent-person-42", stats[0])
+	}
+}
+
+func TestRunReviewReviewerStats_RequiresContextPalace(t *testing.T) {
+	old := reviewReviewer
+	t.Cleanup(func() { reviewReviewer = old })
+	reviewReviewer = "ent-person-42"
+
+	deps := &ReviewCommandDeps{LoadConfig: func() (*config.CLIConfig, error) { return mockConfig(), nil }}
+	err := runReviewReviewerStats(context.Background(), deps, nil)
+	if err == nil || !strings.Contains(err.Error(), "Context-Palace") {
+		t.Errorf("expected a Context-Palace configuration error, got %v", err)
+	}
+}
@@ -200,7 +200,7 @@ func runRuleShow(ctx context.Context, deps *PipelineCommandDeps, name string) er
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("getting automation rule: %w", err)
 	}
@@ -416,7 +416,7 @@ func newRuleUpdateCmd(deps *PipelineCommandDeps) *cobra.Command {
 	cmd.Flags().BoolVar(&enable, "enable", false, "Enable the rule")
 	cmd.Flags().BoolVar(&disable, "disable", false, "Disable the rule")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runRuleUpdate(ctx context.Context, deps *PipelineCommandDeps, name string,
@@ -432,6 +432,7 @@ func runRuleUpdate(ctx context.Context, deps *PipelineCommandDeps, name string,
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -494,38 +495,48 @@ func runRuleUpdate(ctx context.Context, deps *PipelineCommandDeps, name string,
 	resp, err := client.UpdateAutomationRule(ctx, req)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("updating automation rule: %w", err)
 	}
 
 	r := resp.Rule
-	fmt.Printf("Updated rule: %s (enabled: %t)\n", r.Name, r.Enabled)
-	return nil
+	fmt.Fprintf(w, "Updated rule: %s (enabled: %t)\n", r.Name, r.Enabled)
+	return writeResult(r, "Updated rule: "+r.Name)
+}
+
+// RuleActionResult is the --output json result of the rule commands that
+// act on a rule by name.
+type RuleActionResult struct {
+	Rule          string `json:"rule"`
+	Action        string `json:"action"`
+	DryRun        bool   `json:"dry_run,omitempty"`
+	WorkflowID    string `json:"workflow_id,omitempty"`
+	DryRunSummary string `json:"dry_run_summary,omitempty"`
 }
 
 // ==================== enable / disable ====================
 
 func newRuleEnableCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "enable <name>",
 		Short: "Enable an automation rule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRuleSetEnabled(ctx(cmd), deps, args[0], true)
 		},
-	}
+	})
 }
 
 func newRuleDisableCmd(deps *PipelineCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "disable <name>",
 		Short: "Disable an automation rule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRuleSetEnabled(ctx(cmd), deps, args[0], false)
 		},
-	}
+	})
 }
 
 func runRuleSetEnabled(ctx context.Context, deps *PipelineCommandDeps, name string, enabled bool) error {
@@ -534,6 +545,7 @@ func runRuleSetEnabled(ctx context.Context, deps *PipelineCommandDeps, name stri
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -561,17 +573,17 @@ func runRuleSetEnabled(ctx context.Context, deps *PipelineCommandDeps, name stri
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("updating automation rule: %w", err)
 	}
 
-	state := "enabled"
+	action, state := "enable", "enabled"
 	if !enabled {
-		state = "disabled"
+		action, state = "disable", "disabled"
 	}
-	fmt.Printf("Rule %s: %s\n", name, state)
-	return nil
+	fmt.Fprintf(w, "Rule %s: %s\n", name, state)
+	return writeResult(RuleActionResult{Rule: name, Action: action}, "Rule "+name+": "+state)
 }
 
 // ==================== delete ====================
@@ -590,18 +602,19 @@ func newRuleDeleteCmd(deps *PipelineCommandDeps) *cobra.Command {
 
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Skip confirmation prompt")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runRuleDelete(ctx context.Context, deps *PipelineCommandDeps, name string, confirmed bool) error {
+	w := resultOut()
 	if !confirmed {
-		fmt.Printf("Delete automation rule %q? [y/N] ", name)
+		fmt.Fprintf(os.Stderr, "Delete automation rule %q? [y/N] ", name)
 		scanner := bufio.NewScanner(os.Stdin)
 		scanner.Scan()
 		answer := strings.TrimSpace(strings.ToLower(scanner.Text()))
 		if answer != "y" && answer != "yes" {
-			fmt.Println("Cancelled.")
-			return nil
+			fmt.Fprintln(w, "Cancelled.")
+			return writeOutcome(false, RuleActionResult{Rule: name, Action: "delete"}, "Cancelled.")
 		}
 	}
 
@@ -635,13 +648,13 @@ func runRuleDelete(ctx context.Context, deps *PipelineCommandDeps, name string,
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("deleting automation rule: %w", err)
 	}
 
-	fmt.Printf("Deleted rule: %s\n", name)
-	return nil
+	fmt.Fprintf(w, "Deleted rule: %s\n", name)
+	return writeResult(RuleActionResult{Rule: name, Action: "delete"}, "Deleted rule: "+name)
 }
 
 // ==================== run ====================
@@ -660,7 +673,7 @@ func newRuleRunCmd(deps *PipelineCommandDeps) *cobra.Command {
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be selected without executing")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runRuleRun(ctx context.Context, deps *PipelineCommandDeps, name string, dryRun bool) error {
@@ -669,6 +682,7 @@ func runRuleRun(ctx context.Context, deps *PipelineCommandDeps, name string, dry
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
+	w := resultOut()
 
 	tenantID := cfg.EffectiveTenantID()
 	if tenantID == "" {
@@ -695,22 +709,23 @@ func runRuleRun(ctx context.Context, deps *PipelineCommandDeps, name string, dry
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("running automation rule: %w", err)
 	}
 
+	result := RuleActionResult{Rule: name, Action: "run", DryRun: resp.DryRun, WorkflowID: resp.WorkflowId, DryRunSummary: resp.DryRunSummary}
 	if resp.DryRun {
-		fmt.Printf("Dry run for rule: %s\n\n", name)
+		fmt.Fprintf(w, "Dry run for rule: %s\n\n", name)
 		if resp.DryRunSummary != "" {
-			fmt.Println(resp.DryRunSummary)
+			fmt.Fprintln(w, resp.DryRunSummary)
 		} else {
-			fmt.Println("(no dry-run summary returned)")
+			fmt.Fprintln(w, "(no dry-run summary returned)")
 		}
 	} else {
-		fmt.Printf("Rule %s started (workflow: %s)\n", name, resp.WorkflowId)
+		fmt.Fprintf(w, "Rule %s started (workflow: %s)\n", name, resp.WorkflowId)
 	}
-	return nil
+	return writeResult(result, "")
 }
 
 // ==================== history ====================
@@ -764,7 +779,7 @@ func runRuleHistory(ctx context.Context, deps *PipelineCommandDeps, name string,
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return fmt.Errorf("rule not found: %s", name)
+			return notFoundError("rule not found: %s", name)
 		}
 		return fmt.Errorf("listing rule executions: %w", err)
 	}
@@ -827,7 +842,7 @@ func resolveRuleID(gctx context.Context, client pipelinev1.PipelineServiceClient
 			return r.Id, nil
 		}
 	}
-	return "", fmt.Errorf("rule not found: %s", nameOrID)
+	return "", notFoundError("rule not found: %s", nameOrID)
 }
 
 // buildTriggerConfig builds the trigger config JSON from convenience flags.
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	schedulev1 "github.com/otherjamesbrown/penf-cli/api/proto/schedule/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -164,7 +163,7 @@ func runScheduleShow(ctx context.Context, deps *ScheduleCommandDeps, scheduleID
 		}
 	}
 	if found == nil {
-		return fmt.Errorf("schedule not found: %s", scheduleID)
+		return notFoundError("schedule not found: %s", scheduleID)
 	}
 
 	return outputScheduleDetail(cfg, found)
@@ -290,7 +289,7 @@ func newScheduleUpdateCommand(deps *ScheduleCommandDeps) *cobra.Command {
 	cmd.Flags().StringSliceVar(&scheduleDeliver, "deliver", nil, "Delivery channels (repeatable): store, email")
 	cmd.Flags().StringVar(&scheduleDeliverTo, "deliver-to", "", "Delivery target (e.g., email address)")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runScheduleUpdate(ctx context.Context, deps *ScheduleCommandDeps, input string) error {
@@ -361,6 +360,10 @@ func runScheduleUpdate(ctx context.Context, deps *ScheduleCommandDeps, input str
 		return fmt.Errorf("updating schedule: %w", err)
 	}
 
+	if getScheduleOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ScheduleActionResult{Schedule: input, ScheduleID: scheduleID, Action: "update"}, "Updated schedule: "+input)
+	}
+
 	fmt.Printf("\033[32mUpdated schedule:\033[0m %s\n", input)
 	if workflowParams != "" {
 		delivery := formatDeliveryDisplay(workflowParams)
@@ -374,14 +377,14 @@ func runScheduleUpdate(ctx context.Context, deps *ScheduleCommandDeps, input str
 // ==================== pause ====================
 
 func newSchedulePauseCommand(deps *ScheduleCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "pause <id-or-name>",
 		Short: "Pause a schedule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSchedulePause(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 func runSchedulePause(ctx context.Context, deps *ScheduleCommandDeps, input string) error {
@@ -416,6 +419,10 @@ func runSchedulePause(ctx context.Context, deps *ScheduleCommandDeps, input stri
 		return fmt.Errorf("pausing schedule: %w", err)
 	}
 
+	if getScheduleOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ScheduleActionResult{Schedule: input, ScheduleID: scheduleID, Action: "pause"}, "Paused schedule: "+input)
+	}
+
 	fmt.Printf("\033[33mPaused schedule:\033[0m %s\n", input)
 	return nil
 }
@@ -423,14 +430,14 @@ func runSchedulePause(ctx context.Context, deps *ScheduleCommandDeps, input stri
 // ==================== resume ====================
 
 func newScheduleResumeCommand(deps *ScheduleCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "resume <id-or-name>",
 		Short: "Resume a paused schedule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runScheduleResume(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 func runScheduleResume(ctx context.Context, deps *ScheduleCommandDeps, input string) error {
@@ -465,6 +472,10 @@ func runScheduleResume(ctx context.Context, deps *ScheduleCommandDeps, input str
 		return fmt.Errorf("resuming schedule: %w", err)
 	}
 
+	if getScheduleOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ScheduleActionResult{Schedule: input, ScheduleID: scheduleID, Action: "resume"}, "Resumed schedule: "+input)
+	}
+
 	fmt.Printf("\033[32mResumed schedule:\033[0m %s\n", input)
 	return nil
 }
@@ -481,7 +492,7 @@ func newScheduleTriggerCommand(deps *ScheduleCommandDeps) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&scheduleTriggerDate, "date", "", "Reference date (YYYY-MM-DD) for date simulation")
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func runScheduleTrigger(ctx context.Context, deps *ScheduleCommandDeps, input string) error {
@@ -527,6 +538,10 @@ func runScheduleTrigger(ctx context.Context, deps *ScheduleCommandDeps, input st
 		return fmt.Errorf("triggering schedule: %w", err)
 	}
 
+	if getScheduleOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ScheduleActionResult{Schedule: input, ScheduleID: scheduleID, Action: "trigger", ReferenceDate: scheduleTriggerDate}, "Triggered schedule: "+input)
+	}
+
 	if scheduleTriggerDate != "" {
 		fmt.Printf("\033[32mTriggered schedule:\033[0m %s (reference date: %s)\n", input, scheduleTriggerDate)
 	} else {
@@ -539,14 +554,14 @@ func runScheduleTrigger(ctx context.Context, deps *ScheduleCommandDeps, input st
 // ==================== delete ====================
 
 func newScheduleDeleteCommand(deps *ScheduleCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "delete <id-or-name>",
 		Short: "Delete a schedule",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runScheduleDelete(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 func runScheduleDelete(ctx context.Context, deps *ScheduleCommandDeps, input string) error {
@@ -581,6 +596,10 @@ func runScheduleDelete(ctx context.Context, deps *ScheduleCommandDeps, input str
 		return fmt.Errorf("deleting schedule: %w", err)
 	}
 
+	if getScheduleOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, ScheduleActionResult{Schedule: input, ScheduleID: scheduleID, Action: "delete"}, "Deleted schedule: "+input)
+	}
+
 	fmt.Printf("\033[31mDeleted schedule:\033[0m %s\n", input)
 	return nil
 }
@@ -648,6 +667,15 @@ func getTenantIDForSchedule(deps *ScheduleCommandDeps) (string, error) {
 	return "", fmt.Errorf("tenant ID required: set PENF_TENANT_ID env var or tenant_id in config")
 }
 
+// ScheduleActionResult is the JSON result of a schedule update, pause,
+// resume, trigger or delete.
+type ScheduleActionResult struct {
+	Schedule      string `json:"schedule"`
+	ScheduleID    string `json:"schedule_id"`
+	Action        string `json:"action"`
+	ReferenceDate string `json:"reference_date,omitempty"`
+}
+
 func getScheduleOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if scheduleOutput != "" {
 		return config.OutputFormat(scheduleOutput)
@@ -829,7 +857,7 @@ func scheduleJSON(v interface{}) error {
 }
 
 func scheduleYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -959,5 +987,5 @@ func scheduleResolveID(ctx context.Context, client schedulev1.ScheduleServiceCli
 			return s.Id, nil
 		}
 	}
-	return "", fmt.Errorf("schedule not found: %s", input)
+	return "", notFoundError("schedule not found: %s", input)
 }
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	mentionsv1 "github.com/otherjamesbrown/penf-cli/api/proto/mentions/v1"
 	searchv1 "github.com/otherjamesbrown/penf-cli/api/proto/search/v1"
@@ -52,25 +51,28 @@ type SearchResult struct {
 	Source      string            `json:"source" yaml:"source"`
 	Snippet     string            `json:"snippet" yaml:"snippet"`
 	Highlights  []string          `json:"highlights,omitempty" yaml:"highlights,omitempty"`
+	Matches     []SearchMatch     `json:"matches,omitempty" yaml:"matches,omitempty"`
 	Score       float64           `json:"score" yaml:"score"`
 	TextScore   float64           `json:"text_score,omitempty" yaml:"text_score,omitempty"`
 	VectorScore float64           `json:"vector_score,omitempty" yaml:"vector_score,omitempty"`
+	MatchedBy   SearchMode        `json:"matched_by,omitempty" yaml:"matched_by,omitempty"`
 	CreatedAt   time.Time         `json:"created_at" yaml:"created_at"`
 	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // SearchResponse contains search results and metadata.
 type SearchResponse struct {
-	Query       string         `json:"query" yaml:"query"`
-	Mode        SearchMode     `json:"mode" yaml:"mode"`
-	Results     []SearchResult `json:"results" yaml:"results"`
-	TotalCount  int64          `json:"total_count" yaml:"total_count"`
-	QueryTimeMs float64        `json:"query_time_ms" yaml:"query_time_ms"`
-	Limit       int            `json:"limit" yaml:"limit"`
-	Offset      int            `json:"offset" yaml:"offset"`
-	Filters       SearchFilters `json:"filters,omitempty" yaml:"filters,omitempty"`
-	SearchedAt    time.Time     `json:"searched_at" yaml:"searched_at"`
-	ExpansionInfo string        `json:"expansion_info,omitempty" yaml:"expansion_info,omitempty"`
+	Query          string         `json:"query" yaml:"query"`
+	Mode           SearchMode     `json:"mode" yaml:"mode"`
+	SemanticWeight *float64       `json:"semantic_weight,omitempty" yaml:"semantic_weight,omitempty"`
+	Results        []SearchResult `json:"results" yaml:"results"`
+	TotalCount     int64          `json:"total_count" yaml:"total_count"`
+	QueryTimeMs    float64        `json:"query_time_ms" yaml:"query_time_ms"`
+	Limit          int            `json:"limit" yaml:"limit"`
+	Offset         int            `json:"offset" yaml:"offset"`
+	Filters        SearchFilters  `json:"filters,omitempty" yaml:"filters,omitempty"`
+	SearchedAt     time.Time      `json:"searched_at" yaml:"searched_at"`
+	ExpansionInfo  string         `json:"expansion_info,omitempty" yaml:"expansion_info,omitempty"`
 }
 
 // SearchFilters contains the active filters for a search.
@@ -132,8 +134,11 @@ func DefaultSearchDeps() *SearchCommandDeps {
 			opts := client.DefaultOptions()
 			opts.Insecure = cfg.Insecure
 			opts.Debug = cfg.Debug
+			opts.DebugTrace = cfg.DebugTrace
 			opts.TenantID = cfg.TenantID
-			// Keep the default ConnectTimeout (10s) for fast failure detection.
+			opts.ConnectTimeout = cfg.GetConnectTimeout()
+			opts.RequestTimeout = cfg.Timeout
+			opts.ApplyConnectionConfig(cfg)
 
 			// Load TLS config if not insecure
 			if !cfg.Insecure && cfg.TLS.Enabled {
@@ -173,6 +178,14 @@ var (
 	searchFilters  []string
 )
 
+// Search cache flags.
+var (
+	searchCacheTTL   time.Duration
+	searchNoCache    bool
+	searchClearCache bool
+	searchDebug      bool
+)
+
 // Advanced search flags.
 var (
 	advancedFilters  []string
@@ -197,11 +210,17 @@ func NewSearchCommand(deps *SearchCommandDeps) *cobra.Command {
 		Short: "Search the Penfold knowledge base",
 		Long: `Search the Penfold knowledge base using natural language or structured queries.
 
-Penfold supports three search modes:
+Penfold supports three search modes, selected with --mode:
   - hybrid:   Combines semantic understanding with keyword matching (default)
   - semantic: Uses AI embeddings for conceptual similarity
   - keyword:  Traditional full-text search with boolean operators
 
+Keyword search is best for exact IDs, error codes and acronyms; semantic
+search for concepts phrased differently from the query. In hybrid mode,
+--semantic-weight sets the share of the score from semantic similarity
+(0.0 = keyword only, 1.0 = semantic only); the server default is 0.5. With
+--verbose, each result shows which mode matched it.
+
 Subcommands:
   advanced   Advanced search with field filters and sorting
   history    View and manage search history
@@ -217,7 +236,13 @@ Examples:
   penf search "budget review" --after=2024-01-01 --before=2024-06-30
 
   # Use semantic search for conceptual matching
-  penf search "cost reduction strategies" --semantic
+  penf search "cost reduction strategies" --mode=semantic
+
+  # Literal keyword matching for IDs and acronyms
+  penf search "INC-4821" --mode=keyword
+
+  # Hybrid search leaning towards semantic similarity
+  penf search "vendor pricing concerns" --semantic-weight=0.8 --verbose
 
   # Exact match search
   penf search "ERROR: connection refused" --exact
@@ -231,6 +256,44 @@ Examples:
   # Specify tenant
   penf search "project" --tenant=my-tenant-123
 
+  # Reuse identical results for 5 minutes
+  penf search "project status" --cache-ttl=5m
+
+  # Structured filters, combined with AND
+  penf search "renewal" --filter source=email --filter after=2024-01-01
+
+  # Boolean filter expression
+  penf search "outage" --filter-expr "type=email AND (tag=urgent OR after=lastweek)"
+
+  # Bold the matched terms and show two lines of context around each match
+  penf search "renewal terms" --highlight --context-lines 2
+
+Filters:
+  --filter key=value may be repeated; all filters must match. Keys:
+    after, since, before, until   Date (YYYY-MM-DD, today, yesterday, lastweek, lastmonth)
+    source, type                  Content type or source system, e.g. email, meeting
+    subject, tag                  Substring of the title or tags
+    entity                        Entity ID, email or name in any role
+    from, to, cc, participant...  Entity in a specific role
+
+  --filter-expr accepts the same key=value terms joined with AND, OR, NOT and
+  parentheses. AND binds tighter than OR. Entity and role keys may only be
+  joined with AND. Unknown keys are rejected with the list of valid keys.
+
+Caching:
+  Results are cached locally only when --cache-ttl is set. A later search with
+  the same query, tenant and flags within the TTL is answered from
+  ~/.penf/cache/search/ without contacting the server. Use --no-cache to
+  bypass the cache and 'penf search --clear-cache' to empty it.
+
+Highlighting:
+  By default the server's highlighted words are shown in color. --highlight
+  also bolds every query term found in the snippet. --context-lines N shows
+  each snippet line containing a match with N lines either side, separating
+  groups with "--" as grep -C does. --no-color turns off all colors. JSON and
+  YAML output include each result's "matches": byte offsets (start, end) of
+  the matched terms within "snippet".
+
 Query Syntax:
   - Quoted phrases: "exact phrase"
   - Boolean operators: project AND budget NOT cancelled
@@ -254,8 +317,22 @@ When to Use:
   search     Find specific content by keywords, dates, or content type
   ai query   Get synthesized answers to natural language questions
   briefing   Get priority-ordered assertions for a specific project`,
-		Args: cobra.MinimumNArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if searchClearCache {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if searchClearCache {
+				return runSearchClearCache()
+			}
+			searchDebug, _ = cmd.Flags().GetBool("debug")
+			searchSemanticWeightSet = cmd.Flags().Changed("semantic-weight")
+			searchContextLinesSet = cmd.Flags().Changed("context-lines")
+			if searchContextLines < 0 {
+				return fmt.Errorf("--context-lines must not be negative")
+			}
 			return runSearch(cmd.Context(), deps, strings.Join(args, " "))
 		},
 	}
@@ -265,6 +342,7 @@ When to Use:
 	cmd.Flags().StringVar(&searchAfter, "after", "", "Filter results after this date (YYYY-MM-DD or relative: yesterday, lastweek)")
 	cmd.Flags().StringVar(&searchBefore, "before", "", "Filter results before this date (YYYY-MM-DD or relative: today)")
 	cmd.Flags().StringVarP(&searchMode, "mode", "m", "hybrid", "Search mode: hybrid, keyword, semantic")
+	cmd.Flags().Float64Var(&searchSemanticWeight, "semantic-weight", 0.5, "Hybrid mode: share of the score from semantic similarity (0.0-1.0)")
 	cmd.Flags().IntVarP(&searchLimit, "limit", "l", 10, "Maximum number of results (1-100)")
 	cmd.Flags().IntVar(&searchOffset, "offset", 0, "Offset for pagination")
 	cmd.Flags().StringVarP(&searchSort, "sort", "s", "relevance", "Sort order: relevance, date, date_asc")
@@ -273,7 +351,14 @@ When to Use:
 	cmd.Flags().StringVar(&searchTenant, "tenant", "", "Tenant ID (overrides config)")
 	cmd.Flags().BoolVar(&searchSemantic, "semantic", false, "Use semantic (vector) search only")
 	cmd.Flags().BoolVar(&searchExact, "exact", false, "Exact match only (no fuzzy matching)")
-	cmd.Flags().StringSliceVarP(&searchFilters, "filter", "f", nil, "Field filters (from:name/email, to:name/email, after:date, before:date, participant:name/email)")
+	cmd.Flags().StringSliceVarP(&searchFilters, "filter", "f", nil, "Field filter key=value, repeatable (source, type, after, before, entity, from, to, participant, ...)")
+	cmd.Flags().StringVar(&searchFilterExpr, "filter-expr", "", "Boolean filter expression, e.g. \"source=email AND (tag=urgent OR after=lastweek)\"")
+	cmd.Flags().DurationVar(&searchCacheTTL, "cache-ttl", 0, "Cache results locally for this long, e.g. 5m (default off)")
+	cmd.Flags().BoolVar(&searchNoCache, "no-cache", false, "Bypass the local result cache")
+	cmd.Flags().BoolVar(&searchClearCache, "clear-cache", false, "Remove all locally cached search results")
+	cmd.Flags().BoolVar(&searchHighlight, "highlight", false, "Highlight matched query terms in bold")
+	cmd.Flags().IntVar(&searchContextLines, "context-lines", 0, "Show matching snippet lines with N lines of context, like grep -C")
+	cmd.Flags().BoolVar(&searchNoColor, "no-color", false, "Disable colored output")
 
 	// Add subcommands.
 	cmd.AddCommand(newSearchAdvancedCommand(deps))
@@ -282,6 +367,16 @@ When to Use:
 	return cmd
 }
 
+// runSearchClearCache removes all locally cached search results.
+func runSearchClearCache() error {
+	removed, err := clearSearchCache()
+	if err != nil {
+		return fmt.Errorf("clearing search cache: %w", err)
+	}
+	fmt.Printf("Cleared %d cached search result(s).\n", removed)
+	return nil
+}
+
 // runSearch executes the search command.
 func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) error {
 	// Load configuration.
@@ -319,6 +414,17 @@ func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) er
 		return fmt.Errorf("invalid search mode: %s (must be hybrid, keyword, or semantic)", searchMode)
 	}
 
+	// Validate the hybrid blend.
+	textWeight, vectorWeight, err := hybridSearchWeights(mode, searchSemanticWeight, searchSemanticWeightSet)
+	if err != nil {
+		return err
+	}
+	var semanticWeight *float64
+	if vectorWeight != nil {
+		w := searchSemanticWeight
+		semanticWeight = &w
+	}
+
 	// Validate and clamp limit.
 	if searchLimit < 1 {
 		searchLimit = 1
@@ -367,6 +473,34 @@ func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) er
 		ExactMatch:   searchExact,
 	}
 
+	// Answer from the local cache when enabled and fresh.
+	useCache := searchCacheTTL > 0 && !searchNoCache
+	cacheKey := searchCacheKey{
+		Server:         cfg.GetSearchServiceAddress(),
+		Tenant:         cfg.EffectiveTenantID(),
+		Query:          queryStr,
+		Mode:           mode,
+		Types:          searchTypes,
+		DateFrom:       dateFrom,
+		DateTo:         dateTo,
+		Limit:          searchLimit,
+		Offset:         searchOffset,
+		Sort:           sortOrder,
+		Exact:          searchExact,
+		Verbose:        searchVerbose,
+		Filters:        searchFilters,
+		SemanticWeight: semanticWeight,
+		FilterExpr:     searchFilterExpr,
+	}
+	if useCache {
+		if cached, age, ok := readSearchCache(cacheKey, searchCacheTTL); ok {
+			if cfg.Debug || searchDebug {
+				fmt.Fprintf(os.Stderr, "Using cached search results (%s old)\n", age.Round(time.Second))
+			}
+			return outputSearchResults(outputFormat, *cached, searchVerbose)
+		}
+	}
+
 	// Initialize search client.
 	searchClient, err := deps.InitSearch(cfg)
 	if err != nil {
@@ -385,10 +519,27 @@ func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) er
 		protoSortOrder = searchv1.SortOrder_SORT_ORDER_RELEVANCE
 	}
 
-	// Parse structured filters from --filter flag.
+	// Parse structured filters from --filter and --filter-expr. A plain AND
+	// expression is folded into the structured filters; anything else is
+	// evaluated against the results.
+	filterTerms, err := validateSearchFilters(searchFilters)
+	if err != nil {
+		return err
+	}
+	var filterExpr searchFilterNode
+	if searchFilterExpr != "" {
+		filterExpr, err = parseSearchFilterExpr(searchFilterExpr)
+		if err != nil {
+			return err
+		}
+		if terms, ok := filterExpr.conjunction(); ok {
+			filterTerms = append(filterTerms, terms...)
+			filterExpr = nil
+		}
+	}
 	var parsed parsedFilters
-	if len(searchFilters) > 0 {
-		parsed = parseFilters(searchFilters)
+	if len(filterTerms) > 0 {
+		parsed = parseFilters(searchFilterStrings(filterTerms))
 	}
 
 	// Merge date filters: --filter after:/before: override --after/--before flags.
@@ -410,6 +561,8 @@ func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) er
 		Offset:            int32(searchOffset),
 		SortOrder:         protoSortOrder,
 		EntityRoleFilters: parsed.roleFilters,
+		TextWeight:        textWeight,
+		VectorWeight:      vectorWeight,
 	}
 
 	// Execute the search based on mode.
@@ -433,29 +586,41 @@ func runSearch(ctx context.Context, deps *SearchCommandDeps, queryStr string) er
 
 	// Convert search response to CLI format.
 	results := convertSearchResults(searchResp.Results, searchVerbose)
+	annotateSearchMatchedBy(mode, results)
+	annotateSearchMatches(queryStr, results)
 
 	// Apply remaining client-side filters (subject, tag, etc.)
 	if len(parsed.remaining) > 0 {
 		results = applyFieldFilters(results, parsed.remaining)
 	}
+	if filterExpr != nil {
+		results = filterSearchResults(results, filterExpr)
+	}
 
 	// Build response.
 	response := SearchResponse{
-		Query:         queryStr,
-		Mode:          mode,
-		Results:       results,
-		TotalCount:    searchResp.TotalCount,
-		QueryTimeMs:   queryTime,
-		Limit:         searchLimit,
-		Offset:        searchOffset,
-		Filters:       filters,
-		SearchedAt:    time.Now(),
-		ExpansionInfo: searchResp.ExpansionInfo,
+		Query:          queryStr,
+		Mode:           mode,
+		SemanticWeight: semanticWeight,
+		Results:        results,
+		TotalCount:     searchResp.TotalCount,
+		QueryTimeMs:    queryTime,
+		Limit:          searchLimit,
+		Offset:         searchOffset,
+		Filters:        filters,
+		SearchedAt:     time.Now(),
+		ExpansionInfo:  searchResp.ExpansionInfo,
 	}
 
 	// Log activity (fire-and-forget)
 	logActivity(cfg, fmt.Sprintf("search: %s (%d results)", queryStr, len(results)))
 
+	if useCache {
+		if err := writeSearchCache(cacheKey, response); err != nil && (cfg.Debug || searchDebug) {
+			fmt.Fprintf(os.Stderr, "Warning: caching search results: %v\n", err)
+		}
+	}
+
 	// Output results.
 	return outputSearchResults(outputFormat, response, searchVerbose)
 }
@@ -568,7 +733,7 @@ func outputSearchResults(format config.OutputFormat, response SearchResponse, ve
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputSearchResultsText(response, verbose)
@@ -579,12 +744,16 @@ func outputSearchResults(format config.OutputFormat, response SearchResponse, ve
 func outputSearchResultsText(response SearchResponse, verbose bool) error {
 	// Header.
 	fmt.Printf("Search: %s\n", response.Query)
+	mode := string(response.Mode)
+	if response.SemanticWeight != nil {
+		mode = fmt.Sprintf("%s (semantic weight %.2f)", mode, *response.SemanticWeight)
+	}
 	fmt.Printf("Mode: %s | Results: %d of %d | Time: %.1fms\n",
-		response.Mode, len(response.Results), response.TotalCount, response.QueryTimeMs)
+		mode, len(response.Results), response.TotalCount, response.QueryTimeMs)
 
 	// Show glossary expansion info.
 	if response.ExpansionInfo != "" {
-		fmt.Printf("\033[36mExpanded:\033[0m %s\n", response.ExpansionInfo)
+		fmt.Printf("%sExpanded:%s %s\n", searchColor("\033[36m"), searchColor(ansiReset), response.ExpansionInfo)
 	}
 
 	// Show active filters.
@@ -621,13 +790,16 @@ func outputSearchResultsText(response SearchResponse, verbose bool) error {
 	// Results.
 	for i, result := range response.Results {
 		resultNum := response.Offset + i + 1
-		fmt.Printf("\033[1m%d. %s\033[0m\n", resultNum, result.Title)
+		fmt.Printf("%s%d. %s%s\n", searchColor(ansiBold), resultNum, result.Title, searchColor(ansiReset))
 
 		// Score with color coding.
 		scoreColor := getScoreColor(result.Score)
-		fmt.Printf("   Score: %s%.2f\033[0m", scoreColor, result.Score)
+		fmt.Printf("   Score: %s%.2f%s", searchColor(scoreColor), result.Score, searchColor(ansiReset))
 		if verbose {
 			fmt.Printf(" (text: %.2f, vector: %.2f)", result.TextScore, result.VectorScore)
+			if result.MatchedBy != "" {
+				fmt.Printf(" | Matched by: %s", result.MatchedBy)
+			}
 		}
 		fmt.Println()
 
@@ -638,8 +810,9 @@ func outputSearchResultsText(response SearchResponse, verbose bool) error {
 			formatRelativeTime(result.CreatedAt))
 
 		// Snippet with highlighting preserved.
-		snippet := formatSnippet(result.Snippet)
-		fmt.Printf("   %s\n", snippet)
+		for _, line := range formatSearchResultSnippet(result) {
+			fmt.Printf("   %s\n", line)
+		}
 
 		// Verbose: show metadata.
 		if verbose && len(result.Metadata) > 0 {
@@ -945,6 +1118,7 @@ func runAdvancedSearch(ctx context.Context, deps *SearchCommandDeps, queryStr st
 
 	// Convert search response to CLI format.
 	results := convertSearchResults(searchResp.Results, searchVerbose)
+	annotateSearchMatches(queryStr, results)
 
 	// Apply client-side field filters if specified.
 	// Note: These filters are applied after the search since the search service
@@ -973,10 +1147,10 @@ func runAdvancedSearch(ctx context.Context, deps *SearchCommandDeps, queryStr st
 
 // roleFilterMapping maps CLI filter field names to ParticipationRole values.
 var roleFilterMapping = map[string][]mentionsv1.ParticipationRole{
-	"from":      {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_FROM},
-	"sender":    {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_FROM},
-	"to":        {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_TO},
-	"cc":        {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_CC},
+	"from":   {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_FROM},
+	"sender": {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_FROM},
+	"to":     {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_TO},
+	"cc":     {mentionsv1.ParticipationRole_PARTICIPATION_ROLE_CC},
 	"recipient": {
 		mentionsv1.ParticipationRole_PARTICIPATION_ROLE_TO,
 		mentionsv1.ParticipationRole_PARTICIPATION_ROLE_CC,
@@ -1027,6 +1201,17 @@ func parseFilters(filters []string) parsedFilters {
 		field, value := strings.ToLower(parts[0]), parts[1]
 
 		switch {
+		case field == "entity":
+			// Any participation by the entity, by ID, email or name.
+			rf := &searchv1.EntityRoleFilter{}
+			if strings.Contains(value, "@") {
+				rf.Entity = &searchv1.EntityRoleFilter_Email{Email: strings.ToLower(value)}
+			} else if id, err := ParseEntityID(value); err == nil {
+				rf.Entity = &searchv1.EntityRoleFilter_EntityId{EntityId: id}
+			} else {
+				rf.Entity = &searchv1.EntityRoleFilter_EntityName{EntityName: value}
+			}
+			result.roleFilters = append(result.roleFilters, rf)
 		case field == "after" || field == "since":
 			if d, err := parseSearchDate(parser, value); err == nil {
 				result.dateFrom = &d
@@ -1079,7 +1264,8 @@ func applyFieldFilters(results []SearchResult, fieldFilters []string) []SearchRe
 					match = false
 				}
 			case "source":
-				if !strings.Contains(strings.ToLower(result.Source), value) {
+				if !strings.Contains(strings.ToLower(result.ContentType), value) &&
+					!strings.Contains(strings.ToLower(result.Source), value) {
 					match = false
 				}
 			case "subject":
@@ -1221,7 +1407,7 @@ func outputSearchHistory(format config.OutputFormat, response SearchHistoryRespo
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputSearchHistoryText(response)
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// searchCacheMaxAge bounds how long cached search results are kept on disk,
+// whatever --cache-ttl later runs ask for. Older entries are pruned on write.
+const searchCacheMaxAge = 24 * time.Hour
+
+// searchCacheKey identifies a search by everything that affects its results.
+type searchCacheKey struct {
+	Server   string     `json:"server"`
+	Tenant   string     `json:"tenant"`
+	Query    string     `json:"query"`
+	Mode     SearchMode `json:"mode"`
+	Types    []string   `json:"types,omitempty"`
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
+	Limit    int        `json:"limit"`
+	Offset   int        `json:"offset"`
+	Sort     SortOrder  `json:"sort"`
+	Exact    bool       `json:"exact,omitempty"`
+	Verbose  bool       `json:"verbose,omitempty"`
+	Filters  []string   `json:"filters,omitempty"`
+
+	SemanticWeight *float64 `json:"semantic_weight,omitempty"`
+	FilterExpr     string   `json:"filter_expr,omitempty"`
+}
+
+// hash returns the cache file name for the key.
+func (k searchCacheKey) hash() string {
+	data, _ := json.Marshal(k)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// searchCacheEntry is the on-disk form of a cached search.
+type searchCacheEntry struct {
+	StoredAt time.Time      `json:"stored_at"`
+	Response SearchResponse `json:"response"`
+}
+
+// searchCacheDir returns ~/.penf/cache/search.
+func searchCacheDir() (string, error) {
+	return cacheDir("search")
+}
+
+// readSearchCache returns the cached response for key if it was stored within
+// ttl. The second return value is the age of the entry.
+func readSearchCache(key searchCacheKey, ttl time.Duration) (*SearchResponse, time.Duration, bool) {
+	dir, err := searchCacheDir()
+	if err != nil {
+		return nil, 0, false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, key.hash()+".json"))
+	if err != nil {
+		return nil, 0, false
+	}
+
+	var entry searchCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, 0, false
+	}
+	age := time.Since(entry.StoredAt)
+	if age < 0 || age >= ttl {
+		return nil, 0, false
+	}
+	return &entry.Response, age, true
+}
+
+// writeSearchCache stores a response for key and prunes expired entries.
+func writeSearchCache(key searchCacheKey, response SearchResponse) error {
+	dir, err := searchCacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("creating search cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(searchCacheEntry{StoredAt: time.Now(), Response: response})
+	if err != nil {
+		return fmt.Errorf("encoding search cache entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, key.hash()+".json"), data, 0600); err != nil {
+		return fmt.Errorf("writing search cache entry: %w", err)
+	}
+
+	pruneSearchCache(dir, searchCacheMaxAge)
+	return nil
+}
+
+// pruneSearchCache removes cache files last written more than maxAge ago.
+func pruneSearchCache(dir string, maxAge time.Duration) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || time.Since(info.ModTime()) < maxAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, e.Name()))
+	}
+}
+
+// clearSearchCache removes all cached search results and returns how many
+// entries were removed.
+func clearSearchCache() (int, error) {
+	dir, err := searchCacheDir()
+	if err != nil {
+		return 0, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading search cache: %w", err)
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("removing %s: %w", e.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
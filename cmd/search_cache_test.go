@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchCacheKey_Hash(t *testing.T) {
+	base := searchCacheKey{Server: "gw:50051", Tenant: "acme", Query: "budget", Mode: SearchModeHybrid, Limit: 10, Sort: SortOrderRelevance}
+
+	same := base
+	if base.hash() != same.hash() {
+		t.Error("identical keys should hash the same")
+	}
+
+	otherTenant := base
+	otherTenant.Tenant = "globex"
+	otherLimit := base
+	otherLimit.Limit = 20
+	otherTypes := base
+	otherTypes.Types = []string{"email"}
+	for name, k := range map[string]searchCacheKey{"tenant": otherTenant, "limit": otherLimit, "types": otherTypes} {
+		if k.hash() == base.hash() {
+			t.Errorf("changing %s should change the hash", name)
+		}
+	}
+}
+
+func TestSearchCache_ReadWriteTTL(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+	key := searchCacheKey{Query: "budget", Limit: 10}
+
+	if _, _, ok := readSearchCache(key, time.Minute); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	if err := writeSearchCache(key, SearchResponse{Query: "budget", TotalCount: 3}); err != nil {
+		t.Fatalf("writeSearchCache: %v", err)
+	}
+
+	cached, _, ok := readSearchCache(key, time.Minute)
+	if !ok || cached.Query != "budget" || cached.TotalCount != 3 {
+		t.Fatalf("expected a hit, got %+v, %v", cached, ok)
+	}
+
+	if _, _, ok := readSearchCache(key, time.Nanosecond); ok {
+		t.Error("expected a miss once the entry is older than the TTL")
+	}
+}
+
+func TestClearSearchCache(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+
+	if removed, err := clearSearchCache(); err != nil || removed != 0 {
+		t.Fatalf("clearing a missing cache = %d, %v", removed, err)
+	}
+
+	for _, q := range []string{"a", "b"} {
+		if err := writeSearchCache(searchCacheKey{Query: q}, SearchResponse{Query: q}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := clearSearchCache()
+	if err != nil || removed != 2 {
+		t.Errorf("clearSearchCache = %d, %v; want 2", removed, err)
+	}
+}
+
+func TestPruneSearchCache(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.json")
+	fresh := filepath.Join(dir, "fresh.json")
+	for _, p := range []string{old, fresh} {
+		if err := os.WriteFile(p, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	pruneSearchCache(dir, 24*time.Hour)
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("expected old entry to be pruned")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("expected fresh entry to be kept")
+	}
+}
+
+func TestRunSearch_CacheHitSkipsServer(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+	cfg := mockConfig()
+	deps := createSearchTestDeps(cfg) // InitSearch always fails
+
+	searchTypes = nil
+	searchAfter = ""
+	searchBefore = ""
+	searchMode = "hybrid"
+	searchLimit = 10
+	searchOffset = 0
+	searchSort = "relevance"
+	searchVerbose = false
+	searchOutput = "json"
+	searchFilters = nil
+	searchCacheTTL = time.Minute
+	searchNoCache = false
+	defer func() {
+		searchOutput = ""
+		searchCacheTTL = 0
+		searchNoCache = false
+	}()
+
+	key := searchCacheKey{
+		Server: cfg.GetSearchServiceAddress(),
+		Tenant: cfg.EffectiveTenantID(),
+		Query:  "cached query",
+		Mode:   SearchModeHybrid,
+		Limit:  10,
+		Sort:   SortOrderRelevance,
+	}
+	if err := writeSearchCache(key, SearchResponse{Query: "cached query", TotalCount: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = runSearch(context.Background(), deps, "cached query")
+	})
+	if runErr != nil {
+		t.Fatalf("expected cache hit without contacting the server, got: %v", runErr)
+	}
+	var resp SearchResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, out)
+	}
+	if resp.TotalCount != 7 {
+		t.Errorf("total_count = %d, want cached 7", resp.TotalCount)
+	}
+
+	searchNoCache = true
+	err := runSearch(context.Background(), deps, "cached query")
+	if err == nil || !strings.Contains(err.Error(), searchServiceUnavailableError) {
+		t.Errorf("--no-cache should bypass the cache, got: %v", err)
+	}
+}
+
+func TestNewSearchCommand_CacheFlags(t *testing.T) {
+	cmd := NewSearchCommand(createSearchTestDeps(mockConfig()))
+	for _, flag := range []string{"cache-ttl", "no-cache", "clear-cache"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag %q to exist", flag)
+		}
+	}
+	if got := cmd.Flags().Lookup("cache-ttl").DefValue; got != "0s" {
+		t.Errorf("cache-ttl default = %s, want 0s (off)", got)
+	}
+}
+
+func TestSearchClearCache_NoQueryRequired(t *testing.T) {
+	t.Setenv("PENF_CONFIG_DIR", t.TempDir())
+	cmd := NewSearchCommand(createSearchTestDeps(mockConfig()))
+	cmd.SetArgs([]string{"--clear-cache"})
+	defer func() { searchClearCache = false }()
+
+	out := captureStdout(func() {
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("search --clear-cache: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Cleared 0 cached search result(s).") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/otherjamesbrown/penf-cli/services/search/query"
+)
+
+// Search filter expression flag.
+var searchFilterExpr string
+
+// searchFilterKind says where a filter key is applied.
+type searchFilterKind int
+
+const (
+	// filterKindDate filters on the result date, server-side when possible.
+	filterKindDate searchFilterKind = iota
+	// filterKindField filters on result fields client-side.
+	filterKindField
+	// filterKindEntity filters server-side on an entity's participation.
+	filterKindEntity
+)
+
+// searchFilterKeys lists the keys accepted by --filter and --filter-expr.
+// Role keys (from, to, cc, ...) come from roleFilterMapping.
+var searchFilterKeys = map[string]searchFilterKind{
+	"after":   filterKindDate,
+	"since":   filterKindDate,
+	"before":  filterKindDate,
+	"until":   filterKindDate,
+	"source":  filterKindField,
+	"type":    filterKindField,
+	"subject": filterKindField,
+	"tag":     filterKindField,
+	"entity":  filterKindEntity,
+}
+
+// searchFilterTerm is one validated "key=value" filter.
+type searchFilterTerm struct {
+	Key   string
+	Value string
+}
+
+// String renders the term in the "key:value" form parseFilters expects.
+func (t searchFilterTerm) String() string {
+	return t.Key + ":" + t.Value
+}
+
+// clientSide reports whether the term can be evaluated against a result.
+func (t searchFilterTerm) clientSide() bool {
+	kind, ok := searchFilterKeys[t.Key]
+	return ok && kind != filterKindEntity
+}
+
+// validSearchFilterKeys returns every accepted filter key, sorted.
+func validSearchFilterKeys() []string {
+	keys := make([]string, 0, len(searchFilterKeys)+len(roleFilterMapping))
+	for k := range searchFilterKeys {
+		keys = append(keys, k)
+	}
+	for k := range roleFilterMapping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseSearchFilterTerm parses "key=value" or "key:value", checking the key
+// against the known set and dates for validity.
+func parseSearchFilterTerm(s string) (searchFilterTerm, error) {
+	i := strings.IndexAny(s, "=:")
+	if i <= 0 || i == len(s)-1 {
+		return searchFilterTerm{}, fmt.Errorf("invalid filter %q: expected key=value, e.g. source=email", s)
+	}
+	term := searchFilterTerm{
+		Key:   strings.ToLower(strings.TrimSpace(s[:i])),
+		Value: strings.TrimSpace(s[i+1:]),
+	}
+
+	kind, known := searchFilterKeys[term.Key]
+	if _, role := roleFilterMapping[term.Key]; !known && !role {
+		return searchFilterTerm{}, fmt.Errorf("unknown filter key %q in %q; valid keys: %s",
+			term.Key, s, strings.Join(validSearchFilterKeys(), ", "))
+	}
+	if known && kind == filterKindDate {
+		if _, err := parseSearchDate(query.NewParser(), term.Value); err != nil {
+			return searchFilterTerm{}, fmt.Errorf("invalid date in filter %q: use YYYY-MM-DD or today, yesterday, lastweek, lastmonth", s)
+		}
+	}
+	return term, nil
+}
+
+// validateSearchFilters parses every --filter value.
+func validateSearchFilters(filters []string) ([]searchFilterTerm, error) {
+	terms := make([]searchFilterTerm, 0, len(filters))
+	for _, f := range filters {
+		term, err := parseSearchFilterTerm(f)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// searchFilterStrings renders terms for parseFilters.
+func searchFilterStrings(terms []searchFilterTerm) []string {
+	out := make([]string, len(terms))
+	for i, t := range terms {
+		out[i] = t.String()
+	}
+	return out
+}
+
+// searchFilterNode is a node of a parsed --filter-expr.
+type searchFilterNode interface {
+	// eval reports whether a result matches.
+	eval(r SearchResult) bool
+	// conjunction returns the node's terms when it is a plain AND of terms.
+	conjunction() ([]searchFilterTerm, bool)
+	// terms returns every term in the node.
+	terms() []searchFilterTerm
+}
+
+type filterTermNode struct{ term searchFilterTerm }
+
+type filterAndNode struct{ left, right searchFilterNode }
+
+type filterOrNode struct{ left, right searchFilterNode }
+
+type filterNotNode struct{ operand searchFilterNode }
+
+func (n filterTermNode) eval(r SearchResult) bool {
+	return matchSearchFilterTerm(r, n.term)
+}
+
+func (n filterTermNode) conjunction() ([]searchFilterTerm, bool) {
+	return []searchFilterTerm{n.term}, true
+}
+
+func (n filterTermNode) terms() []searchFilterTerm {
+	return []searchFilterTerm{n.term}
+}
+
+func (n filterAndNode) eval(r SearchResult) bool {
+	return n.left.eval(r) && n.right.eval(r)
+}
+
+func (n filterAndNode) conjunction() ([]searchFilterTerm, bool) {
+	left, ok := n.left.conjunction()
+	if !ok {
+		return nil, false
+	}
+	right, ok := n.right.conjunction()
+	if !ok {
+		return nil, false
+	}
+	return append(left, right...), true
+}
+
+func (n filterAndNode) terms() []searchFilterTerm {
+	return append(n.left.terms(), n.right.terms()...)
+}
+
+func (n filterOrNode) eval(r SearchResult) bool {
+	return n.left.eval(r) || n.right.eval(r)
+}
+
+func (n filterOrNode) conjunction() ([]searchFilterTerm, bool) {
+	return nil, false
+}
+
+func (n filterOrNode) terms() []searchFilterTerm {
+	return append(n.left.terms(), n.right.terms()...)
+}
+
+func (n filterNotNode) eval(r SearchResult) bool {
+	return !n.operand.eval(r)
+}
+
+func (n filterNotNode) conjunction() ([]searchFilterTerm, bool) {
+	return nil, false
+}
+
+func (n filterNotNode) terms() []searchFilterTerm {
+	return n.operand.terms()
+}
+
+// matchSearchFilterTerm evaluates a date or field term against a result.
+func matchSearchFilterTerm(r SearchResult, t searchFilterTerm) bool {
+	value := strings.ToLower(t.Value)
+	switch t.Key {
+	case "after", "since":
+		d, err := parseSearchDate(query.NewParser(), t.Value)
+		return err == nil && !r.CreatedAt.Before(d)
+	case "before", "until":
+		d, err := parseSearchDate(query.NewParser(), t.Value)
+		return err == nil && r.CreatedAt.Before(d)
+	case "source":
+		return strings.Contains(strings.ToLower(r.ContentType), value) || strings.Contains(strings.ToLower(r.Source), value)
+	case "type":
+		return strings.Contains(strings.ToLower(r.ContentType), value)
+	case "subject":
+		return strings.Contains(strings.ToLower(r.Title), value)
+	case "tag":
+		return strings.Contains(strings.ToLower(r.Metadata["tags"]), value)
+	}
+	return false
+}
+
+// parseSearchFilterExpr parses a --filter-expr such as
+// `source=email AND (after=2024-01-01 OR tag=urgent) AND NOT type=chat`.
+// AND binds tighter than OR; adjacent terms without an operator are ANDed.
+// Expressions using OR or NOT may only contain keys that can be checked on
+// results, since role and entity filters are applied by the server.
+func parseSearchFilterExpr(expr string) (searchFilterNode, error) {
+	tokens, err := tokenizeSearchFilterExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty --filter-expr")
+	}
+
+	p := &searchFilterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid --filter-expr: unexpected %q", p.tokens[p.pos])
+	}
+
+	if _, ok := node.conjunction(); !ok {
+		for _, t := range node.terms() {
+			if !t.clientSide() {
+				return nil, fmt.Errorf("invalid --filter-expr: %q can only be combined with AND; use --filter instead", t.Key)
+			}
+		}
+	}
+	return node, nil
+}
+
+// tokenizeSearchFilterExpr splits an expression into parentheses, operators
+// and terms. Values may be double-quoted to include spaces.
+func tokenizeSearchFilterExpr(expr string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, c := range expr {
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			cur.WriteRune(c)
+		case c == '(' || c == ')':
+			flush()
+			tokens = append(tokens, string(c))
+		case unicode.IsSpace(c):
+			flush()
+		default:
+			cur.WriteRune(c)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("invalid --filter-expr: unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+// searchFilterParser is a recursive-descent parser over expression tokens.
+type searchFilterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *searchFilterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *searchFilterParser) parseOr() (searchFilterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOrNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *searchFilterParser) parseAnd() (searchFilterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		next := p.peek()
+		switch {
+		case strings.EqualFold(next, "AND"):
+			p.pos++
+		case next == "" || next == ")" || strings.EqualFold(next, "OR"):
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAndNode{left, right}
+	}
+}
+
+func (p *searchFilterParser) parseUnary() (searchFilterNode, error) {
+	tok := p.peek()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("invalid --filter-expr: expected a filter at end of expression")
+	case strings.EqualFold(tok, "NOT"):
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNotNode{operand}, nil
+	case tok == "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("invalid --filter-expr: missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case tok == ")" || strings.EqualFold(tok, "AND") || strings.EqualFold(tok, "OR"):
+		return nil, fmt.Errorf("invalid --filter-expr: unexpected %q", tok)
+	}
+
+	p.pos++
+	term, err := parseSearchFilterTerm(tok)
+	if err != nil {
+		return nil, err
+	}
+	return filterTermNode{term}, nil
+}
+
+// filterSearchResults keeps the results matching node.
+func filterSearchResults(results []SearchResult, node searchFilterNode) []SearchResult {
+	filtered := []SearchResult{}
+	for _, r := range results {
+		if node.eval(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchFilterTerm(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    searchFilterTerm
+		wantErr string
+	}{
+		{in: "source=email", want: searchFilterTerm{Key: "source", Value: "email"}},
+		{in: "Type:meeting", want: searchFilterTerm{Key: "type", Value: "meeting"}},
+		{in: "from=alice@example.com", want: searchFilterTerm{Key: "from", Value: "alice@example.com"}},
+		{in: "after=2024-01-01", want: searchFilterTerm{Key: "after", Value: "2024-01-01"}},
+		{in: "colour=red", wantErr: "valid keys"},
+		{in: "after=someday", wantErr: "invalid date"},
+		{in: "source", wantErr: "expected key=value"},
+		{in: "source=", wantErr: "expected key=value"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSearchFilterTerm(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSearchFilterTerm(%q) error = %v, want containing %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSearchFilterTerm(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSearchFilterTerm(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseSearchFilterExpr_Conjunction(t *testing.T) {
+	node, err := parseSearchFilterExpr(`source=email AND from=alice@example.com subject:"budget review"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	terms, ok := node.conjunction()
+	if !ok {
+		t.Fatal("expected a plain AND expression")
+	}
+	got := strings.Join(searchFilterStrings(terms), ",")
+	want := "source:email,from:alice@example.com,subject:budget review"
+	if got != want {
+		t.Errorf("terms = %q, want %q", got, want)
+	}
+}
+
+func TestParseSearchFilterExpr_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "", wantErr: "empty"},
+		{expr: "source=email OR", wantErr: "expected a filter"},
+		{expr: "(source=email", wantErr: "missing closing parenthesis"},
+		{expr: "source=email)", wantErr: "unexpected"},
+		{expr: `subject:"open`, wantErr: "unterminated quote"},
+		{expr: "source=email OR from=alice", wantErr: "only be combined with AND"},
+		{expr: "NOT entity=42", wantErr: "only be combined with AND"},
+		{expr: "bogus=1 OR source=email", wantErr: "valid keys"},
+	}
+
+	for _, tt := range tests {
+		_, err := parseSearchFilterExpr(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("parseSearchFilterExpr(%q) error = %v, want containing %q", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFilterSearchResults(t *testing.T) {
+	results := []SearchResult{
+		{ID: "1", ContentType: "email", Title: "Outage report", CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Metadata: map[string]string{"tags": "urgent"}},
+		{ID: "2", ContentType: "email", Title: "Lunch", CreatedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "3", ContentType: "meeting", Title: "Outage review", CreatedAt: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: "4", ContentType: "document", Title: "Old spec", CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "type=email AND (tag=urgent OR after=2024-01-01)", want: "1"},
+		{expr: "type=email OR type=meeting", want: "1,2,3"},
+		{expr: "NOT type=email", want: "3,4"},
+		{expr: "subject=outage OR before=2023-01-01", want: "1,3,4"},
+		{expr: "NOT (type=email OR subject=outage)", want: "4"},
+	}
+
+	for _, tt := range tests {
+		node, err := parseSearchFilterExpr(tt.expr)
+		if err != nil {
+			t.Fatalf("parseSearchFilterExpr(%q): %v", tt.expr, err)
+		}
+		var ids []string
+		for _, r := range filterSearchResults(results, node) {
+			ids = append(ids, r.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("%q matched %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilters_Entity(t *testing.T) {
+	parsed := parseFilters([]string{"entity:ent-person-42", "entity:bob@example.com", "entity:Alice Smith"})
+	if len(parsed.roleFilters) != 3 {
+		t.Fatalf("got %d role filters, want 3", len(parsed.roleFilters))
+	}
+	if got := parsed.roleFilters[0].GetEntityId(); got != 42 {
+		t.Errorf("entity ID = %d, want 42", got)
+	}
+	if got := parsed.roleFilters[1].GetEmail(); got != "bob@example.com" {
+		t.Errorf("email = %q, want bob@example.com", got)
+	}
+	if got := parsed.roleFilters[2].GetEntityName(); got != "Alice Smith" {
+		t.Errorf("entity name = %q, want Alice Smith", got)
+	}
+	if len(parsed.roleFilters[0].GetRoles()) != 0 {
+		t.Error("entity filter should match any role")
+	}
+}
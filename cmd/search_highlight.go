@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Search highlighting flags.
+var (
+	searchHighlight       bool
+	searchContextLines    int
+	searchContextLinesSet bool
+	searchNoColor         bool
+)
+
+const (
+	// searchSnippetMaxLen is how much of a snippet the one-line view shows.
+	searchSnippetMaxLen = 200
+
+	ansiBold      = "\033[1m"
+	ansiBoldAmber = "\033[1;33m"
+	ansiReset     = "\033[0m"
+)
+
+// searchEmPattern matches the <em>...</em> highlights the server puts in
+// snippets. Group 1 is the highlighted text.
+var searchEmPattern = regexp.MustCompile(`<em>(.*?)</em>`)
+
+// SearchMatch is a matched term in a result's snippet. Start and End are
+// byte offsets into Snippet as returned, including any <em> markup.
+type SearchMatch struct {
+	Start int    `json:"start" yaml:"start"`
+	End   int    `json:"end" yaml:"end"`
+	Term  string `json:"term" yaml:"term"`
+}
+
+// searchQueryTerms extracts the terms worth highlighting from a query:
+// words and quoted phrases, without boolean operators, field filters such
+// as type:email, or negated -terms.
+func searchQueryTerms(queryStr string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	add := func(t string) {
+		t = strings.Trim(t, `()"'.,;!?`)
+		if len([]rune(t)) < 2 || seen[strings.ToLower(t)] {
+			return
+		}
+		seen[strings.ToLower(t)] = true
+		terms = append(terms, t)
+	}
+
+	parts := strings.Split(queryStr, `"`)
+	for i, part := range parts {
+		// Odd parts are inside quotes and kept as phrases.
+		if i%2 == 1 {
+			add(part)
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			switch {
+			case word == "AND" || word == "OR" || word == "NOT":
+			case strings.HasPrefix(word, "-"), strings.Contains(word, ":"):
+			default:
+				add(word)
+			}
+		}
+	}
+	return terms
+}
+
+// findSearchMatches locates the server's <em> highlights and every
+// case-insensitive occurrence of terms in snippet, merging overlaps.
+func findSearchMatches(snippet string, terms []string) []SearchMatch {
+	var matches []SearchMatch
+	for _, loc := range searchEmPattern.FindAllStringSubmatchIndex(snippet, -1) {
+		if loc[3] > loc[2] {
+			matches = append(matches, SearchMatch{Start: loc[2], End: loc[3], Term: snippet[loc[2]:loc[3]]})
+		}
+	}
+
+	if len(terms) > 0 {
+		quoted := make([]string, len(terms))
+		for i, t := range terms {
+			quoted[i] = regexp.QuoteMeta(t)
+		}
+		// Longer terms first so a phrase wins over its own words.
+		sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+		re := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+		for _, loc := range re.FindAllStringIndex(snippet, -1) {
+			matches = append(matches, SearchMatch{Start: loc[0], End: loc[1], Term: snippet[loc[0]:loc[1]]})
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	merged := []SearchMatch{matches[0]}
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m.Start <= last.End {
+			if m.End > last.End {
+				last.End = m.End
+				last.Term = snippet[last.Start:last.End]
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// annotateSearchMatches sets Matches on each result from the query's terms.
+func annotateSearchMatches(queryStr string, results []SearchResult) {
+	terms := searchQueryTerms(queryStr)
+	for i := range results {
+		results[i].Matches = findSearchMatches(results[i].Snippet, terms)
+	}
+}
+
+// searchSnippetStyle controls how renderSearchSnippet marks matches.
+type searchSnippetStyle struct {
+	// highlight marks every match rather than only the server's <em> spans.
+	highlight bool
+	// noColor strips markup without adding ANSI codes.
+	noColor bool
+}
+
+// renderSearchSnippet renders snippet[start:end] for the terminal: <em>
+// markup is removed and matches are wrapped in ANSI codes according to
+// style. At most maxLen visible bytes are kept (0 means no limit), ending
+// in "..." when cut.
+func renderSearchSnippet(snippet string, start, end int, matches []SearchMatch, style searchSnippetStyle, maxLen int) string {
+	open := ""
+	if !style.noColor {
+		open = ansiBoldAmber
+		if style.highlight {
+			open = ansiBold
+		}
+	}
+	// Without --highlight only the server's own <em> spans are marked.
+	marked := func(m SearchMatch) bool {
+		return style.highlight || strings.HasSuffix(snippet[:m.Start], "<em>")
+	}
+
+	var sb strings.Builder
+	visible, inMatch, next := 0, false, 0
+	for next < len(matches) && matches[next].End <= start {
+		next++
+	}
+	closeMatch := func() {
+		if inMatch && open != "" {
+			sb.WriteString(ansiReset)
+		}
+		inMatch = false
+	}
+
+	for i := start; i < end; {
+		if inMatch && i >= matches[next-1].End {
+			closeMatch()
+		}
+		if !inMatch && next < len(matches) && i >= matches[next].Start {
+			m := matches[next]
+			next++
+			if marked(m) {
+				inMatch = true
+				if open != "" {
+					sb.WriteString(open)
+				}
+			}
+		}
+		if strings.HasPrefix(snippet[i:], "<em>") {
+			i += len("<em>")
+			continue
+		}
+		if strings.HasPrefix(snippet[i:], "</em>") {
+			i += len("</em>")
+			continue
+		}
+		if maxLen > 0 && visible >= maxLen-3 && end-i > 3 {
+			closeMatch()
+			sb.WriteString("...")
+			return sb.String()
+		}
+		sb.WriteByte(snippet[i])
+		visible++
+		i++
+	}
+	closeMatch()
+	return sb.String()
+}
+
+// searchSnippetLines returns the lines of snippet to show with
+// --context-lines: every line containing a match plus context lines either
+// side, as [start, end) byte ranges. Non-adjacent groups are separated by a
+// nil entry, like grep's "--". With no matches, the leading lines are kept.
+func searchSnippetLines(snippet string, matches []SearchMatch, context int) [][]int {
+	var lines [][]int
+	for start := 0; start <= len(snippet); {
+		end := strings.IndexByte(snippet[start:], '\n')
+		if end < 0 {
+			lines = append(lines, []int{start, len(snippet)})
+			break
+		}
+		lines = append(lines, []int{start, start + end})
+		start += end + 1
+	}
+
+	keep := make([]bool, len(lines))
+	found := false
+	for i, line := range lines {
+		for _, m := range matches {
+			if m.Start < line[1] && m.End > line[0] {
+				found = true
+				for j := i - context; j <= i+context; j++ {
+					if j >= 0 && j < len(lines) {
+						keep[j] = true
+					}
+				}
+				break
+			}
+		}
+	}
+	if !found {
+		for j := 0; j <= context && j < len(lines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var out [][]int
+	prev := -1
+	for i, k := range keep {
+		if !k {
+			continue
+		}
+		if prev >= 0 && i != prev+1 {
+			out = append(out, nil)
+		}
+		out = append(out, lines[i])
+		prev = i
+	}
+	return out
+}
+
+// formatSearchResultSnippet renders a result's snippet for text output,
+// honoring --highlight, --context-lines and --no-color. Without them the
+// server's highlights are shown as before.
+func formatSearchResultSnippet(result SearchResult) []string {
+	if !searchHighlight && !searchNoColor && !searchContextLinesSet {
+		return []string{formatSnippet(result.Snippet)}
+	}
+
+	style := searchSnippetStyle{highlight: searchHighlight, noColor: searchNoColor}
+	if !searchContextLinesSet {
+		flat := strings.ReplaceAll(result.Snippet, "\n", " ")
+		return []string{renderSearchSnippet(flat, 0, len(flat), result.Matches, style, searchSnippetMaxLen)}
+	}
+
+	var out []string
+	for _, line := range searchSnippetLines(result.Snippet, result.Matches, searchContextLines) {
+		if line == nil {
+			out = append(out, "--")
+			continue
+		}
+		out = append(out, renderSearchSnippet(result.Snippet, line[0], line[1], result.Matches, style, 0))
+	}
+	return out
+}
+
+// searchColor returns code, or "" when --no-color is set.
+func searchColor(code string) string {
+	if searchNoColor {
+		return ""
+	}
+	return code
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchQueryTerms(t *testing.T) {
+	got := searchQueryTerms(`"renewal terms" budget AND Q4 type:email -draft a`)
+	want := []string{"renewal terms", "budget", "Q4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("searchQueryTerms = %q, want %q", got, want)
+	}
+}
+
+func TestFindSearchMatches(t *testing.T) {
+	snippet := "The <em>budget</em> for Budget review"
+	got := findSearchMatches(snippet, []string{"budget", "review"})
+	want := []SearchMatch{
+		{Start: 8, End: 14, Term: "budget"},
+		{Start: 24, End: 30, Term: "Budget"},
+		{Start: 31, End: 37, Term: "review"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findSearchMatches = %+v, want %+v", got, want)
+	}
+	for _, m := range got {
+		if snippet[m.Start:m.End] != m.Term {
+			t.Errorf("offsets %d-%d select %q, want %q", m.Start, m.End, snippet[m.Start:m.End], m.Term)
+		}
+	}
+
+	if got := findSearchMatches("nothing here", []string{"budget"}); got != nil {
+		t.Errorf("expected no matches, got %+v", got)
+	}
+}
+
+func TestRenderSearchSnippet(t *testing.T) {
+	snippet := "The <em>budget</em> for Budget review"
+	matches := findSearchMatches(snippet, []string{"budget"})
+
+	tests := []struct {
+		name   string
+		style  searchSnippetStyle
+		maxLen int
+		want   string
+	}{
+		{
+			name: "server highlights only",
+			want: "The \033[1;33mbudget\033[0m for Budget review",
+		},
+		{
+			name:  "highlight all matches",
+			style: searchSnippetStyle{highlight: true},
+			want:  "The \033[1mbudget\033[0m for \033[1mBudget\033[0m review",
+		},
+		{
+			name:  "no color",
+			style: searchSnippetStyle{highlight: true, noColor: true},
+			want:  "The budget for Budget review",
+		},
+		{
+			name:   "truncated inside a match",
+			style:  searchSnippetStyle{highlight: true},
+			maxLen: 10,
+			want:   "The \033[1mbud\033[0m...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderSearchSnippet(snippet, 0, len(snippet), matches, tt.style, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("renderSearchSnippet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchSnippetLines(t *testing.T) {
+	snippet := "one\ntwo budget\nthree\nfour\nfive\nsix budget\nseven"
+	matches := findSearchMatches(snippet, []string{"budget"})
+
+	render := func(ranges [][]int) []string {
+		var out []string
+		for _, r := range ranges {
+			if r == nil {
+				out = append(out, "--")
+				continue
+			}
+			out = append(out, snippet[r[0]:r[1]])
+		}
+		return out
+	}
+
+	got := render(searchSnippetLines(snippet, matches, 1))
+	want := []string{"one", "two budget", "three", "--", "five", "six budget", "seven"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("context 1 = %q, want %q", got, want)
+	}
+
+	got = render(searchSnippetLines(snippet, matches, 0))
+	want = []string{"two budget", "--", "six budget"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("context 0 = %q, want %q", got, want)
+	}
+
+	got = render(searchSnippetLines(snippet, nil, 1))
+	want = []string{"one", "two budget"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("no matches = %q, want %q", got, want)
+	}
+}
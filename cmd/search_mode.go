@@ -0,0 +1,51 @@
+package cmd
+
+import "fmt"
+
+// Search mode flags.
+var (
+	searchSemanticWeight    float64
+	searchSemanticWeightSet bool
+)
+
+// hybridSearchWeights converts --semantic-weight into the text and vector
+// weights of a hybrid search request. Both are nil when the weight is unset,
+// leaving the server's default blend.
+func hybridSearchWeights(mode SearchMode, weight float64, set bool) (textWeight, vectorWeight *float32, err error) {
+	if !set {
+		return nil, nil, nil
+	}
+	if mode != SearchModeHybrid {
+		return nil, nil, fmt.Errorf("--semantic-weight only applies to --mode hybrid (got %s)", mode)
+	}
+	if weight < 0 || weight > 1 {
+		return nil, nil, fmt.Errorf("--semantic-weight must be between 0.0 and 1.0, got %g", weight)
+	}
+	vector := float32(weight)
+	text := float32(1 - weight)
+	return &text, &vector, nil
+}
+
+// searchMatchedBy reports which strategy produced a result: the forced mode
+// for keyword and semantic searches, and for hybrid searches whichever
+// component scored, or "hybrid" when both did.
+func searchMatchedBy(mode SearchMode, r SearchResult) SearchMode {
+	if mode != SearchModeHybrid {
+		return mode
+	}
+	switch {
+	case r.TextScore > 0 && r.VectorScore <= 0:
+		return SearchModeKeyword
+	case r.VectorScore > 0 && r.TextScore <= 0:
+		return SearchModeSemantic
+	default:
+		return SearchModeHybrid
+	}
+}
+
+// annotateSearchMatchedBy sets MatchedBy on each result.
+func annotateSearchMatchedBy(mode SearchMode, results []SearchResult) {
+	for i := range results {
+		results[i].MatchedBy = searchMatchedBy(mode, results[i])
+	}
+}
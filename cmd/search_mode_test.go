@@ -0,0 +1,45 @@
+package cmd
+
+import "testing"
+
+func TestHybridSearchWeights(t *testing.T) {
+	text, vector, err := hybridSearchWeights(SearchModeHybrid, 0.5, false)
+	if err != nil || text != nil || vector != nil {
+		t.Fatalf("unset weight should leave the server default, got %v %v %v", text, vector, err)
+	}
+
+	text, vector, err = hybridSearchWeights(SearchModeHybrid, 0.8, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *vector != 0.8 || *text < 0.199 || *text > 0.201 {
+		t.Errorf("got text=%v vector=%v, want 0.2 and 0.8", *text, *vector)
+	}
+
+	if _, _, err := hybridSearchWeights(SearchModeHybrid, 1.5, true); err == nil {
+		t.Error("expected error for weight above 1")
+	}
+	if _, _, err := hybridSearchWeights(SearchModeKeyword, 0.5, true); err == nil {
+		t.Error("expected error for weight outside hybrid mode")
+	}
+}
+
+func TestSearchMatchedBy(t *testing.T) {
+	tests := []struct {
+		mode SearchMode
+		r    SearchResult
+		want SearchMode
+	}{
+		{SearchModeKeyword, SearchResult{VectorScore: 0.9}, SearchModeKeyword},
+		{SearchModeSemantic, SearchResult{TextScore: 0.9}, SearchModeSemantic},
+		{SearchModeHybrid, SearchResult{TextScore: 0.7}, SearchModeKeyword},
+		{SearchModeHybrid, SearchResult{VectorScore: 0.7}, SearchModeSemantic},
+		{SearchModeHybrid, SearchResult{TextScore: 0.4, VectorScore: 0.6}, SearchModeHybrid},
+		{SearchModeHybrid, SearchResult{Score: 0.5}, SearchModeHybrid},
+	}
+	for _, tt := range tests {
+		if got := searchMatchedBy(tt.mode, tt.r); got != tt.want {
+			t.Errorf("searchMatchedBy(%s, text=%.1f vector=%.1f) = %s, want %s", tt.mode, tt.r.TextScore, tt.r.VectorScore, got, tt.want)
+		}
+	}
+}
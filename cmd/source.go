@@ -151,7 +151,7 @@ func newSourceRemoveCommand(deps *SourceCommandDeps) *cobra.Command {
 			return runSourceRemove(cmd.Context(), deps, args[0])
 		},
 	}
-	return cmd
+	return withEnvelope(cmd)
 }
 
 func getTenantIDForSource(deps *SourceCommandDeps) string {
@@ -309,10 +309,20 @@ func runSourceRemove(ctx context.Context, deps *SourceCommandDeps, idStr string)
 		return fmt.Errorf("mapping %d not found or could not be deleted", id)
 	}
 
+	if sourceOutput == "json" {
+		return writeOutcomeJSON(true, SourceMappingActionResult{ID: id, Action: "remove"}, fmt.Sprintf("Removed source mapping %d", id))
+	}
+
 	fmt.Printf("Removed source mapping %d\n", id)
 	return nil
 }
 
+// SourceMappingActionResult is the JSON result of a source remove.
+type SourceMappingActionResult struct {
+	ID     int64  `json:"id"`
+	Action string `json:"action"`
+}
+
 // ==================== Helpers ====================
 
 // resolveProjectID looks up a project by name or parses it as a numeric ID.
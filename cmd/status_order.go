@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+// statusOrder is the lifecycle order status breakdowns are printed in, so
+// the same statuses always appear in the same place.
+var statusOrder = []string{
+	"pending", "queued", "in_progress", "processing", "running",
+	"completed", "complete", "succeeded", "resolved", "skipped",
+	"failed", "rejected", "cancelled", "canceled",
+}
+
+// priorityOrder is the order priority breakdowns are printed in.
+var priorityOrder = []string{"critical", "urgent", "high", "medium", "normal", "low"}
+
+// orderedKeys returns the keys of m with those listed in order first, in
+// that order (case-insensitively), followed by the rest alphabetically.
+// With a nil order the keys are simply sorted.
+func orderedKeys[V any](m map[string]V, order []string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortByOrder(keys, func(k string) string { return k }, order)
+	return keys
+}
+
+// sortByOrder sorts items by the rank of their key in order, then by key.
+func sortByOrder[T any](items []T, key func(T) string, order []string) {
+	rank := func(k string) int {
+		if i := slices.Index(order, strings.ToLower(k)); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		ka, kb := key(a), key(b)
+		if d := rank(ka) - rank(kb); d != 0 {
+			return d
+		}
+		return strings.Compare(ka, kb)
+	})
+}
+
+// SortedStatuses returns the statuses of a status breakdown in lifecycle
+// order (pending, processing, completed, failed, ...), with statuses not in
+// that order following alphabetically.
+func SortedStatuses[V any](m map[string]V) []string {
+	return orderedKeys(m, statusOrder)
+}
+
+// sortedStatusCounts returns a copy of counts in lifecycle order.
+func sortedStatusCounts(counts []*pipelinev1.StatusCount) []*pipelinev1.StatusCount {
+	sorted := slices.Clone(counts)
+	sortByOrder(sorted, (*pipelinev1.StatusCount).GetStatus, statusOrder)
+	return sorted
+}
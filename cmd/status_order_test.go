@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+)
+
+func TestSortedStatuses(t *testing.T) {
+	m := map[string]int64{"failed": 2, "zombie": 1, "completed": 5, "pending": 3, "archived": 1, "Processing": 4, "rejected": 1}
+	want := []string{"pending", "Processing", "completed", "failed", "rejected", "archived", "zombie"}
+	for i := 0; i < 20; i++ {
+		if got := SortedStatuses(m); !slices.Equal(got, want) {
+			t.Fatalf("SortedStatuses = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestOrderedKeys(t *testing.T) {
+	m := map[string]int{"low": 1, "high": 2, "medium": 3, "other": 4}
+	if got, want := orderedKeys(m, priorityOrder), []string{"high", "medium", "low", "other"}; !slices.Equal(got, want) {
+		t.Errorf("orderedKeys(priority) = %v, want %v", got, want)
+	}
+	if got, want := orderedKeys(m, nil), []string{"high", "low", "medium", "other"}; !slices.Equal(got, want) {
+		t.Errorf("orderedKeys(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestSortedStatusCounts(t *testing.T) {
+	counts := []*pipelinev1.StatusCount{
+		{Status: "failed", Count: 1},
+		{Status: "completed", Count: 2},
+		{Status: "pending", Count: 3},
+	}
+	sorted := sortedStatusCounts(counts)
+
+	var got []string
+	for _, sc := range sorted {
+		got = append(got, sc.Status)
+	}
+	if want := []string{"pending", "completed", "failed"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if counts[0].Status != "failed" {
+		t.Error("sortedStatusCounts modified its input")
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	teamsv1 "github.com/otherjamesbrown/penf-cli/api/proto/teams/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -28,14 +27,16 @@ var (
 
 // TeamCommandDeps holds the dependencies for team commands.
 type TeamCommandDeps struct {
-	Config     *config.CLIConfig
-	LoadConfig func() (*config.CLIConfig, error)
+	Config        *config.CLIConfig
+	LoadConfig    func() (*config.CLIConfig, error)
+	InitRelClient func(*config.CLIConfig) (*client.RelationshipClient, error)
 }
 
 // DefaultTeamDeps returns the default dependencies for production use.
 func DefaultTeamDeps() *TeamCommandDeps {
 	return &TeamCommandDeps{
-		LoadConfig: config.LoadConfig,
+		LoadConfig:    config.LoadConfig,
+		InitRelClient: DefaultRelationshipDeps().InitRelClient,
 	}
 }
 
@@ -78,6 +79,10 @@ Examples:
   # Add a member to a team
   penf team add-member "Platform Team" --email john@example.com --role lead
 
+  # Reconcile inferred membership against an HR roster
+  penf team roster "Platform Team"
+  penf team diff "Platform Team" --against-file roster.csv
+
   # Output as JSON for programmatic use
   penf team list --output json
 
@@ -99,6 +104,8 @@ Related Commands:
 	cmd.AddCommand(newTeamAddMemberCommand(deps))
 	cmd.AddCommand(newTeamRemoveMemberCommand(deps))
 	cmd.AddCommand(newTeamMembersCommand(deps))
+	cmd.AddCommand(newTeamRosterCommand(deps))
+	cmd.AddCommand(newTeamDiffCommand(deps))
 
 	return cmd
 }
@@ -169,7 +176,7 @@ Examples:
 
 	cmd.Flags().StringVar(&teamDescription, "description", "", "Team description")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newTeamShowCommand creates the 'team show' subcommand.
@@ -233,7 +240,7 @@ Examples:
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newTeamAddMemberCommand creates the 'team add-member' subcommand.
@@ -272,12 +279,12 @@ Examples:
 	cmd.Flags().StringVar(&teamRole, "role", "member", "Role in the team")
 	cmd.MarkFlagRequired("email")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newTeamRemoveMemberCommand creates the 'team remove-member' subcommand.
 func newTeamRemoveMemberCommand(deps *TeamCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "remove-member <member-id>",
 		Short: "Remove a member from a team",
 		Long: `Remove a member from a team by member ID.
@@ -292,7 +299,7 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTeamRemoveMember(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 // newTeamMembersCommand creates the 'team members' subcommand.
@@ -319,7 +326,7 @@ Examples:
 // ==================== gRPC Connection ====================
 
 func connectTeamToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -344,9 +351,11 @@ func connectTeamToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -431,12 +440,16 @@ func runTeamCreate(ctx context.Context, deps *TeamCommandDeps, name string) erro
 		return fmt.Errorf("creating team: %w", err)
 	}
 
-	fmt.Printf("\033[32mCreated team:\033[0m %s (ID: %d)\n", name, resp.Team.Id)
-	if teamDescription != "" {
-		fmt.Printf("  Description: %s\n", teamDescription)
+	teamDescription = ""
+
+	if getTeamOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Team, "Created team: "+name)
 	}
 
-	teamDescription = ""
+	fmt.Printf("\033[32mCreated team:\033[0m %s (ID: %d)\n", name, resp.Team.Id)
+	if input.Description != "" {
+		fmt.Printf("  Description: %s\n", input.Description)
+	}
 
 	return nil
 }
@@ -529,6 +542,10 @@ func runTeamDelete(ctx context.Context, deps *TeamCommandDeps, identifier string
 		return fmt.Errorf("deleting team: %w", err)
 	}
 
+	if getTeamOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, TeamActionResult{TeamID: team.Id, Team: team.Name, Action: "delete"}, "Deleted team: "+team.Name)
+	}
+
 	fmt.Printf("\033[32mDeleted team:\033[0m %s (ID: %d)\n", team.Name, team.Id)
 	return nil
 }
@@ -562,11 +579,15 @@ func runTeamAddMember(ctx context.Context, deps *TeamCommandDeps, teamName, emai
 		return fmt.Errorf("adding team member: %w", err)
 	}
 
+	teamRole = "member"
+
+	if getTeamOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Member, "Added member to "+teamName)
+	}
+
 	fmt.Printf("\033[32mAdded member:\033[0m %s (%s) to %s as %s (ID: %d)\n",
 		resp.Member.PersonName, email, teamName, resp.Member.Role, resp.Member.Id)
 
-	teamRole = "member"
-
 	return nil
 }
 
@@ -602,6 +623,10 @@ func runTeamRemoveMember(ctx context.Context, deps *TeamCommandDeps, memberIDStr
 		return fmt.Errorf("removing team member: %w", err)
 	}
 
+	if getTeamOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, TeamActionResult{MemberID: memberID, Action: "remove-member"}, fmt.Sprintf("Removed member: ID %d", memberID))
+	}
+
 	fmt.Printf("\033[32mRemoved member:\033[0m ID %d\n", memberID)
 	return nil
 }
@@ -638,6 +663,14 @@ func runTeamMembers(ctx context.Context, deps *TeamCommandDeps, identifier strin
 
 // ==================== Output Functions ====================
 
+// TeamActionResult is the JSON result of a team delete or remove-member.
+type TeamActionResult struct {
+	TeamID   int64  `json:"team_id,omitempty"`
+	Team     string `json:"team,omitempty"`
+	MemberID int64  `json:"member_id,omitempty"`
+	Action   string `json:"action"`
+}
+
 func getTeamOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
 	if teamOutput != "" {
 		return config.OutputFormat(teamOutput)
@@ -801,7 +834,7 @@ func outputTeamJSON(v interface{}) error {
 }
 
 func outputTeamYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -0,0 +1,499 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	relationshipv1 "github.com/otherjamesbrown/penf-cli/api/proto/relationship/v1"
+	teamsv1 "github.com/otherjamesbrown/penf-cli/api/proto/teams/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// RosterMember is a team member inferred from a member_of relationship in
+// the relationship graph.
+type RosterMember struct {
+	EntityID      string  `json:"entity_id" yaml:"entity_id"`
+	Name          string  `json:"name" yaml:"name"`
+	Email         string  `json:"email,omitempty" yaml:"email,omitempty"`
+	Title         string  `json:"title,omitempty" yaml:"title,omitempty"`
+	SeniorityTier *int    `json:"seniority_tier,omitempty" yaml:"seniority_tier,omitempty"`
+	TrustLevel    *int    `json:"trust_level,omitempty" yaml:"trust_level,omitempty"`
+	Confidence    float32 `json:"confidence" yaml:"confidence"`
+}
+
+// TeamRoster is the output of 'team roster'.
+type TeamRoster struct {
+	Team     string         `json:"team" yaml:"team"`
+	EntityID string         `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	Members  []RosterMember `json:"members" yaml:"members"`
+}
+
+// RosterFileEntry is one person listed in an authoritative roster file.
+type RosterFileEntry struct {
+	Line  int    `json:"line" yaml:"line"`
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+}
+
+// RosterMatch pairs a roster file entry with the graph member it matched.
+type RosterMatch struct {
+	Entry   RosterFileEntry `json:"entry" yaml:"entry"`
+	Member  RosterMember    `json:"member" yaml:"member"`
+	MatchBy string          `json:"match_by" yaml:"match_by"`
+}
+
+// TeamRosterDiff is the output of 'team diff'.
+type TeamRosterDiff struct {
+	Team        string            `json:"team" yaml:"team"`
+	File        string            `json:"file" yaml:"file"`
+	Matched     []RosterMatch     `json:"matched" yaml:"matched"`
+	OnlyInFile  []RosterFileEntry `json:"only_in_file" yaml:"only_in_file"`
+	OnlyInGraph []RosterMember    `json:"only_in_graph" yaml:"only_in_graph"`
+}
+
+// relationshipClient returns a relationship client, or an error when the
+// deps have none configured.
+func (d *TeamCommandDeps) relationshipClient(cfg *config.CLIConfig) (*client.RelationshipClient, error) {
+	if d.InitRelClient == nil {
+		return nil, errors.New("relationship service not configured")
+	}
+	return d.InitRelClient(cfg)
+}
+
+// newTeamRosterCommand creates the 'team roster' subcommand.
+func newTeamRosterCommand(deps *TeamCommandDeps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "roster <team>",
+		Short: "List team members inferred from the relationship graph",
+		Long: `List the people the relationship graph links to a team through member_of
+relationships, with their seniority tier and trust level where known.
+
+Unlike 'team members', which lists explicitly assigned members, the roster is
+inferred from content and may include people who have moved on or miss people
+who have just joined. Use 'team diff' to reconcile it against an HR roster.
+
+The identifier can be:
+  - Team name (case-sensitive)
+  - Team ID (numeric)
+
+Examples:
+  penf team roster "Platform Team"
+  penf team roster "Platform Team" --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTeamRoster(cmd.Context(), deps, args[0])
+		},
+	}
+}
+
+// newTeamDiffCommand creates the 'team diff' subcommand.
+func newTeamDiffCommand(deps *TeamCommandDeps) *cobra.Command {
+	var againstFile string
+
+	cmd := &cobra.Command{
+		Use:   "diff <team> --against-file <roster.csv>",
+		Short: "Compare the inferred team roster with a roster file",
+		Long: `Compare the team members inferred from the relationship graph with an
+authoritative roster file, reporting who is in the file but not the graph and
+who is in the graph but not the file.
+
+The roster file is CSV with one person per line. With a header row, the
+"email" and "name" columns are used; without one, a cell containing '@' is
+taken as the email and the first other cell as the name. Blank lines and
+lines starting with '#' are skipped. People are matched by email first, then
+by name (case-insensitive).
+
+Examples:
+  penf team diff "Platform Team" --against-file roster.csv
+  penf team diff "Platform Team" --against-file roster.csv --output json
+
+Roster file:
+  name,email
+  Alice Smith,alice@example.com
+  Bob Jones,bob@example.com`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTeamDiff(cmd.Context(), deps, args[0], againstFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&againstFile, "against-file", "", "Roster CSV to compare against (required)")
+	_ = cmd.MarkFlagRequired("against-file")
+
+	return cmd
+}
+
+func runTeamRoster(ctx context.Context, deps *TeamCommandDeps, identifier string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	roster, err := loadTeamRoster(ctx, deps, cfg, identifier)
+	if err != nil {
+		return err
+	}
+	return outputTeamRoster(cfg, roster)
+}
+
+func runTeamDiff(ctx context.Context, deps *TeamCommandDeps, identifier, path string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	entries, err := readTeamRosterFile(path)
+	if err != nil {
+		return err
+	}
+
+	roster, err := loadTeamRoster(ctx, deps, cfg, identifier)
+	if err != nil {
+		return err
+	}
+
+	diff := diffTeamRoster(entries, roster.Members)
+	diff.Team = roster.Team
+	diff.File = path
+	return outputTeamRosterDiff(cfg, diff)
+}
+
+// loadTeamRoster resolves the team and lists its members in the relationship
+// graph. A team with no matching graph entity has an empty roster.
+func loadTeamRoster(ctx context.Context, deps *TeamCommandDeps, cfg *config.CLIConfig, identifier string) (*TeamRoster, error) {
+	tenantID, err := getTenantIDForTeam(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := connectTeamToGateway(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	resp, err := teamsv1.NewTeamsServiceClient(conn).GetTeam(ctx, &teamsv1.GetTeamRequest{
+		TenantId:   tenantID,
+		Identifier: identifier,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %s", identifier)
+	}
+
+	relClient, err := deps.relationshipClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to relationship service: %w", err)
+	}
+	defer relClient.Close()
+
+	roster := &TeamRoster{Team: resp.Team.Name, Members: []RosterMember{}}
+
+	candidates, _, err := relClient.ListEntities(ctx, &client.ListEntitiesRequest{
+		TenantID:   tenantID,
+		EntityType: relationshipv1.EntityType_ENTITY_TYPE_ORGANIZATION,
+		Search:     resp.Team.Name,
+		PageSize:   50,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding team in relationship graph: %w", err)
+	}
+	entity := matchEntityByName(candidates, resp.Team.Name)
+	if entity == nil {
+		return roster, nil
+	}
+	roster.EntityID = entity.ID
+
+	rels, _, err := relClient.ListRelationships(ctx, &client.ListRelationshipsRequest{
+		TenantID:         tenantID,
+		EntityID:         entity.ID,
+		RelationshipType: stringToRelType("member_of"),
+		PageSize:         500,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing team relationships: %w", err)
+	}
+	roster.Members = rosterMembersFromRelationships(entity.ID, rels)
+	return roster, nil
+}
+
+// rosterMembersFromRelationships returns the person on the far side of each
+// member_of relationship touching teamEntityID, once per person.
+func rosterMembersFromRelationships(teamEntityID string, rels []*client.Relationship) []RosterMember {
+	members := []RosterMember{}
+	seen := map[string]bool{}
+	for _, r := range rels {
+		other := r.SourceEntity
+		if other != nil && other.ID == teamEntityID {
+			other = r.TargetEntity
+		}
+		if other == nil || other.ID == teamEntityID || seen[other.ID] {
+			continue
+		}
+		seen[other.ID] = true
+		members = append(members, RosterMember{
+			EntityID:      other.ID,
+			Name:          other.Name,
+			Email:         entityEmail(other),
+			Title:         other.Metadata["title"],
+			SeniorityTier: metadataInt(other.Metadata, "seniority_tier"),
+			TrustLevel:    metadataInt(other.Metadata, "trust_level"),
+			Confidence:    r.Confidence,
+		})
+	}
+	return members
+}
+
+// entityEmail returns the entity's email from its metadata or, failing
+// that, the first alias that looks like an email address.
+func entityEmail(e *client.RelEntity) string {
+	if email := e.Metadata["email"]; email != "" {
+		return email
+	}
+	for _, alias := range e.Aliases {
+		if strings.Contains(alias, "@") {
+			return alias
+		}
+	}
+	return ""
+}
+
+// metadataInt parses an integer metadata value, returning nil when it is
+// absent or not a number.
+func metadataInt(metadata map[string]string, key string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(metadata[key]))
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// readTeamRosterFile reads a roster CSV of names and emails.
+func readTeamRosterFile(path string) ([]RosterFileEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening roster file: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := parseTeamRoster(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("roster file %s contains no people", path)
+	}
+	return entries, nil
+}
+
+// parseTeamRoster parses roster CSV. A first row naming an "email" or "name"
+// column is a header; otherwise a cell containing '@' is the email and the
+// first other non-empty cell is the name.
+func parseTeamRoster(r io.Reader) ([]RosterFileEntry, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	nameCol, emailCol := -1, -1
+	first := true
+	var entries []RosterFileEntry
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading roster file: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first {
+			first = false
+			for i, cell := range record {
+				switch strings.ToLower(strings.TrimSpace(cell)) {
+				case "name", "full_name", "full name":
+					nameCol = i
+				case "email", "email_address", "email address":
+					emailCol = i
+				}
+			}
+			if nameCol >= 0 || emailCol >= 0 {
+				continue
+			}
+		}
+
+		entry := RosterFileEntry{Line: line}
+		if nameCol >= 0 || emailCol >= 0 {
+			entry.Name = csvCell(record, nameCol)
+			entry.Email = csvCell(record, emailCol)
+		} else {
+			for _, cell := range record {
+				cell = strings.TrimSpace(cell)
+				switch {
+				case cell == "":
+				case strings.Contains(cell, "@"):
+					if entry.Email == "" {
+						entry.Email = cell
+					}
+				case entry.Name == "":
+					entry.Name = cell
+				}
+			}
+		}
+		if entry.Name == "" && entry.Email == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// csvCell returns the trimmed cell at i, or "" when the row is short.
+func csvCell(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// diffTeamRoster matches roster file entries to graph members by email, then
+// by name, and reports what is left over on each side.
+func diffTeamRoster(entries []RosterFileEntry, members []RosterMember) *TeamRosterDiff {
+	diff := &TeamRosterDiff{
+		Matched:     []RosterMatch{},
+		OnlyInFile:  []RosterFileEntry{},
+		OnlyInGraph: []RosterMember{},
+	}
+
+	byEmail := map[string]int{}
+	byName := map[string]int{}
+	for i, m := range members {
+		if m.Email != "" {
+			byEmail[strings.ToLower(m.Email)] = i
+		}
+		if key := normalizeRosterName(m.Name); key != "" {
+			byName[key] = i
+		}
+	}
+
+	used := make([]bool, len(members))
+	for _, e := range entries {
+		i, matchBy := -1, ""
+		if j, ok := byEmail[strings.ToLower(e.Email)]; ok && e.Email != "" && !used[j] {
+			i, matchBy = j, "email"
+		} else if j, ok := byName[normalizeRosterName(e.Name)]; ok && e.Name != "" && !used[j] {
+			i, matchBy = j, "name"
+		}
+		if i < 0 {
+			diff.OnlyInFile = append(diff.OnlyInFile, e)
+			continue
+		}
+		used[i] = true
+		diff.Matched = append(diff.Matched, RosterMatch{Entry: e, Member: members[i], MatchBy: matchBy})
+	}
+
+	for i, m := range members {
+		if !used[i] {
+			diff.OnlyInGraph = append(diff.OnlyInGraph, m)
+		}
+	}
+	return diff
+}
+
+// normalizeRosterName lowercases a name and collapses its whitespace.
+func normalizeRosterName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ==================== Output Functions ====================
+
+func outputTeamRoster(cfg *config.CLIConfig, roster *TeamRoster) error {
+	switch getTeamOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		return outputTeamJSON(roster)
+	case config.OutputFormatYAML:
+		return outputTeamYAML(roster)
+	default:
+		return outputTeamRosterTable(roster)
+	}
+}
+
+func outputTeamRosterTable(roster *TeamRoster) error {
+	if roster.EntityID == "" {
+		fmt.Printf("Team '%s' was not found in the relationship graph\n", roster.Team)
+		return nil
+	}
+	if len(roster.Members) == 0 {
+		fmt.Printf("No inferred members for team '%s'\n", roster.Team)
+		return nil
+	}
+
+	fmt.Printf("Inferred roster for '%s' (%d):\n\n", roster.Team, len(roster.Members))
+	fmt.Println("  NAME                           EMAIL                          SENIORITY  TRUST  CONFIDENCE")
+	fmt.Println("  ----                           -----                          ---------  -----  ----------")
+
+	for _, m := range roster.Members {
+		fmt.Printf("  %-30s %-30s %-10s %-6s %.0f%%\n",
+			teamTruncateString(m.Name, 30),
+			teamTruncateString(m.Email, 30),
+			formatOptionalInt(m.SeniorityTier),
+			formatOptionalInt(m.TrustLevel),
+			m.Confidence*100)
+	}
+
+	fmt.Println()
+	return nil
+}
+
+func outputTeamRosterDiff(cfg *config.CLIConfig, diff *TeamRosterDiff) error {
+	switch getTeamOutputFormat(cfg) {
+	case config.OutputFormatJSON:
+		return outputTeamJSON(diff)
+	case config.OutputFormatYAML:
+		return outputTeamYAML(diff)
+	default:
+		return outputTeamRosterDiffText(diff)
+	}
+}
+
+func outputTeamRosterDiffText(diff *TeamRosterDiff) error {
+	fmt.Printf("Roster diff for '%s' against %s:\n", diff.Team, diff.File)
+	fmt.Printf("  %d matched, %d only in file, %d only in graph\n",
+		len(diff.Matched), len(diff.OnlyInFile), len(diff.OnlyInGraph))
+
+	if len(diff.OnlyInFile) > 0 {
+		fmt.Println("\nIn file but not in graph:")
+		for _, e := range diff.OnlyInFile {
+			fmt.Printf("  + %-30s %-30s (line %d)\n", teamTruncateString(e.Name, 30), teamTruncateString(e.Email, 30), e.Line)
+		}
+	}
+
+	if len(diff.OnlyInGraph) > 0 {
+		fmt.Println("\nIn graph but not in file (possibly stale):")
+		for _, m := range diff.OnlyInGraph {
+			fmt.Printf("  - %-30s %-30s %s\n", teamTruncateString(m.Name, 30), teamTruncateString(m.Email, 30), m.EntityID)
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// formatOptionalInt renders n, or "-" when it is unknown.
+func formatOptionalInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
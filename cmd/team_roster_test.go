@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestParseTeamRoster(t *testing.T) {
+	t.Run("header", func(t *testing.T) {
+		entries, err := parseTeamRoster(strings.NewReader("email,name,role\n# comment\nalice@example.com,Alice Smith,lead\n\n,Bob Jones,\n"))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, RosterFileEntry{Line: 3, Name: "Alice Smith", Email: "alice@example.com"}, entries[0])
+		assert.Equal(t, "Bob Jones", entries[1].Name)
+		assert.Empty(t, entries[1].Email)
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		entries, err := parseTeamRoster(strings.NewReader("Alice Smith,alice@example.com\nbob@example.com\n"))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, RosterFileEntry{Line: 1, Name: "Alice Smith", Email: "alice@example.com"}, entries[0])
+		assert.Equal(t, RosterFileEntry{Line: 2, Email: "bob@example.com"}, entries[1])
+	})
+}
+
+func TestRosterMembersFromRelationships(t *testing.T) {
+	team := &client.RelEntity{ID: "team-1", Name: "Platform Team"}
+	alice := &client.RelEntity{
+		ID:       "p-1",
+		Name:     "Alice Smith",
+		Aliases:  []string{"Al", "alice@example.com"},
+		Metadata: map[string]string{"seniority_tier": "5", "trust_level": "4"},
+	}
+	bob := &client.RelEntity{ID: "p-2", Name: "Bob Jones", Metadata: map[string]string{"email": "bob@example.com", "trust_level": "n/a"}}
+
+	members := rosterMembersFromRelationships("team-1", []*client.Relationship{
+		{SourceEntity: alice, TargetEntity: team, Confidence: 0.9},
+		{SourceEntity: team, TargetEntity: bob, Confidence: 0.6},
+		{SourceEntity: alice, TargetEntity: team, Confidence: 0.5},
+	})
+	require.Len(t, members, 2)
+
+	assert.Equal(t, "alice@example.com", members[0].Email)
+	require.NotNil(t, members[0].SeniorityTier)
+	assert.Equal(t, 5, *members[0].SeniorityTier)
+	require.NotNil(t, members[0].TrustLevel)
+	assert.Equal(t, 4, *members[0].TrustLevel)
+	assert.InDelta(t, 0.9, members[0].Confidence, 0.001)
+
+	assert.Equal(t, "bob@example.com", members[1].Email)
+	assert.Nil(t, members[1].TrustLevel, "non-numeric metadata is ignored")
+}
+
+func TestDiffTeamRoster(t *testing.T) {
+	members := []RosterMember{
+		{EntityID: "p-1", Name: "Alice Smith", Email: "alice@example.com"},
+		{EntityID: "p-2", Name: "Bob  Jones"},
+		{EntityID: "p-3", Name: "Carol White", Email: "carol@example.com"},
+	}
+	entries := []RosterFileEntry{
+		{Line: 1, Name: "A. Smith", Email: "ALICE@example.com"},
+		{Line: 2, Name: "bob jones"},
+		{Line: 3, Name: "Dan Brown", Email: "dan@example.com"},
+	}
+
+	diff := diffTeamRoster(entries, members)
+	require.Len(t, diff.Matched, 2)
+	assert.Equal(t, "email", diff.Matched[0].MatchBy)
+	assert.Equal(t, "p-1", diff.Matched[0].Member.EntityID)
+	assert.Equal(t, "name", diff.Matched[1].MatchBy)
+	assert.Equal(t, "p-2", diff.Matched[1].Member.EntityID)
+
+	require.Len(t, diff.OnlyInFile, 1)
+	assert.Equal(t, "Dan Brown", diff.OnlyInFile[0].Name)
+	require.Len(t, diff.OnlyInGraph, 1)
+	assert.Equal(t, "p-3", diff.OnlyInGraph[0].EntityID)
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/metadata"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -42,6 +41,14 @@ type TenantInfo struct {
 	IsCurrent   bool      `json:"is_current" yaml:"is_current"`
 }
 
+// TenantSwitchResult is the JSON result of 'tenant switch'.
+type TenantSwitchResult struct {
+	TenantID string `json:"tenant_id"`
+	UUID     string `json:"uuid,omitempty"`
+	Profile  string `json:"profile,omitempty"`
+	Alias    string `json:"alias,omitempty"`
+}
+
 // TenantListResponse represents the response from listing tenants.
 type TenantListResponse struct {
 	Tenants    []TenantInfo `json:"tenants" yaml:"tenants"`
@@ -72,11 +79,13 @@ func DefaultDeps() *TenantCommandDeps {
 		LoadConfig: config.LoadConfig,
 		SaveConfig: config.SaveConfig,
 		InitTenantClient: func(cfg *config.CLIConfig) (*client.TenantClient, error) {
-			opts := &client.ClientOptions{
-				Insecure:       cfg.Insecure,
-				Debug:          cfg.Debug,
-				ConnectTimeout: cfg.Timeout,
-			}
+			opts := client.DefaultOptions()
+			opts.Insecure = cfg.Insecure
+			opts.Debug = cfg.Debug
+			opts.DebugTrace = cfg.DebugTrace
+			opts.ConnectTimeout = cfg.GetConnectTimeout()
+			opts.RequestTimeout = cfg.Timeout
+			opts.ApplyConnectionConfig(cfg)
 
 			// Load TLS config if not in insecure mode.
 			if !cfg.Insecure {
@@ -88,7 +97,7 @@ func DefaultDeps() *TenantCommandDeps {
 			}
 
 			tenantClient := client.NewTenantClient(cfg.ServerAddress, opts)
-			ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+			ctx, cancel := context.WithTimeout(context.Background(), opts.ConnectTimeout)
 			defer cancel()
 
 			if err := tenantClient.Connect(ctx); err != nil {
@@ -211,7 +220,7 @@ Example:
 
 	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "Skip tenant access validation")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newTenantCurrentCommand creates the 'tenant current' subcommand.
@@ -221,7 +230,8 @@ func newTenantCurrentCommand(deps *TenantCommandDeps) *cobra.Command {
 		Short: "Show current tenant",
 		Long: `Show the currently active tenant context.
 
-Displays the tenant ID from the configuration file or environment variable.
+Displays the tenant ID from the configuration file or environment variable,
+with its UUID, alias and the active profile when known.
 The environment variable PENF_TENANT_ID takes precedence over the config file.`,
 		Aliases: []string{"whoami"},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -306,7 +316,7 @@ func runTenantList(ctx context.Context, deps *TenantCommandDeps, insecureFlag bo
 			Description: t.Description,
 			CreatedAt:   t.CreatedAt,
 			Status:      status,
-			IsCurrent:   t.Slug == currentTenantID,
+			IsCurrent:   isCurrentTenant(cfg, currentTenantID, t),
 		}
 	}
 
@@ -363,17 +373,23 @@ func runTenantSwitch(ctx context.Context, deps *TenantCommandDeps, tenantRef str
 		return fmt.Errorf("saving configuration: %w", err)
 	}
 
-	fmt.Printf("Switched to tenant: %s\n", tenantID)
+	w := resultOut()
+	fmt.Fprintf(w, "Switched to tenant: %s\n", tenantID)
 	if tenantUUID != "" {
-		fmt.Printf("  UUID: %s\n", tenantUUID)
+		fmt.Fprintf(w, "  UUID: %s\n", tenantUUID)
+	}
+	if cfg.ActiveProfile != "" {
+		fmt.Fprintf(w, "  Profile: %s\n", cfg.ActiveProfile)
 	}
 
 	// Show alias if it was used.
+	result := TenantSwitchResult{TenantID: tenantID, UUID: tenantUUID, Profile: cfg.ActiveProfile}
 	if tenantRef != tenantID {
-		fmt.Printf("  (alias: %s)\n", tenantRef)
+		fmt.Fprintf(w, "  (alias: %s)\n", tenantRef)
+		result.Alias = tenantRef
 	}
 
-	return nil
+	return writeResult(result, "Switched to tenant: "+tenantID)
 }
 
 // runTenantCurrent executes the tenant current command.
@@ -400,28 +416,47 @@ func runTenantCurrent(deps *TenantCommandDeps, cmd *cobra.Command) error {
 		source = "environment variable (PENF_TENANT_ID)"
 	}
 
+	// The configured UUID only describes the configured tenant, not one
+	// overridden by the environment.
+	var tenantUUID string
+	if currentTenantID == cfg.TenantID {
+		tenantUUID = cfg.TenantUUID
+	}
+	alias := findTenantAlias(cfg, currentTenantID)
+
 	switch cfg.OutputFormat {
-	case config.OutputFormatJSON:
+	case config.OutputFormatJSON, config.OutputFormatYAML:
 		output := map[string]string{
 			"tenant_id": currentTenantID,
 			"source":    source,
 		}
+		if tenantUUID != "" {
+			output["tenant_uuid"] = tenantUUID
+		}
+		if alias != "" {
+			output["alias"] = alias
+		}
+		if cfg.ActiveProfile != "" {
+			output["profile"] = cfg.ActiveProfile
+		}
+		if cfg.OutputFormat == config.OutputFormatYAML {
+			return NewYAMLEncoder(os.Stdout).Encode(output)
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(output)
-	case config.OutputFormatYAML:
-		output := map[string]string{
-			"tenant_id": currentTenantID,
-			"source":    source,
-		}
-		enc := yaml.NewEncoder(os.Stdout)
-		return enc.Encode(output)
 	default:
 		fmt.Printf("Current tenant: %s\n", currentTenantID)
+		if tenantUUID != "" {
+			fmt.Printf("  UUID: %s\n", tenantUUID)
+		}
 		fmt.Printf("  Source: %s\n", source)
+		if cfg.ActiveProfile != "" {
+			fmt.Printf("  Profile: %s\n", cfg.ActiveProfile)
+		}
 
 		// Show alias if there is one.
-		if alias := findTenantAlias(cfg, currentTenantID); alias != "" {
+		if alias != "" {
 			fmt.Printf("  Alias: %s\n", alias)
 		}
 	}
@@ -495,6 +530,16 @@ func getCurrentTenantID(cfg *config.CLIConfig) string {
 	return cfg.TenantID
 }
 
+// isCurrentTenant reports whether t is the current tenant, which may be
+// configured by slug, UUID or alias.
+func isCurrentTenant(cfg *config.CLIConfig, currentTenantID string, t *client.Tenant) bool {
+	if currentTenantID == "" {
+		return false
+	}
+	ref := resolveTenantAlias(cfg, currentTenantID)
+	return ref == t.Slug || ref == t.ID
+}
+
 // resolveTenantAlias resolves a tenant reference to its actual ID.
 // If the reference is an alias in the config, returns the mapped ID.
 // Otherwise returns the reference as-is.
@@ -618,7 +663,7 @@ func outputTenantList(format config.OutputFormat, response TenantListResponse) e
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputTenantListText(response)
@@ -674,7 +719,7 @@ func outputTenantDetail(format config.OutputFormat, info TenantInfo) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(info)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(info)
 	default:
 		return outputTenantDetailText(info)
@@ -732,7 +777,7 @@ Examples:
 	cmd.Flags().StringVar(&description, "description", "", "Tenant description")
 	_ = cmd.MarkFlagRequired("name")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // runTenantCreate executes the tenant create command.
@@ -768,15 +813,26 @@ func runTenantCreate(ctx context.Context, deps *TenantCommandDeps, name, slug, d
 		return fmt.Errorf("creating tenant: %w", err)
 	}
 
-	fmt.Printf("Created tenant:\n")
-	fmt.Printf("  ID:   %s\n", tenant.ID)
-	fmt.Printf("  Slug: %s\n", tenant.Slug)
-	fmt.Printf("  Name: %s\n", tenant.Name)
+	w := resultOut()
+	fmt.Fprintf(w, "Created tenant:\n")
+	fmt.Fprintf(w, "  ID:   %s\n", tenant.ID)
+	fmt.Fprintf(w, "  Slug: %s\n", tenant.Slug)
+	fmt.Fprintf(w, "  Name: %s\n", tenant.Name)
 	if tenant.Description != "" {
-		fmt.Printf("  Description: %s\n", tenant.Description)
+		fmt.Fprintf(w, "  Description: %s\n", tenant.Description)
 	}
 
-	return nil
+	status := "inactive"
+	if tenant.IsActive {
+		status = "active"
+	}
+	return writeResult(TenantInfo{
+		ID:          tenant.ID,
+		Name:        tenant.Name,
+		Description: tenant.Description,
+		CreatedAt:   tenant.CreatedAt,
+		Status:      status,
+	}, "Created tenant: "+tenant.Slug)
 }
 
 // truncateString truncates a string to the given length with ellipsis.
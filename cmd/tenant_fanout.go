@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// ReadOnlyAnnotation marks a command as read-only. Only read-only commands
+// may take --all-tenants.
+const ReadOnlyAnnotation = "penf.read-only"
+
+// allTenantsConcurrency bounds the number of tenants queried at once.
+const allTenantsConcurrency = 4
+
+// AddAllTenantsFlag adds --all-tenants to a read-only command. It panics if
+// the command is not annotated with ReadOnlyAnnotation, so the flag can never
+// be wired into a command that changes anything.
+func AddAllTenantsFlag(cmd *cobra.Command, target *bool) {
+	if cmd.Annotations[ReadOnlyAnnotation] != "true" {
+		panic(fmt.Sprintf("--all-tenants added to %q, which is not annotated read-only", cmd.Name()))
+	}
+	cmd.Flags().BoolVar(target, "all-tenants", false, "Run for every active tenant and show a per-tenant rollup with totals")
+}
+
+// TenantRollupRow is one tenant's values in an --all-tenants rollup.
+type TenantRollupRow struct {
+	TenantID string           `json:"tenant_id" yaml:"tenant_id"`
+	Slug     string           `json:"slug" yaml:"slug"`
+	Name     string           `json:"name" yaml:"name"`
+	Values   map[string]int64 `json:"values,omitempty" yaml:"values,omitempty"`
+	Error    string           `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// TenantRollup is the output of a command run with --all-tenants.
+type TenantRollup struct {
+	Columns []string          `json:"columns" yaml:"columns"`
+	Tenants []TenantRollupRow `json:"tenants" yaml:"tenants"`
+	Total   map[string]int64  `json:"total" yaml:"total"`
+	Failed  int               `json:"failed" yaml:"failed"`
+}
+
+// ListAllTenants returns every active tenant visible to the caller.
+func ListAllTenants(ctx context.Context, cfg *config.CLIConfig) ([]*client.Tenant, error) {
+	deps := DefaultDeps()
+	tenantClient, err := deps.InitTenantClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer tenantClient.Close()
+
+	active := true
+	var tenants []*client.Tenant
+	for {
+		page, total, err := deps.ListTenants(ctx, tenantClient, &client.ListTenantsRequest{
+			IsActive: &active,
+			Limit:    100,
+			Offset:   int32(len(tenants)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing tenants: %w", err)
+		}
+		tenants = append(tenants, page...)
+		if len(page) == 0 || int64(len(tenants)) >= total {
+			return tenants, nil
+		}
+	}
+}
+
+// FanOutTenants calls query for each tenant, at most allTenantsConcurrency at
+// a time, and collects the results into a rollup in tenant order. A tenant
+// whose query fails is reported with its error and left out of the totals.
+func FanOutTenants(ctx context.Context, tenants []*client.Tenant, columns []string, query func(ctx context.Context, tenantID string) (map[string]int64, error)) *TenantRollup {
+	rollup := &TenantRollup{
+		Columns: columns,
+		Tenants: make([]TenantRollupRow, len(tenants)),
+		Total:   make(map[string]int64, len(columns)),
+	}
+
+	sem := make(chan struct{}, allTenantsConcurrency)
+	var wg sync.WaitGroup
+	for i, t := range tenants {
+		rollup.Tenants[i] = TenantRollupRow{TenantID: t.ID, Slug: t.Slug, Name: t.Name}
+		wg.Add(1)
+		go func(row *TenantRollupRow) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			values, err := query(ctx, row.TenantID)
+			if err != nil {
+				row.Error = err.Error()
+				return
+			}
+			row.Values = values
+		}(&rollup.Tenants[i])
+	}
+	wg.Wait()
+
+	for _, row := range rollup.Tenants {
+		if row.Error != "" {
+			rollup.Failed++
+			continue
+		}
+		for _, c := range columns {
+			rollup.Total[c] += row.Values[c]
+		}
+	}
+	return rollup
+}
+
+// OutputTenantRollup writes a rollup in the given format.
+func OutputTenantRollup(w io.Writer, format config.OutputFormat, rollup *TenantRollup) error {
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rollup)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(w).Encode(rollup)
+	}
+
+	fmt.Fprintf(w, "%-24s", "TENANT")
+	for _, c := range rollup.Columns {
+		fmt.Fprintf(w, " %14s", strings.ToUpper(c))
+	}
+	fmt.Fprintln(w)
+
+	for _, row := range rollup.Tenants {
+		name := row.Slug
+		if name == "" {
+			name = row.TenantID
+		}
+		fmt.Fprintf(w, "%-24s", truncateString(name, 24))
+		if row.Error != "" {
+			fmt.Fprintf(w, " \033[31merror: %s\033[0m\n", row.Error)
+			continue
+		}
+		for _, c := range rollup.Columns {
+			fmt.Fprintf(w, " %14s", formatNumber(row.Values[c]))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\033[1m%-24s", "TOTAL")
+	for _, c := range rollup.Columns {
+		fmt.Fprintf(w, " %14s", formatNumber(rollup.Total[c]))
+	}
+	fmt.Fprintln(w, "\033[0m")
+
+	fmt.Fprintf(w, "\n%d tenant(s)", len(rollup.Tenants))
+	if rollup.Failed > 0 {
+		fmt.Fprintf(w, ", \033[31m%d failed\033[0m (excluded from totals)", rollup.Failed)
+	}
+	fmt.Fprintln(w)
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func TestFanOutTenants(t *testing.T) {
+	var tenants []*client.Tenant
+	for i := 0; i < 10; i++ {
+		tenants = append(tenants, &client.Tenant{ID: fmt.Sprintf("t-%d", i), Slug: fmt.Sprintf("tenant-%d", i)})
+	}
+
+	var running, maxRunning int32
+	rollup := FanOutTenants(context.Background(), tenants, []string{"pending"}, func(ctx context.Context, tenantID string) (map[string]int64, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if tenantID == "t-3" {
+			return nil, fmt.Errorf("unavailable")
+		}
+		return map[string]int64{"pending": 2}, nil
+	})
+
+	assert.LessOrEqual(t, int(maxRunning), allTenantsConcurrency)
+	require.Len(t, rollup.Tenants, 10)
+	for i, row := range rollup.Tenants {
+		assert.Equal(t, fmt.Sprintf("t-%d", i), row.TenantID, "rows keep tenant order")
+	}
+	assert.Equal(t, "unavailable", rollup.Tenants[3].Error)
+	assert.Equal(t, 1, rollup.Failed)
+	assert.Equal(t, int64(18), rollup.Total["pending"])
+}
+
+func TestOutputTenantRollup_Text(t *testing.T) {
+	rollup := &TenantRollup{
+		Columns: []string{"pending"},
+		Tenants: []TenantRollupRow{
+			{TenantID: "t-1", Slug: "acme", Values: map[string]int64{"pending": 1200}},
+			{TenantID: "t-2", Slug: "globex", Error: "unavailable"},
+		},
+		Total:  map[string]int64{"pending": 1200},
+		Failed: 1,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, OutputTenantRollup(&buf, config.OutputFormatText, rollup))
+	out := buf.String()
+	assert.Contains(t, out, "PENDING")
+	assert.Contains(t, out, "acme")
+	assert.Contains(t, out, "1,200")
+	assert.Contains(t, out, "error: unavailable")
+	assert.Contains(t, out, "TOTAL")
+	assert.Contains(t, out, "1 failed")
+}
+
+func TestPipelineStatusAllTenants_YAML(t *testing.T) {
+	cmd := newPipelineStatusCmd(&PipelineCommandDeps{
+		LoadConfig: func() (*config.CLIConfig, error) {
+			return nil, fmt.Errorf("stop after format check")
+		},
+	})
+
+	cmd.SetArgs([]string{"--all-tenants", "-o", "yaml"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop after format check", "yaml output should be accepted")
+
+	cmd.SetArgs([]string{"--all-tenants", "-o", "table"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
+
+func TestAddAllTenantsFlag_RequiresReadOnly(t *testing.T) {
+	var all bool
+	assert.Panics(t, func() {
+		AddAllTenantsFlag(&cobra.Command{Use: "delete"}, &all)
+	})
+
+	readOnly := &cobra.Command{Use: "list", Annotations: map[string]string{ReadOnlyAnnotation: "true"}}
+	AddAllTenantsFlag(readOnly, &all)
+	assert.NotNil(t, readOnly.Flags().Lookup("all-tenants"))
+}
+
+// TestAllTenantsFlag_OnlyOnReadOnlyCommands checks that every command with
+// --all-tenants is annotated read-only.
+func TestAllTenantsFlag_OnlyOnReadOnlyCommands(t *testing.T) {
+	var walk func(c *cobra.Command) []string
+	walk = func(c *cobra.Command) []string {
+		var found []string
+		if c.Flags().Lookup("all-tenants") != nil {
+			assert.Equal(t, "true", c.Annotations[ReadOnlyAnnotation], "%s has --all-tenants but is not read-only", c.CommandPath())
+			found = append(found, c.CommandPath())
+		}
+		for _, sub := range c.Commands() {
+			found = append(found, walk(sub)...)
+		}
+		return found
+	}
+
+	found := walk(NewReviewCommand(nil))
+	found = append(found, walk(NewPipelineCommand(nil))...)
+	assert.ElementsMatch(t, []string{"review queue", "pipeline status"}, found)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// expectedTenant is set by the root --expect-tenant flag.
+var expectedTenant string
+
+// SetExpectedTenant makes destructive commands abort unless the tenant they
+// are about to act on is the given tenant (ID, UUID or alias).
+func SetExpectedTenant(tenant string) {
+	expectedTenant = tenant
+}
+
+// guardDestructiveTenant shows which tenant a destructive operation is about
+// to act on and enforces the tenant guards. It must be called before any
+// confirmation prompt and before anything is changed.
+func guardDestructiveTenant(cfg *config.CLIConfig, tenantID string) error {
+	return checkDestructiveTenant(os.Stderr, os.Stdin, isInteractiveStdin(), cfg, tenantID)
+}
+
+// checkDestructiveTenant prints the tenant banner to w, then:
+//   - with --expect-tenant, aborts unless it names tenantID;
+//   - with confirm_tenant set and no matching --expect-tenant, asks for the
+//     tenant to be typed on in, and aborts if it is not.
+func checkDestructiveTenant(w io.Writer, in io.Reader, interactive bool, cfg *config.CLIConfig, tenantID string) error {
+	if tenantID == "" {
+		// The gateway applies its default tenant, which can't be checked here.
+		if expectedTenant != "" || cfg.ConfirmTenant {
+			return fmt.Errorf("tenant guard: no tenant configured to check; set via --tenant flag or 'penf config set tenant_id <id>'")
+		}
+		return nil
+	}
+	names := tenantNames(cfg, tenantID)
+
+	// Highlight the tenant only on a terminal, so redirected stderr and CI
+	// logs don't collect escape codes.
+	if isTerminalWriter(w) {
+		fmt.Fprintf(w, "\033[1;33mTenant: %s\033[0m", names[0])
+	} else {
+		fmt.Fprintf(w, "Tenant: %s", names[0])
+	}
+	if len(names) > 1 {
+		fmt.Fprintf(w, " (%s)", strings.Join(names[1:], ", "))
+	}
+	fmt.Fprintf(w, "  server: %s", cfg.ServerAddress)
+	if cfg.ActiveProfile != "" {
+		fmt.Fprintf(w, "  profile: %s", cfg.ActiveProfile)
+	}
+	fmt.Fprintln(w)
+
+	if expectedTenant != "" {
+		if !slices.Contains(names, expectedTenant) {
+			return fmt.Errorf("tenant guard: this command would act on tenant %s, not %s (--expect-tenant)", names[0], expectedTenant)
+		}
+		return nil
+	}
+
+	if !cfg.ConfirmTenant {
+		return nil
+	}
+	if !interactive {
+		return fmt.Errorf("confirm_tenant is set: pass --expect-tenant %s to confirm the tenant non-interactively", names[0])
+	}
+	fmt.Fprintf(w, "Type the tenant (%s) to continue: ", names[0])
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	if !slices.Contains(names, strings.TrimSpace(line)) {
+		return fmt.Errorf("tenant not confirmed; nothing was changed")
+	}
+	return nil
+}
+
+// tenantNames returns the names that identify tenantID, the most readable
+// first: the configured slug and UUID when tenantID is the configured tenant,
+// and any aliases.
+func tenantNames(cfg *config.CLIConfig, tenantID string) []string {
+	var names []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	if tenantID == cfg.TenantID || tenantID == cfg.TenantUUID {
+		add(cfg.TenantID)
+		add(cfg.TenantUUID)
+	}
+	add(tenantID)
+	for _, id := range append([]string(nil), names...) {
+		add(findTenantAlias(cfg, id))
+	}
+	return names
+}
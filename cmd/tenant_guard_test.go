@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+func tenantGuardConfig() *config.CLIConfig {
+	return &config.CLIConfig{
+		ServerAddress: "gateway.prod:50051",
+		TenantID:      "acme",
+		TenantUUID:    "3f2a0000-0000-0000-0000-000000000001",
+		TenantAliases: map[string]string{"work": "acme"},
+		ActiveProfile: "prod",
+	}
+}
+
+func TestCheckDestructiveTenant_Banner(t *testing.T) {
+	defer SetExpectedTenant("")
+	SetExpectedTenant("")
+
+	var buf bytes.Buffer
+	cfg := tenantGuardConfig()
+	if err := checkDestructiveTenant(&buf, strings.NewReader(""), false, cfg, cfg.EffectiveTenantID()); err != nil {
+		t.Fatalf("checkDestructiveTenant() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{"Tenant: acme", "(3f2a0000-0000-0000-0000-000000000001, work)", "server: gateway.prod:50051", "profile: prod"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("banner missing %q: %q", want, got)
+		}
+	}
+	if strings.Contains(got, "\033[") {
+		t.Errorf("banner written to a non-terminal contains ANSI codes: %q", got)
+	}
+}
+
+func TestCheckDestructiveTenant_ExpectTenant(t *testing.T) {
+	defer SetExpectedTenant("")
+	cfg := tenantGuardConfig()
+
+	for _, expect := range []string{"acme", "3f2a0000-0000-0000-0000-000000000001", "work"} {
+		SetExpectedTenant(expect)
+		if err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader(""), false, cfg, cfg.EffectiveTenantID()); err != nil {
+			t.Errorf("--expect-tenant %s: error = %v, want match", expect, err)
+		}
+	}
+
+	SetExpectedTenant("globex")
+	err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader(""), false, cfg, cfg.EffectiveTenantID())
+	if err == nil || !strings.Contains(err.Error(), "would act on tenant acme, not globex") {
+		t.Errorf("mismatched --expect-tenant: error = %v", err)
+	}
+
+	// A --tenant override is a different tenant from the configured one.
+	SetExpectedTenant("acme")
+	if err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader(""), false, cfg, "globex"); err == nil {
+		t.Error("expected the guard to reject an overridden tenant")
+	}
+}
+
+func TestCheckDestructiveTenant_ConfirmTenant(t *testing.T) {
+	defer SetExpectedTenant("")
+	SetExpectedTenant("")
+	cfg := tenantGuardConfig()
+	cfg.ConfirmTenant = true
+
+	if err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader("acme\n"), true, cfg, cfg.EffectiveTenantID()); err != nil {
+		t.Errorf("typed tenant: error = %v", err)
+	}
+	if err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader("globex\n"), true, cfg, cfg.EffectiveTenantID()); err == nil {
+		t.Error("expected a wrongly typed tenant to abort")
+	}
+	err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader(""), false, cfg, cfg.EffectiveTenantID())
+	if err == nil || !strings.Contains(err.Error(), "--expect-tenant acme") {
+		t.Errorf("non-interactive: error = %v, want a hint to use --expect-tenant", err)
+	}
+
+	SetExpectedTenant("acme")
+	if err := checkDestructiveTenant(&bytes.Buffer{}, strings.NewReader(""), false, cfg, cfg.EffectiveTenantID()); err != nil {
+		t.Errorf("--expect-tenant should confirm non-interactively: error = %v", err)
+	}
+}
@@ -740,6 +740,44 @@ func TestRunTenantCurrent_NoTenant(t *testing.T) {
 	}
 }
 
+func TestRunTenantCurrent_ShowsUUIDAndProfile(t *testing.T) {
+	cfg := mockConfig()
+	cfg.TenantID = "tenant-acme-002"
+	cfg.TenantUUID = "3f2a0000-0000-0000-0000-000000000002"
+	cfg.ActiveProfile = "prod"
+	deps := createTestDeps(cfg)
+
+	os.Unsetenv("PENF_TENANT_ID")
+
+	output := captureStdout(func() {
+		if err := runTenantCurrent(deps, nil); err != nil {
+			t.Fatalf("runTenantCurrent failed: %v", err)
+		}
+	})
+
+	for _, want := range []string{"UUID: 3f2a0000-0000-0000-0000-000000000002", "Profile: prod", "Alias: work"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestIsCurrentTenant(t *testing.T) {
+	cfg := mockConfig()
+	tenant := &client.Tenant{ID: "3f2a0000-0000-0000-0000-000000000002", Slug: "tenant-acme-002"}
+
+	for _, current := range []string{"tenant-acme-002", "3f2a0000-0000-0000-0000-000000000002", "work"} {
+		if !isCurrentTenant(cfg, current, tenant) {
+			t.Errorf("isCurrentTenant(%q) = false, want true", current)
+		}
+	}
+	for _, current := range []string{"", "tenant-default-001", "personal"} {
+		if isCurrentTenant(cfg, current, tenant) {
+			t.Errorf("isCurrentTenant(%q) = true, want false", current)
+		}
+	}
+}
+
 func TestRunTenantSwitch(t *testing.T) {
 	cfg := mockConfig()
 	cfg.TenantID = "old-tenant"
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
 	threadsv1 "github.com/otherjamesbrown/penf-cli/api/proto/threads/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -29,6 +30,10 @@ var (
 type ThreadCommandDeps struct {
 	Config     *config.CLIConfig
 	LoadConfig func() (*config.CLIConfig, error)
+
+	// GenerateSummary sends a summary request to the AI service. Nil uses
+	// generateThreadSummary.
+	GenerateSummary func(ctx context.Context, cfg *config.CLIConfig, req *aiv1.SummaryRequest) (*aiv1.SummaryResponse, error)
 }
 
 // DefaultThreadDeps returns the default dependencies for production use.
@@ -52,19 +57,22 @@ func NewThreadCommand(deps *ThreadCommandDeps) *cobra.Command {
 Threads group related email messages by conversation, tracking participants,
 subjects, and temporal sequence.
 
-This command provides two main operations:
+This command provides three main operations:
 
-  list      List threads with pagination
-  show      Show detailed thread view with all messages
+  list       List threads with pagination
+  show       Show detailed thread view with all messages
+  summarize  Summarize a thread into key points, decisions and action items
 
 Examples:
   penf thread list --limit 10
   penf thread show 42
+  penf thread summarize 42 --focus "launch date"
   penf thread list -o json`,
 	}
 
 	cmd.AddCommand(newThreadListCommand(deps))
 	cmd.AddCommand(newThreadShowCommand(deps))
+	cmd.AddCommand(newThreadSummarizeCommand(deps))
 
 	return cmd
 }
@@ -135,7 +143,7 @@ Examples:
 
 // connectThreadsToGateway creates a gRPC connection to the gateway service.
 func connectThreadsToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -158,9 +166,11 @@ func connectThreadsToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
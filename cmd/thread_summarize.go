@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	aiv1 "github.com/otherjamesbrown/penf-cli/api/proto/ai/v1"
+	threadsv1 "github.com/otherjamesbrown/penf-cli/api/proto/threads/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Thread summarize flags.
+var (
+	threadMaxLength int
+	threadFocus     string
+	threadModel     string
+)
+
+const (
+	// threadTranscriptMaxChars bounds the transcript sent to the LLM so long
+	// threads stay inside the model's context window.
+	threadTranscriptMaxChars = 24000
+	// threadSummaryTimeout bounds a single summarization request.
+	threadSummaryTimeout = 2 * time.Minute
+)
+
+// ThreadActionItem is a follow-up task extracted from a thread.
+type ThreadActionItem struct {
+	Task  string `json:"task" yaml:"task"`
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Due   string `json:"due,omitempty" yaml:"due,omitempty"`
+}
+
+// ThreadSummary is the output of 'thread summarize'.
+type ThreadSummary struct {
+	ThreadID     int64              `json:"thread_id" yaml:"thread_id"`
+	Subject      string             `json:"subject" yaml:"subject"`
+	MessageCount int32              `json:"message_count" yaml:"message_count"`
+	Focus        string             `json:"focus,omitempty" yaml:"focus,omitempty"`
+	Summary      string             `json:"summary" yaml:"summary"`
+	KeyPoints    []string           `json:"key_points" yaml:"key_points"`
+	Decisions    []string           `json:"decisions" yaml:"decisions"`
+	ActionItems  []ThreadActionItem `json:"action_items" yaml:"action_items"`
+}
+
+// newThreadSummarizeCommand creates the 'thread summarize' subcommand.
+func newThreadSummarizeCommand(deps *ThreadCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summarize <thread-id>",
+		Short: "Summarize a thread into key points, decisions and action items",
+		Long: `Summarize a thread with the AI service.
+
+The thread's messages are sent, oldest first, through the gateway to the AI
+service, using --model or the configured default model. The summary has four
+sections: an overview, key points, decisions, and action items with owners and
+due dates where the thread states them.
+
+Flags:
+  --max-length        Approximate length of the overview in words (default 150)
+  --focus             Topic to bias the summary towards
+  --model             LLM model to use (default: config default_model)
+  -o, --output        Output format: text, json, yaml
+
+Examples:
+  penf thread summarize 42
+  penf thread summarize 42 --focus "launch date"
+  penf thread summarize 42 --max-length 60 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threadID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid thread ID %q: %w", args[0], err)
+			}
+			return runThreadSummarize(cmd.Context(), deps, threadID)
+		},
+	}
+
+	cmd.Flags().IntVar(&threadMaxLength, "max-length", 150, "Approximate length of the overview in words")
+	cmd.Flags().StringVar(&threadFocus, "focus", "", "Topic to bias the summary towards")
+	cmd.Flags().StringVar(&threadModel, "model", "", "LLM model to use (default: config default_model)")
+	cmd.Flags().StringVarP(&threadOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runThreadSummarize executes the thread summarize command.
+func runThreadSummarize(ctx context.Context, deps *ThreadCommandDeps, threadID int64) error {
+	if threadMaxLength <= 0 {
+		return fmt.Errorf("--max-length must be positive")
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectThreadsToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	thread, err := threadsv1.NewThreadsServiceClient(conn).GetThread(ctx, &threadsv1.GetThreadRequest{
+		TenantId: getTenantIDForThreads(deps),
+		ThreadId: threadID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting thread: %w", err)
+	}
+	if len(thread.Messages) == 0 {
+		return fmt.Errorf("thread %d has no messages to summarize", threadID)
+	}
+
+	generate := deps.GenerateSummary
+	if generate == nil {
+		generate = generateThreadSummary
+	}
+
+	transcript := buildThreadTranscript(thread.Messages, threadTranscriptMaxChars)
+	req := buildThreadSummaryRequest(thread.Subject, transcript, threadMaxLength, threadFocus)
+	model := threadModel
+	if model == "" {
+		model = cfg.DefaultModel
+	}
+	if model != "" {
+		req.Model = &model
+	}
+
+	summaryCtx, cancel := context.WithTimeout(ctx, threadSummaryTimeout)
+	defer cancel()
+	resp, err := generate(summaryCtx, cfg, req)
+	if err != nil {
+		return fmt.Errorf("summarizing thread: %w", err)
+	}
+	if strings.TrimSpace(resp.Summary) == "" {
+		return fmt.Errorf("summarizing thread: the model returned an empty response")
+	}
+
+	summary := parseThreadSummary(resp.Summary)
+	summary.ThreadID = thread.Id
+	summary.Subject = thread.Subject
+	summary.MessageCount = thread.MessageCount
+	summary.Focus = threadFocus
+	return outputThreadSummary(summary)
+}
+
+// buildThreadTranscript renders messages oldest first. When the transcript
+// would exceed maxChars, each message body is cut to an equal share so every
+// message keeps its sender and date.
+func buildThreadTranscript(messages []*threadsv1.ThreadMessage, maxChars int) string {
+	header := func(m *threadsv1.ThreadMessage) string {
+		from := m.FromName
+		if m.FromEmail != "" {
+			from = strings.TrimSpace(fmt.Sprintf("%s <%s>", m.FromName, m.FromEmail))
+		}
+		return fmt.Sprintf("[%d] From: %s\nDate: %s\n", m.PositionInThread, from, formatThreadTimestamp(m.MessageDate))
+	}
+
+	total := 0
+	for _, m := range messages {
+		total += len(header(m)) + len(m.BodyPreview) + 1
+	}
+	bodyLimit := -1
+	if total > maxChars {
+		bodyLimit = maxChars / len(messages)
+	}
+
+	var sb strings.Builder
+	for _, m := range messages {
+		sb.WriteString(header(m))
+		body := strings.TrimSpace(m.BodyPreview)
+		if bodyLimit >= 0 {
+			body = truncateThreadString(body, bodyLimit)
+		}
+		sb.WriteString(body)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// buildThreadSummaryRequest asks for a JSON summary of the transcript. The
+// instructions travel with the transcript, as the summary request has a
+// single content field.
+func buildThreadSummaryRequest(subject, transcript string, maxLength int, focus string) *aiv1.SummaryRequest {
+	var prompt strings.Builder
+	prompt.WriteString(`You summarize email threads for a busy reader. Reply with only a JSON object:
+{"summary": string, "key_points": [string], "decisions": [string], "action_items": [{"task": string, "owner": string, "due": string}]}
+Use empty arrays for sections the thread does not support. Leave owner and due empty unless the thread states them. Do not invent facts.
+
+`)
+	fmt.Fprintf(&prompt, "Summarize this thread. Keep \"summary\" under %d words.\n", maxLength)
+	if focus != "" {
+		fmt.Fprintf(&prompt, "Focus on: %s. Leave out points unrelated to it.\n", focus)
+	}
+	fmt.Fprintf(&prompt, "\nSubject: %s\n\n%s", subject, transcript)
+
+	maxTokens := int32(threadSummaryMaxTokens(maxLength))
+	jsonMode := true
+	return &aiv1.SummaryRequest{
+		Content:   prompt.String(),
+		MaxLength: &maxTokens,
+		JsonMode:  &jsonMode,
+	}
+}
+
+// threadSummaryMaxTokens budgets completion tokens for an overview of
+// maxLength words plus the list sections and JSON syntax.
+func threadSummaryMaxTokens(maxLength int) int {
+	return maxLength*2 + 512
+}
+
+// parseThreadSummary decodes the model's JSON reply. Replies that are not
+// valid JSON, for example wrapped in prose, are kept whole as the overview.
+func parseThreadSummary(content string) *ThreadSummary {
+	summary := &ThreadSummary{
+		KeyPoints:   []string{},
+		Decisions:   []string{},
+		ActionItems: []ThreadActionItem{},
+	}
+
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start >= 0 && end > start {
+		var parsed ThreadSummary
+		if err := json.Unmarshal([]byte(content[start:end+1]), &parsed); err == nil {
+			summary.Summary = strings.TrimSpace(parsed.Summary)
+			if parsed.KeyPoints != nil {
+				summary.KeyPoints = parsed.KeyPoints
+			}
+			if parsed.Decisions != nil {
+				summary.Decisions = parsed.Decisions
+			}
+			if parsed.ActionItems != nil {
+				summary.ActionItems = parsed.ActionItems
+			}
+			return summary
+		}
+	}
+
+	summary.Summary = strings.TrimSpace(content)
+	return summary
+}
+
+// generateThreadSummary sends req to the AI service through the gateway,
+// with the configured tenant, TLS and timeouts.
+func generateThreadSummary(ctx context.Context, cfg *config.CLIConfig, req *aiv1.SummaryRequest) (*aiv1.SummaryResponse, error) {
+	opts := client.DefaultOptions()
+	opts.Insecure = cfg.Insecure
+	opts.Debug = cfg.Debug
+	opts.DebugTrace = cfg.DebugTrace
+	opts.TenantID = cfg.EffectiveTenantID()
+	opts.ConnectTimeout = cfg.GetConnectTimeout()
+	opts.RequestTimeout = cfg.Timeout
+	opts.ApplyConnectionConfig(cfg)
+
+	if !cfg.Insecure && cfg.TLS.Enabled {
+		tlsConfig, err := client.LoadClientTLSConfig(&cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS config: %w", err)
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	aiClient := client.NewAIClient(cfg.ServerAddress, opts)
+	connectCtx, cancel := context.WithTimeout(ctx, opts.ConnectTimeout)
+	defer cancel()
+	if err := aiClient.Connect(connectCtx); err != nil {
+		return nil, fmt.Errorf("connecting to AI service: %w", err)
+	}
+	defer aiClient.Close()
+
+	return aiClient.GenerateSummary(ctx, opts.TenantID, req)
+}
+
+// outputThreadSummary formats and displays a thread summary.
+func outputThreadSummary(summary *ThreadSummary) error {
+	switch threadOutput {
+	case "json":
+		return outputJSON(summary)
+	case "yaml":
+		return outputYAML(summary)
+	default:
+		outputThreadSummaryText(summary)
+		return nil
+	}
+}
+
+// outputThreadSummaryText displays a thread summary as formatted text.
+func outputThreadSummaryText(summary *ThreadSummary) {
+	fmt.Printf("Thread %d: %s (%d messages)\n", summary.ThreadID, summary.Subject, summary.MessageCount)
+	if summary.Focus != "" {
+		fmt.Printf("Focus: %s\n", summary.Focus)
+	}
+	fmt.Printf("\n%s\n", summary.Summary)
+
+	printList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Printf("\n%s:\n", title)
+		for _, item := range items {
+			fmt.Printf("  - %s\n", item)
+		}
+	}
+	printList("Key Points", summary.KeyPoints)
+	printList("Decisions", summary.Decisions)
+
+	if len(summary.ActionItems) > 0 {
+		fmt.Println("\nAction Items:")
+		for _, a := range summary.ActionItems {
+			fmt.Printf("  - %s", a.Task)
+			var details []string
+			if a.Owner != "" {
+				details = append(details, "owner: "+a.Owner)
+			}
+			if a.Due != "" {
+				details = append(details, "due: "+a.Due)
+			}
+			if len(details) > 0 {
+				fmt.Printf(" (%s)", strings.Join(details, ", "))
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+}
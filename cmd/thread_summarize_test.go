@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	threadsv1 "github.com/otherjamesbrown/penf-cli/api/proto/threads/v1"
+)
+
+// TestParseThreadSummary tests decoding of the model's JSON reply.
+func TestParseThreadSummary(t *testing.T) {
+	content := "Here you go:\n```json\n" + `{"summary": " Launch slips a week. ", "key_points": ["QA found a blocker"], "decisions": ["Ship on the 14th"], "action_items": [{"task": "Fix login bug", "owner": "Alice"}]}` + "\n```"
+
+	summary := parseThreadSummary(content)
+	if summary.Summary != "Launch slips a week." {
+		t.Errorf("Summary = %q", summary.Summary)
+	}
+	if len(summary.KeyPoints) != 1 || len(summary.Decisions) != 1 {
+		t.Errorf("KeyPoints = %v, Decisions = %v", summary.KeyPoints, summary.Decisions)
+	}
+	if len(summary.ActionItems) != 1 || summary.ActionItems[0].Owner != "Alice" {
+		t.Errorf("ActionItems = %+v", summary.ActionItems)
+	}
+
+	plain := parseThreadSummary("The team agreed to ship.")
+	if plain.Summary != "The team agreed to ship." {
+		t.Errorf("non-JSON reply should be kept as the summary, got %q", plain.Summary)
+	}
+	if plain.KeyPoints == nil || plain.Decisions == nil || plain.ActionItems == nil {
+		t.Error("sections should be empty, not nil, so JSON output has arrays")
+	}
+}
+
+// TestBuildThreadTranscript tests transcript rendering and truncation.
+func TestBuildThreadTranscript(t *testing.T) {
+	messages := []*threadsv1.ThreadMessage{
+		{PositionInThread: 1, FromName: "Alice", FromEmail: "alice@example.com", BodyPreview: strings.Repeat("a", 500)},
+		{PositionInThread: 2, FromName: "Bob", BodyPreview: "short reply"},
+	}
+
+	full := buildThreadTranscript(messages, 10000)
+	if !strings.Contains(full, "[1] From: Alice <alice@example.com>") || !strings.Contains(full, "[2] From: Bob\n") {
+		t.Errorf("transcript missing headers:\n%s", full)
+	}
+	if !strings.Contains(full, strings.Repeat("a", 500)) {
+		t.Error("short transcripts should not be truncated")
+	}
+
+	cut := buildThreadTranscript(messages, 200)
+	if strings.Contains(cut, strings.Repeat("a", 101)) {
+		t.Error("long bodies should be cut to an equal share of the budget")
+	}
+	if !strings.Contains(cut, "short reply") || !strings.Contains(cut, "[2] From: Bob") {
+		t.Errorf("every message should keep its header and short body:\n%s", cut)
+	}
+}
+
+// TestBuildThreadSummaryRequest tests that length and focus reach the prompt.
+func TestBuildThreadSummaryRequest(t *testing.T) {
+	req := buildThreadSummaryRequest("Launch", "transcript", 80, "budget")
+	for _, want := range []string{"Reply with only a JSON object", "under 80 words", "Focus on: budget", "Subject: Launch", "transcript"} {
+		if !strings.Contains(req.Content, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if !req.GetJsonMode() || req.GetMaxLength() != int32(threadSummaryMaxTokens(80)) {
+		t.Errorf("json_mode = %v, max_length = %d", req.GetJsonMode(), req.GetMaxLength())
+	}
+	if req.Model != nil {
+		t.Errorf("model = %q, want unset so the caller picks it", req.GetModel())
+	}
+
+	if strings.Contains(buildThreadSummaryRequest("Launch", "t", 80, "").Content, "Focus on") {
+		t.Error("prompt should not mention a focus when none is given")
+	}
+}
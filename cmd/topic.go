@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	topicv1 "github.com/otherjamesbrown/penf-cli/api/proto/topic/v1"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -527,7 +526,7 @@ func outputTopicJSON(v interface{}) error {
 }
 
 func outputTopicYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
@@ -8,8 +8,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
+	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
 	"github.com/otherjamesbrown/penf-cli/pkg/contentid"
 )
@@ -27,6 +27,7 @@ type TraceCommandDeps struct {
 	LoadConfig   func() (*config.CLIConfig, error)
 	OutputFormat config.OutputFormat
 	LangfuseHost string // Langfuse server host (default: dev02.brown.chat:3000)
+	InitClient   func(*config.CLIConfig) (*client.GRPCClient, error)
 }
 
 // DefaultTraceDeps returns the default dependencies for production use.
@@ -34,6 +35,7 @@ func DefaultTraceDeps() *TraceCommandDeps {
 	return &TraceCommandDeps{
 		LoadConfig:   config.LoadConfig,
 		LangfuseHost: "dev02.brown.chat:3000",
+		InitClient:   client.ConnectFromConfig,
 	}
 }
 
@@ -49,9 +51,18 @@ func NewTraceCommand(deps *TraceCommandDeps) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "trace <content_id>",
-		Short: "Find Langfuse traces for a content item",
-		Long: `Look up Langfuse observability traces for a content item by its content ID.
+		Use:   "trace <content_id|trace_id>",
+		Short: "Show a distributed trace, or find Langfuse traces for a content item",
+		Long: `Show a distributed trace by trace ID, or look up Langfuse observability traces
+for a content item by its content ID.
+
+Trace IDs (16 or 32 hex digits, as shown in 'penf logs') are stitched together
+from the logs of every service that took part - gateway, worker, AI coordinator.
+Log entries sharing a span ID form a span; spans are ordered by start time and
+indented under their parent span when the logs record a parent_span_id. Each
+span shows its service, operation, offset from the trace start, duration, and
+any errors logged in it. With --output json the spans array can be fed into a
+trace viewer.
 
 This command generates a Langfuse URL that filters traces by the penfold.content_id
 metadata field, allowing you to view all AI processing traces related to a specific
@@ -72,6 +83,12 @@ Use this command when:
   - Analyzing the AI pipeline behavior for a content item
 
 Examples:
+  # Show the spans of a failed pipeline run end to end
+  penf trace 4bf92f3577b34da6a3ce929d0e0e4736
+
+  # Spans as JSON for a trace viewer
+  penf trace 4bf92f3577b34da6a3ce929d0e0e4736 -o json
+
   # Look up traces for a document
   penf trace dc-9x3kp7mn
 
@@ -114,6 +131,10 @@ func runTrace(cmd *cobra.Command, deps *TraceCommandDeps, contentID string) erro
 		}
 	}
 
+	if isTraceID(contentID) {
+		return runTraceSpans(cmd.Context(), deps, cfg, outputFormat, contentID)
+	}
+
 	// Validate content ID.
 	parsed, err := contentid.Parse(contentID)
 	if err != nil {
@@ -172,7 +193,7 @@ func outputTraceResults(format config.OutputFormat, output TraceOutput) error {
 		enc.SetIndent("", "  ")
 		return enc.Encode(output)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(output)
 	default:
 		return outputTraceResultsText(output)
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Paging for fetching the log entries of a trace.
+const (
+	traceLogsPageSize   = 500
+	traceLogsMaxEntries = 5000
+)
+
+// traceNoSpan is the operation shown for log entries logged outside a span.
+const traceNoSpan = "(no span)"
+
+// TraceSpan is one span of a distributed trace, reconstructed from the log
+// entries that share its span ID.
+type TraceSpan struct {
+	SpanID       string    `json:"span_id,omitempty" yaml:"span_id,omitempty"`
+	ParentSpanID string    `json:"parent_span_id,omitempty" yaml:"parent_span_id,omitempty"`
+	Service      string    `json:"service" yaml:"service"`
+	Operation    string    `json:"operation" yaml:"operation"`
+	Start        time.Time `json:"start" yaml:"start"`
+	DurationMs   int64     `json:"duration_ms" yaml:"duration_ms"`
+	Error        bool      `json:"error" yaml:"error"`
+	Errors       []string  `json:"errors,omitempty" yaml:"errors,omitempty"`
+	LogCount     int       `json:"log_count" yaml:"log_count"`
+}
+
+// TraceSpansOutput is the output of 'penf trace <trace-id>'.
+type TraceSpansOutput struct {
+	TraceID    string      `json:"trace_id" yaml:"trace_id"`
+	Start      time.Time   `json:"start" yaml:"start"`
+	DurationMs int64       `json:"duration_ms" yaml:"duration_ms"`
+	Services   []string    `json:"services" yaml:"services"`
+	Spans      []TraceSpan `json:"spans" yaml:"spans"`
+	Truncated  bool        `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// isTraceID reports whether s looks like a trace ID (16 or 32 hex digits)
+// rather than a content ID.
+func isTraceID(s string) bool {
+	if len(s) != 16 && len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// runTraceSpans fetches the logs for a trace ID and shows its spans.
+func runTraceSpans(ctx context.Context, deps *TraceCommandDeps, cfg *config.CLIConfig, format config.OutputFormat, traceID string) error {
+	c, err := deps.InitClient(cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to gateway: %w", err)
+	}
+	defer c.Close()
+
+	entries, truncated, err := fetchTraceLogs(ctx, c, strings.ToLower(traceID))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no logs found for trace %s", traceID)
+	}
+
+	output := buildTraceSpansOutput(strings.ToLower(traceID), entries)
+	output.Truncated = truncated
+
+	switch format {
+	case config.OutputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	case config.OutputFormatYAML:
+		return NewYAMLEncoder(os.Stdout).Encode(output)
+	default:
+		outputTraceSpansText(os.Stdout, output)
+		return nil
+	}
+}
+
+// fetchTraceLogs pages through every log entry for a trace, oldest first,
+// up to traceLogsMaxEntries.
+func fetchTraceLogs(ctx context.Context, c *client.GRPCClient, traceID string) ([]client.LogEntry, bool, error) {
+	filter := client.LogFilter{TraceID: traceID}
+
+	var entries []client.LogEntry
+	for offset := 0; offset < traceLogsMaxEntries; offset += traceLogsPageSize {
+		resp, err := c.ListLogs(ctx, filter, traceLogsPageSize, offset, true)
+		if err != nil {
+			return nil, false, fmt.Errorf("fetching logs for trace: %w", err)
+		}
+		entries = append(entries, resp.Entries...)
+		if !resp.Truncated || len(resp.Entries) == 0 {
+			return entries, false, nil
+		}
+	}
+	return entries, true, nil
+}
+
+// buildTraceSpansOutput groups log entries into spans by service and span
+// ID, ordered by start time.
+func buildTraceSpansOutput(traceID string, entries []client.LogEntry) TraceSpansOutput {
+	type spanKey struct{ service, spanID string }
+
+	spans := make(map[spanKey]*TraceSpan)
+	var order []spanKey
+	ends := make(map[spanKey]time.Time)
+	services := make(map[string]bool)
+	var traceStart, traceEnd time.Time
+
+	for _, e := range entries {
+		key := spanKey{e.Service, e.SpanID}
+		span, ok := spans[key]
+		if !ok {
+			span = &TraceSpan{SpanID: e.SpanID, Service: e.Service, Start: e.Timestamp, Operation: traceNoSpan}
+			if e.SpanID != "" {
+				span.Operation = e.Message
+			}
+			spans[key] = span
+			order = append(order, key)
+		}
+		span.LogCount++
+		services[e.Service] = true
+
+		if e.Timestamp.Before(span.Start) {
+			span.Start = e.Timestamp
+		}
+		if e.Timestamp.After(ends[key]) {
+			ends[key] = e.Timestamp
+		}
+		if op := traceEntryOperation(e); op != "" && e.SpanID != "" {
+			span.Operation = op
+		}
+		if parent := e.Fields["parent_span_id"]; parent != "" {
+			span.ParentSpanID = parent
+		}
+		if ms, err := strconv.ParseInt(e.Fields["duration_ms"], 10, 64); err == nil && ms > span.DurationMs {
+			span.DurationMs = ms
+		}
+		if strings.EqualFold(e.Level, string(LogLevelError)) {
+			span.Error = true
+			span.Errors = append(span.Errors, e.Message)
+		}
+
+		if traceStart.IsZero() || e.Timestamp.Before(traceStart) {
+			traceStart = e.Timestamp
+		}
+		if e.Timestamp.After(traceEnd) {
+			traceEnd = e.Timestamp
+		}
+	}
+
+	output := TraceSpansOutput{TraceID: traceID, Start: traceStart}
+	for _, key := range order {
+		span := spans[key]
+		if observed := ends[key].Sub(span.Start).Milliseconds(); observed > span.DurationMs {
+			span.DurationMs = observed
+		}
+		if end := span.Start.Add(time.Duration(span.DurationMs) * time.Millisecond); end.After(traceEnd) {
+			traceEnd = end
+		}
+		output.Spans = append(output.Spans, *span)
+	}
+	sort.SliceStable(output.Spans, func(i, j int) bool { return output.Spans[i].Start.Before(output.Spans[j].Start) })
+
+	output.DurationMs = traceEnd.Sub(traceStart).Milliseconds()
+	for s := range services {
+		output.Services = append(output.Services, s)
+	}
+	sort.Strings(output.Services)
+	return output
+}
+
+// traceEntryOperation returns the operation name a log entry declares in
+// its fields, if any.
+func traceEntryOperation(e client.LogEntry) string {
+	for _, k := range []string{"operation", "span_name", "method"} {
+		if v := e.Fields[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// outputTraceSpansText prints the spans as a tree: each span is indented
+// under its parent, siblings in start order.
+func outputTraceSpansText(w io.Writer, output TraceSpansOutput) {
+	fmt.Fprintf(w, "Trace %s\n", output.TraceID)
+	fmt.Fprintf(w, "  %d spans across %s, %s, started %s\n\n",
+		len(output.Spans), strings.Join(output.Services, ", "),
+		formatTraceDuration(output.DurationMs), output.Start.Local().Format("2006-01-02 15:04:05.000"))
+
+	known := make(map[string]bool)
+	for _, s := range output.Spans {
+		if s.SpanID != "" {
+			known[s.SpanID] = true
+		}
+	}
+	children := make(map[string][]TraceSpan)
+	var roots []TraceSpan
+	for _, s := range output.Spans {
+		if s.ParentSpanID != "" && s.ParentSpanID != s.SpanID && known[s.ParentSpanID] {
+			children[s.ParentSpanID] = append(children[s.ParentSpanID], s)
+		} else {
+			roots = append(roots, s)
+		}
+	}
+
+	visited := make(map[string]bool)
+	var printSpan func(s TraceSpan, depth int)
+	printSpan = func(s TraceSpan, depth int) {
+		indent := strings.Repeat("  ", depth+1)
+		status := ""
+		if s.Error {
+			status = "  ERROR"
+		}
+		fmt.Fprintf(w, "%s[%s] %s  +%s  %s%s\n",
+			indent, s.Service, s.Operation,
+			formatTraceDuration(s.Start.Sub(output.Start).Milliseconds()), formatTraceDuration(s.DurationMs), status)
+		for _, msg := range s.Errors {
+			fmt.Fprintf(w, "%s    %s\n", indent, truncate(msg, 120))
+		}
+
+		if s.SpanID == "" || visited[s.SpanID] {
+			return
+		}
+		visited[s.SpanID] = true
+		for _, child := range children[s.SpanID] {
+			printSpan(child, depth+1)
+		}
+	}
+	for _, s := range roots {
+		printSpan(s, 0)
+	}
+	// Spans whose parents form a cycle are never reached from a root.
+	for _, s := range output.Spans {
+		if s.SpanID != "" && !visited[s.SpanID] {
+			printSpan(s, 0)
+		}
+	}
+
+	if output.Truncated {
+		fmt.Fprintf(w, "\n(showing the first %d log entries of this trace)\n", traceLogsMaxEntries)
+	}
+}
+
+// formatTraceDuration formats milliseconds compactly: 850ms, 1.42s, 2m3s.
+func formatTraceDuration(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", ms)
+	case d < time.Minute:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	default:
+		return d.Round(time.Second).String()
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+func TestIsTraceID(t *testing.T) {
+	for _, id := range []string{"4bf92f3577b34da6a3ce929d0e0e4736", "00F067AA0BA902B7"} {
+		if !isTraceID(id) {
+			t.Errorf("isTraceID(%q) = false, want true", id)
+		}
+	}
+	for _, id := range []string{"dc-0000aaaa", "em-1234abcd", "4bf92f3577b34da6a3", "4bf92f3577b34da6a3ce929d0e0e473z", ""} {
+		if isTraceID(id) {
+			t.Errorf("isTraceID(%q) = true, want false", id)
+		}
+	}
+}
+
+func traceTestEntries() []client.LogEntry {
+	t0 := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+	return []client.LogEntry{
+		{Timestamp: at(0), Service: "gateway", SpanID: "a1", Level: "info", Message: "request received", Fields: map[string]string{"method": "IngestContent"}},
+		{Timestamp: at(5), Service: "worker", SpanID: "b2", Level: "info", Message: "processing email", Fields: map[string]string{"parent_span_id": "a1", "operation": "process_email"}},
+		{Timestamp: at(20), Service: "gateway", SpanID: "a1", Level: "info", Message: "request done"},
+		{Timestamp: at(100), Service: "ai_coordinator", SpanID: "c3", Level: "info", Message: "summarize", Fields: map[string]string{"parent_span_id": "b2"}},
+		{Timestamp: at(900), Service: "ai_coordinator", SpanID: "c3", Level: "error", Message: "model timeout", Fields: map[string]string{"duration_ms": "1200"}},
+		{Timestamp: at(950), Service: "worker", Level: "warn", Message: "job retried"},
+	}
+}
+
+func TestBuildTraceSpansOutput(t *testing.T) {
+	out := buildTraceSpansOutput("4bf92f3577b34da6a3ce929d0e0e4736", traceTestEntries())
+
+	if len(out.Spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(out.Spans))
+	}
+	if got := strings.Join(out.Services, ","); got != "ai_coordinator,gateway,worker" {
+		t.Errorf("services = %s", got)
+	}
+
+	gateway := out.Spans[0]
+	if gateway.SpanID != "a1" || gateway.Operation != "IngestContent" || gateway.DurationMs != 20 || gateway.LogCount != 2 {
+		t.Errorf("gateway span = %+v", gateway)
+	}
+
+	ai := out.Spans[2]
+	if ai.SpanID != "c3" || ai.ParentSpanID != "b2" || !ai.Error || ai.DurationMs != 1200 {
+		t.Errorf("ai span = %+v, want an errored child of b2 lasting the logged 1200ms", ai)
+	}
+	if out.DurationMs != 1300 {
+		t.Errorf("trace duration = %dms, want 1300ms (ai span start + logged duration)", out.DurationMs)
+	}
+
+	if last := out.Spans[3]; last.SpanID != "" || last.Operation != traceNoSpan {
+		t.Errorf("entries without a span ID = %+v, want a %q span", last, traceNoSpan)
+	}
+}
+
+func TestOutputTraceSpansText(t *testing.T) {
+	var buf bytes.Buffer
+	outputTraceSpansText(&buf, buildTraceSpansOutput("4bf92f3577b34da6a3ce929d0e0e4736", traceTestEntries()))
+	got := buf.String()
+
+	for _, want := range []string{
+		"\n  [gateway] IngestContent  +0ms  20ms\n",
+		"\n    [worker] process_email  +5ms  0ms\n",
+		"\n      [ai_coordinator] summarize  +100ms  1.20s  ERROR\n",
+		"        model timeout\n",
+		"\n  [worker] (no span)  +950ms  0ms\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
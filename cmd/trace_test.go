@@ -156,8 +156,8 @@ func TestNewTraceCommand(t *testing.T) {
 	cmd := NewTraceCommand(deps)
 
 	// Check command configuration.
-	if cmd.Use != "trace <content_id>" {
-		t.Errorf("Use = %q, want %q", cmd.Use, "trace <content_id>")
+	if cmd.Use != "trace <content_id|trace_id>" {
+		t.Errorf("Use = %q, want %q", cmd.Use, "trace <content_id|trace_id>")
 	}
 
 	// Check flags.
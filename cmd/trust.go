@@ -4,6 +4,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	entityv1 "github.com/otherjamesbrown/penf-cli/api/proto/entity/v1"
 	watchlistv1 "github.com/otherjamesbrown/penf-cli/api/proto/watchlist/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -19,17 +22,21 @@ import (
 
 // Trust command flags.
 var (
-	trustTenant  string
-	trustOutput  string
-	trustLevel   int32
-	trustDomains []string
+	trustTenant   string
+	trustOutput   string
+	trustLevel    int32
+	trustDomains  []string
+	trustFromFile string
+	trustDryRun   bool
 )
 
 // Seniority command flags.
 var (
-	seniorityTenant string
-	seniorityOutput string
-	seniorityTier   int32
+	seniorityTenant   string
+	seniorityOutput   string
+	seniorityTier     int32
+	seniorityFromFile string
+	seniorityDryRun   bool
 )
 
 // TrustCommandDeps holds the dependencies for trust commands.
@@ -124,23 +131,41 @@ Examples:
   penf trust set 123 --level 4
 
   # Set trust level with domains
-  penf trust set 123 --level 4 --domains technical-risk,timeline`,
-		Args: cobra.ExactArgs(1),
+  penf trust set 123 --level 4 --domains technical-risk,timeline
+
+Roster files:
+  Use --from-file to set trust for many people at once. The file is a CSV of
+  "entity_id,level" or "email,level" rows; emails are resolved to entities.
+  A header row and lines starting with # are ignored. --domains, if given,
+  applies to every row. Each row is reported separately and a failed row does
+  not stop the rest.
+
+  # Preview, then apply a roster
+  penf trust set --from-file roster.csv --dry-run
+  penf trust set --from-file roster.csv`,
+		Args: rosterArgs(&trustFromFile),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if trustFromFile != "" {
+				return runTrustSetFromFile(cmd.Context(), deps, trustFromFile)
+			}
+			if !cmd.Flags().Changed("level") {
+				return fmt.Errorf(`required flag(s) "level" not set`)
+			}
 			return runTrustSet(cmd.Context(), deps, args[0])
 		},
 	}
 
-	cmd.Flags().Int32Var(&trustLevel, "level", 0, "Trust level (0-5, required)")
+	cmd.Flags().Int32Var(&trustLevel, "level", 0, "Trust level (0-5, required unless --from-file)")
 	cmd.Flags().StringSliceVar(&trustDomains, "domains", nil, "Trust domains (comma-separated)")
-	cmd.MarkFlagRequired("level")
+	cmd.Flags().StringVar(&trustFromFile, "from-file", "", "Set trust from a CSV roster of entity_id,level or email,level rows")
+	cmd.Flags().BoolVar(&trustDryRun, "dry-run", false, "With --from-file, show what would be set without applying")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newTrustClearCommand creates the 'trust clear' subcommand.
 func newTrustClearCommand(deps *TrustCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "clear <person_id>",
 		Short: "Clear trust for a person",
 		Long: `Clear trust for a person by setting trust level to 0 and clearing all domains.
@@ -151,7 +176,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTrustClear(cmd.Context(), deps, args[0])
 		},
-	}
+	})
 }
 
 // NewSeniorityCommand creates the root seniority command with all subcommands.
@@ -207,23 +232,39 @@ func newSenioritySetCommand(deps *SeniorityCommandDeps) *cobra.Command {
 
 Seniority tier must be 1-7.
 
-Example:
-  penf seniority set 123 --tier 5`,
-		Args: cobra.ExactArgs(1),
+Use --from-file to set seniority for many people at once. The file is a CSV
+of "entity_id,tier" or "email,tier" rows; emails are resolved to entities. A
+header row and lines starting with # are ignored. Each row is reported
+separately and a failed row does not stop the rest.
+
+Examples:
+  penf seniority set 123 --tier 5
+
+  # Preview, then apply a roster
+  penf seniority set --from-file roster.csv --dry-run
+  penf seniority set --from-file roster.csv`,
+		Args: rosterArgs(&seniorityFromFile),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if seniorityFromFile != "" {
+				return runSenioritySetFromFile(cmd.Context(), deps, seniorityFromFile)
+			}
+			if !cmd.Flags().Changed("tier") {
+				return fmt.Errorf(`required flag(s) "tier" not set`)
+			}
 			return runSenioritySet(cmd.Context(), deps, args[0])
 		},
 	}
 
-	cmd.Flags().Int32Var(&seniorityTier, "tier", 0, "Seniority tier (1-7, required)")
-	cmd.MarkFlagRequired("tier")
+	cmd.Flags().Int32Var(&seniorityTier, "tier", 0, "Seniority tier (1-7, required unless --from-file)")
+	cmd.Flags().StringVar(&seniorityFromFile, "from-file", "", "Set seniority from a CSV roster of entity_id,tier or email,tier rows")
+	cmd.Flags().BoolVar(&seniorityDryRun, "dry-run", false, "With --from-file, show what would be set without applying")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newSeniorityClearCommand creates the 'seniority clear' subcommand.
 func newSeniorityClearCommand(deps *SeniorityCommandDeps) *cobra.Command {
-	return &cobra.Command{
+	return withEnvelope(&cobra.Command{
 		Use:   "clear <person_id>",
 		Short: "Clear seniority for a person",
 		Long: `Clear seniority for a person by setting tier to 0.
@@ -234,6 +275,19 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSeniorityClear(cmd.Context(), deps, args[0])
 		},
+	})
+}
+
+// rosterArgs requires a person ID, or no arguments when --from-file is set.
+func rosterArgs(fromFile *string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if *fromFile != "" {
+			if len(args) != 0 {
+				return fmt.Errorf("cannot combine a person ID with --from-file")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
 	}
 }
 
@@ -241,7 +295,7 @@ Example:
 
 // connectTrustToGateway creates a gRPC connection to the gateway service.
 func connectTrustToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -264,9 +318,11 @@ func connectTrustToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -284,6 +340,20 @@ func getTenantIDForTrust() string {
 	return "00000001-0000-0000-0000-000000000001"
 }
 
+// getTrustOutputFormat returns the output format from flag or config.
+func getTrustOutputFormat(cfg *config.CLIConfig) config.OutputFormat {
+	if trustOutput != "" {
+		return config.OutputFormat(trustOutput)
+	}
+	if seniorityOutput != "" {
+		return config.OutputFormat(seniorityOutput)
+	}
+	if cfg != nil {
+		return cfg.OutputFormat
+	}
+	return config.OutputFormatText
+}
+
 // ==================== Command Execution Functions ====================
 
 // runTrustSet executes the trust set command via gRPC.
@@ -333,16 +403,20 @@ func runTrustSet(ctx context.Context, deps *TrustCommandDeps, personIDStr string
 		return fmt.Errorf("setting trust: %w", err)
 	}
 
+	// Reset flags for next call.
+	trustLevel = 0
+	trustDomains = nil
+
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Person, "Set trust: "+resp.Person.Name)
+	}
+
 	fmt.Printf("\033[32mSet trust:\033[0m %s (ID: %d)\n", resp.Person.Name, resp.Person.Id)
 	fmt.Printf("  Trust level: %d\n", resp.Person.TrustLevel)
 	if len(resp.Person.TrustDomains) > 0 {
 		fmt.Printf("  Domains: %s\n", strings.Join(resp.Person.TrustDomains, ", "))
 	}
 
-	// Reset flags for next call.
-	trustLevel = 0
-	trustDomains = nil
-
 	return nil
 }
 
@@ -379,6 +453,10 @@ func runTrustClear(ctx context.Context, deps *TrustCommandDeps, personIDStr stri
 		return fmt.Errorf("clearing trust: %w", err)
 	}
 
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Person, "Cleared trust: "+resp.Person.Name)
+	}
+
 	fmt.Printf("\033[32mCleared trust:\033[0m %s (ID: %d)\n", resp.Person.Name, resp.Person.Id)
 	return nil
 }
@@ -420,15 +498,19 @@ func runSenioritySet(ctx context.Context, deps *SeniorityCommandDeps, personIDSt
 		return fmt.Errorf("setting seniority: %w", err)
 	}
 
+	// Reset flags for next call.
+	seniorityTier = 0
+
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Person, "Set seniority: "+resp.Person.Name)
+	}
+
 	fmt.Printf("\033[32mSet seniority:\033[0m %s (ID: %d)\n", resp.Person.Name, resp.Person.Id)
 	fmt.Printf("  Seniority tier: %d\n", resp.Person.SeniorityTier)
 	if resp.Person.Title != "" {
 		fmt.Printf("  Title: %s\n", resp.Person.Title)
 	}
 
-	// Reset flags for next call.
-	seniorityTier = 0
-
 	return nil
 }
 
@@ -464,6 +546,119 @@ func runSeniorityClear(ctx context.Context, deps *SeniorityCommandDeps, personID
 		return fmt.Errorf("clearing seniority: %w", err)
 	}
 
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		return writeOutcomeJSON(true, resp.Person, "Cleared seniority: "+resp.Person.Name)
+	}
+
 	fmt.Printf("\033[32mCleared seniority:\033[0m %s (ID: %d)\n", resp.Person.Name, resp.Person.Id)
 	return nil
 }
+
+// runTrustSetFromFile sets trust for every row of a roster file.
+func runTrustSetFromFile(ctx context.Context, deps *TrustCommandDeps, path string) error {
+	rows, err := readRosterFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectTrustToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := watchlistv1.NewWatchListServiceClient(conn)
+	entityClient := entityv1.NewEntityManagementServiceClient(conn)
+	tenantID := getTenantIDForTrust()
+
+	var cleanDomains []string
+	for _, d := range trustDomains {
+		d = strings.TrimSpace(d)
+		if d != "" {
+			cleanDomains = append(cleanDomains, d)
+		}
+	}
+
+	resolve := func(ctx context.Context, ref string) (int64, error) {
+		return resolveEntityRef(ctx, entityClient, tenantID, ref)
+	}
+	apply := func(ctx context.Context, personID int64, level int32) (string, error) {
+		resp, err := client.SetTrust(ctx, &watchlistv1.SetTrustRequest{
+			TenantId:     tenantID,
+			PersonId:     personID,
+			TrustLevel:   level,
+			TrustDomains: cleanDomains,
+		})
+		if err != nil {
+			return "", fmt.Errorf("setting trust: %w", err)
+		}
+		return resp.Person.Name, nil
+	}
+
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		succeeded, failed := applyRoster(ctx, io.Discard, rows, "trust", parseTrustLevel, resolve, apply, trustDryRun)
+		return writeRosterResult("trust", trustDryRun, succeeded, failed)
+	}
+
+	_, failed := applyRoster(ctx, os.Stdout, rows, "trust", parseTrustLevel, resolve, apply, trustDryRun)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(rows))
+	}
+	return nil
+}
+
+// runSenioritySetFromFile sets seniority for every row of a roster file.
+func runSenioritySetFromFile(ctx context.Context, deps *SeniorityCommandDeps, path string) error {
+	rows, err := readRosterFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	conn, err := connectTrustToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := watchlistv1.NewWatchListServiceClient(conn)
+	entityClient := entityv1.NewEntityManagementServiceClient(conn)
+	tenantID := getTenantIDForTrust()
+
+	resolve := func(ctx context.Context, ref string) (int64, error) {
+		return resolveEntityRef(ctx, entityClient, tenantID, ref)
+	}
+	apply := func(ctx context.Context, personID int64, tier int32) (string, error) {
+		resp, err := client.SetSeniority(ctx, &watchlistv1.SetSeniorityRequest{
+			TenantId:      tenantID,
+			PersonId:      personID,
+			SeniorityTier: tier,
+		})
+		if err != nil {
+			return "", fmt.Errorf("setting seniority: %w", err)
+		}
+		return resp.Person.Name, nil
+	}
+
+	if getTrustOutputFormat(cfg) == config.OutputFormatJSON {
+		succeeded, failed := applyRoster(ctx, io.Discard, rows, "seniority", parseSeniorityTier, resolve, apply, seniorityDryRun)
+		return writeRosterResult("seniority", seniorityDryRun, succeeded, failed)
+	}
+
+	_, failed := applyRoster(ctx, os.Stdout, rows, "seniority", parseSeniorityTier, resolve, apply, seniorityDryRun)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(rows))
+	}
+	return nil
+}
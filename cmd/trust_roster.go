@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// rosterRow is one "entity_id,value" or "email,value" row of a roster file.
+type rosterRow struct {
+	Line  int
+	Ref   string
+	Value string
+}
+
+// rosterResolver resolves an entity ID or email to a person ID.
+type rosterResolver func(ctx context.Context, ref string) (int64, error)
+
+// rosterApplier sets value on a person and returns the person's name.
+type rosterApplier func(ctx context.Context, personID int64, value int32) (string, error)
+
+// readRosterFile reads a two-column roster CSV. Blank lines and lines starting
+// with '#' are skipped, as is a header row whose first column is "entity_id",
+// "id" or "email".
+func readRosterFile(path string) ([]rosterRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening roster file: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rows []rosterRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading roster file: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		if len(rows) == 0 && len(record) > 0 {
+			switch strings.ToLower(strings.TrimSpace(record[0])) {
+			case "entity_id", "id", "email":
+				continue
+			}
+		}
+		if len(record) != 2 {
+			return nil, fmt.Errorf("roster file line %d: expected 2 columns (entity_id or email, value), got %d", line, len(record))
+		}
+
+		rows = append(rows, rosterRow{
+			Line:  line,
+			Ref:   strings.TrimSpace(record[0]),
+			Value: strings.TrimSpace(record[1]),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("roster file has no rows")
+	}
+	return rows, nil
+}
+
+// applyRoster resolves and applies each row, reporting each row's outcome to
+// w. A failed row never stops the rest. In dry-run mode rows are parsed and
+// resolved but nothing is set. It returns the number of rows that succeeded
+// and failed.
+func applyRoster(ctx context.Context, w io.Writer, rows []rosterRow, attr string, parse func(string) (int32, error), resolve rosterResolver, apply rosterApplier, dryRun bool) (int, int) {
+	succeeded, failed := 0, 0
+	for i, row := range rows {
+		prefix := fmt.Sprintf("[%d/%d]", i+1, len(rows))
+		fail := func(err error) {
+			failed++
+			fmt.Fprintf(w, "%s \033[31mfailed\033[0m line %d (%s): %v\n", prefix, row.Line, row.Ref, err)
+		}
+
+		value, err := parse(row.Value)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		personID, err := resolve(ctx, row.Ref)
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		if dryRun {
+			succeeded++
+			fmt.Fprintf(w, "%s would set %s %d for %s (ID: %d)\n", prefix, attr, value, row.Ref, personID)
+			continue
+		}
+
+		name, err := apply(ctx, personID, value)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		succeeded++
+		fmt.Fprintf(w, "%s set %s %d for %s (ID: %d)\n", prefix, attr, value, name, personID)
+	}
+
+	fmt.Fprintln(w)
+	if dryRun {
+		fmt.Fprintf(w, "Dry run: %d row(s) would be set, %d failed (omit --dry-run to apply)\n", succeeded, failed)
+	} else {
+		fmt.Fprintf(w, "Roster import complete: %d set, %d failed\n", succeeded, failed)
+	}
+	return succeeded, failed
+}
+
+// RosterResult is the JSON result of a roster import.
+type RosterResult struct {
+	Attribute string `json:"attribute"`
+	DryRun    bool   `json:"dry_run"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// writeRosterResult writes a roster import summary as a ResultEnvelope that
+// reports failure when any row failed.
+func writeRosterResult(attr string, dryRun bool, succeeded, failed int) error {
+	return writeOutcomeJSON(failed == 0, RosterResult{Attribute: attr, DryRun: dryRun, Succeeded: succeeded, Failed: failed},
+		fmt.Sprintf("%d set, %d failed", succeeded, failed))
+}
+
+// parseTrustLevel parses and validates a trust level (0-5).
+func parseTrustLevel(s string) (int32, error) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid trust level: %q", s)
+	}
+	if n < 0 || n > 5 {
+		return 0, fmt.Errorf("trust level must be 0-5, got: %d", n)
+	}
+	return int32(n), nil
+}
+
+// parseSeniorityTier parses and validates a seniority tier (1-7).
+func parseSeniorityTier(s string) (int32, error) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid seniority tier: %q", s)
+	}
+	if n < 1 || n > 7 {
+		return 0, fmt.Errorf("seniority tier must be 1-7, got: %d", n)
+	}
+	return int32(n), nil
+}
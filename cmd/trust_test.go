@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -193,3 +198,91 @@ func TestSenioritySet_ValidatesTier(t *testing.T) {
 		})
 	}
 }
+
+func writeRosterFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "roster.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestReadRosterFile(t *testing.T) {
+	path := writeRosterFile(t, "email,level\n# leadership\njane@example.com, 4\n\n123,2\n")
+
+	rows, err := readRosterFile(path)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, rosterRow{Line: 3, Ref: "jane@example.com", Value: "4"}, rows[0])
+	assert.Equal(t, rosterRow{Line: 5, Ref: "123", Value: "2"}, rows[1])
+}
+
+func TestReadRosterFile_Errors(t *testing.T) {
+	_, err := readRosterFile(writeRosterFile(t, "123,4,extra\n"))
+	assert.ErrorContains(t, err, "line 1: expected 2 columns")
+
+	_, err = readRosterFile(writeRosterFile(t, "entity_id,tier\n"))
+	assert.ErrorContains(t, err, "no rows")
+}
+
+func TestApplyRoster(t *testing.T) {
+	rows := []rosterRow{
+		{Line: 1, Ref: "jane@example.com", Value: "4"},
+		{Line: 2, Ref: "missing@example.com", Value: "3"},
+		{Line: 3, Ref: "42", Value: "9"},
+		{Line: 4, Ref: "43", Value: "1"},
+	}
+	resolve := func(ctx context.Context, ref string) (int64, error) {
+		switch ref {
+		case "jane@example.com":
+			return 7, nil
+		case "43":
+			return 43, nil
+		}
+		return 0, fmt.Errorf("no entity found with email %q", ref)
+	}
+
+	applied := map[int64]int32{}
+	apply := func(ctx context.Context, personID int64, value int32) (string, error) {
+		applied[personID] = value
+		return fmt.Sprintf("Person %d", personID), nil
+	}
+
+	var buf bytes.Buffer
+	succeeded, failed := applyRoster(context.Background(), &buf, rows, "trust", parseTrustLevel, resolve, apply, false)
+	assert.Equal(t, 2, succeeded)
+	assert.Equal(t, 2, failed)
+	assert.Equal(t, map[int64]int32{7: 4, 43: 1}, applied)
+
+	out := buf.String()
+	assert.Contains(t, out, "[1/4] set trust 4 for Person 7 (ID: 7)")
+	assert.Contains(t, out, `line 2 (missing@example.com): no entity found with email "missing@example.com"`)
+	assert.Contains(t, out, "line 3 (42): trust level must be 0-5, got: 9")
+	assert.Contains(t, out, "Roster import complete: 2 set, 2 failed")
+}
+
+func TestApplyRoster_DryRun(t *testing.T) {
+	rows := []rosterRow{{Line: 1, Ref: "123", Value: "5"}}
+	resolve := func(ctx context.Context, ref string) (int64, error) { return 123, nil }
+	apply := func(ctx context.Context, personID int64, value int32) (string, error) {
+		t.Fatal("apply called in dry-run mode")
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	succeeded, failed := applyRoster(context.Background(), &buf, rows, "seniority", parseSeniorityTier, resolve, apply, true)
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 0, failed)
+	assert.Contains(t, buf.String(), "would set seniority 5 for 123 (ID: 123)")
+	assert.Contains(t, buf.String(), "Dry run: 1 row(s) would be set")
+}
+
+func TestTrustSet_FromFileArgs(t *testing.T) {
+	cmd := newTrustSetCommand(DefaultTrustDeps())
+	t.Cleanup(func() { trustFromFile = "" })
+
+	assert.Error(t, cmd.Args(cmd, nil), "person ID required without --from-file")
+
+	trustFromFile = "roster.csv"
+	assert.NoError(t, cmd.Args(cmd, nil))
+	assert.ErrorContains(t, cmd.Args(cmd, []string{"123"}), "cannot combine")
+}
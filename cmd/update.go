@@ -2,13 +2,19 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,16 +29,29 @@ const (
 	// GitHubRepo is the GitHub repository name.
 	GitHubRepo = "penfold"
 	// GitHubReleasesAPI is the GitHub API URL for releases.
-	GitHubReleasesAPI = "https://api.github.com/repos/%s/%s/releases/latest"
+	GitHubReleasesAPI = "https://api.github.com/repos/%s/%s/releases"
+
+	// UpdateChannelStable tracks published releases only.
+	UpdateChannelStable = "stable"
+	// UpdateChannelBeta also tracks pre-releases.
+	UpdateChannelBeta = "beta"
 )
 
 var (
 	updateCheck       bool
+	updateCheckOnly   bool
 	updateForce       bool
 	updateVersion     string
+	updateChannel     string
 	updateInstallPath string
+
+	updateVerifySignature bool
+	updatePublicKey       string
 )
 
+// releasesURL is the releases endpoint; tests point it at a local server.
+var releasesURL = fmt.Sprintf(GitHubReleasesAPI, GitHubOwner, GitHubRepo)
+
 // GitHubRelease represents a GitHub release from the API.
 type GitHubRelease struct {
 	TagName     string        `json:"tag_name"`
@@ -60,53 +79,109 @@ func NewUpdateCommand(currentVersion string) *cobra.Command {
 		Long: `Update penf CLI to the latest version from GitHub releases.
 
 This command will:
-1. Check GitHub for the latest release
+1. Check GitHub for the latest release on the selected channel
 2. Download the binary for your platform
-3. Replace the current binary
-4. Update the assistant CLAUDE.md configuration
-5. Update process definitions
+3. Verify the binary against the release's published SHA-256 checksum
+   (and, with --verify-signature, its minisign or cosign signature)
+4. Atomically replace the current binary
+5. Update the assistant CLAUDE.md configuration
+6. Update process definitions
+
+Channels:
+  stable   Published releases only (default)
+  beta     Also includes pre-releases
+
+Use --version to install a specific release, including an older one to
+downgrade.
+
+--check-only prints a single line and exits 0 when penf is up to date and 10
+when an update is available, for use in CI and shell prompts. Any other
+non-zero code means the check itself failed.
 
 Examples:
-  penf update           # Update to latest version
-  penf update --check   # Check for updates without installing
-  penf update --force   # Force reinstall current version`,
+  penf update                    # Update to latest version
+  penf update --check            # Check for updates and show release notes
+  penf update --check-only       # Exit 10 if an update is available
+  penf update --channel beta     # Update to the latest pre-release
+  penf update --version v0.9.2   # Install (or downgrade to) a specific version
+  penf update --verify-signature --public-key ~/.penf/release.pub
+  penf update --force            # Force reinstall current version`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(currentVersion)
+			err := runUpdate(currentVersion)
+			if IsStatusExit(err) {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
 		},
 	}
 
 	updateCmd.Flags().BoolVar(&updateCheck, "check", false, "Check for updates without installing")
+	updateCmd.Flags().BoolVar(&updateCheckOnly, "check-only", false, fmt.Sprintf("Report whether an update is available and exit %d if so, without installing", ExitUpdateAvailable))
 	updateCmd.Flags().BoolVar(&updateForce, "force", false, "Force update even if already at latest version")
-	updateCmd.Flags().StringVar(&updateVersion, "version", "", "Update to specific version (e.g., v1.0.0)")
+	updateCmd.Flags().StringVar(&updateVersion, "version", "", "Update to specific version, including downgrades (e.g., v1.0.0)")
+	updateCmd.Flags().StringVar(&updateChannel, "channel", UpdateChannelStable, "Release channel: stable or beta")
+	updateCmd.Flags().BoolVar(&updateVerifySignature, "verify-signature", false, "Also verify the release's minisign or cosign signature")
+	updateCmd.Flags().StringVar(&updatePublicKey, "public-key", "", "Signing public key: minisign key, or path to a minisign .pub or cosign PEM key (default: $PENF_UPDATE_PUBLIC_KEY)")
 	updateCmd.Flags().StringVar(&updateInstallPath, "install-path", "", "Install to this path (default: current location or config install_path)")
 
 	return updateCmd
 }
 
 func runUpdate(currentVersion string) error {
+	if updateChannel != UpdateChannelStable && updateChannel != UpdateChannelBeta {
+		return fmt.Errorf("invalid --channel %q: must be stable or beta", updateChannel)
+	}
+
+	// Check-only mode: one line of output and an exit code.
+	if updateCheckOnly {
+		release, err := resolveRelease(updateChannel, updateVersion)
+		if err != nil {
+			return fmt.Errorf("checking for updates: %w", err)
+		}
+		if !updateAvailable(currentVersion, release.TagName, updateVersion != "") {
+			fmt.Printf("penf %s is up to date\n", currentVersion)
+			return nil
+		}
+		fmt.Printf("Update available: %s -> %s\n", currentVersion, release.TagName)
+		return &statusExit{code: ExitUpdateAvailable}
+	}
+
 	fmt.Printf("Current version: %s\n", currentVersion)
 	fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	if updateChannel != UpdateChannelStable {
+		fmt.Printf("Channel: %s\n", updateChannel)
+	}
 	fmt.Println()
 
-	// Get latest release info.
+	// Get release info.
 	fmt.Println("Checking for updates...")
-	release, err := getLatestRelease()
+	release, err := resolveRelease(updateChannel, updateVersion)
 	if err != nil {
 		return fmt.Errorf("checking for updates: %w", err)
 	}
 
 	latestVersion := release.TagName
-	fmt.Printf("Latest version: %s\n", latestVersion)
+	if updateVersion != "" {
+		fmt.Printf("Requested version: %s\n", latestVersion)
+	} else {
+		fmt.Printf("Latest version: %s\n", latestVersion)
+	}
 	fmt.Println()
 
-	// Compare versions.
-	isNewer := isNewerVersion(currentVersion, latestVersion)
+	// Compare versions. A pinned version is installed whenever it differs
+	// from the current one, so it can be used to downgrade.
+	isNewer := updateAvailable(currentVersion, latestVersion, updateVersion != "")
 	if !isNewer && !updateForce {
-		fmt.Println("You are already running the latest version.")
+		if updateVersion != "" {
+			fmt.Printf("You are already running %s.\n", latestVersion)
+		} else {
+			fmt.Println("You are already running the latest version.")
+		}
 		return nil
 	}
 
-	// Check-only mode.
+	// Check mode.
 	if updateCheck {
 		if isNewer {
 			fmt.Printf("Update available: %s -> %s\n", currentVersion, latestVersion)
@@ -161,14 +236,47 @@ func runUpdate(currentVersion string) error {
 		return fmt.Errorf("cannot write to %s: %w\n\nTry one of:\n  1. sudo penf update\n  2. penf update --install-path ~/bin/penf\n  3. penf config set install_path ~/bin/penf", installDir, err)
 	}
 
-	// Download the new binary.
+	// Check signature settings before downloading anything.
+	var publicKey string
+	if updateVerifySignature {
+		publicKey = updatePublicKey
+		if publicKey == "" {
+			publicKey = os.Getenv("PENF_UPDATE_PUBLIC_KEY")
+		}
+		if publicKey == "" {
+			return fmt.Errorf("--verify-signature requires --public-key or PENF_UPDATE_PUBLIC_KEY")
+		}
+	}
+
+	// Download the new binary next to the installed one, so the final
+	// rename is atomic and an interrupted update leaves the old binary intact.
 	fmt.Printf("Downloading %s (%.2f MB)...\n", assetName, float64(assetSize)/(1024*1024))
-	tempFile, err := downloadAsset(downloadURL)
+	tempFile, err := downloadAsset(downloadURL, installDir)
 	if err != nil {
 		return fmt.Errorf("downloading update: %w", err)
 	}
 	defer os.Remove(tempFile)
 
+	// Verify the download before it replaces the installed binary.
+	fmt.Println("Verifying checksum...")
+	expected, err := releaseChecksum(release, assetName)
+	if err != nil {
+		return fmt.Errorf("verifying update: %w", err)
+	}
+	if err := verifyChecksum(tempFile, expected); err != nil {
+		return fmt.Errorf("verifying update: %w", err)
+	}
+	fmt.Printf("  \033[32m✓\033[0m SHA-256 %s\n", expected)
+
+	if updateVerifySignature {
+		fmt.Println("Verifying signature...")
+		scheme, err := verifyReleaseSignature(release, assetName, tempFile, publicKey)
+		if err != nil {
+			return fmt.Errorf("verifying update: %w", err)
+		}
+		fmt.Printf("  \033[32m✓\033[0m %s signature valid\n", scheme)
+	}
+
 	// Extract and install.
 	fmt.Printf("Installing to %s...\n", installPath)
 	if err := installUpdate(tempFile, installPath); err != nil {
@@ -218,37 +326,99 @@ func runUpdate(currentVersion string) error {
 	return nil
 }
 
-// getLatestRelease fetches the latest release from GitHub API.
+// resolveRelease returns the release to install: the pinned version if one
+// is given, otherwise the newest release on channel.
+func resolveRelease(channel, version string) (*GitHubRelease, error) {
+	if version != "" {
+		return getReleaseByTag(normalizeVersionTag(version))
+	}
+	if channel == UpdateChannelBeta {
+		return getLatestPrerelease()
+	}
+	return getLatestRelease()
+}
+
+// getLatestRelease fetches the latest stable release from GitHub API.
 func getLatestRelease() (*GitHubRelease, error) {
-	url := fmt.Sprintf(GitHubReleasesAPI, GitHubOwner, GitHubRepo)
+	var release GitHubRelease
+	if err := fetchGitHubJSON(releasesURL+"/latest", &release); err != nil {
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("no releases found")
+		}
+		return nil, err
+	}
+	return &release, nil
+}
 
+// getLatestPrerelease fetches the newest release on the beta channel, which
+// includes both pre-releases and stable releases.
+func getLatestPrerelease() (*GitHubRelease, error) {
+	var releases []GitHubRelease
+	if err := fetchGitHubJSON(releasesURL+"?per_page=50", &releases); err != nil {
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("no releases found")
+		}
+		return nil, err
+	}
+
+	var newest *GitHubRelease
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft {
+			continue
+		}
+		if newest == nil || compareVersions(r.TagName, newest.TagName) > 0 {
+			newest = r
+		}
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("no releases found")
+	}
+	return newest, nil
+}
+
+// getReleaseByTag fetches the release with the given tag.
+func getReleaseByTag(tag string) (*GitHubRelease, error) {
+	var release GitHubRelease
+	if err := fetchGitHubJSON(releasesURL+"/tags/"+tag, &release); err != nil {
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("release %s not found", tag)
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+// errGitHubNotFound is returned by fetchGitHubJSON for a 404 response.
+var errGitHubNotFound = errors.New("not found")
+
+// fetchGitHubJSON GETs a GitHub API URL and decodes the response into v.
+func fetchGitHubJSON(url string, v interface{}) error {
 	client := &http.Client{Timeout: 30 * time.Second}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", "penf-cli")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("no releases found")
+		return errGitHubNotFound
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API returned %d", resp.StatusCode)
+		return fmt.Errorf("GitHub API returned %d", resp.StatusCode)
 	}
 
-	var release GitHubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("parsing release info: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("parsing release info: %w", err)
 	}
-
-	return &release, nil
+	return nil
 }
 
 // getAssetName returns the expected asset filename for the current platform.
@@ -260,8 +430,8 @@ func getAssetName() string {
 	return fmt.Sprintf("penf-%s-%s", goos, arch)
 }
 
-// downloadAsset downloads a release asset to a temporary file.
-func downloadAsset(url string) (string, error) {
+// downloadAsset downloads a release asset to a temporary file in dir.
+func downloadAsset(url, dir string) (string, error) {
 	client := &http.Client{Timeout: 5 * time.Minute}
 	resp, err := client.Get(url)
 	if err != nil {
@@ -273,14 +443,18 @@ func downloadAsset(url string) (string, error) {
 		return "", fmt.Errorf("download returned %d", resp.StatusCode)
 	}
 
-	tempFile, err := os.CreateTemp("", "penf-update-*")
+	tempFile, err := os.CreateTemp(dir, ".penf-update-*")
 	if err != nil {
 		return "", err
 	}
 	defer tempFile.Close()
 
-	_, err = io.Copy(tempFile, resp.Body)
-	if err != nil {
+	if _, err := io.Copy(tempFile, resp.Body); err != nil {
+		os.Remove(tempFile.Name())
+		return "", err
+	}
+	// Flush to disk so a crash after the rename can't leave a truncated binary.
+	if err := tempFile.Sync(); err != nil {
 		os.Remove(tempFile.Name())
 		return "", err
 	}
@@ -295,8 +469,9 @@ func installUpdate(downloadedPath, targetPath string) error {
 		return fmt.Errorf("making binary executable: %w", err)
 	}
 
-	// Replace the old binary.
-	// On Unix, we can rename over the running binary.
+	// Replace the old binary. The download lives in the same directory, so
+	// this is an atomic rename: the target is either the old binary or the
+	// complete new one. On Unix, we can rename over the running binary.
 	if err := os.Rename(downloadedPath, targetPath); err != nil {
 		return fmt.Errorf("replacing binary: %w", err)
 	}
@@ -304,18 +479,171 @@ func installUpdate(downloadedPath, targetPath string) error {
 	return nil
 }
 
-// isNewerVersion compares version strings (simple comparison).
-func isNewerVersion(current, latest string) bool {
-	// Normalize versions (remove 'v' prefix).
-	current = strings.TrimPrefix(current, "v")
-	latest = strings.TrimPrefix(latest, "v")
+// releaseChecksum returns the expected SHA-256 of assetName, read from the
+// release's "<asset>.sha256" file or its checksums.txt manifest.
+func releaseChecksum(release *GitHubRelease, assetName string) (string, error) {
+	var manifestURL string
+	for _, asset := range release.Assets {
+		switch asset.Name {
+		case assetName + ".sha256":
+			data, err := downloadSmallAsset(asset.BrowserDownloadURL)
+			if err != nil {
+				return "", fmt.Errorf("downloading %s: %w", asset.Name, err)
+			}
+			return parseChecksum(data, assetName)
+		case "checksums.txt", "SHA256SUMS":
+			manifestURL = asset.BrowserDownloadURL
+		}
+	}
+	if manifestURL == "" {
+		return "", fmt.Errorf("release %s publishes no checksum for %s; refusing to install an unverified binary", release.TagName, assetName)
+	}
+
+	data, err := downloadSmallAsset(manifestURL)
+	if err != nil {
+		return "", fmt.Errorf("downloading checksums: %w", err)
+	}
+	return parseChecksum(data, assetName)
+}
+
+// parseChecksum finds the SHA-256 for assetName in sha256sum-format data
+// ("<hex>  <name>" per line). A single bare hash is also accepted, as
+// written to "<asset>.sha256" files.
+func parseChecksum(data []byte, assetName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 1 && isSHA256Hex(fields[0]):
+			return strings.ToLower(fields[0]), nil
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == assetName && isSHA256Hex(fields[0]):
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", assetName)
+}
+
+// isSHA256Hex reports whether s looks like a hex-encoded SHA-256 digest.
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// verifyChecksum checks that the file at path has the given SHA-256.
+func verifyChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
 
+// downloadSmallAsset downloads a small release asset, such as a checksum
+// file, into memory.
+func downloadSmallAsset(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("download returned %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// updateAvailable reports whether target should be installed over current.
+// A pinned target only needs to differ; otherwise it must be newer.
+func updateAvailable(current, target string, pinned bool) bool {
+	if pinned {
+		return compareVersions(current, target) != 0
+	}
+	return isNewerVersion(current, target)
+}
+
+// isNewerVersion reports whether latest is a newer version than current.
+func isNewerVersion(current, latest string) bool {
 	// Handle "dev" version.
-	if current == "dev" || current == "unknown" {
+	c := strings.TrimPrefix(current, "v")
+	if c == "dev" || c == "unknown" || c == "" {
 		return true
 	}
 
-	return latest > current
+	return compareVersions(latest, current) > 0
+}
+
+// normalizeVersionTag adds the "v" prefix release tags use.
+func normalizeVersionTag(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+// compareVersions compares two semantic versions ("v1.2.3" or
+// "1.2.3-beta.1"), returning -1, 0 or 1. Numeric parts compare numerically,
+// and a pre-release sorts before the release it precedes.
+func compareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bCore, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	if c := compareDotted(aCore, bCore); c != 0 {
+		return c
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return compareDotted(aPre, bPre)
+}
+
+// compareDotted compares dot-separated identifiers, numerically where both
+// are numbers and lexically otherwise. Missing identifiers sort first.
+func compareDotted(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		if i >= len(aParts) {
+			return -1
+		}
+		if i >= len(bParts) {
+			return 1
+		}
+		an, aErr := strconv.Atoi(aParts[i])
+		bn, bErr := strconv.Atoi(bParts[i])
+		var c int
+		if aErr == nil && bErr == nil {
+			c = an - bn
+		} else {
+			c = strings.Compare(aParts[i], bParts[i])
+		}
+		if c < 0 {
+			return -1
+		}
+		if c > 0 {
+			return 1
+		}
+	}
+	return 0
 }
 
 // formatReleaseNotes formats release notes for terminal display.
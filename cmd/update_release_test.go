@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "1.0.0", 0},
+		{"v0.10.0", "v0.9.2", 1},
+		{"v0.9.2", "v0.10.0", -1},
+		{"v1.0.0-beta.1", "v1.0.0", -1},
+		{"v1.0.0-beta.2", "v1.0.0-beta.10", -1},
+		{"v1.0.0-rc.1", "v1.0.0-beta.3", 1},
+		{"v1.1.0-beta.1", "v1.0.0", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, compareVersions(tt.a, tt.b), "%s vs %s", tt.a, tt.b)
+	}
+}
+
+func TestUpdateAvailable(t *testing.T) {
+	assert.True(t, updateAvailable("v0.9.2", "v0.10.0", false))
+	assert.False(t, updateAvailable("v0.10.0", "v0.9.2", false))
+	assert.True(t, updateAvailable("dev", "v0.9.2", false))
+	assert.True(t, updateAvailable("v0.10.0", "v0.9.2", true), "a pinned older version is a downgrade")
+	assert.False(t, updateAvailable("v0.9.2", "0.9.2", true))
+}
+
+func TestParseChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("penf"))
+	digest := hex.EncodeToString(sum[:])
+
+	manifest := "0000000000000000000000000000000000000000000000000000000000000000  penf-darwin-arm64\n" +
+		digest + " *penf-linux-amd64\n"
+	got, err := parseChecksum([]byte(manifest), "penf-linux-amd64")
+	require.NoError(t, err)
+	assert.Equal(t, digest, got)
+
+	got, err = parseChecksum([]byte(digest+"\n"), "penf-linux-amd64")
+	require.NoError(t, err)
+	assert.Equal(t, digest, got)
+
+	_, err = parseChecksum([]byte(manifest), "penf-windows-amd64")
+	assert.Error(t, err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "penf")
+	require.NoError(t, os.WriteFile(path, []byte("penf"), 0644))
+	sum := sha256.Sum256([]byte("penf"))
+
+	assert.NoError(t, verifyChecksum(path, hex.EncodeToString(sum[:])))
+	assert.ErrorContains(t, verifyChecksum(path, "00"), "checksum mismatch")
+}
+
+func TestResolveRelease(t *testing.T) {
+	releases := []GitHubRelease{
+		{TagName: "v1.1.0-beta.1", Prerelease: true},
+		{TagName: "v1.2.0-beta.1", Prerelease: true, Draft: true},
+		{TagName: "v1.0.0"},
+		{TagName: "v0.9.2"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases":
+			_ = json.NewEncoder(w).Encode(releases)
+		case "/releases/latest":
+			_ = json.NewEncoder(w).Encode(releases[2])
+		case "/releases/tags/v0.9.2":
+			_ = json.NewEncoder(w).Encode(releases[3])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	orig := releasesURL
+	releasesURL = srv.URL + "/releases"
+	t.Cleanup(func() { releasesURL = orig })
+
+	release, err := resolveRelease(UpdateChannelStable, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", release.TagName)
+
+	release, err = resolveRelease(UpdateChannelBeta, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0-beta.1", release.TagName, "drafts are skipped")
+
+	release, err = resolveRelease(UpdateChannelStable, "0.9.2")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.9.2", release.TagName)
+
+	_, err = resolveRelease(UpdateChannelStable, "v0.1.0")
+	assert.ErrorContains(t, err, "release v0.1.0 not found")
+}
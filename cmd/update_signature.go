@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Minisign signature algorithms: "Ed" signs the file itself, "ED" signs its
+// BLAKE2b-512 hash (the default since minisign 0.10).
+const (
+	minisignAlgPure   = "Ed"
+	minisignAlgHashed = "ED"
+)
+
+// verifyReleaseSignature verifies the downloaded asset at path against the
+// detached signature published with the release: "<asset>.minisig" is
+// checked natively, "<asset>.sig" with the cosign CLI. It returns the scheme
+// used.
+func verifyReleaseSignature(release *GitHubRelease, assetName, path, publicKey string) (string, error) {
+	for _, asset := range release.Assets {
+		switch asset.Name {
+		case assetName + ".minisig":
+			sig, err := downloadSmallAsset(asset.BrowserDownloadURL)
+			if err != nil {
+				return "", fmt.Errorf("downloading %s: %w", asset.Name, err)
+			}
+			key, err := loadMinisignPublicKey(publicKey)
+			if err != nil {
+				return "", err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			return "minisign", verifyMinisign(key, data, sig)
+		case assetName + ".sig":
+			sig, err := downloadSmallAsset(asset.BrowserDownloadURL)
+			if err != nil {
+				return "", fmt.Errorf("downloading %s: %w", asset.Name, err)
+			}
+			return "cosign", verifyCosign(publicKey, path, sig)
+		}
+	}
+	return "", fmt.Errorf("release %s publishes no signature for %s", release.TagName, assetName)
+}
+
+// minisignPublicKey is a decoded minisign public key.
+type minisignPublicKey struct {
+	keyID [8]byte
+	key   ed25519.PublicKey
+}
+
+// loadMinisignPublicKey parses a minisign public key given either inline
+// (the base64 line) or as the path to a .pub file.
+func loadMinisignPublicKey(s string) (*minisignPublicKey, error) {
+	text := s
+	if data, err := os.ReadFile(s); err == nil {
+		text = string(data)
+	}
+
+	var encoded string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "untrusted comment:") {
+			encoded = line
+			break
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != minisignAlgPure {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+
+	pk := &minisignPublicKey{key: ed25519.PublicKey(raw[10:])}
+	copy(pk.keyID[:], raw[2:10])
+	return pk, nil
+}
+
+// verifyMinisign checks a minisign signature file over data, including the
+// global signature that covers its trusted comment.
+func verifyMinisign(pk *minisignPublicKey, data, sigFile []byte) error {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(sigFile))
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("malformed minisign signature")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	if !bytes.Equal(sig[2:10], pk.keyID[:]) {
+		return fmt.Errorf("signature was made with a different key")
+	}
+
+	message := data
+	switch string(sig[:2]) {
+	case minisignAlgPure:
+	case minisignAlgHashed:
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", sig[:2])
+	}
+	if !ed25519.Verify(pk.key, message, sig[10:]) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	signed := make([]byte, 0, ed25519.SignatureSize+len(trusted))
+	signed = append(append(signed, sig[10:]...), trusted...)
+	if !ed25519.Verify(pk.key, signed, global) {
+		return fmt.Errorf("trusted comment signature mismatch")
+	}
+	return nil
+}
+
+// verifyCosign checks a cosign blob signature using the cosign CLI, which
+// must be installed. keyPath is the cosign public key file.
+func verifyCosign(keyPath, path string, sig []byte) error {
+	if _, err := exec.LookPath("cosign"); err != nil {
+		return fmt.Errorf("release is signed with cosign but the cosign CLI is not installed")
+	}
+
+	sigFile, err := os.CreateTemp("", "penf-update-*.sig")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(sigFile.Name())
+	if _, err := sigFile.Write(sig); err != nil {
+		sigFile.Close()
+		return err
+	}
+	sigFile.Close()
+
+	out, err := exec.Command("cosign", "verify-blob", "--key", keyPath, "--signature", sigFile.Name(), path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cosign verification failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// signMinisign produces a minisign public key line and signature file for
+// data, the way `minisign -S` does.
+func signMinisign(t *testing.T, data []byte, alg string) (string, []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	keyID := []byte("penfkey1")
+
+	message := data
+	if alg == minisignAlgHashed {
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	}
+	sig := append(append([]byte(alg), keyID...), ed25519.Sign(priv, message)...)
+	trusted := "timestamp:1760000000\tfile:penf-linux-amd64"
+	global := ed25519.Sign(priv, append(append([]byte{}, sig[10:]...), trusted...))
+
+	pubKey := base64.StdEncoding.EncodeToString(append(append([]byte(minisignAlgPure), keyID...), pub...))
+	sigFile := fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(sig), trusted, base64.StdEncoding.EncodeToString(global))
+	return pubKey, []byte(sigFile)
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	data := []byte("penf binary")
+
+	for _, alg := range []string{minisignAlgPure, minisignAlgHashed} {
+		t.Run(alg, func(t *testing.T) {
+			pubKey, sig := signMinisign(t, data, alg)
+			pk, err := loadMinisignPublicKey(pubKey)
+			require.NoError(t, err)
+
+			assert.NoError(t, verifyMinisign(pk, data, sig))
+			assert.ErrorContains(t, verifyMinisign(pk, []byte("tampered"), sig), "signature mismatch")
+		})
+	}
+}
+
+func TestVerifyMinisign_WrongKey(t *testing.T) {
+	data := []byte("penf binary")
+	_, sig := signMinisign(t, data, minisignAlgHashed)
+	otherKey, _ := signMinisign(t, data, minisignAlgHashed)
+
+	pk, err := loadMinisignPublicKey(otherKey)
+	require.NoError(t, err)
+	pk.keyID = [8]byte{}
+	assert.ErrorContains(t, verifyMinisign(pk, data, sig), "different key")
+}
+
+func TestLoadMinisignPublicKey_File(t *testing.T) {
+	pubKey, _ := signMinisign(t, []byte("x"), minisignAlgHashed)
+	path := filepath.Join(t.TempDir(), "release.pub")
+	require.NoError(t, os.WriteFile(path, []byte("untrusted comment: minisign public key\n"+pubKey+"\n"), 0644))
+
+	_, err := loadMinisignPublicKey(path)
+	assert.NoError(t, err)
+
+	_, err = loadMinisignPublicKey("not-a-key")
+	assert.Error(t, err)
+}
@@ -12,7 +12,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"gopkg.in/yaml.v3"
 
 	watchlistv1 "github.com/otherjamesbrown/penf-cli/api/proto/watchlist/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
@@ -81,15 +80,35 @@ Examples:
   # Output as JSON for programmatic use
   penf watch list --output json
 
+Daemon Mode:
+  With --daemon, watch polls for new high-priority review items and failed
+  pipeline jobs every --interval, and POSTs each new event as JSON to a
+  webhook. Use --filter to choose which events matter (review, job-failed).
+  Each event is sent once; events already present when the daemon starts
+  are not sent. Stop with Ctrl+C.
+
+  # Notify a webhook of new high-priority reviews and failed jobs
+  penf watch --daemon --webhook https://hooks.example.com/penf
+
+  # Only failed jobs, polling every 5 minutes (webhook from config)
+  penf watch --daemon --filter job-failed --interval 5m
+
 Related Commands:
   penf project      Manage projects
   penf search       Search for assertions`,
 		Aliases: []string{"watchlist"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !watchDaemon {
+				return cmd.Help()
+			}
+			return runWatchDaemon(cmd.Context(), deps)
+		},
 	}
 
 	// Add persistent flags.
 	cmd.PersistentFlags().StringVarP(&watchTenant, "tenant", "t", "", "Tenant ID (overrides config)")
 	cmd.PersistentFlags().StringVarP(&watchOutput, "output", "o", "", "Output format: text, json, yaml")
+	addWatchDaemonFlags(cmd)
 
 	// Add subcommands.
 	cmd.AddCommand(newWatchListCommand(deps))
@@ -216,7 +235,7 @@ Examples:
 
 // connectWatchToGateway creates a gRPC connection to the gateway service.
 func connectWatchToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetConnectTimeout())
 	defer cancel()
 
 	opts := []grpc.DialOption{
@@ -239,9 +258,11 @@ func connectWatchToGateway(cfg *config.CLIConfig) (*grpc.ClientConn, error) {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	conn, err := grpc.DialContext(ctx, cfg.ServerAddress, opts...)
+	opts = append(opts, client.GatewayDialOptions(cfg)...)
+
+	conn, err := client.Dial(ctx, "gateway at "+cfg.ServerAddress, cfg.ServerAddress, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("connecting to gateway at %s: %w", cfg.ServerAddress, err)
+		return nil, err
 	}
 
 	return conn, nil
@@ -506,7 +527,7 @@ func outputWatchJSON(v interface{}) error {
 }
 
 func outputWatchYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
+	enc := NewYAMLEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
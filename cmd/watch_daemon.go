@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	reviewv1 "github.com/otherjamesbrown/penf-cli/api/proto/review/v1"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Watch daemon event types, also accepted by --filter.
+const (
+	watchEventReview    = "review"
+	watchEventJobFailed = "job-failed"
+)
+
+// watchEventTypes lists every event type the daemon can report.
+var watchEventTypes = []string{watchEventReview, watchEventJobFailed}
+
+// watchDaemonPageSize is how many review items and failed jobs are fetched
+// per poll.
+const watchDaemonPageSize = 100
+
+// watchWebhookTimeout bounds a single webhook POST.
+const watchWebhookTimeout = 10 * time.Second
+
+// Watch daemon flags.
+var (
+	watchDaemon   bool
+	watchWebhook  string
+	watchInterval time.Duration
+	watchFilter   []string
+)
+
+// WatchEvent is a notification sent by 'watch --daemon'. It is the JSON
+// payload POSTed to the webhook.
+type WatchEvent struct {
+	Event      string    `json:"event" yaml:"event"` // review, job-failed
+	ID         string    `json:"id" yaml:"id"`
+	Summary    string    `json:"summary" yaml:"summary"`
+	Priority   string    `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Source     string    `json:"source,omitempty" yaml:"source,omitempty"`
+	ContentID  string    `json:"content_id,omitempty" yaml:"content_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at" yaml:"occurred_at"`
+	TenantID   string    `json:"tenant_id,omitempty" yaml:"tenant_id,omitempty"`
+}
+
+// key identifies an event for de-duplication.
+func (e WatchEvent) key() string {
+	return e.Event + "/" + e.ID
+}
+
+// addWatchDaemonFlags registers the daemon flags on the watch root command.
+func addWatchDaemonFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&watchDaemon, "daemon", false, "Poll for new events and send webhook notifications until interrupted")
+	cmd.Flags().StringVar(&watchWebhook, "webhook", "", "URL to POST notifications to (default: watch_webhook in config)")
+	cmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "How often to poll in daemon mode")
+	cmd.Flags().StringSliceVar(&watchFilter, "filter", nil, "Events to notify on: review, job-failed (default: all)")
+}
+
+// runWatchDaemon executes 'watch --daemon'.
+func runWatchDaemon(ctx context.Context, deps *WatchCommandDeps) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	events, err := parseWatchFilter(watchFilter)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	webhook := watchWebhook
+	if webhook == "" {
+		webhook = cfg.WatchWebhook
+	}
+	if webhook == "" {
+		return fmt.Errorf("no webhook URL: set --webhook, PENF_WATCH_WEBHOOK, or watch_webhook in config")
+	}
+
+	format := getWatchOutputFormat(cfg)
+	if !format.IsValid() {
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+
+	conn, err := connectWatchToGateway(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tenantID := getTenantIDForWatch(deps)
+	poll := newWatchPoller(reviewv1.NewReviewServiceClient(conn), pipelinev1.NewPipelineServiceClient(conn), tenantID, events)
+	notify := newWatchWebhookNotifier(http.DefaultClient, webhook)
+
+	fmt.Fprintf(os.Stderr, "Watching for %s every %s (Ctrl+C to stop)\n", strings.Join(events, ", "), watchInterval)
+	return watchDaemonLoop(ctx, watchInterval, poll, notify, func(e WatchEvent) {
+		printWatchEvent(os.Stdout, format, e)
+	})
+}
+
+// parseWatchFilter validates --filter values. An empty filter means every
+// event type.
+func parseWatchFilter(filter []string) ([]string, error) {
+	if len(filter) == 0 {
+		return watchEventTypes, nil
+	}
+	var events []string
+	seen := make(map[string]bool)
+	for _, f := range filter {
+		f = strings.ToLower(strings.TrimSpace(f))
+		valid := false
+		for _, t := range watchEventTypes {
+			if f == t {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid --filter %q (valid: %s)", f, strings.Join(watchEventTypes, ", "))
+		}
+		if !seen[f] {
+			seen[f] = true
+			events = append(events, f)
+		}
+	}
+	return events, nil
+}
+
+// watchPoller fetches the current set of notifiable events.
+type watchPoller func(ctx context.Context) ([]WatchEvent, error)
+
+// watchNotifier delivers one event.
+type watchNotifier func(ctx context.Context, e WatchEvent) error
+
+// newWatchPoller returns a poller for the given event types: pending review
+// items of high priority or above, and failed pipeline jobs.
+func newWatchPoller(reviews reviewv1.ReviewServiceClient, pipeline pipelinev1.PipelineServiceClient, tenantID string, events []string) watchPoller {
+	return func(ctx context.Context) ([]WatchEvent, error) {
+		var out []WatchEvent
+		for _, event := range events {
+			switch event {
+			case watchEventReview:
+				resp, err := reviews.ListReviewItems(ctx, &reviewv1.ListReviewItemsRequest{
+					TenantId:   &tenantID,
+					Statuses:   []reviewv1.ReviewStatus{reviewv1.ReviewStatus_REVIEW_STATUS_PENDING},
+					Priorities: []reviewv1.Priority{reviewv1.Priority_PRIORITY_HIGH, reviewv1.Priority_PRIORITY_URGENT},
+					PageSize:   watchDaemonPageSize,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("listing review items: %w", err)
+				}
+				for _, item := range resp.Items {
+					out = append(out, reviewItemWatchEvent(item, tenantID))
+				}
+			case watchEventJobFailed:
+				resp, err := pipeline.ListJobs(ctx, &pipelinev1.ListJobsRequest{
+					Limit:  watchDaemonPageSize,
+					Status: "failed",
+				})
+				if err != nil {
+					return nil, fmt.Errorf("listing pipeline jobs: %w", err)
+				}
+				for _, job := range resp.Jobs {
+					out = append(out, failedJobWatchEvent(job, tenantID))
+				}
+			}
+		}
+		return out, nil
+	}
+}
+
+// reviewItemWatchEvent converts a review queue item to a watch event.
+func reviewItemWatchEvent(item *reviewv1.ReviewItem, tenantID string) WatchEvent {
+	e := WatchEvent{
+		Event:     watchEventReview,
+		ID:        item.Id,
+		Summary:   item.ContentSummary,
+		Priority:  strings.ToLower(strings.TrimPrefix(item.Priority.String(), "PRIORITY_")),
+		Source:    item.Source,
+		ContentID: item.ContentId,
+		TenantID:  tenantID,
+	}
+	if item.CreatedAt != nil {
+		e.OccurredAt = item.CreatedAt.AsTime()
+	}
+	return e
+}
+
+// failedJobWatchEvent converts a failed pipeline job to a watch event.
+func failedJobWatchEvent(job *pipelinev1.JobSummary, tenantID string) WatchEvent {
+	e := WatchEvent{
+		Event:    watchEventJobFailed,
+		ID:       job.Id,
+		Summary:  fmt.Sprintf("Job failed: %d of %d files failed", job.FailedCount, job.TotalFiles),
+		Source:   job.SourceTag,
+		TenantID: tenantID,
+	}
+	switch {
+	case job.CompletedAt != nil:
+		e.OccurredAt = job.CompletedAt.AsTime()
+	case job.CreatedAt != nil:
+		e.OccurredAt = job.CreatedAt.AsTime()
+	}
+	return e
+}
+
+// newWatchWebhookNotifier returns a notifier that POSTs each event as JSON to
+// url. Any non-2xx response is an error.
+func newWatchWebhookNotifier(httpClient *http.Client, url string) watchNotifier {
+	return func(ctx context.Context, e WatchEvent) error {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("encoding event: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, watchWebhookTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("creating webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("posting to webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// watchDaemonLoop polls every interval until ctx is cancelled, notifying once
+// per new event. Events already present on the first poll are treated as
+// seen, so starting the daemon does not replay the existing backlog. An event
+// whose notification fails is retried on the next poll. Poll and notify
+// errors are reported to stderr and do not stop the loop.
+func watchDaemonLoop(ctx context.Context, interval time.Duration, poll watchPoller, notify watchNotifier, onNotified func(WatchEvent)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	seen := make(map[string]bool)
+	first := true
+	for {
+		events, err := poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			for _, e := range events {
+				key := e.key()
+				if seen[key] {
+					continue
+				}
+				if first {
+					seen[key] = true
+					continue
+				}
+				if err := notify(ctx, e); err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					fmt.Fprintf(os.Stderr, "Error: notifying %s %s: %v\n", e.Event, e.ID, err)
+					continue
+				}
+				seen[key] = true
+				if onNotified != nil {
+					onNotified(e)
+				}
+			}
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printWatchEvent reports a delivered notification.
+func printWatchEvent(w io.Writer, format config.OutputFormat, e WatchEvent) {
+	switch format {
+	case config.OutputFormatJSON:
+		// One JSON object per line, so the stream can be piped.
+		data, _ := json.Marshal(e)
+		fmt.Fprintln(w, string(data))
+	case config.OutputFormatYAML:
+		enc := NewYAMLEncoder(w)
+		_ = enc.Encode(e)
+		_ = enc.Close()
+	default:
+		priority := ""
+		if e.Priority != "" {
+			priority = " (" + e.Priority + ")"
+		}
+		fmt.Fprintf(w, "%s  %-10s  %s%s  %s\n", time.Now().Format("15:04:05"), e.Event, e.ID, priority, e.Summary)
+	}
+}
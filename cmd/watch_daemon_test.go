@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	reviewv1 "github.com/otherjamesbrown/penf-cli/api/proto/review/v1"
+)
+
+func TestParseWatchFilter(t *testing.T) {
+	events, err := parseWatchFilter(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{watchEventReview, watchEventJobFailed}, events)
+
+	events, err = parseWatchFilter([]string{"Job-Failed", "job-failed"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{watchEventJobFailed}, events)
+
+	_, err = parseWatchFilter([]string{"everything"})
+	assert.ErrorContains(t, err, "invalid --filter")
+}
+
+func TestWatchDaemonLoop_NotifiesNewEventsOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	a := WatchEvent{Event: watchEventReview, ID: "a"}
+	b := WatchEvent{Event: watchEventReview, ID: "b"}
+	c := WatchEvent{Event: watchEventJobFailed, ID: "c"}
+	polls := [][]WatchEvent{
+		{a},       // startup backlog: not notified
+		{a, b},    // b is new
+		{a, b, c}, // c is new, but the first delivery fails
+		{a, b, c}, // c is retried
+	}
+
+	calls := 0
+	poll := func(ctx context.Context) ([]WatchEvent, error) {
+		if calls == len(polls) {
+			cancel()
+			return nil, ctx.Err()
+		}
+		events := polls[calls]
+		calls++
+		return events, nil
+	}
+
+	var attempts, delivered []string
+	failedOnce := false
+	notify := func(ctx context.Context, e WatchEvent) error {
+		attempts = append(attempts, e.ID)
+		if e.ID == "c" && !failedOnce {
+			failedOnce = true
+			return errors.New("webhook down")
+		}
+		return nil
+	}
+
+	err := watchDaemonLoop(ctx, time.Millisecond, poll, notify, func(e WatchEvent) {
+		delivered = append(delivered, e.ID)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b", "c", "c"}, attempts)
+	assert.Equal(t, []string{"b", "c"}, delivered)
+}
+
+func TestWatchDaemonLoop_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	poll := func(ctx context.Context) ([]WatchEvent, error) { return nil, nil }
+	notify := func(ctx context.Context, e WatchEvent) error { return nil }
+
+	assert.NoError(t, watchDaemonLoop(ctx, time.Hour, poll, notify, nil))
+}
+
+func TestWatchWebhookNotifier(t *testing.T) {
+	var got WatchEvent
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		contentType = r.Header.Get("Content-Type")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := WatchEvent{Event: watchEventReview, ID: "rvw-1", Summary: "Check this", Priority: "high"}
+	notify := newWatchWebhookNotifier(server.Client(), server.URL)
+
+	require.NoError(t, notify(t.Context(), event))
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, event.ID, got.ID)
+	assert.Equal(t, event.Priority, got.Priority)
+}
+
+func TestWatchWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notify := newWatchWebhookNotifier(server.Client(), server.URL)
+	err := notify(t.Context(), WatchEvent{Event: watchEventJobFailed, ID: "job-1"})
+	assert.ErrorContains(t, err, "502")
+}
+
+func TestWatchEventConversion(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	review := reviewItemWatchEvent(&reviewv1.ReviewItem{
+		Id:             "rvw-1",
+		ContentSummary: "Contract renewal",
+		Priority:       reviewv1.Priority_PRIORITY_URGENT,
+		CreatedAt:      timestamppb.New(created),
+	}, "tenant-1")
+	assert.Equal(t, watchEventReview, review.Event)
+	assert.Equal(t, "urgent", review.Priority)
+	assert.Equal(t, created, review.OccurredAt)
+
+	completed := created.Add(time.Hour)
+	job := failedJobWatchEvent(&pipelinev1.JobSummary{
+		Id:          "job-1",
+		SourceTag:   "gmail",
+		TotalFiles:  10,
+		FailedCount: 3,
+		CreatedAt:   timestamppb.New(created),
+		CompletedAt: timestamppb.New(completed),
+	}, "tenant-1")
+	assert.Equal(t, watchEventJobFailed, job.Event)
+	assert.Equal(t, "Job failed: 3 of 10 files failed", job.Summary)
+	assert.Equal(t, completed, job.OccurredAt)
+}
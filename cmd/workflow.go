@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,7 +11,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -79,6 +79,8 @@ type WorkflowCommandDeps struct {
 	GetWorkflowStatusFn  func(context.Context, string, string) (*client.WorkflowStatusDetails, error)
 	CancelWorkflowFn     func(context.Context, string, string, string) (*client.CancelWorkflowResult, error)
 	TerminateWorkflowFn  func(context.Context, string, string, string) (*client.CancelWorkflowResult, error)
+	GetWorkflowHistoryFn func(context.Context, string, string, []byte) (*client.WorkflowHistoryPage, error)
+	ConfirmFn            func(prompt string) (bool, error)
 }
 
 // DefaultWorkflowDeps returns the default dependencies for production use.
@@ -97,6 +99,8 @@ var (
 	workflowOutput string
 	workflowWatch  bool
 	workflowForce  bool
+	workflowYes    bool
+	workflowReason string
 )
 
 // NewWorkflowCommand creates the root workflow command with all subcommands.
@@ -117,6 +121,7 @@ or scheduled tasks. Use these commands to monitor and manage workflow execution.
 Commands:
   list      - List all workflows
   status    - Show detailed workflow status
+  describe  - Show event history as a timeline of activities
   cancel    - Cancel a running workflow
   terminate - Terminate a workflow immediately
 
@@ -130,6 +135,9 @@ Examples:
   # Check status of a specific workflow
   penf workflow status wf-abc123
 
+  # See which activity failed and why
+  penf workflow describe wf-abc123
+
   # Cancel a running workflow (graceful)
   penf workflow cancel wf-abc123
 
@@ -144,6 +152,7 @@ Related Commands:
 	// Add subcommands.
 	cmd.AddCommand(newWorkflowListCommand(deps))
 	cmd.AddCommand(newWorkflowStatusCommand(deps))
+	cmd.AddCommand(newWorkflowDescribeCommand(deps))
 	cmd.AddCommand(newWorkflowCancelCommand(deps))
 	cmd.AddCommand(newWorkflowTerminateCommand(deps))
 
@@ -229,13 +238,25 @@ func newWorkflowCancelCommand(deps *WorkflowCommandDeps) *cobra.Command {
 		Short: "Cancel a running workflow",
 		Long: `Cancel a running or pending workflow.
 
-This will attempt to gracefully stop the workflow. Already completed steps
-will not be rolled back. Use --force to immediately terminate the workflow.
+This requests a graceful stop: Temporal delivers the cancellation to the
+workflow, which can run its cleanup before it closes. Already completed steps
+will not be rolled back. If the workflow does not respond to cancellation,
+use 'penf workflow terminate' instead.
+
+The workflow's current state is shown and confirmation is requested before
+cancelling, unless --yes is given. The state after the request is shown
+once it has been sent.
+
+--force skips the graceful stop and terminates the workflow immediately,
+without prompting, like 'penf workflow terminate --yes'.
 
 Examples:
   # Cancel a workflow
   penf workflow cancel wf-abc123
 
+  # Cancel without prompting, recording why
+  penf workflow cancel wf-abc123 --yes --reason "duplicate ingest"
+
   # Force cancel (immediate termination)
   penf workflow cancel wf-abc123 --force`,
 		Args: cobra.ExactArgs(1),
@@ -245,9 +266,11 @@ Examples:
 	}
 
 	// Define flags.
-	cmd.Flags().BoolVarP(&workflowForce, "force", "f", false, "Force immediate termination")
+	cmd.Flags().BoolVarP(&workflowForce, "force", "f", false, "Terminate immediately instead of cancelling gracefully, without prompting")
+	cmd.Flags().BoolVarP(&workflowYes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&workflowReason, "reason", "", "Reason recorded with the cancellation")
 
-	return cmd
+	return withEnvelope(cmd)
 }
 
 // newWorkflowTerminateCommand creates the 'workflow terminate' subcommand.
@@ -264,19 +287,28 @@ Terminate is more forceful than cancel:
 - Cancel requests graceful shutdown (workflow can still run cleanup)
 - Terminate stops execution immediately (no cleanup allowed)
 
+The workflow's current state is shown and confirmation is requested before
+terminating, unless --yes (or --force) is given. The reason is recorded in
+the workflow's history.
+
 Examples:
   # Terminate a stuck workflow
-  penf workflow terminate wf-abc123
+  penf workflow terminate wf-abc123 --reason "stuck on embedding activity"
 
-  # Terminate when cancel doesn't work
-  penf workflow terminate wf-abc123`,
+  # Terminate without prompting (for scripts)
+  penf workflow terminate wf-abc123 --reason "cancel did not stop it" --yes`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runWorkflowTerminate(cmd.Context(), deps, args[0])
 		},
 	}
 
-	return cmd
+	// Define flags.
+	cmd.Flags().BoolVarP(&workflowYes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVarP(&workflowForce, "force", "f", false, "Same as --yes")
+	cmd.Flags().StringVar(&workflowReason, "reason", "", "Reason recorded in the workflow history")
+
+	return withEnvelope(cmd)
 }
 
 // runWorkflowList executes the workflow list command.
@@ -530,95 +562,212 @@ func runWorkflowWatch(ctx context.Context, deps *WorkflowCommandDeps, workflowID
 }
 
 // runWorkflowCancel executes the workflow cancel command.
+// With --force it terminates the workflow instead.
 func runWorkflowCancel(ctx context.Context, deps *WorkflowCommandDeps, workflowID string) error {
-	cfg, err := deps.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("loading configuration: %w", err)
-	}
-	deps.Config = cfg
+	if workflowForce {
+		return runWorkflowControl(ctx, deps, workflowID, workflowControlAction{
+			verb:          "terminate",
+			title:         "Terminate",
+			progress:      "Force cancelling",
+			defaultReason: "Terminated via CLI (--force)",
+			terminate:     true,
+		})
+	}
+	return runWorkflowControl(ctx, deps, workflowID, workflowControlAction{
+		verb:          "cancel",
+		title:         "Cancel",
+		progress:      "Cancelling",
+		defaultReason: "Cancelled via CLI",
+	})
+}
 
-	var grpcClient *client.GRPCClient
-	var result *client.CancelWorkflowResult
+// runWorkflowTerminate executes the workflow terminate command.
+func runWorkflowTerminate(ctx context.Context, deps *WorkflowCommandDeps, workflowID string) error {
+	return runWorkflowControl(ctx, deps, workflowID, workflowControlAction{
+		verb:          "terminate",
+		title:         "Terminate",
+		progress:      "Terminating",
+		defaultReason: "Terminated via CLI",
+		terminate:     true,
+	})
+}
 
-	// Use mock functions if provided (for testing).
-	if workflowForce {
-		fmt.Printf("Force cancelling workflow %s...\n", workflowID)
-		if deps.TerminateWorkflowFn != nil {
-			result, err = deps.TerminateWorkflowFn(ctx, workflowID, "", "Terminated via CLI (--force)")
-		} else {
-			// Initialize gRPC client.
-			grpcClient, err = deps.InitClient(cfg)
-			if err != nil {
-				return fmt.Errorf("initializing client: %w", err)
-			}
-			defer grpcClient.Close()
-			result, err = grpcClient.TerminateWorkflow(ctx, workflowID, "", "Terminated via CLI (--force)")
-		}
-	} else {
-		fmt.Printf("Cancelling workflow %s...\n", workflowID)
-		if deps.CancelWorkflowFn != nil {
-			result, err = deps.CancelWorkflowFn(ctx, workflowID, "", "Cancelled via CLI")
-		} else {
-			// Initialize gRPC client.
-			grpcClient, err = deps.InitClient(cfg)
-			if err != nil {
-				return fmt.Errorf("initializing client: %w", err)
-			}
-			defer grpcClient.Close()
-			result, err = grpcClient.CancelWorkflow(ctx, workflowID, "", "Cancelled via CLI")
-		}
+// workflowControlAction describes a cancel or terminate request.
+type workflowControlAction struct {
+	verb          string
+	title         string
+	progress      string
+	defaultReason string
+	terminate     bool
+}
+
+// WorkflowControlResult is the --output json result of 'workflow cancel'
+// and 'workflow terminate'.
+type WorkflowControlResult struct {
+	WorkflowID  string `json:"workflow_id"`
+	Action      string `json:"action"`
+	Reason      string `json:"reason,omitempty"`
+	Accepted    bool   `json:"accepted"`
+	StateBefore string `json:"state_before,omitempty"`
+	StateAfter  string `json:"state_after,omitempty"`
+}
+
+// workflowControlClient holds the workflow calls used by cancel, terminate
+// and describe, taken from the test overrides or a gateway connection.
+type workflowControlClient struct {
+	status    func(context.Context, string, string) (*client.WorkflowStatusDetails, error)
+	cancel    func(context.Context, string, string, string) (*client.CancelWorkflowResult, error)
+	terminate func(context.Context, string, string, string) (*client.CancelWorkflowResult, error)
+	history   func(context.Context, string, string, []byte) (*client.WorkflowHistoryPage, error)
+	close     func()
+}
+
+// newWorkflowControlClient resolves the workflow calls, connecting to the
+// gateway only when an override is missing.
+func newWorkflowControlClient(deps *WorkflowCommandDeps, cfg *config.CLIConfig) (*workflowControlClient, error) {
+	c := &workflowControlClient{
+		status:    deps.GetWorkflowStatusFn,
+		cancel:    deps.CancelWorkflowFn,
+		terminate: deps.TerminateWorkflowFn,
+		history:   deps.GetWorkflowHistoryFn,
+		close:     func() {},
+	}
+	if c.status != nil && c.cancel != nil && c.terminate != nil && c.history != nil {
+		return c, nil
 	}
 
+	grpcClient, err := deps.InitClient(cfg)
 	if err != nil {
-		return fmt.Errorf("cancelling workflow: %w", err)
+		return nil, err
 	}
-
-	if result.Accepted {
-		fmt.Printf("\n%s\n", result.Message)
-	} else {
-		fmt.Printf("\nFailed to cancel workflow: %s\n", result.Message)
+	c.close = func() { grpcClient.Close() }
+	if c.status == nil {
+		c.status = grpcClient.GetWorkflowStatus
 	}
-
-	return nil
+	if c.cancel == nil {
+		c.cancel = grpcClient.CancelWorkflow
+	}
+	if c.terminate == nil {
+		c.terminate = grpcClient.TerminateWorkflow
+	}
+	if c.history == nil {
+		c.history = grpcClient.GetWorkflowHistory
+	}
+	return c, nil
 }
 
-// runWorkflowTerminate executes the workflow terminate command.
-func runWorkflowTerminate(ctx context.Context, deps *WorkflowCommandDeps, workflowID string) error {
+// runWorkflowControl shows the workflow's state, asks for confirmation
+// unless --yes or --force is set, sends the cancel or terminate request and shows
+// the resulting state.
+func runWorkflowControl(ctx context.Context, deps *WorkflowCommandDeps, workflowID string, action workflowControlAction) error {
 	cfg, err := deps.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 	deps.Config = cfg
 
-	var grpcClient *client.GRPCClient
-	var result *client.CancelWorkflowResult
+	wc, err := newWorkflowControlClient(deps, cfg)
+	if err != nil {
+		return fmt.Errorf("initializing client: %w", err)
+	}
+	defer wc.close()
 
-	fmt.Printf("Terminating workflow %s...\n", workflowID)
+	w := resultOut()
+	outcome := WorkflowControlResult{WorkflowID: workflowID, Action: action.verb}
 
-	// Use mock function if provided (for testing).
-	if deps.TerminateWorkflowFn != nil {
-		result, err = deps.TerminateWorkflowFn(ctx, workflowID, "", "Terminated via CLI")
-	} else {
-		// Initialize gRPC client.
-		grpcClient, err = deps.InitClient(cfg)
+	before, err := wc.status(ctx, workflowID, "")
+	if err != nil {
+		return fmt.Errorf("getting workflow status: %w", err)
+	}
+	outcome.StateBefore = before.Status
+	if isClosedWorkflowStatus(before.Status) {
+		msg := fmt.Sprintf("Workflow %s is already %s; nothing to %s.", workflowID, strings.ToLower(before.Status), action.verb)
+		fmt.Fprintln(w, msg)
+		return writeResult(outcome, msg)
+	}
+
+	fmt.Fprintf(w, "Workflow: %s\n", workflowID)
+	fmt.Fprintf(w, "Type:     %s\n", before.WorkflowType)
+	fmt.Fprintf(w, "State:    %s\n", before.Status)
+	if !before.StartTime.IsZero() {
+		fmt.Fprintf(w, "Started:  %s\n", before.StartTime.Format(time.RFC3339))
+	}
+	if before.PendingActivities > 0 {
+		fmt.Fprintf(w, "Pending:  %d activities\n", before.PendingActivities)
+	}
+	fmt.Fprintln(w)
+
+	if !workflowYes && !workflowForce {
+		confirm := deps.ConfirmFn
+		if confirm == nil {
+			confirm = confirmWorkflowAction
+		}
+		ok, err := confirm(fmt.Sprintf("%s workflow %s? [y/N] ", action.title, workflowID))
 		if err != nil {
-			return fmt.Errorf("initializing client: %w", err)
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(w, "Aborted.")
+			return writeOutcome(false, outcome, "Aborted.")
 		}
-		defer grpcClient.Close()
-		result, err = grpcClient.TerminateWorkflow(ctx, workflowID, "", "Terminated via CLI")
 	}
 
+	reason := workflowReason
+	if reason == "" {
+		reason = action.defaultReason
+	}
+	outcome.Reason = reason
+
+	fmt.Fprintf(w, "%s workflow %s...\n", action.progress, workflowID)
+	send := wc.cancel
+	if action.terminate {
+		send = wc.terminate
+	}
+	result, err := send(ctx, workflowID, "", reason)
 	if err != nil {
-		return fmt.Errorf("terminating workflow: %w", err)
+		return fmt.Errorf("%s workflow: %w", strings.ToLower(action.progress), err)
+	}
+	outcome.Accepted = result.Accepted
+	if !result.Accepted {
+		fmt.Fprintf(w, "\nFailed to %s workflow: %s\n", action.verb, result.Message)
+		return writeOutcome(false, outcome, result.Message)
 	}
+	fmt.Fprintf(w, "\n%s\n", result.Message)
 
-	if result.Accepted {
-		fmt.Printf("\n%s\n", result.Message)
+	after, err := wc.status(ctx, workflowID, "")
+	if err != nil {
+		fmt.Fprintf(w, "State:    unknown (%v)\n", err)
+		return writeResult(outcome, result.Message)
+	}
+	outcome.StateAfter = after.Status
+	if isClosedWorkflowStatus(after.Status) {
+		fmt.Fprintf(w, "State:    %s\n", after.Status)
 	} else {
-		fmt.Printf("\nFailed to terminate workflow: %s\n", result.Message)
+		fmt.Fprintf(w, "State:    %s (%s requested; check again with 'penf workflow status %s')\n", after.Status, action.verb, workflowID)
 	}
+	return writeResult(outcome, result.Message)
+}
 
-	return nil
+// isClosedWorkflowStatus reports whether a workflow status is final.
+func isClosedWorkflowStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "completed", "failed", "canceled", "cancelled", "terminated", "timedout", "timed_out":
+		return true
+	default:
+		return false
+	}
+}
+
+// confirmWorkflowAction prints prompt to stderr, keeping stdout for the
+// result, and reads a yes/no answer from stdin.
+func confirmWorkflowAction(prompt string) (bool, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
 }
 
 // mapAPIStatusToWorkflowStatus maps API status string to WorkflowStatus.
@@ -649,7 +798,7 @@ func outputWorkflowList(format config.OutputFormat, response WorkflowListRespons
 		enc.SetIndent("", "  ")
 		return enc.Encode(response)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(response)
 	default:
 		return outputWorkflowListText(response)
@@ -687,7 +836,7 @@ func outputWorkflowStatus(format config.OutputFormat, workflow *Workflow) error
 		enc.SetIndent("", "  ")
 		return enc.Encode(workflow)
 	case config.OutputFormatYAML:
-		enc := yaml.NewEncoder(os.Stdout)
+		enc := NewYAMLEncoder(os.Stdout)
 		return enc.Encode(workflow)
 	default:
 		return outputWorkflowStatusText(workflow)
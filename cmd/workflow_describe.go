@@ -0,0 +1,477 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+	"github.com/otherjamesbrown/penf-cli/config"
+)
+
+// Workflow describe flags.
+var workflowRunID string
+
+// workflowHistoryMaxPages bounds history paging for very long workflows;
+// the output says when the history was cut short.
+const workflowHistoryMaxPages = 50
+
+// WorkflowTimelineEntry is one row of a workflow's timeline: a workflow
+// lifecycle event, or an activity, timer or child workflow from when it was
+// scheduled to when it closed.
+type WorkflowTimelineEntry struct {
+	EventID    int64     `json:"event_id" yaml:"event_id"`
+	Time       time.Time `json:"time" yaml:"time"`
+	Kind       string    `json:"kind" yaml:"kind"`
+	Name       string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Status     string    `json:"status" yaml:"status"`
+	Attempt    int32     `json:"attempt,omitempty" yaml:"attempt,omitempty"`
+	StartedAt  time.Time `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	ClosedAt   time.Time `json:"closed_at,omitempty" yaml:"closed_at,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty" yaml:"duration_ms,omitempty"`
+	Failure    string    `json:"failure,omitempty" yaml:"failure,omitempty"`
+}
+
+// WorkflowDescription is the output of 'workflow describe'.
+type WorkflowDescription struct {
+	WorkflowID   string                  `json:"workflow_id" yaml:"workflow_id"`
+	RunID        string                  `json:"run_id,omitempty" yaml:"run_id,omitempty"`
+	WorkflowType string                  `json:"workflow_type" yaml:"workflow_type"`
+	Status       string                  `json:"status" yaml:"status"`
+	TaskQueue    string                  `json:"task_queue,omitempty" yaml:"task_queue,omitempty"`
+	StartTime    time.Time               `json:"start_time" yaml:"start_time"`
+	CloseTime    time.Time               `json:"close_time,omitempty" yaml:"close_time,omitempty"`
+	EventCount   int                     `json:"event_count" yaml:"event_count"`
+	Truncated    bool                    `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+	Timeline     []WorkflowTimelineEntry `json:"timeline" yaml:"timeline"`
+}
+
+// newWorkflowDescribeCommand creates the 'workflow describe' subcommand.
+func newWorkflowDescribeCommand(deps *WorkflowCommandDeps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe <workflow-id>",
+		Short: "Show a workflow's event history as a timeline",
+		Long: `Show a workflow's event history as a timeline of activities.
+
+Each activity is shown from when it was scheduled to when it completed,
+failed, timed out or was cancelled, with its attempt count, duration and
+failure message. Timers, child workflows and the workflow's own start and
+close events are included, similar to 'tctl workflow show'.
+
+The history and workflow summary are read through the gateway. Very long
+histories are cut off after ` + strconv.Itoa(workflowHistoryMaxPages) + ` pages, and the output says so.
+
+Flags:
+  --run-id            Run to describe (default: latest run)
+  -o, --output        Output format: text, json (raw history events), yaml (timeline)
+
+Examples:
+  # Why did this workflow fail?
+  penf workflow describe wf-abc123
+
+  # Raw event history for scripting
+  penf workflow describe wf-abc123 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkflowDescribe(cmd.Context(), deps, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&workflowRunID, "run-id", "", "Run ID (default: latest run)")
+	cmd.Flags().StringVarP(&workflowOutput, "output", "o", "", "Output format: text, json, yaml")
+
+	return cmd
+}
+
+// runWorkflowDescribe executes the workflow describe command.
+func runWorkflowDescribe(ctx context.Context, deps *WorkflowCommandDeps, workflowID string) error {
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	deps.Config = cfg
+
+	outputFormat := cfg.OutputFormat
+	if workflowOutput != "" {
+		outputFormat = config.OutputFormat(workflowOutput)
+		if !outputFormat.IsValid() {
+			return fmt.Errorf("invalid output format: %s", workflowOutput)
+		}
+	}
+
+	wc, err := newWorkflowControlClient(deps, cfg)
+	if err != nil {
+		return fmt.Errorf("initializing client: %w", err)
+	}
+	defer wc.close()
+
+	events, truncated, err := fetchWorkflowHistory(ctx, wc.history, workflowID, workflowRunID)
+	if err != nil {
+		return fmt.Errorf("getting workflow history: %w", err)
+	}
+
+	if outputFormat == config.OutputFormatJSON {
+		return outputJSON(map[string]interface{}{"events": events, "truncated": truncated})
+	}
+
+	desc := &WorkflowDescription{WorkflowID: workflowID, RunID: workflowRunID, EventCount: len(events), Truncated: truncated}
+	desc.Timeline, err = buildWorkflowTimeline(events)
+	if err != nil {
+		return err
+	}
+
+	// The summary comes from the gateway's status call when it succeeds,
+	// and is otherwise reconstructed from the history.
+	fillWorkflowDescriptionFromTimeline(desc)
+	if status, err := wc.status(ctx, workflowID, workflowRunID); err == nil {
+		desc.RunID = status.RunID
+		desc.WorkflowType = status.WorkflowType
+		desc.Status = status.Status
+		desc.TaskQueue = status.TaskQueue
+		desc.StartTime = status.StartTime
+		desc.CloseTime = status.CloseTime
+	}
+
+	if outputFormat == config.OutputFormatYAML {
+		return outputYAML(desc)
+	}
+	outputWorkflowDescriptionText(desc)
+	return nil
+}
+
+// temporalFailure is a Temporal failure in its JSON encoding.
+type temporalFailure struct {
+	Message                string           `json:"message"`
+	Cause                  *temporalFailure `json:"cause"`
+	ApplicationFailureInfo *struct {
+		Type string `json:"type"`
+	} `json:"applicationFailureInfo"`
+}
+
+// String renders the failure and its causes on one line.
+func (f *temporalFailure) String() string {
+	var parts []string
+	for c := f; c != nil; c = c.Cause {
+		msg := c.Message
+		if c.ApplicationFailureInfo != nil && c.ApplicationFailureInfo.Type != "" {
+			msg = c.ApplicationFailureInfo.Type + ": " + msg
+		}
+		if msg != "" {
+			parts = append(parts, msg)
+		}
+	}
+	return strings.Join(parts, ": caused by: ")
+}
+
+// temporalInt64 decodes an int64 sent either as a JSON number or, as
+// protobuf JSON does, a string.
+type temporalInt64 int64
+
+func (n *temporalInt64) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = temporalInt64(v)
+	return nil
+}
+
+// temporalEventAttributes holds the fields of the per-event attributes
+// that the timeline uses.
+type temporalEventAttributes struct {
+	ActivityType struct {
+		Name string `json:"name"`
+	} `json:"activityType"`
+	WorkflowType struct {
+		Name string `json:"name"`
+	} `json:"workflowType"`
+	TimerID          string           `json:"timerId"`
+	ScheduledEventID temporalInt64    `json:"scheduledEventId"`
+	InitiatedEventID temporalInt64    `json:"initiatedEventId"`
+	StartedEventID   temporalInt64    `json:"startedEventId"`
+	Attempt          int32            `json:"attempt"`
+	Reason           string           `json:"reason"`
+	Failure          *temporalFailure `json:"failure"`
+	LastFailure      *temporalFailure `json:"lastFailure"`
+}
+
+// temporalEvent is one history event.
+type temporalEvent struct {
+	EventID    temporalInt64
+	EventTime  time.Time
+	EventType  string
+	Attributes temporalEventAttributes
+}
+
+// parseTemporalEvent decodes an event, taking its attributes from whichever
+// "...EventAttributes" field it carries. The event type is normalized from
+// either "EVENT_TYPE_ACTIVITY_TASK_STARTED" or "ActivityTaskStarted" to
+// "activitytaskstarted".
+func parseTemporalEvent(raw json.RawMessage) (*temporalEvent, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decoding history event: %w", err)
+	}
+
+	ev := &temporalEvent{}
+	var eventType string
+	for key, value := range fields {
+		var err error
+		switch {
+		case key == "eventId":
+			err = json.Unmarshal(value, &ev.EventID)
+		case key == "eventTime":
+			err = json.Unmarshal(value, &ev.EventTime)
+		case key == "eventType":
+			err = json.Unmarshal(value, &eventType)
+		case strings.HasSuffix(key, "EventAttributes"):
+			err = json.Unmarshal(value, &ev.Attributes)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding history event field %s: %w", key, err)
+		}
+	}
+	eventType = strings.TrimPrefix(eventType, "EVENT_TYPE_")
+	ev.EventType = strings.ToLower(strings.ReplaceAll(eventType, "_", ""))
+	return ev, nil
+}
+
+// buildWorkflowTimeline folds history events into timeline entries: one per
+// activity, timer and child workflow, updated as later events close them,
+// plus the workflow's own lifecycle events.
+func buildWorkflowTimeline(events []json.RawMessage) ([]WorkflowTimelineEntry, error) {
+	timeline := []WorkflowTimelineEntry{}
+	// byEvent maps the event that opened an entry to its index.
+	byEvent := map[int64]int{}
+
+	open := func(ev *temporalEvent, kind, name string) {
+		timeline = append(timeline, WorkflowTimelineEntry{
+			EventID: int64(ev.EventID),
+			Time:    ev.EventTime,
+			Kind:    kind,
+			Name:    name,
+			Status:  "scheduled",
+		})
+		byEvent[int64(ev.EventID)] = len(timeline) - 1
+	}
+	update := func(opening temporalInt64, ev *temporalEvent, status string, failure *temporalFailure) {
+		i, ok := byEvent[int64(opening)]
+		if !ok {
+			return
+		}
+		e := &timeline[i]
+		e.Status = status
+		switch status {
+		case "started":
+			e.StartedAt = ev.EventTime
+			e.Attempt = ev.Attributes.Attempt
+		default:
+			e.ClosedAt = ev.EventTime
+			from := e.StartedAt
+			if from.IsZero() {
+				from = e.Time
+			}
+			e.DurationMs = ev.EventTime.Sub(from).Milliseconds()
+		}
+		if failure != nil {
+			e.Failure = failure.String()
+		}
+	}
+	lifecycle := func(ev *temporalEvent, status, detail string) {
+		timeline = append(timeline, WorkflowTimelineEntry{
+			EventID: int64(ev.EventID),
+			Time:    ev.EventTime,
+			Kind:    "workflow",
+			Name:    ev.Attributes.WorkflowType.Name,
+			Status:  status,
+			Failure: detail,
+		})
+	}
+
+	for _, raw := range events {
+		ev, err := parseTemporalEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		a := ev.Attributes
+		failure := ""
+		if a.Failure != nil {
+			failure = a.Failure.String()
+		}
+
+		switch ev.EventType {
+		case "workflowexecutionstarted":
+			lifecycle(ev, "started", "")
+		case "workflowexecutioncompleted":
+			lifecycle(ev, "completed", "")
+		case "workflowexecutionfailed":
+			lifecycle(ev, "failed", failure)
+		case "workflowexecutiontimedout":
+			lifecycle(ev, "timed_out", "")
+		case "workflowexecutioncanceled":
+			lifecycle(ev, "canceled", "")
+		case "workflowexecutioncancelrequested":
+			lifecycle(ev, "cancel_requested", a.Reason)
+		case "workflowexecutionterminated":
+			lifecycle(ev, "terminated", a.Reason)
+		case "workflowexecutioncontinuedasnew":
+			lifecycle(ev, "continued_as_new", "")
+
+		case "activitytaskscheduled":
+			open(ev, "activity", a.ActivityType.Name)
+		case "activitytaskstarted":
+			update(a.ScheduledEventID, ev, "started", a.LastFailure)
+		case "activitytaskcompleted":
+			update(a.ScheduledEventID, ev, "completed", nil)
+		case "activitytaskfailed":
+			update(a.ScheduledEventID, ev, "failed", a.Failure)
+		case "activitytasktimedout":
+			update(a.ScheduledEventID, ev, "timed_out", a.Failure)
+		case "activitytaskcanceled":
+			update(a.ScheduledEventID, ev, "canceled", nil)
+
+		case "timerstarted":
+			open(ev, "timer", a.TimerID)
+		case "timerfired":
+			update(a.StartedEventID, ev, "fired", nil)
+		case "timercanceled":
+			update(a.StartedEventID, ev, "canceled", nil)
+
+		case "startchildworkflowexecutioninitiated":
+			open(ev, "child", a.WorkflowType.Name)
+		case "childworkflowexecutionstarted":
+			update(a.InitiatedEventID, ev, "started", nil)
+		case "childworkflowexecutioncompleted":
+			update(a.InitiatedEventID, ev, "completed", nil)
+		case "childworkflowexecutionfailed":
+			update(a.InitiatedEventID, ev, "failed", a.Failure)
+		case "childworkflowexecutiontimedout":
+			update(a.InitiatedEventID, ev, "timed_out", nil)
+		case "childworkflowexecutioncanceled":
+			update(a.InitiatedEventID, ev, "canceled", nil)
+		case "childworkflowexecutionterminated":
+			update(a.InitiatedEventID, ev, "terminated", nil)
+		}
+	}
+	return timeline, nil
+}
+
+// fillWorkflowDescriptionFromTimeline sets the type, status and times from
+// the history when the gateway could not supply them.
+func fillWorkflowDescriptionFromTimeline(desc *WorkflowDescription) {
+	desc.Status = "Running"
+	for _, e := range desc.Timeline {
+		if e.Kind != "workflow" {
+			continue
+		}
+		switch e.Status {
+		case "started":
+			desc.WorkflowType = e.Name
+			desc.StartTime = e.Time
+		case "cancel_requested":
+		default:
+			desc.Status = e.Status
+			desc.CloseTime = e.Time
+		}
+	}
+}
+
+// fetchWorkflowHistory reads a workflow's event history page by page,
+// stopping after workflowHistoryMaxPages. It reports whether pages were left
+// unread.
+func fetchWorkflowHistory(ctx context.Context, getPage func(context.Context, string, string, []byte) (*client.WorkflowHistoryPage, error), workflowID, runID string) ([]json.RawMessage, bool, error) {
+	var events []json.RawMessage
+	var pageToken []byte
+	for page := 0; page < workflowHistoryMaxPages; page++ {
+		resp, err := getPage(ctx, workflowID, runID, pageToken)
+		if err != nil {
+			return nil, false, err
+		}
+		events = append(events, resp.Events...)
+		if len(resp.NextPageToken) == 0 {
+			return events, false, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+	return events, true, nil
+}
+
+// outputWorkflowDescriptionText displays a workflow's summary and timeline.
+func outputWorkflowDescriptionText(desc *WorkflowDescription) {
+	fmt.Printf("\033[1mWorkflow: %s\033[0m\n", desc.WorkflowID)
+	if desc.RunID != "" {
+		fmt.Printf("  Run ID:     %s\n", desc.RunID)
+	}
+	fmt.Printf("  Type:       %s\n", desc.WorkflowType)
+	statusColor := getWorkflowStatusColor(mapAPIStatusToWorkflowStatus(desc.Status))
+	fmt.Printf("  Status:     %s%s\033[0m\n", statusColor, desc.Status)
+	if desc.TaskQueue != "" {
+		fmt.Printf("  Task queue: %s\n", desc.TaskQueue)
+	}
+	if !desc.StartTime.IsZero() {
+		fmt.Printf("  Started:    %s\n", desc.StartTime.Local().Format("2006-01-02 15:04:05"))
+	}
+	if !desc.CloseTime.IsZero() {
+		fmt.Printf("  Closed:     %s (%s)\n", desc.CloseTime.Local().Format("2006-01-02 15:04:05"),
+			formatDuration(desc.CloseTime.Sub(desc.StartTime)))
+	}
+	if desc.Truncated {
+		fmt.Printf("  Events:     %d (history truncated after %d pages; later events not shown)\n\n", desc.EventCount, workflowHistoryMaxPages)
+	} else {
+		fmt.Printf("  Events:     %d\n\n", desc.EventCount)
+	}
+
+	if len(desc.Timeline) == 0 {
+		fmt.Println("No history events found.")
+		return
+	}
+
+	fmt.Printf("  %-8s %-6s %-9s %-36s %-17s %-7s %s\n", "TIME", "ID", "KIND", "NAME", "STATUS", "ATTEMPT", "DURATION")
+	fmt.Println("  " + strings.Repeat("─", 100))
+	for _, e := range desc.Timeline {
+		attempt, duration := "", ""
+		if e.Attempt > 0 {
+			attempt = strconv.Itoa(int(e.Attempt))
+		}
+		if !e.ClosedAt.IsZero() {
+			duration = formatDuration(time.Duration(e.DurationMs) * time.Millisecond)
+		}
+		fmt.Printf("  %-8s %-6d %-9s %-36s %s%-17s\033[0m %-7s %s\n",
+			e.Time.Local().Format("15:04:05"),
+			e.EventID,
+			e.Kind,
+			truncateString(e.Name, 36),
+			workflowTimelineColor(e.Status),
+			e.Status,
+			attempt,
+			duration)
+		if e.Failure != "" {
+			fmt.Printf("  %-8s \033[31m└ %s\033[0m\n", "", e.Failure)
+		}
+	}
+}
+
+// workflowTimelineColor returns the color for a timeline status.
+func workflowTimelineColor(status string) string {
+	switch status {
+	case "completed", "fired":
+		return "\033[32m"
+	case "started", "scheduled", "cancel_requested":
+		return "\033[34m"
+	case "failed", "timed_out", "terminated":
+		return "\033[31m"
+	case "canceled", "continued_as_new":
+		return "\033[90m"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/otherjamesbrown/penf-cli/client"
+)
+
+// sampleWorkflowHistory is a failed workflow's history in Temporal's JSON
+// encoding: one activity that succeeds and one that fails after retries.
+const sampleWorkflowHistory = `[
+  {"eventId": "1", "eventTime": "2024-05-01T10:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED",
+   "workflowExecutionStartedEventAttributes": {"workflowType": {"name": "EmailProcessingWorkflow"}}},
+  {"eventId": "5", "eventTime": "2024-05-01T10:00:01Z", "eventType": "EVENT_TYPE_ACTIVITY_TASK_SCHEDULED",
+   "activityTaskScheduledEventAttributes": {"activityId": "5", "activityType": {"name": "FetchSource"}}},
+  {"eventId": "6", "eventTime": "2024-05-01T10:00:02Z", "eventType": "EVENT_TYPE_ACTIVITY_TASK_STARTED",
+   "activityTaskStartedEventAttributes": {"scheduledEventId": "5", "attempt": 1}},
+  {"eventId": "7", "eventTime": "2024-05-01T10:00:04Z", "eventType": "EVENT_TYPE_ACTIVITY_TASK_COMPLETED",
+   "activityTaskCompletedEventAttributes": {"scheduledEventId": "5", "startedEventId": "6"}},
+  {"eventId": 11, "eventTime": "2024-05-01T10:00:05Z", "eventType": "ActivityTaskScheduled",
+   "activityTaskScheduledEventAttributes": {"activityType": {"name": "GenerateEmbedding"}}},
+  {"eventId": 12, "eventTime": "2024-05-01T10:01:00Z", "eventType": "ActivityTaskStarted",
+   "activityTaskStartedEventAttributes": {"scheduledEventId": 11, "attempt": 3}},
+  {"eventId": 13, "eventTime": "2024-05-01T10:01:30Z", "eventType": "ActivityTaskFailed",
+   "activityTaskFailedEventAttributes": {"scheduledEventId": 11, "failure": {"message": "embedding request failed",
+     "applicationFailureInfo": {"type": "EmbeddingError"}, "cause": {"message": "connection refused"}}}},
+  {"eventId": "17", "eventTime": "2024-05-01T10:01:31Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_FAILED",
+   "workflowExecutionFailedEventAttributes": {"failure": {"message": "activity error"}}}
+]`
+
+func sampleWorkflowEvents(t *testing.T) []json.RawMessage {
+	t.Helper()
+	var events []json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(sampleWorkflowHistory), &events))
+	return events
+}
+
+func TestBuildWorkflowTimeline(t *testing.T) {
+	timeline, err := buildWorkflowTimeline(sampleWorkflowEvents(t))
+	require.NoError(t, err)
+	require.Len(t, timeline, 4)
+
+	assert.Equal(t, "workflow", timeline[0].Kind)
+	assert.Equal(t, "EmailProcessingWorkflow", timeline[0].Name)
+	assert.Equal(t, "started", timeline[0].Status)
+
+	fetch := timeline[1]
+	assert.Equal(t, "FetchSource", fetch.Name)
+	assert.Equal(t, "completed", fetch.Status)
+	assert.Equal(t, int32(1), fetch.Attempt)
+	assert.Equal(t, int64(2000), fetch.DurationMs)
+
+	embed := timeline[2]
+	assert.Equal(t, int64(11), embed.EventID)
+	assert.Equal(t, "GenerateEmbedding", embed.Name)
+	assert.Equal(t, "failed", embed.Status)
+	assert.Equal(t, int32(3), embed.Attempt)
+	assert.Equal(t, int64(30000), embed.DurationMs)
+	assert.Equal(t, "EmbeddingError: embedding request failed: caused by: connection refused", embed.Failure)
+
+	assert.Equal(t, "failed", timeline[3].Status)
+	assert.Equal(t, "activity error", timeline[3].Failure)
+}
+
+func TestFillWorkflowDescriptionFromTimeline(t *testing.T) {
+	timeline, err := buildWorkflowTimeline(sampleWorkflowEvents(t))
+	require.NoError(t, err)
+
+	desc := &WorkflowDescription{Timeline: timeline}
+	fillWorkflowDescriptionFromTimeline(desc)
+
+	assert.Equal(t, "EmailProcessingWorkflow", desc.WorkflowType)
+	assert.Equal(t, "failed", desc.Status)
+	assert.Equal(t, "2024-05-01T10:01:31Z", desc.CloseTime.Format("2006-01-02T15:04:05Z07:00"))
+}
+
+func TestRunWorkflowDescribe(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, mock := createWorkflowTestDepsWithMocks(cfg)
+	events := sampleWorkflowEvents(t)
+	mock.historyPages = [][]json.RawMessage{events[:4], events[4:]}
+
+	oldOutput := workflowOutput
+	defer func() {
+		workflowOutput = oldOutput
+	}()
+
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{output: "text", want: []string{"Workflow: wf-test-001", "GenerateEmbedding", "connection refused"}},
+		{output: "json", want: []string{`"events"`, "EVENT_TYPE_ACTIVITY_TASK_SCHEDULED"}},
+		{output: "yaml", want: []string{"timeline:", "status: failed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			workflowOutput = tt.output
+
+			// Capture stdout.
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runWorkflowDescribe(context.Background(), deps, "wf-test-001")
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestFetchWorkflowHistory(t *testing.T) {
+	events := sampleWorkflowEvents(t)
+
+	t.Run("follows page tokens", func(t *testing.T) {
+		mock := newMockWorkflowClient()
+		mock.historyPages = [][]json.RawMessage{events[:3], events[3:6], events[6:]}
+
+		got, truncated, err := fetchWorkflowHistory(context.Background(), mock.GetWorkflowHistory, "wf-test-001", "")
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		assert.Len(t, got, len(events))
+	})
+
+	t.Run("reports truncation at the page cap", func(t *testing.T) {
+		calls := 0
+		endless := func(ctx context.Context, workflowID, runID string, pageToken []byte) (*client.WorkflowHistoryPage, error) {
+			calls++
+			return &client.WorkflowHistoryPage{Events: events[:1], NextPageToken: []byte("more")}, nil
+		}
+
+		got, truncated, err := fetchWorkflowHistory(context.Background(), endless, "wf-test-001", "")
+		require.NoError(t, err)
+		assert.True(t, truncated)
+		assert.Equal(t, workflowHistoryMaxPages, calls)
+		assert.Len(t, got, workflowHistoryMaxPages)
+	})
+}
@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -16,6 +17,9 @@ type mockWorkflowClient struct {
 	// workflows stores mock workflow data keyed by workflow ID.
 	workflows map[string]*Workflow
 
+	// historyPages holds the history events returned page by page.
+	historyPages [][]json.RawMessage
+
 	// Flags to simulate various error conditions.
 	shouldFailListWorkflows      bool
 	shouldFailGetWorkflowStatus  bool
@@ -205,6 +209,27 @@ func (m *mockWorkflowClient) TerminateWorkflow(ctx context.Context, workflowID,
 	}, nil
 }
 
+// GetWorkflowHistory mocks the GetWorkflowHistory client method, using the
+// page number as the page token.
+func (m *mockWorkflowClient) GetWorkflowHistory(ctx context.Context, workflowID, runID string, pageToken []byte) (*client.WorkflowHistoryPage, error) {
+	if _, exists := m.workflows[workflowID]; !exists {
+		return nil, fmt.Errorf("workflow not found: %s", workflowID)
+	}
+
+	page := 0
+	if len(pageToken) > 0 {
+		fmt.Sscanf(string(pageToken), "%d", &page)
+	}
+	result := &client.WorkflowHistoryPage{}
+	if page < len(m.historyPages) {
+		result.Events = m.historyPages[page]
+	}
+	if page+1 < len(m.historyPages) {
+		result.NextPageToken = []byte(fmt.Sprintf("%d", page+1))
+	}
+	return result, nil
+}
+
 // Close mocks the Close method (no-op for mock).
 func (m *mockWorkflowClient) Close() error {
 	return nil
@@ -236,10 +261,14 @@ func createWorkflowTestDepsWithMocks(cfg *config.CLIConfig) (*WorkflowCommandDep
 			return nil, fmt.Errorf("mock: InitClient should not be called")
 		},
 		// Wire up mock functions.
-		ListWorkflowsFn:     mock.ListWorkflows,
-		GetWorkflowStatusFn: mock.GetWorkflowStatus,
-		CancelWorkflowFn:    mock.CancelWorkflow,
-		TerminateWorkflowFn: mock.TerminateWorkflow,
+		ListWorkflowsFn:      mock.ListWorkflows,
+		GetWorkflowStatusFn:  mock.GetWorkflowStatus,
+		CancelWorkflowFn:     mock.CancelWorkflow,
+		TerminateWorkflowFn:  mock.TerminateWorkflow,
+		GetWorkflowHistoryFn: mock.GetWorkflowHistory,
+		ConfirmFn: func(string) (bool, error) {
+			return true, nil
+		},
 	}
 
 	return deps, mock
@@ -416,6 +416,10 @@ func TestRunWorkflowCancel(t *testing.T) {
 func TestRunWorkflowCancel_Force(t *testing.T) {
 	cfg := mockWorkflowConfig()
 	deps, _ := createWorkflowTestDepsWithMocks(cfg)
+	deps.ConfirmFn = func(string) (bool, error) {
+		t.Fatal("--force should skip the confirmation prompt")
+		return false, nil
+	}
 
 	oldForce := workflowForce
 	workflowForce = true
@@ -442,6 +446,120 @@ func TestRunWorkflowCancel_Force(t *testing.T) {
 	assert.Contains(t, output, "Force cancelling")
 }
 
+func TestRunWorkflowCancel_Yes(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, _ := createWorkflowTestDepsWithMocks(cfg)
+	deps.ConfirmFn = func(string) (bool, error) {
+		t.Fatal("--yes should skip the confirmation prompt")
+		return false, nil
+	}
+	deps.TerminateWorkflowFn = func(ctx context.Context, workflowID, runID, reason string) (*client.CancelWorkflowResult, error) {
+		t.Fatal("--yes alone should cancel, not terminate")
+		return nil, nil
+	}
+
+	oldYes := workflowYes
+	workflowYes = true
+	defer func() {
+		workflowYes = oldYes
+	}()
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runWorkflowCancel(context.Background(), deps, "wf-test-001")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Cancelling workflow")
+	assert.Contains(t, output, "has been cancelled")
+}
+
+func TestRunWorkflowCancel_Declined(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, mock := createWorkflowTestDepsWithMocks(cfg)
+	deps.ConfirmFn = func(string) (bool, error) {
+		return false, nil
+	}
+	deps.CancelWorkflowFn = func(ctx context.Context, workflowID, runID, reason string) (*client.CancelWorkflowResult, error) {
+		t.Fatal("declined cancel should not be sent")
+		return nil, nil
+	}
+
+	oldForce := workflowForce
+	workflowForce = false
+	defer func() {
+		workflowForce = oldForce
+	}()
+
+	err := runWorkflowCancel(context.Background(), deps, "wf-test-001")
+
+	assert.NoError(t, err)
+	assert.Equal(t, WorkflowStatusRunning, mock.workflows["wf-test-001"].Status)
+}
+
+func TestRunWorkflowTerminate_Reason(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, mock := createWorkflowTestDepsWithMocks(cfg)
+
+	oldReason := workflowReason
+	workflowReason = "stuck on embedding activity"
+	defer func() {
+		workflowReason = oldReason
+	}()
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runWorkflowTerminate(context.Background(), deps, "wf-test-001")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "stuck on embedding activity", mock.workflows["wf-test-001"].Message)
+	assert.Contains(t, output, "State:    cancelled")
+}
+
+func TestRunWorkflowCancel_AlreadyClosed(t *testing.T) {
+	cfg := mockWorkflowConfig()
+	deps, _ := createWorkflowTestDepsWithMocks(cfg)
+	deps.ConfirmFn = func(string) (bool, error) {
+		t.Fatal("closed workflow should not prompt")
+		return false, nil
+	}
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runWorkflowCancel(context.Background(), deps, "wf-001")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "already completed")
+}
+
 func TestRunWorkflowCancel_NotFound(t *testing.T) {
 	cfg := mockWorkflowConfig()
 	deps, mock := createWorkflowTestDepsWithMocks(cfg)
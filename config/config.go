@@ -23,6 +23,21 @@ const (
 	OutputFormatJSON OutputFormat = "json"
 	// OutputFormatYAML is YAML-formatted output for machine processing.
 	OutputFormatYAML OutputFormat = "yaml"
+	// OutputFormatCSV is comma-separated output for spreadsheets and scripts.
+	// Only some list and export commands support it, so it is not accepted
+	// as the configured default (see IsValid).
+	OutputFormatCSV OutputFormat = "csv"
+	// OutputFormatJSONL is newline-delimited JSON, one record per line,
+	// streamed as results arrive. Only list commands support it, so it is not
+	// accepted as the configured default (see IsValid).
+	OutputFormatJSONL OutputFormat = "jsonl"
+	// OutputFormatTemplate renders each record with the Go template given by
+	// --template. Like jsonl it needs a per-command flag, so it is not
+	// accepted as the configured default.
+	OutputFormatTemplate OutputFormat = "template"
+	// OutputFormatPrometheus is the Prometheus text exposition format. Only
+	// 'health' supports it, so it is not accepted as the configured default.
+	OutputFormatPrometheus OutputFormat = "prometheus"
 )
 
 // Default configuration values.
@@ -30,10 +45,12 @@ const (
 	DefaultServerAddress        = "localhost:50051"
 	DefaultSearchServiceAddress = "localhost:50053"
 	DefaultTimeout              = 10 * time.Minute
+	DefaultConnectTimeout       = 10 * time.Second
 	DefaultOutputFormat         = OutputFormatText
 	DefaultConfigDir            = ".penf"
 	DefaultConfigFile           = "config.yaml"
 	DefaultCertDir              = ".config/penf/certs"
+	DefaultStuckThreshold       = 5 * time.Minute
 )
 
 // TLSConfig holds client TLS settings.
@@ -277,9 +294,31 @@ type CLIConfig struct {
 	// If empty, search commands will use the gateway address.
 	SearchServiceAddress string `yaml:"search_service_address,omitempty"`
 
-	// Timeout is the default timeout for API requests.
+	// Timeout is the request timeout: how long a single API request may
+	// take. It is set with timeout or its alias request_timeout.
 	Timeout time.Duration `yaml:"timeout"`
 
+	// ConnectTimeout is how long to wait for the connection to the gateway
+	// before giving up. Zero uses DefaultConnectTimeout. It is kept short so
+	// an unreachable server fails fast rather than after the request timeout.
+	ConnectTimeout time.Duration `yaml:"connect_timeout,omitempty"`
+
+	// MaxMessageSize is the largest gRPC response, in bytes, the client
+	// accepts. Zero uses the client default.
+	MaxMessageSize int `yaml:"max_message_size,omitempty"`
+
+	// KeepaliveTime is the interval between gRPC keepalive pings on idle
+	// connections. Zero uses the client default.
+	KeepaliveTime time.Duration `yaml:"keepalive_time,omitempty"`
+
+	// KeepaliveTimeout is how long to wait for a keepalive ping response
+	// before closing the connection. Zero uses the client default.
+	KeepaliveTimeout time.Duration `yaml:"keepalive_timeout,omitempty"`
+
+	// GRPCRetries is how many times read-only RPCs are retried after a
+	// transient error. Nil uses the client default; zero disables retries.
+	GRPCRetries *int `yaml:"grpc_retries,omitempty"`
+
 	// OutputFormat specifies the default output format for commands.
 	OutputFormat OutputFormat `yaml:"output_format"`
 
@@ -299,6 +338,23 @@ type CLIConfig struct {
 	// Supports ~ for home directory expansion.
 	InstallPath string `yaml:"install_path,omitempty"`
 
+	// WatchWebhook is the URL 'watch --daemon' posts notifications to when
+	// --webhook is not given.
+	WatchWebhook string `yaml:"watch_webhook,omitempty"`
+
+	// DefaultModel is the AI model ID used by reprocess and ai commands when
+	// --model is not given. Empty lets the server choose.
+	DefaultModel string `yaml:"default_model,omitempty"`
+
+	// StuckThresholdSeconds is how long, in seconds, the oldest item of a
+	// queue may wait before the queue counts as stuck. Zero uses
+	// DefaultStuckThreshold.
+	StuckThresholdSeconds int `yaml:"stuck_threshold_seconds,omitempty"`
+
+	// ConfirmTenant makes destructive commands ask for the tenant to be typed
+	// before they run, unless --expect-tenant names it.
+	ConfirmTenant bool `yaml:"confirm_tenant,omitempty"`
+
 	// Debug enables verbose debug logging.
 	Debug bool `yaml:"debug,omitempty"`
 
@@ -313,6 +369,33 @@ type CLIConfig struct {
 
 	// TLS contains the TLS/mTLS configuration settings.
 	TLS TLSConfig `yaml:"tls"`
+
+	// Profiles holds named overrides (e.g. dev, staging, prod) merged over the
+	// base settings when selected.
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
+
+	// CurrentProfile is the profile applied when neither --profile nor
+	// $PENF_PROFILE is set. Empty means the base settings only.
+	CurrentProfile string `yaml:"current_profile,omitempty"`
+
+	// ActiveProfile is the profile applied by LoadConfig, if any.
+	ActiveProfile string `yaml:"-"`
+
+	// DebugTrace adds message sizes to the per-RPC trace printed in debug
+	// mode. It is set by --debug-trace and never saved.
+	DebugTrace bool `yaml:"-"`
+
+	// Warnings holds non-fatal problems found while loading, such as a
+	// reference to an unset environment variable.
+	Warnings []string `yaml:"-"`
+
+	// profileBase holds the settings as they were before the active profile
+	// was applied.
+	profileBase *CLIConfig
+
+	// rawValues holds the unexpanded form of values that referenced
+	// environment variables, keyed by config key.
+	rawValues map[string]rawValue
 }
 
 // EffectiveTenantID returns the UUID if available, falling back to slug.
@@ -362,8 +445,22 @@ func ConfigPath() (string, error) {
 // Configuration is loaded in this order (later sources override earlier):
 // 1. Default values
 // 2. Config file (~/.penf/config.yaml or $PENF_CONFIG_DIR/config.yaml)
-// 3. Environment variables (PENF_SERVER_ADDRESS, PENF_TIMEOUT, PENF_OUTPUT_FORMAT)
+// 3. Selected profile (--profile, $PENF_PROFILE, or current_profile)
+// 4. Environment variables (PENF_SERVER_ADDRESS, PENF_TIMEOUT, PENF_OUTPUT_FORMAT)
+//
+// References to environment variables in EnvExpandedKeys are expanded after
+// the profile is applied.
 func LoadConfig() (*CLIConfig, error) {
+	return loadConfig(true)
+}
+
+// LoadBaseConfig loads the configuration without applying any profile. It is
+// used when managing profiles, so a missing or broken profile can be fixed.
+func LoadBaseConfig() (*CLIConfig, error) {
+	return loadConfig(false)
+}
+
+func loadConfig(withProfile bool) (*CLIConfig, error) {
 	cfg := DefaultConfig()
 
 	// Try to load from config file.
@@ -378,6 +475,18 @@ func LoadConfig() (*CLIConfig, error) {
 		}
 	}
 
+	// Merge the selected profile over the base settings.
+	if withProfile {
+		if name := selectedProfile(cfg); name != "" {
+			if err := cfg.applyProfile(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Expand ${VAR} references in values read from the file.
+	cfg.Warnings = append(cfg.Warnings, cfg.expandEnv()...)
+
 	// Overlay environment variables.
 	loadFromEnv(cfg)
 
@@ -398,19 +507,31 @@ func loadFromFile(cfg *CLIConfig, path string) error {
 
 	// We need a temp struct for unmarshaling duration as string.
 	type configFile struct {
-		ServerAddress        string               `yaml:"server_address"`
-		SearchServiceAddress string               `yaml:"search_service_address"`
-		Timeout              string               `yaml:"timeout"`
-		OutputFormat         OutputFormat         `yaml:"output_format"`
-		TenantID             string               `yaml:"tenant_id"`
-		TenantUUID           string               `yaml:"tenant_uuid"`
-		TenantAliases        map[string]string    `yaml:"tenant_aliases"`
-		InstallPath          string               `yaml:"install_path"`
-		Debug                bool                 `yaml:"debug"`
-		Insecure             bool                 `yaml:"insecure"`
-		Database             *DatabaseConfig      `yaml:"database"`
-		ContextPalace        *ContextPalaceConfig `yaml:"context_palace"`
-		TLS                  TLSConfig            `yaml:"tls"`
+		ServerAddress        string                   `yaml:"server_address"`
+		SearchServiceAddress string                   `yaml:"search_service_address"`
+		Timeout              string                   `yaml:"timeout"`
+		RequestTimeout       string                   `yaml:"request_timeout"`
+		ConnectTimeout       string                   `yaml:"connect_timeout"`
+		MaxMessageSize       int                      `yaml:"max_message_size"`
+		KeepaliveTime        string                   `yaml:"keepalive_time"`
+		KeepaliveTimeout     string                   `yaml:"keepalive_timeout"`
+		GRPCRetries          *int                     `yaml:"grpc_retries"`
+		OutputFormat         OutputFormat             `yaml:"output_format"`
+		TenantID             string                   `yaml:"tenant_id"`
+		TenantUUID           string                   `yaml:"tenant_uuid"`
+		TenantAliases        map[string]string        `yaml:"tenant_aliases"`
+		InstallPath          string                   `yaml:"install_path"`
+		WatchWebhook         string                   `yaml:"watch_webhook"`
+		DefaultModel         string                   `yaml:"default_model"`
+		StuckThreshold       int                      `yaml:"stuck_threshold_seconds"`
+		ConfirmTenant        bool                     `yaml:"confirm_tenant"`
+		Debug                bool                     `yaml:"debug"`
+		Insecure             bool                     `yaml:"insecure"`
+		Database             *DatabaseConfig          `yaml:"database"`
+		ContextPalace        *ContextPalaceConfig     `yaml:"context_palace"`
+		TLS                  TLSConfig                `yaml:"tls"`
+		Profiles             map[string]ProfileConfig `yaml:"profiles"`
+		CurrentProfile       string                   `yaml:"current_profile"`
 	}
 
 	var fileCfg configFile
@@ -431,6 +552,40 @@ func loadFromFile(cfg *CLIConfig, path string) error {
 		}
 		cfg.Timeout = timeout
 	}
+	if fileCfg.RequestTimeout != "" {
+		timeout, err := time.ParseDuration(fileCfg.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("parsing request_timeout: %w", err)
+		}
+		cfg.Timeout = timeout
+	}
+	if fileCfg.ConnectTimeout != "" {
+		d, err := time.ParseDuration(fileCfg.ConnectTimeout)
+		if err != nil {
+			return fmt.Errorf("parsing connect_timeout: %w", err)
+		}
+		cfg.ConnectTimeout = d
+	}
+	if fileCfg.MaxMessageSize != 0 {
+		cfg.MaxMessageSize = fileCfg.MaxMessageSize
+	}
+	if fileCfg.KeepaliveTime != "" {
+		d, err := time.ParseDuration(fileCfg.KeepaliveTime)
+		if err != nil {
+			return fmt.Errorf("parsing keepalive_time: %w", err)
+		}
+		cfg.KeepaliveTime = d
+	}
+	if fileCfg.KeepaliveTimeout != "" {
+		d, err := time.ParseDuration(fileCfg.KeepaliveTimeout)
+		if err != nil {
+			return fmt.Errorf("parsing keepalive_timeout: %w", err)
+		}
+		cfg.KeepaliveTimeout = d
+	}
+	if fileCfg.GRPCRetries != nil {
+		cfg.GRPCRetries = fileCfg.GRPCRetries
+	}
 	if fileCfg.OutputFormat != "" {
 		cfg.OutputFormat = fileCfg.OutputFormat
 	}
@@ -446,6 +601,16 @@ func loadFromFile(cfg *CLIConfig, path string) error {
 	if fileCfg.InstallPath != "" {
 		cfg.InstallPath = fileCfg.InstallPath
 	}
+	if fileCfg.WatchWebhook != "" {
+		cfg.WatchWebhook = fileCfg.WatchWebhook
+	}
+	if fileCfg.DefaultModel != "" {
+		cfg.DefaultModel = fileCfg.DefaultModel
+	}
+	if fileCfg.StuckThreshold != 0 {
+		cfg.StuckThresholdSeconds = fileCfg.StuckThreshold
+	}
+	cfg.ConfirmTenant = fileCfg.ConfirmTenant
 	if fileCfg.Database != nil {
 		cfg.Database = fileCfg.Database
 	}
@@ -455,6 +620,8 @@ func loadFromFile(cfg *CLIConfig, path string) error {
 	cfg.Debug = fileCfg.Debug
 	cfg.Insecure = fileCfg.Insecure
 	cfg.TLS = fileCfg.TLS
+	cfg.Profiles = fileCfg.Profiles
+	cfg.CurrentProfile = fileCfg.CurrentProfile
 
 	return nil
 }
@@ -475,6 +642,42 @@ func loadFromEnv(cfg *CLIConfig) {
 		}
 	}
 
+	if v := os.Getenv("PENF_REQUEST_TIMEOUT"); v != "" {
+		if timeout, err := time.ParseDuration(v); err == nil {
+			cfg.Timeout = timeout
+		}
+	}
+
+	if v := os.Getenv("PENF_CONNECT_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.ConnectTimeout = d
+		}
+	}
+
+	if v := os.Getenv("PENF_MAX_MESSAGE_SIZE"); v != "" {
+		if size, err := strconv.Atoi(v); err == nil {
+			cfg.MaxMessageSize = size
+		}
+	}
+
+	if v := os.Getenv("PENF_KEEPALIVE_TIME"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.KeepaliveTime = d
+		}
+	}
+
+	if v := os.Getenv("PENF_KEEPALIVE_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.KeepaliveTimeout = d
+		}
+	}
+
+	if v := os.Getenv("PENF_GRPC_RETRIES"); v != "" {
+		if retries, err := strconv.Atoi(v); err == nil {
+			cfg.GRPCRetries = &retries
+		}
+	}
+
 	if v := os.Getenv("PENF_OUTPUT_FORMAT"); v != "" {
 		cfg.OutputFormat = OutputFormat(v)
 	}
@@ -487,6 +690,24 @@ func loadFromEnv(cfg *CLIConfig) {
 		cfg.InstallPath = v
 	}
 
+	if v := os.Getenv("PENF_WATCH_WEBHOOK"); v != "" {
+		cfg.WatchWebhook = v
+	}
+
+	if v := os.Getenv("PENF_DEFAULT_MODEL"); v != "" {
+		cfg.DefaultModel = v
+	}
+
+	if v := os.Getenv("PENF_STUCK_THRESHOLD_SECONDS"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			cfg.StuckThresholdSeconds = secs
+		}
+	}
+
+	if v := os.Getenv("PENF_CONFIRM_TENANT"); v == "true" || v == "1" {
+		cfg.ConfirmTenant = true
+	}
+
 	if v := os.Getenv("PENF_DEBUG"); v == "true" || v == "1" {
 		cfg.Debug = true
 	}
@@ -635,6 +856,26 @@ func (c *CLIConfig) Validate() error {
 		return fmt.Errorf("timeout must be positive")
 	}
 
+	if c.ConnectTimeout < 0 {
+		return fmt.Errorf("connect_timeout must not be negative")
+	}
+
+	if c.MaxMessageSize < 0 {
+		return fmt.Errorf("max_message_size must not be negative")
+	}
+
+	if c.KeepaliveTime < 0 || c.KeepaliveTimeout < 0 {
+		return fmt.Errorf("keepalive_time and keepalive_timeout must not be negative")
+	}
+
+	if c.GRPCRetries != nil && *c.GRPCRetries < 0 {
+		return fmt.Errorf("grpc_retries must not be negative")
+	}
+
+	if c.StuckThresholdSeconds < 0 {
+		return fmt.Errorf("stuck_threshold_seconds must not be negative")
+	}
+
 	if !c.OutputFormat.IsValid() {
 		return fmt.Errorf("invalid output_format: %q (must be text, json, or yaml)", c.OutputFormat)
 	}
@@ -673,35 +914,61 @@ func SaveConfig(cfg *CLIConfig) error {
 
 	// Convert to YAML-friendly format with duration as string.
 	type configFile struct {
-		ServerAddress        string               `yaml:"server_address"`
-		SearchServiceAddress string               `yaml:"search_service_address,omitempty"`
-		Timeout              string               `yaml:"timeout"`
-		OutputFormat         OutputFormat         `yaml:"output_format"`
-		TenantID             string               `yaml:"tenant_id,omitempty"`
-		TenantUUID           string               `yaml:"tenant_uuid,omitempty"`
-		TenantAliases        map[string]string    `yaml:"tenant_aliases,omitempty"`
-		InstallPath          string               `yaml:"install_path,omitempty"`
-		Debug                bool                 `yaml:"debug,omitempty"`
-		Insecure             bool                 `yaml:"insecure,omitempty"`
-		Database             *DatabaseConfig      `yaml:"database,omitempty"`
-		ContextPalace        *ContextPalaceConfig `yaml:"context_palace,omitempty"`
-		TLS                  TLSConfig            `yaml:"tls,omitempty"`
-	}
+		ServerAddress        string                   `yaml:"server_address"`
+		SearchServiceAddress string                   `yaml:"search_service_address,omitempty"`
+		Timeout              string                   `yaml:"timeout"`
+		ConnectTimeout       string                   `yaml:"connect_timeout,omitempty"`
+		MaxMessageSize       int                      `yaml:"max_message_size,omitempty"`
+		KeepaliveTime        string                   `yaml:"keepalive_time,omitempty"`
+		KeepaliveTimeout     string                   `yaml:"keepalive_timeout,omitempty"`
+		GRPCRetries          *int                     `yaml:"grpc_retries,omitempty"`
+		OutputFormat         OutputFormat             `yaml:"output_format"`
+		TenantID             string                   `yaml:"tenant_id,omitempty"`
+		TenantUUID           string                   `yaml:"tenant_uuid,omitempty"`
+		TenantAliases        map[string]string        `yaml:"tenant_aliases,omitempty"`
+		InstallPath          string                   `yaml:"install_path,omitempty"`
+		WatchWebhook         string                   `yaml:"watch_webhook,omitempty"`
+		DefaultModel         string                   `yaml:"default_model,omitempty"`
+		StuckThreshold       int                      `yaml:"stuck_threshold_seconds,omitempty"`
+		ConfirmTenant        bool                     `yaml:"confirm_tenant,omitempty"`
+		Debug                bool                     `yaml:"debug,omitempty"`
+		Insecure             bool                     `yaml:"insecure,omitempty"`
+		Database             *DatabaseConfig          `yaml:"database,omitempty"`
+		ContextPalace        *ContextPalaceConfig     `yaml:"context_palace,omitempty"`
+		TLS                  TLSConfig                `yaml:"tls,omitempty"`
+		Profiles             map[string]ProfileConfig `yaml:"profiles,omitempty"`
+		CurrentProfile       string                   `yaml:"current_profile,omitempty"`
+	}
+
+	// Write ${VAR} references back as written, and keep settings owned by the
+	// active profile out of the base configuration.
+	base, profiles := cfg.withRawValues().splitProfile()
 
 	fileCfg := configFile{
-		ServerAddress:        cfg.ServerAddress,
-		SearchServiceAddress: cfg.SearchServiceAddress,
-		Timeout:              cfg.Timeout.String(),
-		OutputFormat:         cfg.OutputFormat,
-		TenantID:             cfg.TenantID,
-		TenantUUID:           cfg.TenantUUID,
-		TenantAliases:        cfg.TenantAliases,
-		InstallPath:          cfg.InstallPath,
-		Debug:                cfg.Debug,
-		Insecure:             cfg.Insecure,
-		Database:             cfg.Database,
-		ContextPalace:        cfg.ContextPalace,
-		TLS:                  cfg.TLS,
+		ServerAddress:        base.ServerAddress,
+		SearchServiceAddress: base.SearchServiceAddress,
+		Timeout:              base.Timeout.String(),
+		ConnectTimeout:       durationOrEmpty(base.ConnectTimeout),
+		MaxMessageSize:       base.MaxMessageSize,
+		KeepaliveTime:        durationOrEmpty(base.KeepaliveTime),
+		KeepaliveTimeout:     durationOrEmpty(base.KeepaliveTimeout),
+		GRPCRetries:          base.GRPCRetries,
+		OutputFormat:         base.OutputFormat,
+		TenantID:             base.TenantID,
+		TenantUUID:           base.TenantUUID,
+		TenantAliases:        base.TenantAliases,
+		InstallPath:          base.InstallPath,
+		WatchWebhook:         base.WatchWebhook,
+		DefaultModel:         base.DefaultModel,
+		StuckThreshold:       base.StuckThresholdSeconds,
+		ConfirmTenant:        base.ConfirmTenant,
+		Debug:                base.Debug,
+		Insecure:             base.Insecure,
+		Database:             base.Database,
+		ContextPalace:        base.ContextPalace,
+		TLS:                  base.TLS,
+		Profiles:             profiles,
+		CurrentProfile:       base.CurrentProfile,
 	}
 
 	data, err := yaml.Marshal(&fileCfg)
@@ -716,6 +983,15 @@ func SaveConfig(cfg *CLIConfig) error {
 	return nil
 }
 
+// durationOrEmpty formats d for the config file, leaving unset (zero)
+// durations out.
+func durationOrEmpty(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
 // EnsureConfigDir creates the configuration directory if it doesn't exist.
 func EnsureConfigDir() error {
 	dir, err := ConfigDir()
@@ -768,3 +1044,26 @@ func (c *CLIConfig) GetSearchServiceAddress() string {
 	}
 	return DefaultServerAddress
 }
+
+// GetConnectTimeout returns how long to wait for a connection to the
+// gateway: ConnectTimeout, or DefaultConnectTimeout if unset, but never more
+// than the request timeout.
+func (c *CLIConfig) GetConnectTimeout() time.Duration {
+	d := c.ConnectTimeout
+	if d <= 0 {
+		d = DefaultConnectTimeout
+	}
+	if c.Timeout > 0 && c.Timeout < d {
+		d = c.Timeout
+	}
+	return d
+}
+
+// GetStuckThreshold returns how long the oldest item of a queue may wait
+// before the queue counts as stuck.
+func (c *CLIConfig) GetStuckThreshold() time.Duration {
+	if c.StuckThresholdSeconds > 0 {
+		return time.Duration(c.StuckThresholdSeconds) * time.Second
+	}
+	return DefaultStuckThreshold
+}
@@ -64,6 +64,7 @@ func TestOutputFormat_IsValid(t *testing.T) {
 		{OutputFormatText, true},
 		{OutputFormatJSON, true},
 		{OutputFormatYAML, true},
+		{OutputFormatCSV, false},
 		{"invalid", false},
 		{"", false},
 		{"JSON", false}, // Case sensitive
@@ -860,3 +861,178 @@ func TestFilePermissions(t *testing.T) {
 		t.Errorf("File permissions = %o, want 0600", mode)
 	}
 }
+
+// TestLoadConfig_ConnectionSettings verifies the gRPC message size and
+// keepalive settings load from file, are overridden by env, and round-trip.
+func TestLoadConfig_ConnectionSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("PENF_CONFIG_DIR", tempDir)
+	t.Setenv("PENF_MAX_MESSAGE_SIZE", "")
+	t.Setenv("PENF_KEEPALIVE_TIME", "")
+	t.Setenv("PENF_KEEPALIVE_TIMEOUT", "")
+
+	configContent := `server_address: file.server:7070
+timeout: 2m
+output_format: text
+max_message_size: 134217728
+keepalive_time: 10m
+keepalive_timeout: 30s
+`
+	configPath := filepath.Join(tempDir, DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.MaxMessageSize != 128<<20 {
+		t.Errorf("MaxMessageSize = %d, want %d", cfg.MaxMessageSize, 128<<20)
+	}
+	if cfg.KeepaliveTime != 10*time.Minute {
+		t.Errorf("KeepaliveTime = %v, want 10m", cfg.KeepaliveTime)
+	}
+	if cfg.KeepaliveTimeout != 30*time.Second {
+		t.Errorf("KeepaliveTimeout = %v, want 30s", cfg.KeepaliveTimeout)
+	}
+
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	t.Setenv("PENF_KEEPALIVE_TIME", "7m")
+	reloaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() after save error = %v", err)
+	}
+	if reloaded.MaxMessageSize != cfg.MaxMessageSize || reloaded.KeepaliveTimeout != cfg.KeepaliveTimeout {
+		t.Errorf("settings did not round-trip: got %d, %v", reloaded.MaxMessageSize, reloaded.KeepaliveTimeout)
+	}
+	if reloaded.KeepaliveTime != 7*time.Minute {
+		t.Errorf("KeepaliveTime = %v, want env override 7m", reloaded.KeepaliveTime)
+	}
+
+	t.Setenv("PENF_KEEPALIVE_TIME", "")
+	if err := os.WriteFile(configPath, []byte("keepalive_time: soon\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, err := LoadConfig(); err == nil {
+		t.Error("LoadConfig() should fail with invalid keepalive_time")
+	}
+}
+
+// TestLoadConfig_Timeouts verifies connect_timeout and request_timeout load
+// from file, are overridden by env, and that the connect timeout defaults to
+// 10s and never exceeds the request timeout.
+func TestLoadConfig_Timeouts(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("PENF_CONFIG_DIR", tempDir)
+	t.Setenv("PENF_TIMEOUT", "")
+	t.Setenv("PENF_REQUEST_TIMEOUT", "")
+	t.Setenv("PENF_CONNECT_TIMEOUT", "")
+
+	if got := (&CLIConfig{Timeout: time.Minute}).GetConnectTimeout(); got != DefaultConnectTimeout {
+		t.Errorf("GetConnectTimeout() unset = %v, want %v", got, DefaultConnectTimeout)
+	}
+	if got := (&CLIConfig{Timeout: 2 * time.Second}).GetConnectTimeout(); got != 2*time.Second {
+		t.Errorf("GetConnectTimeout() with 2s request timeout = %v, want 2s", got)
+	}
+
+	configContent := `server_address: file.server:7070
+request_timeout: 10m
+connect_timeout: 3s
+output_format: text
+`
+	configPath := filepath.Join(tempDir, DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Timeout != 10*time.Minute {
+		t.Errorf("Timeout = %v, want request_timeout 10m", cfg.Timeout)
+	}
+	if got := cfg.GetConnectTimeout(); got != 3*time.Second {
+		t.Errorf("GetConnectTimeout() = %v, want 3s", got)
+	}
+
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	t.Setenv("PENF_CONNECT_TIMEOUT", "1s")
+	t.Setenv("PENF_REQUEST_TIMEOUT", "5m")
+	reloaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() after save error = %v", err)
+	}
+	if reloaded.GetConnectTimeout() != time.Second || reloaded.Timeout != 5*time.Minute {
+		t.Errorf("env overrides: connect %v, request %v, want 1s and 5m", reloaded.GetConnectTimeout(), reloaded.Timeout)
+	}
+
+	t.Setenv("PENF_CONNECT_TIMEOUT", "")
+	t.Setenv("PENF_REQUEST_TIMEOUT", "")
+	if err := os.WriteFile(configPath, []byte("connect_timeout: soon\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, err := LoadConfig(); err == nil {
+		t.Error("LoadConfig() should fail with invalid connect_timeout")
+	}
+}
+
+// TestLoadConfig_StuckThreshold verifies stuck_threshold_seconds loads from
+// file, is overridden by env, round-trips, and defaults to 5 minutes.
+func TestLoadConfig_StuckThreshold(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("PENF_CONFIG_DIR", tempDir)
+	t.Setenv("PENF_STUCK_THRESHOLD_SECONDS", "")
+
+	if got := (&CLIConfig{}).GetStuckThreshold(); got != DefaultStuckThreshold {
+		t.Errorf("GetStuckThreshold() unset = %v, want %v", got, DefaultStuckThreshold)
+	}
+
+	configContent := `server_address: file.server:7070
+timeout: 2m
+output_format: text
+stuck_threshold_seconds: 1800
+`
+	if err := os.WriteFile(filepath.Join(tempDir, DefaultConfigFile), []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.GetStuckThreshold(); got != 30*time.Minute {
+		t.Errorf("GetStuckThreshold() = %v, want 30m", got)
+	}
+
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	t.Setenv("PENF_STUCK_THRESHOLD_SECONDS", "60")
+	reloaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() after save error = %v", err)
+	}
+	if got := reloaded.GetStuckThreshold(); got != time.Minute {
+		t.Errorf("GetStuckThreshold() = %v, want env override 1m", got)
+	}
+
+	t.Setenv("PENF_STUCK_THRESHOLD_SECONDS", "")
+	reloaded, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() after save error = %v", err)
+	}
+	if reloaded.StuckThresholdSeconds != 1800 {
+		t.Errorf("StuckThresholdSeconds = %d, want 1800 after round-trip", reloaded.StuckThresholdSeconds)
+	}
+
+	t.Setenv("PENF_STUCK_THRESHOLD_SECONDS", "-5")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("LoadConfig() with negative stuck threshold: expected error")
+	}
+}
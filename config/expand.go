@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvExpandedKeys lists the config keys whose values may reference environment
+// variables ($VAR or ${VAR}). They are expanded when the config is loaded and
+// saved back in their raw form.
+var EnvExpandedKeys = []string{
+	"server_address",
+	"tenant_id",
+	"tls.ca_cert",
+	"tls.client_cert",
+	"tls.client_key",
+	"tls.cert_dir",
+}
+
+// rawValue records a value as written in the config file and what it
+// expanded to at load time.
+type rawValue struct {
+	raw      string
+	expanded string
+}
+
+// envExpandedFields maps EnvExpandedKeys to the fields they configure.
+func (c *CLIConfig) envExpandedFields() map[string]*string {
+	return map[string]*string{
+		"server_address":  &c.ServerAddress,
+		"tenant_id":       &c.TenantID,
+		"tls.ca_cert":     &c.TLS.CACert,
+		"tls.client_cert": &c.TLS.ClientCert,
+		"tls.client_key":  &c.TLS.ClientKey,
+		"tls.cert_dir":    &c.TLS.CertDir,
+	}
+}
+
+// expandEnv expands environment variable references in EnvExpandedKeys. The
+// raw values are kept so SaveConfig can write them back unexpanded. It returns
+// a warning for each referenced variable that is unset or empty.
+func (c *CLIConfig) expandEnv() []string {
+	var warnings []string
+	fields := c.envExpandedFields()
+
+	for _, key := range EnvExpandedKeys {
+		field := fields[key]
+		if !strings.Contains(*field, "$") {
+			continue
+		}
+
+		raw := *field
+		expanded := os.Expand(raw, func(name string) string {
+			v := os.Getenv(name)
+			if v == "" {
+				warnings = append(warnings, fmt.Sprintf("%s references unset environment variable %s", key, name))
+			}
+			return v
+		})
+
+		if c.rawValues == nil {
+			c.rawValues = make(map[string]rawValue)
+		}
+		c.rawValues[key] = rawValue{raw: raw, expanded: expanded}
+		*field = expanded
+	}
+
+	return warnings
+}
+
+// withRawValues returns a copy of c with expanded values restored to their raw
+// form. Values changed since loading are kept as they are.
+func (c *CLIConfig) withRawValues() *CLIConfig {
+	cp := *c
+	if len(c.rawValues) == 0 {
+		return &cp
+	}
+
+	fields := cp.envExpandedFields()
+	for key, v := range c.rawValues {
+		if field := fields[key]; *field == v.expanded {
+			*field = v.raw
+		}
+	}
+	return &cp
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_ExpandsEnvReferences(t *testing.T) {
+	dir := setupProfileConfig(t)
+	t.Setenv("PENF_GATEWAY", "gw.example.com")
+	t.Setenv("PENF_TEST_CERTS", "/etc/penf/certs")
+
+	data := "server_address: ${PENF_GATEWAY}:50051\ntimeout: 30s\noutput_format: text\ntenant_id: $PENF_TEST_MISSING\ntls:\n  cert_dir: ${PENF_TEST_CERTS}/dev\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFile), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.ServerAddress != "gw.example.com:50051" {
+		t.Errorf("server_address = %q", cfg.ServerAddress)
+	}
+	if cfg.TLS.CertDir != "/etc/penf/certs/dev" {
+		t.Errorf("tls.cert_dir = %q", cfg.TLS.CertDir)
+	}
+	if cfg.TenantID != "" {
+		t.Errorf("tenant_id = %q, want empty", cfg.TenantID)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], "PENF_TEST_MISSING") {
+		t.Errorf("expected a warning for the unset variable, got %v", cfg.Warnings)
+	}
+}
+
+func TestSaveConfig_KeepsRawEnvReferences(t *testing.T) {
+	dir := setupProfileConfig(t)
+	t.Setenv("PENF_GATEWAY", "gw.example.com")
+
+	data := "server_address: ${PENF_GATEWAY}:50051\ntimeout: 30s\noutput_format: text\ntls:\n  cert_dir: ${PENF_GATEWAY}/certs\n"
+	path := filepath.Join(dir, DefaultConfigFile)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	cfg.TLS.CertDir = "/opt/certs"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(saved), "${PENF_GATEWAY}:50051") {
+		t.Errorf("expected raw server_address to be kept, got:\n%s", saved)
+	}
+	if !strings.Contains(string(saved), "cert_dir: /opt/certs") {
+		t.Errorf("expected changed cert_dir to be saved as set, got:\n%s", saved)
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProfileConfig holds per-environment overrides stored under the profiles key
+// of the config file. Empty fields inherit the base configuration.
+type ProfileConfig struct {
+	ServerAddress        string       `yaml:"server_address,omitempty"`
+	SearchServiceAddress string       `yaml:"search_service_address,omitempty"`
+	Timeout              string       `yaml:"timeout,omitempty"`
+	OutputFormat         OutputFormat `yaml:"output_format,omitempty"`
+	TenantID             string       `yaml:"tenant_id,omitempty"`
+	TenantUUID           string       `yaml:"tenant_uuid,omitempty"`
+	Insecure             *bool        `yaml:"insecure,omitempty"`
+	TLS                  *TLSConfig   `yaml:"tls,omitempty"`
+}
+
+// profileOverride is the profile selected by the --profile flag.
+var profileOverride string
+
+// SetProfile selects a named profile for subsequent LoadConfig calls,
+// taking precedence over $PENF_PROFILE and current_profile.
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// selectedProfile returns the profile to apply: --profile, then $PENF_PROFILE,
+// then current_profile from the config file.
+func selectedProfile(cfg *CLIConfig) string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if v := os.Getenv("PENF_PROFILE"); v != "" {
+		return v
+	}
+	return cfg.CurrentProfile
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (c *CLIConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile merges the named profile over the base configuration. The
+// pre-profile values are kept so SaveConfig can write them back unchanged.
+func (c *CLIConfig) applyProfile(name string) error {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("unknown profile %q (no profiles configured)", name)
+		}
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	base := *c
+	c.profileBase = &base
+	c.ActiveProfile = name
+
+	if p.ServerAddress != "" {
+		c.ServerAddress = p.ServerAddress
+	}
+	if p.SearchServiceAddress != "" {
+		c.SearchServiceAddress = p.SearchServiceAddress
+	}
+	if p.Timeout != "" {
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return fmt.Errorf("parsing timeout for profile %q: %w", name, err)
+		}
+		c.Timeout = timeout
+	}
+	if p.OutputFormat != "" {
+		c.OutputFormat = p.OutputFormat
+	}
+	// The base tenant UUID belongs to the base tenant, so a profile that names
+	// its own tenant replaces both.
+	if p.ownsTenant() {
+		c.TenantID = p.TenantID
+		c.TenantUUID = p.TenantUUID
+	}
+	if p.Insecure != nil {
+		c.Insecure = *p.Insecure
+	}
+	if p.TLS != nil {
+		c.TLS = *p.TLS
+	}
+
+	return nil
+}
+
+// ownsTenant reports whether the profile overrides the tenant.
+func (p ProfileConfig) ownsTenant() bool {
+	return p.TenantID != "" || p.TenantUUID != ""
+}
+
+// splitProfile separates cfg into the base values and the active profile's
+// values for saving. Settings the active profile overrides are written to the
+// profile, so 'config set' and 'tenant switch' update the profile in use rather
+// than the base configuration.
+func (c *CLIConfig) splitProfile() (base CLIConfig, profiles map[string]ProfileConfig) {
+	base = *c
+	if c.ActiveProfile == "" || c.profileBase == nil {
+		return base, c.Profiles
+	}
+
+	profiles = make(map[string]ProfileConfig, len(c.Profiles))
+	for name, p := range c.Profiles {
+		profiles[name] = p
+	}
+	p := profiles[c.ActiveProfile]
+	orig := c.profileBase
+
+	if p.ServerAddress != "" {
+		p.ServerAddress = c.ServerAddress
+		base.ServerAddress = orig.ServerAddress
+	}
+	if p.SearchServiceAddress != "" {
+		p.SearchServiceAddress = c.SearchServiceAddress
+		base.SearchServiceAddress = orig.SearchServiceAddress
+	}
+	if p.Timeout != "" {
+		p.Timeout = c.Timeout.String()
+		base.Timeout = orig.Timeout
+	}
+	if p.OutputFormat != "" {
+		p.OutputFormat = c.OutputFormat
+		base.OutputFormat = orig.OutputFormat
+	}
+	if p.ownsTenant() {
+		p.TenantID = c.TenantID
+		p.TenantUUID = c.TenantUUID
+		base.TenantID = orig.TenantID
+		base.TenantUUID = orig.TenantUUID
+	}
+	if p.Insecure != nil {
+		insecure := c.Insecure
+		p.Insecure = &insecure
+		base.Insecure = orig.Insecure
+	}
+	if p.TLS != nil {
+		tls := c.TLS
+		p.TLS = &tls
+		base.TLS = orig.TLS
+	}
+
+	profiles[c.ActiveProfile] = p
+	return base, profiles
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const profileTestConfig = `server_address: localhost:50051
+timeout: 30s
+output_format: text
+tenant_id: base-tenant
+tenant_uuid: base-uuid
+profiles:
+  staging:
+    server_address: staging.example.com:50051
+    tenant_id: acme-staging
+    timeout: 2m
+  prod:
+    server_address: prod.example.com:50051
+current_profile: prod
+`
+
+// setupProfileConfig writes the profile test config to a temp config dir.
+func setupProfileConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("PENF_CONFIG_DIR", dir)
+	t.Setenv("PENF_PROFILE", "")
+	for _, key := range []string{"PENF_SERVER_ADDRESS", "PENF_TIMEOUT", "PENF_TENANT_ID", "PENF_OUTPUT_FORMAT"} {
+		t.Setenv(key, "")
+	}
+	SetProfile("")
+	t.Cleanup(func() { SetProfile("") })
+
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFile), []byte(profileTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadConfig_ProfileSelection(t *testing.T) {
+	setupProfileConfig(t)
+
+	// current_profile applies by default.
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.ActiveProfile != "prod" || cfg.ServerAddress != "prod.example.com:50051" {
+		t.Errorf("expected prod profile, got %q at %s", cfg.ActiveProfile, cfg.ServerAddress)
+	}
+	if cfg.TenantID != "base-tenant" {
+		t.Errorf("expected tenant inherited from base, got %q", cfg.TenantID)
+	}
+
+	// PENF_PROFILE beats current_profile.
+	t.Setenv("PENF_PROFILE", "staging")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.ActiveProfile != "staging" || cfg.Timeout != 2*time.Minute {
+		t.Errorf("expected staging profile with 2m timeout, got %q / %s", cfg.ActiveProfile, cfg.Timeout)
+	}
+	if cfg.TenantID != "acme-staging" || cfg.TenantUUID != "" {
+		t.Errorf("expected profile tenant to replace base tenant and UUID, got %q / %q", cfg.TenantID, cfg.TenantUUID)
+	}
+
+	// --profile beats PENF_PROFILE.
+	SetProfile("prod")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.ActiveProfile != "prod" {
+		t.Errorf("expected --profile to win, got %q", cfg.ActiveProfile)
+	}
+
+	SetProfile("missing")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestLoadBaseConfig_IgnoresProfile(t *testing.T) {
+	setupProfileConfig(t)
+
+	cfg, err := LoadBaseConfig()
+	if err != nil {
+		t.Fatalf("LoadBaseConfig: %v", err)
+	}
+	if cfg.ActiveProfile != "" || cfg.ServerAddress != "localhost:50051" {
+		t.Errorf("expected base settings, got profile %q at %s", cfg.ActiveProfile, cfg.ServerAddress)
+	}
+	if got := cfg.ProfileNames(); len(got) != 2 || got[0] != "prod" || got[1] != "staging" {
+		t.Errorf("ProfileNames = %v", got)
+	}
+}
+
+func TestSaveConfig_WritesProfileSettingsToProfile(t *testing.T) {
+	setupProfileConfig(t)
+	SetProfile("staging")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	cfg.ServerAddress = "staging2.example.com:50051"
+	cfg.OutputFormat = OutputFormatJSON
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	base, err := LoadBaseConfig()
+	if err != nil {
+		t.Fatalf("LoadBaseConfig: %v", err)
+	}
+	if base.ServerAddress != "localhost:50051" {
+		t.Errorf("base server_address changed to %s", base.ServerAddress)
+	}
+	if base.TenantUUID != "base-uuid" {
+		t.Errorf("base tenant_uuid changed to %q", base.TenantUUID)
+	}
+	if base.OutputFormat != OutputFormatJSON {
+		t.Errorf("expected non-profile setting saved to base, got %s", base.OutputFormat)
+	}
+	if got := base.Profiles["staging"].ServerAddress; got != "staging2.example.com:50051" {
+		t.Errorf("staging server_address = %s", got)
+	}
+	if base.CurrentProfile != "prod" {
+		t.Errorf("current_profile = %q, want prod", base.CurrentProfile)
+	}
+}
@@ -135,12 +135,63 @@ func (c *Client) History(ctx context.Context, agent string, limit int) ([]Comman
 	}
 	defer rows.Close()
 
+	return c.scanCommandEntries(rows)
+}
+
+// commandLogScanLimit bounds how many recent entries QueryCommands filters.
+const commandLogScanLimit = 100000
+
+// CommandQuery filters the CLI command log. Zero values match everything.
+type CommandQuery struct {
+	Agent  string    // only commands run by this agent
+	Word   string    // only commands whose command line contains this word, e.g. "merge"
+	Since  time.Time // only commands run at or after this time
+	Until  time.Time // only commands run before this time
+	Limit  int
+	Offset int
+}
+
+// QueryCommands returns logged commands matching q, newest first.
+func (c *Client) QueryCommands(ctx context.Context, q CommandQuery) ([]CommandEntry, error) {
+	if q.Limit <= 0 {
+		q.Limit = 20
+	}
+
+	query := `
+		SELECT id, agent, command, full_command, duration_ms, success, error_message, created_at
+		FROM cli_history($1, $2, $3)
+		WHERE ($4::text IS NULL OR $4 = ANY(string_to_array(full_command, ' ')))
+		  AND ($5::timestamptz IS NULL OR created_at >= $5)
+		  AND ($6::timestamptz IS NULL OR created_at < $6)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $7 OFFSET $8`
+
+	rows, err := c.db.QueryContext(ctx, query,
+		c.project,
+		nullIfEmpty(q.Agent),
+		commandLogScanLimit,
+		nullIfEmpty(q.Word),
+		nullIfZeroTime(q.Since),
+		nullIfZeroTime(q.Until),
+		q.Limit,
+		q.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying command log: %w", err)
+	}
+	defer rows.Close()
+
+	return c.scanCommandEntries(rows)
+}
+
+// scanCommandEntries reads rows in the column order returned by cli_history:
+// id, agent, command, full_command, duration_ms, success, error_message, created_at.
+func (c *Client) scanCommandEntries(rows *sql.Rows) ([]CommandEntry, error) {
 	var entries []CommandEntry
 	for rows.Next() {
 		var e CommandEntry
 		var errorMsg sql.NullString
 
-		// cli_history returns: id, agent, command, full_command, duration_ms, success, error_message, created_at
 		err := rows.Scan(
 			&e.ID,
 			&e.Agent,
@@ -184,6 +235,14 @@ func nullIfEmpty(s string) interface{} {
 	return s
 }
 
+// nullIfZeroTime returns nil for the zero time, otherwise the time.
+func nullIfZeroTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
 // Shard represents a Context-Palace shard.
 type Shard struct {
 	ID         string     `json:"id"`
@@ -19,6 +19,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -520,6 +521,65 @@ func (s *Store) GetActiveCredential() (*Credentials, error) {
 	return creds, nil
 }
 
+// StoredExpiry returns the auth type and token expiry recorded in the
+// credentials file. Both are stored unencrypted, so this does not need the
+// encryption key and is cheap enough to call before every command.
+func StoredExpiry() (authType string, expiresAt time.Time, err error) {
+	credPath, err := CredentialsPath()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	data, err := os.ReadFile(credPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", time.Time{}, ErrNoCredentials
+		}
+		return "", time.Time{}, fmt.Errorf("reading credentials file: %w", err)
+	}
+
+	var creds Credentials
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return "", time.Time{}, fmt.Errorf("parsing credentials: %w", err)
+	}
+	return creds.AuthType, creds.ExpiresAt, nil
+}
+
+// TokenClaims holds the registered claims read from a JWT.
+type TokenClaims struct {
+	// Subject is the "sub" claim.
+	Subject string
+	// ExpiresAt is the "exp" claim, zero if absent.
+	ExpiresAt time.Time
+}
+
+// ParseTokenClaims reads the subject and expiry from a JWT's payload. The
+// signature is not verified; the claims are only used for display and
+// expiry warnings.
+func ParseTokenClaims(token string) (TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenClaims{}, fmt.Errorf("invalid JWT token format")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return TokenClaims{}, fmt.Errorf("decoding JWT payload: %w", err)
+	}
+
+	var raw struct {
+		Sub string  `json:"sub"`
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return TokenClaims{}, fmt.Errorf("parsing JWT payload: %w", err)
+	}
+
+	claims := TokenClaims{Subject: raw.Sub}
+	if raw.Exp > 0 {
+		claims.ExpiresAt = time.Unix(int64(raw.Exp), 0)
+	}
+	return claims, nil
+}
+
 // MaskCredential returns a masked version of the credential for display.
 func MaskCredential(cred string) string {
 	if len(cred) <= 8 {
@@ -715,3 +715,57 @@ func encryptWithKey(plaintext string, key []byte) (string, error) {
 	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
+
+func TestParseTokenClaims(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice@example.com","exp":1893456000}`))
+	claims, err := ParseTokenClaims("eyJhbGciOiJIUzI1NiJ9." + payload + ".sig")
+	if err != nil {
+		t.Fatalf("ParseTokenClaims() error = %v", err)
+	}
+	if claims.Subject != "alice@example.com" {
+		t.Errorf("Subject = %q", claims.Subject)
+	}
+	if !claims.ExpiresAt.Equal(time.Unix(1893456000, 0)) {
+		t.Errorf("ExpiresAt = %v", claims.ExpiresAt)
+	}
+
+	noExp := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"svc"}`))
+	if claims, err := ParseTokenClaims("h." + noExp + ".s"); err != nil || !claims.ExpiresAt.IsZero() {
+		t.Errorf("token without exp: claims %+v, err %v", claims, err)
+	}
+
+	for _, bad := range []string{"not-a-jwt", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := ParseTokenClaims(bad); err == nil {
+			t.Errorf("ParseTokenClaims(%q) expected error", bad)
+		}
+	}
+}
+
+func TestStoredExpiry(t *testing.T) {
+	tempDir := t.TempDir()
+	cleanup := setupTestEnv(t, tempDir)
+	defer cleanup()
+
+	if _, _, err := StoredExpiry(); err != ErrNoCredentials {
+		t.Fatalf("StoredExpiry() with no file: err = %v, want ErrNoCredentials", err)
+	}
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	expiresAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	if err := store.Save(&Credentials{AuthType: AuthTypeToken, Token: "a.b.c", ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// The expiry is readable without the encryption key.
+	os.Unsetenv("PENF_ENCRYPTION_KEY")
+	authType, got, err := StoredExpiry()
+	if err != nil {
+		t.Fatalf("StoredExpiry() error = %v", err)
+	}
+	if authType != AuthTypeToken || !got.Equal(expiresAt) {
+		t.Errorf("StoredExpiry() = %q, %v; want token, %v", authType, got, expiresAt)
+	}
+}
@@ -6,20 +6,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
 	"github.com/otherjamesbrown/penf-cli/client"
 	"github.com/otherjamesbrown/penf-cli/cmd"
 	"github.com/otherjamesbrown/penf-cli/config"
@@ -34,7 +41,16 @@ var (
 	timeout      time.Duration
 	outputFormat string
 	debug        bool
+	debugTrace   bool
 	insecure     bool
+	quiet        bool
+	profile      string
+
+	noCircuitBreaker bool
+	grpcRetries      int
+	expectTenant     string
+	outputTemplate   string
+	yamlFlow         bool
 
 	// cfg holds the loaded configuration.
 	cfg *config.CLIConfig
@@ -85,7 +101,17 @@ DISCOVERY:
   penf <command> --help       Subcommands, flags, and examples for any command
   penf health -e              System health with pipeline statistics
   penf pipeline status        Processing pipeline overview
-  penf debug info             Full diagnostic information`,
+  penf debug info             Full diagnostic information
+
+EXIT CODES:
+  0  success               5  permission denied
+  1  other error           6  unauthenticated
+  2  invalid argument      7  gateway unavailable
+  4  not found             8  deadline exceeded
+                          10  update available (update --check-only)
+                         130  interrupted (Ctrl+C)
+  With --output json, a failed command also writes {"error": {"code", "message",
+  "command"}} to stdout.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Record start time for command logging.
 		cmdStartTime = time.Now()
@@ -94,11 +120,33 @@ DISCOVERY:
 		cmdOutputBuf = &bytes.Buffer{}
 		outputCapture = &outputTee{writer: os.Stdout, buffer: cmdOutputBuf}
 
+		if err := checkRootOutputFormat(cmd); err != nil {
+			return err
+		}
+
 		// Skip initialization for commands that don't need it.
 		if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "completion" {
 			return nil
 		}
 
+		// Shell completion runs on every Tab press; completion functions load
+		// config themselves and must stay fast and silent.
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return nil
+		}
+
+		// Doctor loads the config itself so it can report a broken config
+		// file instead of failing before any checks run.
+		if cmd.Name() == "doctor" {
+			return nil
+		}
+
+		// Profile management loads the base config itself, so an unknown or
+		// broken profile can still be listed and cleared.
+		if cmd.Parent() != nil && cmd.Parent() == configProfileCmd {
+			return nil
+		}
+
 		// Load configuration.
 		var err error
 		cfg, err = config.LoadConfig()
@@ -106,6 +154,11 @@ DISCOVERY:
 			return fmt.Errorf("loading configuration: %w", err)
 		}
 
+		for _, warning := range cfg.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		warnCredentialExpiry(cmd)
+
 		// Override with command-line flags.
 		if serverAddr != "" {
 			cfg.ServerAddress = serverAddr
@@ -116,12 +169,21 @@ DISCOVERY:
 		if outputFormat != "" {
 			cfg.OutputFormat = config.OutputFormat(outputFormat)
 		}
-		if debug {
+		if debug || debugTrace {
 			cfg.Debug = true
 		}
+		if debugTrace {
+			cfg.DebugTrace = true
+		}
 		if insecure {
 			cfg.Insecure = true
 		}
+		if cmd.Flags().Changed("grpc-retries") {
+			if grpcRetries < 0 {
+				return fmt.Errorf("--grpc-retries must not be negative")
+			}
+			cfg.GRPCRetries = &grpcRetries
+		}
 
 		// Resolve --tenant flag: if set to a slug, look up the UUID before any RPC.
 		if err := resolveTenantFlagIfNeeded(cmd.Context(), cmd, cfg); err != nil {
@@ -144,6 +206,16 @@ DISCOVERY:
 	},
 }
 
+// checkRootOutputFormat rejects a root --output that executed cannot render.
+// CSV is rendered only by commands with their own -o flag, which shadows the
+// root one; anywhere else it would silently print text.
+func checkRootOutputFormat(executed *cobra.Command) error {
+	if config.OutputFormat(outputFormat) == config.OutputFormatCSV {
+		return cmd.InvalidArgumentError(fmt.Errorf("--output csv is not supported by %s", executed.CommandPath()))
+	}
+	return nil
+}
+
 // Version command flags.
 var (
 	versionAll        bool
@@ -240,44 +312,8 @@ Examples:
 		}
 
 		// Query all services.
-		services := []struct {
-			Name string
-			URL  string
-		}{
-			{"penfold-gateway", "http://dev02.brown.chat:8080"},
-			{"penfold-worker", "http://dev01.brown.chat:8085"},
-			{"penfold-ai-coordinator", "http://dev02.brown.chat:8090"},
-		}
-
-		type result struct {
-			Info buildinfo.Info
-			Err  error
-		}
-
-		results := []result{{Info: info}} // CLI first
-
 		httpClient := &http.Client{Timeout: 5 * time.Second}
-		for _, svc := range services {
-			resp, err := httpClient.Get(svc.URL + "/version")
-			if err != nil {
-				results = append(results, result{
-					Info: buildinfo.Info{ServiceName: svc.Name, Version: "unreachable"},
-					Err:  err,
-				})
-				continue
-			}
-			defer resp.Body.Close()
-
-			var svcInfo buildinfo.Info
-			if err := json.NewDecoder(resp.Body).Decode(&svcInfo); err != nil {
-				results = append(results, result{
-					Info: buildinfo.Info{ServiceName: svc.Name, Version: "error"},
-					Err:  err,
-				})
-				continue
-			}
-			results = append(results, result{Info: svcInfo})
-		}
+		results := fetchServiceVersions(cmd.Context(), httpClient, info)
 
 		if versionOutputJSON {
 			infos := make([]buildinfo.Info, len(results))
@@ -313,6 +349,21 @@ Examples:
 	},
 }
 
+// warnCredentialExpiry warns on stderr before a command runs if the active
+// token is about to expire. The auth commands report expiry themselves.
+func warnCredentialExpiry(c *cobra.Command) {
+	if c.Parent() == cmd.AuthCmd {
+		return
+	}
+	cmd.WarnIfCredentialExpiring(os.Stderr)
+}
+
+// fetchServiceVersions returns the CLI's build info followed by that of each
+// deployed service.
+func fetchServiceVersions(ctx context.Context, httpClient *http.Client, cli buildinfo.Info) []cmd.ServiceVersion {
+	return append([]cmd.ServiceVersion{{Info: cli}}, cmd.FetchServiceVersions(ctx, httpClient, cmd.DeployedServices)...)
+}
+
 // statusCmd checks the connection status to the API Gateway.
 var statusCmd = &cobra.Command{
 	Use:   "status",
@@ -380,10 +431,28 @@ var configShowCmd = &cobra.Command{
 
 		fmt.Println("Current configuration:")
 		fmt.Printf("  Config file:    %s\n", configPath)
+		fmt.Printf("  Profile:        %s\n", valueOrDefault(cfg.ActiveProfile, "(none)"))
 		fmt.Printf("  Server address: %s\n", cfg.ServerAddress)
 		fmt.Printf("  Timeout:        %s\n", cfg.Timeout)
+		fmt.Printf("  Connect wait:   %s\n", cfg.GetConnectTimeout())
 		fmt.Printf("  Output format:  %s\n", cfg.OutputFormat)
 		fmt.Printf("  Tenant ID:      %s\n", valueOrDefault(cfg.TenantID, "(not set)"))
+		fmt.Printf("  Default model:  %s\n", valueOrDefault(cfg.DefaultModel, "(server chooses)"))
+		if cfg.ConfirmTenant {
+			fmt.Printf("  Confirm tenant: true\n")
+		}
+		if cfg.StuckThresholdSeconds > 0 {
+			fmt.Printf("  Stuck after:    %s\n", cfg.GetStuckThreshold())
+		}
+		if cfg.MaxMessageSize > 0 {
+			fmt.Printf("  Max message:    %d bytes\n", cfg.MaxMessageSize)
+		}
+		if cfg.KeepaliveTime > 0 {
+			fmt.Printf("  Keepalive:      %s\n", cfg.KeepaliveTime)
+		}
+		if cfg.KeepaliveTimeout > 0 {
+			fmt.Printf("  Keepalive wait: %s\n", cfg.KeepaliveTimeout)
+		}
 		fmt.Printf("  Debug:          %t\n", cfg.Debug)
 		fmt.Printf("  Insecure:       %t\n", cfg.Insecure)
 
@@ -432,19 +501,37 @@ var configSetCmd = &cobra.Command{
 	Long: `Set a configuration value in the config file.
 
 Available keys:
-  server_address  - API Gateway server address (host:port)
-  timeout         - Request timeout (e.g., 30s, 1m)
-  output_format   - Default output format (text, json, yaml)
-  tenant_id       - Default tenant ID
-  install_path    - Path for penf binary updates (supports ~)
-  debug           - Enable debug mode (true/false)
-  insecure        - Disable TLS verification (true/false)
+  server_address    - API Gateway server address (host:port)
+  timeout           - Request timeout (e.g., 30s, 1m); request_timeout is an alias
+  connect_timeout   - Time to wait for a connection to the server (default 10s)
+  max_message_size  - Largest gRPC response in bytes (default 64MB)
+  keepalive_time    - Interval between keepalive pings on idle connections (default 5m)
+  keepalive_timeout - Time to wait for a keepalive ping response (default 20s)
+  grpc_retries      - Retries for read-only requests after transient errors (default 3, 0 disables)
+  output_format     - Default output format (text, json, yaml)
+  tenant_id         - Default tenant ID
+  install_path      - Path for penf binary updates (supports ~)
+  default_model     - AI model used when --model is not given (see 'penf model set-default')
+  stuck_threshold_seconds - Age in seconds at which a queue counts as stuck (default 300)
+  confirm_tenant    - Require typing the tenant to confirm destructive commands (true/false)
+  debug             - Enable debug mode (true/false)
+  insecure          - Disable TLS verification (true/false)
+
+When a profile is active, keys it overrides are updated in that profile.
+
+server_address, tenant_id and the tls cert paths (tls.ca_cert, tls.client_cert,
+tls.client_key, tls.cert_dir) may reference environment variables as $VAR or
+${VAR}. They are expanded when the config is loaded and saved unexpanded.
+Quote the value so your shell doesn't expand it first.
 
 Examples:
   penf config set server_address localhost:50051
   penf config set timeout 1m
+  penf config set connect_timeout 5s
+  penf config set max_message_size 134217728
   penf config set output_format json
   penf config set tenant_id my-tenant-123
+  penf config set server_address '${PENF_GATEWAY}:50051'
   penf config set install_path ~/bin/penf`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -461,12 +548,40 @@ Examples:
 		switch key {
 		case "server_address":
 			currentCfg.ServerAddress = value
-		case "timeout":
+		case "timeout", "request_timeout":
 			duration, err := time.ParseDuration(value)
 			if err != nil {
-				return fmt.Errorf("invalid timeout value: %w", err)
+				return fmt.Errorf("invalid %s value: %w", key, err)
 			}
 			currentCfg.Timeout = duration
+		case "connect_timeout":
+			duration, err := time.ParseDuration(value)
+			if err != nil || duration <= 0 {
+				return fmt.Errorf("invalid connect_timeout value: %s (must be a positive duration)", value)
+			}
+			currentCfg.ConnectTimeout = duration
+		case "max_message_size":
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return fmt.Errorf("invalid max_message_size value: %s (must be a positive number of bytes)", value)
+			}
+			currentCfg.MaxMessageSize = size
+		case "keepalive_time", "keepalive_timeout":
+			duration, err := time.ParseDuration(value)
+			if err != nil || duration <= 0 {
+				return fmt.Errorf("invalid %s value: %s (must be a positive duration)", key, value)
+			}
+			if key == "keepalive_time" {
+				currentCfg.KeepaliveTime = duration
+			} else {
+				currentCfg.KeepaliveTimeout = duration
+			}
+		case "grpc_retries":
+			retries, err := strconv.Atoi(value)
+			if err != nil || retries < 0 {
+				return fmt.Errorf("invalid grpc_retries value: %s (must be 0 or more)", value)
+			}
+			currentCfg.GRPCRetries = &retries
 		case "output_format":
 			format := config.OutputFormat(value)
 			if !format.IsValid() {
@@ -475,6 +590,22 @@ Examples:
 			currentCfg.OutputFormat = format
 		case "tenant_id":
 			currentCfg.TenantID = value
+		case "default_model":
+			currentCfg.DefaultModel = value
+		case "stuck_threshold_seconds":
+			secs, err := strconv.Atoi(value)
+			if err != nil || secs <= 0 {
+				return fmt.Errorf("invalid stuck_threshold_seconds value: %s (must be a positive number of seconds)", value)
+			}
+			currentCfg.StuckThresholdSeconds = secs
+		case "confirm_tenant":
+			if value == "true" || value == "1" {
+				currentCfg.ConfirmTenant = true
+			} else if value == "false" || value == "0" {
+				currentCfg.ConfirmTenant = false
+			} else {
+				return fmt.Errorf("invalid confirm_tenant value: %s (must be true or false)", value)
+			}
 		case "install_path":
 			// Validate the path is expandable.
 			expanded, err := config.ExpandPath(value)
@@ -514,6 +645,124 @@ Examples:
 	},
 }
 
+// configProfileClear clears the default profile in 'config profile use'.
+var configProfileClear bool
+
+// configProfileCmd manages named configuration profiles.
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named configuration profiles",
+	Long: `Manage named configuration profiles for switching between servers.
+
+Profiles live under the profiles key of the config file and override the base
+settings (server_address, search_service_address, timeout, output_format,
+tenant_id, tenant_uuid, insecure, tls). Unset fields inherit the base values.
+
+A profile is selected, in order of precedence, by the --profile flag, the
+PENF_PROFILE environment variable, or the default set with 'profile use'.
+With none of these set, only the base settings apply.
+
+Example config.yaml:
+  server_address: localhost:50051
+  profiles:
+    staging:
+      server_address: staging.example.com:50051
+      tenant_id: acme-staging
+    prod:
+      server_address: prod.example.com:50051
+      tenant_id: acme
+      tls:
+        enabled: true
+        cert_dir: ~/.penf/certs/prod
+
+Examples:
+  penf config profile list
+  penf config profile use staging
+  penf --profile prod pipeline status`,
+}
+
+// configProfileListCmd lists configured profiles.
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseCfg, err := config.LoadBaseConfig()
+		if err != nil {
+			return fmt.Errorf("loading configuration: %w", err)
+		}
+
+		names := baseCfg.ProfileNames()
+		if len(names) == 0 {
+			fmt.Println("No profiles configured.")
+			fmt.Println("Add profiles under the 'profiles:' key of the config file (see 'penf config profile --help').")
+			return nil
+		}
+
+		active := profile
+		if active == "" {
+			active = os.Getenv("PENF_PROFILE")
+		}
+		if active == "" {
+			active = baseCfg.CurrentProfile
+		}
+
+		fmt.Printf("  %-2s %-16s %-30s %s\n", "", "PROFILE", "SERVER", "TENANT")
+		for _, name := range names {
+			p := baseCfg.Profiles[name]
+			marker := ""
+			if name == active {
+				marker = "*"
+			}
+			fmt.Printf("  %-2s %-16s %-30s %s\n",
+				marker,
+				name,
+				valueOrDefault(p.ServerAddress, "(base)"),
+				valueOrDefault(p.TenantID, "(base)"))
+		}
+		return nil
+	},
+}
+
+// configProfileUseCmd sets the default profile.
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default configuration profile",
+	Long: `Set the profile applied when neither --profile nor PENF_PROFILE is given.
+
+Use --clear to go back to the base settings.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configProfileClear == (len(args) == 1) {
+			return fmt.Errorf("specify a profile name or --clear")
+		}
+
+		baseCfg, err := config.LoadBaseConfig()
+		if err != nil {
+			return fmt.Errorf("loading configuration: %w", err)
+		}
+
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+			if _, ok := baseCfg.Profiles[name]; !ok {
+				return fmt.Errorf("unknown profile %q (see 'penf config profile list')", name)
+			}
+		}
+
+		baseCfg.CurrentProfile = name
+		if err := config.SaveConfig(baseCfg); err != nil {
+			return fmt.Errorf("saving configuration: %w", err)
+		}
+
+		if name == "" {
+			fmt.Println("Cleared default profile; using base settings.")
+		} else {
+			fmt.Printf("Default profile set to %s\n", name)
+		}
+		return nil
+	},
+}
+
 // completionCmd generates shell completion scripts.
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
@@ -553,6 +802,10 @@ PowerShell:
   # To load completions for every new session, run:
   PS> penf completion powershell > penf.ps1
   # and source this file from your PowerShell profile.
+
+IDs are completed from the server for commands such as 'relationship show',
+'relationship entity show', 'review accept' and 'pipeline job'. Results are
+cached for 30 seconds under ~/.penf/cache/completion/.
 `,
 	DisableFlagsInUseLine: true,
 	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
@@ -574,10 +827,13 @@ PowerShell:
 
 // Health command flags.
 var (
-	healthWatch         bool
-	healthWatchInterval time.Duration
-	healthExtended      bool
-	healthFunctional    bool
+	healthWatch          bool
+	healthWatchInterval  time.Duration
+	healthExtended       bool
+	healthFunctional     bool
+	healthSummaryOnly    bool
+	healthAllTenants     bool
+	healthStuckThreshold time.Duration
 )
 
 // healthCmd checks system health status.
@@ -592,13 +848,24 @@ Flags:
   --extended, -e   Include pipeline statistics (sources, embeddings, jobs by status)
   --functional, -f Run functional inference tests (actual embedding/LLM calls)
   --watch, -w      Continuously monitor health status
+  --summary-only   Print a one-line verdict (HEALTHY, DEGRADED or UNHEALTHY)
+  --all-tenants    Show pipeline statistics for every tenant, with totals
+  --stuck-threshold  Age at which a queue counts as stuck with -e
+                   (default: stuck_threshold_seconds in config, else 5m)
+  --output prometheus  Print metrics in Prometheus text format (includes pipeline stats)
   --json           Output as JSON for machine processing
 
 Examples:
   penf health                # Basic health check
   penf health -e             # Include pipeline stats
   penf health -e -f          # Full check with inference tests
-  penf health -w             # Watch mode`,
+  penf health -w             # Watch mode
+  penf health -e --summary-only             # HEALTHY services=5/5 pending=12 dead_letter=0
+  penf health --summary-only --output json  # Flat summary object
+  penf health --output prometheus > /var/lib/node_exporter/penf.prom
+  penf health -e --all-tenants              # Per-tenant pipeline rollup
+  penf health -e --stuck-threshold 30m      # Tolerate slower queues`,
+	Annotations: map[string]string{cmd.ReadOnlyAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize client.
 		if err := initClient(); err != nil {
@@ -607,6 +874,13 @@ Examples:
 
 		ctx := cmd.Context()
 
+		if healthAllTenants {
+			if healthWatch || healthFunctional || healthSummaryOnly {
+				return fmt.Errorf("--all-tenants cannot be combined with --watch, --functional or --summary-only")
+			}
+			return runHealthAllTenants(ctx)
+		}
+
 		if healthWatch {
 			return runHealthWatch(ctx)
 		}
@@ -618,9 +892,10 @@ Examples:
 // ExtendedHealthStatus combines system status with pipeline stats and functional tests.
 type ExtendedHealthStatus struct {
 	*client.SystemStatus
-	Pipeline    *PipelineStats    `json:"pipeline,omitempty"`
-	Functional  *FunctionalTests  `json:"functional,omitempty"`
-	WorkerIdle  *WorkerIdleStatus `json:"worker_idle,omitempty"`
+	Pipeline    *PipelineStats     `json:"pipeline,omitempty"`
+	Functional  *FunctionalTests   `json:"functional,omitempty"`
+	WorkerIdle  *WorkerIdleStatus  `json:"worker_idle,omitempty"`
+	StuckQueues *StuckQueuesStatus `json:"stuck_queues,omitempty"`
 }
 
 // PipelineStats holds pipeline statistics from GetStats.
@@ -654,6 +929,16 @@ type WorkerIdleStatus struct {
 	Message      string `json:"message"`
 }
 
+// StuckQueuesStatus lists the queues whose oldest item has waited longer than
+// Threshold. Stuck queues are reported apart from an idle worker: items can be
+// stuck while the worker is busy elsewhere.
+type StuckQueuesStatus struct {
+	Queues       []string `json:"queues"`
+	PendingCount int64    `json:"pending_count"`
+	Threshold    string   `json:"threshold"`
+	Message      string   `json:"message"`
+}
+
 // runHealthOnce performs a single health check and outputs results.
 func runHealthOnce(ctx context.Context) error {
 	// Create context with timeout.
@@ -665,8 +950,14 @@ func runHealthOnce(ctx context.Context) error {
 		return fmt.Errorf("failed to get status: %w", err)
 	}
 
+	format := cfg.OutputFormat
+	if outputFormat != "" {
+		format = config.OutputFormat(outputFormat)
+	}
+	prometheus := format == config.OutputFormatPrometheus
+
 	// If no extended or functional flags, just output basic status.
-	if !healthExtended && !healthFunctional {
+	if !healthExtended && !healthFunctional && !healthSummaryOnly && !prometheus {
 		return outputStatus(status)
 	}
 
@@ -675,8 +966,8 @@ func runHealthOnce(ctx context.Context) error {
 		SystemStatus: status,
 	}
 
-	// Fetch pipeline stats if extended.
-	if healthExtended {
+	// Fetch pipeline stats if extended. Metrics always include them.
+	if healthExtended || prometheus {
 		pipelineStats, workerIdle, err := fetchPipelineStats(checkCtx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to get pipeline stats: %v\n", err)
@@ -684,6 +975,14 @@ func runHealthOnce(ctx context.Context) error {
 			extStatus.Pipeline = pipelineStats
 			extStatus.WorkerIdle = workerIdle
 		}
+
+		queues, err := grpcClient.GetQueueStatus(checkCtx, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get queue status: %v\n", err)
+		} else {
+			threshold := cmd.ResolveStuckThreshold(cfg, healthStuckThreshold)
+			extStatus.StuckQueues = buildStuckQueuesStatus(cmd.StuckQueues(queues.Queues, threshold), threshold)
+		}
 	}
 
 	// Run functional tests if requested.
@@ -691,9 +990,244 @@ func runHealthOnce(ctx context.Context) error {
 		extStatus.Functional = runFunctionalTests(checkCtx)
 	}
 
+	if prometheus {
+		return writeHealthPrometheus(os.Stdout, extStatus)
+	}
+	if healthSummaryOnly {
+		return outputHealthSummary(buildHealthSummary(extStatus))
+	}
 	return outputExtendedStatus(extStatus)
 }
 
+// promMetrics writes metrics in the Prometheus text exposition format. Each
+// metric's HELP and TYPE lines are written before its first sample, so the
+// samples of a metric must be written together.
+type promMetrics struct {
+	w    io.Writer
+	seen map[string]bool
+}
+
+// gauge writes one gauge sample. labels are name/value pairs.
+func (m *promMetrics) gauge(name, help string, value float64, labels ...string) {
+	if !m.seen[name] {
+		m.seen[name] = true
+		fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	fmt.Fprint(m.w, name)
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels)/2)
+		for i := 0; i+1 < len(labels); i += 2 {
+			pairs = append(pairs, labels[i]+`="`+promLabelEscaper.Replace(labels[i+1])+`"`)
+		}
+		fmt.Fprintf(m.w, "{%s}", strings.Join(pairs, ","))
+	}
+	fmt.Fprintf(m.w, " %s\n", strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// promLabelEscaper escapes label values as the exposition format requires.
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promBool converts a health flag to a 0/1 sample.
+func promBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// writeHealthPrometheus writes a health report as Prometheus metrics, for a
+// node_exporter textfile collector or a Pushgateway.
+func writeHealthPrometheus(w io.Writer, status *ExtendedHealthStatus) error {
+	m := &promMetrics{w: w, seen: make(map[string]bool)}
+
+	m.gauge("penf_up", "Whether the system reports itself healthy.", promBool(status.Healthy))
+	if !status.Timestamp.IsZero() {
+		m.gauge("penf_health_timestamp_seconds", "When the health check was taken, in Unix seconds.", float64(status.Timestamp.Unix()))
+	}
+
+	for _, svc := range status.Services {
+		m.gauge("penf_service_up", "Whether a service is healthy.", promBool(svc.Healthy), "name", svc.Name)
+	}
+	for _, svc := range status.Services {
+		if svc.LatencyMs > 0 {
+			m.gauge("penf_service_latency_seconds", "Latency of a service's health check.", svc.LatencyMs/1000, "name", svc.Name)
+		}
+	}
+
+	if db := status.Database; db != nil {
+		m.gauge("penf_database_up", "Whether the database is healthy.", promBool(db.Healthy))
+		m.gauge("penf_database_connections_active", "Active database connections.", float64(db.ActiveConnections))
+		m.gauge("penf_database_connections_max", "Maximum database connections.", float64(db.MaxConnections))
+		m.gauge("penf_content_items", "Content items stored.", float64(db.ContentCount))
+		m.gauge("penf_entities", "Entities stored.", float64(db.EntityCount))
+	}
+
+	if q := status.Queues; q != nil {
+		m.gauge("penf_queue_up", "Whether the queues are healthy.", promBool(q.Healthy))
+		m.gauge("penf_queue_pending", "Messages pending across all queues.", float64(q.TotalPending))
+		m.gauge("penf_queue_dead_letter", "Messages in the dead letter queue.", float64(q.DeadLetterCount))
+		m.gauge("penf_queue_processing_rate_per_minute", "Messages processed per minute.", q.ProcessingRate)
+		for _, name := range slices.Sorted(maps.Keys(q.QueueDepths)) {
+			m.gauge("penf_queue_depth", "Messages pending in a queue.", float64(q.QueueDepths[name]), "queue", name)
+		}
+	}
+
+	if p := status.Pipeline; p != nil {
+		m.gauge("penf_sources_total", "Sources ingested.", float64(p.SourcesTotal))
+		for _, s := range cmd.SortedStatuses(p.SourcesByStatus) {
+			m.gauge("penf_sources_by_status", "Sources by processing status.", float64(p.SourcesByStatus[s]), "status", s)
+		}
+		m.gauge("penf_embeddings_total", "Embeddings stored.", float64(p.EmbeddingsTotal))
+		m.gauge("penf_embeddings_last_hour", "Embeddings created in the last hour.", float64(p.EmbeddingsRecent))
+		m.gauge("penf_jobs_total", "Ingest jobs.", float64(p.JobsTotal))
+		for _, s := range cmd.SortedStatuses(p.JobsByStatus) {
+			m.gauge("penf_jobs_by_status", "Ingest jobs by status.", float64(p.JobsByStatus[s]), "status", s)
+		}
+		m.gauge("penf_worker_idle", "Whether the worker looks idle with sources pending.", promBool(status.WorkerIdle != nil && status.WorkerIdle.IsIdle))
+	}
+
+	if status.Pipeline != nil || status.StuckQueues != nil {
+		var stuck int
+		if status.StuckQueues != nil {
+			stuck = len(status.StuckQueues.Queues)
+		}
+		m.gauge("penf_queues_stuck", "Queues whose oldest item is older than the stuck threshold.", float64(stuck))
+	}
+
+	if f := status.Functional; f != nil {
+		tests := map[string]*FunctionalTestResult{"embeddings": f.Embeddings, "llm": f.LLM}
+		names := []string{"embeddings", "llm"}
+		for _, name := range names {
+			if result := tests[name]; result != nil {
+				m.gauge("penf_functional_up", "Whether a functional inference test passed.", promBool(result.Healthy), "test", name)
+			}
+		}
+		for _, name := range names {
+			if result := tests[name]; result != nil && result.LatencyMs > 0 {
+				m.gauge("penf_functional_latency_seconds", "Latency of a functional inference test.", result.LatencyMs/1000, "test", name)
+			}
+		}
+	}
+	return nil
+}
+
+// Health verdicts reported by --summary-only.
+const (
+	healthVerdictHealthy   = "HEALTHY"
+	healthVerdictDegraded  = "DEGRADED"
+	healthVerdictUnhealthy = "UNHEALTHY"
+)
+
+// HealthSummary is the flat one-line health verdict printed by --summary-only.
+type HealthSummary struct {
+	Status          string    `json:"status"`
+	ServicesHealthy int       `json:"services_healthy"`
+	ServicesTotal   int       `json:"services_total"`
+	DatabaseHealthy *bool     `json:"database_healthy,omitempty"`
+	Pending         int64     `json:"pending"`
+	DeadLetter      int64     `json:"dead_letter"`
+	WorkerIdle      bool      `json:"worker_idle,omitempty"`
+	StuckQueues     int       `json:"stuck_queues,omitempty"`
+	FunctionalOK    *bool     `json:"functional_ok,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// buildHealthSummary reduces a health report to a verdict. The system is
+// unhealthy when no service or the database is healthy, and degraded when
+// it reports itself unhealthy or any service, queue or functional test is
+// failing, the worker looks idle or a queue is stuck.
+func buildHealthSummary(status *ExtendedHealthStatus) HealthSummary {
+	summary := HealthSummary{
+		ServicesTotal: len(status.Services),
+		Timestamp:     status.Timestamp,
+	}
+	degraded := !status.Healthy
+	for _, svc := range status.Services {
+		if svc.Healthy {
+			summary.ServicesHealthy++
+		} else {
+			degraded = true
+		}
+	}
+	if status.Database != nil {
+		healthy := status.Database.Healthy
+		summary.DatabaseHealthy = &healthy
+	}
+	if q := status.Queues; q != nil {
+		summary.Pending = q.TotalPending
+		summary.DeadLetter = q.DeadLetterCount
+		degraded = degraded || !q.Healthy
+	}
+	if status.WorkerIdle != nil && status.WorkerIdle.IsIdle {
+		summary.WorkerIdle = true
+		degraded = true
+	}
+	if status.StuckQueues != nil && len(status.StuckQueues.Queues) > 0 {
+		summary.StuckQueues = len(status.StuckQueues.Queues)
+		degraded = true
+	}
+	if f := status.Functional; f != nil {
+		ok := (f.Embeddings == nil || f.Embeddings.Healthy) && (f.LLM == nil || f.LLM.Healthy)
+		summary.FunctionalOK = &ok
+		degraded = degraded || !ok
+	}
+
+	switch {
+	case (summary.ServicesTotal > 0 && summary.ServicesHealthy == 0) ||
+		(summary.DatabaseHealthy != nil && !*summary.DatabaseHealthy):
+		summary.Status = healthVerdictUnhealthy
+	case degraded:
+		summary.Status = healthVerdictDegraded
+	default:
+		summary.Status = healthVerdictHealthy
+	}
+	return summary
+}
+
+// outputHealthSummary outputs a health summary as one line, or as a flat
+// object for --output json and yaml.
+func outputHealthSummary(summary HealthSummary) error {
+	format := cfg.OutputFormat
+	if outputFormat != "" {
+		format = config.OutputFormat(outputFormat)
+	}
+
+	switch format {
+	case config.OutputFormatJSON:
+		return outputJSON(summary)
+	case config.OutputFormatYAML:
+		return outputYAML(summary)
+	default:
+		fmt.Println(formatHealthSummary(summary))
+		return nil
+	}
+}
+
+// formatHealthSummary formats a health summary as a single line, e.g.
+// "HEALTHY services=5/5 pending=12 dead_letter=0".
+func formatHealthSummary(summary HealthSummary) string {
+	parts := []string{
+		summary.Status,
+		fmt.Sprintf("services=%d/%d", summary.ServicesHealthy, summary.ServicesTotal),
+	}
+	if summary.DatabaseHealthy != nil && !*summary.DatabaseHealthy {
+		parts = append(parts, "database=down")
+	}
+	parts = append(parts,
+		fmt.Sprintf("pending=%d", summary.Pending),
+		fmt.Sprintf("dead_letter=%d", summary.DeadLetter))
+	if summary.WorkerIdle {
+		parts = append(parts, "worker=idle")
+	}
+	if summary.StuckQueues > 0 {
+		parts = append(parts, fmt.Sprintf("stuck_queues=%d", summary.StuckQueues))
+	}
+	if summary.FunctionalOK != nil && !*summary.FunctionalOK {
+		parts = append(parts, "functional=failed")
+	}
+	return strings.Join(parts, " ")
+}
+
 // fetchPipelineStats fetches pipeline statistics via gRPC.
 func fetchPipelineStats(ctx context.Context) (*PipelineStats, *WorkerIdleStatus, error) {
 	resp, err := grpcClient.GetStats(ctx, cfg.TenantID)
@@ -738,6 +1272,85 @@ func fetchPipelineStats(ctx context.Context) (*PipelineStats, *WorkerIdleStatus,
 	return pStats, workerIdle, nil
 }
 
+// buildStuckQueuesStatus reports the stuck queues, or nil when nothing is stuck.
+func buildStuckQueuesStatus(stuck []*pipelinev1.QueueStats, threshold time.Duration) *StuckQueuesStatus {
+	if len(stuck) == 0 {
+		return nil
+	}
+
+	status := &StuckQueuesStatus{Threshold: threshold.String()}
+	for _, q := range stuck {
+		status.Queues = append(status.Queues, q.Name)
+		status.PendingCount += q.PendingCount
+	}
+	status.Message = fmt.Sprintf("Queues stuck longer than %s: %s (%d pending items)", threshold, strings.Join(status.Queues, ", "), status.PendingCount)
+	return status
+}
+
+// healthRollupColumns are the per-tenant counts shown by 'health --all-tenants'.
+var healthRollupColumns = []string{"sources", "pending", "failed", "embeddings", "recent", "jobs"}
+
+// runHealthAllTenants shows pipeline statistics for every tenant.
+func runHealthAllTenants(ctx context.Context) error {
+	tenants, err := cmd.ListAllTenants(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	rollup := cmd.FanOutTenants(ctx, tenants, healthRollupColumns, func(ctx context.Context, tenantID string) (map[string]int64, error) {
+		checkCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+
+		resp, err := grpcClient.GetStats(checkCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		stats := resp.GetStats()
+		values := map[string]int64{
+			"sources":    stats.GetSourcesTotal(),
+			"embeddings": stats.GetEmbeddingsTotal(),
+			"recent":     stats.GetEmbeddingsRecent(),
+			"jobs":       stats.GetJobsTotal(),
+		}
+		for _, sc := range stats.GetSourcesByStatus() {
+			switch sc.GetStatus() {
+			case "pending", "failed":
+				values[sc.GetStatus()] += sc.GetCount()
+			}
+		}
+		return values, nil
+	})
+
+	format := cfg.OutputFormat
+	if outputFormat != "" {
+		format = config.OutputFormat(outputFormat)
+	}
+	return cmd.OutputTenantRollup(os.Stdout, format, rollup)
+}
+
+// fetchQualityCoverage derives embedding coverage for 'quality report' from
+// the pipeline stats.
+func fetchQualityCoverage(ctx context.Context) (*cmd.QualityCoverage, error) {
+	if err := initClient(); err != nil {
+		return nil, err
+	}
+	stats, _, err := fetchPipelineStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := &cmd.QualityCoverage{
+		SourcesTotal:     stats.SourcesTotal,
+		SourcesCompleted: stats.SourcesByStatus["completed"],
+		SourcesPending:   stats.SourcesByStatus["pending"],
+		EmbeddingsTotal:  stats.EmbeddingsTotal,
+	}
+	if coverage.SourcesTotal > 0 {
+		coverage.CoveragePercent = float64(coverage.SourcesCompleted) / float64(coverage.SourcesTotal) * 100
+	}
+	return coverage, nil
+}
+
 // runFunctionalTests executes actual inference calls to verify ML services.
 func runFunctionalTests(ctx context.Context) *FunctionalTests {
 	tests := &FunctionalTests{}
@@ -897,7 +1510,8 @@ func outputExtendedHealthHuman(status *ExtendedHealthStatus) error {
 		if len(p.SourcesByStatus) > 0 {
 			fmt.Print("    ")
 			parts := make([]string, 0, len(p.SourcesByStatus))
-			for status, count := range p.SourcesByStatus {
+			for _, status := range cmd.SortedStatuses(p.SourcesByStatus) {
+				count := p.SourcesByStatus[status]
 				color := "\033[0m"
 				if status == "completed" {
 					color = "\033[32m"
@@ -915,7 +1529,8 @@ func outputExtendedHealthHuman(status *ExtendedHealthStatus) error {
 		if len(p.JobsByStatus) > 0 {
 			fmt.Print("    ")
 			parts := make([]string, 0, len(p.JobsByStatus))
-			for status, count := range p.JobsByStatus {
+			for _, status := range cmd.SortedStatuses(p.JobsByStatus) {
+				count := p.JobsByStatus[status]
 				color := "\033[0m"
 				if status == "completed" {
 					color = "\033[32m"
@@ -938,6 +1553,13 @@ func outputExtendedHealthHuman(status *ExtendedHealthStatus) error {
 		fmt.Println()
 	}
 
+	// Stuck queue warning.
+	if status.StuckQueues != nil {
+		fmt.Printf("\033[33m⚠ %s\033[0m\n", status.StuckQueues.Message)
+		fmt.Printf("  Run 'penf pipeline queue --stuck --stuck-threshold %s --requeue' to requeue stuck items.\n", status.StuckQueues.Threshold)
+		fmt.Println()
+	}
+
 	// Functional tests.
 	if status.Functional != nil {
 		fmt.Println("Functional Tests:")
@@ -986,8 +1608,9 @@ func runHealthWatch(ctx context.Context) error {
 			fmt.Println("\nStopped watching.")
 			return nil
 		case <-ticker.C:
-			if outputFormat != "json" && outputFormat != "yaml" {
-				// Clear screen for human-readable output.
+			if outputFormat != "json" && outputFormat != "yaml" && outputFormat != "prometheus" && !healthSummaryOnly {
+				// Clear screen for human-readable output; summary lines
+				// accumulate like a log.
 				fmt.Print("\033[H\033[2J")
 			}
 			if err := runHealthOnce(ctx); err != nil {
@@ -1023,8 +1646,7 @@ func outputJSON(v interface{}) error {
 
 // outputYAML outputs data as YAML.
 func outputYAML(v interface{}) error {
-	enc := yaml.NewEncoder(os.Stdout)
-	return enc.Encode(v)
+	return cmd.NewYAMLEncoder(os.Stdout).Encode(v)
 }
 
 // outputHealthHuman outputs health status in human-readable format.
@@ -1081,8 +1703,8 @@ func outputHealthHuman(status *client.SystemStatus) error {
 		}
 		if len(q.QueueDepths) > 0 {
 			fmt.Println("  Queue Depths:")
-			for name, depth := range q.QueueDepths {
-				fmt.Printf("    %s: %d\n", name, depth)
+			for _, name := range slices.Sorted(maps.Keys(q.QueueDepths)) {
+				fmt.Printf("    %s: %d\n", name, q.QueueDepths[name])
 			}
 		}
 		fmt.Println()
@@ -1186,11 +1808,13 @@ func resolveSlugToUUID(ctx context.Context, cfg *config.CLIConfig, slugOrAlias s
 		}
 	}
 
-	opts := &client.ClientOptions{
-		Insecure:       cfg.Insecure,
-		Debug:          cfg.Debug,
-		ConnectTimeout: cfg.Timeout,
-	}
+	opts := client.DefaultOptions()
+	opts.Insecure = cfg.Insecure
+	opts.Debug = cfg.Debug
+	opts.DebugTrace = cfg.DebugTrace
+	opts.ConnectTimeout = cfg.GetConnectTimeout()
+	opts.RequestTimeout = cfg.Timeout
+	opts.ApplyConnectionConfig(cfg)
 	if !cfg.Insecure {
 		tlsConfig, err := client.LoadClientTLSConfig(&cfg.TLS)
 		if err != nil {
@@ -1242,19 +1866,58 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&serverAddr, "server", "", "API Gateway server address (host:port)")
 	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "request timeout (e.g., 30s, 1m)")
 	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "", "output format: text, json, yaml")
-	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging, including a trace of each gRPC call")
+	rootCmd.PersistentFlags().BoolVar(&debugTrace, "debug-trace", false, "like --debug, also logging request and response message sizes")
 	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "disable TLS verification")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress progress and informational output (implied by --output json)")
+
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "configuration profile to use (overrides $PENF_PROFILE)")
+	rootCmd.PersistentFlags().IntVar(&grpcRetries, "grpc-retries", client.DefaultMaxRetries, "retries for read-only requests after transient server errors (0 disables)")
+	rootCmd.PersistentFlags().BoolVar(&noCircuitBreaker, "no-circuit-breaker", false, "always attempt to connect, even after repeated connection failures")
+	rootCmd.PersistentFlags().StringVar(&expectTenant, "expect-tenant", "", "abort destructive commands unless they act on this tenant (ID, UUID or alias)")
+	rootCmd.PersistentFlags().BoolVar(&yamlFlow, "yaml-flow", false, "write --output yaml in compact flow style")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go template for --output template, applied to each record (helpers: red, green, yellow, blue, bold, dim, upper, lower, trunc, join, date, ago)")
+
+	// Apply --quiet before any command runs, including those that skip config loading.
+	cobra.OnInitialize(func() { cmd.SetQuiet(quiet) })
+
+	// Select the --profile before any command loads configuration.
+	cobra.OnInitialize(func() { config.SetProfile(profile) })
+
+	// Disable fail-fast after repeated connection failures if requested.
+	cobra.OnInitialize(func() { client.SetCircuitBreakerEnabled(!noCircuitBreaker) })
+
+	// Guard destructive commands against acting on the wrong tenant.
+	cobra.OnInitialize(func() { cmd.SetExpectedTenant(expectTenant) })
+
+	// Apply --template for commands that support --output template.
+	cobra.OnInitialize(func() { cmd.SetOutputTemplate(outputTemplate) })
+
+	// Select block or flow style for YAML output.
+	cobra.OnInitialize(func() { cmd.SetYAMLFlow(yamlFlow) })
+
+	// Let commands without their own --output flag honor the root one.
+	cobra.OnInitialize(func() { cmd.SetRootOutputFormat(outputFormat) })
+
+	// Report flag parsing errors as invalid_argument in JSON errors.
+	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return cmd.InvalidArgumentError(err)
+	})
 
 	// Health command flags.
 	healthCmd.Flags().BoolVarP(&healthWatch, "watch", "w", false, "Continuously monitor health status")
 	healthCmd.Flags().DurationVar(&healthWatchInterval, "interval", 5*time.Second, "Watch interval (default 5s)")
 	healthCmd.Flags().BoolVarP(&healthExtended, "extended", "e", false, "Include pipeline stats and database counts")
 	healthCmd.Flags().BoolVarP(&healthFunctional, "functional", "f", false, "Run functional inference tests (embeddings, LLM)")
+	healthCmd.Flags().BoolVar(&healthSummaryOnly, "summary-only", false, "Print a one-line verdict instead of the full report")
+	healthCmd.Flags().DurationVar(&healthStuckThreshold, "stuck-threshold", 0, "Age of a queue's oldest item at which it counts as stuck (default: stuck_threshold_seconds in config, else 5m)")
+	cmd.AddAllTenantsFlag(healthCmd, &healthAllTenants)
 
 	// Health subcommands.
 	healthCmd.AddCommand(cmd.NewHealthLocalCommand())
 	healthCmd.AddCommand(cmd.NewHealthGatewayCommand())
 	healthCmd.AddCommand(cmd.NewHealthPreflightCommand())
+	healthCmd.AddCommand(cmd.NewHealthConnectivityCommand())
 
 	// Add command groups for organized help output.
 	rootCmd.AddGroup(
@@ -1437,7 +2100,9 @@ func init() {
 	debugCmd.GroupID = "ops"
 	rootCmd.AddCommand(debugCmd)
 
-	qualityCmd := cmd.NewQualityCommand(nil)
+	qualityDeps := cmd.DefaultQualityDeps()
+	qualityDeps.FetchCoverage = fetchQualityCoverage
+	qualityCmd := cmd.NewQualityCommand(qualityDeps)
 	qualityCmd.GroupID = "ops"
 	rootCmd.AddCommand(qualityCmd)
 
@@ -1468,6 +2133,10 @@ func init() {
 	initCmd.GroupID = "setup"
 	rootCmd.AddCommand(initCmd)
 
+	doctorCmd := cmd.NewDoctorCommand()
+	doctorCmd.GroupID = "setup"
+	rootCmd.AddCommand(doctorCmd)
+
 	updateCmd := cmd.NewUpdateCommand(buildinfo.Version)
 	updateCmd.GroupID = "setup"
 	rootCmd.AddCommand(updateCmd)
@@ -1489,6 +2158,10 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileUseCmd.Flags().BoolVar(&configProfileClear, "clear", false, "Stop using a default profile and return to the base settings")
 	configCmd.AddCommand(cmd.NewConfigEmailCmd(cmd.DefaultPipelineDeps()))
 }
 
@@ -1500,25 +2173,47 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// The first signal cancels the context so the command can stop cleanly
+	// (flushing logs and printing summaries) and return; a second one exits
+	// immediately.
+	var interrupted atomic.Bool
 	go func() {
 		<-sigChan
-		fmt.Println("\nReceived interrupt signal, shutting down...")
+		interrupted.Store(true)
+		fmt.Fprintln(os.Stderr, "\nReceived interrupt signal, shutting down...")
 		cancel()
-		if grpcClient != nil {
-			_ = grpcClient.Close()
-		}
-		os.Exit(0)
+		<-sigChan
+		os.Exit(cmd.ExitInterrupted)
 	}()
 
+	// Report argument validation errors as invalid_argument in JSON errors.
+	cmd.MarkArgErrors(rootCmd)
+
+	// Keep the arguments for JSON errors raised before flags are parsed.
+	cmd.SetCommandArgs(os.Args[1:])
+
 	// Execute root command and capture the error for logging.
-	cmdErr := rootCmd.ExecuteContext(ctx)
+	executed, cmdErr := rootCmd.ExecuteContextC(ctx)
 
 	// Log the command to Context-Palace (called here to capture both success and failure).
 	logCommandExecution(os.Args, cmdErr)
 
+	if interrupted.Load() {
+		if grpcClient != nil {
+			_ = grpcClient.Close()
+		}
+		if cmdErr != nil && !errors.Is(cmdErr, context.Canceled) && status.Code(cmdErr) != codes.Canceled {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", cmdErr)
+		}
+		os.Exit(cmd.ExitInterrupted)
+	}
+
 	if cmdErr != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", cmdErr)
-		os.Exit(1)
+		if !cmd.IsStatusExit(cmdErr) {
+			cmd.WriteErrorJSON(os.Stdout, executed, cmdErr)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", cmdErr)
+		}
+		os.Exit(cmd.ExitCode(cmdErr))
 	}
 }
 
@@ -1538,7 +2233,8 @@ func logCommandExecution(args []string, cmdErr error) {
 	// Skip logging for certain commands.
 	if len(args) > 1 {
 		cmd := args[1]
-		if cmd == "version" || cmd == "help" || cmd == "completion" || cmd == "context" {
+		if cmd == "version" || cmd == "help" || cmd == "completion" || cmd == "context" ||
+			cmd == cobra.ShellCompRequestCmd || cmd == cobra.ShellCompNoDescRequestCmd {
 			return
 		}
 	}
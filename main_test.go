@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
+
+	pipelinev1 "github.com/otherjamesbrown/penf-cli/api/proto/pipeline/v1"
+	"github.com/otherjamesbrown/penf-cli/client"
 )
 
 func TestVersionCommand(t *testing.T) {
@@ -195,3 +199,191 @@ func isHexString(s string) bool {
 	}
 	return len(s) > 0
 }
+
+func TestBuildHealthSummary(t *testing.T) {
+	services := func(healthy ...bool) []client.ServiceHealth {
+		var svcs []client.ServiceHealth
+		for _, h := range healthy {
+			svcs = append(svcs, client.ServiceHealth{Healthy: h})
+		}
+		return svcs
+	}
+
+	tests := []struct {
+		name   string
+		status *ExtendedHealthStatus
+		want   string
+	}{
+		{
+			name: "healthy",
+			status: &ExtendedHealthStatus{SystemStatus: &client.SystemStatus{
+				Healthy: true, Services: services(true, true),
+				Queues: &client.QueueStatus{Healthy: true, TotalPending: 12},
+			}},
+			want: "HEALTHY services=2/2 pending=12 dead_letter=0",
+		},
+		{
+			name: "service down",
+			status: &ExtendedHealthStatus{SystemStatus: &client.SystemStatus{
+				Healthy: false, Services: services(true, false),
+				Queues: &client.QueueStatus{Healthy: true, DeadLetterCount: 3},
+			}},
+			want: "DEGRADED services=1/2 pending=0 dead_letter=3",
+		},
+		{
+			name: "worker idle",
+			status: &ExtendedHealthStatus{
+				SystemStatus: &client.SystemStatus{Healthy: true, Services: services(true)},
+				WorkerIdle:   &WorkerIdleStatus{IsIdle: true, PendingCount: 5},
+			},
+			want: "DEGRADED services=1/1 pending=0 dead_letter=0 worker=idle",
+		},
+		{
+			name: "functional test failed",
+			status: &ExtendedHealthStatus{
+				SystemStatus: &client.SystemStatus{Healthy: true, Services: services(true)},
+				Functional:   &FunctionalTests{Embeddings: &FunctionalTestResult{Healthy: true}, LLM: &FunctionalTestResult{Healthy: false}},
+			},
+			want: "DEGRADED services=1/1 pending=0 dead_letter=0 functional=failed",
+		},
+		{
+			name: "database down",
+			status: &ExtendedHealthStatus{SystemStatus: &client.SystemStatus{
+				Healthy: false, Services: services(true),
+				Database: &client.DatabaseStatus{Healthy: false},
+			}},
+			want: "UNHEALTHY services=1/1 database=down pending=0 dead_letter=0",
+		},
+		{
+			name: "all services down",
+			status: &ExtendedHealthStatus{SystemStatus: &client.SystemStatus{
+				Healthy: false, Services: services(false, false),
+			}},
+			want: "UNHEALTHY services=0/2 pending=0 dead_letter=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatHealthSummary(buildHealthSummary(tt.status)); got != tt.want {
+				t.Errorf("summary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthSummaryJSON(t *testing.T) {
+	summary := buildHealthSummary(&ExtendedHealthStatus{SystemStatus: &client.SystemStatus{
+		Healthy:  true,
+		Services: []client.ServiceHealth{{Healthy: true}},
+		Queues:   &client.QueueStatus{Healthy: true, TotalPending: 4},
+	}})
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for key, want := range map[string]interface{}{"status": "HEALTHY", "services_healthy": 1.0, "services_total": 1.0, "pending": 4.0, "dead_letter": 0.0} {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %v", key, got[key], want)
+		}
+	}
+	for key, v := range got {
+		if _, nested := v.(map[string]interface{}); nested {
+			t.Errorf("%s is nested; the summary must be flat", key)
+		}
+	}
+}
+
+func TestWriteHealthPrometheus(t *testing.T) {
+	status := &ExtendedHealthStatus{
+		SystemStatus: &client.SystemStatus{
+			Healthy: true,
+			Services: []client.ServiceHealth{
+				{Name: "gateway", Healthy: true, LatencyMs: 12.5},
+				{Name: `odd"name`, Healthy: false},
+			},
+			Queues: &client.QueueStatus{
+				Healthy:         true,
+				TotalPending:    7,
+				DeadLetterCount: 1,
+				QueueDepths:     map[string]int64{"embed": 5, "classify": 2},
+			},
+		},
+		Pipeline: &PipelineStats{
+			SourcesTotal:    10,
+			SourcesByStatus: map[string]int64{"failed": 1, "completed": 6, "pending": 3},
+			JobsByStatus:    map[string]int64{},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeHealthPrometheus(&buf, status); err != nil {
+		t.Fatalf("writeHealthPrometheus: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# HELP penf_up Whether the system reports itself healthy.\n# TYPE penf_up gauge\npenf_up 1\n",
+		"penf_service_up{name=\"gateway\"} 1\npenf_service_up{name=\"odd\\\"name\"} 0\n",
+		"penf_service_latency_seconds{name=\"gateway\"} 0.0125\n",
+		"penf_queue_pending 7\n",
+		"penf_queue_depth{queue=\"classify\"} 2\npenf_queue_depth{queue=\"embed\"} 5\n",
+		"penf_sources_total 10\n",
+		"penf_sources_by_status{status=\"pending\"} 3\npenf_sources_by_status{status=\"completed\"} 6\npenf_sources_by_status{status=\"failed\"} 1\n",
+		"penf_worker_idle 0\n",
+		"penf_queues_stuck 0\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "# TYPE penf_service_up gauge") != 1 {
+		t.Errorf("TYPE line for penf_service_up must appear once\n%s", out)
+	}
+	if strings.Contains(out, "penf_database_up") || strings.Contains(out, "penf_functional_up") {
+		t.Errorf("metrics written for sections that were not collected\n%s", out)
+	}
+}
+
+func TestBuildStuckQueuesStatus(t *testing.T) {
+	if got := buildStuckQueuesStatus(nil, 5*time.Minute); got != nil {
+		t.Errorf("buildStuckQueuesStatus(nil) = %+v, want nil", got)
+	}
+
+	stuck := []*pipelinev1.QueueStats{
+		{Name: "entities", PendingCount: 4},
+		{Name: "keywords", PendingCount: 6},
+	}
+	got := buildStuckQueuesStatus(stuck, 30*time.Minute)
+	if got == nil {
+		t.Fatal("buildStuckQueuesStatus() = nil, want stuck status")
+	}
+	if strings.Join(got.Queues, ",") != "entities,keywords" || got.Threshold != "30m0s" || got.PendingCount != 10 {
+		t.Errorf("Queues = %v, Threshold = %q, PendingCount = %d", got.Queues, got.Threshold, got.PendingCount)
+	}
+	if want := "Queues stuck longer than 30m0s: entities, keywords (10 pending items)"; got.Message != want {
+		t.Errorf("Message = %q, want %q", got.Message, want)
+	}
+
+	// Stuck queues degrade the verdict without reporting the worker as idle.
+	summary := buildHealthSummary(&ExtendedHealthStatus{
+		SystemStatus: &client.SystemStatus{Healthy: true},
+		StuckQueues:  got,
+	})
+	if line := formatHealthSummary(summary); line != "DEGRADED services=0/0 pending=0 dead_letter=0 stuck_queues=2" {
+		t.Errorf("formatHealthSummary() = %q", line)
+	}
+
+	var buf bytes.Buffer
+	if err := writeHealthPrometheus(&buf, &ExtendedHealthStatus{SystemStatus: &client.SystemStatus{Healthy: true}, StuckQueues: got}); err != nil {
+		t.Fatalf("writeHealthPrometheus: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "penf_queues_stuck 2\n") || strings.Contains(out, "penf_worker_idle 1") {
+		t.Errorf("unexpected stuck queue metrics\n%s", out)
+	}
+}
@@ -1,9 +1,12 @@
 package buildinfo
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
 )
 
 // These vars are set at build time via ldflags:
@@ -14,6 +17,10 @@ var (
 	Version   = "dev"
 	Commit    = "unknown"
 	BuildTime = "unknown"
+
+	// SchemaVersion is the database migration version the service was built
+	// against, e.g. "054". Empty when not set at build time.
+	SchemaVersion = ""
 )
 
 // Info holds build information for a service.
@@ -23,16 +30,19 @@ type Info struct {
 	Commit      string `json:"commit"`
 	BuildTime   string `json:"build_time"`
 	GoVersion   string `json:"go_version"`
+	// SchemaVersion is the database migration version the service expects.
+	SchemaVersion string `json:"schema_version,omitempty"`
 }
 
 // Get returns build info for the named service.
 func Get(serviceName string) Info {
 	return Info{
-		ServiceName: serviceName,
-		Version:     Version,
-		Commit:      Commit,
-		BuildTime:   BuildTime,
-		GoVersion:   runtime.Version(),
+		ServiceName:   serviceName,
+		Version:       Version,
+		Commit:        Commit,
+		BuildTime:     BuildTime,
+		GoVersion:     runtime.Version(),
+		SchemaVersion: SchemaVersion,
 	}
 }
 
@@ -49,3 +59,27 @@ func Handler(serviceName string) http.HandlerFunc {
 		json.NewEncoder(w).Encode(info)
 	}
 }
+
+// Fetch queries a service's /version endpoint at baseURL (e.g.
+// "http://host:8080") and decodes its build info.
+func Fetch(ctx context.Context, httpClient *http.Client, baseURL string) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/version", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Info{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var info Info
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return Info{}, fmt.Errorf("decoding version response: %w", err)
+	}
+	return info, nil
+}
@@ -1,6 +1,7 @@
 package buildinfo_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -86,3 +87,28 @@ func TestHandler_MultipleServices(t *testing.T) {
 		})
 	}
 }
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(buildinfo.Handler("penfold-gateway"))
+	defer srv.Close()
+
+	info, err := buildinfo.Fetch(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if info.ServiceName != "penfold-gateway" {
+		t.Errorf("Expected service_name 'penfold-gateway', got '%s'", info.ServiceName)
+	}
+	if info.Version != buildinfo.Version {
+		t.Errorf("Expected version %s, got %s", buildinfo.Version, info.Version)
+	}
+}
+
+func TestFetch_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, err := buildinfo.Fetch(context.Background(), srv.Client(), srv.URL); err == nil {
+		t.Error("Expected error for 404 response")
+	}
+}
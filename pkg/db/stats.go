@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// btreeDefaultFillFactor is the leaf density of a freshly built B-tree
+// index; density below it is treated as bloat.
+const btreeDefaultFillFactor = 90.0
+
+// TableStats describes a user table's size and row count.
+type TableStats struct {
+	Schema        string `json:"schema" yaml:"schema"`
+	Name          string `json:"name" yaml:"name"`
+	EstimatedRows int64  `json:"estimated_rows" yaml:"estimated_rows"`
+	DeadRows      int64  `json:"dead_rows" yaml:"dead_rows"`
+	TableBytes    int64  `json:"table_bytes" yaml:"table_bytes"`
+	IndexBytes    int64  `json:"index_bytes" yaml:"index_bytes"`
+	TotalBytes    int64  `json:"total_bytes" yaml:"total_bytes"`
+}
+
+// IndexStats describes an index's size, usage and, when requested and
+// pgstattuple is installed, its estimated bloat.
+type IndexStats struct {
+	Schema      string   `json:"schema" yaml:"schema"`
+	Table       string   `json:"table" yaml:"table"`
+	Name        string   `json:"name" yaml:"name"`
+	Method      string   `json:"method" yaml:"method"`
+	Bytes       int64    `json:"bytes" yaml:"bytes"`
+	Scans       int64    `json:"scans" yaml:"scans"`
+	LeafDensity *float64 `json:"leaf_density,omitempty" yaml:"leaf_density,omitempty"`
+	BloatPct    *float64 `json:"bloat_pct,omitempty" yaml:"bloat_pct,omitempty"`
+}
+
+// Stats holds database-wide size statistics.
+type Stats struct {
+	Database       string       `json:"database" yaml:"database"`
+	DatabaseBytes  int64        `json:"database_bytes" yaml:"database_bytes"`
+	Tables         []TableStats `json:"tables" yaml:"tables"`
+	Indexes        []IndexStats `json:"indexes" yaml:"indexes"`
+	BloatChecked   bool         `json:"bloat_checked" yaml:"bloat_checked"`
+	BloatAvailable bool         `json:"bloat_available" yaml:"bloat_available"`
+}
+
+// GetStats collects table sizes and estimated row counts for every user
+// table, largest first, and the indexLimit largest indexes. When withBloat
+// is set and the pgstattuple extension is installed, bloat is estimated for
+// the B-tree indexes; pgstatindex reads each index in full, so this is slow
+// and I/O-heavy on large databases.
+func GetStats(ctx context.Context, pool *pgxpool.Pool, indexLimit int, withBloat bool) (*Stats, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pool is nil")
+	}
+
+	stats := &Stats{}
+	if err := pool.QueryRow(ctx,
+		`SELECT current_database(), pg_database_size(current_database())`,
+	).Scan(&stats.Database, &stats.DatabaseBytes); err != nil {
+		return nil, fmt.Errorf("failed to get database size: %w", err)
+	}
+
+	tables, err := getTableStats(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	stats.Tables = tables
+
+	indexes, err := getIndexStats(ctx, pool, indexLimit)
+	if err != nil {
+		return nil, err
+	}
+	stats.Indexes = indexes
+
+	if !withBloat {
+		return stats, nil
+	}
+	stats.BloatChecked = true
+	if err := pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pgstattuple')`,
+	).Scan(&stats.BloatAvailable); err != nil {
+		return nil, fmt.Errorf("failed to check for pgstattuple: %w", err)
+	}
+	if stats.BloatAvailable {
+		for i := range stats.Indexes {
+			estimateIndexBloat(ctx, pool, &stats.Indexes[i])
+		}
+	}
+
+	return stats, nil
+}
+
+// getTableStats lists user tables with their sizes, largest first.
+func getTableStats(ctx context.Context, pool *pgxpool.Pool) ([]TableStats, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT schemaname, relname, n_live_tup, n_dead_tup,
+		       pg_relation_size(relid), pg_indexes_size(relid), pg_total_relation_size(relid)
+		FROM pg_stat_user_tables
+		ORDER BY pg_total_relation_size(relid) DESC, relname`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table stats: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []TableStats
+	for rows.Next() {
+		var t TableStats
+		if err := rows.Scan(&t.Schema, &t.Name, &t.EstimatedRows, &t.DeadRows,
+			&t.TableBytes, &t.IndexBytes, &t.TotalBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan table stats: %w", err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// getIndexStats lists the limit largest user indexes.
+func getIndexStats(ctx context.Context, pool *pgxpool.Pool, limit int) ([]IndexStats, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT s.schemaname, s.relname, s.indexrelname, am.amname,
+		       pg_relation_size(s.indexrelid), s.idx_scan
+		FROM pg_stat_user_indexes s
+		JOIN pg_class c ON c.oid = s.indexrelid
+		JOIN pg_am am ON am.oid = c.relam
+		ORDER BY pg_relation_size(s.indexrelid) DESC, s.indexrelname
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index stats: %w", err)
+	}
+	defer rows.Close()
+
+	var indexes []IndexStats
+	for rows.Next() {
+		var idx IndexStats
+		if err := rows.Scan(&idx.Schema, &idx.Table, &idx.Name, &idx.Method, &idx.Bytes, &idx.Scans); err != nil {
+			return nil, fmt.Errorf("failed to scan index stats: %w", err)
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes, rows.Err()
+}
+
+// estimateIndexBloat sets the leaf density and bloat of a B-tree index
+// using pgstatindex. Other index methods, empty indexes (NaN density) and
+// indexes pgstatindex cannot read, e.g. for lack of privileges, are left
+// unset.
+func estimateIndexBloat(ctx context.Context, pool *pgxpool.Pool, idx *IndexStats) {
+	if idx.Method != "btree" {
+		return
+	}
+	name := pgx.Identifier{idx.Schema, idx.Name}.Sanitize()
+
+	var density float64
+	if err := pool.QueryRow(ctx,
+		`SELECT avg_leaf_density FROM pgstatindex($1::regclass)`, name,
+	).Scan(&density); err != nil || math.IsNaN(density) {
+		return
+	}
+	bloat := IndexBloatPct(density)
+	idx.LeafDensity = &density
+	idx.BloatPct = &bloat
+}
+
+// IndexBloatPct converts a B-tree's average leaf density (percent) into the
+// share of the index that is wasted space compared with a fresh build.
+func IndexBloatPct(leafDensity float64) float64 {
+	if leafDensity >= btreeDefaultFillFactor {
+		return 0
+	}
+	return (1 - leafDensity/btreeDefaultFillFactor) * 100
+}
+
+// CountRows returns the exact row count of a table.
+func CountRows(ctx context.Context, pool *pgxpool.Pool, schema, table string) (int64, error) {
+	if pool == nil {
+		return 0, fmt.Errorf("pool is nil")
+	}
+	var n int64
+	query := "SELECT count(*) FROM " + pgx.Identifier{schema, table}.Sanitize()
+	if err := pool.QueryRow(ctx, query).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count rows in %s.%s: %w", schema, table, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,20 @@
+package db
+
+import "testing"
+
+func TestIndexBloatPct(t *testing.T) {
+	tests := []struct {
+		density float64
+		want    float64
+	}{
+		{density: 90, want: 0},
+		{density: 95, want: 0},
+		{density: 45, want: 50},
+		{density: 0, want: 100},
+	}
+	for _, tt := range tests {
+		if got := IndexBloatPct(tt.density); got != tt.want {
+			t.Errorf("IndexBloatPct(%v) = %v, want %v", tt.density, got, tt.want)
+		}
+	}
+}